@@ -2,8 +2,10 @@ package app
 
 import (
 	"encoding/json"
+	"fmt"
 	"github.com/aliciatay-zls/banking-lib/errs"
 	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
 	"github.com/aliciatay-zls/banking/backend/dto"
 	"github.com/aliciatay-zls/banking/backend/service"
 	"github.com/gorilla/mux"
@@ -11,7 +13,8 @@ import (
 )
 
 type AccountHandler struct {
-	service service.AccountService //REST handler has dependency on service (service is a field)
+	service   service.AccountService //REST handler has dependency on service (service is a field)
+	approvals service.ApprovalService
 }
 
 func (h AccountHandler) accountsHandler(w http.ResponseWriter, r *http.Request) {
@@ -19,7 +22,7 @@ func (h AccountHandler) accountsHandler(w http.ResponseWriter, r *http.Request)
 
 	response, appErr := h.service.GetAllAccounts(vars["customer_id"])
 	if appErr != nil {
-		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		writeErrorResponse(w, r, appErr)
 		return
 	}
 
@@ -34,18 +37,18 @@ func (h AccountHandler) newAccountHandler(w http.ResponseWriter, r *http.Request
 
 	if err := json.NewDecoder(r.Body).Decode(&newAccountRequest); err != nil {
 		logger.Error("Error while decoding json body of new account request: " + err.Error())
-		writeJsonResponse(w, http.StatusBadRequest, errs.NewMessageObject("Please check that all fields are correctly filled."))
+		writeErrorResponse(w, r, errs.NewAppError(http.StatusBadRequest, "Please check that all fields are correctly filled."))
 		return
 	}
 
-	if appErr := newAccountRequest.Validate(); appErr != nil {
-		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+	if valErr := newAccountRequest.Validate(); valErr != nil {
+		writeValidationErrorResponse(w, r, valErr)
 		return
 	}
 
 	response, appErr := h.service.CreateNewAccount(newAccountRequest)
 	if appErr != nil {
-		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		writeErrorResponse(w, r, appErr)
 		return
 	}
 
@@ -61,24 +64,75 @@ func (h AccountHandler) transactionHandler(w http.ResponseWriter, r *http.Reques
 
 	if err := json.NewDecoder(r.Body).Decode(&transactionRequest); err != nil { // (*)
 		logger.Error("Error while decoding json body of transaction request: " + err.Error())
-		writeJsonResponse(w, http.StatusBadRequest, errs.NewMessageObject("Please check that all fields are correctly filled."))
+		writeErrorResponse(w, r, errs.NewAppError(http.StatusBadRequest, "Please check that all fields are correctly filled."))
 		return
 	}
 
-	if appErr := transactionRequest.Validate(); appErr != nil {
-		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+	if valErr := transactionRequest.Validate(); valErr != nil {
+		writeValidationErrorResponse(w, r, valErr)
+		return
+	}
+
+	if h.approvals.IsTransactionApprovalRequired(requestClaims(r), transactionRequest) {
+		description := fmt.Sprintf("%s of %.2f on account %s of customer %s", transactionRequest.TransactionType,
+			transactionRequest.Amount, transactionRequest.AccountId, transactionRequest.CustomerId)
+		requestApproval(w, r, h.approvals, domain.ApprovalOperationTransaction, description, transactionRequest)
 		return
 	}
 
 	response, appErr := h.service.MakeTransaction(transactionRequest)
 	if appErr != nil {
-		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		writeErrorResponse(w, r, appErr)
 		return
 	}
 
+	if response.Status == dto.TransactionStatusPendingReview { //held, not made yet
+		writeJsonResponse(w, http.StatusAccepted, response)
+		return
+	}
 	writeJsonResponse(w, http.StatusCreated, response)
 }
 
+func (h AccountHandler) transactionsHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	response, appErr := h.service.GetTransactions(vars["customer_id"], vars["account_id"])
+	if appErr != nil {
+		writeErrorResponse(w, r, appErr)
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, response)
+}
+
+func (h AccountHandler) transactionCategoryHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	categoryRequest := dto.TransactionCategoryRequest{
+		CustomerId:    vars["customer_id"],
+		AccountId:     vars["account_id"],
+		TransactionId: vars["transaction_id"],
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&categoryRequest); err != nil {
+		logger.Error("Error while decoding json body of transaction category request: " + err.Error())
+		writeErrorResponse(w, r, errs.NewAppError(http.StatusBadRequest, "Please check that all fields are correctly filled."))
+		return
+	}
+
+	if valErr := categoryRequest.Validate(); valErr != nil {
+		writeValidationErrorResponse(w, r, valErr)
+		return
+	}
+
+	response, appErr := h.service.UpdateTransactionCategory(categoryRequest)
+	if appErr != nil {
+		writeErrorResponse(w, r, appErr)
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, response)
+}
+
 // (*)
 //json.Decoder.Decode uses json.Unmarshal internally
 //json.Unmarshal docs: "By default, object keys which don't have a corresponding struct field are ignored
@@ -5,6 +5,7 @@ import (
 	"github.com/aliciatay-zls/banking-lib/errs"
 	"github.com/aliciatay-zls/banking-lib/formValidator"
 	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
 	"github.com/aliciatay-zls/banking/backend/dto"
 	"github.com/aliciatay-zls/banking/backend/mocks/service"
 	"github.com/gorilla/mux"
@@ -48,7 +49,8 @@ func init() {
 func setupAccountHandlerTest(t *testing.T, path string, payload string) func() {
 	ctrl := gomock.NewController(t)
 	mockAccountService = service.NewMockAccountService(ctrl)
-	ah = AccountHandler{mockAccountService}
+	mockApprovalService = service.NewMockApprovalService(ctrl)
+	ah = AccountHandler{mockAccountService, mockApprovalService}
 
 	router = mux.NewRouter()
 
@@ -115,8 +117,8 @@ func TestAccountHandler_accountsHandler_respondsWith_accountsAndStatusCode200_wh
 	router.HandleFunc(getAccountsPath, ah.accountsHandler).Methods(http.MethodGet)
 
 	dummyAccounts := []dto.AccountResponse{
-		{dummyAccountId, dummyDate, dummyAccountType, dummyAmount},
-		{"1980", dummyDate, dto.AccountTypeChecking, 7000},
+		{dummyAccountId, dummyDate, dummyAccountType, dummyAmount, dummyAmount},
+		{"1980", dummyDate, dto.AccountTypeChecking, 7000, 6500},
 	}
 	mockAccountService.EXPECT().GetAllAccounts(dummyCustomerId).Return(dummyAccounts, nil)
 
@@ -248,6 +250,7 @@ func TestAccountHandler_transactionHandler_respondsWith_newTransactionAndStatusC
 
 	dummyNewTransactionRequestObject := getDefaultDummyNewTransactionRequestObject()
 	dummyTransaction := dto.TransactionResponse{TransactionId: dummyTransactionId, Balance: dummyBalance}
+	mockApprovalService.EXPECT().IsTransactionApprovalRequired(gomock.Any(), dummyNewTransactionRequestObject).Return(false)
 	mockAccountService.EXPECT().MakeTransaction(dummyNewTransactionRequestObject).Return(&dummyTransaction, nil)
 	expectedStatusCode := http.StatusCreated
 
@@ -272,6 +275,7 @@ func TestAccountHandler_transactionHandler_respondsWith_errorStatusCode_when_ser
 
 	dummyNewTransactionRequestObject := getDefaultDummyNewTransactionRequestObject()
 	dummyAppError := errs.NewUnexpectedError("some error message")
+	mockApprovalService.EXPECT().IsTransactionApprovalRequired(gomock.Any(), dummyNewTransactionRequestObject).Return(false)
 	mockAccountService.EXPECT().MakeTransaction(dummyNewTransactionRequestObject).Return(nil, dummyAppError)
 
 	//Act
@@ -286,3 +290,125 @@ func TestAccountHandler_transactionHandler_respondsWith_errorStatusCode_when_ser
 		t.Errorf("Expecting response to contain %s but got %s", dummyAppError.Message, actualResponse)
 	}
 }
+
+func TestAccountHandler_transactionHandler_respondsWith_statusCode202_when_transaction_heldForReview(t *testing.T) {
+	//Arrange
+	teardown := setupAccountHandlerTest(t, dummyNewTransactionPath, dummyNewTransactionPayload)
+	defer teardown()
+	router.HandleFunc(newTransactionPath, ah.transactionHandler)
+
+	dummyNewTransactionRequestObject := getDefaultDummyNewTransactionRequestObject()
+	dummyTransaction := dto.TransactionResponse{ReviewId: "5", Status: dto.TransactionStatusPendingReview, Balance: dummyAmount}
+	mockApprovalService.EXPECT().IsTransactionApprovalRequired(gomock.Any(), dummyNewTransactionRequestObject).Return(false)
+	mockAccountService.EXPECT().MakeTransaction(dummyNewTransactionRequestObject).Return(&dummyTransaction, nil)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusAccepted {
+		t.Errorf("Expected status code %d but got %d", http.StatusAccepted, recorder.Result().StatusCode)
+	}
+	actualResponse, _ := io.ReadAll(recorder.Result().Body)
+	if !strings.Contains(string(actualResponse), `"status":"pending_review"`) {
+		t.Errorf("Expecting response to contain the pending review status but got %s", actualResponse)
+	}
+}
+
+func TestAccountHandler_transactionHandler_respondsWith_approvalRequestAndStatusCode202_when_approval_required(t *testing.T) {
+	//Arrange
+	teardown := setupAccountHandlerTest(t, dummyNewTransactionPath, dummyNewTransactionPayload)
+	defer teardown()
+	router.HandleFunc(newTransactionPath, ah.transactionHandler)
+
+	dummyNewTransactionRequestObject := getDefaultDummyNewTransactionRequestObject()
+	dummyApproval := dto.ApprovalResponse{ApprovalId: "3", Status: dto.ApprovalStatusPending}
+	mockApprovalService.EXPECT().IsTransactionApprovalRequired(gomock.Any(), dummyNewTransactionRequestObject).Return(true)
+	mockApprovalService.EXPECT().RequestApproval(domain.ApprovalOperationTransaction,
+		"deposit of 6000.00 on account 1977 of customer 2", dummyNewTransactionRequestObject, "").Return(&dummyApproval, nil)
+	mockAccountService.EXPECT().MakeTransaction(gomock.Any()).Times(0)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusAccepted {
+		t.Errorf("Expected status code %d but got %d", http.StatusAccepted, recorder.Result().StatusCode)
+	}
+	actualResponse, _ := io.ReadAll(recorder.Result().Body)
+	if !strings.Contains(string(actualResponse), `"approval_id":"3"`) {
+		t.Errorf("Expecting response to contain the approval request but got %s", actualResponse)
+	}
+}
+
+func TestAccountHandler_transactionsHandler_respondsWith_transactionsAndStatusCode200_when_service_succeeds(t *testing.T) {
+	//Arrange
+	teardown := setupAccountHandlerTest(t, dummyNewTransactionPath+"/transactions", "")
+	defer teardown()
+	request = httptest.NewRequest(http.MethodGet, dummyNewTransactionPath+"/transactions", nil)
+	router.HandleFunc(newTransactionPath+"/transactions", ah.transactionsHandler)
+
+	dummyTransactions := []dto.AccountTransactionResponse{
+		{TransactionId: dummyTransactionId, Amount: dummyAmount, Status: dto.TransactionStatusPosted},
+		{ReviewId: "5", Amount: dummyAmount, Status: dto.TransactionStatusPendingReview},
+	}
+	mockAccountService.EXPECT().GetTransactions(dummyCustomerId, dummyAccountId).Return(dummyTransactions, nil)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusOK {
+		t.Errorf("Expected status code %d but got %d", http.StatusOK, recorder.Result().StatusCode)
+	}
+	actualResponse, _ := io.ReadAll(recorder.Result().Body)
+	if !strings.Contains(string(actualResponse), `"review_id":"5"`) {
+		t.Errorf("Expecting response to contain the held transaction but got %s", actualResponse)
+	}
+}
+
+func TestAccountHandler_transactionCategoryHandler_respondsWith_statusCode422_when_category_invalid(t *testing.T) {
+	//Arrange
+	path := dummyNewTransactionPath + "/transactions/" + dummyTransactionId
+	teardown := setupAccountHandlerTest(t, path, `{"category": "gambling"}`)
+	defer teardown()
+	request = httptest.NewRequest(http.MethodPatch, path, bytes.NewBuffer([]byte(`{"category": "gambling"}`)))
+	router.HandleFunc(newTransactionPath+"/transactions/{transaction_id:[0-9]+}", ah.transactionCategoryHandler)
+
+	mockAccountService.EXPECT().UpdateTransactionCategory(gomock.Any()).Times(0)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status code %d but got %d", http.StatusUnprocessableEntity, recorder.Result().StatusCode)
+	}
+}
+
+func TestAccountHandler_transactionCategoryHandler_respondsWith_transactionAndStatusCode200_when_service_succeeds(t *testing.T) {
+	//Arrange
+	path := dummyNewTransactionPath + "/transactions/" + dummyTransactionId
+	teardown := setupAccountHandlerTest(t, path, `{"category": "dining"}`)
+	defer teardown()
+	request = httptest.NewRequest(http.MethodPatch, path, bytes.NewBuffer([]byte(`{"category": "dining"}`)))
+	router.HandleFunc(newTransactionPath+"/transactions/{transaction_id:[0-9]+}", ah.transactionCategoryHandler)
+
+	expectedRequest := dto.TransactionCategoryRequest{CustomerId: dummyCustomerId, AccountId: dummyAccountId,
+		TransactionId: dummyTransactionId, Category: dto.TransactionCategoryDining}
+	dummyTransaction := dto.AccountTransactionResponse{TransactionId: dummyTransactionId, Amount: dummyAmount,
+		Status: dto.TransactionStatusPosted, Category: dto.TransactionCategoryDining}
+	mockAccountService.EXPECT().UpdateTransactionCategory(expectedRequest).Return(&dummyTransaction, nil)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusOK {
+		t.Errorf("Expected status code %d but got %d", http.StatusOK, recorder.Result().StatusCode)
+	}
+	actualResponse, _ := io.ReadAll(recorder.Result().Body)
+	if !strings.Contains(string(actualResponse), `"category":"dining"`) {
+		t.Errorf("Expecting response to contain the new category but got %s", actualResponse)
+	}
+}
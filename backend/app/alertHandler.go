@@ -0,0 +1,77 @@
+package app
+
+import (
+	"encoding/json"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/service"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+type AlertHandler struct {
+	service service.AlertService
+}
+
+func (h AlertHandler) rulesHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	response, appErr := h.service.GetRules(vars["customer_id"], vars["account_id"])
+	if appErr != nil {
+		writeErrorResponse(w, r, appErr)
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, response)
+}
+
+func (h AlertHandler) newRuleHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	request := dto.NewAlertRuleRequest{
+		CustomerId: vars["customer_id"],
+		AccountId:  vars["account_id"],
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		logger.Error("Error while decoding json body of new alert rule request: " + err.Error())
+		writeErrorResponse(w, r, errs.NewAppError(http.StatusBadRequest, "Please check that all fields are correctly filled."))
+		return
+	}
+
+	if valErr := request.Validate(); valErr != nil {
+		writeValidationErrorResponse(w, r, valErr)
+		return
+	}
+
+	response, appErr := h.service.CreateRule(request)
+	if appErr != nil {
+		writeErrorResponse(w, r, appErr)
+		return
+	}
+
+	writeJsonResponse(w, http.StatusCreated, response)
+}
+
+func (h AlertHandler) deleteRuleHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	if appErr := h.service.DeleteRule(vars["customer_id"], vars["account_id"], vars["rule_id"]); appErr != nil {
+		writeErrorResponse(w, r, appErr)
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, errs.NewMessageObject("Alert rule deleted"))
+}
+
+func (h AlertHandler) alertsHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	response, appErr := h.service.GetAlerts(vars["customer_id"])
+	if appErr != nil {
+		writeErrorResponse(w, r, appErr)
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, response)
+}
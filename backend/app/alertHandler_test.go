@@ -0,0 +1,118 @@
+package app
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/mocks/service"
+	"github.com/gorilla/mux"
+	"go.uber.org/mock/gomock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockAlertService *service.MockAlertService
+var alh AlertHandler
+
+const alertRulesPath = "/customers/2/account/1977/alerts"
+
+func setupAlertHandlerTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	mockAlertService = service.NewMockAlertService(ctrl)
+	alh = AlertHandler{mockAlertService}
+
+	router = mux.NewRouter()
+	router.HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/alerts", alh.newRuleHandler).Methods(http.MethodPost)
+	router.HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/alerts/{rule_id:[0-9]+}", alh.deleteRuleHandler).Methods(http.MethodDelete)
+	router.HandleFunc("/customers/{customer_id:[0-9]+}/alerts", alh.alertsHandler).Methods(http.MethodGet)
+
+	recorder = httptest.NewRecorder()
+
+	return func() {
+		router = nil
+		recorder = nil
+		request = nil
+		defer ctrl.Finish()
+	}
+}
+
+func TestAlertHandler_newRuleHandler_respondsWith_statusCode422_when_request_invalid(t *testing.T) {
+	//Arrange
+	teardown := setupAlertHandlerTest(t)
+	defer teardown()
+	request = httptest.NewRequest(http.MethodPost, alertRulesPath, strings.NewReader(`{"rule_type": "low_balance", "threshold": -5}`))
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status code %d but got %d", http.StatusUnprocessableEntity, recorder.Result().StatusCode)
+	}
+}
+
+func TestAlertHandler_newRuleHandler_respondsWith_ruleAndStatusCode201_when_service_succeeds(t *testing.T) {
+	//Arrange
+	teardown := setupAlertHandlerTest(t)
+	defer teardown()
+	request = httptest.NewRequest(http.MethodPost, alertRulesPath, strings.NewReader(`{"rule_type": "low_balance", "threshold": 500}`))
+
+	expectedRequest := dto.NewAlertRuleRequest{CustomerId: "2", AccountId: "1977", RuleType: dto.AlertRuleTypeLowBalance, Threshold: 500}
+	dummyResponse := dto.AlertRuleResponse{RuleId: "3", AccountId: "1977", RuleType: dto.AlertRuleTypeLowBalance, Threshold: 500}
+	mockAlertService.EXPECT().CreateRule(expectedRequest).Return(&dummyResponse, nil)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusCreated {
+		t.Errorf("Expected status code %d but got %d", http.StatusCreated, recorder.Result().StatusCode)
+	}
+	actualResponse, _ := io.ReadAll(recorder.Result().Body)
+	if !strings.Contains(string(actualResponse), `"rule_id":"3"`) {
+		t.Errorf("Expected response to contain the new rule id but got %s", string(actualResponse))
+	}
+}
+
+func TestAlertHandler_deleteRuleHandler_respondsWith_errorStatusCode_when_service_fails(t *testing.T) {
+	//Arrange
+	teardown := setupAlertHandlerTest(t)
+	defer teardown()
+	request = httptest.NewRequest(http.MethodDelete, alertRulesPath+"/3", nil)
+
+	dummyAppErr := errs.NewNotFoundError("Alert rule not found")
+	mockAlertService.EXPECT().DeleteRule("2", "1977", "3").Return(dummyAppErr)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != dummyAppErr.Code {
+		t.Errorf("Expected status code %d but got %d", dummyAppErr.Code, recorder.Result().StatusCode)
+	}
+}
+
+func TestAlertHandler_alertsHandler_respondsWith_alertsAndStatusCode200_when_service_succeeds(t *testing.T) {
+	//Arrange
+	teardown := setupAlertHandlerTest(t)
+	defer teardown()
+	request = httptest.NewRequest(http.MethodGet, "/customers/2/alerts", nil)
+
+	dummyAlerts := []dto.AlertResponse{{AlertId: "1", AccountId: "1977", RuleType: dto.AlertRuleTypeLowBalance, Message: "Low balance"}}
+	mockAlertService.EXPECT().GetAlerts("2").Return(dummyAlerts, nil)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusOK {
+		t.Errorf("Expected status code %d but got %d", http.StatusOK, recorder.Result().StatusCode)
+	}
+	actualResponse, _ := io.ReadAll(recorder.Result().Body)
+	if !strings.Contains(string(actualResponse), "Low balance") {
+		t.Errorf("Expected response to contain the alert but got %s", string(actualResponse))
+	}
+}
@@ -0,0 +1,34 @@
+package app
+
+import (
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/service"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+type AnalyticsHandler struct {
+	service service.AnalyticsService
+}
+
+func (h AnalyticsHandler) analyticsHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	analyticsRequest := dto.AnalyticsRequest{
+		CustomerId: vars["customer_id"],
+		From:       r.URL.Query().Get("from"),
+		To:         r.URL.Query().Get("to"),
+	}
+
+	if valErr := analyticsRequest.Validate(); valErr != nil {
+		writeValidationErrorResponse(w, r, valErr)
+		return
+	}
+
+	response, appErr := h.service.GetAnalytics(analyticsRequest)
+	if appErr != nil {
+		writeErrorResponse(w, r, appErr)
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, response)
+}
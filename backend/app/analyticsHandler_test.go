@@ -0,0 +1,97 @@
+package app
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/mocks/service"
+	"github.com/gorilla/mux"
+	"go.uber.org/mock/gomock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockAnalyticsService *service.MockAnalyticsService
+var anh AnalyticsHandler
+
+const analyticsPath = "/customers/2/analytics"
+
+func setupAnalyticsHandlerTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	mockAnalyticsService = service.NewMockAnalyticsService(ctrl)
+	anh = AnalyticsHandler{mockAnalyticsService}
+
+	router = mux.NewRouter()
+	router.HandleFunc("/customers/{customer_id:[0-9]+}/analytics", anh.analyticsHandler).Methods(http.MethodGet)
+
+	recorder = httptest.NewRecorder()
+
+	return func() {
+		router = nil
+		recorder = nil
+		request = nil
+		defer ctrl.Finish()
+	}
+}
+
+func TestAnalyticsHandler_analyticsHandler_respondsWith_statusCode422_when_month_invalid(t *testing.T) {
+	//Arrange
+	teardown := setupAnalyticsHandlerTest(t)
+	defer teardown()
+	request = httptest.NewRequest(http.MethodGet, analyticsPath+"?from=2006-1", nil)
+
+	mockAnalyticsService.EXPECT().GetAnalytics(gomock.Any()).Times(0)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status code %d but got %d", http.StatusUnprocessableEntity, recorder.Result().StatusCode)
+	}
+}
+
+func TestAnalyticsHandler_analyticsHandler_respondsWith_analyticsAndStatusCode200_when_service_succeeds(t *testing.T) {
+	//Arrange
+	teardown := setupAnalyticsHandlerTest(t)
+	defer teardown()
+	request = httptest.NewRequest(http.MethodGet, analyticsPath+"?from=2006-01&to=2006-03", nil)
+
+	dummyResponse := dto.AnalyticsResponse{CustomerId: "2", From: "2006-01", To: "2006-03",
+		Totals: dto.AnalyticsTotalsResponse{Income: 500, Spending: 120, Net: 380}}
+	mockAnalyticsService.EXPECT().GetAnalytics(dto.AnalyticsRequest{CustomerId: "2", From: "2006-01", To: "2006-03"}).
+		Return(&dummyResponse, nil)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusOK {
+		t.Errorf("Expected status code %d but got %d", http.StatusOK, recorder.Result().StatusCode)
+	}
+	actualResponse, _ := io.ReadAll(recorder.Result().Body)
+	if !strings.Contains(string(actualResponse), `"net":380`) {
+		t.Errorf("Expected response to contain the net total but got %s", string(actualResponse))
+	}
+}
+
+func TestAnalyticsHandler_analyticsHandler_respondsWith_errorStatusCode_when_service_fails(t *testing.T) {
+	//Arrange
+	teardown := setupAnalyticsHandlerTest(t)
+	defer teardown()
+	request = httptest.NewRequest(http.MethodGet, analyticsPath, nil)
+
+	mockAnalyticsService.EXPECT().GetAnalytics(dto.AnalyticsRequest{CustomerId: "2"}).
+		Return(nil, errs.NewUnexpectedError("Unexpected database error"))
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusInternalServerError {
+		t.Errorf("Expected status code %d but got %d", http.StatusInternalServerError, recorder.Result().StatusCode)
+	}
+}
@@ -1,92 +1,157 @@
 package app
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/aliciatay-zls/banking-lib/clock"
 	"github.com/aliciatay-zls/banking-lib/logger"
 	"github.com/aliciatay-zls/banking/backend/domain"
 	"github.com/aliciatay-zls/banking/backend/service"
-	_ "github.com/go-sql-driver/mysql"
 	"github.com/gorilla/mux"
 	"github.com/jmoiron/sqlx"
 	"github.com/joho/godotenv"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 )
 
-func checkEnvVars() {
+// outboxRelayInterval is how often the outbox is polled for events to publish.
+const outboxRelayInterval = time.Second
+
+// webhookDispatchInterval is how often webhook deliveries are checked for ones that are due.
+const webhookDispatchInterval = 5 * time.Second
+
+// defaultApprovalThreshold is the amount above which a transaction made by an admin needs the approval of a second
+// admin, unless another is set in APPROVAL_THRESHOLD.
+const defaultApprovalThreshold float64 = 5000
+
+// defaultOverdraftFee is the one-off fee charged when a checking account goes overdrawn, unless another is set in
+// OVERDRAFT_FEE.
+const defaultOverdraftFee float64 = 25
+
+// defaultOverdraftInterestRate is the yearly interest rate, in percent, charged daily on overdrawn balances, unless
+// another is set in OVERDRAFT_INTEREST_RATE.
+const defaultOverdraftInterestRate float64 = 18
+
+// defaultPayeeCoolingOffHours is how long after being added a payee can only receive transfers of up to
+// defaultPayeeCoolingOffLimit, unless other terms are set in PAYEE_COOLING_OFF_HOURS and PAYEE_COOLING_OFF_LIMIT.
+const defaultPayeeCoolingOffHours float64 = 24
+const defaultPayeeCoolingOffLimit float64 = 1000
+
+// defaultRateLimit is the budget of each client for the routes that only read, and defaultMutatingRateLimit the
+// stricter one for the routes that change data, unless others are set in RATE_LIMIT_DEFAULT and RATE_LIMIT_MUTATING.
+var defaultRateLimit = RateLimit{Requests: 120, Per: time.Minute}
+var defaultMutatingRateLimit = RateLimit{Requests: 30, Per: time.Minute}
+
+// defaultCorsMaxAge is how long browsers may cache the result of a preflight request, unless another is set in
+// CORS_MAX_AGE.
+const defaultCorsMaxAge = 10 * time.Minute
+
+// defaultLegacyApiDeprecatedAt is when the legacy paths were deprecated in favour of /v1, and
+// defaultLegacyApiSunset when they will stop being served, unless others are set in LEGACY_API_DEPRECATED_AT and
+// LEGACY_API_SUNSET.
+var defaultLegacyApiDeprecatedAt = time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+var defaultLegacyApiSunset = time.Date(2027, time.April, 30, 0, 0, 0, 0, time.UTC)
+
+// webhookClient is used to POST webhook deliveries, and gives up on receivers that take too long to respond.
+var webhookClient = &http.Client{Timeout: 10 * time.Second}
+
+var serverEnvVars = []string{
+	"SERVER_ADDRESS",
+	"SERVER_PORT",
+	"SERVER_DOMAIN",
+	"AUTH_SERVER_ADDRESS",
+	"AUTH_SERVER_DOMAIN",
+	"FRONTEND_SERVER_ADDRESS",
+	"FRONTEND_SERVER_DOMAIN",
+}
+
+var dbEnvVars = []string{
+	"DB_USER",
+	"DB_PASSWORD",
+	"DB_HOST",
+	"DB_PORT",
+	"DB_NAME",
+}
+
+// dbServerEnvVars are the dbEnvVars that an embedded SQLite database does not need, as its DB_NAME is the path of the
+// database file.
+var dbServerEnvVars = map[string]bool{
+	"DB_USER":     true,
+	"DB_PASSWORD": true,
+	"DB_HOST":     true,
+	"DB_PORT":     true,
+}
+
+// checkEnvVars checks that the given environment variables have been set, after loading them from the .env file in
+// production mode.
+func checkEnvVars(envVars []string) {
 	val, ok := os.LookupEnv("APP_ENV")
 	if !ok {
 		logger.Fatal("Environment variable APP_ENV not defined")
 	}
 
-	envVars := []string{
-		"SERVER_ADDRESS",
-		"SERVER_PORT",
-		"SERVER_DOMAIN",
-		"AUTH_SERVER_ADDRESS",
-		"AUTH_SERVER_DOMAIN",
-		"FRONTEND_SERVER_ADDRESS",
-		"FRONTEND_SERVER_DOMAIN",
-		"DB_USER",
-		"DB_PASSWORD",
-		"DB_HOST",
-		"DB_PORT",
-		"DB_NAME",
-	}
-
 	if val == "production" {
 		err := godotenv.Load(".env")
 		if err != nil {
 			logger.Fatal("Error loading .env file (needed in production mode)")
 		}
-	} else {
-		envVars = append(envVars, "AUTH_SERVER_PORT", "FRONTEND_SERVER_PORT")
 	}
 
 	for _, key := range envVars {
+		if !isEnvVarNeeded(key) {
+			continue
+		}
 		if os.Getenv(key) == "" {
 			logger.Fatal(fmt.Sprintf("Environment variable %s was not defined", key))
 		}
 	}
 }
 
-func Start() {
-	checkEnvVars()
+// isEnvVarNeeded reports whether the given environment variable is needed for the database selected by DB_DRIVER:
+// stub mode needs no database at all and an embedded SQLite database only needs DB_NAME.
+func isEnvVarNeeded(key string) bool {
+	switch os.Getenv("DB_DRIVER") {
+	case "stub":
+		for _, dbKey := range dbEnvVars {
+			if key == dbKey {
+				return false
+			}
+		}
+	case "sqlite":
+		return !dbServerEnvVars[key]
+	}
+	return true
+}
 
-	router := mux.NewRouter()
+func Start() {
+	envVars := append(serverEnvVars, dbEnvVars...)
+	if os.Getenv("APP_ENV") != "production" {
+		envVars = append(envVars, "AUTH_SERVER_PORT", "FRONTEND_SERVER_PORT")
+	}
+	checkEnvVars(envVars)
 
-	dbClient := getDbClient()
-	clk := clock.RealClock{}
-	customerRepositoryDb := domain.NewCustomerRepositoryDb(dbClient)
-	accountRepositoryDb := domain.NewAccountRepositoryDb(dbClient)
-	ch := CustomerHandlers{service.NewCustomerService(customerRepositoryDb)}
-	ah := AccountHandler{service.NewAccountService(accountRepositoryDb, clk)}
+	var repos repositories
+	if os.Getenv("DB_DRIVER") == "stub" {
+		logger.Info("Running in stub mode: data is kept in memory and lost when the app stops")
+		repos = newStubRepositories()
+	} else {
+		dbClient := getDbClient()
+		checkSchemaVersion(dbClient)
+		repos = newDbRepositories(dbClient)
 
-	router.
-		HandleFunc("/customers", ch.customersHandler).
-		Methods(http.MethodGet, http.MethodOptions).
-		Name("GetAllCustomers")
-	router.
-		HandleFunc("/customers/{customer_id:[0-9]+}", ah.accountsHandler).
-		Methods(http.MethodGet, http.MethodOptions).
-		Name("GetAccountsForCustomer")
-	router.
-		HandleFunc("/customers/{customer_id:[0-9]+}/profile", ch.customerProfileHandler).
-		Methods(http.MethodGet, http.MethodOptions).
-		Name("GetCustomer")
-	router.
-		HandleFunc("/customers/{customer_id:[0-9]+}/account/new", ah.newAccountHandler).
-		Methods(http.MethodPost, http.MethodOptions).
-		Name("NewAccount")
-	router.
-		HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}", ah.transactionHandler).
-		Methods(http.MethodPost, http.MethodOptions).
-		Name("NewTransaction")
+		publisher := domain.NewMultiEventPublisher(newEventPublisher(),
+			domain.NewWebhookEventPublisher(repos.webhook, clock.RealClock{}))
+		relay := service.NewOutboxRelay(domain.NewOutboxRepositoryDb(dbClient), publisher, clock.RealClock{})
+		go relay.Run(context.Background(), outboxRelayInterval)
 
-	amw := AuthMiddleware{domain.NewDefaultAuthRepository()}
-	router.Use(amw.AuthMiddlewareHandler)
+		dispatcher := service.NewWebhookDispatcher(repos.webhook, webhookClient, clock.RealClock{})
+		go dispatcher.Run(context.Background(), webhookDispatchInterval)
+	}
+	router := newRouter(repos, domain.NewDefaultAuthRepository(), clock.RealClock{})
 
 	address := os.Getenv("SERVER_ADDRESS")
 	port := os.Getenv("SERVER_PORT")
@@ -106,23 +171,539 @@ func Start() {
 	}
 }
 
-func getDbClient() *sqlx.DB {
-	dbUser := os.Getenv("DB_USER")
-	dbPassword := os.Getenv("DB_PASSWORD")
-	dbHost := os.Getenv("DB_HOST")
-	dbPort := os.Getenv("DB_PORT")
-	dbName := os.Getenv("DB_NAME")
+// newEventPublisher returns the publisher selected by EVENT_PUBLISHER: "file" appends the events to the file at
+// EVENT_FILE, anything else logs them.
+func newEventPublisher() domain.EventPublisher {
+	if os.Getenv("EVENT_PUBLISHER") != "file" {
+		return domain.NewLogEventPublisher()
+	}
+
+	path := os.Getenv("EVENT_FILE")
+	if path == "" {
+		logger.Fatal("Environment variable EVENT_FILE was not defined (needed when EVENT_PUBLISHER is file)")
+	}
+	return domain.NewFileEventPublisher(path)
+}
+
+// newNotifier returns the notifier selected by ALERT_NOTIFIER: "smtp" emails alerts to customers through the mail
+// server at SMTP_ADDRESS, anything else puts them in the customers' in-app inbox.
+func newNotifier(alertRepo domain.AlertRepository, customerRepo domain.CustomerRepository) domain.Notifier {
+	if os.Getenv("ALERT_NOTIFIER") != "smtp" {
+		return domain.NewInboxNotifier(alertRepo)
+	}
+
+	for _, k := range []string{"SMTP_ADDRESS", "SMTP_FROM"} {
+		if os.Getenv(k) == "" {
+			logger.Fatal(fmt.Sprintf("Environment variable %s was not defined (needed when ALERT_NOTIFIER is smtp)", k))
+		}
+	}
+	return domain.NewSmtpNotifier(os.Getenv("SMTP_ADDRESS"), os.Getenv("SMTP_USERNAME"), os.Getenv("SMTP_PASSWORD"),
+		os.Getenv("SMTP_FROM"), customerRepo)
+}
+
+// newFraudEngine returns the fraud engine with the rules configured in the JSON file at FRAUD_RULES_FILE, or with the
+// default rules if it is not set.
+func newFraudEngine() domain.FraudEngine {
+	configs := domain.DefaultFraudRuleConfigs()
+	if path := os.Getenv("FRAUD_RULES_FILE"); path != "" {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			logger.Fatal("Error while reading fraud rules file: " + err.Error())
+		}
+		if err = json.Unmarshal(content, &configs); err != nil {
+			logger.Fatal("Error while parsing fraud rules file: " + err.Error())
+		}
+	}
+
+	rules, err := domain.NewFraudRules(configs)
+	if err != nil {
+		logger.Fatal("Error while configuring fraud rules: " + err.Error())
+	}
+	return domain.NewFraudEngine(rules...)
+}
+
+// approvalThreshold returns the amount set in APPROVAL_THRESHOLD above which a transaction made by an admin needs the
+// approval of a second admin, or defaultApprovalThreshold if it is not set.
+func approvalThreshold() float64 {
+	value := os.Getenv("APPROVAL_THRESHOLD")
+	if value == "" {
+		return defaultApprovalThreshold
+	}
+
+	threshold, err := strconv.ParseFloat(value, 64)
+	if err != nil || threshold < 0 {
+		logger.Fatal("Environment variable APPROVAL_THRESHOLD must be a non-negative amount")
+	}
+	return threshold
+}
+
+// overdraftTerms returns the overdraft fee and interest rate set in OVERDRAFT_FEE and OVERDRAFT_INTEREST_RATE, or
+// defaultOverdraftFee and defaultOverdraftInterestRate for those that are not set.
+func overdraftTerms() domain.OverdraftTerms {
+	return domain.OverdraftTerms{
+		Fee:          nonNegativeEnvVar("OVERDRAFT_FEE", defaultOverdraftFee),
+		InterestRate: nonNegativeEnvVar("OVERDRAFT_INTEREST_RATE", defaultOverdraftInterestRate),
+	}
+}
+
+// payeeTerms returns the cooling-off period and limit of new payees set in PAYEE_COOLING_OFF_HOURS and
+// PAYEE_COOLING_OFF_LIMIT, or defaultPayeeCoolingOffHours and defaultPayeeCoolingOffLimit for those that are not set.
+func payeeTerms() domain.PayeeTerms {
+	hours := nonNegativeEnvVar("PAYEE_COOLING_OFF_HOURS", defaultPayeeCoolingOffHours)
+	return domain.PayeeTerms{
+		CoolingOffPeriod: time.Duration(hours * float64(time.Hour)),
+		CoolingOffLimit:  nonNegativeEnvVar("PAYEE_COOLING_OFF_LIMIT", defaultPayeeCoolingOffLimit),
+	}
+}
+
+// rateLimitPolicy returns the rate limits set in RATE_LIMIT_DEFAULT, RATE_LIMIT_MUTATING and RATE_LIMIT_ROUTES, or
+// defaultRateLimit and defaultMutatingRateLimit for those that are not set. RATE_LIMIT_ROUTES is a comma-separated
+// list of route names and their own limits, e.g. "NewTransaction=10/1m,GetAllCustomers=30/1m".
+func rateLimitPolicy() RateLimitPolicy {
+	policy := RateLimitPolicy{
+		Default:           rateLimitEnvVar("RATE_LIMIT_DEFAULT", defaultRateLimit),
+		Mutating:          rateLimitEnvVar("RATE_LIMIT_MUTATING", defaultMutatingRateLimit),
+		Routes:            make(map[string]RateLimit),
+		TrustForwardedFor: os.Getenv("RATE_LIMIT_TRUST_FORWARDED_FOR") == "true",
+	}
+	for _, entry := range strings.Split(os.Getenv("RATE_LIMIT_ROUTES"), ",") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		routeName, value, found := strings.Cut(entry, "=")
+		limit, err := ParseRateLimit(value)
+		if !found || err != nil {
+			logger.Fatal(fmt.Sprintf("Environment variable RATE_LIMIT_ROUTES has an invalid entry %q", entry))
+		}
+		policy.Routes[strings.TrimSpace(routeName)] = limit
+	}
+	return policy
+}
+
+// corsPolicy returns the CORS policy, which allows the frontend at FRONTEND_SERVER_DOMAIN and any other origins set in
+// CORS_ALLOWED_ORIGINS, a comma-separated list of origins or patterns such as "https://banking-*.vercel.app" for
+// preview deploys. Browsers may cache preflight results for CORS_MAX_AGE, or defaultCorsMaxAge if it is not set, and
+// only send credentials if CORS_ALLOW_CREDENTIALS is "true".
+func corsPolicy() CorsPolicy {
+	policy := CorsPolicy{
+		AllowedOrigins: []string{fmt.Sprintf("https://%s", os.Getenv("FRONTEND_SERVER_DOMAIN"))},
+		AllowedHeaders: []string{"Content-Type", "Authorization", requestIdHeader},
+		ExposedHeaders: []string{
+			"RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy", "Retry-After",
+			requestIdHeader, "Deprecation", "Sunset", "Link",
+		},
+		AllowCredentials: os.Getenv("CORS_ALLOW_CREDENTIALS") == "true",
+		MaxAge:           defaultCorsMaxAge,
+	}
+	for _, origin := range strings.Split(os.Getenv("CORS_ALLOWED_ORIGINS"), ",") {
+		origin = strings.TrimSpace(origin)
+		if origin == "" {
+			continue
+		}
+		if !strings.HasPrefix(origin, "https://") && !strings.HasPrefix(origin, "http://") ||
+			strings.Count(origin, "*") > 1 || strings.HasSuffix(origin, "/") {
+			logger.Fatal(fmt.Sprintf("Environment variable CORS_ALLOWED_ORIGINS has an invalid origin %q", origin))
+		}
+		policy.AllowedOrigins = append(policy.AllowedOrigins, origin)
+	}
+	if value := os.Getenv("CORS_MAX_AGE"); value != "" {
+		maxAge, err := time.ParseDuration(value)
+		if err != nil || maxAge < 0 {
+			logger.Fatal("Environment variable CORS_MAX_AGE must be a non-negative duration")
+		}
+		policy.MaxAge = maxAge
+	}
+	return policy
+}
+
+// rateLimitEnvVar returns the rate limit set in the given environment variable, or the given default if it is not set.
+func rateLimitEnvVar(key string, defaultValue RateLimit) RateLimit {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
 
-	dataSource := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s", dbUser, dbPassword, dbHost, dbPort, dbName)
-	db, err := sqlx.Open("mysql", dataSource)
+	limit, err := ParseRateLimit(value)
 	if err != nil {
-		logger.Fatal("Error while opening connection to database: " + err.Error())
+		logger.Fatal(fmt.Sprintf("Environment variable %s is invalid: %s", key, err.Error()))
+	}
+	return limit
+}
+
+// deprecationPolicy returns the deprecation of the legacy paths set in LEGACY_API_DEPRECATED_AT and LEGACY_API_SUNSET,
+// dates given as YYYY-MM-DD, or defaultLegacyApiDeprecatedAt and defaultLegacyApiSunset for those that are not set.
+func deprecationPolicy() DeprecationPolicy {
+	policy := DeprecationPolicy{
+		DeprecatedAt: dateEnvVar("LEGACY_API_DEPRECATED_AT", defaultLegacyApiDeprecatedAt),
+		Sunset:       dateEnvVar("LEGACY_API_SUNSET", defaultLegacyApiSunset),
+		Successor:    currentApiVersion,
+	}
+	if !policy.Sunset.After(policy.DeprecatedAt) {
+		logger.Fatal("Environment variable LEGACY_API_SUNSET must be after LEGACY_API_DEPRECATED_AT")
+	}
+	return policy
+}
+
+// dateEnvVar returns the date set as YYYY-MM-DD in the given environment variable, or the given default if it is not set.
+func dateEnvVar(key string, defaultValue time.Time) time.Time {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		logger.Fatal(fmt.Sprintf("Environment variable %s must be a date given as YYYY-MM-DD", key))
+	}
+	return date
+}
+
+// nonNegativeEnvVar returns the number set in the given environment variable, or the given default if it is not set.
+func nonNegativeEnvVar(key string, defaultValue float64) float64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || number < 0 {
+		logger.Fatal(fmt.Sprintf("Environment variable %s must be a non-negative number", key))
+	}
+	return number
+}
+
+// repositories holds the adapters (secondary ports) that the app is wired with.
+type repositories struct {
+	customer          domain.CustomerRepository
+	account           domain.AccountRepository
+	fraud             domain.FraudRepository
+	transactionReview domain.TransactionReviewRepository
+	hold              domain.HoldRepository
+	approval          domain.ApprovalRepository
+	overdraft         domain.OverdraftRepository
+	fee               domain.FeeRepository
+	reversal          domain.TransactionReversalRepository
+	analytics         domain.AnalyticsRepository
+	payee             domain.PayeeRepository
+	alert             domain.AlertRepository
+	notifier          domain.Notifier
+	transactionImport domain.TransactionImportRepository //nil in stub mode
+	reconciliation    domain.ReconciliationRepository    //nil in stub mode
+	webhook           domain.WebhookRepository           //nil in stub mode
+}
+
+func newDbRepositories(dbClient *sqlx.DB) repositories {
+	customerRepo := domain.NewCustomerRepositoryDb(dbClient)
+	alertRepo := domain.NewAlertRepositoryDb(dbClient)
+	return repositories{
+		customer:          customerRepo,
+		account:           domain.NewAccountRepositoryDb(dbClient),
+		fraud:             domain.NewFraudRepositoryDb(dbClient),
+		transactionReview: domain.NewTransactionReviewRepositoryDb(dbClient),
+		hold:              domain.NewHoldRepositoryDb(dbClient),
+		approval:          domain.NewApprovalRepositoryDb(dbClient),
+		overdraft:         domain.NewOverdraftRepositoryDb(dbClient),
+		fee:               domain.NewFeeRepositoryDb(dbClient),
+		reversal:          domain.NewTransactionReversalRepositoryDb(dbClient),
+		analytics:         domain.NewAnalyticsRepositoryDb(dbClient),
+		payee:             domain.NewPayeeRepositoryDb(dbClient),
+		alert:             alertRepo,
+		notifier:          newNotifier(alertRepo, customerRepo),
+		transactionImport: domain.NewTransactionImportRepositoryDb(dbClient),
+		reconciliation:    domain.NewReconciliationRepositoryDb(dbClient),
+		webhook:           domain.NewWebhookRepositoryDb(dbClient),
+	}
+}
+
+// newStubRepositories returns in-memory stubs for the customer, account, fraud, transaction review, hold, approval,
+// overdraft, fee, transaction reversal, analytics, payee and alert repositories. The admin features that only have DB
+// adapters (transaction import, reconciliation and webhooks) are not available in stub mode.
+func newStubRepositories() repositories {
+	customerRepo := domain.NewCustomerRepositoryStub()
+	accountRepo := domain.NewAccountRepositoryStub()
+	alertRepo := domain.NewAlertRepositoryStub()
+	return repositories{
+		customer:          customerRepo,
+		account:           accountRepo,
+		fraud:             domain.NewFraudRepositoryStub(accountRepo),
+		transactionReview: domain.NewTransactionReviewRepositoryStub(),
+		hold:              domain.NewHoldRepositoryStub(),
+		approval:          domain.NewApprovalRepositoryStub(),
+		overdraft:         domain.NewOverdraftRepositoryStub(),
+		fee:               domain.NewFeeRepositoryStub(accountRepo),
+		reversal:          domain.NewTransactionReversalRepositoryStub(accountRepo),
+		analytics:         domain.NewAnalyticsRepositoryStub(accountRepo),
+		payee:             domain.NewPayeeRepositoryStub(),
+		alert:             alertRepo,
+		notifier:          newNotifier(alertRepo, customerRepo),
+	}
+}
+
+// newRouter wires the services and REST handlers with the given repositories, and registers the routes behind the
+// auth middleware.
+func newRouter(repos repositories, authRepo domain.AuthRepository, clk clock.Clock) *mux.Router {
+	router := mux.NewRouter()
+	api := router.PathPrefix(currentApiVersion).Subrouter() //the routes of the current version, which need an access token
+
+	fraudService := service.NewFraudService(repos.fraud, newFraudEngine(), clk)
+	alertService := service.NewAlertService(repos.alert, repos.account, repos.notifier, clk)
+	overdraftService := service.NewOverdraftService(repos.overdraft, repos.account, repos.hold, overdraftTerms(), clk)
+	feeService := service.NewFeeService(repos.fee, repos.account, overdraftService, clk)
+	accountService := service.NewAccountService(repos.account, repos.transactionReview, repos.hold, fraudService, alertService, overdraftService, feeService, clk)
+	reversalService := service.NewTransactionReversalService(repos.reversal, repos.account, repos.transactionReview, repos.hold, alertService, overdraftService, clk)
+
+	executors := map[string]service.ApprovalExecutor{
+		domain.ApprovalOperationTransaction:         service.NewTransactionApprovalExecutor(accountService),
+		domain.ApprovalOperationTransactionReversal: service.NewTransactionReversalApprovalExecutor(reversalService),
+	}
+	var importService service.TransactionImportService
+	if repos.transactionImport != nil {
+		importService = service.NewTransactionImportService(repos.account, repos.transactionImport, repos.transactionReview, repos.hold, clk)
+		executors[domain.ApprovalOperationTransactionImport] = service.NewTransactionImportApprovalExecutor(importService)
+	}
+	var reconciliationService service.ReconciliationService
+	if repos.reconciliation != nil {
+		reconciliationService = service.NewReconciliationService(repos.reconciliation, clk)
+		executors[domain.ApprovalOperationFreezeAccounts] = service.NewFreezeApprovalExecutor(reconciliationService)
+	}
+	var webhookService service.WebhookService
+	if repos.webhook != nil {
+		webhookService = service.NewWebhookService(repos.webhook, service.NewWebhookDispatcher(repos.webhook, webhookClient, clk), clk)
+		executors[domain.ApprovalOperationNewWebhookSubscription] = service.NewWebhookSubscriptionApprovalExecutor(webhookService)
+		executors[domain.ApprovalOperationDeleteWebhookSubscription] = service.NewWebhookDeletionApprovalExecutor(webhookService)
+	}
+	approvalService := service.NewApprovalService(repos.approval, executors, approvalThreshold(), clk)
+
+	ch := CustomerHandlers{service.NewCustomerService(repos.customer)}
+	ah := AccountHandler{accountService, approvalService}
+	alh := AlertHandler{alertService}
+	trh := TransactionReviewHandler{service.NewTransactionReviewService(repos.transactionReview, repos.account, repos.hold, alertService, overdraftService, feeService, clk)}
+	hh := HoldHandler{service.NewHoldService(repos.hold, repos.account, repos.transactionReview, alertService, overdraftService, feeService, clk)}
+	oh := OverdraftHandler{overdraftService}
+	fh := FeeHandler{feeService}
+	rvh := TransactionReversalHandler{reversalService, approvalService}
+	aph := ApprovalHandler{approvalService}
+	anh := AnalyticsHandler{service.NewAnalyticsService(repos.analytics, repos.account, clk)}
+	terms := payeeTerms()
+	ph := PayeeHandler{service.NewPayeeService(repos.payee, repos.account, repos.customer, terms, clk)}
+	tfh := TransferHandler{service.NewTransferService(repos.account, repos.payee, repos.transactionReview, repos.hold, fraudService, alertService, overdraftService, feeService, terms, clk)}
+
+	api.
+		HandleFunc("/customers", ch.customersHandler).
+		Methods(http.MethodGet, http.MethodOptions).
+		Name("GetAllCustomers")
+	api.
+		HandleFunc("/customers/{customer_id:[0-9]+}", ah.accountsHandler).
+		Methods(http.MethodGet, http.MethodOptions).
+		Name("GetAccountsForCustomer")
+	api.
+		HandleFunc("/customers/{customer_id:[0-9]+}/profile", ch.customerProfileHandler).
+		Methods(http.MethodGet, http.MethodOptions).
+		Name("GetCustomer")
+	api.
+		HandleFunc("/customers/{customer_id:[0-9]+}/analytics", anh.analyticsHandler).
+		Methods(http.MethodGet, http.MethodOptions).
+		Name("GetCustomerAnalytics")
+	api.
+		HandleFunc("/customers/{customer_id:[0-9]+}/payees", ph.payeesHandler).
+		Methods(http.MethodGet, http.MethodOptions).
+		Name("GetPayees")
+	api.
+		HandleFunc("/customers/{customer_id:[0-9]+}/payees", ph.newPayeeHandler).
+		Methods(http.MethodPost, http.MethodOptions).
+		Name("NewPayee")
+	api.
+		HandleFunc("/customers/{customer_id:[0-9]+}/payees/audit", ph.auditTrailHandler).
+		Methods(http.MethodGet, http.MethodOptions).
+		Name("GetPayeeAuditTrail")
+	api.
+		HandleFunc("/customers/{customer_id:[0-9]+}/payees/{payee_id:[0-9]+}", ph.renamePayeeHandler).
+		Methods(http.MethodPatch, http.MethodOptions).
+		Name("RenamePayee")
+	api.
+		HandleFunc("/customers/{customer_id:[0-9]+}/payees/{payee_id:[0-9]+}", ph.deletePayeeHandler).
+		Methods(http.MethodDelete, http.MethodOptions).
+		Name("DeletePayee")
+	api.
+		HandleFunc("/customers/{customer_id:[0-9]+}/account/new", ah.newAccountHandler).
+		Methods(http.MethodPost, http.MethodOptions).
+		Name("NewAccount")
+	api.
+		HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}", ah.transactionHandler).
+		Methods(http.MethodPost, http.MethodOptions).
+		Name("NewTransaction")
+	api.
+		HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/transactions", ah.transactionsHandler).
+		Methods(http.MethodGet, http.MethodOptions).
+		Name("GetTransactions")
+	api.
+		HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/transfers", tfh.transferHandler).
+		Methods(http.MethodPost, http.MethodOptions).
+		Name("NewTransfer")
+	api.
+		HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/transactions/{transaction_id:[0-9]+}", ah.transactionCategoryHandler).
+		Methods(http.MethodPatch, http.MethodOptions).
+		Name("UpdateTransactionCategory")
+	api.
+		HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/alerts", alh.rulesHandler).
+		Methods(http.MethodGet, http.MethodOptions).
+		Name("GetAlertRules")
+	api.
+		HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/alerts", alh.newRuleHandler).
+		Methods(http.MethodPost, http.MethodOptions).
+		Name("NewAlertRule")
+	api.
+		HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/alerts/{rule_id:[0-9]+}", alh.deleteRuleHandler).
+		Methods(http.MethodDelete, http.MethodOptions).
+		Name("DeleteAlertRule")
+	api.
+		HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/holds", hh.holdsHandler).
+		Methods(http.MethodGet, http.MethodOptions).
+		Name("GetHolds")
+	api.
+		HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/holds", hh.newHoldHandler).
+		Methods(http.MethodPost, http.MethodOptions).
+		Name("NewHold")
+	api.
+		HandleFunc("/holds/{hold_id:[0-9]+}/capture", hh.captureHandler).
+		Methods(http.MethodPost, http.MethodOptions).
+		Name("CaptureHold")
+	api.
+		HandleFunc("/holds/{hold_id:[0-9]+}/release", hh.releaseHandler).
+		Methods(http.MethodPost, http.MethodOptions).
+		Name("ReleaseHold")
+	api.
+		HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/overdraft", oh.overdraftHandler).
+		Methods(http.MethodGet, http.MethodOptions).
+		Name("GetOverdraft")
+	api.
+		HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/overdraft", oh.limitHandler).
+		Methods(http.MethodPost, http.MethodOptions).
+		Name("SetOverdraftLimit")
+	api.
+		HandleFunc("/fees/schedules", fh.schedulesHandler).
+		Methods(http.MethodGet, http.MethodOptions).
+		Name("GetFeeSchedules")
+	api.
+		HandleFunc("/fees/schedules", fh.newScheduleHandler).
+		Methods(http.MethodPost, http.MethodOptions).
+		Name("NewFeeSchedule")
+	api.
+		HandleFunc("/fees/schedules/{schedule_id:[0-9]+}", fh.deleteScheduleHandler).
+		Methods(http.MethodDelete, http.MethodOptions).
+		Name("DeleteFeeSchedule")
+	api.
+		HandleFunc("/customers/{customer_id:[0-9]+}/alerts", alh.alertsHandler).
+		Methods(http.MethodGet, http.MethodOptions).
+		Name("GetAlerts")
+	api.
+		HandleFunc("/transactions/reviews", trh.reviewsHandler).
+		Methods(http.MethodGet, http.MethodOptions).
+		Name("GetTransactionReviews")
+	api.
+		HandleFunc("/transactions/reviews/{review_id:[0-9]+}/approve", trh.approveHandler).
+		Methods(http.MethodPost, http.MethodOptions).
+		Name("ApproveTransactionReview")
+	api.
+		HandleFunc("/transactions/reviews/{review_id:[0-9]+}/reject", trh.rejectHandler).
+		Methods(http.MethodPost, http.MethodOptions).
+		Name("RejectTransactionReview")
+	api.
+		HandleFunc("/transactions/{transaction_id:[0-9]+}/reverse", rvh.reverseHandler).
+		Methods(http.MethodPost, http.MethodOptions).
+		Name("ReverseTransaction")
+	api.
+		HandleFunc("/approvals", aph.approvalsHandler).
+		Methods(http.MethodGet, http.MethodOptions).
+		Name("GetApprovalRequests")
+	api.
+		HandleFunc("/approvals/{approval_id:[0-9]+}/approve", aph.approveHandler).
+		Methods(http.MethodPost, http.MethodOptions).
+		Name("ApproveApprovalRequest")
+	api.
+		HandleFunc("/approvals/{approval_id:[0-9]+}/reject", aph.rejectHandler).
+		Methods(http.MethodPost, http.MethodOptions).
+		Name("RejectApprovalRequest")
+
+	if importService != nil {
+		tih := TransactionImportHandler{importService, approvalService}
+		api.
+			HandleFunc("/transactions/import", tih.importHandler).
+			Methods(http.MethodPost, http.MethodOptions).
+			Name("ImportTransactions")
+	}
+	if reconciliationService != nil {
+		rh := ReconciliationHandler{reconciliationService, approvalService}
+		api.
+			HandleFunc("/reconciliation", rh.reportHandler).
+			Methods(http.MethodGet, http.MethodOptions).
+			Name("GetReconciliationReport")
+		api.
+			HandleFunc("/reconciliation/freeze", rh.freezeHandler).
+			Methods(http.MethodPost, http.MethodOptions).
+			Name("FreezeMismatchedAccounts")
+	}
+	if webhookService != nil {
+		wh := WebhookHandler{webhookService, approvalService}
+		api.
+			HandleFunc("/webhooks", wh.newSubscriptionHandler).
+			Methods(http.MethodPost, http.MethodOptions).
+			Name("NewWebhookSubscription")
+		api.
+			HandleFunc("/webhooks", wh.subscriptionsHandler).
+			Methods(http.MethodGet, http.MethodOptions).
+			Name("GetWebhookSubscriptions")
+		api.
+			HandleFunc("/webhooks/{subscription_id:[0-9]+}", wh.deleteSubscriptionHandler).
+			Methods(http.MethodDelete, http.MethodOptions).
+			Name("DeleteWebhookSubscription")
+		api.
+			HandleFunc("/webhooks/{subscription_id:[0-9]+}/deliveries", wh.deliveriesHandler).
+			Methods(http.MethodGet, http.MethodOptions).
+			Name("GetWebhookDeliveries")
+		api.
+			HandleFunc("/webhooks/deliveries/{delivery_id:[0-9]+}", wh.deliveryHandler).
+			Methods(http.MethodGet, http.MethodOptions).
+			Name("GetWebhookDelivery")
+		api.
+			HandleFunc("/webhooks/deliveries/{delivery_id:[0-9]+}/redeliver", wh.redeliverHandler).
+			Methods(http.MethodPost, http.MethodOptions).
+			Name("RedeliverWebhook")
+	}
+	legacy := newLegacyApi(router, api, currentApiVersion)
+
+	router.
+		HandleFunc("/problems", problemCatalogHandler).
+		Methods(http.MethodGet, http.MethodOptions).
+		Name(problemCatalogRouteName)
+	router.
+		HandleFunc("/problems/{code:[A-Z_]+}", problemTypeHandler).
+		Methods(http.MethodGet, http.MethodOptions).
+		Name(problemTypeRouteName)
+
+	docRoute := router.
+		Path("/openapi.json").
+		Methods(http.MethodGet, http.MethodOptions).
+		Name(apiDocumentRouteName)
+	doc := newApiDocument(router)
+	docRoute.HandlerFunc(apiDocumentHandler(doc))
+
+	cmw := NewCorsMiddleware(corsPolicy(), router)
+	amw := AuthMiddleware{authRepo}
+	rlm := NewRateLimitMiddleware(rateLimitPolicy(), clk)
+	router.Use(RequestIdMiddlewareHandler, cmw.CorsMiddlewareHandler)
+	router.NotFoundHandler = RequestIdMiddlewareHandler(http.HandlerFunc(notFoundHandler))
+	router.MethodNotAllowedHandler = RequestIdMiddlewareHandler(http.HandlerFunc(methodNotAllowedHandler))
+	legacy.Use(DeprecationMiddleware{deprecationPolicy()}.DeprecationMiddlewareHandler)
+	for _, version := range []*mux.Router{api, legacy} { //a new version is added here too
+		version.Use(rlm.IPRateLimitHandler)
+		if os.Getenv("OPENAPI_VALIDATE_REQUESTS") == "true" {
+			version.Use(ApiValidationMiddleware{doc}.ApiValidationMiddlewareHandler)
+		}
+		version.Use(amw.AuthMiddlewareHandler, rlm.CustomerRateLimitHandler)
 	}
-	db.SetConnMaxLifetime(time.Minute * 3)
-	db.SetMaxOpenConns(10)
-	db.SetMaxIdleConns(10)
 
-	return db
+	return router
 }
 
 //Notes
@@ -0,0 +1,996 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	mocksDomain "github.com/aliciatay-zls/banking/backend/mocks/domain"
+	"github.com/aliciatay-zls/banking/backend/service"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/mock/gomock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// Integration tests: the whole HTTP stack (router, auth middleware, handlers, services and DB adapters) is run
+// against an embedded in-memory SQLite database loaded with the demo data. Only the auth server is mocked.
+
+const seededCustomerId = "2001"
+const seededAccountId = "95472"
+const seededAccountAmount float64 = 7000
+
+// testDbClient is the database of the app under test, for tests that look behind the HTTP API.
+var testDbClient *sqlx.DB
+
+func setupAppTest(t *testing.T) func() {
+	t.Setenv("DB_DRIVER", "sqlite")
+	t.Setenv("DB_NAME", ":memory:")
+	logger.MuteLogger()
+
+	dbClient := getDbClient()
+	checkSchemaVersion(dbClient)
+	testDbClient = dbClient
+
+	ctrl := gomock.NewController(t)
+	mockAuthRepo = mocksDomain.NewMockAuthRepository(ctrl)
+	mockAuthRepo.EXPECT().IsAuthorized(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	router = newRouter(newDbRepositories(dbClient), mockAuthRepo, clock.StaticClock{})
+
+	return func() {
+		router = nil
+		testDbClient = nil
+		defer ctrl.Finish()
+		defer dbClient.Close()
+	}
+}
+
+// serve sends a request with the token of a customer through the router and decodes the JSON response body into
+// responseBody.
+func serve(t *testing.T, method string, path string, payload string, responseBody interface{}) int {
+	return serveAs(t, dummyToken, method, path, payload, responseBody)
+}
+
+// serveAs is serve with the given token, e.g. that of an admin.
+func serveAs(t *testing.T, token string, method string, path string, payload string, responseBody interface{}) int {
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(method, path, bytes.NewBufferString(payload))
+	request.Header.Add("Authorization", token)
+
+	router.ServeHTTP(recorder, request)
+
+	if err := json.NewDecoder(recorder.Body).Decode(responseBody); err != nil {
+		t.Fatalf("Error while decoding response body of %s %s: %s", method, path, err.Error())
+	}
+	return recorder.Code
+}
+
+func TestApp_GetAllCustomers_returns_seededCustomers(t *testing.T) {
+	//Arrange
+	teardown := setupAppTest(t)
+	defer teardown()
+
+	var customers []dto.CustomerResponse
+
+	//Act
+	statusCode := serve(t, http.MethodGet, "/customers?status=active", "", &customers)
+
+	//Assert
+	if statusCode != http.StatusOK {
+		t.Fatalf("Expected status code %d but got %d", http.StatusOK, statusCode)
+	}
+	if len(customers) != 4 {
+		t.Fatalf("Expected 4 active customers but got %d: %v", len(customers), customers)
+	}
+	if customers[0].Id != "2000" || customers[0].DateOfBirth != "1978-12-15" || customers[0].Status != "active" {
+		t.Errorf("Expected first customer to be seeded customer 2000 but got %v", customers[0])
+	}
+}
+
+func TestApp_NewAccount_then_GetAccountsForCustomer_returns_newAccount(t *testing.T) {
+	//Arrange
+	teardown := setupAppTest(t)
+	defer teardown()
+
+	var newAccount dto.NewAccountResponse
+	var accounts []dto.AccountResponse
+
+	//Act
+	newAccountStatusCode := serve(t, http.MethodPost, "/customers/"+seededCustomerId+"/account/new",
+		`{"account_type": "checking", "amount": 5000}`, &newAccount)
+	accountsStatusCode := serve(t, http.MethodGet, "/customers/"+seededCustomerId, "", &accounts)
+
+	//Assert
+	if newAccountStatusCode != http.StatusCreated {
+		t.Fatalf("Expected status code %d but got %d", http.StatusCreated, newAccountStatusCode)
+	}
+	if newAccount.AccountId == "" || newAccount.OpeningDate != dummyDate {
+		t.Errorf("Expected new account with id opened on %s but got %v", dummyDate, newAccount)
+	}
+	if accountsStatusCode != http.StatusOK {
+		t.Fatalf("Expected status code %d but got %d", http.StatusOK, accountsStatusCode)
+	}
+	if len(accounts) != 3 {
+		t.Fatalf("Expected 3 accounts (2 seeded, 1 new) but got %d: %v", len(accounts), accounts)
+	}
+	lastAccount := accounts[len(accounts)-1]
+	if lastAccount.AccountId != newAccount.AccountId || lastAccount.Amount != 5000 {
+		t.Errorf("Expected new account %s with amount 5000 but got %v", newAccount.AccountId, lastAccount)
+	}
+}
+
+func TestApp_NewTransaction_updates_balance_and_keeps_reconciliation_clean(t *testing.T) {
+	//Arrange
+	teardown := setupAppTest(t)
+	defer teardown()
+
+	var transaction dto.TransactionResponse
+	var report dto.ReconciliationReportResponse
+
+	//Act
+	transactionStatusCode := serve(t, http.MethodPost, "/customers/"+seededCustomerId+"/account/"+seededAccountId,
+		`{"transaction_type": "withdrawal", "amount": 1000.5}`, &transaction)
+	reportStatusCode := serve(t, http.MethodGet, "/reconciliation", "", &report)
+
+	//Assert
+	if transactionStatusCode != http.StatusCreated {
+		t.Fatalf("Expected status code %d but got %d", http.StatusCreated, transactionStatusCode)
+	}
+	if transaction.TransactionId == "" || transaction.Balance != seededAccountAmount-1000.5 {
+		t.Errorf("Expected new transaction with balance %.2f but got %v", seededAccountAmount-1000.5, transaction)
+	}
+	if reportStatusCode != http.StatusOK {
+		t.Fatalf("Expected status code %d but got %d", http.StatusOK, reportStatusCode)
+	}
+	if report.AccountsChecked != 4 || len(report.Mismatches) != 0 {
+		t.Errorf("Expected 4 accounts checked with no mismatches but got %v", report)
+	}
+}
+
+func TestApp_ImportTransactions_posts_rows_once_approved_by_secondAdmin(t *testing.T) {
+	//Arrange
+	teardown := setupAppTest(t)
+	defer teardown()
+
+	csvFile := "account_id,amount,type,reference\n" +
+		seededAccountId + ",100,deposit,REF-1\n" +
+		seededAccountId + ",50,withdrawal,REF-2\n"
+	var approval, executed dto.ApprovalResponse
+	var committed, duplicateImport dto.TransactionImportResponse
+	var accounts []dto.AccountResponse
+
+	//Act
+	requestStatusCode := serveAs(t, dummyAdminToken, http.MethodPost, "/transactions/import?mode=commit", csvFile, &approval)
+	approveStatusCode := serveAs(t, dummySecondAdminToken, http.MethodPost, "/approvals/"+approval.ApprovalId+"/approve", "", &executed)
+	againStatusCode := serveAs(t, dummySecondAdminToken, http.MethodPost, "/approvals/"+approval.ApprovalId+"/approve", "", &dto.ApprovalResponse{})
+	duplicateStatusCode := serveAs(t, dummyAdminToken, http.MethodPost, "/transactions/import?mode=commit", csvFile, &duplicateImport)
+	serve(t, http.MethodGet, "/customers/"+seededCustomerId, "", &accounts)
+
+	//Assert
+	if requestStatusCode != http.StatusAccepted || approval.Status != dto.ApprovalStatusPending || approval.RequestedBy != "admin" {
+		t.Fatalf("Expected commit to wait for approval with status code %d but got %d: %v",
+			http.StatusAccepted, requestStatusCode, approval)
+	}
+	if err := json.Unmarshal(executed.Result, &committed); err != nil {
+		t.Fatal("Expected import report as result but got error while decoding it: " + err.Error())
+	}
+	if approveStatusCode != http.StatusOK || executed.Status != dto.ApprovalStatusExecuted || !committed.IsCommitted {
+		t.Fatalf("Expected import to be committed on approval with status code %d but got %d: %v",
+			http.StatusOK, approveStatusCode, executed)
+	}
+	if againStatusCode != http.StatusConflict {
+		t.Errorf("Expected second approval to get status code %d but got %d", http.StatusConflict, againStatusCode)
+	}
+	if duplicateStatusCode != http.StatusUnprocessableEntity || !duplicateImport.IsDuplicate {
+		t.Errorf("Expected duplicate import to get status code %d but got %d: %v",
+			http.StatusUnprocessableEntity, duplicateStatusCode, duplicateImport)
+	}
+	for _, account := range accounts {
+		if account.AccountId == seededAccountId && account.Amount != seededAccountAmount+50 {
+			t.Errorf("Expected balance %.2f after import but got %.2f", seededAccountAmount+50, account.Amount)
+		}
+	}
+}
+
+func TestApp_adminTransaction_above_approvalThreshold_waits_for_secondAdmin_and_expires(t *testing.T) {
+	//Arrange
+	teardown := setupAppTest(t)
+	defer teardown()
+
+	transactionPath := "/customers/" + seededCustomerId + "/account/" + seededAccountId
+	var approval, expiring, executed dto.ApprovalResponse
+	var small, posted dto.TransactionResponse
+	var pending []dto.ApprovalResponse
+
+	//Act
+	requestStatusCode := serveAs(t, dummyAdminToken, http.MethodPost, transactionPath,
+		`{"transaction_type": "deposit", "amount": 6000}`, &approval)
+	smallStatusCode := serveAs(t, dummyAdminToken, http.MethodPost, transactionPath,
+		`{"transaction_type": "deposit", "amount": 100}`, &small)
+	selfApproveStatusCode := serveAs(t, dummyAdminToken, http.MethodPost, "/approvals/"+approval.ApprovalId+"/approve", "", &dto.ApprovalResponse{})
+	approveStatusCode := serveAs(t, dummySecondAdminToken, http.MethodPost, "/approvals/"+approval.ApprovalId+"/approve", "", &executed)
+	againStatusCode := serveAs(t, dummySecondAdminToken, http.MethodPost, "/approvals/"+approval.ApprovalId+"/approve", "", &dto.ApprovalResponse{})
+
+	serveAs(t, dummyAdminToken, http.MethodPost, transactionPath, `{"transaction_type": "deposit", "amount": 7000}`, &expiring)
+	if _, err := testDbClient.Exec("UPDATE approval_requests SET expires_on = '2006-01-01 15:04:05' WHERE approval_id = ?",
+		expiring.ApprovalId); err != nil {
+		t.Fatal("Error during testing setup: " + err.Error())
+	}
+	serveAs(t, dummySecondAdminToken, http.MethodGet, "/approvals", "", &pending)
+	expiredStatusCode := serveAs(t, dummySecondAdminToken, http.MethodPost, "/approvals/"+expiring.ApprovalId+"/approve", "", &dto.ApprovalResponse{})
+
+	//Assert
+	if requestStatusCode != http.StatusAccepted || approval.Operation != domain.ApprovalOperationTransaction ||
+		approval.ExpiresOn != "2006-01-03 15:04:05" {
+		t.Fatalf("Expected transaction to wait for approval for a day with status code %d but got %d: %v",
+			http.StatusAccepted, requestStatusCode, approval)
+	}
+	if smallStatusCode != http.StatusCreated || small.Balance != seededAccountAmount+100 {
+		t.Errorf("Expected transaction under the threshold to be made right away but got status code %d and %v",
+			smallStatusCode, small)
+	}
+	if selfApproveStatusCode != http.StatusForbidden {
+		t.Errorf("Expected approval by the requesting admin to get status code %d but got %d",
+			http.StatusForbidden, selfApproveStatusCode)
+	}
+	if err := json.Unmarshal(executed.Result, &posted); err != nil {
+		t.Fatal("Expected transaction as result but got error while decoding it: " + err.Error())
+	}
+	if approveStatusCode != http.StatusOK || executed.Status != dto.ApprovalStatusExecuted || executed.DecidedBy != "admin2" ||
+		posted.Balance != seededAccountAmount+100+6000 {
+		t.Errorf("Expected transaction to be made on approval by the second admin but got status code %d and %v",
+			approveStatusCode, executed)
+	}
+	if againStatusCode != http.StatusConflict {
+		t.Errorf("Expected second approval to get status code %d but got %d", http.StatusConflict, againStatusCode)
+	}
+	if len(pending) != 0 {
+		t.Errorf("Expected no pending approval requests after expiry but got %v", pending)
+	}
+	if expiredStatusCode != http.StatusConflict {
+		t.Errorf("Expected approval of expired request to get status code %d but got %d", http.StatusConflict, expiredStatusCode)
+	}
+	var balance float64
+	if err := testDbClient.Get(&balance, "SELECT amount FROM accounts WHERE account_id = ?", seededAccountId); err != nil {
+		t.Fatal("Expected no error but got error while retrieving balance: " + err.Error())
+	}
+	if balance != seededAccountAmount+100+6000 {
+		t.Errorf("Expected balance %.2f with the expired transaction not made but got %.2f", seededAccountAmount+100+6000, balance)
+	}
+}
+
+func TestApp_NewAccount_and_NewTransaction_publish_events_inOrder_once(t *testing.T) {
+	//Arrange
+	teardown := setupAppTest(t)
+	defer teardown()
+
+	publisher := domain.NewChannelEventPublisher(10)
+	relay := service.NewOutboxRelay(domain.NewOutboxRepositoryDb(testDbClient), publisher, clock.StaticClock{})
+	var newAccount dto.NewAccountResponse
+	var transaction dto.TransactionResponse
+
+	//Act
+	serve(t, http.MethodPost, "/customers/"+seededCustomerId+"/account/new",
+		`{"account_type": "checking", "amount": 5000}`, &newAccount)
+	serve(t, http.MethodPost, "/customers/"+seededCustomerId+"/account/"+newAccount.AccountId,
+		`{"transaction_type": "deposit", "amount": 250}`, &transaction)
+	firstCount, firstErr := relay.RelayPending()
+	secondCount, secondErr := relay.RelayPending()
+
+	//Assert
+	if firstErr != nil || secondErr != nil {
+		t.Fatal("Expected no error but got error while relaying events")
+	}
+	if firstCount != 2 || secondCount != 0 {
+		t.Fatalf("Expected 2 events to be published once but got %d then %d", firstCount, secondCount)
+	}
+	opened, posted := <-publisher.Events(), <-publisher.Events()
+	if opened.EventType != domain.EventTypeAccountOpened || opened.AccountId != newAccount.AccountId {
+		t.Errorf("Expected AccountOpened event for account %s but got %v", newAccount.AccountId, opened)
+	}
+	var payload dto.TransactionPostedPayload
+	if err := json.Unmarshal([]byte(posted.Payload), &payload); err != nil {
+		t.Fatal("Expected JSON payload but got error while decoding it: " + err.Error())
+	}
+	if posted.EventType != domain.EventTypeTransactionPosted || payload.TransactionId != transaction.TransactionId ||
+		payload.Balance != 5250 {
+		t.Errorf("Expected TransactionPosted event for transaction %s with new balance 5250 but got %v",
+			transaction.TransactionId, posted)
+	}
+}
+
+func TestApp_NewTransaction_is_delivered_once_to_subscribedWebhook(t *testing.T) {
+	//Arrange
+	teardown := setupAppTest(t)
+	defer teardown()
+
+	var signatures []string
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		timestamp := r.Header.Get(service.WebhookHeaderTimestamp)
+		if r.Header.Get(service.WebhookHeaderSignature) == "sha256="+domain.SignWebhookPayload(dummyWebhookSecret, timestamp, string(body)) {
+			signatures = append(signatures, r.Header.Get(service.WebhookHeaderSignature))
+		}
+	}))
+	defer receiver.Close()
+
+	webhookRepo := domain.NewWebhookRepositoryDb(testDbClient)
+	webhookPublisher := domain.NewWebhookEventPublisher(webhookRepo, clock.StaticClock{})
+	channelPublisher := domain.NewChannelEventPublisher(10)
+	relay := service.NewOutboxRelay(domain.NewOutboxRepositoryDb(testDbClient),
+		domain.NewMultiEventPublisher(webhookPublisher, channelPublisher), clock.StaticClock{})
+	dispatcher := service.NewWebhookDispatcher(webhookRepo, receiver.Client(), clock.StaticClock{})
+	var approval, executed dto.ApprovalResponse
+	var subscription dto.WebhookSubscriptionResponse
+	var transaction dto.TransactionResponse
+	var deliveries []dto.WebhookDeliveryResponse
+
+	//Act
+	serveAs(t, dummyAdminToken, http.MethodPost, "/webhooks",
+		`{"url": "`+receiver.URL+`", "event_types": ["TransactionPosted"], "secret": "`+dummyWebhookSecret+`"}`, &approval)
+	serveAs(t, dummySecondAdminToken, http.MethodPost, "/approvals/"+approval.ApprovalId+"/approve", "", &executed)
+	if err := json.Unmarshal(executed.Result, &subscription); err != nil {
+		t.Fatal("Expected subscription as result of approval but got error while decoding it: " + err.Error())
+	}
+	serve(t, http.MethodPost, "/customers/"+seededCustomerId+"/account/"+seededAccountId,
+		`{"transaction_type": "deposit", "amount": 250}`, &transaction)
+	_, relayErr := relay.RelayPending()
+	republishErr := webhookPublisher.Publish(<-channelPublisher.Events()) //as if the event could not be marked published
+	count, dispatchErr := dispatcher.DispatchDue()
+	statusCode := serve(t, http.MethodGet, "/webhooks/"+subscription.SubscriptionId+"/deliveries", "", &deliveries)
+
+	//Assert
+	if relayErr != nil || republishErr != nil || dispatchErr != nil {
+		t.Fatal("Expected no error but got error while relaying or dispatching events")
+	}
+	if count != 1 || len(signatures) != 1 {
+		t.Fatalf("Expected 1 signed delivery but got %d attempted and %d signed", count, len(signatures))
+	}
+	if statusCode != http.StatusOK || len(deliveries) != 1 || deliveries[0].Status != dto.WebhookDeliveryStatusSucceeded {
+		t.Errorf("Expected 1 succeeded delivery but got status code %d and %v", statusCode, deliveries)
+	}
+}
+
+func TestApp_NewTransaction_alerts_customer_in_inbox_as_set_in_alertRules(t *testing.T) {
+	//Arrange
+	teardown := setupAppTest(t)
+	defer teardown()
+
+	alertRulesPath := "/customers/" + seededCustomerId + "/account/" + seededAccountId + "/alerts"
+	var lowBalanceRule, largeWithdrawalRule dto.AlertRuleResponse
+	var transaction dto.TransactionResponse
+	var rules []dto.AlertRuleResponse
+	var alerts []dto.AlertResponse
+
+	//Act
+	serve(t, http.MethodPost, alertRulesPath, `{"rule_type": "low_balance", "threshold": 6900}`, &lowBalanceRule)
+	serve(t, http.MethodPost, alertRulesPath, `{"rule_type": "large_withdrawal", "threshold": 500}`, &largeWithdrawalRule)
+	serve(t, http.MethodPost, "/customers/"+seededCustomerId+"/account/"+seededAccountId,
+		`{"transaction_type": "withdrawal", "amount": 200}`, &transaction)
+	serve(t, http.MethodGet, alertRulesPath, "", &rules)
+	statusCode := serve(t, http.MethodGet, "/customers/"+seededCustomerId+"/alerts", "", &alerts)
+
+	//Assert
+	if len(rules) != 2 || rules[0].RuleId != lowBalanceRule.RuleId || rules[1].RuleId != largeWithdrawalRule.RuleId {
+		t.Fatalf("Expected the 2 new alert rules but got %v", rules)
+	}
+	if transaction.Balance != seededAccountAmount-200 {
+		t.Fatalf("Expected new balance %f but got %f", seededAccountAmount-200, transaction.Balance)
+	}
+	if statusCode != http.StatusOK || len(alerts) != 1 || alerts[0].RuleType != dto.AlertRuleTypeLowBalance {
+		t.Errorf("Expected only a low balance alert but got status code %d and %v", statusCode, alerts)
+	}
+}
+
+func TestApp_NewTransaction_screened_by_fraudRules_with_decisions_persisted(t *testing.T) {
+	//Arrange
+	teardown := setupAppTest(t)
+	defer teardown()
+
+	transactionPath := "/customers/" + seededCustomerId + "/account/" + seededAccountId
+	statusCodes := make([]int, 0)
+	var transaction dto.TransactionResponse
+	var decisions []string
+
+	//Act
+	statusCodes = append(statusCodes, serve(t, http.MethodPost, transactionPath,
+		`{"transaction_type": "deposit", "amount": 9600}`, &transaction))
+	for i := 0; i < 6; i++ {
+		statusCodes = append(statusCodes, serve(t, http.MethodPost, transactionPath,
+			`{"transaction_type": "withdrawal", "amount": 10}`, &transaction))
+	}
+	err := testDbClient.Select(&decisions, "SELECT decision FROM fraud_decisions WHERE account_id = ? ORDER BY decision_id",
+		seededAccountId)
+
+	//Assert
+	expectedStatusCodes := []int{http.StatusAccepted, http.StatusCreated, http.StatusCreated, http.StatusCreated,
+		http.StatusCreated, http.StatusCreated, http.StatusForbidden}
+	if !reflect.DeepEqual(statusCodes, expectedStatusCodes) {
+		t.Errorf("Expected status codes %v but got %v", expectedStatusCodes, statusCodes)
+	}
+	if err != nil {
+		t.Fatal("Expected no error but got error while retrieving fraud decisions: " + err.Error())
+	}
+	expectedDecisions := []string{domain.FraudDecisionReview, domain.FraudDecisionAllow, domain.FraudDecisionAllow,
+		domain.FraudDecisionAllow, domain.FraudDecisionAllow, domain.FraudDecisionAllow, domain.FraudDecisionBlock}
+	if !reflect.DeepEqual(decisions, expectedDecisions) {
+		t.Errorf("Expected fraud decisions %v but got %v", expectedDecisions, decisions)
+	}
+}
+
+func TestApp_heldWithdrawal_reserves_funds_until_approved_by_admin(t *testing.T) {
+	//Arrange
+	teardown := setupAppTest(t)
+	defer teardown()
+
+	transactionPath := "/customers/" + seededCustomerId + "/account/" + seededAccountId
+	var held, overdraw, approved dto.TransactionReviewResponse
+	var heldTransaction dto.TransactionResponse
+	var queue []dto.TransactionReviewResponse
+	var pendingList, postedList []dto.AccountTransactionResponse
+
+	//Act
+	serve(t, http.MethodPost, transactionPath, `{"transaction_type": "deposit", "amount": 5000}`, &heldTransaction)
+	heldStatusCode := serve(t, http.MethodPost, transactionPath, `{"transaction_type": "withdrawal", "amount": 9600}`, &heldTransaction)
+	overdrawStatusCode := serve(t, http.MethodPost, transactionPath, `{"transaction_type": "withdrawal", "amount": 3000}`, &overdraw)
+	serve(t, http.MethodGet, "/transactions/reviews", "", &queue)
+	serve(t, http.MethodGet, transactionPath+"/transactions", "", &pendingList)
+	approveStatusCode := serve(t, http.MethodPost, "/transactions/reviews/"+heldTransaction.ReviewId+"/approve",
+		`{"comment": "Customer confirmed by phone"}`, &approved)
+	againStatusCode := serve(t, http.MethodPost, "/transactions/reviews/"+heldTransaction.ReviewId+"/reject",
+		`{"comment": "Too late"}`, &held)
+	serve(t, http.MethodGet, transactionPath+"/transactions", "", &postedList)
+
+	//Assert
+	if heldStatusCode != http.StatusAccepted || heldTransaction.Status != dto.TransactionStatusPendingReview ||
+		heldTransaction.Balance != seededAccountAmount+5000 {
+		t.Fatalf("Expected withdrawal near the limit to be held for review but got status code %d and %v",
+			heldStatusCode, heldTransaction)
+	}
+	if overdrawStatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("Expected withdrawal of reserved funds to be refused but got status code %d", overdrawStatusCode)
+	}
+	if len(queue) != 1 || queue[0].ReviewId != heldTransaction.ReviewId {
+		t.Errorf("Expected held withdrawal in review queue but got %v", queue)
+	}
+	if len(pendingList) == 0 || pendingList[len(pendingList)-1].Status != dto.TransactionStatusPendingReview {
+		t.Errorf("Expected held withdrawal last in transaction list as pending review but got %v", pendingList)
+	}
+	if approveStatusCode != http.StatusOK || approved.Status != dto.TransactionStatusPosted {
+		t.Errorf("Expected approval to post held withdrawal but got status code %d and %v", approveStatusCode, approved)
+	}
+	if againStatusCode != http.StatusConflict {
+		t.Errorf("Expected rejection of approved review to conflict but got status code %d", againStatusCode)
+	}
+	if len(postedList) != len(pendingList) || postedList[len(postedList)-1].Status != dto.TransactionStatusPosted ||
+		postedList[len(postedList)-1].Amount != 9600 {
+		t.Errorf("Expected held withdrawal to be listed as posted but got %v", postedList)
+	}
+	var balance float64
+	if err := testDbClient.Get(&balance, "SELECT amount FROM accounts WHERE account_id = ?", seededAccountId); err != nil {
+		t.Fatal("Expected no error but got error while retrieving balance: " + err.Error())
+	}
+	if balance != seededAccountAmount+5000-9600 {
+		t.Errorf("Expected balance %f after approval but got %f", seededAccountAmount+5000-9600, balance)
+	}
+}
+
+func TestApp_hold_reduces_availableBalance_until_captured_or_released(t *testing.T) {
+	//Arrange
+	teardown := setupAppTest(t)
+	defer teardown()
+
+	accountPath := "/customers/" + seededCustomerId + "/account/" + seededAccountId
+	var held, cheque, released, captured dto.HoldResponse
+	var overdraw map[string]string
+	var heldAccounts, capturedAccounts []dto.AccountResponse
+	var holds []dto.HoldResponse
+
+	//Act
+	serveAs(t, dummyAdminToken, http.MethodPost, accountPath+"/holds", `{"amount": 6000, "reason": "Card authorization"}`, &held)
+	serveAs(t, dummyAdminToken, http.MethodPost, accountPath+"/holds", `{"amount": 500, "reason": "Cheque clearing"}`, &cheque)
+	serve(t, http.MethodGet, "/customers/"+seededCustomerId, "", &heldAccounts)
+	overdrawStatusCode := serve(t, http.MethodPost, accountPath, `{"transaction_type": "withdrawal", "amount": 1000}`, &overdraw)
+	serveAs(t, dummyAdminToken, http.MethodPost, "/holds/"+cheque.HoldId+"/release", "", &released)
+	captureStatusCode := serveAs(t, dummyAdminToken, http.MethodPost, "/holds/"+held.HoldId+"/capture", "", &captured)
+	serve(t, http.MethodGet, "/customers/"+seededCustomerId, "", &capturedAccounts)
+	serve(t, http.MethodGet, accountPath+"/holds", "", &holds)
+
+	//Assert
+	findAccount := func(accounts []dto.AccountResponse) dto.AccountResponse {
+		for _, a := range accounts {
+			if a.AccountId == seededAccountId {
+				return a
+			}
+		}
+		t.Fatalf("Expected account %s to be listed but got %v", seededAccountId, accounts)
+		return dto.AccountResponse{}
+	}
+	if account := findAccount(heldAccounts); account.Amount != seededAccountAmount || account.AvailableAmount != seededAccountAmount-6500 {
+		t.Errorf("Expected ledger balance %f and available balance %f while on hold but got %v",
+			seededAccountAmount, seededAccountAmount-6500, account)
+	}
+	if overdrawStatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("Expected withdrawal of funds on hold to be refused but got status code %d", overdrawStatusCode)
+	}
+	if released.Status != dto.HoldStatusReleased {
+		t.Errorf("Expected cheque hold to be released but got %v", released)
+	}
+	if captureStatusCode != http.StatusOK || captured.Status != dto.HoldStatusCaptured || captured.TransactionId == "" {
+		t.Errorf("Expected hold to be captured by a withdrawal but got status code %d and %v", captureStatusCode, captured)
+	}
+	if account := findAccount(capturedAccounts); account.Amount != seededAccountAmount-6000 || account.AvailableAmount != seededAccountAmount-6000 {
+		t.Errorf("Expected ledger and available balance %f after capture but got %v", seededAccountAmount-6000, account)
+	}
+	if len(holds) != 2 || holds[0].TransactionId != captured.TransactionId || holds[1].Status != dto.HoldStatusReleased {
+		t.Errorf("Expected captured and released holds to be listed but got %v", holds)
+	}
+}
+
+func TestApp_overdraft_lets_checkingAccount_go_below_zero_down_to_limit_and_charges_fee_once(t *testing.T) {
+	//Arrange
+	teardown := setupAppTest(t)
+	defer teardown()
+
+	var newAccount dto.NewAccountResponse
+	var granted, overdrawn, repaid dto.OverdraftResponse
+	var beyondLimit, savingRefusal map[string]string
+	var withdrawal, deposit dto.TransactionResponse
+	var report dto.ReconciliationReportResponse
+
+	//Act
+	serve(t, http.MethodPost, "/customers/"+seededCustomerId+"/account/new",
+		`{"account_type": "checking", "amount": 5000}`, &newAccount)
+	accountPath := "/customers/" + seededCustomerId + "/account/" + newAccount.AccountId
+	if _, err := testDbClient.Exec("UPDATE accounts SET opening_date = '2006-01-01 15:04:05' WHERE account_id = ?",
+		newAccount.AccountId); err != nil { //past the window in which withdrawals from new accounts are held for review
+		t.Fatal("Error during testing setup: " + err.Error())
+	}
+	grantStatusCode := serveAs(t, dummyAdminToken, http.MethodPost, accountPath+"/overdraft", `{"overdraft_limit": 1000}`, &granted)
+	savingStatusCode := serveAs(t, dummyAdminToken, http.MethodPost,
+		"/customers/"+seededCustomerId+"/account/"+seededAccountId+"/overdraft", `{"overdraft_limit": 1000}`, &savingRefusal)
+	beyondLimitStatusCode := serve(t, http.MethodPost, accountPath, `{"transaction_type": "withdrawal", "amount": 6000.01}`, &beyondLimit)
+	serve(t, http.MethodPost, accountPath, `{"transaction_type": "withdrawal", "amount": 5500}`, &withdrawal)
+	serve(t, http.MethodGet, accountPath+"/overdraft", "", &overdrawn)
+	serve(t, http.MethodPost, accountPath, `{"transaction_type": "deposit", "amount": 1000}`, &deposit)
+	serve(t, http.MethodGet, accountPath+"/overdraft", "", &repaid)
+	serve(t, http.MethodGet, "/reconciliation", "", &report)
+
+	//Assert
+	if grantStatusCode != http.StatusOK || granted.OverdraftLimit != 1000 || granted.AvailableOverdraft != 1000 {
+		t.Fatalf("Expected overdraft limit of 1000 to be granted but got status code %d and %v", grantStatusCode, granted)
+	}
+	if savingStatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("Expected overdraft on saving account to be refused but got status code %d", savingStatusCode)
+	}
+	if beyondLimitStatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("Expected withdrawal beyond overdraft limit to be refused but got status code %d", beyondLimitStatusCode)
+	}
+	if withdrawal.Balance != -500 {
+		t.Errorf("Expected withdrawal to take balance to -500 but got %v", withdrawal)
+	}
+	if overdrawn.OverdrawnAmount != 525 || overdrawn.AvailableOverdraft != 475 || overdrawn.FeeCharged != defaultOverdraftFee ||
+		overdrawn.OverdrawnSince != dummyDate {
+		t.Errorf("Expected account overdrawn by 525 including the fee of %.2f since %s but got %v",
+			defaultOverdraftFee, dummyDate, overdrawn)
+	}
+	if deposit.Balance != 475 || repaid.OverdrawnAmount != 0 || repaid.OverdrawnSince != "" || repaid.AvailableOverdraft != 1000 {
+		t.Errorf("Expected deposit to repay the overdraft but got %v and %v", deposit, repaid)
+	}
+	if report.AccountsChecked != 5 || len(report.Mismatches) != 0 {
+		t.Errorf("Expected 5 accounts checked with no mismatches after fee was charged but got %v", report)
+	}
+}
+
+func TestApp_fees_charges_withdrawalFee_after_freeWithdrawals_linked_to_withdrawal(t *testing.T) {
+	//Arrange
+	teardown := setupAppTest(t)
+	defer teardown()
+
+	var newAccount dto.NewAccountResponse
+	var schedule dto.FeeScheduleResponse
+	var schedules []dto.FeeScheduleResponse
+	var backdatedRefusal, deleteRefusal map[string]string
+	var freeWithdrawal, chargedWithdrawal dto.TransactionResponse
+	var transactions []dto.AccountTransactionResponse
+
+	//Act
+	backdatedStatusCode := serveAs(t, dummyAdminToken, http.MethodPost, "/fees/schedules",
+		`{"account_type": "checking", "monthly_fee": 10, "effective_from": "2006-01-01"}`, &backdatedRefusal)
+	createStatusCode := serveAs(t, dummyAdminToken, http.MethodPost, "/fees/schedules",
+		`{"account_type": "checking", "monthly_fee": 10, "free_withdrawals": 1, "withdrawal_fee": 2, "effective_from": "2006-01-02"}`, &schedule)
+	deleteStatusCode := serveAs(t, dummyAdminToken, http.MethodDelete, "/fees/schedules/"+schedule.ScheduleId, "", &deleteRefusal)
+	serveAs(t, dummyAdminToken, http.MethodGet, "/fees/schedules", "", &schedules)
+	serve(t, http.MethodPost, "/customers/"+seededCustomerId+"/account/new",
+		`{"account_type": "checking", "amount": 5000}`, &newAccount)
+	accountPath := "/customers/" + seededCustomerId + "/account/" + newAccount.AccountId
+	if _, err := testDbClient.Exec("UPDATE accounts SET opening_date = '2006-01-01 15:04:05' WHERE account_id = ?",
+		newAccount.AccountId); err != nil { //past the window in which withdrawals from new accounts are held for review
+		t.Fatal("Error during testing setup: " + err.Error())
+	}
+	serve(t, http.MethodPost, accountPath, `{"transaction_type": "withdrawal", "amount": 100}`, &freeWithdrawal)
+	serve(t, http.MethodPost, accountPath, `{"transaction_type": "withdrawal", "amount": 100}`, &chargedWithdrawal)
+	serve(t, http.MethodGet, accountPath+"/transactions", "", &transactions)
+
+	//Assert
+	if backdatedStatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("Expected backdated fee schedule to be refused but got status code %d", backdatedStatusCode)
+	}
+	if createStatusCode != http.StatusCreated || schedule.Status != dto.FeeScheduleStatusInEffect || schedule.CreatedBy != "admin" {
+		t.Fatalf("Expected fee schedule in effect to be created but got status code %d and %v", createStatusCode, schedule)
+	}
+	if deleteStatusCode != http.StatusConflict {
+		t.Errorf("Expected deletion of fee schedule in effect to be refused but got status code %d", deleteStatusCode)
+	}
+	if len(schedules) != 1 || schedules[0].ScheduleId != schedule.ScheduleId {
+		t.Errorf("Expected the created fee schedule to be listed but got %v", schedules)
+	}
+	if freeWithdrawal.Balance != 4900 || chargedWithdrawal.Balance != 4800 {
+		t.Errorf("Expected withdrawals to take balance to 4900 and 4800 but got %v and %v", freeWithdrawal, chargedWithdrawal)
+	}
+	if len(transactions) != 3 {
+		t.Fatalf("Expected 2 withdrawals and 1 fee but got %v", transactions)
+	}
+	fee := transactions[2]
+	if fee.TransactionType != dto.TransactionTypeFee || fee.Amount != 2 || fee.RelatedTransactionId != chargedWithdrawal.TransactionId {
+		t.Errorf("Expected fee of 2 linked to withdrawal %s but got %v", chargedWithdrawal.TransactionId, fee)
+	}
+}
+
+func TestApp_reversals_post_compensating_transactions_visible_in_history(t *testing.T) {
+	//Arrange
+	teardown := setupAppTest(t)
+	defer teardown()
+
+	var newAccount dto.NewAccountResponse
+	var deposit, withdrawal dto.TransactionResponse
+	var refusal, doubleRefusal, missingRefusal map[string]string
+	var reversal, chainedReversal dto.TransactionReversalResponse
+	var approval, executed dto.ApprovalResponse
+	var transactions []dto.AccountTransactionResponse
+
+	serve(t, http.MethodPost, "/customers/"+seededCustomerId+"/account/new",
+		`{"account_type": "saving", "amount": 5000}`, &newAccount)
+	accountPath := "/customers/" + seededCustomerId + "/account/" + newAccount.AccountId
+	if _, err := testDbClient.Exec("UPDATE accounts SET opening_date = '2006-01-01 15:04:05' WHERE account_id = ?",
+		newAccount.AccountId); err != nil { //past the window in which withdrawals from new accounts are held for review
+		t.Fatal("Error during testing setup: " + err.Error())
+	}
+	serve(t, http.MethodPost, accountPath, `{"transaction_type": "deposit", "amount": 300}`, &deposit)
+	serve(t, http.MethodPost, accountPath, `{"transaction_type": "withdrawal", "amount": 5200}`, &withdrawal)
+	reversePath := "/transactions/" + deposit.TransactionId + "/reverse"
+
+	//Act
+	refusedStatusCode := serveAs(t, dummyAdminToken, http.MethodPost, reversePath, `{"reason": "Duplicate deposit"}`, &refusal)
+	forcedStatusCode := serveAs(t, dummyAdminToken, http.MethodPost, reversePath,
+		`{"reason": "Duplicate deposit", "force": true}`, &approval)
+	approveStatusCode := serveAs(t, dummySecondAdminToken, http.MethodPost, "/approvals/"+approval.ApprovalId+"/approve",
+		"", &executed)
+	if err := json.Unmarshal(executed.Result, &reversal); err != nil {
+		t.Fatal("Expected reversal as result of approval but got error while decoding it: " + err.Error())
+	}
+	doubleStatusCode := serveAs(t, dummyAdminToken, http.MethodPost, reversePath,
+		`{"reason": "Duplicate deposit", "force": true}`, &doubleRefusal)
+	chainedStatusCode := serveAs(t, dummyAdminToken, http.MethodPost, "/transactions/"+reversal.ReversalTransactionId+"/reverse",
+		`{"reason": "Deposit was not a duplicate"}`, &chainedReversal)
+	missingStatusCode := serveAs(t, dummyAdminToken, http.MethodPost, "/transactions/999999/reverse",
+		`{"reason": "Duplicate deposit"}`, &missingRefusal)
+	serve(t, http.MethodGet, accountPath+"/transactions", "", &transactions)
+
+	//Assert
+	if refusedStatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("Expected reversal overdrawing the account to be refused but got status code %d", refusedStatusCode)
+	}
+	if forcedStatusCode != http.StatusAccepted || approval.Operation != domain.ApprovalOperationTransactionReversal {
+		t.Fatalf("Expected forced reversal to wait for approval but got status code %d and %v", forcedStatusCode, approval)
+	}
+	if approveStatusCode != http.StatusOK || reversal.TransactionType != dto.TransactionTypeWithdrawal ||
+		reversal.Balance != -200 || !reversal.Forced || reversal.ReversedBy != "admin" {
+		t.Fatalf("Expected approved reversal to withdraw the deposit but got status code %d and %v", approveStatusCode, reversal)
+	}
+	if doubleStatusCode != http.StatusConflict {
+		t.Errorf("Expected second reversal of the deposit to be refused but got status code %d", doubleStatusCode)
+	}
+	if chainedStatusCode != http.StatusCreated || chainedReversal.TransactionType != dto.TransactionTypeDeposit ||
+		chainedReversal.Balance != 100 { //no overdraft fee, as a saving account has no overdraft
+		t.Errorf("Expected reversal of the reversal to deposit the amount again but got status code %d and %v",
+			chainedStatusCode, chainedReversal)
+	}
+	if missingStatusCode != http.StatusNotFound {
+		t.Errorf("Expected reversal of non-existent transaction to be refused but got status code %d", missingStatusCode)
+	}
+	if len(transactions) != 4 {
+		t.Fatalf("Expected deposit, withdrawal and 2 reversals but got %v", transactions)
+	}
+	reversedDeposit, firstReversal, secondReversal := transactions[0], transactions[2], transactions[3]
+	if reversedDeposit.Status != dto.TransactionStatusReversed || reversedDeposit.ReversedByTransactionId != firstReversal.TransactionId {
+		t.Errorf("Expected deposit reversed by transaction %s but got %v", firstReversal.TransactionId, reversedDeposit)
+	}
+	if firstReversal.RelatedTransactionId != deposit.TransactionId || firstReversal.ReversedByTransactionId != secondReversal.TransactionId {
+		t.Errorf("Expected reversal of deposit %s reversed by transaction %s but got %v", deposit.TransactionId,
+			secondReversal.TransactionId, firstReversal)
+	}
+	if secondReversal.Status != dto.TransactionStatusPosted || secondReversal.RelatedTransactionId != firstReversal.TransactionId {
+		t.Errorf("Expected posted reversal of transaction %s but got %v", firstReversal.TransactionId, secondReversal)
+	}
+}
+
+func TestApp_transactions_keep_description_reference_and_category_which_customer_can_change(t *testing.T) {
+	//Arrange
+	teardown := setupAppTest(t)
+	defer teardown()
+
+	var deposit dto.TransactionResponse
+	var recategorized dto.AccountTransactionResponse
+	var refusal dto.ValidationErrorResponse
+	var missingRefusal map[string]string
+	var transactions []dto.AccountTransactionResponse
+
+	accountPath := "/customers/" + seededCustomerId + "/account/" + seededAccountId
+	serve(t, http.MethodPost, accountPath, `{"transaction_type": "deposit", "amount": 120, `+
+		`"description": "Dinner split", "reference": "INV-0042", "category": "other"}`, &deposit)
+	transactionPath := accountPath + "/transactions/" + deposit.TransactionId
+
+	//Act
+	refusedStatusCode := serve(t, http.MethodPatch, transactionPath, `{"category": "gambling"}`, &refusal)
+	statusCode := serve(t, http.MethodPatch, transactionPath, `{"category": "dining"}`, &recategorized)
+	missingStatusCode := serve(t, http.MethodPatch, accountPath+"/transactions/999999", `{"category": "dining"}`,
+		&missingRefusal)
+	serve(t, http.MethodGet, accountPath+"/transactions", "", &transactions)
+
+	//Assert
+	if deposit.Description != "Dinner split" || deposit.Reference != "INV-0042" || deposit.Category != dto.TransactionCategoryOther {
+		t.Errorf("Expected deposit to keep its details but got %v", deposit)
+	}
+	if refusedStatusCode != http.StatusUnprocessableEntity || len(refusal.Errors) != 1 || refusal.Errors[0].Field != "category" {
+		t.Errorf("Expected unknown category to be refused but got status code %d and %v", refusedStatusCode, refusal)
+	}
+	if statusCode != http.StatusOK || recategorized.Category != dto.TransactionCategoryDining {
+		t.Errorf("Expected deposit to be moved to category dining but got status code %d and %v", statusCode, recategorized)
+	}
+	if missingStatusCode != http.StatusNotFound {
+		t.Errorf("Expected recategorizing of non-existent transaction to be refused but got status code %d", missingStatusCode)
+	}
+	if len(transactions) == 0 {
+		t.Fatal("Expected the deposit in the transaction history but got none")
+	}
+	listed := transactions[len(transactions)-1]
+	if listed.TransactionId != deposit.TransactionId || listed.Description != "Dinner split" ||
+		listed.Reference != "INV-0042" || listed.Category != dto.TransactionCategoryDining {
+		t.Errorf("Expected deposit %s in category dining with its details in history but got %v", deposit.TransactionId, listed)
+	}
+}
+
+func TestApp_analytics_sum_transactions_of_all_accounts_of_customer_by_month_category_and_type(t *testing.T) {
+	//Arrange
+	teardown := setupAppTest(t)
+	defer teardown()
+
+	var deposit, rent, lunch dto.TransactionResponse
+	var analytics dto.AnalyticsResponse
+	var refusal map[string]string
+
+	customerPath := "/customers/" + seededCustomerId
+	serve(t, http.MethodPost, customerPath+"/account/"+seededAccountId,
+		`{"transaction_type": "deposit", "amount": 1000, "category": "income"}`, &deposit)
+	serve(t, http.MethodPost, customerPath+"/account/"+seededAccountId,
+		`{"transaction_type": "withdrawal", "amount": 800, "description": "Rent", "category": "bills"}`, &rent)
+	serve(t, http.MethodPost, customerPath+"/account/95473", `{"transaction_type": "withdrawal", "amount": 40}`, &lunch)
+	if _, err := testDbClient.Exec("UPDATE transactions SET transaction_date = '2005-12-24 12:00:00' WHERE transaction_id = ?",
+		rent.TransactionId); err != nil {
+		t.Fatal("Error during testing setup: " + err.Error())
+	}
+
+	//Act
+	statusCode := serve(t, http.MethodGet, customerPath+"/analytics?from=2005-11&to=2006-01", "", &analytics)
+	refusedStatusCode := serve(t, http.MethodGet, customerPath+"/analytics?from=2006-02&to=2006-01", "", &refusal)
+
+	//Assert
+	if statusCode != http.StatusOK {
+		t.Fatalf("Expected status code %d but got %d", http.StatusOK, statusCode)
+	}
+	if analytics.Totals.Income != 1000 || analytics.Totals.Spending != 840 || analytics.Totals.TransactionCount != 3 ||
+		analytics.Totals.AverageSpending != 420 {
+		t.Errorf("Expected income of 1000 and spending of 840 over 3 transactions but got %v", analytics.Totals)
+	}
+	if len(analytics.ByMonth) != 3 || analytics.ByMonth[1].Spending != 800 || analytics.ByMonth[2].Spending != 40 ||
+		*analytics.ByMonth[2].SpendingChange != -760 || *analytics.ByMonth[2].SpendingChangePercent != -95 {
+		t.Errorf("Expected spending of 800 in December and 40 in January but got %v", analytics.ByMonth)
+	}
+	expectedCategories := []string{dto.AnalyticsUncategorized, dto.TransactionCategoryBills, dto.TransactionCategoryIncome}
+	if len(analytics.ByCategory) != len(expectedCategories) {
+		t.Fatalf("Expected categories %v but got %v", expectedCategories, analytics.ByCategory)
+	}
+	for i, category := range expectedCategories {
+		if analytics.ByCategory[i].Category != category {
+			t.Errorf("Expected categories %v but got %v", expectedCategories, analytics.ByCategory)
+		}
+	}
+	if len(analytics.ByType) != 2 || analytics.ByType[1].TransactionType != dto.TransactionTypeWithdrawal ||
+		analytics.ByType[1].AverageAmount != 420 {
+		t.Errorf("Expected deposits and withdrawals averaging 420 but got %v", analytics.ByType)
+	}
+	if len(analytics.TopTransactions) != 2 || analytics.TopTransactions[0].TransactionId != rent.TransactionId ||
+		analytics.TopTransactions[1].AccountId != "95473" {
+		t.Errorf("Expected rent then the withdrawal from account 95473 as top transactions but got %v", analytics.TopTransactions)
+	}
+	if refusedStatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("Expected analytics from after the last month to be refused but got status code %d", refusedStatusCode)
+	}
+}
+
+func TestApp_payees_receive_confirmed_transfers_and_every_change_is_audited(t *testing.T) {
+	//Arrange
+	teardown := setupAppTest(t)
+	defer teardown()
+
+	var payee, renamed dto.PayeeResponse
+	var payees []dto.PayeeResponse
+	var transfer dto.TransferResponse
+	var wrongOwnerRefusal, unconfirmedRefusal, coolingOffRefusal, deleted map[string]string
+	var auditTrail []dto.AuditEntryResponse
+
+	payeesPath := "/customers/" + seededCustomerId + "/payees"
+	transfersPath := "/customers/" + seededCustomerId + "/account/" + seededAccountId + "/transfers"
+
+	//Act
+	wrongOwnerStatusCode := serve(t, http.MethodPost, payeesPath, `{"nickname": "Steve", "account_id": "95470", "owner_name": "Arian"}`,
+		&wrongOwnerRefusal)
+	serve(t, http.MethodPost, payeesPath, `{"nickname": "Steve", "account_id": "95470", "owner_name": " steve "}`, &payee)
+	payeePath := payeesPath + "/" + payee.PayeeId
+	unconfirmedStatusCode := serve(t, http.MethodPost, transfersPath, `{"payee_id": "`+payee.PayeeId+`", "amount": 50}`,
+		&unconfirmedRefusal)
+	coolingOffStatusCode := serve(t, http.MethodPost, transfersPath,
+		`{"payee_id": "`+payee.PayeeId+`", "amount": 1500, "confirm_payee": true}`, &coolingOffRefusal)
+	transferStatusCode := serve(t, http.MethodPost, transfersPath,
+		`{"payee_id": "`+payee.PayeeId+`", "amount": 50, "confirm_payee": true}`, &transfer)
+	serve(t, http.MethodGet, payeesPath, "", &payees)
+	serve(t, http.MethodPatch, payeePath, `{"nickname": "Steve J"}`, &renamed)
+	deleteStatusCode := serve(t, http.MethodDelete, payeePath, "", &deleted)
+	serve(t, http.MethodGet, payeesPath+"/audit", "", &auditTrail)
+
+	//Assert
+	if wrongOwnerStatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("Expected payee with wrong owner name to be refused but got status code %d", wrongOwnerStatusCode)
+	}
+	if payee.OwnerName != "Steve" || payee.Status != dto.PayeeStatusPendingConfirmation || payee.CoolingOffUntil == "" {
+		t.Errorf("Expected payee of Steve pending confirmation and cooling off but got %v", payee)
+	}
+	if unconfirmedStatusCode != http.StatusConflict {
+		t.Errorf("Expected unconfirmed first transfer to be refused but got status code %d", unconfirmedStatusCode)
+	}
+	if coolingOffStatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("Expected large transfer during cooling-off to be refused but got status code %d", coolingOffStatusCode)
+	}
+	if transferStatusCode != http.StatusCreated || transfer.ToAccountId != "95470" || transfer.Balance != seededAccountAmount-50 {
+		t.Errorf("Expected transfer to account 95470 leaving %.2f but got status code %d and %v", seededAccountAmount-50,
+			transferStatusCode, transfer)
+	}
+	var payeeBalance float64
+	if err := testDbClient.Get(&payeeBalance, "SELECT amount FROM accounts WHERE account_id = 95470"); err != nil {
+		t.Fatal("Error while reading balance of account of payee: " + err.Error())
+	}
+	if payeeBalance != 6873.23 {
+		t.Errorf("Expected account of payee to hold 6873.23 but got %.2f", payeeBalance)
+	}
+	if len(payees) != 1 || payees[0].Status != dto.PayeeStatusActive {
+		t.Errorf("Expected payee to be active after the first transfer but got %v", payees)
+	}
+	if renamed.Nickname != "Steve J" || deleteStatusCode != http.StatusOK {
+		t.Errorf("Expected payee to be renamed then deleted but got %v and status code %d", renamed, deleteStatusCode)
+	}
+	expectedActions := []string{domain.AuditActionDeleted, domain.AuditActionRenamed, domain.AuditActionConfirmed, domain.AuditActionAdded}
+	if len(auditTrail) != len(expectedActions) {
+		t.Fatalf("Expected %d audit entries but got %v", len(expectedActions), auditTrail)
+	}
+	for i, action := range expectedActions {
+		if auditTrail[i].Action != action || auditTrail[i].EntityId != payee.PayeeId || auditTrail[i].ChangedBy != seededCustomerId {
+			t.Errorf("Expected entry %d to record customer %s %s payee %s but got %v", i, seededCustomerId, action, payee.PayeeId, auditTrail[i])
+		}
+	}
+}
+
+func TestApp_preflightRequests_are_checked_against_route_methods_without_auth_server(t *testing.T) {
+	//Arrange
+	t.Setenv("FRONTEND_SERVER_DOMAIN", "banking.example.com")
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockAuthRepo = mocksDomain.NewMockAuthRepository(ctrl)
+	mockAuthRepo.EXPECT().IsAuthorized(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	router = newRouter(newStubRepositories(), mockAuthRepo, clock.StaticClock{})
+	defer func() { router = nil }()
+	logger.MuteLogger()
+
+	newPreflight := func(method string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodOptions, "/customers/2001/payees/1", nil)
+		request.Header.Set("Origin", "https://banking.example.com")
+		request.Header.Set("Access-Control-Request-Method", method)
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	//Act
+	allowed := newPreflight(http.MethodPatch)
+	notAllowed := newPreflight(http.MethodPost)
+
+	//Assert
+	if allowed.Code != http.StatusNoContent || allowed.Header().Get("Access-Control-Allow-Methods") != "PATCH, DELETE" {
+		t.Errorf("Expected PATCH to be allowed with methods \"PATCH, DELETE\" but got status code %d and %q",
+			allowed.Code, allowed.Header().Get("Access-Control-Allow-Methods"))
+	}
+	if notAllowed.Code != http.StatusForbidden {
+		t.Errorf("Expected POST not to be allowed but got status code %d", notAllowed.Code)
+	}
+}
+
+func TestApp_runs_in_stubMode_without_database(t *testing.T) {
+	//Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockAuthRepo = mocksDomain.NewMockAuthRepository(ctrl)
+	mockAuthRepo.EXPECT().IsAuthorized(dummyToken, gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	router = newRouter(newStubRepositories(), mockAuthRepo, clock.StaticClock{})
+	defer func() { router = nil }()
+	logger.MuteLogger()
+
+	var newAccount dto.NewAccountResponse
+	var transaction dto.TransactionResponse
+	var accounts []dto.AccountResponse
+
+	//Act
+	serve(t, http.MethodPost, "/customers/1/account/new", `{"account_type": "saving", "amount": 5000}`, &newAccount)
+	transactionStatusCode := serve(t, http.MethodPost, "/customers/1/account/"+newAccount.AccountId,
+		`{"transaction_type": "deposit", "amount": 250}`, &transaction)
+	serve(t, http.MethodGet, "/customers/1", "", &accounts)
+	reconciliationRecorder := httptest.NewRecorder()
+	router.ServeHTTP(reconciliationRecorder, httptest.NewRequest(http.MethodGet, "/reconciliation", nil))
+
+	//Assert
+	if transactionStatusCode != http.StatusCreated || transaction.Balance != 5250 {
+		t.Errorf("Expected deposit to be made with new balance 5250 but got status code %d and %v",
+			transactionStatusCode, transaction)
+	}
+	if len(accounts) != 2 || accounts[1].AccountId != newAccount.AccountId || accounts[1].Amount != 5250 {
+		t.Errorf("Expected default and new account with balance 5250 but got %v", accounts)
+	}
+	if reconciliationRecorder.Code != http.StatusNotFound {
+		t.Errorf("Expected reconciliation to be unavailable in stub mode but got status code %d",
+			reconciliationRecorder.Code)
+	}
+}
+
+func Test_isEnvVarNeeded_skips_dbEnvVars_not_needed_by_DB_DRIVER(t *testing.T) {
+	tests := []struct {
+		dbDriver       string
+		key            string
+		expectedNeeded bool
+	}{
+		{"mysql", "DB_HOST", true},
+		{"postgres", "DB_PASSWORD", true},
+		{"sqlite", "DB_HOST", false},
+		{"sqlite", "DB_NAME", true},
+		{"stub", "DB_NAME", false},
+		{"stub", "SERVER_PORT", true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.dbDriver+" "+tc.key, func(t *testing.T) {
+			//Arrange
+			t.Setenv("DB_DRIVER", tc.dbDriver)
+
+			//Act
+			actualNeeded := isEnvVarNeeded(tc.key)
+
+			//Assert
+			if actualNeeded != tc.expectedNeeded {
+				t.Errorf("Expected %s to be needed: %t but got %t", tc.key, tc.expectedNeeded, actualNeeded)
+			}
+		})
+	}
+}
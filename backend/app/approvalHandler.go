@@ -0,0 +1,59 @@
+package app
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/service"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+type ApprovalHandler struct {
+	service service.ApprovalService
+}
+
+func (h ApprovalHandler) approvalsHandler(w http.ResponseWriter, r *http.Request) {
+	response, appErr := h.service.GetPendingApprovals()
+	if appErr != nil {
+		writeErrorResponse(w, r, appErr)
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, response)
+}
+
+func (h ApprovalHandler) approveHandler(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, h.service.Approve)
+}
+
+func (h ApprovalHandler) rejectHandler(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, h.service.Reject)
+}
+
+// decide decides the approval request of the given request with the given service method, on behalf of the admin who
+// sent the request.
+func (h ApprovalHandler) decide(w http.ResponseWriter, r *http.Request,
+	decideFunc func(string, string) (*dto.ApprovalResponse, *errs.AppError)) {
+	vars := mux.Vars(r)
+
+	response, appErr := decideFunc(vars["approval_id"], requestClaims(r).Username)
+	if appErr != nil {
+		writeErrorResponse(w, r, appErr)
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, response)
+}
+
+// requestApproval asks for a second admin to approve the given operation instead of carrying it out, on behalf of the
+// admin who sent the request, and responds with the approval request.
+func requestApproval(w http.ResponseWriter, r *http.Request, approvals service.ApprovalService,
+	operation string, description string, payload interface{}) {
+	response, appErr := approvals.RequestApproval(operation, description, payload, requestClaims(r).Username)
+	if appErr != nil {
+		writeErrorResponse(w, r, appErr)
+		return
+	}
+
+	writeJsonResponse(w, http.StatusAccepted, response)
+}
@@ -0,0 +1,116 @@
+package app
+
+import (
+	"context"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/mocks/service"
+	"github.com/gorilla/mux"
+	"go.uber.org/mock/gomock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockApprovalService *service.MockApprovalService
+var aph ApprovalHandler
+
+const approvalsPath = "/approvals"
+const dummyAdminUsername = "admin2"
+
+func setupApprovalHandlerTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	mockApprovalService = service.NewMockApprovalService(ctrl)
+	aph = ApprovalHandler{mockApprovalService}
+
+	router = mux.NewRouter()
+	router.HandleFunc(approvalsPath, aph.approvalsHandler).Methods(http.MethodGet)
+	router.HandleFunc("/approvals/{approval_id:[0-9]+}/approve", aph.approveHandler).Methods(http.MethodPost)
+	router.HandleFunc("/approvals/{approval_id:[0-9]+}/reject", aph.rejectHandler).Methods(http.MethodPost)
+
+	recorder = httptest.NewRecorder()
+
+	return func() {
+		router = nil
+		recorder = nil
+		request = nil
+		defer ctrl.Finish()
+	}
+}
+
+// newAdminRequest returns a request that has passed through the auth middleware with the token of the given admin.
+func newAdminRequest(method string, path string, username string) *http.Request {
+	claims := domain.AuthClaims{Username: username, Role: domain.AuthRoleAdmin}
+	r := httptest.NewRequest(method, path, nil)
+	return r.WithContext(context.WithValue(r.Context(), authClaimsKey{}, claims))
+}
+
+func TestApprovalHandler_approvalsHandler_respondsWith_pendingApprovalsAndStatusCode200_when_service_succeeds(t *testing.T) {
+	//Arrange
+	teardown := setupApprovalHandlerTest(t)
+	defer teardown()
+	request = httptest.NewRequest(http.MethodGet, approvalsPath, nil)
+
+	dummyApprovals := []dto.ApprovalResponse{{ApprovalId: "3", Status: dto.ApprovalStatusPending}}
+	mockApprovalService.EXPECT().GetPendingApprovals().Return(dummyApprovals, nil)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusOK {
+		t.Errorf("Expected status code %d but got %d", http.StatusOK, recorder.Result().StatusCode)
+	}
+	actualResponse, _ := io.ReadAll(recorder.Result().Body)
+	if !strings.Contains(string(actualResponse), `"approval_id":"3"`) {
+		t.Errorf("Expected response to contain approval request 3 but got: %s", actualResponse)
+	}
+}
+
+func TestApprovalHandler_approveHandler_approves_as_adminOfToken(t *testing.T) {
+	//Arrange
+	teardown := setupApprovalHandlerTest(t)
+	defer teardown()
+	request = newAdminRequest(http.MethodPost, "/approvals/3/approve", dummyAdminUsername)
+
+	dummyApproval := dto.ApprovalResponse{ApprovalId: "3", Status: dto.ApprovalStatusExecuted, DecidedBy: dummyAdminUsername}
+	mockApprovalService.EXPECT().Approve("3", dummyAdminUsername).Return(&dummyApproval, nil)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusOK {
+		t.Errorf("Expected status code %d but got %d", http.StatusOK, recorder.Result().StatusCode)
+	}
+	actualResponse, _ := io.ReadAll(recorder.Result().Body)
+	if !strings.Contains(string(actualResponse), `"status":"executed"`) {
+		t.Errorf("Expected response to contain the executed status but got: %s", actualResponse)
+	}
+}
+
+func TestApprovalHandler_rejectHandler_respondsWith_errorStatusCode_when_service_fails(t *testing.T) {
+	//Arrange
+	teardown := setupApprovalHandlerTest(t)
+	defer teardown()
+	request = newAdminRequest(http.MethodPost, "/approvals/3/reject", dummyAdminUsername)
+
+	dummyAppErr := errs.NewConflictError("Approval request has expired")
+	mockApprovalService.EXPECT().Reject("3", dummyAdminUsername).Return(nil, dummyAppErr)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusConflict {
+		t.Errorf("Expected status code %d but got %d", http.StatusConflict, recorder.Result().StatusCode)
+	}
+	actualResponse, _ := io.ReadAll(recorder.Result().Body)
+	if !strings.Contains(string(actualResponse), dummyAppErr.Message) {
+		t.Errorf("Expected response to contain %s but got: %s", dummyAppErr.Message, actualResponse)
+	}
+}
@@ -1,53 +1,54 @@
 package app
 
 import (
-	"fmt"
+	"context"
 	"github.com/aliciatay-zls/banking-lib/errs"
 	"github.com/aliciatay-zls/banking-lib/logger"
 	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/problem"
 	"github.com/gorilla/mux"
 	"net/http"
-	"os"
 )
 
 type AuthMiddleware struct {
 	repo domain.AuthRepository //middleware handler has dependency on repo (server side) directly, skipped service
 }
 
+// authClaimsKey is the key of the claims of the verified token in the context of a request.
+type authClaimsKey struct{}
+
 // AuthMiddlewareHandler is a middleware that retrieves the token, route name and any vars in the route from the
 // client's request to build a URL to the auth server's verify api. It then sends a request to the URL and if
-// verification is successful, passes the client's request down to the actual route handler.
+// verification is successful, passes the client's request down to the actual route handler together with the claims
+// of the token, which identify the client.
 func (m AuthMiddleware) AuthMiddlewareHandler(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		//handle preflight requests
-		enableCORS(w)
-		if r.Method == http.MethodOptions {
-			writeJsonResponse(w, http.StatusOK, errs.NewMessageObject("all preflight requests currently accepted"))
-			return
-		}
-
-		//handle actual request
 		tokenString := r.Header.Get("Authorization")
 		if tokenString == "" {
 			logger.Error("Client did not provide a token")
-			writeJsonResponse(w, http.StatusUnauthorized, errs.NewMessageObject(errs.MessageMissingToken))
+			writeErrorResponse(w, r, problem.NewAuthenticationError(problem.TokenMissing, errs.MessageMissingToken))
 			return
 		}
 		routeName := mux.CurrentRoute(r).GetName()
 		routeVars := mux.Vars(r)
 
 		if appErr := m.repo.IsAuthorized(tokenString, routeName, routeVars); appErr != nil {
-			writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+			writeErrorResponse(w, r, appErr)
+			return
+		}
+		claims, appErr := domain.ParseAuthClaims(tokenString)
+		if appErr != nil {
+			writeErrorResponse(w, r, appErr)
 			return
 		}
 
-		next.ServeHTTP(w, r)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), authClaimsKey{}, *claims)))
 	})
 }
 
-func enableCORS(w http.ResponseWriter) {
-	w.Header().Add("Access-Control-Allow-Origin",
-		fmt.Sprintf("https://%s", os.Getenv("FRONTEND_SERVER_DOMAIN")))
-	w.Header().Add("Access-Control-Allow-Methods", "POST, GET, OPTIONS") //OPTIONS: preflight request method
-	w.Header().Add("Access-Control-Allow-Headers", "Content-Type, Authorization")
+// requestClaims returns the claims of the token that the given request was verified with, or empty claims if it did
+// not pass through the auth middleware.
+func requestClaims(r *http.Request) domain.AuthClaims {
+	claims, _ := r.Context().Value(authClaimsKey{}).(domain.AuthClaims)
+	return claims
 }
@@ -17,10 +17,21 @@ import (
 var mockAuthRepo *domain.MockAuthRepository
 var amw AuthMiddleware
 var dummyRouteVars map[string]string
-var preflightRequest *http.Request
 
 const dummyPath = "/some/path"
-const dummyToken = "header.payload.signature"
+
+// dummyToken is the access token of customer 2001
+const dummyToken = "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9." +
+	"eyJjdXN0b21lcl9pZCI6IjIwMDEiLCJ1c2VybmFtZSI6IjIwMDEiLCJyb2xlIjoidXNlciJ9.signature"
+
+// dummyAdminToken is the access token of admin "admin"
+const dummyAdminToken = "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9." +
+	"eyJjdXN0b21lcl9pZCI6IiIsInVzZXJuYW1lIjoiYWRtaW4iLCJyb2xlIjoiYWRtaW4ifQ.signature"
+
+// dummySecondAdminToken is the access token of admin "admin2"
+const dummySecondAdminToken = "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9." +
+	"eyJjdXN0b21lcl9pZCI6IiIsInVzZXJuYW1lIjoiYWRtaW4yIiwicm9sZSI6ImFkbWluIn0.signature"
+
 const dummyRouteName = "SomeRoute"
 const dummyStatusCodeFromHandler = http.StatusContinue
 const dummyResponseMessage = "Entered next handler"
@@ -50,8 +61,6 @@ func setupAuthMiddlewareTest(t *testing.T, isTokenGiven bool) func() {
 		request.Header.Add("Authorization", dummyToken)
 	}
 
-	preflightRequest = httptest.NewRequest(http.MethodOptions, dummyPath, nil)
-
 	return func() {
 		router = nil
 		recorder = nil
@@ -60,28 +69,6 @@ func setupAuthMiddlewareTest(t *testing.T, isTokenGiven bool) func() {
 	}
 }
 
-func TestAuthMiddleware_AuthMiddlewareHandler_respondsWith_200_when_preflightRequest(t *testing.T) {
-	//Arrange
-	teardownAll := setupAuthMiddlewareTest(t, true)
-	defer teardownAll()
-
-	expectedStatusCode := http.StatusOK
-	expectedHeaderKeys := []string{"Access-Control-Allow-Origin", "Access-Control-Allow-Methods", "Access-Control-Allow-Headers"}
-
-	//Act
-	router.ServeHTTP(recorder, preflightRequest)
-
-	//Assert
-	if recorder.Result().StatusCode != expectedStatusCode {
-		t.Errorf("Expected status code %d but got %d", expectedStatusCode, recorder.Result().StatusCode)
-	}
-	for _, v := range expectedHeaderKeys {
-		if recorder.Result().Header.Get(v) == "" {
-			t.Errorf("Expected header to be set but was not: %s", v)
-		}
-	}
-}
-
 func TestAuthMiddleware_AuthMiddlewareHandler_respondsWith_errorStatusCode_when_token_missing(t *testing.T) {
 	//Arrange
 	teardownAll := setupAuthMiddlewareTest(t, false)
@@ -156,4 +143,51 @@ func TestAuthMiddleware_AuthMiddlewareHandler_runsNextHandlerFunc_when_repo_succ
 	}
 }
 
+func TestAuthMiddleware_AuthMiddlewareHandler_respondsWith_401_when_tokenClaims_unreadable(t *testing.T) {
+	//Arrange
+	teardownAll := setupAuthMiddlewareTest(t, false)
+	defer teardownAll()
+
+	unreadableToken := "header.payload.signature"
+	request.Header.Add("Authorization", unreadableToken)
+	mockAuthRepo.EXPECT().IsAuthorized(unreadableToken, dummyRouteName, dummyRouteVars).Return(nil)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected status code %d but got %d", http.StatusUnauthorized, recorder.Result().StatusCode)
+	}
+	actualResponse, _ := io.ReadAll(recorder.Result().Body)
+	if !strings.Contains(string(actualResponse), errs.MessageInvalidAccessToken) {
+		t.Errorf("Expecting response to contain %s but got %s", errs.MessageInvalidAccessToken, actualResponse)
+	}
+}
+
+func TestAuthMiddleware_AuthMiddlewareHandler_passes_tokenClaims_to_nextHandlerFunc(t *testing.T) {
+	//Arrange
+	teardownAll := setupAuthMiddlewareTest(t, false)
+	defer teardownAll()
+
+	router.HandleFunc("/claims", func(w http.ResponseWriter, r *http.Request) {
+		writeJsonResponse(w, http.StatusOK, requestClaims(r))
+	}).Name(dummyRouteName)
+	request = httptest.NewRequest(http.MethodGet, "/claims", nil)
+	request.Header.Add("Authorization", "Bearer "+dummyAdminToken)
+	mockAuthRepo.EXPECT().IsAuthorized("Bearer "+dummyAdminToken, dummyRouteName, dummyRouteVars).Return(nil)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusOK {
+		t.Fatalf("Expected status code %d but got %d", http.StatusOK, recorder.Result().StatusCode)
+	}
+	actualResponse, _ := io.ReadAll(recorder.Result().Body)
+	if !strings.Contains(string(actualResponse), `"username":"admin"`) || !strings.Contains(string(actualResponse), `"role":"admin"`) {
+		t.Errorf("Expecting response to contain the claims of the admin token but got %s", actualResponse)
+	}
+}
+
 //mux.Router: It implements the http.Handler interface, so it can be registered to serve requests
@@ -0,0 +1,107 @@
+package app
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/service"
+	"os"
+)
+
+// RunCommand runs a one-off command given on the command line instead of starting the server, e.g.
+// `go run main.go reconcile -freeze`, `go run main.go overdraft-interest`, `go run main.go monthly-fees` or
+// `go run main.go migrate up`.
+func RunCommand(args []string) {
+	switch args[0] {
+	case "reconcile":
+		runReconcile(args[1:])
+	case "overdraft-interest":
+		runOverdraftInterest()
+	case "monthly-fees":
+		runMonthlyFees(args[1:])
+	case "migrate":
+		runMigrate(args[1:])
+	default:
+		logger.Fatal(fmt.Sprintf("Unknown command %s", args[0]))
+	}
+}
+
+// runReconcile prints the balance reconciliation report to stdout and exits with status 2 if any mismatch was found,
+// so that it can be scheduled as a job that alerts on failure.
+func runReconcile(args []string) {
+	flags := flag.NewFlagSet("reconcile", flag.ExitOnError)
+	freeze := flags.Bool("freeze", false, "freeze mismatched accounts pending review")
+	_ = flags.Parse(args) //exits on error
+
+	checkEnvVars(dbEnvVars)
+	dbClient := getDbClient()
+
+	reconciliationService := service.NewReconciliationService(domain.NewReconciliationRepositoryDb(dbClient), clock.RealClock{})
+	report, appErr := reconciliationService.Reconcile(*freeze)
+	_ = dbClient.Close()
+	if appErr != nil {
+		logger.Fatal("Error while reconciling account balances: " + appErr.Message)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		logger.Fatal("Error while printing reconciliation report: " + err.Error())
+	}
+
+	if len(report.Mismatches) > 0 {
+		os.Exit(2)
+	}
+}
+
+// runOverdraftInterest charges the overdraft interest due on every overdrawn account and prints what was charged to
+// stdout. It is meant to be scheduled as a daily job, but running it more often does not charge interest twice.
+func runOverdraftInterest() {
+	checkEnvVars(dbEnvVars)
+	dbClient := getDbClient()
+
+	overdraftService := service.NewOverdraftService(domain.NewOverdraftRepositoryDb(dbClient), domain.NewAccountRepositoryDb(dbClient),
+		domain.NewHoldRepositoryDb(dbClient), overdraftTerms(), clock.RealClock{})
+	report, appErr := overdraftService.ChargeInterest()
+	_ = dbClient.Close()
+	if appErr != nil {
+		logger.Fatal("Error while charging overdraft interest: " + appErr.Message)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		logger.Fatal("Error while printing overdraft interest report: " + err.Error())
+	}
+}
+
+// runMonthlyFees charges the monthly fees of the given month, or of the previous month if none is given, and prints
+// what was charged to stdout. It is meant to be scheduled as a job at the start of every month, but running it again
+// only charges the accounts that were not charged yet.
+func runMonthlyFees(args []string) {
+	flags := flag.NewFlagSet("monthly-fees", flag.ExitOnError)
+	month := flags.String("month", "", "month to charge, as YYYY-MM (default the previous month)")
+	_ = flags.Parse(args) //exits on error
+
+	checkEnvVars(dbEnvVars)
+	dbClient := getDbClient()
+
+	accountRepo := domain.NewAccountRepositoryDb(dbClient)
+	overdraftService := service.NewOverdraftService(domain.NewOverdraftRepositoryDb(dbClient), accountRepo,
+		domain.NewHoldRepositoryDb(dbClient), overdraftTerms(), clock.RealClock{})
+	feeService := service.NewFeeService(domain.NewFeeRepositoryDb(dbClient), accountRepo, overdraftService, clock.RealClock{})
+	report, appErr := feeService.ChargeMonthlyFees(*month)
+	_ = dbClient.Close()
+	if appErr != nil {
+		logger.Fatal("Error while charging monthly fees: " + appErr.Message)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		logger.Fatal("Error while printing monthly fee report: " + err.Error())
+	}
+}
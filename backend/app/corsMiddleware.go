@@ -0,0 +1,176 @@
+package app
+
+import (
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/problem"
+	"github.com/gorilla/mux"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CorsPolicy sets which cross-origin requests browsers may make to the backend.
+type CorsPolicy struct {
+	AllowedOrigins   []string      //exact origins, or patterns with a "*" standing for part of a host name
+	AllowedHeaders   []string      //request headers that clients may send
+	ExposedHeaders   []string      //response headers that clients may read
+	AllowCredentials bool          //whether browsers may send cookies and TLS client certificates
+	MaxAge           time.Duration //how long browsers may cache the result of a preflight request
+}
+
+// corsMethods are the methods that the routes can be registered with, besides OPTIONS.
+var corsMethods = []string{
+	http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete,
+}
+
+// allowsOrigin reports whether the given origin is one of the AllowedOrigins or matches one of their patterns, e.g.
+// "https://banking-git-fix-login.vercel.app" matches "https://banking-*.vercel.app". A "*" only stands for letters,
+// digits and hyphens, so that it cannot span the dots of another domain.
+func (p CorsPolicy) allowsOrigin(origin string) bool {
+	for _, allowed := range p.AllowedOrigins {
+		prefix, suffix, isPattern := strings.Cut(allowed, "*")
+		if !isPattern {
+			if origin == allowed {
+				return true
+			}
+			continue
+		}
+		if len(origin) <= len(prefix)+len(suffix) || !strings.HasPrefix(origin, prefix) || !strings.HasSuffix(origin, suffix) {
+			continue
+		}
+		if isHostLabelPart(origin[len(prefix) : len(origin)-len(suffix)]) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowsHeaders reports whether all the headers in the given comma-separated list, as sent in
+// Access-Control-Request-Headers, are AllowedHeaders.
+func (p CorsPolicy) allowsHeaders(requested string) bool {
+	for _, header := range strings.Split(requested, ",") {
+		header = strings.TrimSpace(header)
+		if header == "" {
+			continue
+		}
+		if !containsFold(p.AllowedHeaders, header) {
+			return false
+		}
+	}
+	return true
+}
+
+// containsFold reports whether the given list contains the given string, regardless of case.
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func isHostLabelPart(s string) bool {
+	for _, c := range s {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+type CorsMiddleware struct {
+	policy CorsPolicy
+	router *mux.Router //to find the methods that each route is registered with
+}
+
+func NewCorsMiddleware(policy CorsPolicy, router *mux.Router) CorsMiddleware {
+	return CorsMiddleware{policy, router}
+}
+
+// CorsMiddlewareHandler is a middleware that applies the CorsPolicy. It runs before all other middleware so that
+// preflight requests, which never carry a token, are answered without being rate limited or sent to the auth server.
+// A preflight request is only allowed if its origin is allowed and the path is registered with the method and
+// headers it asks for. Other requests from an allowed origin are passed on with the headers that let the browser read
+// the response; those from other origins are passed on without them, so that the browser withholds the response.
+func (m CorsMiddleware) CorsMiddlewareHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Origin")
+		origin := r.Header.Get("Origin")
+
+		if r.Method == http.MethodOptions {
+			m.handlePreflight(w, r, origin)
+			return
+		}
+
+		if origin != "" && m.policy.allowsOrigin(origin) {
+			m.setAllowOrigin(w, origin)
+			if len(m.policy.ExposedHeaders) > 0 {
+				w.Header().Set("Access-Control-Expose-Headers", strings.Join(m.policy.ExposedHeaders, ", "))
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// handlePreflight answers an OPTIONS request. If it is not a preflight request, it only lists the methods of the path.
+func (m CorsMiddleware) handlePreflight(w http.ResponseWriter, r *http.Request, origin string) {
+	w.Header().Add("Vary", "Access-Control-Request-Method")
+	w.Header().Add("Vary", "Access-Control-Request-Headers")
+
+	methods := m.routeMethods(r)
+	w.Header().Set("Allow", strings.Join(append(methods, http.MethodOptions), ", "))
+
+	requestedMethod := r.Header.Get("Access-Control-Request-Method")
+	if origin == "" || requestedMethod == "" {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	if !m.policy.allowsOrigin(origin) {
+		logger.Error(fmt.Sprintf("Preflight request from origin %s is not allowed", origin))
+		writeErrorResponse(w, r, problem.NewAuthorizationError(problem.CorsOriginNotAllowed, "Origin not allowed"))
+		return
+	}
+	if !containsFold(methods, requestedMethod) {
+		logger.Error(fmt.Sprintf("Preflight request for %s %s is not allowed", requestedMethod, r.URL.Path))
+		writeErrorResponse(w, r, problem.NewAuthorizationError(problem.CorsMethodNotAllowed,
+			"Method not allowed for this route"))
+		return
+	}
+	if requestedHeaders := r.Header.Get("Access-Control-Request-Headers"); !m.policy.allowsHeaders(requestedHeaders) {
+		logger.Error(fmt.Sprintf("Preflight request with headers %s is not allowed", requestedHeaders))
+		writeErrorResponse(w, r, problem.NewAuthorizationError(problem.CorsHeadersNotAllowed, "Headers not allowed"))
+		return
+	}
+
+	m.setAllowOrigin(w, origin)
+	w.Header().Set("Access-Control-Allow-Methods", strings.Join(methods, ", "))
+	w.Header().Set("Access-Control-Allow-Headers", strings.Join(m.policy.AllowedHeaders, ", "))
+	w.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(m.policy.MaxAge.Seconds())))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (m CorsMiddleware) setAllowOrigin(w http.ResponseWriter, origin string) {
+	w.Header().Set("Access-Control-Allow-Origin", origin)
+	if m.policy.AllowCredentials {
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+	}
+}
+
+// routeMethods returns the methods that the path of the given request is registered with. A path can have a route
+// for each method, so the router is asked to match the path with every method rather than only the current route.
+func (m CorsMiddleware) routeMethods(r *http.Request) []string {
+	var methods []string
+	for _, method := range corsMethods {
+		candidate := r.Clone(r.Context())
+		candidate.Method = method
+		var match mux.RouteMatch
+		if m.router.Match(candidate, &match) && match.MatchErr == nil {
+			methods = append(methods, method)
+		}
+	}
+	return methods
+}
@@ -0,0 +1,224 @@
+package app
+
+import (
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/gorilla/mux"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// Test common variables and inputs
+var cmw CorsMiddleware
+
+const dummyOrigin = "https://banking.example.com"
+const dummyCorsPath = "/customers/2001/payees"
+
+func setupCorsMiddlewareTest(t *testing.T) func() {
+	router = mux.NewRouter()
+	cmw = NewCorsMiddleware(newDummyCorsPolicy(), router)
+
+	dummyHandler := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(dummyResponseMessage)); err != nil {
+			t.Fatal("Error during testing setup: " + err.Error())
+		}
+	}
+	router.HandleFunc("/customers/{customer_id:[0-9]+}/payees", dummyHandler).
+		Methods(http.MethodGet, http.MethodOptions).
+		Name("GetPayees")
+	router.HandleFunc("/customers/{customer_id:[0-9]+}/payees", dummyHandler).
+		Methods(http.MethodPost, http.MethodOptions).
+		Name("NewPayee")
+	router.Use(cmw.CorsMiddlewareHandler)
+
+	recorder = httptest.NewRecorder()
+
+	return func() {
+		router = nil
+		recorder = nil
+		request = nil
+	}
+}
+
+func newDummyCorsPolicy() CorsPolicy {
+	return CorsPolicy{
+		AllowedOrigins:   []string{dummyOrigin, "https://banking-*.vercel.app"},
+		AllowedHeaders:   []string{"Content-Type", "Authorization"},
+		ExposedHeaders:   []string{"RateLimit-Remaining"},
+		AllowCredentials: true,
+		MaxAge:           10 * time.Minute,
+	}
+}
+
+func newPreflightRequest(origin string, method string, headers string) *http.Request {
+	r := httptest.NewRequest(http.MethodOptions, dummyCorsPath, nil)
+	r.Header.Set("Origin", origin)
+	r.Header.Set("Access-Control-Request-Method", method)
+	if headers != "" {
+		r.Header.Set("Access-Control-Request-Headers", headers)
+	}
+	return r
+}
+
+func TestCorsPolicy_allowsOrigin(t *testing.T) {
+	tests := []struct {
+		origin   string
+		expected bool
+	}{
+		{dummyOrigin, true},
+		{"https://banking-git-fix-login.vercel.app", true},
+		{"https://banking-.vercel.app", false},
+		{"https://banking-x.evil.com/.vercel.app", false},
+		{"https://banking-a.b.vercel.app", false},
+		{"http://banking.example.com", false},
+		{"https://banking.example.com.evil.com", false},
+		{"", false},
+	}
+	policy := newDummyCorsPolicy()
+	for _, tc := range tests {
+		//Act
+		actual := policy.allowsOrigin(tc.origin)
+
+		//Assert
+		if actual != tc.expected {
+			t.Errorf("Expected origin %q to be allowed: %t but got %t", tc.origin, tc.expected, actual)
+		}
+	}
+}
+
+func TestCorsMiddleware_CorsMiddlewareHandler_respondsWith_204_when_preflightRequest_allowed(t *testing.T) {
+	//Arrange
+	teardown := setupCorsMiddlewareTest(t)
+	defer teardown()
+	request = newPreflightRequest(dummyOrigin, http.MethodPost, "content-type, authorization")
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Code != http.StatusNoContent {
+		t.Errorf("Expected status code %d but got %d", http.StatusNoContent, recorder.Code)
+	}
+	expectedHeaders := map[string]string{
+		"Access-Control-Allow-Origin":      dummyOrigin,
+		"Access-Control-Allow-Methods":     "GET, POST",
+		"Access-Control-Allow-Headers":     "Content-Type, Authorization",
+		"Access-Control-Allow-Credentials": "true",
+		"Access-Control-Max-Age":           "600",
+		"Allow":                            "GET, POST, OPTIONS",
+	}
+	for k, v := range expectedHeaders {
+		if recorder.Header().Get(k) != v {
+			t.Errorf("Expected header %s to be %q but got %q", k, v, recorder.Header().Get(k))
+		}
+	}
+	if vary := recorder.Header().Values("Vary"); len(vary) == 0 || vary[0] != "Origin" {
+		t.Errorf("Expected response to vary by Origin but got %v", vary)
+	}
+}
+
+func TestCorsMiddleware_CorsMiddlewareHandler_respondsWith_403_when_preflightRequest_not_allowed(t *testing.T) {
+	tests := []struct {
+		name            string
+		request         *http.Request
+		expectedMessage string
+	}{
+		{"origin", newPreflightRequest("https://evil.example.com", http.MethodPost, ""), "Origin not allowed"},
+		{"method", newPreflightRequest(dummyOrigin, http.MethodDelete, ""), "Method not allowed for this route"},
+		{"headers", newPreflightRequest(dummyOrigin, http.MethodPost, "X-Custom"), "Headers not allowed"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Arrange
+			teardown := setupCorsMiddlewareTest(t)
+			defer teardown()
+			logs := logger.ReplaceWithTestLogger()
+
+			//Act
+			router.ServeHTTP(recorder, tc.request)
+
+			//Assert
+			if recorder.Code != http.StatusForbidden {
+				t.Errorf("Expected status code %d but got %d", http.StatusForbidden, recorder.Code)
+			}
+			if recorder.Header().Get("Access-Control-Allow-Origin") != "" {
+				t.Errorf("Expected no Access-Control-Allow-Origin but got %s",
+					recorder.Header().Get("Access-Control-Allow-Origin"))
+			}
+			expectedBody := `{"message":"` + tc.expectedMessage + `"}` + "\n"
+			if recorder.Body.String() != expectedBody {
+				t.Errorf("Expected response %s but got %s", expectedBody, recorder.Body.String())
+			}
+			if logs.Len() != 1 {
+				t.Errorf("Expected 1 message to be logged but got %d logs", logs.Len())
+			}
+		})
+	}
+}
+
+func TestCorsMiddleware_CorsMiddlewareHandler_respondsWith_204_when_options_not_preflightRequest(t *testing.T) {
+	//Arrange
+	teardown := setupCorsMiddlewareTest(t)
+	defer teardown()
+	request = httptest.NewRequest(http.MethodOptions, dummyCorsPath, nil)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Code != http.StatusNoContent {
+		t.Errorf("Expected status code %d but got %d", http.StatusNoContent, recorder.Code)
+	}
+	if recorder.Header().Get("Allow") != "GET, POST, OPTIONS" {
+		t.Errorf("Expected Allow to be \"GET, POST, OPTIONS\" but got %q", recorder.Header().Get("Allow"))
+	}
+}
+
+func TestCorsMiddleware_CorsMiddlewareHandler_sets_headers_when_origin_allowed(t *testing.T) {
+	//Arrange
+	teardown := setupCorsMiddlewareTest(t)
+	defer teardown()
+	request = httptest.NewRequest(http.MethodGet, dummyCorsPath, nil)
+	request.Header.Set("Origin", dummyOrigin)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Body.String() != dummyResponseMessage {
+		t.Errorf("Expected next handler to be run but got response %s", recorder.Body.String())
+	}
+	if recorder.Header().Get("Access-Control-Allow-Origin") != dummyOrigin {
+		t.Errorf("Expected Access-Control-Allow-Origin to be %s but got %s",
+			dummyOrigin, recorder.Header().Get("Access-Control-Allow-Origin"))
+	}
+	if recorder.Header().Get("Access-Control-Expose-Headers") != "RateLimit-Remaining" {
+		t.Errorf("Expected Access-Control-Expose-Headers to be RateLimit-Remaining but got %s",
+			recorder.Header().Get("Access-Control-Expose-Headers"))
+	}
+}
+
+func TestCorsMiddleware_CorsMiddlewareHandler_does_not_set_headers_when_origin_not_allowed(t *testing.T) {
+	//Arrange
+	teardown := setupCorsMiddlewareTest(t)
+	defer teardown()
+	request = httptest.NewRequest(http.MethodGet, dummyCorsPath, nil)
+	request.Header.Set("Origin", "https://evil.example.com")
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Body.String() != dummyResponseMessage {
+		t.Errorf("Expected next handler to be run but got response %s", recorder.Body.String())
+	}
+	if recorder.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Errorf("Expected no Access-Control-Allow-Origin but got %s",
+			recorder.Header().Get("Access-Control-Allow-Origin"))
+	}
+	if recorder.Header().Get("Vary") != "Origin" {
+		t.Errorf("Expected response to vary by Origin but got %s", recorder.Header().Get("Vary"))
+	}
+}
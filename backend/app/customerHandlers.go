@@ -16,7 +16,7 @@ func (h CustomerHandlers) customersHandler(w http.ResponseWriter, r *http.Reques
 
 	customers, err := h.customerService.GetAllCustomers(q)
 	if err != nil {
-		writeJsonResponse(w, err.Code, err.AsMessage())
+		writeErrorResponse(w, r, err)
 	} else {
 		writeJsonResponse(w, http.StatusOK, customers)
 	}
@@ -26,7 +26,7 @@ func (h CustomerHandlers) customerProfileHandler(w http.ResponseWriter, r *http.
 	vars := mux.Vars(r)
 	customer, err := h.customerService.GetCustomer(vars["customer_id"])
 	if err != nil {
-		writeJsonResponse(w, err.Code, err.AsMessage()) // (*)
+		writeErrorResponse(w, r, err) // (*)
 	} else {
 		writeJsonResponse(w, http.StatusOK, customer)
 	}
@@ -0,0 +1,70 @@
+package app
+
+import (
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/jmoiron/sqlx"
+	_ "modernc.org/sqlite"
+	"net"
+	"net/url"
+	"os"
+	"time"
+)
+
+// getDbClient opens a database handle for the database selected by DB_DRIVER ("mysql", the default, "postgres" or
+// "sqlite").
+func getDbClient() *sqlx.DB {
+	driverName, dataSource := getDataSource()
+	db, err := sqlx.Open(driverName, dataSource)
+	if err != nil {
+		logger.Fatal("Error while opening connection to database: " + err.Error())
+	}
+
+	if driverName == domain.DriverSQLite {
+		//SQLite allows only one writer at a time, and an in-memory database only lives as long as its connection
+		db.SetMaxOpenConns(1)
+		db.SetMaxIdleConns(1)
+		return db
+	}
+	db.SetConnMaxLifetime(time.Minute * 3)
+	db.SetMaxOpenConns(10)
+	db.SetMaxIdleConns(10)
+
+	return db
+}
+
+// getDataSource returns the name of the driver to use and the data source name in the format of that driver.
+func getDataSource() (string, string) {
+	dbUser := os.Getenv("DB_USER")
+	dbPassword := os.Getenv("DB_PASSWORD")
+	dbHost := os.Getenv("DB_HOST")
+	dbPort := os.Getenv("DB_PORT")
+	dbName := os.Getenv("DB_NAME")
+
+	switch os.Getenv("DB_DRIVER") {
+	case "", "mysql":
+		return domain.DriverMySQL, fmt.Sprintf("%s:%s@tcp(%s:%s)/%s", dbUser, dbPassword, dbHost, dbPort, dbName)
+	case "postgres":
+		sslMode := os.Getenv("DB_SSL_MODE")
+		if sslMode == "" {
+			sslMode = "prefer"
+		}
+		dataSource := url.URL{
+			Scheme:   "postgres",
+			User:     url.UserPassword(dbUser, dbPassword),
+			Host:     net.JoinHostPort(dbHost, dbPort),
+			Path:     dbName,
+			RawQuery: url.Values{"sslmode": {sslMode}}.Encode(),
+		}
+		return domain.DriverPostgres, dataSource.String()
+	case "sqlite":
+		//DB_NAME is the path of the database file, or ":memory:" for a database that is discarded on exit
+		return domain.DriverSQLite, fmt.Sprintf("file:%s?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)", dbName)
+	default:
+		logger.Fatal(fmt.Sprintf("Unsupported DB_DRIVER %s (expected mysql, postgres or sqlite)", os.Getenv("DB_DRIVER")))
+		return "", ""
+	}
+}
@@ -0,0 +1,75 @@
+package app
+
+import (
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/gorilla/mux"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// currentApiVersion is the path prefix of the current version of the API. A new version is mounted side by side
+// under its own prefix, e.g. /v2, with its routes named like those of the version they replace, as the name is what
+// the auth server grants access to.
+const currentApiVersion = "/v1"
+
+// legacyApiRouteName names the route of the subrouter of the legacy aliases, so that they can be told apart from the
+// routes they alias, e.g. in the API document.
+const legacyApiRouteName = "LegacyApi"
+
+// DeprecationPolicy is when the legacy paths, those from before the API was versioned, were deprecated and when they
+// will stop being served.
+type DeprecationPolicy struct {
+	DeprecatedAt time.Time
+	Sunset       time.Time
+	Successor    string //path prefix of the version that replaces them, e.g. /v1
+}
+
+type DeprecationMiddleware struct {
+	policy DeprecationPolicy
+}
+
+// DeprecationMiddlewareHandler is a middleware that tells clients of a legacy path that it is deprecated, with the
+// Deprecation (RFC 9745) and Sunset (RFC 8594) headers and a Link to the same path in the version that replaces it.
+// It runs before all other middleware of the legacy paths, so that even errors carry the headers.
+func (m DeprecationMiddleware) DeprecationMiddlewareHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Deprecation", fmt.Sprintf("@%d", m.policy.DeprecatedAt.Unix()))
+		w.Header().Set("Sunset", m.policy.Sunset.UTC().Format(http.TimeFormat))
+		w.Header().Add("Link", fmt.Sprintf("<%s%s>; rel=\"successor-version\"", m.policy.Successor, r.URL.Path))
+		next.ServeHTTP(w, r)
+	})
+}
+
+// newLegacyApi registers every route of the given version of the API again without its prefix, on a subrouter of the
+// given router, for clients from before the API was versioned. The aliases keep the names of the routes they alias.
+func newLegacyApi(router *mux.Router, api *mux.Router, prefix string) *mux.Router {
+	legacy := router.NewRoute().Name(legacyApiRouteName).Subrouter()
+	err := api.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		pathTemplate, pathErr := route.GetPathTemplate()
+		methods, methodsErr := route.GetMethods()
+		if route.GetName() == "" || pathErr != nil || methodsErr != nil { //e.g. a subrouter
+			return nil
+		}
+		legacy.
+			Handle(strings.TrimPrefix(pathTemplate, prefix), route.GetHandler()).
+			Methods(methods...).
+			Name(route.GetName())
+		return nil
+	})
+	if err != nil {
+		logger.Error("Error while registering legacy paths: " + err.Error())
+	}
+	return legacy
+}
+
+// isLegacyRoute reports whether a route with the given ancestors, as given by mux.Router.Walk, is a legacy alias.
+func isLegacyRoute(ancestors []*mux.Route) bool {
+	for _, ancestor := range ancestors {
+		if ancestor.GetName() == legacyApiRouteName {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+package app
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	mocksDomain "github.com/aliciatay-zls/banking/backend/mocks/domain"
+	"github.com/aliciatay-zls/banking/backend/openapi"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestApp_legacyPath_is_served_like_v1_with_deprecation_headers(t *testing.T) {
+	//Arrange
+	teardown := setupAppTest(t)
+	defer teardown()
+
+	path := "/customers/" + seededCustomerId
+	var legacyAccounts, v1Accounts []dto.AccountResponse
+	expectedLink := "<" + currentApiVersion + path + ">; rel=\"successor-version\""
+
+	//Act
+	legacyStatusCode := serve(t, http.MethodGet, path, "", &legacyAccounts)
+	v1StatusCode := serve(t, http.MethodGet, currentApiVersion+path, "", &v1Accounts)
+	legacyRecorder := httptest.NewRecorder()
+	router.ServeHTTP(legacyRecorder, httptest.NewRequest(http.MethodGet, path, nil))
+	v1Recorder := httptest.NewRecorder()
+	router.ServeHTTP(v1Recorder, httptest.NewRequest(http.MethodGet, currentApiVersion+path, nil))
+
+	//Assert
+	if legacyStatusCode != http.StatusOK || v1StatusCode != http.StatusOK || !reflect.DeepEqual(legacyAccounts, v1Accounts) {
+		t.Errorf("Expected the same accounts from both paths but got status codes %d and %d and %v and %v",
+			legacyStatusCode, v1StatusCode, legacyAccounts, v1Accounts)
+	}
+	if legacyRecorder.Code != http.StatusUnauthorized { //without a token
+		t.Fatalf("Expected status code %d but got %d", http.StatusUnauthorized, legacyRecorder.Code)
+	}
+	if deprecation := legacyRecorder.Header().Get("Deprecation"); deprecation != "@1792368000" {
+		t.Errorf("Expected Deprecation @1792368000 but got %q", deprecation)
+	}
+	if sunset := legacyRecorder.Header().Get("Sunset"); sunset != "Fri, 30 Apr 2027 00:00:00 GMT" {
+		t.Errorf("Expected Sunset Fri, 30 Apr 2027 00:00:00 GMT but got %q", sunset)
+	}
+	if link := legacyRecorder.Header().Get("Link"); link != expectedLink {
+		t.Errorf("Expected Link %s but got %q", expectedLink, link)
+	}
+	if v1Recorder.Header().Get("Deprecation") != "" || v1Recorder.Header().Get("Sunset") != "" {
+		t.Errorf("Expected no deprecation headers for %s but got %v", currentApiVersion+path, v1Recorder.Header())
+	}
+}
+
+func TestApp_legacyPath_keeps_routeName_sent_to_authServer(t *testing.T) {
+	//Arrange
+	teardown := setupAppTest(t)
+	defer teardown()
+
+	ctrl := gomock.NewController(t)
+	authRepo := mocksDomain.NewMockAuthRepository(ctrl)
+	router = newRouter(newDbRepositories(testDbClient), authRepo, clock.StaticClock{})
+	path := "/customers/" + seededCustomerId + "/account/" + seededAccountId + "/transactions"
+	var legacyTransactions, v1Transactions []dto.AccountTransactionResponse
+
+	authRepo.EXPECT().IsAuthorized(dummyToken, "GetTransactions", gomock.Any()).Return(nil).Times(2)
+
+	//Act
+	serve(t, http.MethodGet, path, "", &legacyTransactions)
+	serve(t, http.MethodGet, currentApiVersion+path, "", &v1Transactions)
+
+	//Assert
+	ctrl.Finish()
+}
+
+func TestApp_apiDocument_marks_legacyPaths_deprecated(t *testing.T) {
+	//Arrange
+	teardown := setupAppTest(t)
+	defer teardown()
+
+	path := "/customers/{customer_id}/account/{account_id}"
+
+	//Act
+	doc := newApiDocument(router)
+
+	//Assert
+	legacyOp, legacyOk := doc.Operation(http.MethodPost, openapi.PathOf(path))
+	v1Op, v1Ok := doc.Operation(http.MethodPost, openapi.PathOf(currentApiVersion+path))
+	if !legacyOk || !v1Ok {
+		t.Fatalf("Expected NewTransaction at both %s and %s in the API document", path, currentApiVersion+path)
+	}
+	if !legacyOp.Deprecated || legacyOp.OperationId != "NewTransactionLegacy" {
+		t.Errorf("Expected %s to be deprecated as NewTransactionLegacy but got %s (deprecated: %v)",
+			path, legacyOp.OperationId, legacyOp.Deprecated)
+	}
+	if v1Op.Deprecated || v1Op.OperationId != "NewTransaction" {
+		t.Errorf("Expected %s to be NewTransaction but got %s (deprecated: %v)",
+			currentApiVersion+path, v1Op.OperationId, v1Op.Deprecated)
+	}
+}
+
+func Test_deprecationPolicy_returns_dates_set_in_envVars(t *testing.T) {
+	//Arrange
+	t.Setenv("LEGACY_API_DEPRECATED_AT", "2027-01-01")
+	t.Setenv("LEGACY_API_SUNSET", "2027-12-31")
+	expected := DeprecationPolicy{
+		DeprecatedAt: time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC),
+		Sunset:       time.Date(2027, time.December, 31, 0, 0, 0, 0, time.UTC),
+		Successor:    currentApiVersion,
+	}
+
+	//Act
+	actual := deprecationPolicy()
+
+	//Assert
+	if !actual.DeprecatedAt.Equal(expected.DeprecatedAt) || !actual.Sunset.Equal(expected.Sunset) ||
+		actual.Successor != expected.Successor {
+		t.Errorf("Expected policy %v but got %v", expected, actual)
+	}
+}
@@ -0,0 +1,59 @@
+package app
+
+import (
+	"encoding/json"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/service"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+type FeeHandler struct {
+	service service.FeeService
+}
+
+func (h FeeHandler) schedulesHandler(w http.ResponseWriter, r *http.Request) {
+	response, appErr := h.service.GetSchedules()
+	if appErr != nil {
+		writeErrorResponse(w, r, appErr)
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, response)
+}
+
+func (h FeeHandler) newScheduleHandler(w http.ResponseWriter, r *http.Request) {
+	var request dto.NewFeeScheduleRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		logger.Error("Error while decoding json body of new fee schedule request: " + err.Error())
+		writeErrorResponse(w, r, errs.NewAppError(http.StatusBadRequest, "Please check that all fields are correctly filled."))
+		return
+	}
+	request.CreatedBy = requestClaims(r).Username
+
+	if valErr := request.Validate(); valErr != nil {
+		writeValidationErrorResponse(w, r, valErr)
+		return
+	}
+
+	response, appErr := h.service.CreateSchedule(request)
+	if appErr != nil {
+		writeErrorResponse(w, r, appErr)
+		return
+	}
+
+	writeJsonResponse(w, http.StatusCreated, response)
+}
+
+func (h FeeHandler) deleteScheduleHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	if appErr := h.service.DeleteSchedule(vars["schedule_id"]); appErr != nil {
+		writeErrorResponse(w, r, appErr)
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, errs.NewMessageObject("Fee schedule deleted"))
+}
@@ -0,0 +1,118 @@
+package app
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/mocks/service"
+	"github.com/gorilla/mux"
+	"go.uber.org/mock/gomock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockFeeService *service.MockFeeService
+var fh FeeHandler
+
+const feeSchedulesPath = "/fees/schedules"
+
+func setupFeeHandlerTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	mockFeeService = service.NewMockFeeService(ctrl)
+	fh = FeeHandler{mockFeeService}
+
+	router = mux.NewRouter()
+	router.HandleFunc("/fees/schedules", fh.schedulesHandler).Methods(http.MethodGet)
+	router.HandleFunc("/fees/schedules", fh.newScheduleHandler).Methods(http.MethodPost)
+	router.HandleFunc("/fees/schedules/{schedule_id:[0-9]+}", fh.deleteScheduleHandler).Methods(http.MethodDelete)
+
+	recorder = httptest.NewRecorder()
+
+	return func() {
+		router = nil
+		recorder = nil
+		request = nil
+		defer ctrl.Finish()
+	}
+}
+
+func TestFeeHandler_schedulesHandler_respondsWith_schedulesAndStatusCode200_when_service_succeeds(t *testing.T) {
+	//Arrange
+	teardown := setupFeeHandlerTest(t)
+	defer teardown()
+	request = httptest.NewRequest(http.MethodGet, feeSchedulesPath, nil)
+
+	dummyResponse := []dto.FeeScheduleResponse{{ScheduleId: "4", AccountType: dto.AccountTypeSaving, MonthlyFee: 5,
+		Status: dto.FeeScheduleStatusInEffect}}
+	mockFeeService.EXPECT().GetSchedules().Return(dummyResponse, nil)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusOK {
+		t.Errorf("Expected status code %d but got %d", http.StatusOK, recorder.Result().StatusCode)
+	}
+	actualResponse, _ := io.ReadAll(recorder.Result().Body)
+	if !strings.Contains(string(actualResponse), `"status":"in_effect"`) {
+		t.Errorf("Expected response to contain the status of the schedule but got %s", string(actualResponse))
+	}
+}
+
+func TestFeeHandler_newScheduleHandler_respondsWith_statusCode422_when_request_invalid(t *testing.T) {
+	//Arrange
+	teardown := setupFeeHandlerTest(t)
+	defer teardown()
+	request = newAdminRequest(http.MethodPost, feeSchedulesPath, "admin")
+	request.Body = io.NopCloser(strings.NewReader(`{"account_type": "fixed", "monthly_fee": 5, "effective_from": "2006-02-01"}`))
+	mockFeeService.EXPECT().CreateSchedule(gomock.Any()).Times(0)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status code %d but got %d", http.StatusUnprocessableEntity, recorder.Result().StatusCode)
+	}
+}
+
+func TestFeeHandler_newScheduleHandler_respondsWith_scheduleAndStatusCode201_when_service_succeeds(t *testing.T) {
+	//Arrange
+	teardown := setupFeeHandlerTest(t)
+	defer teardown()
+	request = newAdminRequest(http.MethodPost, feeSchedulesPath, "admin")
+	request.Body = io.NopCloser(strings.NewReader(
+		`{"account_type": "saving", "monthly_fee": 5, "free_withdrawals": 2, "withdrawal_fee": 0.5, "effective_from": "2006-02-01"}`))
+
+	expectedRequest := dto.NewFeeScheduleRequest{AccountType: dto.AccountTypeSaving, MonthlyFee: 5, FreeWithdrawals: 2,
+		WithdrawalFee: 0.5, EffectiveFrom: "2006-02-01", CreatedBy: "admin"}
+	dummyResponse := dto.FeeScheduleResponse{ScheduleId: "4", Status: dto.FeeScheduleStatusScheduled}
+	mockFeeService.EXPECT().CreateSchedule(expectedRequest).Return(&dummyResponse, nil)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusCreated {
+		t.Errorf("Expected status code %d but got %d", http.StatusCreated, recorder.Result().StatusCode)
+	}
+}
+
+func TestFeeHandler_deleteScheduleHandler_respondsWith_serviceError_when_service_fails(t *testing.T) {
+	//Arrange
+	teardown := setupFeeHandlerTest(t)
+	defer teardown()
+	request = httptest.NewRequest(http.MethodDelete, feeSchedulesPath+"/4", nil)
+	mockFeeService.EXPECT().DeleteSchedule("4").Return(errs.NewConflictError("Fee schedule has already taken effect"))
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusConflict {
+		t.Errorf("Expected status code %d but got %d", http.StatusConflict, recorder.Result().StatusCode)
+	}
+}
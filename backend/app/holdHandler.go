@@ -0,0 +1,75 @@
+package app
+
+import (
+	"encoding/json"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/service"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+type HoldHandler struct {
+	service service.HoldService
+}
+
+func (h HoldHandler) holdsHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	response, appErr := h.service.GetHolds(vars["customer_id"], vars["account_id"])
+	if appErr != nil {
+		writeErrorResponse(w, r, appErr)
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, response)
+}
+
+func (h HoldHandler) newHoldHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	request := dto.NewHoldRequest{
+		CustomerId: vars["customer_id"],
+		AccountId:  vars["account_id"],
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		logger.Error("Error while decoding json body of new hold request: " + err.Error())
+		writeErrorResponse(w, r, errs.NewAppError(http.StatusBadRequest, "Please check that all fields are correctly filled."))
+		return
+	}
+
+	if valErr := request.Validate(); valErr != nil {
+		writeValidationErrorResponse(w, r, valErr)
+		return
+	}
+
+	response, appErr := h.service.PlaceHold(request)
+	if appErr != nil {
+		writeErrorResponse(w, r, appErr)
+		return
+	}
+
+	writeJsonResponse(w, http.StatusCreated, response)
+}
+
+func (h HoldHandler) captureHandler(w http.ResponseWriter, r *http.Request) {
+	h.end(w, r, h.service.CaptureHold)
+}
+
+func (h HoldHandler) releaseHandler(w http.ResponseWriter, r *http.Request) {
+	h.end(w, r, h.service.ReleaseHold)
+}
+
+// end ends the hold of the given request with the given service method.
+func (h HoldHandler) end(w http.ResponseWriter, r *http.Request, endFunc func(string) (*dto.HoldResponse, *errs.AppError)) {
+	vars := mux.Vars(r)
+
+	response, appErr := endFunc(vars["hold_id"])
+	if appErr != nil {
+		writeErrorResponse(w, r, appErr)
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, response)
+}
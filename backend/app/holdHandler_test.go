@@ -0,0 +1,125 @@
+package app
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/mocks/service"
+	"github.com/gorilla/mux"
+	"go.uber.org/mock/gomock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockHoldService *service.MockHoldService
+var hh HoldHandler
+
+const holdsPath = "/customers/2/account/1977/holds"
+
+func setupHoldHandlerTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	mockHoldService = service.NewMockHoldService(ctrl)
+	hh = HoldHandler{mockHoldService}
+
+	router = mux.NewRouter()
+	router.HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/holds", hh.holdsHandler).Methods(http.MethodGet)
+	router.HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/holds", hh.newHoldHandler).Methods(http.MethodPost)
+	router.HandleFunc("/holds/{hold_id:[0-9]+}/capture", hh.captureHandler).Methods(http.MethodPost)
+	router.HandleFunc("/holds/{hold_id:[0-9]+}/release", hh.releaseHandler).Methods(http.MethodPost)
+
+	recorder = httptest.NewRecorder()
+
+	return func() {
+		router = nil
+		recorder = nil
+		request = nil
+		defer ctrl.Finish()
+	}
+}
+
+func TestHoldHandler_newHoldHandler_respondsWith_statusCode422_when_request_invalid(t *testing.T) {
+	//Arrange
+	teardown := setupHoldHandlerTest(t)
+	defer teardown()
+	request = httptest.NewRequest(http.MethodPost, holdsPath, strings.NewReader(`{"amount": 500}`))
+	mockHoldService.EXPECT().PlaceHold(gomock.Any()).Times(0)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status code %d but got %d", http.StatusUnprocessableEntity, recorder.Result().StatusCode)
+	}
+}
+
+func TestHoldHandler_newHoldHandler_respondsWith_holdAndStatusCode201_when_service_succeeds(t *testing.T) {
+	//Arrange
+	teardown := setupHoldHandlerTest(t)
+	defer teardown()
+	request = httptest.NewRequest(http.MethodPost, holdsPath,
+		strings.NewReader(`{"amount": 500, "reason": "Card authorization", "duration_hours": 48}`))
+
+	expectedRequest := dto.NewHoldRequest{CustomerId: "2", AccountId: "1977", Amount: 500, Reason: "Card authorization", DurationHours: 48}
+	dummyResponse := dto.HoldResponse{HoldId: "4", AccountId: "1977", Amount: 500, Status: dto.HoldStatusActive}
+	mockHoldService.EXPECT().PlaceHold(expectedRequest).Return(&dummyResponse, nil)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusCreated {
+		t.Errorf("Expected status code %d but got %d", http.StatusCreated, recorder.Result().StatusCode)
+	}
+	actualResponse, _ := io.ReadAll(recorder.Result().Body)
+	if !strings.Contains(string(actualResponse), `"hold_id":"4"`) {
+		t.Errorf("Expected response to contain the new hold id but got %s", string(actualResponse))
+	}
+}
+
+func TestHoldHandler_captureHandler_respondsWith_capturedHold_when_service_succeeds(t *testing.T) {
+	//Arrange
+	teardown := setupHoldHandlerTest(t)
+	defer teardown()
+	request = httptest.NewRequest(http.MethodPost, "/holds/4/capture", nil)
+
+	dummyResponse := dto.HoldResponse{HoldId: "4", Status: dto.HoldStatusCaptured, TransactionId: "7791"}
+	mockHoldService.EXPECT().CaptureHold("4").Return(&dummyResponse, nil)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusOK {
+		t.Errorf("Expected status code %d but got %d", http.StatusOK, recorder.Result().StatusCode)
+	}
+	actualResponse, _ := io.ReadAll(recorder.Result().Body)
+	if !strings.Contains(string(actualResponse), `"transaction_id":"7791"`) {
+		t.Errorf("Expected response to contain the capturing transaction but got %s", string(actualResponse))
+	}
+}
+
+func TestHoldHandler_releaseHandler_respondsWith_errorStatusCode_when_service_fails(t *testing.T) {
+	//Arrange
+	teardown := setupHoldHandlerTest(t)
+	defer teardown()
+	request = httptest.NewRequest(http.MethodPost, "/holds/4/release", nil)
+
+	dummyAppErr := errs.NewConflictError("Hold has expired")
+	mockHoldService.EXPECT().ReleaseHold("4").Return(nil, dummyAppErr)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusConflict {
+		t.Errorf("Expected status code %d but got %d", http.StatusConflict, recorder.Result().StatusCode)
+	}
+	actualResponse, _ := io.ReadAll(recorder.Result().Body)
+	if !strings.Contains(string(actualResponse), dummyAppErr.Message) {
+		t.Errorf("Expected response to contain %s but got: %s", dummyAppErr.Message, actualResponse)
+	}
+}
@@ -0,0 +1,147 @@
+package app
+
+import (
+	"flag"
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/migrations"
+	"github.com/jmoiron/sqlx"
+	"os"
+	"time"
+)
+
+// checkSchemaVersion stops the app if the database schema is behind the migrations embedded in this binary, unless
+// MIGRATE_ON_START is set to "true", in which case the pending migrations are applied first. An embedded SQLite
+// database is always brought up to date and loaded with the demo data, so that the app can run with no database
+// server.
+func checkSchemaVersion(dbClient *sqlx.DB) {
+	waitForDb(dbClient)
+	migrator, err := migrations.NewMigrator(dbClient, clock.RealClock{})
+	if err != nil {
+		logger.Fatal("Error while loading migrations: " + err.Error())
+	}
+
+	if dbClient.DriverName() == domain.DriverSQLite {
+		if err = setupEmbeddedDb(migrator); err != nil {
+			logger.Fatal("Error while setting up embedded database: " + err.Error())
+		}
+		return
+	}
+
+	pending, err := migrator.Pending()
+	if err != nil {
+		logger.Fatal("Error while checking database schema version: " + err.Error())
+	}
+	if len(pending) == 0 {
+		return
+	}
+	if unversioned, err := migrator.IsUnversioned(); err != nil {
+		logger.Fatal("Error while checking database schema version: " + err.Error())
+	} else if unversioned {
+		logger.Fatal("Database schema was created before versioned migrations, run `go run main.go migrate baseline` " +
+			"and then `go run main.go migrate up` first")
+	}
+
+	if os.Getenv("MIGRATE_ON_START") != "true" {
+		logger.Fatal(fmt.Sprintf("Database schema is %d migration(s) behind, run `go run main.go migrate up` first",
+			len(pending)))
+	}
+	if _, err = migrator.Up(); err != nil {
+		logger.Fatal("Error while applying pending migrations: " + err.Error())
+	}
+}
+
+// setupEmbeddedDb applies all pending migrations and the demo data.
+func setupEmbeddedDb(migrator migrations.Migrator) error {
+	if _, err := migrator.Up(); err != nil {
+		return err
+	}
+	return migrator.Seed()
+}
+
+// waitForDb pings the database until it accepts connections, giving up after about 30 seconds. This lets the app
+// be started together with the database container during development.
+func waitForDb(dbClient *sqlx.DB) {
+	var err error
+	for attempt := 0; attempt < 30; attempt++ {
+		if err = dbClient.Ping(); err == nil {
+			return
+		}
+		time.Sleep(time.Second)
+	}
+	logger.Fatal("Error while connecting to database: " + err.Error())
+}
+
+// runMigrate applies, reverts or lists the schema migrations, adopts a schema created before versioned migrations, or
+// loads the demo data, depending on the subcommand: `migrate up`, `migrate down [-steps n]`, `migrate status`,
+// `migrate baseline [-version n]` or `migrate seed`.
+func runMigrate(args []string) {
+	if len(args) == 0 {
+		logger.Fatal("Missing migrate subcommand (up, down, status, baseline or seed)")
+	}
+	flags := flag.NewFlagSet("migrate "+args[0], flag.ExitOnError)
+	steps := flags.Int("steps", 1, "number of migrations to revert (down only)")
+	version := flags.Int("version", 1, "latest migration that the existing schema already has (baseline only)")
+	_ = flags.Parse(args[1:]) //exits on error
+
+	checkEnvVars(dbEnvVars)
+	if os.Getenv("DB_DRIVER") == "stub" { //lets the run scripts be used unchanged in stub mode
+		fmt.Println("Stub mode has no database to migrate")
+		return
+	}
+	dbClient := getDbClient()
+	defer dbClient.Close()
+	waitForDb(dbClient)
+
+	migrator, err := migrations.NewMigrator(dbClient, clock.RealClock{})
+	if err != nil {
+		logger.Fatal("Error while loading migrations: " + err.Error())
+	}
+
+	switch args[0] {
+	case "up":
+		if unversioned, err := migrator.IsUnversioned(); err != nil {
+			logger.Fatal(err.Error())
+		} else if unversioned {
+			logger.Fatal("Database schema was created before versioned migrations, run `go run main.go migrate baseline` first")
+		}
+		applied, err := migrator.Up()
+		if err != nil {
+			logger.Fatal(err.Error())
+		}
+		fmt.Printf("Applied %d migration(s)\n", len(applied))
+	case "down":
+		reverted, err := migrator.Down(*steps)
+		if err != nil {
+			logger.Fatal(err.Error())
+		}
+		fmt.Printf("Reverted %d migration(s)\n", len(reverted))
+	case "status":
+		statuses, err := migrator.Status()
+		if err != nil {
+			logger.Fatal(err.Error())
+		}
+		for _, s := range statuses {
+			appliedOn := "pending"
+			if s.IsApplied {
+				appliedOn = "applied on " + s.AppliedOn
+			}
+			fmt.Printf("%04d %-40s %s\n", s.Version, s.Name, appliedOn)
+		}
+	case "baseline":
+		recorded, err := migrator.Baseline(*version)
+		if err != nil {
+			logger.Fatal(err.Error())
+		}
+		fmt.Printf("Recorded %d migration(s) as applied\n", len(recorded))
+	case "seed":
+		if err = migrator.Seed(); err != nil {
+			logger.Fatal("Error while loading demo data: " + err.Error())
+		}
+		fmt.Println("Loaded demo data")
+	default:
+		logger.Fatal(fmt.Sprintf("Unknown migrate subcommand %s", args[0]))
+	}
+}
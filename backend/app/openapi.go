@@ -0,0 +1,298 @@
+package app
+
+import (
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/openapi"
+	"github.com/aliciatay-zls/banking/backend/problem"
+	"github.com/gorilla/mux"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const apiDocumentRouteName = "GetApiDocument"
+const apiSecuritySchemeName = "accessToken"
+
+// apiOperation describes a route, by its name, in the OpenAPI document. The path and methods are taken from the
+// route itself.
+type apiOperation struct {
+	summary  string
+	tag      string
+	query    []openapi.Parameter
+	request  interface{} //DTO decoded from the JSON body, if any
+	csv      bool        //whether the body is a CSV file instead
+	status   int         //of a successful response
+	response interface{} //DTO written in a successful response
+	approval bool        //whether the operation can be held for the approval of a second admin (202)
+	public   bool        //whether the operation needs no access token
+}
+
+// apiOperations describes every route. TestApp_apiDocument_has_every_route fails for a route without an entry.
+var apiOperations = map[string]apiOperation{
+	"GetAllCustomers": {summary: "List customers, optionally by status", tag: "customers",
+		query: []openapi.Parameter{{Name: "status", In: "query",
+			Schema: &openapi.Schema{Type: openapi.TypeString, Enum: []string{"active", "inactive"}}}},
+		status: http.StatusOK, response: []dto.CustomerResponse{}},
+	"GetAccountsForCustomer": {summary: "List the accounts of a customer", tag: "accounts",
+		status: http.StatusOK, response: []dto.AccountResponse{}},
+	"GetCustomer": {summary: "Get the profile of a customer", tag: "customers",
+		status: http.StatusOK, response: dto.CustomerResponse{}},
+	"GetCustomerAnalytics": {summary: "Sum the income and spending of a customer by month, category and type", tag: "analytics",
+		query: []openapi.Parameter{
+			{Name: "from", In: "query", Description: "First month covered",
+				Schema: &openapi.Schema{Type: openapi.TypeString, Pattern: "^[0-9]{4}-[0-9]{2}$"}},
+			{Name: "to", In: "query", Description: "Last month covered, the current month if not given",
+				Schema: &openapi.Schema{Type: openapi.TypeString, Pattern: "^[0-9]{4}-[0-9]{2}$"}},
+		},
+		status: http.StatusOK, response: dto.AnalyticsResponse{}},
+	"GetPayees": {summary: "List the payees of a customer", tag: "payees",
+		status: http.StatusOK, response: []dto.PayeeResponse{}},
+	"NewPayee": {summary: "Add a payee", tag: "payees",
+		request: dto.NewPayeeRequest{}, status: http.StatusCreated, response: dto.PayeeResponse{}},
+	"GetPayeeAuditTrail": {summary: "List the changes to the payee book of a customer", tag: "payees",
+		status: http.StatusOK, response: []dto.AuditEntryResponse{}},
+	"RenamePayee": {summary: "Rename a payee", tag: "payees",
+		request: dto.PayeeNicknameRequest{}, status: http.StatusOK, response: dto.PayeeResponse{}},
+	"DeletePayee": {summary: "Delete a payee", tag: "payees",
+		status: http.StatusOK, response: errs.MessageObject{}},
+	"NewAccount": {summary: "Open an account", tag: "accounts",
+		request: dto.NewAccountRequest{}, status: http.StatusCreated, response: dto.NewAccountResponse{}},
+	"NewTransaction": {summary: "Make a withdrawal or deposit", tag: "transactions",
+		request: dto.TransactionRequest{}, status: http.StatusCreated, response: dto.TransactionResponse{}, approval: true},
+	"GetTransactions": {summary: "List the transactions of an account", tag: "transactions",
+		status: http.StatusOK, response: []dto.AccountTransactionResponse{}},
+	"NewTransfer": {summary: "Transfer to a payee", tag: "payees",
+		request: dto.TransferRequest{}, status: http.StatusCreated, response: dto.TransferResponse{}},
+	"UpdateTransactionCategory": {summary: "Change the description, reference or category of a transaction", tag: "transactions",
+		request: dto.TransactionCategoryRequest{}, status: http.StatusOK, response: dto.AccountTransactionResponse{}},
+	"GetAlertRules": {summary: "List the alert rules of an account", tag: "alerts",
+		status: http.StatusOK, response: []dto.AlertRuleResponse{}},
+	"NewAlertRule": {summary: "Add an alert rule to an account", tag: "alerts",
+		request: dto.NewAlertRuleRequest{}, status: http.StatusCreated, response: dto.AlertRuleResponse{}},
+	"DeleteAlertRule": {summary: "Delete an alert rule", tag: "alerts",
+		status: http.StatusOK, response: errs.MessageObject{}},
+	"GetHolds": {summary: "List the holds on an account", tag: "holds",
+		status: http.StatusOK, response: []dto.HoldResponse{}},
+	"NewHold": {summary: "Put funds on hold", tag: "holds",
+		request: dto.NewHoldRequest{}, status: http.StatusCreated, response: dto.HoldResponse{}},
+	"CaptureHold": {summary: "Withdraw the funds on hold", tag: "holds",
+		status: http.StatusOK, response: dto.HoldResponse{}},
+	"ReleaseHold": {summary: "Release the funds on hold", tag: "holds",
+		status: http.StatusOK, response: dto.HoldResponse{}},
+	"GetOverdraft": {summary: "Get the overdraft of an account", tag: "overdrafts",
+		status: http.StatusOK, response: dto.OverdraftResponse{}},
+	"SetOverdraftLimit": {summary: "Set the overdraft limit of a checking account", tag: "overdrafts",
+		request: dto.OverdraftLimitRequest{}, status: http.StatusOK, response: dto.OverdraftResponse{}},
+	"GetFeeSchedules": {summary: "List the fee schedules", tag: "fees",
+		status: http.StatusOK, response: []dto.FeeScheduleResponse{}},
+	"NewFeeSchedule": {summary: "Add a fee schedule", tag: "fees",
+		request: dto.NewFeeScheduleRequest{}, status: http.StatusCreated, response: dto.FeeScheduleResponse{}},
+	"DeleteFeeSchedule": {summary: "Delete a fee schedule", tag: "fees",
+		status: http.StatusOK, response: errs.MessageObject{}},
+	"GetAlerts": {summary: "List the alerts of a customer", tag: "alerts",
+		status: http.StatusOK, response: []dto.AlertResponse{}},
+	"GetTransactionReviews": {summary: "List the transactions held for review", tag: "reviews",
+		status: http.StatusOK, response: []dto.TransactionReviewResponse{}},
+	"ApproveTransactionReview": {summary: "Approve a transaction held for review", tag: "reviews",
+		request: dto.TransactionReviewRequest{}, status: http.StatusOK, response: dto.TransactionReviewResponse{}},
+	"RejectTransactionReview": {summary: "Reject a transaction held for review", tag: "reviews",
+		request: dto.TransactionReviewRequest{}, status: http.StatusOK, response: dto.TransactionReviewResponse{}},
+	"ReverseTransaction": {summary: "Reverse a posted transaction", tag: "transactions",
+		request: dto.TransactionReversalRequest{}, status: http.StatusCreated, response: dto.TransactionReversalResponse{},
+		approval: true},
+	"GetApprovalRequests": {summary: "List the operations waiting for the approval of a second admin", tag: "approvals",
+		status: http.StatusOK, response: []dto.ApprovalResponse{}},
+	"ApproveApprovalRequest": {summary: "Approve and carry out an operation", tag: "approvals",
+		status: http.StatusOK, response: dto.ApprovalResponse{}},
+	"RejectApprovalRequest": {summary: "Reject an operation", tag: "approvals",
+		status: http.StatusOK, response: dto.ApprovalResponse{}},
+	"ImportTransactions": {summary: "Check or import a CSV file of transactions", tag: "imports",
+		query: []openapi.Parameter{{Name: "mode", In: "query", Schema: &openapi.Schema{Type: openapi.TypeString,
+			Enum: []string{dto.TransactionImportModeDryRun, dto.TransactionImportModeCommit}}}},
+		csv: true, status: http.StatusOK, response: dto.TransactionImportResponse{}, approval: true},
+	"GetReconciliationReport": {summary: "Reconcile the balance of every account with its transactions", tag: "reconciliation",
+		status: http.StatusOK, response: dto.ReconciliationReportResponse{}},
+	"FreezeMismatchedAccounts": {summary: "Freeze the accounts that do not reconcile", tag: "reconciliation",
+		status: http.StatusAccepted, response: dto.ApprovalResponse{}},
+	"NewWebhookSubscription": {summary: "Subscribe a webhook to events", tag: "webhooks",
+		request: dto.NewWebhookSubscriptionRequest{}, status: http.StatusAccepted, response: dto.ApprovalResponse{}},
+	"GetWebhookSubscriptions": {summary: "List the webhook subscriptions", tag: "webhooks",
+		status: http.StatusOK, response: []dto.WebhookSubscriptionResponse{}},
+	"DeleteWebhookSubscription": {summary: "Delete a webhook subscription", tag: "webhooks",
+		status: http.StatusAccepted, response: dto.ApprovalResponse{}},
+	"GetWebhookDeliveries": {summary: "List the deliveries of a webhook subscription", tag: "webhooks",
+		status: http.StatusOK, response: []dto.WebhookDeliveryResponse{}},
+	"GetWebhookDelivery": {summary: "Get a webhook delivery and its attempts", tag: "webhooks",
+		status: http.StatusOK, response: dto.WebhookDeliveryResponse{}},
+	"RedeliverWebhook": {summary: "Send a webhook delivery again", tag: "webhooks",
+		status: http.StatusOK, response: dto.WebhookDeliveryResponse{}},
+	apiDocumentRouteName: {summary: "Get this OpenAPI document", tag: "meta",
+		status: http.StatusOK, response: map[string]interface{}{}, public: true},
+	problemCatalogRouteName: {summary: "List the types of errors by code", tag: "meta",
+		status: http.StatusOK, response: []dto.ProblemTypeResponse{}, public: true},
+	problemTypeRouteName: {summary: "Get the type of error with a code", tag: "meta",
+		status: http.StatusOK, response: dto.ProblemTypeResponse{}, public: true},
+}
+
+// newApiDocument builds the OpenAPI document of the routes of the given router. Routes without an entry in
+// apiOperations are logged and left out.
+func newApiDocument(router *mux.Router) *openapi.Document {
+	doc := openapi.NewDocument(openapi.Info{
+		Title:       "Banking API",
+		Description: "Customers, accounts and transactions of the banking app.",
+		Version:     "1.0.0",
+	})
+	if serverDomain := os.Getenv("SERVER_DOMAIN"); serverDomain != "" {
+		doc.Servers = []openapi.Server{{Url: "https://" + serverDomain}}
+	}
+	doc.Components.SecuritySchemes = map[string]openapi.SecurityScheme{
+		apiSecuritySchemeName: {Type: "apiKey", In: "header", Name: "Authorization",
+			Description: "Access token from the auth server, sent as is."},
+	}
+	doc.Security = []openapi.SecurityRequirement{{apiSecuritySchemeName: []string{}}}
+
+	err := router.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		pathTemplate, pathErr := route.GetPathTemplate()
+		methods, methodsErr := route.GetMethods()
+		if route.GetName() == "" || pathErr != nil || methodsErr != nil { //e.g. a subrouter
+			return nil
+		}
+		apiOp, ok := apiOperations[route.GetName()]
+		if !ok {
+			logger.Error(fmt.Sprintf("Route %s has no entry in the API document", route.GetName()))
+			return nil
+		}
+		for _, method := range methods {
+			if method == http.MethodOptions {
+				continue
+			}
+			op := newOperation(doc, route.GetName(), apiOp, pathTemplate)
+			if isLegacyRoute(ancestors) {
+				op.OperationId += "Legacy" //operation IDs must be unique, unlike route names
+				op.Deprecated = true
+			}
+			doc.AddOperation(method, pathTemplate, op)
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error("Error while building API document: " + err.Error())
+	}
+	return doc
+}
+
+// newOperation builds the operation of a route from its entry in apiOperations.
+func newOperation(doc *openapi.Document, name string, apiOp apiOperation, pathTemplate string) *openapi.Operation {
+	op := &openapi.Operation{
+		OperationId: name,
+		Summary:     apiOp.summary,
+		Tags:        []string{apiOp.tag},
+		Parameters:  append([]openapi.Parameter{}, apiOp.query...),
+		Responses:   make(map[string]openapi.Response),
+	}
+	if apiOp.public {
+		op.Security = &[]openapi.SecurityRequirement{}
+	}
+
+	if apiOp.request != nil {
+		schema := doc.SchemaOf(apiOp.request)
+		excludePathFields(doc, schema, pathTemplate)
+		op.RequestBody = &openapi.RequestBody{Required: true,
+			Content: map[string]openapi.MediaType{openapi.MediaTypeJson: {Schema: schema}}}
+	}
+	if apiOp.csv {
+		op.RequestBody = &openapi.RequestBody{Required: true,
+			Content: map[string]openapi.MediaType{"text/csv": {Schema: &openapi.Schema{Type: openapi.TypeString}}}}
+	}
+
+	op.Responses[strconv.Itoa(apiOp.status)] = openapi.Response{Description: http.StatusText(apiOp.status),
+		Content: map[string]openapi.MediaType{openapi.MediaTypeJson: {Schema: doc.SchemaOf(apiOp.response)}}}
+	if apiOp.approval {
+		op.Responses[strconv.Itoa(http.StatusAccepted)] = openapi.Response{
+			Description: "Held for the approval of a second admin",
+			Content: map[string]openapi.MediaType{
+				openapi.MediaTypeJson: {Schema: doc.SchemaOf(dto.ApprovalResponse{})}}}
+	}
+	if apiOp.public {
+		return op
+	}
+
+	errorStatuses := []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests,
+		http.StatusInternalServerError}
+	if strings.Contains(pathTemplate, "{") {
+		errorStatuses = append(errorStatuses, http.StatusNotFound)
+	}
+	if op.RequestBody != nil || len(apiOp.query) > 0 {
+		errorStatuses = append(errorStatuses, http.StatusBadRequest, http.StatusUnprocessableEntity)
+	}
+	for _, status := range errorStatuses {
+		var body interface{} = errs.MessageObject{}
+		if status == http.StatusUnprocessableEntity {
+			body = dto.ValidationErrorResponse{} //errors is left out if the error is not about the fields
+		}
+		response := openapi.Response{Description: http.StatusText(status),
+			Content: map[string]openapi.MediaType{
+				openapi.MediaTypeJson: {Schema: doc.SchemaOf(body)},
+				problem.MediaType:     {Schema: doc.SchemaOf(dto.ProblemResponse{})}, //if accepted by the client
+			}}
+		if status == http.StatusTooManyRequests {
+			response.Headers = map[string]openapi.Header{"Retry-After": {Description: "Seconds until a request is allowed",
+				Schema: &openapi.Schema{Type: openapi.TypeInteger}}}
+		}
+		op.Responses[strconv.Itoa(status)] = response
+	}
+	return op
+}
+
+// excludePathFields removes the fields that the handler takes from the path, e.g. customer_id, from the required
+// fields of the given request body schema.
+func excludePathFields(doc *openapi.Document, schema *openapi.Schema, pathTemplate string) {
+	body, err := doc.Resolve(schema)
+	if err != nil || body == nil {
+		return
+	}
+	var required []string
+	for _, field := range body.Required {
+		if !strings.Contains(pathTemplate, "{"+field+":") && !strings.Contains(pathTemplate, "{"+field+"}") {
+			required = append(required, field)
+		}
+	}
+	body.Required = required
+}
+
+// apiDocumentHandler responds with the given OpenAPI document.
+func apiDocumentHandler(doc *openapi.Document) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeJsonResponse(w, http.StatusOK, doc)
+	}
+}
+
+// ApiValidationMiddleware rejects requests that do not match the OpenAPI document before they reach the auth server
+// and the handlers. It is only used if OPENAPI_VALIDATE_REQUESTS is "true", as the handlers validate requests anyway.
+type ApiValidationMiddleware struct {
+	doc *openapi.Document
+}
+
+func (m ApiValidationMiddleware) ApiValidationMiddlewareHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pathTemplate, err := mux.CurrentRoute(r).GetPathTemplate()
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		op, ok := m.doc.Operation(r.Method, openapi.PathOf(pathTemplate))
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if appErr := m.doc.ValidateRequest(op, r); appErr != nil {
+			writeErrorResponse(w, r, appErr)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,90 @@
+package app
+
+import (
+	"encoding/json"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/openapi"
+	"github.com/gorilla/mux"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestApp_apiDocument_has_every_route(t *testing.T) {
+	//Arrange
+	teardown := setupAppTest(t)
+	defer teardown()
+
+	//Act
+	doc := newApiDocument(router)
+
+	//Assert
+	err := router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		if route.GetName() == "" {
+			return nil
+		}
+		template, _ := route.GetPathTemplate()
+		methods, _ := route.GetMethods()
+		for _, method := range methods {
+			if method == http.MethodOptions {
+				continue
+			}
+			if _, ok := doc.Operation(method, openapi.PathOf(template)); !ok {
+				t.Errorf("Expected %s %s (%s) to be in the API document but it was not", method, template, route.GetName())
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error while walking the router: %s", err.Error())
+	}
+}
+
+func TestApp_apiDocument_is_served_without_accessToken(t *testing.T) {
+	//Arrange
+	teardown := setupAppTest(t)
+	defer teardown()
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/openapi.json", nil)
+	var doc openapi.Document
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d but got %d", http.StatusOK, recorder.Code)
+	}
+	if err := json.NewDecoder(recorder.Body).Decode(&doc); err != nil {
+		t.Fatalf("Error while decoding API document: %s", err.Error())
+	}
+	if doc.OpenApi != openapi.Version {
+		t.Errorf("Expected OpenAPI version %s but got %s", openapi.Version, doc.OpenApi)
+	}
+	if _, ok := doc.Operation(http.MethodPost, "/customers/{customer_id}/account/{account_id}"); !ok {
+		t.Error("Expected NewTransaction to be in the served API document but it was not")
+	}
+}
+
+func TestApp_ApiValidationMiddleware_rejects_request_not_matching_apiDocument(t *testing.T) {
+	//Arrange
+	t.Setenv("OPENAPI_VALIDATE_REQUESTS", "true")
+	teardown := setupAppTest(t)
+	defer teardown()
+
+	var response errs.MessageObject
+
+	//Act
+	statusCode := serve(t, http.MethodPost, "/customers/"+seededCustomerId+"/account/"+seededAccountId,
+		`{"transaction_type": "withdrawal", "amount": 20000}`, &response)
+
+	//Assert
+	if statusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("Expected status code %d but got %d", http.StatusUnprocessableEntity, statusCode)
+	}
+	if !strings.Contains(response.Message, "Request does not match the API specification") {
+		t.Errorf("Expected error about the API specification but got %s", response.Message)
+	}
+}
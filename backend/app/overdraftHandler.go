@@ -0,0 +1,54 @@
+package app
+
+import (
+	"encoding/json"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/service"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+type OverdraftHandler struct {
+	service service.OverdraftService
+}
+
+func (h OverdraftHandler) overdraftHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	response, appErr := h.service.GetOverdraft(vars["customer_id"], vars["account_id"])
+	if appErr != nil {
+		writeErrorResponse(w, r, appErr)
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, response)
+}
+
+func (h OverdraftHandler) limitHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	request := dto.OverdraftLimitRequest{
+		CustomerId: vars["customer_id"],
+		AccountId:  vars["account_id"],
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		logger.Error("Error while decoding json body of overdraft limit request: " + err.Error())
+		writeErrorResponse(w, r, errs.NewAppError(http.StatusBadRequest, "Please check that all fields are correctly filled."))
+		return
+	}
+
+	if valErr := request.Validate(); valErr != nil {
+		writeValidationErrorResponse(w, r, valErr)
+		return
+	}
+
+	response, appErr := h.service.SetLimit(request)
+	if appErr != nil {
+		writeErrorResponse(w, r, appErr)
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, response)
+}
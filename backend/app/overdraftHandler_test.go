@@ -0,0 +1,119 @@
+package app
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/mocks/service"
+	"github.com/gorilla/mux"
+	"go.uber.org/mock/gomock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockOverdraftService *service.MockOverdraftService
+var oh OverdraftHandler
+
+const overdraftPath = "/customers/2/account/1977/overdraft"
+
+func setupOverdraftHandlerTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	mockOverdraftService = service.NewMockOverdraftService(ctrl)
+	oh = OverdraftHandler{mockOverdraftService}
+
+	router = mux.NewRouter()
+	router.HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/overdraft", oh.overdraftHandler).Methods(http.MethodGet)
+	router.HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/overdraft", oh.limitHandler).Methods(http.MethodPost)
+
+	recorder = httptest.NewRecorder()
+
+	return func() {
+		router = nil
+		recorder = nil
+		request = nil
+		defer ctrl.Finish()
+	}
+}
+
+func TestOverdraftHandler_overdraftHandler_respondsWith_usageAndStatusCode200_when_service_succeeds(t *testing.T) {
+	//Arrange
+	teardown := setupOverdraftHandlerTest(t)
+	defer teardown()
+	request = httptest.NewRequest(http.MethodGet, overdraftPath, nil)
+
+	dummyResponse := dto.OverdraftResponse{AccountId: "1977", OverdraftLimit: 2000, OverdrawnAmount: 500, AvailableOverdraft: 1500}
+	mockOverdraftService.EXPECT().GetOverdraft("2", "1977").Return(&dummyResponse, nil)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusOK {
+		t.Errorf("Expected status code %d but got %d", http.StatusOK, recorder.Result().StatusCode)
+	}
+	actualResponse, _ := io.ReadAll(recorder.Result().Body)
+	if !strings.Contains(string(actualResponse), `"available_overdraft":1500`) {
+		t.Errorf("Expected response to contain the available overdraft but got %s", string(actualResponse))
+	}
+}
+
+func TestOverdraftHandler_limitHandler_respondsWith_statusCode422_when_request_invalid(t *testing.T) {
+	//Arrange
+	teardown := setupOverdraftHandlerTest(t)
+	defer teardown()
+	request = httptest.NewRequest(http.MethodPost, overdraftPath, strings.NewReader(`{"overdraft_limit": 10000.01}`))
+	mockOverdraftService.EXPECT().SetLimit(gomock.Any()).Times(0)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status code %d but got %d", http.StatusUnprocessableEntity, recorder.Result().StatusCode)
+	}
+}
+
+func TestOverdraftHandler_limitHandler_respondsWith_serviceError_when_service_fails(t *testing.T) {
+	//Arrange
+	teardown := setupOverdraftHandlerTest(t)
+	defer teardown()
+	request = httptest.NewRequest(http.MethodPost, overdraftPath, strings.NewReader(`{"overdraft_limit": 500}`))
+
+	expectedRequest := dto.OverdraftLimitRequest{CustomerId: "2", AccountId: "1977", Limit: 500}
+	mockOverdraftService.EXPECT().SetLimit(expectedRequest).
+		Return(nil, errs.NewValidationError("An overdraft can only be granted on a checking account"))
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status code %d but got %d", http.StatusUnprocessableEntity, recorder.Result().StatusCode)
+	}
+}
+
+func TestOverdraftHandler_limitHandler_respondsWith_usageAndStatusCode200_when_service_succeeds(t *testing.T) {
+	//Arrange
+	teardown := setupOverdraftHandlerTest(t)
+	defer teardown()
+	request = httptest.NewRequest(http.MethodPost, overdraftPath, strings.NewReader(`{"overdraft_limit": 500}`))
+
+	expectedRequest := dto.OverdraftLimitRequest{CustomerId: "2", AccountId: "1977", Limit: 500}
+	dummyResponse := dto.OverdraftResponse{AccountId: "1977", OverdraftLimit: 500, AvailableOverdraft: 500}
+	mockOverdraftService.EXPECT().SetLimit(expectedRequest).Return(&dummyResponse, nil)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusOK {
+		t.Errorf("Expected status code %d but got %d", http.StatusOK, recorder.Result().StatusCode)
+	}
+	actualResponse, _ := io.ReadAll(recorder.Result().Body)
+	if !strings.Contains(string(actualResponse), `"overdraft_limit":500`) {
+		t.Errorf("Expected response to contain the new overdraft limit but got %s", string(actualResponse))
+	}
+}
@@ -0,0 +1,103 @@
+package app
+
+import (
+	"encoding/json"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/service"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+type PayeeHandler struct {
+	service service.PayeeService
+}
+
+func (h PayeeHandler) payeesHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	response, appErr := h.service.GetPayees(vars["customer_id"])
+	if appErr != nil {
+		writeErrorResponse(w, r, appErr)
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, response)
+}
+
+func (h PayeeHandler) newPayeeHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	request := dto.NewPayeeRequest{CustomerId: vars["customer_id"]}
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		logger.Error("Error while decoding json body of new payee request: " + err.Error())
+		writeErrorResponse(w, r, errs.NewAppError(http.StatusBadRequest, "Please check that all fields are correctly filled."))
+		return
+	}
+	request.CreatedBy = requestClaims(r).Username
+
+	if valErr := request.Validate(); valErr != nil {
+		writeValidationErrorResponse(w, r, valErr)
+		return
+	}
+
+	response, appErr := h.service.AddPayee(request)
+	if appErr != nil {
+		writeErrorResponse(w, r, appErr)
+		return
+	}
+
+	writeJsonResponse(w, http.StatusCreated, response)
+}
+
+func (h PayeeHandler) renamePayeeHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	request := dto.PayeeNicknameRequest{
+		CustomerId: vars["customer_id"],
+		PayeeId:    vars["payee_id"],
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		logger.Error("Error while decoding json body of payee nickname request: " + err.Error())
+		writeErrorResponse(w, r, errs.NewAppError(http.StatusBadRequest, "Please check that all fields are correctly filled."))
+		return
+	}
+	request.ChangedBy = requestClaims(r).Username
+
+	if valErr := request.Validate(); valErr != nil {
+		writeValidationErrorResponse(w, r, valErr)
+		return
+	}
+
+	response, appErr := h.service.RenamePayee(request)
+	if appErr != nil {
+		writeErrorResponse(w, r, appErr)
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, response)
+}
+
+func (h PayeeHandler) deletePayeeHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	if appErr := h.service.DeletePayee(vars["customer_id"], vars["payee_id"], requestClaims(r).Username); appErr != nil {
+		writeErrorResponse(w, r, appErr)
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, errs.NewMessageObject("Payee deleted"))
+}
+
+func (h PayeeHandler) auditTrailHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	response, appErr := h.service.GetAuditTrail(vars["customer_id"])
+	if appErr != nil {
+		writeErrorResponse(w, r, appErr)
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, response)
+}
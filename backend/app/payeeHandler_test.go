@@ -0,0 +1,125 @@
+package app
+
+import (
+	"context"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/mocks/service"
+	"github.com/gorilla/mux"
+	"go.uber.org/mock/gomock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockPayeeService *service.MockPayeeService
+var ph PayeeHandler
+
+const payeesPath = "/customers/2/payees"
+
+func setupPayeeHandlerTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	mockPayeeService = service.NewMockPayeeService(ctrl)
+	ph = PayeeHandler{mockPayeeService}
+
+	router = mux.NewRouter()
+	router.HandleFunc("/customers/{customer_id:[0-9]+}/payees", ph.newPayeeHandler).Methods(http.MethodPost)
+	router.HandleFunc("/customers/{customer_id:[0-9]+}/payees/{payee_id:[0-9]+}", ph.renamePayeeHandler).Methods(http.MethodPatch)
+	router.HandleFunc("/customers/{customer_id:[0-9]+}/payees/{payee_id:[0-9]+}", ph.deletePayeeHandler).Methods(http.MethodDelete)
+
+	recorder = httptest.NewRecorder()
+
+	return func() {
+		router = nil
+		recorder = nil
+		request = nil
+		defer ctrl.Finish()
+	}
+}
+
+// newCustomerRequest returns a request with the given body that has passed through the auth middleware with the
+// token of the user of the customer with id 2.
+func newCustomerRequest(method string, path string, body string) *http.Request {
+	claims := domain.AuthClaims{CustomerId: "2", Username: "2"}
+	r := httptest.NewRequest(method, path, strings.NewReader(body))
+	return r.WithContext(context.WithValue(r.Context(), authClaimsKey{}, claims))
+}
+
+func TestPayeeHandler_newPayeeHandler_respondsWith_statusCode422_when_request_invalid(t *testing.T) {
+	//Arrange
+	teardown := setupPayeeHandlerTest(t)
+	defer teardown()
+	request = newCustomerRequest(http.MethodPost, payeesPath, `{"nickname": "Mum", "account_id": "abc"}`)
+
+	mockPayeeService.EXPECT().AddPayee(gomock.Any()).Times(0)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status code %d but got %d", http.StatusUnprocessableEntity, recorder.Result().StatusCode)
+	}
+}
+
+func TestPayeeHandler_newPayeeHandler_respondsWith_payeeAndStatusCode201_when_service_succeeds(t *testing.T) {
+	//Arrange
+	teardown := setupPayeeHandlerTest(t)
+	defer teardown()
+	request = newCustomerRequest(http.MethodPost, payeesPath, `{"nickname": "Mum", "account_id": "95470", "owner_name": "Steve"}`)
+
+	expectedRequest := dto.NewPayeeRequest{CustomerId: "2", Nickname: "Mum", AccountId: "95470", OwnerName: "Steve", CreatedBy: "2"}
+	dummyResponse := dto.PayeeResponse{PayeeId: "3", Nickname: "Mum", AccountId: "95470", Status: dto.PayeeStatusPendingConfirmation}
+	mockPayeeService.EXPECT().AddPayee(expectedRequest).Return(&dummyResponse, nil)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusCreated {
+		t.Errorf("Expected status code %d but got %d", http.StatusCreated, recorder.Result().StatusCode)
+	}
+	actualResponse, _ := io.ReadAll(recorder.Result().Body)
+	if !strings.Contains(string(actualResponse), `"payee_id":"3"`) {
+		t.Errorf("Expected response to contain the new payee id but got %s", string(actualResponse))
+	}
+}
+
+func TestPayeeHandler_renamePayeeHandler_passes_payee_and_user_to_service(t *testing.T) {
+	//Arrange
+	teardown := setupPayeeHandlerTest(t)
+	defer teardown()
+	request = newCustomerRequest(http.MethodPatch, payeesPath+"/3", `{"nickname": "Mother"}`)
+
+	expectedRequest := dto.PayeeNicknameRequest{CustomerId: "2", PayeeId: "3", Nickname: "Mother", ChangedBy: "2"}
+	mockPayeeService.EXPECT().RenamePayee(expectedRequest).Return(&dto.PayeeResponse{PayeeId: "3", Nickname: "Mother"}, nil)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusOK {
+		t.Errorf("Expected status code %d but got %d", http.StatusOK, recorder.Result().StatusCode)
+	}
+}
+
+func TestPayeeHandler_deletePayeeHandler_respondsWith_errorStatusCode_when_service_fails(t *testing.T) {
+	//Arrange
+	teardown := setupPayeeHandlerTest(t)
+	defer teardown()
+	request = newCustomerRequest(http.MethodDelete, payeesPath+"/3", "")
+
+	mockPayeeService.EXPECT().DeletePayee("2", "3", "2").Return(errs.NewNotFoundError("Payee not found"))
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusNotFound {
+		t.Errorf("Expected status code %d but got %d", http.StatusNotFound, recorder.Result().StatusCode)
+	}
+}
@@ -0,0 +1,113 @@
+package app
+
+import (
+	"encoding/json"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/problem"
+	"github.com/gorilla/mux"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const problemCatalogRouteName = "GetProblemTypes"
+const problemTypeRouteName = "GetProblemType"
+
+// writeErrorResponse responds with the given error as problem details if the client accepts
+// application/problem+json, or else as {"message": ...} like it always has.
+func writeErrorResponse(w http.ResponseWriter, r *http.Request, appErr *errs.AppError) {
+	w.Header().Add("Vary", "Accept")
+	if !acceptsProblem(r) {
+		writeJsonResponse(w, appErr.Code, appErr.AsMessage())
+		return
+	}
+	writeProblemResponse(w, r, appErr, nil)
+}
+
+// writeValidationErrorResponse is writeErrorResponse for a request that failed validation, which adds every field in
+// error to the response.
+func writeValidationErrorResponse(w http.ResponseWriter, r *http.Request, valErr *dto.ValidationError) {
+	w.Header().Add("Vary", "Accept")
+	if !acceptsProblem(r) {
+		writeJsonResponse(w, valErr.Code, dto.ValidationErrorResponse{Message: valErr.Message, Errors: valErr.Errors})
+		return
+	}
+	writeProblemResponse(w, r, valErr.AppError, valErr.Errors)
+}
+
+func writeProblemResponse(w http.ResponseWriter, r *http.Request, appErr *errs.AppError, fieldErrors []dto.FieldError) {
+	t := problem.Of(appErr)
+	w.Header().Add("Content-Type", problem.MediaType)
+	w.WriteHeader(appErr.Code)
+	response := dto.ProblemResponse{
+		Type:      t.Uri(),
+		Title:     t.Title,
+		Status:    appErr.Code,
+		Detail:    appErr.Message,
+		Instance:  r.URL.Path,
+		Code:      t.Code,
+		RequestId: requestId(r),
+		Errors:    fieldErrors,
+	}
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		panic(err)
+	}
+}
+
+// acceptsProblem reports whether application/problem+json is one of the media types in the Accept header of the
+// given request, without a quality of 0.
+func acceptsProblem(r *http.Request) bool {
+	for _, accept := range r.Header.Values("Accept") {
+		for _, mediaRange := range strings.Split(accept, ",") {
+			mediaType, params, err := mime.ParseMediaType(mediaRange)
+			if err != nil || mediaType != problem.MediaType {
+				continue
+			}
+			if q, err := strconv.ParseFloat(params["q"], 64); err == nil && q == 0 {
+				continue
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// notFoundHandler responds to requests that match no route.
+func notFoundHandler(w http.ResponseWriter, r *http.Request) {
+	logger.Error("No route matches " + r.Method + " " + r.URL.Path)
+	writeErrorResponse(w, r, problem.NewNotFoundError(problem.RouteNotFound, "Route not found"))
+}
+
+// methodNotAllowedHandler responds to requests to a route that is not registered with their method.
+func methodNotAllowedHandler(w http.ResponseWriter, r *http.Request) {
+	logger.Error("Method " + r.Method + " not allowed for " + r.URL.Path)
+	writeErrorResponse(w, r, errs.NewAppError(http.StatusMethodNotAllowed, "Method not allowed for this route"))
+}
+
+// problemCatalogHandler responds with every type of error in the catalog.
+func problemCatalogHandler(w http.ResponseWriter, r *http.Request) {
+	response := make([]dto.ProblemTypeResponse, 0, len(problem.Catalog))
+	for _, t := range problem.Catalog {
+		response = append(response, newProblemTypeResponse(t))
+	}
+	writeJsonResponse(w, http.StatusOK, response)
+}
+
+// problemTypeHandler responds with the type of error with the code in the path, which is the type URI of problem
+// details.
+func problemTypeHandler(w http.ResponseWriter, r *http.Request) {
+	t, ok := problem.Find(mux.Vars(r)["code"])
+	if !ok {
+		logger.Error("Problem type not in catalog: " + mux.Vars(r)["code"])
+		writeErrorResponse(w, r, problem.NewNotFoundError(problem.ProblemTypeNotFound, "Problem type not found"))
+		return
+	}
+	writeJsonResponse(w, http.StatusOK, newProblemTypeResponse(t))
+}
+
+func newProblemTypeResponse(t problem.Type) dto.ProblemTypeResponse {
+	return dto.ProblemTypeResponse{Type: t.Uri(), Code: t.Code, Title: t.Title, Status: t.Status}
+}
@@ -0,0 +1,196 @@
+package app
+
+import (
+	"encoding/json"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/problem"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestApp_error_is_sent_as_problemDetails_when_accepted(t *testing.T) {
+	//Arrange
+	teardown := setupAppTest(t)
+	defer teardown()
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodPost, "/customers/"+seededCustomerId+"/account/"+seededAccountId,
+		strings.NewReader(`{"transaction_type": "withdrawal", "amount": 9000}`))
+	request.Header.Add("Authorization", dummyToken)
+	request.Header.Add("Accept", "application/json, application/problem+json")
+	request.Header.Add(requestIdHeader, "trace-123")
+	expected := dto.ProblemResponse{
+		Type:      "/problems/INSUFFICIENT_FUNDS",
+		Title:     "Insufficient funds",
+		Status:    http.StatusUnprocessableEntity,
+		Detail:    "Account balance insufficient to withdraw given amount",
+		Instance:  "/customers/" + seededCustomerId + "/account/" + seededAccountId,
+		Code:      "INSUFFICIENT_FUNDS",
+		RequestId: "trace-123",
+	}
+	var actual dto.ProblemResponse
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("Expected status code %d but got %d", http.StatusUnprocessableEntity, recorder.Code)
+	}
+	if contentType := recorder.Header().Get("Content-Type"); contentType != problem.MediaType {
+		t.Errorf("Expected content type %s but got %s", problem.MediaType, contentType)
+	}
+	if err := json.NewDecoder(recorder.Body).Decode(&actual); err != nil {
+		t.Fatalf("Error while decoding problem details: %s", err.Error())
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected problem details %v but got %v", expected, actual)
+	}
+	if id := recorder.Header().Get(requestIdHeader); id != "trace-123" {
+		t.Errorf("Expected request ID trace-123 to be sent back but got %s", id)
+	}
+}
+
+func TestApp_error_is_sent_as_message_when_problemDetails_not_accepted(t *testing.T) {
+	tests := []struct {
+		name   string
+		accept string
+	}{
+		{"no Accept", ""},
+		{"JSON", "application/json"},
+		{"quality 0", "application/json, application/problem+json;q=0"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Arrange
+			teardown := setupAppTest(t)
+			defer teardown()
+
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodPost, "/customers/"+seededCustomerId+"/account/99999",
+				strings.NewReader(`{"transaction_type": "withdrawal", "amount": 10}`))
+			request.Header.Add("Authorization", dummyToken)
+			if tc.accept != "" {
+				request.Header.Add("Accept", tc.accept)
+			}
+
+			//Act
+			router.ServeHTTP(recorder, request)
+
+			//Assert
+			if recorder.Code != http.StatusNotFound {
+				t.Fatalf("Expected status code %d but got %d", http.StatusNotFound, recorder.Code)
+			}
+			if contentType := recorder.Header().Get("Content-Type"); contentType != "application/json" {
+				t.Errorf("Expected content type application/json but got %s", contentType)
+			}
+			if body := recorder.Body.String(); !strings.HasPrefix(body, `{"message":`) {
+				t.Errorf("Expected {\"message\": ...} but got %s", body)
+			}
+			if recorder.Header().Get(requestIdHeader) == "" {
+				t.Error("Expected a request ID to be generated but got none")
+			}
+		})
+	}
+}
+
+func TestApp_unknownRoute_is_sent_as_problemDetails(t *testing.T) {
+	//Arrange
+	teardown := setupAppTest(t)
+	defer teardown()
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/nowhere", nil)
+	request.Header.Add("Accept", problem.MediaType)
+	var actual dto.ProblemResponse
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if err := json.NewDecoder(recorder.Body).Decode(&actual); err != nil {
+		t.Fatalf("Error while decoding problem details: %s", err.Error())
+	}
+	if actual.Status != http.StatusNotFound || actual.Code != "ROUTE_NOT_FOUND" || actual.RequestId == "" {
+		t.Errorf("Expected ROUTE_NOT_FOUND with a request ID but got %v", actual)
+	}
+}
+
+func TestApp_problemTypes_are_served_without_accessToken(t *testing.T) {
+	//Arrange
+	teardown := setupAppTest(t)
+	defer teardown()
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/problems/INSUFFICIENT_FUNDS", nil)
+	expected := dto.ProblemTypeResponse{Type: "/problems/INSUFFICIENT_FUNDS", Code: "INSUFFICIENT_FUNDS",
+		Title: "Insufficient funds", Status: http.StatusUnprocessableEntity}
+	var actual dto.ProblemTypeResponse
+	var catalog []dto.ProblemTypeResponse
+	var missing errs.MessageObject
+
+	//Act
+	router.ServeHTTP(recorder, request)
+	catalogStatusCode := serveAs(t, "", http.MethodGet, "/problems", "", &catalog)
+	missingStatusCode := serveAs(t, "", http.MethodGet, "/problems/NO_SUCH_CODE", "", &missing)
+
+	//Assert
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d but got %d", http.StatusOK, recorder.Code)
+	}
+	if err := json.NewDecoder(recorder.Body).Decode(&actual); err != nil {
+		t.Fatalf("Error while decoding problem type: %s", err.Error())
+	}
+	if actual != expected {
+		t.Errorf("Expected problem type %v but got %v", expected, actual)
+	}
+	if catalogStatusCode != http.StatusOK || len(catalog) != len(problem.Catalog) {
+		t.Errorf("Expected status code %d and %d problem types but got %d and %d",
+			http.StatusOK, len(problem.Catalog), catalogStatusCode, len(catalog))
+	}
+	if missingStatusCode != http.StatusNotFound {
+		t.Errorf("Expected status code %d but got %d", http.StatusNotFound, missingStatusCode)
+	}
+}
+
+func TestApp_validationError_is_sent_with_every_field_in_error(t *testing.T) {
+	//Arrange
+	teardown := setupAppTest(t)
+	defer teardown()
+
+	path := "/customers/" + seededCustomerId + "/account/" + seededAccountId
+	payload := `{"transaction_type": "refund", "amount": 20000}`
+	expectedErrors := []dto.FieldError{
+		{Field: "amount", Rule: "lte", Message: "Amount must be at most 10000."},
+		{Field: "transaction_type", Rule: "oneof", Message: "Transaction type should be withdrawal or deposit."},
+	}
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodPost, path, strings.NewReader(payload))
+	request.Header.Add("Authorization", dummyToken)
+	request.Header.Add("Accept", problem.MediaType)
+	var actualMessage dto.ValidationErrorResponse
+	var actualProblem dto.ProblemResponse
+
+	//Act
+	statusCode := serve(t, http.MethodPost, path, payload, &actualMessage)
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if statusCode != http.StatusUnprocessableEntity || !reflect.DeepEqual(actualMessage.Errors, expectedErrors) {
+		t.Errorf("Expected status code %d and errors %v but got %d and %v",
+			http.StatusUnprocessableEntity, expectedErrors, statusCode, actualMessage.Errors)
+	}
+	if expected := expectedErrors[0].Message + " " + expectedErrors[1].Message; actualMessage.Message != expected {
+		t.Errorf("Expected message \"%s\" but got \"%s\"", expected, actualMessage.Message)
+	}
+	if err := json.NewDecoder(recorder.Body).Decode(&actualProblem); err != nil {
+		t.Fatalf("Error while decoding problem details: %s", err.Error())
+	}
+	if actualProblem.Code != problem.ValidationFailed || !reflect.DeepEqual(actualProblem.Errors, expectedErrors) {
+		t.Errorf("Expected %s with errors %v but got %v", problem.ValidationFailed, expectedErrors, actualProblem)
+	}
+}
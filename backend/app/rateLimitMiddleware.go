@@ -0,0 +1,237 @@
+package app
+
+import (
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/gorilla/mux"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimit is the budget of a token bucket: a client can send up to Requests requests at once, and gets them back
+// evenly over Per.
+type RateLimit struct {
+	Requests int
+	Per      time.Duration
+}
+
+// ParseRateLimit reads a rate limit written as "<requests>/<duration>", e.g. "30/1m".
+func ParseRateLimit(value string) (RateLimit, error) {
+	requests, per, found := strings.Cut(strings.TrimSpace(value), "/")
+	if !found {
+		return RateLimit{}, fmt.Errorf("rate limit %q is not of the form <requests>/<duration>", value)
+	}
+	limit := RateLimit{}
+	var err error
+	if limit.Requests, err = strconv.Atoi(requests); err != nil || limit.Requests <= 0 {
+		return RateLimit{}, fmt.Errorf("rate limit %q does not allow a positive number of requests", value)
+	}
+	if limit.Per, err = time.ParseDuration(per); err != nil || limit.Per <= 0 {
+		return RateLimit{}, fmt.Errorf("rate limit %q does not have a positive duration", value)
+	}
+	return limit, nil
+}
+
+// perSecond is the rate at which the bucket is refilled.
+func (l RateLimit) perSecond() float64 {
+	return float64(l.Requests) / l.Per.Seconds()
+}
+
+// RateLimitPolicy sets the budget of each route. The routes without a budget of their own share the Default budget if
+// they only read, and the stricter Mutating budget if they change data.
+type RateLimitPolicy struct {
+	Default           RateLimit
+	Mutating          RateLimit
+	Routes            map[string]RateLimit //by mux route name
+	TrustForwardedFor bool                 //whether the client IP is taken from X-Forwarded-For, set by a trusted proxy
+}
+
+// limitFor returns the budget of the route with the given name and method, and the name of the group of routes that
+// share it.
+func (p RateLimitPolicy) limitFor(routeName string, method string) (string, RateLimit) {
+	if limit, ok := p.Routes[routeName]; ok {
+		return routeName, limit
+	}
+	if method == http.MethodGet || method == http.MethodHead {
+		return "default", p.Default
+	}
+	return "mutating", p.Mutating
+}
+
+// RateLimitMiddleware limits how often each client IP and each customer can call the routes, with a token bucket for
+// every client and group of routes sharing a budget.
+type RateLimitMiddleware struct {
+	policy RateLimitPolicy
+	store  *rateLimitStore //shared by all copies of the middleware
+	clk    clock.Clock
+}
+
+// rateLimitStore holds the token buckets of a RateLimitMiddleware in memory. It is safe for concurrent use.
+type rateLimitStore struct {
+	mu      sync.Mutex
+	buckets map[string]*rateLimitBucket
+	sweptOn time.Time
+}
+
+type rateLimitBucket struct {
+	limit     RateLimit
+	tokens    float64
+	updatedOn time.Time
+}
+
+// rateLimitSweepInterval is how often the buckets that have filled up again, which are the same as new ones, are
+// removed so that the store does not keep growing.
+const rateLimitSweepInterval = time.Minute
+
+// rateLimitState is the state of a bucket after a request has tried to take a token from it.
+type rateLimitState struct {
+	limit      RateLimit
+	allowed    bool
+	remaining  int
+	retryAfter time.Duration //until a token is available again
+	reset      time.Duration //until the bucket is full again
+}
+
+func NewRateLimitMiddleware(policy RateLimitPolicy, clk clock.Clock) RateLimitMiddleware {
+	return RateLimitMiddleware{policy, &rateLimitStore{buckets: make(map[string]*rateLimitBucket)}, clk}
+}
+
+// IPRateLimitHandler is a middleware that limits the requests from each client IP. It runs before the auth middleware,
+// so that a client sending too many requests is turned away without a round trip to the auth server.
+func (m RateLimitMiddleware) IPRateLimitHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodOptions { //preflight requests are answered by the CORS middleware
+			next.ServeHTTP(w, r)
+			return
+		}
+		if !m.allow(w, r, "ip", m.clientIP(r)) {
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// CustomerRateLimitHandler is a middleware that limits the requests for each customer: the customer of the verified
+// token, or for an admin, the customer in the route. It runs after the auth middleware, so that a customer cannot use
+// up the budget of another by sending forged tokens. Requests for no particular customer are only limited by IP.
+func (m RateLimitMiddleware) CustomerRateLimitHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		customerId := requestClaims(r).CustomerId
+		if customerId == "" {
+			customerId = mux.Vars(r)["customer_id"]
+		}
+		if r.Method == http.MethodOptions || customerId == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if !m.allow(w, r, "customer", customerId) {
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// allow takes a token from the bucket of the given client for the route of the given request and sets the RateLimit
+// headers of the response. If the bucket is empty, it responds with 429 and reports that the request is not allowed.
+func (m RateLimitMiddleware) allow(w http.ResponseWriter, r *http.Request, scope string, client string) bool {
+	routeName := mux.CurrentRoute(r).GetName()
+	group, limit := m.policy.limitFor(routeName, r.Method)
+	state := m.store.take(scope+"|"+group+"|"+client, limit, m.clk.Now())
+	setRateLimitHeaders(w, state)
+	if state.allowed {
+		return true
+	}
+
+	logger.Error(fmt.Sprintf("Rate limit of %d requests per %s exceeded for %s %s on route %s",
+		limit.Requests, limit.Per, scope, client, routeName))
+	w.Header().Set("Retry-After", strconv.Itoa(ceilSeconds(state.retryAfter)))
+	writeErrorResponse(w, r, errs.NewAppError(http.StatusTooManyRequests, "Too many requests. Please try again later."))
+	return false
+}
+
+// clientIP returns the IP of the client that sent the given request: the last address in X-Forwarded-For if the
+// policy trusts it, which is the one added by the proxy in front of the app, or else the address of the connection.
+func (m RateLimitMiddleware) clientIP(r *http.Request) string {
+	if forwardedFor := r.Header.Get("X-Forwarded-For"); m.policy.TrustForwardedFor && forwardedFor != "" {
+		addresses := strings.Split(forwardedFor, ",")
+		return strings.TrimSpace(addresses[len(addresses)-1])
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// take refills the bucket with the given key for the time passed since it was last used, creating it full if it is
+// new, and takes a token from it if there is one.
+func (st *rateLimitStore) take(key string, limit RateLimit, now time.Time) rateLimitState {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if now.Sub(st.sweptOn) >= rateLimitSweepInterval {
+		st.sweep(now)
+	}
+
+	bucket, ok := st.buckets[key]
+	if !ok || bucket.limit != limit { //a changed budget starts afresh
+		bucket = &rateLimitBucket{limit: limit, tokens: float64(limit.Requests), updatedOn: now}
+		st.buckets[key] = bucket
+	}
+	bucket.refill(now)
+
+	state := rateLimitState{limit: limit}
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		state.allowed = true
+	} else {
+		state.retryAfter = secondsToDuration((1 - bucket.tokens) / limit.perSecond())
+	}
+	state.remaining = int(math.Floor(bucket.tokens))
+	state.reset = secondsToDuration((float64(limit.Requests) - bucket.tokens) / limit.perSecond())
+	return state
+}
+
+// sweep removes the buckets that are full again. The caller must hold the lock.
+func (st *rateLimitStore) sweep(now time.Time) {
+	for key, bucket := range st.buckets {
+		if bucket.refill(now); bucket.tokens >= float64(bucket.limit.Requests) {
+			delete(st.buckets, key)
+		}
+	}
+	st.sweptOn = now
+}
+
+func (b *rateLimitBucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.updatedOn); elapsed > 0 {
+		b.tokens = math.Min(float64(b.limit.Requests), b.tokens+elapsed.Seconds()*b.limit.perSecond())
+		b.updatedOn = now
+	}
+}
+
+// setRateLimitHeaders describes the given bucket in the RateLimit headers of the response, unless they already
+// describe a bucket with fewer requests remaining, so that a client sees the budget that will run out first.
+func setRateLimitHeaders(w http.ResponseWriter, state rateLimitState) {
+	if remaining, err := strconv.Atoi(w.Header().Get("RateLimit-Remaining")); err == nil && remaining < state.remaining {
+		return
+	}
+	w.Header().Set("RateLimit-Limit", strconv.Itoa(state.limit.Requests))
+	w.Header().Set("RateLimit-Remaining", strconv.Itoa(state.remaining))
+	w.Header().Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(state.reset)))
+	w.Header().Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", state.limit.Requests, ceilSeconds(state.limit.Per)))
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
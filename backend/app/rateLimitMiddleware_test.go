@@ -0,0 +1,352 @@
+package app
+
+import (
+	"context"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/gorilla/mux"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// Test common variables and inputs
+var rlm RateLimitMiddleware
+var testClk *steppingClock
+
+const dummyMutatingPath = "/some/mutation"
+const dummyMutatingRouteName = "SomeMutation"
+const dummyCustomerPath = "/customers/2002"
+
+// steppingClock is a clock that stays at the same time until it is moved forward.
+type steppingClock struct {
+	now time.Time
+}
+
+func (c *steppingClock) Now() time.Time {
+	return c.now
+}
+
+func (c *steppingClock) NowAsString() string {
+	return c.now.Format("2006-01-02 15:04:05")
+}
+
+func (c *steppingClock) advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func setupRateLimitMiddlewareTest(policy RateLimitPolicy) func() {
+	router = mux.NewRouter()
+	testClk = &steppingClock{time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)}
+	rlm = NewRateLimitMiddleware(policy, testClk)
+
+	dummyHandler := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+	router.HandleFunc(dummyPath, dummyHandler).Methods(http.MethodGet, http.MethodOptions).Name(dummyRouteName)
+	router.HandleFunc(dummyMutatingPath, dummyHandler).Methods(http.MethodPost).Name(dummyMutatingRouteName)
+	router.HandleFunc("/customers/{customer_id:[0-9]+}", dummyHandler).Methods(http.MethodGet).Name("GetCustomer")
+	router.Use(rlm.IPRateLimitHandler, rlm.CustomerRateLimitHandler)
+
+	return func() {
+		router = nil
+		recorder = nil
+		request = nil
+	}
+}
+
+func newDummyRateLimitPolicy() RateLimitPolicy {
+	return RateLimitPolicy{
+		Default:  RateLimit{Requests: 3, Per: time.Minute},
+		Mutating: RateLimit{Requests: 2, Per: time.Minute},
+		Routes:   map[string]RateLimit{},
+	}
+}
+
+// serveTimes sends the given number of requests made by newRequest to the router, leaving the last response in recorder.
+func serveTimes(n int, newRequest func() *http.Request) {
+	for i := 0; i < n; i++ {
+		recorder = httptest.NewRecorder()
+		router.ServeHTTP(recorder, newRequest())
+	}
+}
+
+func newRequestFrom(method string, path string, remoteAddr string) func() *http.Request {
+	return func() *http.Request {
+		r := httptest.NewRequest(method, path, nil)
+		r.RemoteAddr = remoteAddr
+		return r
+	}
+}
+
+func newRequestOfCustomer(method string, path string, remoteAddr string, customerId string) func() *http.Request {
+	return func() *http.Request {
+		r := newRequestFrom(method, path, remoteAddr)()
+		claims := domain.AuthClaims{CustomerId: customerId, Username: customerId}
+		return r.WithContext(context.WithValue(r.Context(), authClaimsKey{}, claims))
+	}
+}
+
+func TestParseRateLimit_returns_rateLimit_when_valid(t *testing.T) {
+	//Arrange
+	expected := RateLimit{Requests: 30, Per: time.Minute}
+
+	//Act
+	actual, err := ParseRateLimit("30/1m")
+
+	//Assert
+	if err != nil {
+		t.Fatalf("Expected no error but got %s", err.Error())
+	}
+	if actual != expected {
+		t.Errorf("Expected %v but got %v", expected, actual)
+	}
+}
+
+func TestParseRateLimit_returns_error_when_invalid(t *testing.T) {
+	for _, value := range []string{"", "30", "30/", "/1m", "abc/1m", "0/1m", "-5/1m", "30/abc", "30/0s"} {
+		//Act
+		_, err := ParseRateLimit(value)
+
+		//Assert
+		if err == nil {
+			t.Errorf("Expected an error for %q but got none", value)
+		}
+	}
+}
+
+func TestRateLimitMiddleware_IPRateLimitHandler_allows_requests_withinLimit_and_sets_headers(t *testing.T) {
+	//Arrange
+	teardown := setupRateLimitMiddlewareTest(newDummyRateLimitPolicy())
+	defer teardown()
+
+	//Act
+	serveTimes(2, newRequestFrom(http.MethodGet, dummyPath, "192.0.2.1:1234"))
+
+	//Assert
+	if recorder.Code != http.StatusOK {
+		t.Errorf("Expected status code %d but got %d", http.StatusOK, recorder.Code)
+	}
+	expectedHeaders := map[string]string{
+		"RateLimit-Limit":     "3",
+		"RateLimit-Remaining": "1",
+		"RateLimit-Reset":     "40",
+		"RateLimit-Policy":    "3;w=60",
+	}
+	for k, v := range expectedHeaders {
+		if recorder.Header().Get(k) != v {
+			t.Errorf("Expected header %s to be %s but got %s", k, v, recorder.Header().Get(k))
+		}
+	}
+}
+
+func TestRateLimitMiddleware_IPRateLimitHandler_respondsWith_429_when_limit_exceeded(t *testing.T) {
+	//Arrange
+	teardown := setupRateLimitMiddlewareTest(newDummyRateLimitPolicy())
+	defer teardown()
+	logs := logger.ReplaceWithTestLogger()
+
+	//Act
+	serveTimes(4, newRequestFrom(http.MethodGet, dummyPath, "192.0.2.1:1234"))
+
+	//Assert
+	if recorder.Code != http.StatusTooManyRequests {
+		t.Errorf("Expected status code %d but got %d", http.StatusTooManyRequests, recorder.Code)
+	}
+	if recorder.Header().Get("Retry-After") != "20" {
+		t.Errorf("Expected Retry-After to be 20 but got %s", recorder.Header().Get("Retry-After"))
+	}
+	if recorder.Header().Get("RateLimit-Remaining") != "0" {
+		t.Errorf("Expected RateLimit-Remaining to be 0 but got %s", recorder.Header().Get("RateLimit-Remaining"))
+	}
+	if logs.Len() != 1 {
+		t.Errorf("Expected 1 message to be logged but got %d logs", logs.Len())
+	}
+}
+
+func TestRateLimitMiddleware_IPRateLimitHandler_allows_requests_again_after_refill(t *testing.T) {
+	//Arrange
+	teardown := setupRateLimitMiddlewareTest(newDummyRateLimitPolicy())
+	defer teardown()
+	newRequest := newRequestFrom(http.MethodGet, dummyPath, "192.0.2.1:1234")
+	serveTimes(3, newRequest)
+
+	//Act
+	testClk.advance(20 * time.Second)
+	serveTimes(1, newRequest)
+
+	//Assert
+	if recorder.Code != http.StatusOK {
+		t.Errorf("Expected status code %d but got %d", http.StatusOK, recorder.Code)
+	}
+}
+
+func TestRateLimitMiddleware_IPRateLimitHandler_keeps_separate_budgets_for_each_ip(t *testing.T) {
+	//Arrange
+	teardown := setupRateLimitMiddlewareTest(newDummyRateLimitPolicy())
+	defer teardown()
+	serveTimes(3, newRequestFrom(http.MethodGet, dummyPath, "192.0.2.1:1234"))
+
+	//Act
+	serveTimes(1, newRequestFrom(http.MethodGet, dummyPath, "192.0.2.2:1234"))
+
+	//Assert
+	if recorder.Code != http.StatusOK {
+		t.Errorf("Expected status code %d but got %d", http.StatusOK, recorder.Code)
+	}
+}
+
+func TestRateLimitMiddleware_IPRateLimitHandler_uses_forwardedFor_only_when_trusted(t *testing.T) {
+	tests := []struct {
+		name              string
+		trustForwardedFor bool
+		expectedStatus    int
+	}{
+		{"trusted", true, http.StatusOK},
+		{"not trusted", false, http.StatusTooManyRequests},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Arrange
+			policy := newDummyRateLimitPolicy()
+			policy.TrustForwardedFor = tc.trustForwardedFor
+			teardown := setupRateLimitMiddlewareTest(policy)
+			defer teardown()
+			from := func(clientIP string) func() *http.Request {
+				return func() *http.Request {
+					r := newRequestFrom(http.MethodGet, dummyPath, "10.0.0.1:1234")()
+					r.Header.Set("X-Forwarded-For", "203.0.113.9, "+clientIP)
+					return r
+				}
+			}
+			serveTimes(3, from("192.0.2.1"))
+
+			//Act
+			serveTimes(1, from("192.0.2.2"))
+
+			//Assert
+			if recorder.Code != tc.expectedStatus {
+				t.Errorf("Expected status code %d but got %d", tc.expectedStatus, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestRateLimitMiddleware_IPRateLimitHandler_uses_stricter_budget_for_mutating_routes(t *testing.T) {
+	//Arrange
+	teardown := setupRateLimitMiddlewareTest(newDummyRateLimitPolicy())
+	defer teardown()
+
+	//Act
+	serveTimes(3, newRequestFrom(http.MethodPost, dummyMutatingPath, "192.0.2.1:1234"))
+
+	//Assert
+	if recorder.Code != http.StatusTooManyRequests {
+		t.Errorf("Expected status code %d but got %d", http.StatusTooManyRequests, recorder.Code)
+	}
+	serveTimes(1, newRequestFrom(http.MethodGet, dummyPath, "192.0.2.1:1234"))
+	if recorder.Code != http.StatusOK {
+		t.Errorf("Expected reads to keep their own budget but got status code %d", recorder.Code)
+	}
+}
+
+func TestRateLimitMiddleware_IPRateLimitHandler_uses_route_budget_when_configured(t *testing.T) {
+	//Arrange
+	policy := newDummyRateLimitPolicy()
+	policy.Routes[dummyRouteName] = RateLimit{Requests: 1, Per: time.Minute}
+	teardown := setupRateLimitMiddlewareTest(policy)
+	defer teardown()
+
+	//Act
+	serveTimes(2, newRequestFrom(http.MethodGet, dummyPath, "192.0.2.1:1234"))
+
+	//Assert
+	if recorder.Code != http.StatusTooManyRequests {
+		t.Errorf("Expected status code %d but got %d", http.StatusTooManyRequests, recorder.Code)
+	}
+	if recorder.Header().Get("Retry-After") != "60" {
+		t.Errorf("Expected Retry-After to be 60 but got %s", recorder.Header().Get("Retry-After"))
+	}
+}
+
+func TestRateLimitMiddleware_IPRateLimitHandler_does_not_limit_preflightRequests(t *testing.T) {
+	//Arrange
+	teardown := setupRateLimitMiddlewareTest(newDummyRateLimitPolicy())
+	defer teardown()
+
+	//Act
+	serveTimes(5, newRequestFrom(http.MethodOptions, dummyPath, "192.0.2.1:1234"))
+
+	//Assert
+	if recorder.Code != http.StatusOK {
+		t.Errorf("Expected status code %d but got %d", http.StatusOK, recorder.Code)
+	}
+	if recorder.Header().Get("RateLimit-Limit") != "" {
+		t.Errorf("Expected no RateLimit headers but got %s", recorder.Header().Get("RateLimit-Limit"))
+	}
+}
+
+func TestRateLimitMiddleware_CustomerRateLimitHandler_limits_customer_across_ips(t *testing.T) {
+	//Arrange
+	policy := newDummyRateLimitPolicy()
+	policy.Routes[dummyRouteName] = RateLimit{Requests: 10, Per: time.Minute}
+	teardown := setupRateLimitMiddlewareTest(policy)
+	defer teardown()
+	serveTimes(5, newRequestOfCustomer(http.MethodGet, dummyPath, "192.0.2.1:1234", "2001"))
+	serveTimes(5, newRequestOfCustomer(http.MethodGet, dummyPath, "192.0.2.2:1234", "2001"))
+
+	//Act
+	serveTimes(1, newRequestOfCustomer(http.MethodGet, dummyPath, "192.0.2.3:1234", "2001"))
+
+	//Assert
+	if recorder.Code != http.StatusTooManyRequests {
+		t.Errorf("Expected status code %d but got %d", http.StatusTooManyRequests, recorder.Code)
+	}
+	serveTimes(1, newRequestOfCustomer(http.MethodGet, dummyPath, "192.0.2.3:1234", "2002"))
+	if recorder.Code != http.StatusOK {
+		t.Errorf("Expected other customers to keep their own budget but got status code %d", recorder.Code)
+	}
+}
+
+func TestRateLimitMiddleware_CustomerRateLimitHandler_uses_routeVar_when_no_customer_in_token(t *testing.T) {
+	//Arrange
+	teardown := setupRateLimitMiddlewareTest(newDummyRateLimitPolicy())
+	defer teardown()
+	serveTimes(2, newRequestFrom(http.MethodGet, dummyCustomerPath, "192.0.2.1:1234"))
+
+	//Act
+	serveTimes(1, newRequestFrom(http.MethodGet, dummyCustomerPath, "192.0.2.2:1234"))
+
+	//Assert
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d but got %d", http.StatusOK, recorder.Code)
+	}
+	if recorder.Header().Get("RateLimit-Remaining") != "0" {
+		t.Errorf("Expected customer budget to be reported with 0 remaining but got %s",
+			recorder.Header().Get("RateLimit-Remaining"))
+	}
+	serveTimes(1, newRequestFrom(http.MethodGet, dummyCustomerPath, "192.0.2.3:1234"))
+	if recorder.Code != http.StatusTooManyRequests {
+		t.Errorf("Expected status code %d but got %d", http.StatusTooManyRequests, recorder.Code)
+	}
+}
+
+func TestRateLimitStore_take_removes_buckets_that_are_full_again(t *testing.T) {
+	//Arrange
+	st := &rateLimitStore{buckets: make(map[string]*rateLimitBucket)}
+	limit := RateLimit{Requests: 2, Per: time.Minute}
+	start := time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)
+	st.take("a", limit, start)
+
+	//Act
+	st.take("b", limit, start.Add(2*time.Minute))
+
+	//Assert
+	if _, ok := st.buckets["a"]; ok {
+		t.Error("Expected idle bucket that is full again to be removed but it was not")
+	}
+	if _, ok := st.buckets["b"]; !ok {
+		t.Error("Expected bucket in use to be kept but it was not")
+	}
+}
@@ -0,0 +1,51 @@
+package app
+
+import (
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/problem"
+	"github.com/aliciatay-zls/banking/backend/service"
+	"net/http"
+	"strings"
+)
+
+type ReconciliationHandler struct {
+	service   service.ReconciliationService
+	approvals service.ApprovalService
+}
+
+func (h ReconciliationHandler) reportHandler(w http.ResponseWriter, r *http.Request) {
+	report, appErr := h.service.Reconcile(false)
+	if appErr != nil {
+		writeErrorResponse(w, r, appErr)
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, report)
+}
+
+// freezeHandler asks for a second admin to approve freezing the mismatched accounts that are not yet frozen. The
+// accounts are found now, and exactly those are frozen once the freeze is approved.
+func (h ReconciliationHandler) freezeHandler(w http.ResponseWriter, r *http.Request) {
+	report, appErr := h.service.Reconcile(false)
+	if appErr != nil {
+		writeErrorResponse(w, r, appErr)
+		return
+	}
+
+	accountIds := make([]string, 0)
+	for _, mismatch := range report.Mismatches {
+		if !mismatch.IsFrozen {
+			accountIds = append(accountIds, mismatch.AccountId)
+		}
+	}
+	if len(accountIds) == 0 {
+		logger.Error("Freeze requested with no mismatched accounts to freeze")
+		writeErrorResponse(w, r, problem.NewConflictError(problem.NoAccountsToFreeze,
+			"There are no mismatched accounts to freeze"))
+		return
+	}
+
+	requestApproval(w, r, h.approvals, domain.ApprovalOperationFreezeAccounts,
+		"freeze the accounts whose balance does not reconcile: "+strings.Join(accountIds, ", "), accountIds)
+}
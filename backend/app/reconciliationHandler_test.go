@@ -0,0 +1,108 @@
+package app
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/mocks/service"
+	"github.com/gorilla/mux"
+	"go.uber.org/mock/gomock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockReconciliationService *service.MockReconciliationService
+var rh ReconciliationHandler
+
+const reconciliationPath = "/reconciliation"
+const freezeMismatchedPath = "/reconciliation/freeze"
+
+func setupReconciliationHandlerTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	mockReconciliationService = service.NewMockReconciliationService(ctrl)
+	mockApprovalService = service.NewMockApprovalService(ctrl)
+	rh = ReconciliationHandler{mockReconciliationService, mockApprovalService}
+
+	router = mux.NewRouter()
+	router.HandleFunc(reconciliationPath, rh.reportHandler).Methods(http.MethodGet)
+	router.HandleFunc(freezeMismatchedPath, rh.freezeHandler).Methods(http.MethodPost)
+
+	recorder = httptest.NewRecorder()
+
+	return func() {
+		router = nil
+		recorder = nil
+		request = nil
+		defer ctrl.Finish()
+	}
+}
+
+func TestReconciliationHandler_reportHandler_respondsWith_errorStatusCode_when_service_fails(t *testing.T) {
+	//Arrange
+	teardown := setupReconciliationHandlerTest(t)
+	defer teardown()
+	request = httptest.NewRequest(http.MethodGet, reconciliationPath, nil)
+
+	dummyAppErr := errs.NewUnexpectedError("some error message")
+	mockReconciliationService.EXPECT().Reconcile(false).Return(nil, dummyAppErr)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != dummyAppErr.Code {
+		t.Errorf("Expected status code %d but got %d", dummyAppErr.Code, recorder.Result().StatusCode)
+	}
+}
+
+func TestReconciliationHandler_freezeHandler_respondsWith_approvalRequestAndStatusCode202_without_freezing(t *testing.T) {
+	//Arrange
+	teardown := setupReconciliationHandlerTest(t)
+	defer teardown()
+	request = newAdminRequest(http.MethodPost, freezeMismatchedPath, "admin")
+
+	dummyReport := dto.ReconciliationReportResponse{AccountsChecked: 3, Mismatches: []dto.ReconciliationMismatchResponse{
+		{AccountId: "1978", Delta: 100}, {AccountId: "1979", Delta: -50, IsFrozen: true}}}
+	dummyApproval := dto.ApprovalResponse{ApprovalId: "4", Operation: domain.ApprovalOperationFreezeAccounts, Status: dto.ApprovalStatusPending}
+	mockReconciliationService.EXPECT().Reconcile(false).Return(&dummyReport, nil)
+	mockApprovalService.EXPECT().RequestApproval(domain.ApprovalOperationFreezeAccounts,
+		"freeze the accounts whose balance does not reconcile: 1978", []string{"1978"}, "admin").
+		Return(&dummyApproval, nil)
+	mockReconciliationService.EXPECT().FreezeAccounts(gomock.Any()).Times(0)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusAccepted {
+		t.Errorf("Expected status code %d but got %d", http.StatusAccepted, recorder.Result().StatusCode)
+	}
+	actualResponse, _ := io.ReadAll(recorder.Result().Body)
+	if !strings.Contains(string(actualResponse), `"approval_id":"4"`) {
+		t.Errorf("Expected response to contain approval request 4 but got: %s", actualResponse)
+	}
+}
+
+func TestReconciliationHandler_freezeHandler_respondsWith_statusCode409_when_no_accounts_to_freeze(t *testing.T) {
+	//Arrange
+	teardown := setupReconciliationHandlerTest(t)
+	defer teardown()
+	request = newAdminRequest(http.MethodPost, freezeMismatchedPath, "admin")
+
+	dummyReport := dto.ReconciliationReportResponse{AccountsChecked: 3, Mismatches: []dto.ReconciliationMismatchResponse{
+		{AccountId: "1979", Delta: -50, IsFrozen: true}}}
+	mockReconciliationService.EXPECT().Reconcile(false).Return(&dummyReport, nil)
+	mockApprovalService.EXPECT().RequestApproval(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusConflict {
+		t.Errorf("Expected status code %d but got %d", http.StatusConflict, recorder.Result().StatusCode)
+	}
+}
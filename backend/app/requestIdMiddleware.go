@@ -0,0 +1,46 @@
+package app
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"regexp"
+)
+
+const requestIdHeader = "X-Request-Id"
+
+// requestIdKey is the key of the ID of a request in its context.
+type requestIdKey struct{}
+
+// validRequestId is what an ID sent by a client or proxy must look like to be kept.
+var validRequestId = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// RequestIdMiddlewareHandler is a middleware that gives every request an ID, which is sent back in X-Request-Id and in
+// problem details so that a client can quote it when reporting an error. An ID already set in X-Request-Id, e.g. by
+// a proxy, is kept if it is valid.
+func RequestIdMiddlewareHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(requestIdHeader)
+		if !validRequestId.MatchString(id) {
+			id = newRequestId()
+		}
+		w.Header().Set(requestIdHeader, id)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), requestIdKey{}, id)))
+	})
+}
+
+// requestId returns the ID of the given request, or an empty string if it did not pass through the request ID
+// middleware.
+func requestId(r *http.Request) string {
+	id, _ := r.Context().Value(requestIdKey{}).(string)
+	return id
+}
+
+func newRequestId() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
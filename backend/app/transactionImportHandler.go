@@ -0,0 +1,75 @@
+package app
+
+import (
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/problem"
+	"github.com/aliciatay-zls/banking/backend/service"
+	"io"
+	"net/http"
+)
+
+const transactionImportMaxBytes = 1 << 20 //1 MB
+
+type TransactionImportHandler struct {
+	service   service.TransactionImportService
+	approvals service.ApprovalService
+}
+
+func (h TransactionImportHandler) importHandler(w http.ResponseWriter, r *http.Request) {
+	content, err := io.ReadAll(http.MaxBytesReader(w, r.Body, transactionImportMaxBytes))
+	if err != nil {
+		logger.Error("Error while reading body of transaction import request: " + err.Error())
+		writeErrorResponse(w, r, problem.NewAppError(problem.FileTooLarge, http.StatusBadRequest,
+			"Please upload a CSV file no larger than 1 MB."))
+		return
+	}
+
+	importRequest := dto.TransactionImportRequest{
+		Mode:        r.URL.Query().Get("mode"),
+		FileContent: content,
+	}
+	if importRequest.Mode == "" {
+		importRequest.Mode = dto.TransactionImportModeDryRun
+	}
+
+	if valErr := importRequest.Validate(); valErr != nil {
+		writeValidationErrorResponse(w, r, valErr)
+		return
+	}
+
+	if importRequest.Mode == dto.TransactionImportModeCommit {
+		h.requestCommitApproval(w, r, importRequest)
+		return
+	}
+
+	response, appErr := h.service.ImportTransactions(importRequest)
+	if appErr != nil {
+		writeErrorResponse(w, r, appErr)
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, response)
+}
+
+// requestCommitApproval checks the file with a dry run and, if every row is valid and the file has not been imported
+// before, asks for a second admin to approve committing it. Otherwise, the report of the dry run says what to fix.
+func (h TransactionImportHandler) requestCommitApproval(w http.ResponseWriter, r *http.Request, importRequest dto.TransactionImportRequest) {
+	dryRunRequest := importRequest
+	dryRunRequest.Mode = dto.TransactionImportModeDryRun
+	report, appErr := h.service.ImportTransactions(dryRunRequest)
+	if appErr != nil {
+		writeErrorResponse(w, r, appErr)
+		return
+	}
+	if report.InvalidRows > 0 || report.IsDuplicate {
+		report.Mode = importRequest.Mode
+		writeJsonResponse(w, http.StatusUnprocessableEntity, report) //nothing posted, report says which rows to fix
+		return
+	}
+
+	requestApproval(w, r, h.approvals, domain.ApprovalOperationTransactionImport,
+		fmt.Sprintf("import %d transactions from file %s", report.TotalRows, report.FileHash), importRequest)
+}
@@ -0,0 +1,123 @@
+package app
+
+import (
+	"bytes"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/mocks/service"
+	"github.com/gorilla/mux"
+	"go.uber.org/mock/gomock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockTransactionImportService *service.MockTransactionImportService
+var tih TransactionImportHandler
+
+const importTransactionsPath = "/transactions/import"
+const dummyImportFile = "account_id,amount,type,reference\n1977,500,deposit,PAYROLL-1\n"
+
+func setupTransactionImportHandlerTest(t *testing.T, mode string) func() {
+	ctrl := gomock.NewController(t)
+	mockTransactionImportService = service.NewMockTransactionImportService(ctrl)
+	mockApprovalService = service.NewMockApprovalService(ctrl)
+	tih = TransactionImportHandler{mockTransactionImportService, mockApprovalService}
+
+	router = mux.NewRouter()
+	router.HandleFunc(importTransactionsPath, tih.importHandler).Methods(http.MethodPost)
+
+	recorder = httptest.NewRecorder()
+	request = httptest.NewRequest(http.MethodPost, importTransactionsPath+"?mode="+mode, bytes.NewBuffer([]byte(dummyImportFile)))
+
+	return func() {
+		router = nil
+		recorder = nil
+		request = nil
+		defer ctrl.Finish()
+	}
+}
+
+func TestTransactionImportHandler_importHandler_respondsWith_errorStatusCode_when_mode_invalid(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionImportHandlerTest(t, "some_mode")
+	defer teardown()
+
+	mockTransactionImportService.EXPECT().ImportTransactions(gomock.Any()).Times(0)
+	expectedStatusCode := http.StatusUnprocessableEntity
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != expectedStatusCode {
+		t.Errorf("Expected status code %d but got %d", expectedStatusCode, recorder.Result().StatusCode)
+	}
+}
+
+func TestTransactionImportHandler_importHandler_respondsWith_errorStatusCode_when_service_fails(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionImportHandlerTest(t, dto.TransactionImportModeCommit)
+	defer teardown()
+
+	dummyAppErr := errs.NewConflictError("This file has already been imported")
+	mockTransactionImportService.EXPECT().ImportTransactions(gomock.Any()).Return(nil, dummyAppErr)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != dummyAppErr.Code {
+		t.Errorf("Expected status code %d but got %d", dummyAppErr.Code, recorder.Result().StatusCode)
+	}
+	actualResponse, _ := io.ReadAll(recorder.Result().Body)
+	if !strings.Contains(string(actualResponse), dummyAppErr.Message) {
+		t.Errorf("Expected response to contain %s but got: %s", dummyAppErr.Message, actualResponse)
+	}
+}
+
+func TestTransactionImportHandler_importHandler_respondsWith_statusCodeForOutcome_when_service_succeeds(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name               string
+		mode               string
+		invalidRows        int
+		isDuplicate        bool
+		expectedStatusCode int
+	}{
+		{"dry run", dto.TransactionImportModeDryRun, 1, false, http.StatusOK},
+		{"commit of invalid file", dto.TransactionImportModeCommit, 1, false, http.StatusUnprocessableEntity},
+		{"commit of duplicate file", dto.TransactionImportModeCommit, 0, true, http.StatusUnprocessableEntity},
+		{"commit of valid file", dto.TransactionImportModeCommit, 0, false, http.StatusAccepted},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			teardown := setupTransactionImportHandlerTest(t, tc.mode)
+			defer teardown()
+
+			//a commit is always checked with a dry run first
+			dryRunRequest := dto.TransactionImportRequest{Mode: dto.TransactionImportModeDryRun, FileContent: []byte(dummyImportFile)}
+			dummyResponse := dto.TransactionImportResponse{FileHash: "abc", Mode: dto.TransactionImportModeDryRun,
+				IsDuplicate: tc.isDuplicate, TotalRows: 1, InvalidRows: tc.invalidRows}
+			mockTransactionImportService.EXPECT().ImportTransactions(dryRunRequest).Return(&dummyResponse, nil)
+			if tc.expectedStatusCode == http.StatusAccepted {
+				commitRequest := dto.TransactionImportRequest{Mode: dto.TransactionImportModeCommit, FileContent: []byte(dummyImportFile)}
+				mockApprovalService.EXPECT().RequestApproval(domain.ApprovalOperationTransactionImport,
+					"import 1 transactions from file abc", commitRequest, "").Return(&dto.ApprovalResponse{ApprovalId: "2"}, nil)
+			}
+
+			//Act
+			router.ServeHTTP(recorder, request)
+
+			//Assert
+			if recorder.Result().StatusCode != tc.expectedStatusCode {
+				t.Errorf("Expected status code %d but got %d", tc.expectedStatusCode, recorder.Result().StatusCode)
+			}
+		})
+	}
+}
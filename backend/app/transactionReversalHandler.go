@@ -0,0 +1,61 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/service"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+type TransactionReversalHandler struct {
+	service   service.TransactionReversalService
+	approvals service.ApprovalService
+}
+
+func (h TransactionReversalHandler) reverseHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	request := dto.TransactionReversalRequest{
+		TransactionId: vars["transaction_id"],
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		logger.Error("Error while decoding json body of transaction reversal request: " + err.Error())
+		writeErrorResponse(w, r, errs.NewAppError(http.StatusBadRequest, "Please check that all fields are correctly filled."))
+		return
+	}
+	request.ReversedBy = requestClaims(r).Username
+
+	if valErr := request.Validate(); valErr != nil {
+		writeValidationErrorResponse(w, r, valErr)
+		return
+	}
+
+	transaction, appErr := h.service.GetReversibleTransaction(request.TransactionId)
+	if appErr != nil {
+		writeErrorResponse(w, r, appErr)
+		return
+	}
+	if h.approvals.IsReversalApprovalRequired(request, transaction.Amount) {
+		description := fmt.Sprintf("reversal of %s %s of %.2f", transaction.TransactionType,
+			transaction.TransactionId, transaction.Amount)
+		if request.Force {
+			description = "forced " + description
+		}
+		requestApproval(w, r, h.approvals, domain.ApprovalOperationTransactionReversal, description,
+			dto.TransactionReversalApproval(request))
+		return
+	}
+
+	response, appErr := h.service.Reverse(request)
+	if appErr != nil {
+		writeErrorResponse(w, r, appErr)
+		return
+	}
+
+	writeJsonResponse(w, http.StatusCreated, response)
+}
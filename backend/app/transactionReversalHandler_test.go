@@ -0,0 +1,136 @@
+package app
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/mocks/service"
+	"github.com/gorilla/mux"
+	"go.uber.org/mock/gomock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockTransactionReversalService *service.MockTransactionReversalService
+var rvh TransactionReversalHandler
+
+const reverseTransactionPath = "/transactions/7791/reverse"
+
+// dummyReversibleDeposit is the deposit of 500 with id 7791 that the requests of the tests reverse
+var dummyReversibleDeposit = dto.AccountTransactionResponse{TransactionId: "7791", TransactionType: dto.TransactionTypeDeposit,
+	Amount: 500, Status: dto.TransactionStatusPosted}
+
+func setupTransactionReversalHandlerTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	mockTransactionReversalService = service.NewMockTransactionReversalService(ctrl)
+	mockApprovalService = service.NewMockApprovalService(ctrl)
+	rvh = TransactionReversalHandler{mockTransactionReversalService, mockApprovalService}
+
+	router = mux.NewRouter()
+	router.HandleFunc("/transactions/{transaction_id:[0-9]+}/reverse", rvh.reverseHandler).Methods(http.MethodPost)
+
+	recorder = httptest.NewRecorder()
+
+	return func() {
+		mockApprovalService = nil
+		router = nil
+		recorder = nil
+		request = nil
+		defer ctrl.Finish()
+	}
+}
+
+func TestTransactionReversalHandler_reverseHandler_respondsWith_statusCode422_when_reason_missing(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionReversalHandlerTest(t)
+	defer teardown()
+	request = newAdminRequest(http.MethodPost, reverseTransactionPath, "admin")
+	request.Body = io.NopCloser(strings.NewReader(`{"force": true}`))
+	mockTransactionReversalService.EXPECT().Reverse(gomock.Any()).Times(0)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status code %d but got %d", http.StatusUnprocessableEntity, recorder.Result().StatusCode)
+	}
+}
+
+func TestTransactionReversalHandler_reverseHandler_respondsWith_serviceError_when_service_fails(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionReversalHandlerTest(t)
+	defer teardown()
+	request = newAdminRequest(http.MethodPost, reverseTransactionPath, "admin")
+	request.Body = io.NopCloser(strings.NewReader(`{"reason": "Duplicate deposit"}`))
+	mockTransactionReversalService.EXPECT().GetReversibleTransaction("7791").
+		Return(nil, errs.NewConflictError("Transaction has already been reversed"))
+	mockTransactionReversalService.EXPECT().Reverse(gomock.Any()).Times(0)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusConflict {
+		t.Errorf("Expected status code %d but got %d", http.StatusConflict, recorder.Result().StatusCode)
+	}
+}
+
+func TestTransactionReversalHandler_reverseHandler_respondsWith_reversalAndStatusCode201_when_service_succeeds(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionReversalHandlerTest(t)
+	defer teardown()
+	request = newAdminRequest(http.MethodPost, reverseTransactionPath, "admin")
+	request.Body = io.NopCloser(strings.NewReader(`{"reason": "Duplicate deposit"}`))
+
+	expectedRequest := dto.TransactionReversalRequest{TransactionId: "7791", Reason: "Duplicate deposit", ReversedBy: "admin"}
+	dummyResponse := dto.TransactionReversalResponse{ReversalId: "3", TransactionId: "7791", ReversalTransactionId: "7792"}
+	mockTransactionReversalService.EXPECT().GetReversibleTransaction("7791").Return(&dummyReversibleDeposit, nil)
+	mockApprovalService.EXPECT().IsReversalApprovalRequired(expectedRequest, float64(500)).Return(false)
+	mockTransactionReversalService.EXPECT().Reverse(expectedRequest).Return(&dummyResponse, nil)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusCreated {
+		t.Errorf("Expected status code %d but got %d", http.StatusCreated, recorder.Result().StatusCode)
+	}
+	actualResponse, _ := io.ReadAll(recorder.Result().Body)
+	if !strings.Contains(string(actualResponse), `"reversal_transaction_id":"7792"`) {
+		t.Errorf("Expected response to contain the compensating transaction but got %s", string(actualResponse))
+	}
+}
+
+func TestTransactionReversalHandler_reverseHandler_requestsApproval_when_required(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionReversalHandlerTest(t)
+	defer teardown()
+	request = newAdminRequest(http.MethodPost, reverseTransactionPath, "admin")
+	request.Body = io.NopCloser(strings.NewReader(`{"reason": "Duplicate deposit", "force": true}`))
+
+	expectedRequest := dto.TransactionReversalRequest{TransactionId: "7791", Reason: "Duplicate deposit", Force: true, ReversedBy: "admin"}
+	dummyApproval := dto.ApprovalResponse{ApprovalId: "3", Status: dto.ApprovalStatusPending}
+	mockTransactionReversalService.EXPECT().GetReversibleTransaction("7791").Return(&dummyReversibleDeposit, nil)
+	mockApprovalService.EXPECT().IsReversalApprovalRequired(expectedRequest, float64(500)).Return(true)
+	mockApprovalService.EXPECT().RequestApproval(domain.ApprovalOperationTransactionReversal,
+		"forced reversal of deposit 7791 of 500.00", dto.TransactionReversalApproval(expectedRequest), "admin").
+		Return(&dummyApproval, nil)
+	mockTransactionReversalService.EXPECT().Reverse(gomock.Any()).Times(0)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusAccepted {
+		t.Errorf("Expected status code %d but got %d", http.StatusAccepted, recorder.Result().StatusCode)
+	}
+	actualResponse, _ := io.ReadAll(recorder.Result().Body)
+	if !strings.Contains(string(actualResponse), `"approval_id":"3"`) {
+		t.Errorf("Expected response to contain the approval request but got %s", actualResponse)
+	}
+}
@@ -0,0 +1,62 @@
+package app
+
+import (
+	"encoding/json"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/service"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+type TransactionReviewHandler struct {
+	service service.TransactionReviewService
+}
+
+func (h TransactionReviewHandler) reviewsHandler(w http.ResponseWriter, r *http.Request) {
+	response, appErr := h.service.GetPendingReviews()
+	if appErr != nil {
+		writeErrorResponse(w, r, appErr)
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, response)
+}
+
+func (h TransactionReviewHandler) approveHandler(w http.ResponseWriter, r *http.Request) {
+	h.resolve(w, r, h.service.Approve)
+}
+
+func (h TransactionReviewHandler) rejectHandler(w http.ResponseWriter, r *http.Request) {
+	h.resolve(w, r, h.service.Reject)
+}
+
+// resolve decodes and validates the review request of the given request and resolves the review with the given
+// service method.
+func (h TransactionReviewHandler) resolve(w http.ResponseWriter, r *http.Request,
+	resolveFunc func(dto.TransactionReviewRequest) (*dto.TransactionReviewResponse, *errs.AppError)) {
+	vars := mux.Vars(r)
+	request := dto.TransactionReviewRequest{
+		ReviewId: vars["review_id"],
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		logger.Error("Error while decoding json body of transaction review request: " + err.Error())
+		writeErrorResponse(w, r, errs.NewAppError(http.StatusBadRequest, "Please check that all fields are correctly filled."))
+		return
+	}
+
+	if valErr := request.Validate(); valErr != nil {
+		writeValidationErrorResponse(w, r, valErr)
+		return
+	}
+
+	response, appErr := resolveFunc(request)
+	if appErr != nil {
+		writeErrorResponse(w, r, appErr)
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, response)
+}
@@ -0,0 +1,119 @@
+package app
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/mocks/service"
+	"github.com/gorilla/mux"
+	"go.uber.org/mock/gomock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockTransactionReviewService *service.MockTransactionReviewService
+var trh TransactionReviewHandler
+
+const transactionReviewsPath = "/transactions/reviews"
+
+func setupTransactionReviewHandlerTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	mockTransactionReviewService = service.NewMockTransactionReviewService(ctrl)
+	trh = TransactionReviewHandler{mockTransactionReviewService}
+
+	router = mux.NewRouter()
+	router.HandleFunc("/transactions/reviews", trh.reviewsHandler).Methods(http.MethodGet)
+	router.HandleFunc("/transactions/reviews/{review_id:[0-9]+}/approve", trh.approveHandler).Methods(http.MethodPost)
+	router.HandleFunc("/transactions/reviews/{review_id:[0-9]+}/reject", trh.rejectHandler).Methods(http.MethodPost)
+
+	recorder = httptest.NewRecorder()
+
+	return func() {
+		router = nil
+		recorder = nil
+		request = nil
+		defer ctrl.Finish()
+	}
+}
+
+func TestTransactionReviewHandler_reviewsHandler_respondsWith_pendingReviewsAndStatusCode200(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionReviewHandlerTest(t)
+	defer teardown()
+	request = httptest.NewRequest(http.MethodGet, transactionReviewsPath, nil)
+
+	dummyReviews := []dto.TransactionReviewResponse{{ReviewId: "5", AccountId: dummyAccountId, Status: dto.TransactionStatusPendingReview}}
+	mockTransactionReviewService.EXPECT().GetPendingReviews().Return(dummyReviews, nil)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusOK {
+		t.Errorf("Expected status code %d but got %d", http.StatusOK, recorder.Result().StatusCode)
+	}
+	actualResponse, _ := io.ReadAll(recorder.Result().Body)
+	if !strings.Contains(string(actualResponse), `"review_id":"5"`) {
+		t.Errorf("Expected response to contain the pending review but got %s", string(actualResponse))
+	}
+}
+
+func TestTransactionReviewHandler_approveHandler_respondsWith_statusCode422_when_comment_missing(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionReviewHandlerTest(t)
+	defer teardown()
+	request = httptest.NewRequest(http.MethodPost, transactionReviewsPath+"/5/approve", strings.NewReader(`{"comment": "  "}`))
+	mockTransactionReviewService.EXPECT().Approve(gomock.Any()).Times(0)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status code %d but got %d", http.StatusUnprocessableEntity, recorder.Result().StatusCode)
+	}
+}
+
+func TestTransactionReviewHandler_approveHandler_respondsWith_reviewAndStatusCode200_when_service_succeeds(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionReviewHandlerTest(t)
+	defer teardown()
+	request = httptest.NewRequest(http.MethodPost, transactionReviewsPath+"/5/approve", strings.NewReader(`{"comment": "Confirmed by phone"}`))
+
+	expectedRequest := dto.TransactionReviewRequest{ReviewId: "5", Comment: "Confirmed by phone"}
+	dummyResponse := dto.TransactionReviewResponse{ReviewId: "5", Status: dto.TransactionStatusPosted, Comment: "Confirmed by phone"}
+	mockTransactionReviewService.EXPECT().Approve(expectedRequest).Return(&dummyResponse, nil)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusOK {
+		t.Errorf("Expected status code %d but got %d", http.StatusOK, recorder.Result().StatusCode)
+	}
+	actualResponse, _ := io.ReadAll(recorder.Result().Body)
+	if !strings.Contains(string(actualResponse), `"status":"posted"`) {
+		t.Errorf("Expected response to contain the posted status but got %s", string(actualResponse))
+	}
+}
+
+func TestTransactionReviewHandler_rejectHandler_respondsWith_errorStatusCode_when_service_fails(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionReviewHandlerTest(t)
+	defer teardown()
+	request = httptest.NewRequest(http.MethodPost, transactionReviewsPath+"/5/reject", strings.NewReader(`{"comment": "Card reported stolen"}`))
+
+	dummyAppErr := errs.NewConflictError("Transaction review has already been resolved")
+	mockTransactionReviewService.EXPECT().Reject(gomock.Any()).Return(nil, dummyAppErr)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusConflict {
+		t.Errorf("Expected status code %d but got %d", http.StatusConflict, recorder.Result().StatusCode)
+	}
+}
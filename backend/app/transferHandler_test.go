@@ -0,0 +1,90 @@
+package app
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/mocks/service"
+	"github.com/gorilla/mux"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockTransferService *service.MockTransferService
+var tfh TransferHandler
+
+const transfersPath = "/customers/2/account/1977/transfers"
+
+func setupTransferHandlerTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	mockTransferService = service.NewMockTransferService(ctrl)
+	tfh = TransferHandler{mockTransferService}
+
+	router = mux.NewRouter()
+	router.HandleFunc("/customers/{customer_id:[0-9]+}/account/{account_id:[0-9]+}/transfers", tfh.transferHandler).Methods(http.MethodPost)
+
+	recorder = httptest.NewRecorder()
+
+	return func() {
+		router = nil
+		recorder = nil
+		request = nil
+		defer ctrl.Finish()
+	}
+}
+
+func TestTransferHandler_transferHandler_respondsWith_statusCode422_when_amount_invalid(t *testing.T) {
+	//Arrange
+	teardown := setupTransferHandlerTest(t)
+	defer teardown()
+	request = newCustomerRequest(http.MethodPost, transfersPath, `{"payee_id": "3", "amount": -5}`)
+
+	mockTransferService.EXPECT().Transfer(gomock.Any()).Times(0)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status code %d but got %d", http.StatusUnprocessableEntity, recorder.Result().StatusCode)
+	}
+}
+
+func TestTransferHandler_transferHandler_respondsWith_errorStatusCode_when_service_fails(t *testing.T) {
+	//Arrange
+	teardown := setupTransferHandlerTest(t)
+	defer teardown()
+	request = newCustomerRequest(http.MethodPost, transfersPath, `{"payee_id": "3", "amount": 50}`)
+
+	expectedRequest := dto.TransferRequest{CustomerId: "2", AccountId: "1977", PayeeId: "3", Amount: 50, RequestedBy: "2"}
+	mockTransferService.EXPECT().Transfer(expectedRequest).
+		Return(nil, errs.NewConflictError("Please confirm the payee before the first transfer to it."))
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusConflict {
+		t.Errorf("Expected status code %d but got %d", http.StatusConflict, recorder.Result().StatusCode)
+	}
+}
+
+func TestTransferHandler_transferHandler_respondsWith_statusCode201_when_service_succeeds(t *testing.T) {
+	//Arrange
+	teardown := setupTransferHandlerTest(t)
+	defer teardown()
+	request = newCustomerRequest(http.MethodPost, transfersPath, `{"payee_id": "3", "amount": 50, "confirm_payee": true}`)
+
+	expectedRequest := dto.TransferRequest{CustomerId: "2", AccountId: "1977", PayeeId: "3", Amount: 50, ConfirmPayee: true, RequestedBy: "2"}
+	mockTransferService.EXPECT().Transfer(expectedRequest).Return(&dto.TransferResponse{TransactionId: "10", PayeeId: "3"}, nil)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusCreated {
+		t.Errorf("Expected status code %d but got %d", http.StatusCreated, recorder.Result().StatusCode)
+	}
+}
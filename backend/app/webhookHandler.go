@@ -0,0 +1,89 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/service"
+	"github.com/gorilla/mux"
+	"net/http"
+	"strings"
+)
+
+type WebhookHandler struct {
+	service   service.WebhookService
+	approvals service.ApprovalService
+}
+
+func (h WebhookHandler) newSubscriptionHandler(w http.ResponseWriter, r *http.Request) {
+	var request dto.NewWebhookSubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		logger.Error("Error while decoding json body of new webhook subscription request: " + err.Error())
+		writeErrorResponse(w, r, errs.NewAppError(http.StatusBadRequest, "Please check that all fields are correctly filled."))
+		return
+	}
+
+	if valErr := request.Validate(); valErr != nil {
+		writeValidationErrorResponse(w, r, valErr)
+		return
+	}
+
+	requestApproval(w, r, h.approvals, domain.ApprovalOperationNewWebhookSubscription,
+		fmt.Sprintf("subscribe %s to %s", request.Url, strings.Join(request.EventTypes, ", ")), request)
+}
+
+func (h WebhookHandler) subscriptionsHandler(w http.ResponseWriter, r *http.Request) {
+	response, appErr := h.service.GetAllSubscriptions()
+	if appErr != nil {
+		writeErrorResponse(w, r, appErr)
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, response)
+}
+
+func (h WebhookHandler) deleteSubscriptionHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	requestApproval(w, r, h.approvals, domain.ApprovalOperationDeleteWebhookSubscription,
+		"delete webhook subscription "+vars["subscription_id"], vars["subscription_id"])
+}
+
+func (h WebhookHandler) deliveriesHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	response, appErr := h.service.GetDeliveries(vars["subscription_id"])
+	if appErr != nil {
+		writeErrorResponse(w, r, appErr)
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, response)
+}
+
+func (h WebhookHandler) deliveryHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	response, appErr := h.service.GetDelivery(vars["delivery_id"])
+	if appErr != nil {
+		writeErrorResponse(w, r, appErr)
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, response)
+}
+
+func (h WebhookHandler) redeliverHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	response, appErr := h.service.Redeliver(vars["delivery_id"])
+	if appErr != nil {
+		writeErrorResponse(w, r, appErr)
+		return
+	}
+
+	writeJsonResponse(w, http.StatusOK, response)
+}
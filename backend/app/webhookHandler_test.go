@@ -0,0 +1,131 @@
+package app
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/mocks/service"
+	"github.com/gorilla/mux"
+	"go.uber.org/mock/gomock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockWebhookService *service.MockWebhookService
+var wh WebhookHandler
+
+const webhooksPath = "/webhooks"
+const dummyWebhookSecret = "0123456789abcdef"
+
+func setupWebhookHandlerTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	mockWebhookService = service.NewMockWebhookService(ctrl)
+	mockApprovalService = service.NewMockApprovalService(ctrl)
+	wh = WebhookHandler{mockWebhookService, mockApprovalService}
+
+	router = mux.NewRouter()
+	router.HandleFunc(webhooksPath, wh.newSubscriptionHandler).Methods(http.MethodPost)
+	router.HandleFunc("/webhooks/{subscription_id:[0-9]+}", wh.deleteSubscriptionHandler).Methods(http.MethodDelete)
+	router.HandleFunc("/webhooks/deliveries/{delivery_id:[0-9]+}/redeliver", wh.redeliverHandler).Methods(http.MethodPost)
+
+	recorder = httptest.NewRecorder()
+
+	return func() {
+		router = nil
+		recorder = nil
+		request = nil
+		defer ctrl.Finish()
+	}
+}
+
+func TestWebhookHandler_newSubscriptionHandler_respondsWith_statusCode422_when_request_invalid(t *testing.T) {
+	//Arrange
+	teardown := setupWebhookHandlerTest(t)
+	defer teardown()
+	payload := `{"url": "ftp://example.com/hooks", "event_types": ["AccountOpened"], "secret": "0123456789abcdef"}`
+	request = httptest.NewRequest(http.MethodPost, webhooksPath, strings.NewReader(payload))
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status code %d but got %d", http.StatusUnprocessableEntity, recorder.Result().StatusCode)
+	}
+}
+
+func TestWebhookHandler_newSubscriptionHandler_respondsWith_approvalRequestAndStatusCode202_when_request_valid(t *testing.T) {
+	//Arrange
+	teardown := setupWebhookHandlerTest(t)
+	defer teardown()
+	payload := `{"url": "https://example.com/hooks", "event_types": ["AccountOpened"], "secret": "0123456789abcdef"}`
+	request = httptest.NewRequest(http.MethodPost, webhooksPath, strings.NewReader(payload))
+
+	expectedRequest := dto.NewWebhookSubscriptionRequest{
+		Url:        "https://example.com/hooks",
+		EventTypes: []string{"AccountOpened"},
+		Secret:     "0123456789abcdef",
+	}
+	dummyApproval := dto.ApprovalResponse{ApprovalId: "5", Description: "subscribe https://example.com/hooks to AccountOpened"}
+	mockApprovalService.EXPECT().RequestApproval(domain.ApprovalOperationNewWebhookSubscription,
+		"subscribe https://example.com/hooks to AccountOpened", expectedRequest, "").Return(&dummyApproval, nil)
+	mockWebhookService.EXPECT().CreateSubscription(gomock.Any()).Times(0)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusAccepted {
+		t.Errorf("Expected status code %d but got %d", http.StatusAccepted, recorder.Result().StatusCode)
+	}
+	actualResponse, _ := io.ReadAll(recorder.Result().Body)
+	if strings.Contains(string(actualResponse), "secret") {
+		t.Errorf("Expected response without the secret but got %s", string(actualResponse))
+	}
+}
+
+func TestWebhookHandler_deleteSubscriptionHandler_respondsWith_errorStatusCode_when_approvalRequest_fails(t *testing.T) {
+	//Arrange
+	teardown := setupWebhookHandlerTest(t)
+	defer teardown()
+	request = httptest.NewRequest(http.MethodDelete, "/webhooks/5", nil)
+
+	dummyAppErr := errs.NewAuthorizationError("Unable to identify the admin making the request")
+	mockApprovalService.EXPECT().RequestApproval(domain.ApprovalOperationDeleteWebhookSubscription,
+		"delete webhook subscription 5", "5", "").Return(nil, dummyAppErr)
+	mockWebhookService.EXPECT().DeleteSubscription(gomock.Any()).Times(0)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusForbidden {
+		t.Errorf("Expected status code %d but got %d", http.StatusForbidden, recorder.Result().StatusCode)
+	}
+}
+
+func TestWebhookHandler_redeliverHandler_respondsWith_deliveryAndStatusCode200_when_service_succeeds(t *testing.T) {
+	//Arrange
+	teardown := setupWebhookHandlerTest(t)
+	defer teardown()
+	request = httptest.NewRequest(http.MethodPost, "/webhooks/deliveries/8/redeliver", nil)
+
+	dummyResponse := dto.WebhookDeliveryResponse{DeliveryId: "8", Status: dto.WebhookDeliveryStatusSucceeded}
+	mockWebhookService.EXPECT().Redeliver("8").Return(&dummyResponse, nil)
+
+	//Act
+	router.ServeHTTP(recorder, request)
+
+	//Assert
+	if recorder.Result().StatusCode != http.StatusOK {
+		t.Errorf("Expected status code %d but got %d", http.StatusOK, recorder.Result().StatusCode)
+	}
+	actualResponse, _ := io.ReadAll(recorder.Result().Body)
+	if !strings.Contains(string(actualResponse), dto.WebhookDeliveryStatusSucceeded) {
+		t.Errorf("Expected response to contain the delivery status but got %s", string(actualResponse))
+	}
+}
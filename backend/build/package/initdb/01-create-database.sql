@@ -0,0 +1,3 @@
+-- Only creates the empty database. The schema is managed by the migrations embedded in the backend
+-- (`go run main.go migrate up`) and the demo data is loaded separately (`go run main.go migrate seed`).
+CREATE DATABASE IF NOT EXISTS banking;
@@ -4,35 +4,45 @@ import (
 	"github.com/aliciatay-zls/banking-lib/clock"
 	"github.com/aliciatay-zls/banking-lib/errs"
 	"github.com/aliciatay-zls/banking/backend/dto"
+	"math"
 )
 
 //Business Domain
 
+const AccountStatusInactive = "0"
+const AccountStatusActive = "1"
+const AccountStatusFrozen = "2" //frozen pending review, e.g. after a failed balance reconciliation
+
 type Account struct { //business/domain object
-	AccountId   string  `db:"account_id"`
-	CustomerId  string  `db:"customer_id"`
-	OpeningDate string  `db:"opening_date"`
-	AccountType string  `db:"account_type"`
-	Amount      float64 `db:"amount"`
-	Status      string  `db:"status"`
+	AccountId      string  `db:"account_id"`
+	CustomerId     string  `db:"customer_id"`
+	OpeningDate    string  `db:"opening_date"`
+	AccountType    string  `db:"account_type"`
+	Amount         float64 `db:"amount"`
+	Status         string  `db:"status"`
+	OpeningAmount  float64 `db:"opening_amount"`  //only written when the account is created, see AccountBalanceSummary
+	OverdraftLimit float64 `db:"overdraft_limit"` //how far below zero the balance may go, only for checking accounts
+	HeldAmount     float64 `db:"-"`               //total of the active holds on the account, not stored with it
 }
 
 func NewAccount(customerId string, accountType string, amount float64, c clock.Clock) Account {
 	return Account{
-		CustomerId:  customerId,
-		OpeningDate: c.NowAsString(),
-		AccountType: accountType,
-		Amount:      amount,
-		Status:      "1", //default for newly-created account
+		CustomerId:    customerId,
+		OpeningDate:   c.NowAsString(),
+		AccountType:   accountType,
+		Amount:        amount,
+		Status:        AccountStatusActive, //default for newly-created account
+		OpeningAmount: amount,
 	}
 }
 
 func (a Account) ToDTO() *dto.AccountResponse {
 	return &dto.AccountResponse{
-		AccountId:   a.AccountId,
-		OpeningDate: a.OpeningDate,
-		AccountType: a.AccountType,
-		Amount:      a.Amount,
+		AccountId:       a.AccountId,
+		OpeningDate:     a.OpeningDate,
+		AccountType:     a.AccountType,
+		Amount:          a.Amount,
+		AvailableAmount: a.AvailableBalance(),
 	}
 }
 
@@ -40,8 +50,63 @@ func (a Account) ToNewAccountResponseDTO() *dto.NewAccountResponse {
 	return &dto.NewAccountResponse{AccountId: a.AccountId, OpeningDate: a.OpeningDate}
 }
 
+// AvailableBalance returns the ledger balance of the account less the funds on hold, which is what can be withdrawn.
+func (a Account) AvailableBalance() float64 {
+	return a.Amount - a.HeldAmount
+}
+
+// CanWithdraw reports whether the given amount can be withdrawn from the available balance, which a checking account
+// may overdraw down to its overdraft limit. A saving account cannot be overdrawn.
 func (a Account) CanWithdraw(withdrawalAmount float64) bool {
-	return a.Amount >= withdrawalAmount
+	return a.AvailableBalance()+a.usableOverdraftLimit() >= withdrawalAmount
+}
+
+// ChargeableFee returns how much of the given fee can be charged to the account: all of it for a checking account,
+// which may be overdrawn to pay it, but no more than the balance of a saving account, which cannot.
+func (a Account) ChargeableFee(fee float64) float64 {
+	if a.IsChecking() {
+		return fee
+	}
+	return math.Min(fee, math.Max(0, a.Amount))
+}
+
+func (a Account) IsChecking() bool {
+	return a.AccountType == dto.AccountTypeChecking
+}
+
+// OverdrawnAmount returns how far the ledger balance of the account is below zero, or 0 if it is not overdrawn.
+func (a Account) OverdrawnAmount() float64 {
+	return math.Max(0, -a.Amount)
+}
+
+// AvailableOverdraft returns what is left of the overdraft limit of the account once the available balance has been
+// used up.
+func (a Account) AvailableOverdraft() float64 {
+	return math.Max(0, a.usableOverdraftLimit()-math.Max(0, -a.AvailableBalance()))
+}
+
+// usableOverdraftLimit returns the overdraft limit of the account, which is ignored for saving accounts.
+func (a Account) usableOverdraftLimit() float64 {
+	if !a.IsChecking() {
+		return 0
+	}
+	return a.OverdraftLimit
+}
+
+func (a Account) IsFrozen() bool {
+	return a.Status == AccountStatusFrozen
+}
+
+// AccountStatusName gets the string representation of database values for account status.
+func AccountStatusName(status string) string {
+	switch status {
+	case AccountStatusInactive:
+		return "inactive"
+	case AccountStatusFrozen:
+		return "frozen"
+	default:
+		return "active"
+	}
 }
 
 //Server
@@ -52,4 +117,9 @@ type AccountRepository interface { //repo (secondary port)
 	FindAll(string) ([]Account, *errs.AppError)
 	FindById(string) (*Account, *errs.AppError)
 	Transact(Transaction) (*Transaction, *errs.AppError)
+	Transfer(Transaction, Transaction) (*Transaction, *Transaction, *errs.AppError)
+	FindTransactions(string) ([]Transaction, *errs.AppError)
+	FindTransaction(string, string) (*Transaction, *errs.AppError)
+	UpdateTransactionCategory(string, string) *errs.AppError
+	UpdateOverdraftLimit(string, float64) *errs.AppError
 }
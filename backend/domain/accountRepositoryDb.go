@@ -5,6 +5,7 @@ import (
 	"errors"
 	"github.com/aliciatay-zls/banking-lib/errs"
 	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/problem"
 	"github.com/jmoiron/sqlx"
 	"strconv"
 )
@@ -19,36 +20,64 @@ func NewAccountRepositoryDb(dbClient *sqlx.DB) AccountRepositoryDb {
 	return AccountRepositoryDb{dbClient}
 }
 
-// Save creates a new entry in the database for the given account, sets its ID using the database-generated ID
-// and returns the account.
+// Save starts a database transaction, creates a new entry in the database for the given account, sets its ID using
+// the database-generated ID, writes an AccountOpened event to the outbox and commits the database transaction.
+// Save returns the account.
 func (d AccountRepositoryDb) Save(account Account) (*Account, *errs.AppError) { //DB implements repo
-	addAccountSql := "INSERT INTO accounts (customer_id, opening_date, account_type, amount, status) VALUES (?, ?, ?, ?, ?)"
-	result, err := d.client.Exec(addAccountSql,
-		account.CustomerId, account.OpeningDate, account.AccountType, account.Amount, account.Status)
+	tx, err := d.client.Beginx()
+	if err != nil {
+		logger.Error("Error while starting db transaction for creating new account: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	addAccountSql := "INSERT INTO accounts (customer_id, opening_date, account_type, amount, status, opening_amount) VALUES (?, ?, ?, ?, ?, ?)"
+	result, err := execInsert(tx, addAccountSql, "account_id",
+		account.CustomerId, account.OpeningDate, account.AccountType, account.Amount, account.Status, account.OpeningAmount)
 	if err != nil {
 		logger.Error("Error while creating new account: " + err.Error())
+		rollbackAccount(tx)
 		return nil, errs.NewUnexpectedError("Unexpected database error")
 	}
 
 	id, err := result.LastInsertId()
 	if err != nil {
 		logger.Error("Error while getting id of newly inserted account: " + err.Error())
+		rollbackAccount(tx)
 		return nil, errs.NewUnexpectedError("Unexpected database error")
 	}
 	account.AccountId = strconv.FormatInt(id, 10)
 
+	if err = insertOutboxEvent(tx, NewAccountOpenedEvent(account)); err != nil {
+		logger.Error("Error while writing account opened event to outbox: " + err.Error())
+		rollbackAccount(tx)
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("Error while committing db transaction: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
 	return &account, nil
 }
 
+// selectAccountsSql returns the query selecting the columns of the accounts table, to which a WHERE clause can be
+// appended. The opening amount is left out as it is only read by reconciliation and is unset for legacy accounts.
+func (d AccountRepositoryDb) selectAccountsSql() string {
+	return "SELECT account_id, customer_id, " + dateTimeColumn(d.client.DriverName(), "opening_date") +
+		", account_type, amount, status, overdraft_limit FROM accounts"
+}
+
 // FindAll retrieves all accounts belonging to the customer with the given id.
 func (d AccountRepositoryDb) FindAll(customerId string) ([]Account, *errs.AppError) {
 	accounts := make([]Account, 0)
-	selectSql := "SELECT * FROM accounts WHERE customer_id = ?"
-	err := d.client.Select(&accounts, selectSql, customerId)
+	selectSql := d.selectAccountsSql() + " WHERE customer_id = ?"
+	err := d.client.Select(&accounts, d.client.Rebind(selectSql), customerId)
 	if err != nil {
 		logger.Error("Error while retrieving all accounts belonging to this customer: " + err.Error())
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errs.NewNotFoundError("No accounts found for this customer or customer does not exist")
+			return nil, problem.NewNotFoundError(problem.AccountNotFound,
+				"No accounts found for this customer or customer does not exist")
 		}
 		return nil, errs.NewUnexpectedError("Unexpected database error")
 	}
@@ -59,12 +88,12 @@ func (d AccountRepositoryDb) FindAll(customerId string) ([]Account, *errs.AppErr
 // FindById retrieves the account with the given id.
 func (d AccountRepositoryDb) FindById(accountId string) (*Account, *errs.AppError) {
 	var account Account
-	findAccountSql := "SELECT * FROM accounts WHERE account_id = ?"
-	err := d.client.Get(&account, findAccountSql, accountId)
+	findAccountSql := d.selectAccountsSql() + " WHERE account_id = ?"
+	err := d.client.Get(&account, d.client.Rebind(findAccountSql), accountId)
 	if err != nil {
 		logger.Error("Error while retrieving account: " + err.Error())
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errs.NewNotFoundError("Account not found")
+			return nil, problem.NewNotFoundError(problem.AccountNotFound, "Account not found")
 		} else {
 			return nil, errs.NewUnexpectedError("Unexpected database error")
 		}
@@ -73,61 +102,229 @@ func (d AccountRepositoryDb) FindById(accountId string) (*Account, *errs.AppErro
 	return &account, nil
 }
 
-// Transact starts a database transaction, updates the account balance, creates a new entry in the database for
-// the given bank transaction and commits the database transaction. It then fills the missing fields of the given
-// bank transaction by retrieving the ID of the new entry as well as the new account balance.
+// Transact starts a database transaction, posts the given bank transaction and commits the database transaction. It
+// fills the missing fields of the given bank transaction with the ID of the new entry and the new account balance.
 // Transact returns the modified given bank transaction.
 func (d AccountRepositoryDb) Transact(transaction Transaction) (*Transaction, *errs.AppError) { //DB implements repo
-	tx, err := d.client.Begin()
+	tx, err := d.client.Beginx()
 	if err != nil {
 		logger.Error("Error while starting db transaction for making transaction in bank account: " + err.Error())
 		return nil, errs.NewUnexpectedError("Unexpected database error")
 	}
 
+	posted, appErr := postTransaction(tx, transaction)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("Error while committing db transaction: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return posted, nil
+}
+
+// Transfer starts a database transaction, posts the given withdrawal, links the given deposit to it, posts the deposit
+// and commits the database transaction, so that either both accounts change or neither does.
+// Transfer returns the posted withdrawal and deposit.
+func (d AccountRepositoryDb) Transfer(withdrawal Transaction, deposit Transaction) (*Transaction, *Transaction, *errs.AppError) { //DB implements repo
+	tx, err := d.client.Beginx()
+	if err != nil {
+		logger.Error("Error while starting db transaction for making transfer: " + err.Error())
+		return nil, nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	postedWithdrawal, appErr := postTransaction(tx, withdrawal)
+	if appErr != nil {
+		return nil, nil, appErr
+	}
+	deposit.RelatedTransactionId = sql.NullString{String: postedWithdrawal.TransactionId, Valid: true}
+	postedDeposit, appErr := postTransaction(tx, deposit)
+	if appErr != nil {
+		return nil, nil, appErr
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("Error while committing db transaction: " + err.Error())
+		return nil, nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return postedWithdrawal, postedDeposit, nil
+}
+
+// postTransaction updates the account balance, creates a new entry in the database for the given bank transaction,
+// reads the new account balance and writes a TransactionPosted event to the outbox within the given database
+// transaction, which it rolls back on error. A withdrawal is only posted if it leaves the account within its overdraft
+// limit, so that two withdrawals made at once cannot both pass the check of the service. A charge of the bank is
+// always posted. It fills the missing fields of the given bank transaction with the ID of the new entry and the new
+// account balance.
+// postTransaction returns the modified given bank transaction.
+func postTransaction(tx *sqlx.Tx, transaction Transaction) (*Transaction, *errs.AppError) {
 	var updateAccountSql string
-	if transaction.IsWithdrawal() {
+	args := []interface{}{transaction.Amount, transaction.AccountId}
+	switch {
+	case transaction.IsWithdrawal():
+		updateAccountSql = "UPDATE accounts SET amount = amount - ? WHERE account_id = ? AND amount + overdraft_limit >= ?"
+		args = append(args, transaction.Amount)
+	case transaction.IsDebit():
 		updateAccountSql = "UPDATE accounts SET amount = amount - ? WHERE account_id = ?"
-	} else {
+	default:
 		updateAccountSql = "UPDATE accounts SET amount = amount + ? WHERE account_id = ?"
 	}
-	_, err = tx.Exec(updateAccountSql, transaction.Amount, transaction.AccountId)
+	result, err := tx.Exec(tx.Rebind(updateAccountSql), args...)
 	if err != nil {
 		logger.Error("Error while updating account: " + err.Error())
-		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			logger.Fatal("Error while rolling back updating of account: " + rollbackErr.Error())
-		}
+		rollbackAccount(tx)
 		return nil, errs.NewUnexpectedError("Unexpected database error")
 	}
-
-	var result sql.Result
-	addTransactionSql := "INSERT INTO transactions (account_id, amount, transaction_type, transaction_date) VALUES (?, ?, ?, ?)"
-	result, err = tx.Exec(addTransactionSql,
-		transaction.AccountId, transaction.Amount, transaction.TransactionType, transaction.TransactionDate)
-	if err != nil {
-		logger.Error("Error while creating new bank account transaction: " + err.Error())
-		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			logger.Fatal("Error while rolling back creating of new bank account transaction: " + rollbackErr.Error())
+	if transaction.IsWithdrawal() {
+		if rowsAffected, err := result.RowsAffected(); err != nil || rowsAffected != 1 {
+			logger.Error("Error while updating account: withdrawal exceeds account balance")
+			rollbackAccount(tx)
+			return nil, problem.NewValidationError(problem.InsufficientFunds,
+				"Account balance insufficient to withdraw given amount")
 		}
-		return nil, errs.NewUnexpectedError("Unexpected database error")
 	}
 
-	if err = tx.Commit(); err != nil {
-		logger.Error("Error while committing db transaction: " + err.Error())
+	result, err = insertTransaction(tx, transaction)
+	if err != nil {
+		logger.Error("Error while creating new bank account transaction: " + err.Error())
+		rollbackAccount(tx)
 		return nil, errs.NewUnexpectedError("Unexpected database error")
 	}
 
 	id, err := result.LastInsertId()
 	if err != nil {
 		logger.Error("Error while getting id of newly inserted transaction: " + err.Error())
+		rollbackAccount(tx)
 		return nil, errs.NewUnexpectedError("Unexpected database error")
 	}
 	transaction.TransactionId = strconv.FormatInt(id, 10)
 
-	account, appErr := d.FindById(transaction.AccountId)
-	if appErr != nil {
-		return nil, appErr
+	balanceSql := "SELECT amount FROM accounts WHERE account_id = ?"
+	if err = tx.Get(&transaction.Balance, tx.Rebind(balanceSql), transaction.AccountId); err != nil {
+		logger.Error("Error while retrieving new account balance: " + err.Error())
+		rollbackAccount(tx)
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	if err = insertOutboxEvent(tx, NewTransactionPostedEvent(transaction)); err != nil {
+		logger.Error("Error while writing transaction posted event to outbox: " + err.Error())
+		rollbackAccount(tx)
+		return nil, errs.NewUnexpectedError("Unexpected database error")
 	}
-	transaction.Balance = account.Amount
 
 	return &transaction, nil
 }
+
+// canSetAsideFunds locks the row of the account with the given id until the end of the given database transaction and
+// reports whether the given amount can still be set aside from it at the given time, i.e. whether it is within the
+// available balance once the active holds and the withdrawals pending review are taken out, and the overdraft limit
+// of a checking account.
+func canSetAsideFunds(tx *sqlx.Tx, accountId string, amount float64, now string) (bool, *errs.AppError) {
+	lockSql := "UPDATE accounts SET amount = amount WHERE account_id = ?" //a row lock in every database supported
+	if _, err := tx.Exec(tx.Rebind(lockSql), accountId); err != nil {
+		logger.Error("Error while locking account: " + err.Error())
+		return false, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	var account Account
+	findSql := "SELECT account_type, amount, overdraft_limit FROM accounts WHERE account_id = ?"
+	if err := tx.Get(&account, tx.Rebind(findSql), accountId); err != nil {
+		logger.Error("Error while retrieving account balance: " + err.Error())
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, problem.NewNotFoundError(problem.AccountNotFound, "Account not found")
+		}
+		return false, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	held, err := selectHeldAmount(tx, accountId, now)
+	if err != nil {
+		logger.Error("Error while retrieving held amount of account: " + err.Error())
+		return false, errs.NewUnexpectedError("Unexpected database error")
+	}
+	account.HeldAmount = held
+	reserved, err := selectReservedAmount(tx, accountId)
+	if err != nil {
+		logger.Error("Error while retrieving reserved amount of account: " + err.Error())
+		return false, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return account.CanWithdraw(amount + reserved), nil
+}
+
+// selectTransactionsSql returns the query selecting bank transactions together with the compensating transaction of
+// each one that was reversed.
+func selectTransactionsSql(driverName string) string {
+	return "SELECT transaction_id, account_id, amount, transaction_type, " +
+		dateTimeColumn(driverName, "transaction_date") + ", description, reference, category, related_transaction_id, " +
+		"(SELECT r.reversal_transaction_id FROM transaction_reversals r WHERE r.transaction_id = transactions.transaction_id) AS reversed_by_transaction_id " +
+		"FROM transactions"
+}
+
+// FindTransactions retrieves all bank transactions made on the account with the given id, oldest first.
+func (d AccountRepositoryDb) FindTransactions(accountId string) ([]Transaction, *errs.AppError) {
+	transactions := make([]Transaction, 0)
+	findSql := selectTransactionsSql(d.client.DriverName()) + " WHERE account_id = ? ORDER BY transaction_id"
+	if err := d.client.Select(&transactions, d.client.Rebind(findSql), accountId); err != nil {
+		logger.Error("Error while retrieving transactions of account: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return transactions, nil
+}
+
+// FindTransaction retrieves the bank transaction with the given id made on the account with the given id.
+func (d AccountRepositoryDb) FindTransaction(accountId string, transactionId string) (*Transaction, *errs.AppError) {
+	var transaction Transaction
+	findSql := selectTransactionsSql(d.client.DriverName()) + " WHERE transaction_id = ? AND account_id = ?"
+	if err := d.client.Get(&transaction, d.client.Rebind(findSql), transactionId, accountId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Error("Error while retrieving transaction of account: transaction not found")
+			return nil, problem.NewNotFoundError(problem.TransactionNotFound, "Transaction not found")
+		}
+		logger.Error("Error while retrieving transaction of account: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return &transaction, nil
+}
+
+// UpdateTransactionCategory files the bank transaction with the given id under the given category.
+func (d AccountRepositoryDb) UpdateTransactionCategory(transactionId string, category string) *errs.AppError {
+	updateSql := "UPDATE transactions SET category = ? WHERE transaction_id = ?"
+	if _, err := d.client.Exec(d.client.Rebind(updateSql), category, transactionId); err != nil {
+		logger.Error("Error while updating category of transaction: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
+
+// UpdateOverdraftLimit sets the overdraft limit of the account with the given id, which the caller has found. The rows
+// affected are not checked, as MySQL reports none when the limit is unchanged.
+func (d AccountRepositoryDb) UpdateOverdraftLimit(accountId string, limit float64) *errs.AppError {
+	updateSql := "UPDATE accounts SET overdraft_limit = ? WHERE account_id = ?"
+	if _, err := d.client.Exec(d.client.Rebind(updateSql), limit, accountId); err != nil {
+		logger.Error("Error while updating overdraft limit of account: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
+
+// insertTransaction creates a new entry in the database for the given bank transaction within the given database
+// transaction.
+func insertTransaction(tx *sqlx.Tx, transaction Transaction) (sql.Result, error) {
+	addTransactionSql := "INSERT INTO transactions (account_id, amount, transaction_type, transaction_date, description, reference, category, related_transaction_id) " +
+		"VALUES (?, ?, ?, ?, ?, ?, ?, ?)"
+	return execInsert(tx, addTransactionSql, "transaction_id", transaction.AccountId, transaction.Amount, transaction.TransactionType,
+		transaction.TransactionDate, transaction.Description, transaction.Reference, transaction.Category, transaction.RelatedTransactionId)
+}
+
+func rollbackAccount(tx *sqlx.Tx) {
+	if rollbackErr := tx.Rollback(); rollbackErr != nil {
+		logger.Fatal("Error while rolling back changes to account: " + rollbackErr.Error())
+	}
+}
@@ -7,12 +7,14 @@ import (
 	"github.com/aliciatay-zls/banking-lib/logger"
 	"github.com/aliciatay-zls/banking/backend/dto"
 	"github.com/jmoiron/sqlx"
+	"net/http"
+	"strconv"
 	"testing"
 )
 
 // Test common variables and inputs
 var accRepoDb AccountRepositoryDb
-var accountsTableColumns = []string{"account_id", "customer_id", "opening_date", "account_type", "amount", "status"}
+var accountsTableColumns = []string{"account_id", "customer_id", "opening_date", "account_type", "amount", "status", "overdraft_limit"}
 
 const dummyDate = "2006-01-02 15:04:05"
 const dummyAmount float64 = 6000
@@ -27,14 +29,57 @@ const dummyTransactionIdAsInt int64 = 7791
 const dummyBalance float64 = 12000
 const dummyBalanceAfterWithdrawal float64 = 0
 
-const insertAccountsSql = "INSERT INTO accounts (customer_id, opening_date, account_type, amount, status) VALUES (?, ?, ?, ?, ?)"
-const selectAccountsOfCustomerSql = "SELECT * FROM accounts WHERE customer_id = ?"
-const selectAccountsSql = "SELECT * FROM accounts WHERE account_id = ?"
+const insertAccountsSql = "INSERT INTO accounts (customer_id, opening_date, account_type, amount, status, opening_amount) VALUES (?, ?, ?, ?, ?, ?)"
+const selectAccountsOfCustomerSql = "SELECT account_id, customer_id, opening_date, account_type, amount, status, overdraft_limit FROM accounts WHERE customer_id = ?"
+const selectAccountsSql = "SELECT account_id, customer_id, opening_date, account_type, amount, status, overdraft_limit FROM accounts WHERE account_id = ?"
 const updateAccountsDepositSql = "UPDATE accounts SET amount = amount + ? WHERE account_id = ?"
-const updateAccountsWithdrawalSql = "UPDATE accounts SET amount = amount - ? WHERE account_id = ?"
+const updateAccountsDebitSql = "UPDATE accounts SET amount = amount - ? WHERE account_id = ?"
+const updateAccountsWithdrawalSql = "UPDATE accounts SET amount = amount - ? WHERE account_id = ? AND amount + overdraft_limit >= ?"
+const lockAccountsSql = "UPDATE accounts SET amount = amount WHERE account_id = ?"
+const selectAccountBalanceSql = "SELECT account_type, amount, overdraft_limit FROM accounts WHERE account_id = ?"
 const insertTransactionsSql = "INSERT INTO transactions (account_id, amount, transaction_type, transaction_date) VALUES (?, ?, ?, ?)"
+const insertTransactionsWithDetailsSql = "INSERT INTO transactions (account_id, amount, transaction_type, transaction_date, description, reference, category, related_transaction_id) " +
+	"VALUES (?, ?, ?, ?, ?, ?, ?, ?)"
+const selectBalanceSql = "SELECT amount FROM accounts WHERE account_id = ?"
+const selectTransactionsWithReversalSql = "SELECT transaction_id, account_id, amount, transaction_type, transaction_date, description, reference, category, related_transaction_id, " +
+	"(SELECT r.reversal_transaction_id FROM transaction_reversals r WHERE r.transaction_id = transactions.transaction_id) AS reversed_by_transaction_id FROM transactions"
+const selectTransactionsOfAccountSql = selectTransactionsWithReversalSql + " WHERE account_id = ? ORDER BY transaction_id"
+
+var transactionsTableColumns = []string{"transaction_id", "account_id", "amount", "transaction_type", "transaction_date", "description", "reference", "category", "related_transaction_id", "reversed_by_transaction_id"}
+
+const insertAccountsPostgresSql = "INSERT INTO accounts (customer_id, opening_date, account_type, amount, status, opening_amount) VALUES ($1, $2, $3, $4, $5, $6) RETURNING account_id"
+const selectAccountsOfCustomerPostgresSql = "SELECT account_id, customer_id, to_char(opening_date, 'YYYY-MM-DD HH24:MI:SS') AS opening_date, account_type, amount, status, overdraft_limit FROM accounts WHERE customer_id = $1"
+const selectAccountsPostgresSql = "SELECT account_id, customer_id, to_char(opening_date, 'YYYY-MM-DD HH24:MI:SS') AS opening_date, account_type, amount, status, overdraft_limit FROM accounts WHERE account_id = $1"
+const updateAccountsDepositPostgresSql = "UPDATE accounts SET amount = amount + $1 WHERE account_id = $2"
+const updateAccountsWithdrawalPostgresSql = "UPDATE accounts SET amount = amount - $1 WHERE account_id = $2 AND amount + overdraft_limit >= $3"
+const insertTransactionsWithDetailsPostgresSql = "INSERT INTO transactions (account_id, amount, transaction_type, transaction_date, description, reference, category, related_transaction_id) " +
+	"VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING transaction_id"
+const selectBalancePostgresSql = "SELECT amount FROM accounts WHERE account_id = $1"
+
+// accountRepoDbDialects holds the SQL that the account repository is expected to send for each supported driver.
+var accountRepoDbDialects = []struct {
+	driverName                  string
+	insertAccountsSql           string
+	selectAccountsOfCustomerSql string
+	selectAccountsSql           string
+	updateAccountsDepositSql    string
+	updateAccountsWithdrawalSql string
+	insertTransactionsSql       string
+	selectBalanceSql            string
+	insertOutboxSql             string
+}{
+	{DriverMySQL, insertAccountsSql, selectAccountsOfCustomerSql, selectAccountsSql,
+		updateAccountsDepositSql, updateAccountsWithdrawalSql, insertTransactionsWithDetailsSql, selectBalanceSql, insertOutboxSql},
+	{DriverPostgres, insertAccountsPostgresSql, selectAccountsOfCustomerPostgresSql, selectAccountsPostgresSql,
+		updateAccountsDepositPostgresSql, updateAccountsWithdrawalPostgresSql, insertTransactionsWithDetailsPostgresSql,
+		selectBalancePostgresSql, insertOutboxPostgresSql},
+}
 
 func setupAccountRepoDbTest(t *testing.T) func() {
+	return setupAccountRepoDbTestWithDriver(t, driverName)
+}
+
+func setupAccountRepoDbTestWithDriver(t *testing.T, driverName string) func() {
 	teardown := setupDB(t)
 	accRepoDb = NewAccountRepositoryDb(sqlx.NewDb(db, driverName))
 	return teardown
@@ -44,11 +89,12 @@ func setupAccountRepoDbTest(t *testing.T) func() {
 // of amount 6000 at 2006-01-02 15:04:05
 func getDefaultAccountBeforeSave() Account {
 	return Account{
-		CustomerId:  dummyCustomerId,
-		OpeningDate: dummyDate,
-		AccountType: dummyAccountType,
-		Amount:      dummyAmount,
-		Status:      "1",
+		CustomerId:    dummyCustomerId,
+		OpeningDate:   dummyDate,
+		AccountType:   dummyAccountType,
+		Amount:        dummyAmount,
+		Status:        "1",
+		OpeningAmount: dummyAmount,
 	}
 }
 
@@ -59,6 +105,13 @@ func getDefaultAccountAfterSave() Account {
 	return newAccount
 }
 
+// getDefaultAccountAfterSelect returns the same Account as above as it is read back, i.e. without the opening amount
+func getDefaultAccountAfterSelect() Account {
+	account := getDefaultAccountAfterSave()
+	account.OpeningAmount = 0
+	return account
+}
+
 // getDefaultTransactionBeforeTransact returns a Transaction for making a deposit of amount 6000 on the account
 // with id 1977 at 2006-01-02 15:04:05
 func getDefaultTransactionBeforeTransact() Transaction {
@@ -84,12 +137,16 @@ func TestAccountRepositoryDb_Save_returns_error_when_insertAccounts_fails(t *tes
 	teardown := setupAccountRepoDbTest(t)
 	defer teardown()
 
+	mockDB.ExpectBegin()
+
 	dummyAccount := getDefaultAccountBeforeSave()
 	dummyDbErr := errors.New("not connected to database yet")
 	mockDB.ExpectExec(insertAccountsSql).
-		WithArgs(dummyAccount.CustomerId, dummyAccount.OpeningDate, dummyAccount.AccountType, dummyAccount.Amount, dummyAccount.Status).
+		WithArgs(dummyAccount.CustomerId, dummyAccount.OpeningDate, dummyAccount.AccountType, dummyAccount.Amount, dummyAccount.Status, dummyAccount.OpeningAmount).
 		WillReturnError(dummyDbErr)
 
+	mockDB.ExpectRollback()
+
 	logs := logger.ReplaceWithTestLogger()
 	expectedLogMessage := "Error while creating new account: " + dummyDbErr.Error()
 
@@ -117,13 +174,17 @@ func TestAccountRepositoryDb_Save_returns_error_when_gettingInsertId_fails(t *te
 	teardown := setupAccountRepoDbTest(t)
 	defer teardown()
 
+	mockDB.ExpectBegin()
+
 	dummyAccount := getDefaultAccountBeforeSave()
 	dummyErr := errors.New("some error message")
 	dummyErrorResult := sqlmock.NewErrorResult(dummyErr)
 	mockDB.ExpectExec(insertAccountsSql).
-		WithArgs(dummyAccount.CustomerId, dummyAccount.OpeningDate, dummyAccount.AccountType, dummyAccount.Amount, dummyAccount.Status).
+		WithArgs(dummyAccount.CustomerId, dummyAccount.OpeningDate, dummyAccount.AccountType, dummyAccount.Amount, dummyAccount.Status, dummyAccount.OpeningAmount).
 		WillReturnResult(dummyErrorResult)
 
+	mockDB.ExpectRollback()
+
 	logs := logger.ReplaceWithTestLogger()
 	expectedLogMessage := "Error while getting id of newly inserted account: " + dummyErr.Error()
 
@@ -146,30 +207,73 @@ func TestAccountRepositoryDb_Save_returns_error_when_gettingInsertId_fails(t *te
 	}
 }
 
-func TestAccountRepositoryDb_Save_returns_newAccount_when_insertAccounts_and_getInsertionId_succeed(t *testing.T) {
+func TestAccountRepositoryDb_Save_returns_error_when_insertOutbox_fails(t *testing.T) {
 	//Arrange
 	teardown := setupAccountRepoDbTest(t)
 	defer teardown()
 
+	mockDB.ExpectBegin()
+
 	dummyAccount := getDefaultAccountBeforeSave()
-	var lastInsertID int64 = dummyAccountIdAsInt
-	var rowsAffected int64 = 1
-	dummyResult := sqlmock.NewResult(lastInsertID, rowsAffected)
-	mockDB.ExpectExec(insertAccountsSql).
-		WithArgs(dummyAccount.CustomerId, dummyAccount.OpeningDate, dummyAccount.AccountType, dummyAccount.Amount, dummyAccount.Status).
-		WillReturnResult(dummyResult)
+	expectInsert(driverName, insertAccountsSql, "account_id", dummyAccountIdAsInt,
+		dummyAccount.CustomerId, dummyAccount.OpeningDate, dummyAccount.AccountType, dummyAccount.Amount, dummyAccount.Status, dummyAccount.OpeningAmount)
 
-	expectedNewAccount := getDefaultAccountAfterSave()
+	dummyDbErr := errors.New("some error message")
+	mockDB.ExpectExec(insertOutboxSql).WillReturnError(dummyDbErr)
+
+	mockDB.ExpectRollback()
+
+	logs := logger.ReplaceWithTestLogger()
+	expectedLogMessage := "Error while writing account opened event to outbox: " + dummyDbErr.Error()
 
 	//Act
-	actualNewAccount, err := accRepoDb.Save(dummyAccount)
+	_, actualErr := accRepoDb.Save(dummyAccount)
 
 	//Assert
-	if err != nil {
-		t.Fatal("Expected no error but got error while testing successful saving of account: " + err.Message)
+	if actualErr == nil {
+		t.Fatal("Expected error but got none while testing failed writing of event to outbox")
+	}
+	if actualErr.Message != defaultExpectedErrMessage {
+		t.Errorf("Expected error message to be \"%s\" but got \"%s\"", defaultExpectedErrMessage, actualErr.Message)
 	}
-	if *actualNewAccount != expectedNewAccount {
-		t.Errorf("Expected account %v but got account %v", expectedNewAccount, *actualNewAccount)
+	if logs.Len() != 1 {
+		t.Fatalf("Expected 1 message to be logged but got %d logs", logs.Len())
+	}
+	actualLogMessage := logs.All()[0]
+	if actualLogMessage.Message != expectedLogMessage {
+		t.Errorf("Expected log message to be \"%s\" but got \"%s\"", expectedLogMessage, actualLogMessage.Message)
+	}
+}
+
+func TestAccountRepositoryDb_Save_returns_newAccount_when_insertAccounts_and_insertOutbox_succeed(t *testing.T) {
+	for _, dialect := range accountRepoDbDialects {
+		t.Run(dialect.driverName, func(t *testing.T) {
+			//Arrange
+			teardown := setupAccountRepoDbTestWithDriver(t, dialect.driverName)
+			defer teardown()
+
+			mockDB.ExpectBegin()
+
+			dummyAccount := getDefaultAccountBeforeSave()
+			expectInsert(dialect.driverName, dialect.insertAccountsSql, "account_id", dummyAccountIdAsInt,
+				dummyAccount.CustomerId, dummyAccount.OpeningDate, dummyAccount.AccountType, dummyAccount.Amount, dummyAccount.Status, dummyAccount.OpeningAmount)
+
+			expectedNewAccount := getDefaultAccountAfterSave()
+			expectOutboxInsert(dialect.insertOutboxSql, NewAccountOpenedEvent(expectedNewAccount))
+
+			mockDB.ExpectCommit()
+
+			//Act
+			actualNewAccount, err := accRepoDb.Save(dummyAccount)
+
+			//Assert
+			if err != nil {
+				t.Fatal("Expected no error but got error while testing successful saving of account: " + err.Message)
+			}
+			if *actualNewAccount != expectedNewAccount {
+				t.Errorf("Expected account %v but got account %v", expectedNewAccount, *actualNewAccount)
+			}
+		})
 	}
 }
 
@@ -222,41 +326,45 @@ func TestAccountRepositoryDb_FindAll_returns_error_when_select_fails(t *testing.
 }
 
 func TestAccountRepositoryDb_FindAll_returns_accounts_when_select_succeeds(t *testing.T) {
-	//Arrange
-	teardown := setupAccountRepoDbTest(t)
-	defer teardown()
-
-	dummyAccount1 := getDefaultAccountAfterSave()
-	dummyAccount2 := Account{
-		AccountId:   "1980",
-		CustomerId:  dummyCustomerId,
-		OpeningDate: dummyDate,
-		AccountType: dto.AccountTypeChecking,
-		Amount:      7000,
-		Status:      "0",
-	}
-	dummyRows := sqlmock.NewRows(accountsTableColumns).
-		AddRow(dummyAccount1.AccountId, dummyAccount1.CustomerId, dummyAccount1.OpeningDate, dummyAccount1.AccountType, dummyAccount1.Amount, dummyAccount1.Status).
-		AddRow(dummyAccount2.AccountId, dummyAccount2.CustomerId, dummyAccount2.OpeningDate, dummyAccount2.AccountType, dummyAccount2.Amount, dummyAccount2.Status)
-	mockDB.ExpectQuery(selectAccountsOfCustomerSql).
-		WithArgs(dummyCustomerId).
-		WillReturnRows(dummyRows)
-	expectedAccounts := []Account{dummyAccount1, dummyAccount2}
+	for _, dialect := range accountRepoDbDialects {
+		t.Run(dialect.driverName, func(t *testing.T) {
+			//Arrange
+			teardown := setupAccountRepoDbTestWithDriver(t, dialect.driverName)
+			defer teardown()
+
+			dummyAccount1 := getDefaultAccountAfterSelect()
+			dummyAccount2 := Account{
+				AccountId:   "1980",
+				CustomerId:  dummyCustomerId,
+				OpeningDate: dummyDate,
+				AccountType: dto.AccountTypeChecking,
+				Amount:      7000,
+				Status:      "0",
+			}
+			dummyRows := sqlmock.NewRows(accountsTableColumns).
+				AddRow(dummyAccount1.AccountId, dummyAccount1.CustomerId, dummyAccount1.OpeningDate, dummyAccount1.AccountType, dummyAccount1.Amount, dummyAccount1.Status, dummyAccount1.OverdraftLimit).
+				AddRow(dummyAccount2.AccountId, dummyAccount2.CustomerId, dummyAccount2.OpeningDate, dummyAccount2.AccountType, dummyAccount2.Amount, dummyAccount2.Status, dummyAccount2.OverdraftLimit)
+			mockDB.ExpectQuery(dialect.selectAccountsOfCustomerSql).
+				WithArgs(dummyCustomerId).
+				WillReturnRows(dummyRows)
+			expectedAccounts := []Account{dummyAccount1, dummyAccount2}
 
-	//Act
-	actualAccounts, err := accRepoDb.FindAll(dummyCustomerId)
+			//Act
+			actualAccounts, err := accRepoDb.FindAll(dummyCustomerId)
 
-	//Assert
-	if err != nil {
-		t.Fatal("Expected no error but got error while testing successful select: " + err.Message)
-	}
-	if len(actualAccounts) != len(expectedAccounts) {
-		t.Fatalf("Expected %d accounts to be retrieved but got %d accounts", len(expectedAccounts), len(actualAccounts))
-	}
-	for k, _ := range expectedAccounts {
-		if actualAccounts[k] != expectedAccounts[k] {
-			t.Errorf("Expected account %v but got %v", expectedAccounts[k], actualAccounts[k])
-		}
+			//Assert
+			if err != nil {
+				t.Fatal("Expected no error but got error while testing successful select: " + err.Message)
+			}
+			if len(actualAccounts) != len(expectedAccounts) {
+				t.Fatalf("Expected %d accounts to be retrieved but got %d accounts", len(expectedAccounts), len(actualAccounts))
+			}
+			for k, _ := range expectedAccounts {
+				if actualAccounts[k] != expectedAccounts[k] {
+					t.Errorf("Expected account %v but got %v", expectedAccounts[k], actualAccounts[k])
+				}
+			}
+		})
 	}
 }
 
@@ -308,26 +416,30 @@ func TestAccountRepositoryDb_FindById_returns_error_when_selectAccounts_fails(t
 }
 
 func TestAccountRepositoryDb_FindById_returns_account_when_selectAccounts_succeeds(t *testing.T) {
-	//Arrange
-	teardown := setupAccountRepoDbTest(t)
-	defer teardown()
+	for _, dialect := range accountRepoDbDialects {
+		t.Run(dialect.driverName, func(t *testing.T) {
+			//Arrange
+			teardown := setupAccountRepoDbTestWithDriver(t, dialect.driverName)
+			defer teardown()
+
+			dummyNewAccount := getDefaultAccountAfterSelect()
+			dummyRows := sqlmock.NewRows(accountsTableColumns).
+				AddRow(dummyNewAccount.AccountId, dummyNewAccount.CustomerId, dummyNewAccount.OpeningDate, dummyNewAccount.AccountType, dummyNewAccount.Amount, dummyNewAccount.Status, dummyNewAccount.OverdraftLimit)
+			mockDB.ExpectQuery(dialect.selectAccountsSql).
+				WithArgs(dummyNewAccount.AccountId).
+				WillReturnRows(dummyRows)
 
-	dummyNewAccount := getDefaultAccountAfterSave()
-	dummyRows := sqlmock.NewRows(accountsTableColumns).
-		AddRow(dummyNewAccount.AccountId, dummyNewAccount.CustomerId, dummyNewAccount.OpeningDate, dummyNewAccount.AccountType, dummyNewAccount.Amount, dummyNewAccount.Status)
-	mockDB.ExpectQuery(selectAccountsSql).
-		WithArgs(dummyNewAccount.AccountId).
-		WillReturnRows(dummyRows)
-
-	//Act
-	actualAccount, err := accRepoDb.FindById(dummyNewAccount.AccountId)
+			//Act
+			actualAccount, err := accRepoDb.FindById(dummyNewAccount.AccountId)
 
-	//Assert
-	if err != nil {
-		t.Fatal("Expected no error but got error while testing successful select: " + err.Message)
-	}
-	if *actualAccount != dummyNewAccount {
-		t.Errorf("Expected account %v but got %v", dummyNewAccount, *actualAccount)
+			//Assert
+			if err != nil {
+				t.Fatal("Expected no error but got error while testing successful select: " + err.Message)
+			}
+			if *actualAccount != dummyNewAccount {
+				t.Errorf("Expected account %v but got %v", dummyNewAccount, *actualAccount)
+			}
+		})
 	}
 }
 
@@ -415,8 +527,8 @@ func TestAccountRepositoryDb_Transact_returns_error_when_insertTransactions_fail
 		WillReturnResult(dummyUpdateResult)
 
 	dummyDbErr := errors.New("some error message")
-	mockDB.ExpectExec(insertTransactionsSql).
-		WithArgs(dummyTransaction.AccountId, dummyTransaction.Amount, dummyTransaction.TransactionType, dummyTransaction.TransactionDate).
+	mockDB.ExpectExec(insertTransactionsWithDetailsSql).
+		WithArgs(dummyTransaction.AccountId, dummyTransaction.Amount, dummyTransaction.TransactionType, dummyTransaction.TransactionDate, "", "", "", nil).
 		WillReturnError(dummyDbErr)
 
 	mockDB.ExpectRollback()
@@ -460,10 +572,16 @@ func TestAccountRepositoryDb_Transact_returns_error_when_failure_committingDbTra
 
 	lastInsertID = dummyTransactionIdAsInt //dummyTransaction.TransactionId
 	dummyInsertResult := sqlmock.NewResult(lastInsertID, rowsAffected)
-	mockDB.ExpectExec(insertTransactionsSql).
-		WithArgs(dummyTransaction.AccountId, dummyTransaction.Amount, dummyTransaction.TransactionType, dummyTransaction.TransactionDate).
+	mockDB.ExpectExec(insertTransactionsWithDetailsSql).
+		WithArgs(dummyTransaction.AccountId, dummyTransaction.Amount, dummyTransaction.TransactionType, dummyTransaction.TransactionDate, "", "", "", nil).
 		WillReturnResult(dummyInsertResult)
 
+	mockDB.ExpectQuery(selectBalanceSql).
+		WithArgs(dummyTransaction.AccountId).
+		WillReturnRows(sqlmock.NewRows([]string{"amount"}).AddRow(dummyBalance))
+
+	expectOutboxInsert(insertOutboxSql, NewTransactionPostedEvent(getDefaultTransactionAfterTransact()))
+
 	dummyErr := errors.New("some error message")
 	mockDB.ExpectCommit().WillReturnError(dummyErr)
 
@@ -506,11 +624,11 @@ func TestAccountRepositoryDb_Transact_returns_error_when_gettingInsertId_fails(t
 
 	dummyErr := errors.New("some error message")
 	dummyErrorResult := sqlmock.NewErrorResult(dummyErr)
-	mockDB.ExpectExec(insertTransactionsSql).
-		WithArgs(dummyTransaction.AccountId, dummyTransaction.Amount, dummyTransaction.TransactionType, dummyTransaction.TransactionDate).
+	mockDB.ExpectExec(insertTransactionsWithDetailsSql).
+		WithArgs(dummyTransaction.AccountId, dummyTransaction.Amount, dummyTransaction.TransactionType, dummyTransaction.TransactionDate, "", "", "", nil).
 		WillReturnResult(dummyErrorResult)
 
-	mockDB.ExpectCommit()
+	mockDB.ExpectRollback()
 
 	logs := logger.ReplaceWithTestLogger()
 	expectedLogMessage := "Error while getting id of newly inserted transaction: " + dummyErr.Error()
@@ -534,7 +652,7 @@ func TestAccountRepositoryDb_Transact_returns_error_when_gettingInsertId_fails(t
 	}
 }
 
-func TestAccountRepositoryDb_Transact_returns_error_when_cannotFindAccount(t *testing.T) {
+func TestAccountRepositoryDb_Transact_returns_error_when_selectBalance_fails(t *testing.T) {
 	//Arrange
 	teardown := setupAccountRepoDbTest(t)
 	defer teardown()
@@ -551,29 +669,38 @@ func TestAccountRepositoryDb_Transact_returns_error_when_cannotFindAccount(t *te
 
 	lastInsertID = dummyTransactionIdAsInt //dummyTransaction.TransactionId
 	dummyInsertResult := sqlmock.NewResult(lastInsertID, rowsAffected)
-	mockDB.ExpectExec(insertTransactionsSql).
-		WithArgs(dummyTransaction.AccountId, dummyTransaction.Amount, dummyTransaction.TransactionType, dummyTransaction.TransactionDate).
+	mockDB.ExpectExec(insertTransactionsWithDetailsSql).
+		WithArgs(dummyTransaction.AccountId, dummyTransaction.Amount, dummyTransaction.TransactionType, dummyTransaction.TransactionDate, "", "", "", nil).
 		WillReturnResult(dummyInsertResult)
 
-	mockDB.ExpectCommit()
+	dummyDbErr := errors.New("some error message")
+	mockDB.ExpectQuery(selectBalanceSql).WithArgs(dummyTransaction.AccountId).WillReturnError(dummyDbErr)
 
-	dummyErr := errors.New("some error message")
-	mockDB.ExpectQuery(selectAccountsSql).WithArgs(dummyAccountId).WillReturnError(dummyErr)
+	mockDB.ExpectRollback()
+
+	logs := logger.ReplaceWithTestLogger()
+	expectedLogMessage := "Error while retrieving new account balance: " + dummyDbErr.Error()
 
 	//Act
-	logger.MuteLogger()
 	_, actualErr := accRepoDb.Transact(dummyTransaction)
 
 	//Assert
 	if actualErr == nil {
-		t.Fatal("Expected error but got none while testing failed finding of newly inserted account")
+		t.Fatal("Expected error but got none while testing failed retrieving of new balance")
 	}
 	if actualErr.Message != defaultExpectedErrMessage {
 		t.Errorf("Expected error message to be \"%s\" but got \"%s\"", defaultExpectedErrMessage, actualErr.Message)
 	}
+	if logs.Len() != 1 {
+		t.Fatalf("Expected 1 message to be logged but got %d logs", logs.Len())
+	}
+	actualLogMessage := logs.All()[0]
+	if actualLogMessage.Message != expectedLogMessage {
+		t.Errorf("Expected log message to be \"%s\" but got \"%s\"", expectedLogMessage, actualLogMessage.Message)
+	}
 }
 
-func TestAccountRepositoryDb_Transact_returns_newTransaction_when_transactionType_deposit(t *testing.T) {
+func TestAccountRepositoryDb_Transact_returns_error_when_insertOutbox_fails(t *testing.T) {
 	//Arrange
 	teardown := setupAccountRepoDbTest(t)
 	defer teardown()
@@ -590,82 +717,413 @@ func TestAccountRepositoryDb_Transact_returns_newTransaction_when_transactionTyp
 
 	lastInsertID = dummyTransactionIdAsInt //dummyTransaction.TransactionId
 	dummyInsertResult := sqlmock.NewResult(lastInsertID, rowsAffected)
-	mockDB.ExpectExec(insertTransactionsSql).
-		WithArgs(dummyTransaction.AccountId, dummyTransaction.Amount, dummyTransaction.TransactionType, dummyTransaction.TransactionDate).
+	mockDB.ExpectExec(insertTransactionsWithDetailsSql).
+		WithArgs(dummyTransaction.AccountId, dummyTransaction.Amount, dummyTransaction.TransactionType, dummyTransaction.TransactionDate, "", "", "", nil).
 		WillReturnResult(dummyInsertResult)
 
-	mockDB.ExpectCommit()
+	mockDB.ExpectQuery(selectBalanceSql).
+		WithArgs(dummyTransaction.AccountId).
+		WillReturnRows(sqlmock.NewRows([]string{"amount"}).AddRow(dummyBalance))
 
-	dummyExistentAccount := getDefaultAccountAfterSave()
-	dummyExistentAccount.Amount = dummyBalance
-	dummyRows := sqlmock.NewRows(accountsTableColumns).
-		AddRow(dummyExistentAccount.AccountId, dummyExistentAccount.CustomerId, dummyExistentAccount.OpeningDate, dummyExistentAccount.AccountType, dummyExistentAccount.Amount, dummyExistentAccount.Status)
-	mockDB.ExpectQuery(selectAccountsSql).
-		WithArgs(dummyExistentAccount.AccountId).
-		WillReturnRows(dummyRows)
+	dummyDbErr := errors.New("some error message")
+	mockDB.ExpectExec(insertOutboxSql).WillReturnError(dummyDbErr)
 
-	expectedNewTransaction := getDefaultTransactionAfterTransact()
+	mockDB.ExpectRollback()
+
+	logs := logger.ReplaceWithTestLogger()
+	expectedLogMessage := "Error while writing transaction posted event to outbox: " + dummyDbErr.Error()
 
 	//Act
-	actualNewTransaction, err := accRepoDb.Transact(dummyTransaction)
+	_, actualErr := accRepoDb.Transact(dummyTransaction)
 
 	//Assert
-	if err != nil {
-		t.Fatal("Expected no error but got error while testing successful deposit: " + err.Message)
+	if actualErr == nil {
+		t.Fatal("Expected error but got none while testing failed writing of event to outbox")
+	}
+	if actualErr.Message != defaultExpectedErrMessage {
+		t.Errorf("Expected error message to be \"%s\" but got \"%s\"", defaultExpectedErrMessage, actualErr.Message)
 	}
-	if *actualNewTransaction != expectedNewTransaction {
-		t.Errorf("Expected transaction %v but got %v", expectedNewTransaction, *actualNewTransaction)
+	if logs.Len() != 1 {
+		t.Fatalf("Expected 1 message to be logged but got %d logs", logs.Len())
+	}
+	actualLogMessage := logs.All()[0]
+	if actualLogMessage.Message != expectedLogMessage {
+		t.Errorf("Expected log message to be \"%s\" but got \"%s\"", expectedLogMessage, actualLogMessage.Message)
+	}
+}
+
+func TestAccountRepositoryDb_Transact_returns_newTransaction_when_transactionType_deposit(t *testing.T) {
+	for _, dialect := range accountRepoDbDialects {
+		t.Run(dialect.driverName, func(t *testing.T) {
+			//Arrange
+			teardown := setupAccountRepoDbTestWithDriver(t, dialect.driverName)
+			defer teardown()
+
+			mockDB.ExpectBegin()
+
+			dummyTransaction := getDefaultTransactionBeforeTransact()
+			var lastInsertID, rowsAffected int64
+			rowsAffected = 1
+			dummyUpdateResult := sqlmock.NewResult(lastInsertID, rowsAffected)
+			mockDB.ExpectExec(dialect.updateAccountsDepositSql).
+				WithArgs(dummyTransaction.Amount, dummyTransaction.AccountId).
+				WillReturnResult(dummyUpdateResult)
+
+			expectInsert(dialect.driverName, dialect.insertTransactionsSql, "transaction_id", dummyTransactionIdAsInt,
+				dummyTransaction.AccountId, dummyTransaction.Amount, dummyTransaction.TransactionType, dummyTransaction.TransactionDate, "", "", "", nil)
+
+			mockDB.ExpectQuery(dialect.selectBalanceSql).
+				WithArgs(dummyTransaction.AccountId).
+				WillReturnRows(sqlmock.NewRows([]string{"amount"}).AddRow(dummyBalance))
+
+			expectedNewTransaction := getDefaultTransactionAfterTransact()
+			expectOutboxInsert(dialect.insertOutboxSql, NewTransactionPostedEvent(expectedNewTransaction))
+
+			mockDB.ExpectCommit()
+
+			//Act
+			actualNewTransaction, err := accRepoDb.Transact(dummyTransaction)
+
+			//Assert
+			if err != nil {
+				t.Fatal("Expected no error but got error while testing successful deposit: " + err.Message)
+			}
+			if *actualNewTransaction != expectedNewTransaction {
+				t.Errorf("Expected transaction %v but got %v", expectedNewTransaction, *actualNewTransaction)
+			}
+		})
 	}
 }
 
 func TestAccountRepositoryDb_Transact_returns_newTransaction_when_transactionType_withdrawal(t *testing.T) {
+	for _, dialect := range accountRepoDbDialects {
+		t.Run(dialect.driverName, func(t *testing.T) {
+			//Arrange
+			teardown := setupAccountRepoDbTestWithDriver(t, dialect.driverName)
+			defer teardown()
+
+			mockDB.ExpectBegin()
+
+			dummyTransaction := Transaction{
+				AccountId:       dummyAccountId,
+				Amount:          dummyAmount,
+				TransactionType: dto.TransactionTypeWithdrawal,
+				TransactionDate: dummyDate,
+			}
+			var lastInsertID, rowsAffected int64
+			rowsAffected = 1
+			dummyUpdateResult := sqlmock.NewResult(lastInsertID, rowsAffected)
+			mockDB.ExpectExec(dialect.updateAccountsWithdrawalSql).
+				WithArgs(dummyTransaction.Amount, dummyTransaction.AccountId, dummyTransaction.Amount).
+				WillReturnResult(dummyUpdateResult)
+
+			expectInsert(dialect.driverName, dialect.insertTransactionsSql, "transaction_id", dummyTransactionIdAsInt,
+				dummyTransaction.AccountId, dummyTransaction.Amount, dummyTransaction.TransactionType, dummyTransaction.TransactionDate, "", "", "", nil)
+
+			mockDB.ExpectQuery(dialect.selectBalanceSql).
+				WithArgs(dummyTransaction.AccountId).
+				WillReturnRows(sqlmock.NewRows([]string{"amount"}).AddRow(dummyBalanceAfterWithdrawal))
+
+			expectedNewTransaction := dummyTransaction
+			expectedNewTransaction.TransactionId = dummyTransactionId
+			expectedNewTransaction.Balance = dummyBalanceAfterWithdrawal
+			expectOutboxInsert(dialect.insertOutboxSql, NewTransactionPostedEvent(expectedNewTransaction))
+
+			mockDB.ExpectCommit()
+
+			//Act
+			actualNewTransaction, err := accRepoDb.Transact(dummyTransaction)
+
+			//Assert
+			if err != nil {
+				t.Fatal("Expected no error but got error while testing successful deposit: " + err.Message)
+			}
+			if *actualNewTransaction != expectedNewTransaction {
+				t.Errorf("Expected transaction %v but got %v", expectedNewTransaction, *actualNewTransaction)
+			}
+		})
+	}
+}
+
+func TestAccountRepositoryDb_Transact_returns_validationError_and_rolls_back_when_withdrawal_exceeds_balance(t *testing.T) {
 	//Arrange
 	teardown := setupAccountRepoDbTest(t)
 	defer teardown()
 
-	mockDB.ExpectBegin()
-
 	dummyTransaction := Transaction{
 		AccountId:       dummyAccountId,
 		Amount:          dummyAmount,
 		TransactionType: dto.TransactionTypeWithdrawal,
 		TransactionDate: dummyDate,
 	}
-	var lastInsertID, rowsAffected int64
-	rowsAffected = 1
-	dummyUpdateResult := sqlmock.NewResult(lastInsertID, rowsAffected)
+	mockDB.ExpectBegin()
 	mockDB.ExpectExec(updateAccountsWithdrawalSql).
-		WithArgs(dummyTransaction.Amount, dummyTransaction.AccountId).
-		WillReturnResult(dummyUpdateResult)
+		WithArgs(dummyTransaction.Amount, dummyTransaction.AccountId, dummyTransaction.Amount).
+		WillReturnResult(sqlmock.NewResult(0, 0)) //spent by another withdrawal since the service checked the balance
+	mockDB.ExpectRollback()
 
-	lastInsertID = dummyTransactionIdAsInt //dummyTransaction.TransactionId
-	dummyInsertResult := sqlmock.NewResult(lastInsertID, rowsAffected)
-	mockDB.ExpectExec(insertTransactionsSql).
-		WithArgs(dummyTransaction.AccountId, dummyTransaction.Amount, dummyTransaction.TransactionType, dummyTransaction.TransactionDate).
-		WillReturnResult(dummyInsertResult)
+	logs := logger.ReplaceWithTestLogger()
+	expectedLogMessage := "Error while updating account: withdrawal exceeds account balance"
+
+	//Act
+	_, err := accRepoDb.Transact(dummyTransaction)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing withdrawal exceeding account balance")
+	}
+	if err.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status code %d but got %d", http.StatusUnprocessableEntity, err.Code)
+	}
+	if logs.Len() != 1 {
+		t.Fatalf("Expected 1 message to be logged but got %d logs", logs.Len())
+	}
+	if actualLogMessage := logs.All()[0]; actualLogMessage.Message != expectedLogMessage {
+		t.Errorf("Expected log message to be \"%s\" but got \"%s\"", expectedLogMessage, actualLogMessage.Message)
+	}
+	if mockErr := mockDB.ExpectationsWereMet(); mockErr != nil {
+		t.Error(mockErr)
+	}
+}
+
+// expectPostTransaction expects the given bank transaction to be posted as the transaction with the given id, leaving
+// the account with the given balance, and returns the posted transaction.
+func expectPostTransaction(transaction Transaction, id int64, balance float64) Transaction {
+	switch {
+	case transaction.IsWithdrawal():
+		mockDB.ExpectExec(updateAccountsWithdrawalSql).
+			WithArgs(transaction.Amount, transaction.AccountId, transaction.Amount).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	case transaction.IsDebit():
+		mockDB.ExpectExec(updateAccountsDebitSql).
+			WithArgs(transaction.Amount, transaction.AccountId).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	default:
+		mockDB.ExpectExec(updateAccountsDepositSql).
+			WithArgs(transaction.Amount, transaction.AccountId).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+
+	var relatedTransactionId interface{}
+	if transaction.RelatedTransactionId.Valid {
+		relatedTransactionId = transaction.RelatedTransactionId.String
+	}
+	expectInsert(driverName, insertTransactionsWithDetailsSql, "transaction_id", id, transaction.AccountId, transaction.Amount,
+		transaction.TransactionType, transaction.TransactionDate, transaction.Description, transaction.Reference,
+		transaction.Category, relatedTransactionId)
+
+	mockDB.ExpectQuery(selectBalanceSql).
+		WithArgs(transaction.AccountId).
+		WillReturnRows(sqlmock.NewRows([]string{"amount"}).AddRow(balance))
+
+	posted := transaction
+	posted.TransactionId = strconv.FormatInt(id, 10)
+	posted.Balance = balance
+	expectOutboxInsert(insertOutboxSql, NewTransactionPostedEvent(posted))
+	return posted
+}
 
+// expectCanSetAsideFunds expects the row of the saving account with the given id to be locked and its available
+// balance to be read at the given time, with the given ledger balance, held amount and reserved amount.
+func expectCanSetAsideFunds(driverName string, accountId string, balance float64, held float64, reserved float64, now string) {
+	rebind := func(query string) string { return sqlx.Rebind(sqlx.BindType(driverName), query) }
+	mockDB.ExpectExec(rebind(lockAccountsSql)).
+		WithArgs(accountId).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mockDB.ExpectQuery(rebind(selectAccountBalanceSql)).
+		WithArgs(accountId).
+		WillReturnRows(sqlmock.NewRows([]string{"account_type", "amount", "overdraft_limit"}).
+			AddRow(dto.AccountTypeSaving, balance, 0))
+	mockDB.ExpectQuery(rebind(selectHeldAmountSql)).
+		WithArgs(accountId, dto.HoldStatusActive, now).
+		WillReturnRows(sqlmock.NewRows([]string{"COALESCE(SUM(amount), 0)"}).AddRow(held))
+	mockDB.ExpectQuery(rebind(selectReservedAmountSql)).
+		WithArgs(accountId, dto.TransactionTypeWithdrawal, dto.TransactionStatusPendingReview).
+		WillReturnRows(sqlmock.NewRows([]string{"COALESCE(SUM(amount), 0)"}).AddRow(reserved))
+}
+
+func getDummyTransfer() (Transaction, Transaction) {
+	withdrawal := Transaction{
+		AccountId:       dummyAccountId,
+		Amount:          dummyAmount,
+		TransactionType: dto.TransactionTypeWithdrawal,
+		TransactionDate: dummyDate,
+		Description:     "Transfer to Mum",
+		Category:        dto.TransactionCategoryTransfers,
+	}
+	deposit := withdrawal
+	deposit.AccountId = "1978"
+	deposit.TransactionType = dto.TransactionTypeDeposit
+	deposit.Description = "Transfer from account " + dummyAccountId
+	return withdrawal, deposit
+}
+
+func TestAccountRepositoryDb_Transfer_returns_error_and_rolls_back_when_postingDeposit_fails(t *testing.T) {
+	//Arrange
+	teardown := setupAccountRepoDbTest(t)
+	defer teardown()
+
+	withdrawal, deposit := getDummyTransfer()
+	mockDB.ExpectBegin()
+	expectPostTransaction(withdrawal, dummyTransactionIdAsInt, dummyBalanceAfterWithdrawal)
+	dummyDbErr := errors.New("some error message")
+	mockDB.ExpectExec(updateAccountsDepositSql).
+		WithArgs(deposit.Amount, deposit.AccountId).
+		WillReturnError(dummyDbErr)
+	mockDB.ExpectRollback()
+
+	logs := logger.ReplaceWithTestLogger()
+	expectedLogMessage := "Error while updating account: " + dummyDbErr.Error()
+
+	//Act
+	_, _, actualErr := accRepoDb.Transfer(withdrawal, deposit)
+
+	//Assert
+	if actualErr == nil {
+		t.Fatal("Expected error but got none while testing failed posting of deposit of transfer")
+	}
+	if actualErr.Message != defaultExpectedErrMessage {
+		t.Errorf("Expected error message to be \"%s\" but got \"%s\"", defaultExpectedErrMessage, actualErr.Message)
+	}
+	if logs.Len() != 1 {
+		t.Fatalf("Expected 1 message to be logged but got %d logs", logs.Len())
+	}
+	if actualLogMessage := logs.All()[0]; actualLogMessage.Message != expectedLogMessage {
+		t.Errorf("Expected log message to be \"%s\" but got \"%s\"", expectedLogMessage, actualLogMessage.Message)
+	}
+	if err := mockDB.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAccountRepositoryDb_Transfer_returns_both_transactions_with_deposit_linked_to_withdrawal(t *testing.T) {
+	//Arrange
+	teardown := setupAccountRepoDbTest(t)
+	defer teardown()
+
+	withdrawal, deposit := getDummyTransfer()
+	mockDB.ExpectBegin()
+	expectedWithdrawal := expectPostTransaction(withdrawal, dummyTransactionIdAsInt, dummyBalanceAfterWithdrawal)
+	linkedDeposit := deposit
+	linkedDeposit.RelatedTransactionId = sql.NullString{String: dummyTransactionId, Valid: true}
+	expectedDeposit := expectPostTransaction(linkedDeposit, dummyTransactionIdAsInt+1, dummyBalance)
 	mockDB.ExpectCommit()
 
-	dummyExistentAccount := getDefaultAccountAfterSave()
-	dummyExistentAccount.Amount = dummyBalanceAfterWithdrawal
-	dummyRows := sqlmock.NewRows(accountsTableColumns).
-		AddRow(dummyExistentAccount.AccountId, dummyExistentAccount.CustomerId, dummyExistentAccount.OpeningDate, dummyExistentAccount.AccountType, dummyExistentAccount.Amount, dummyExistentAccount.Status)
-	mockDB.ExpectQuery(selectAccountsSql).
-		WithArgs(dummyExistentAccount.AccountId).
-		WillReturnRows(dummyRows)
+	//Act
+	actualWithdrawal, actualDeposit, err := accRepoDb.Transfer(withdrawal, deposit)
 
-	expectedNewTransaction := dummyTransaction
-	expectedNewTransaction.TransactionId = dummyTransactionId
-	expectedNewTransaction.Balance = dummyBalanceAfterWithdrawal
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing successful transfer: " + err.Message)
+	}
+	if *actualWithdrawal != expectedWithdrawal {
+		t.Errorf("Expected withdrawal %v but got %v", expectedWithdrawal, *actualWithdrawal)
+	}
+	if *actualDeposit != expectedDeposit {
+		t.Errorf("Expected deposit %v but got %v", expectedDeposit, *actualDeposit)
+	}
+	if err := mockDB.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAccountRepositoryDb_FindTransactions_returns_transactions_of_account(t *testing.T) {
+	//Arrange
+	teardown := setupAccountRepoDbTest(t)
+	defer teardown()
+
+	expectedTransaction := getDefaultTransactionBeforeTransact()
+	expectedTransaction.TransactionId = dummyTransactionId
+	mockDB.ExpectQuery(selectTransactionsOfAccountSql).
+		WithArgs(dummyAccountId).
+		WillReturnRows(sqlmock.NewRows(transactionsTableColumns).
+			AddRow(expectedTransaction.TransactionId, expectedTransaction.AccountId, expectedTransaction.Amount,
+				expectedTransaction.TransactionType, expectedTransaction.TransactionDate, "", "", "", nil, nil))
 
 	//Act
-	actualNewTransaction, err := accRepoDb.Transact(dummyTransaction)
+	transactions, err := accRepoDb.FindTransactions(dummyAccountId)
 
 	//Assert
 	if err != nil {
-		t.Fatal("Expected no error but got error while testing successful deposit: " + err.Message)
+		t.Fatal("Expected no error but got error while testing retrieval of transactions: " + err.Message)
+	}
+	if len(transactions) != 1 || transactions[0] != expectedTransaction {
+		t.Errorf("Expected transactions %v but got %v", []Transaction{expectedTransaction}, transactions)
+	}
+}
+
+func TestAccountRepositoryDb_FindTransaction_returns_notFoundError_when_transaction_not_on_account(t *testing.T) {
+	//Arrange
+	teardown := setupAccountRepoDbTest(t)
+	defer teardown()
+
+	mockDB.ExpectQuery(selectTransactionsWithReversalSql+" WHERE transaction_id = ? AND account_id = ?").
+		WithArgs(dummyTransactionId, dummyAccountId).
+		WillReturnError(sql.ErrNoRows)
+	logger.MuteLogger()
+
+	//Act
+	_, err := accRepoDb.FindTransaction(dummyAccountId, dummyTransactionId)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing retrieval of transaction not on account")
+	}
+	if err.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d but got %d", http.StatusNotFound, err.Code)
 	}
-	if *actualNewTransaction != expectedNewTransaction {
-		t.Errorf("Expected transaction %v but got %v", expectedNewTransaction, *actualNewTransaction)
+}
+
+func TestAccountRepositoryDb_UpdateTransactionCategory_returns_nil_when_update_succeeds(t *testing.T) {
+	//Arrange
+	teardown := setupAccountRepoDbTest(t)
+	defer teardown()
+
+	mockDB.ExpectExec("UPDATE transactions SET category = ? WHERE transaction_id = ?").
+		WithArgs(dto.TransactionCategoryGroceries, dummyTransactionId).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	//Act
+	err := accRepoDb.UpdateTransactionCategory(dummyTransactionId, dto.TransactionCategoryGroceries)
+
+	//Assert
+	if err != nil {
+		t.Error("Expected no error but got error while testing update of transaction category: " + err.Message)
+	}
+}
+
+func TestAccountRepositoryDb_UpdateOverdraftLimit_returns_nil_when_limit_unchanged(t *testing.T) {
+	//Arrange
+	teardown := setupAccountRepoDbTest(t)
+	defer teardown()
+
+	mockDB.ExpectExec("UPDATE accounts SET overdraft_limit = ? WHERE account_id = ?").
+		WithArgs(500.0, dummyAccountId).
+		WillReturnResult(sqlmock.NewResult(0, 0)) //MySQL reports no rows affected when the value is the same
+
+	//Act
+	err := accRepoDb.UpdateOverdraftLimit(dummyAccountId, 500)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing unchanged overdraft limit: " + err.Message)
+	}
+}
+
+func TestAccountRepositoryDb_UpdateOverdraftLimit_returns_nil_when_accountUpdated(t *testing.T) {
+	//Arrange
+	teardown := setupAccountRepoDbTestWithDriver(t, DriverPostgres)
+	defer teardown()
+
+	mockDB.ExpectExec("UPDATE accounts SET overdraft_limit = $1 WHERE account_id = $2").
+		WithArgs(500.0, dummyAccountId).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	//Act
+	err := accRepoDb.UpdateOverdraftLimit(dummyAccountId, 500)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing successful update of overdraft limit: " + err.Message)
 	}
 }
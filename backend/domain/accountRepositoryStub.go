@@ -0,0 +1,204 @@
+package domain
+
+import (
+	"database/sql"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/problem"
+	"strconv"
+	"sync"
+)
+
+//Server
+
+type AccountRepositoryStub struct { //stub (adapter)
+	store *accountStore //shared by all copies of the stub, so that changes made through one copy are seen by all
+}
+
+// accountStore holds the accounts and bank transactions of an AccountRepositoryStub in memory. It is safe for
+// concurrent use.
+type accountStore struct {
+	mu                sync.Mutex
+	accounts          []Account
+	transactions      []Transaction
+	nextAccountId     int64
+	nextTransactionId int64
+}
+
+func NewAccountRepositoryStub() AccountRepositoryStub { //helper function to create and initialize a stub
+	accounts := []Account{ //default dummy data, belonging to the customers of CustomerRepositoryStub
+		{"95470", "1", "2020-08-22 10:20:06", "saving", 6823.23, AccountStatusActive, 6823.23, 0, 0},
+		{"95471", "2", "2020-08-09 10:27:22", "checking", 3342.96, AccountStatusActive, 3342.96, 0, 0},
+	}
+	return AccountRepositoryStub{&accountStore{
+		accounts:          accounts,
+		transactions:      make([]Transaction, 0),
+		nextAccountId:     95472,
+		nextTransactionId: 1,
+	}}
+}
+
+// Save stores the given account under the next free account ID and returns the account with its ID set.
+func (s AccountRepositoryStub) Save(account Account) (*Account, *errs.AppError) { //stub implements repo
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	account.AccountId = strconv.FormatInt(s.store.nextAccountId, 10)
+	s.store.nextAccountId++
+	s.store.accounts = append(s.store.accounts, account)
+
+	return &account, nil
+}
+
+// FindAll returns all accounts belonging to the customer with the given id, which like the database is an empty list
+// if there are none.
+func (s AccountRepositoryStub) FindAll(customerId string) ([]Account, *errs.AppError) { //stub implements repo
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	accounts := make([]Account, 0)
+	for _, a := range s.store.accounts {
+		if a.CustomerId == customerId {
+			accounts = append(accounts, a)
+		}
+	}
+	return accounts, nil
+}
+
+func (s AccountRepositoryStub) FindById(accountId string) (*Account, *errs.AppError) { //stub implements repo
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	i := s.store.indexOf(accountId)
+	if i < 0 {
+		logger.Error("Error while finding account by id using stub for AccountRepository: not found")
+		return nil, problem.NewNotFoundError(problem.AccountNotFound, "Account not found")
+	}
+	account := s.store.accounts[i]
+	return &account, nil
+}
+
+// Transact updates the balance of the account and records the given bank transaction under the next free
+// transaction ID, all while holding the lock so that concurrent transactions on the same account are not lost. Like
+// AccountRepositoryDb, it does not check the balance, which is the job of the service.
+// Transact returns the given bank transaction with its ID and the new account balance set.
+func (s AccountRepositoryStub) Transact(transaction Transaction) (*Transaction, *errs.AppError) { //stub implements repo
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	i := s.store.indexOf(transaction.AccountId)
+	if i < 0 {
+		logger.Error("Error while making transaction using stub for AccountRepository: account not found")
+		return nil, problem.NewNotFoundError(problem.AccountNotFound, "Account not found")
+	}
+
+	transaction = s.store.post(i, transaction)
+	return &transaction, nil
+}
+
+// Transfer posts the given withdrawal and the given deposit, linked to the withdrawal, while holding the lock, so that
+// either both accounts change or neither does.
+// Transfer returns the posted withdrawal and deposit.
+func (s AccountRepositoryStub) Transfer(withdrawal Transaction, deposit Transaction) (*Transaction, *Transaction, *errs.AppError) { //stub implements repo
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	from, to := s.store.indexOf(withdrawal.AccountId), s.store.indexOf(deposit.AccountId)
+	if from < 0 || to < 0 {
+		logger.Error("Error while making transfer using stub for AccountRepository: account not found")
+		return nil, nil, problem.NewNotFoundError(problem.AccountNotFound, "Account not found")
+	}
+
+	withdrawal = s.store.post(from, withdrawal)
+	deposit.RelatedTransactionId = sql.NullString{String: withdrawal.TransactionId, Valid: true}
+	deposit = s.store.post(to, deposit)
+
+	return &withdrawal, &deposit, nil
+}
+
+// FindTransactions returns the history of bank transactions made on the account with the given id, oldest first.
+func (s AccountRepositoryStub) FindTransactions(accountId string) ([]Transaction, *errs.AppError) { //stub implements repo
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	transactions := make([]Transaction, 0)
+	for _, t := range s.store.transactions {
+		if t.AccountId == accountId {
+			transactions = append(transactions, t)
+		}
+	}
+	return transactions, nil
+}
+
+func (s AccountRepositoryStub) FindTransaction(accountId string, transactionId string) (*Transaction, *errs.AppError) { //stub implements repo
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	i := s.store.indexOfTransaction(transactionId)
+	if i < 0 || s.store.transactions[i].AccountId != accountId {
+		logger.Error("Error while finding transaction using stub for AccountRepository: not found")
+		return nil, problem.NewNotFoundError(problem.TransactionNotFound, "Transaction not found")
+	}
+	transaction := s.store.transactions[i]
+	return &transaction, nil
+}
+
+func (s AccountRepositoryStub) UpdateTransactionCategory(transactionId string, category string) *errs.AppError { //stub implements repo
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	if i := s.store.indexOfTransaction(transactionId); i >= 0 {
+		s.store.transactions[i].Category = category
+	}
+	return nil
+}
+
+func (s AccountRepositoryStub) UpdateOverdraftLimit(accountId string, limit float64) *errs.AppError { //stub implements repo
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	i := s.store.indexOf(accountId)
+	if i < 0 {
+		logger.Error("Error while updating overdraft limit using stub for AccountRepository: account not found")
+		return problem.NewNotFoundError(problem.AccountNotFound, "Account not found")
+	}
+	s.store.accounts[i].OverdraftLimit = limit
+	return nil
+}
+
+// post updates the balance of the account at the given index and records the given bank transaction under the next
+// free transaction ID. It returns the bank transaction with its ID and the new account balance set. The caller must
+// hold the lock.
+func (st *accountStore) post(i int, transaction Transaction) Transaction {
+	if transaction.IsDebit() {
+		st.accounts[i].Amount -= transaction.Amount
+	} else {
+		st.accounts[i].Amount += transaction.Amount
+	}
+	transaction.TransactionId = strconv.FormatInt(st.nextTransactionId, 10)
+	st.nextTransactionId++
+	transaction.Balance = st.accounts[i].Amount
+	st.transactions = append(st.transactions, transaction)
+	return transaction
+}
+
+// indexOf returns the index of the account with the given id, or -1 if there is none. The caller must hold the lock.
+func (st *accountStore) indexOf(accountId string) int {
+	for i, a := range st.accounts {
+		if a.AccountId == accountId {
+			return i
+		}
+	}
+	return -1
+}
+
+// indexOfTransaction returns the index of the bank transaction with the given id, or -1 if there is none. The caller
+// must hold the lock.
+func (st *accountStore) indexOfTransaction(transactionId string) int {
+	for i, t := range st.transactions {
+		if t.TransactionId == transactionId {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,283 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+func TestAccountRepositoryStub_Save_assigns_newAccountIds(t *testing.T) {
+	//Arrange
+	accountRepositoryStub := NewAccountRepositoryStub()
+	dummyAccount := getDefaultAccountBeforeSave()
+
+	//Act
+	firstAccount, firstErr := accountRepositoryStub.Save(dummyAccount)
+	secondAccount, secondErr := accountRepositoryStub.Save(dummyAccount)
+
+	//Assert
+	if firstErr != nil || secondErr != nil {
+		t.Fatal("Expected no error but got error while testing saving of accounts")
+	}
+	if firstAccount.AccountId != "95472" || secondAccount.AccountId != "95473" {
+		t.Errorf("Expected account ids 95472 and 95473 but got %s and %s", firstAccount.AccountId, secondAccount.AccountId)
+	}
+	savedAccount, err := accountRepositoryStub.FindById(secondAccount.AccountId)
+	if err != nil {
+		t.Fatal("Expected no error but got error while finding saved account: " + err.Message)
+	}
+	if *savedAccount != *secondAccount {
+		t.Errorf("Expected account %v but got %v", *secondAccount, *savedAccount)
+	}
+}
+
+func TestAccountRepositoryStub_FindAll_returns_only_accountsOfCustomer(t *testing.T) {
+	tests := []struct {
+		name                  string
+		customerId            string
+		expectedAccountsCount int
+	}{
+		{"customer with default and new account", "1", 2},
+		{"customer with default account only", "2", 1},
+		{"customer without accounts", "321", 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Arrange
+			accountRepositoryStub := NewAccountRepositoryStub()
+			newAccount := getDefaultAccountBeforeSave()
+			newAccount.CustomerId = "1"
+			if _, err := accountRepositoryStub.Save(newAccount); err != nil {
+				t.Fatal("error while setting up test: " + err.Message)
+			}
+
+			//Act
+			actualAccounts, err := accountRepositoryStub.FindAll(tc.customerId)
+
+			//Assert
+			if err != nil {
+				t.Fatal("Expected no error but got error: " + err.Message)
+			}
+			if len(actualAccounts) != tc.expectedAccountsCount {
+				t.Fatalf("Expected %d accounts but got %d", tc.expectedAccountsCount, len(actualAccounts))
+			}
+			for _, a := range actualAccounts {
+				if a.CustomerId != tc.customerId {
+					t.Errorf("Expected only accounts of customer %s but got %v", tc.customerId, a)
+				}
+			}
+		})
+	}
+}
+
+func TestAccountRepositoryStub_FindById_returns_error_when_nonExistentAccount(t *testing.T) {
+	//Arrange
+	accountRepositoryStub := NewAccountRepositoryStub()
+	expectedErrMessage := "Account not found"
+
+	logs := logger.ReplaceWithTestLogger()
+	expectedLogMessage := "Error while finding account by id using stub for AccountRepository: not found"
+
+	//Act
+	_, actualErr := accountRepositoryStub.FindById("321")
+
+	//Assert
+	if actualErr == nil {
+		t.Fatal("Expected error but got none while testing non-existent account id")
+	}
+	if actualErr.Message != expectedErrMessage {
+		t.Errorf("Expected error message to be \"%s\" but got \"%s\"", expectedErrMessage, actualErr.Message)
+	}
+	if logs.Len() != 1 {
+		t.Fatalf("Expected 1 message to be logged but got %d logs", logs.Len())
+	}
+	if actualLogMessage := logs.All()[0].Message; actualLogMessage != expectedLogMessage {
+		t.Errorf("Expected log message to be \"%s\" but got \"%s\"", expectedLogMessage, actualLogMessage)
+	}
+}
+
+func TestAccountRepositoryStub_Transact_updates_balance_and_records_history(t *testing.T) {
+	//Arrange
+	accountRepositoryStub := NewAccountRepositoryStub()
+	deposit := Transaction{AccountId: "95471", Amount: 100, TransactionType: dto.TransactionTypeDeposit, TransactionDate: dummyDate}
+	withdrawal := Transaction{AccountId: "95471", Amount: 42.96, TransactionType: dto.TransactionTypeWithdrawal, TransactionDate: dummyDate}
+
+	//Act
+	actualDeposit, depositErr := accountRepositoryStub.Transact(deposit)
+	actualWithdrawal, withdrawalErr := accountRepositoryStub.Transact(withdrawal)
+
+	//Assert
+	if depositErr != nil || withdrawalErr != nil {
+		t.Fatal("Expected no error but got error while testing transactions on existent account")
+	}
+	if actualDeposit.TransactionId != "1" || actualDeposit.Balance != 3442.96 {
+		t.Errorf("Expected deposit 1 with balance 3442.96 but got %v", *actualDeposit)
+	}
+	if actualWithdrawal.TransactionId != "2" || actualWithdrawal.Balance != 3400 {
+		t.Errorf("Expected withdrawal 2 with balance 3400 but got %v", *actualWithdrawal)
+	}
+	account, _ := accountRepositoryStub.FindById("95471")
+	if account.Amount != 3400 {
+		t.Errorf("Expected stored balance 3400 but got %.2f", account.Amount)
+	}
+	history, _ := accountRepositoryStub.FindTransactions("95471")
+	if len(history) != 2 || history[0] != *actualDeposit || history[1] != *actualWithdrawal {
+		t.Errorf("Expected history of deposit then withdrawal but got %v", history)
+	}
+}
+
+func TestAccountRepositoryStub_Transact_debits_charges(t *testing.T) {
+	//Arrange
+	accountRepositoryStub := NewAccountRepositoryStub()
+	fee := Transaction{AccountId: "95471", Amount: 42.96, TransactionType: dto.TransactionTypeFee, TransactionDate: dummyDate}
+
+	//Act
+	actualFee, err := accountRepositoryStub.Transact(fee)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing fee on existent account")
+	}
+	if actualFee.Balance != 3300 {
+		t.Errorf("Expected balance 3300 after fee but got %.2f", actualFee.Balance)
+	}
+}
+
+func TestAccountRepositoryStub_Transfer_moves_amount_and_links_deposit_to_withdrawal(t *testing.T) {
+	//Arrange
+	accountRepositoryStub := NewAccountRepositoryStub()
+	withdrawal := Transaction{AccountId: "95471", Amount: 42.96, TransactionType: dto.TransactionTypeWithdrawal, TransactionDate: dummyDate}
+	deposit := Transaction{AccountId: "95470", Amount: 42.96, TransactionType: dto.TransactionTypeDeposit, TransactionDate: dummyDate}
+
+	//Act
+	actualWithdrawal, actualDeposit, err := accountRepositoryStub.Transfer(withdrawal, deposit)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing transfer between existent accounts")
+	}
+	if actualWithdrawal.TransactionId != "1" || actualWithdrawal.Balance != 3300 {
+		t.Errorf("Expected withdrawal 1 with balance 3300 but got %v", *actualWithdrawal)
+	}
+	if actualDeposit.TransactionId != "2" || actualDeposit.Balance != 6866.19 {
+		t.Errorf("Expected deposit 2 with balance 6866.19 but got %v", *actualDeposit)
+	}
+	if actualDeposit.RelatedTransactionId.String != "1" {
+		t.Errorf("Expected deposit to be linked to withdrawal 1 but got \"%s\"", actualDeposit.RelatedTransactionId.String)
+	}
+}
+
+func TestAccountRepositoryStub_Transfer_changes_neither_account_when_nonExistentAccount(t *testing.T) {
+	//Arrange
+	accountRepositoryStub := NewAccountRepositoryStub()
+	withdrawal := Transaction{AccountId: "95471", Amount: 42.96, TransactionType: dto.TransactionTypeWithdrawal, TransactionDate: dummyDate}
+	deposit := Transaction{AccountId: "321", Amount: 42.96, TransactionType: dto.TransactionTypeDeposit, TransactionDate: dummyDate}
+	logger.MuteLogger()
+
+	//Act
+	_, _, actualErr := accountRepositoryStub.Transfer(withdrawal, deposit)
+
+	//Assert
+	if actualErr == nil {
+		t.Fatal("Expected error but got none while testing transfer to non-existent account")
+	}
+	if account, _ := accountRepositoryStub.FindById("95471"); account.Amount != 3342.96 {
+		t.Errorf("Expected balance 3342.96 to be unchanged but got %.2f", account.Amount)
+	}
+}
+
+func TestAccountRepositoryStub_UpdateOverdraftLimit_sets_limit_of_account(t *testing.T) {
+	//Arrange
+	accountRepositoryStub := NewAccountRepositoryStub()
+	logger.MuteLogger()
+
+	//Act
+	err := accountRepositoryStub.UpdateOverdraftLimit("95471", 500)
+	missingErr := accountRepositoryStub.UpdateOverdraftLimit(dummyAccountId, 500)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing overdraft limit of existent account: " + err.Message)
+	}
+	if account, _ := accountRepositoryStub.FindById("95471"); account.OverdraftLimit != 500 {
+		t.Errorf("Expected overdraft limit 500 but got %.2f", account.OverdraftLimit)
+	}
+	if missingErr == nil || missingErr.Code != http.StatusNotFound {
+		t.Errorf("Expected not found error for non-existent account but got %v", missingErr)
+	}
+}
+
+func TestAccountRepositoryStub_UpdateTransactionCategory_recategorizes_transaction_of_account(t *testing.T) {
+	//Arrange
+	accountRepositoryStub := NewAccountRepositoryStub()
+	posted, _ := accountRepositoryStub.Transact(NewTransaction("95470", 100, dto.TransactionTypeWithdrawal, clock.StaticClock{}))
+	logger.MuteLogger()
+
+	//Act
+	err := accountRepositoryStub.UpdateTransactionCategory(posted.TransactionId, dto.TransactionCategoryDining)
+	_, otherAccountErr := accountRepositoryStub.FindTransaction("95471", posted.TransactionId)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing update of transaction category: " + err.Message)
+	}
+	if transaction, _ := accountRepositoryStub.FindTransaction("95470", posted.TransactionId); transaction.Category != dto.TransactionCategoryDining {
+		t.Errorf("Expected transaction filed under dining but got %v", *transaction)
+	}
+	if otherAccountErr == nil || otherAccountErr.Code != http.StatusNotFound {
+		t.Errorf("Expected not found error for transaction of another account but got %v", otherAccountErr)
+	}
+}
+
+func TestAccountRepositoryStub_Transact_returns_error_when_nonExistentAccount(t *testing.T) {
+	//Arrange
+	accountRepositoryStub := NewAccountRepositoryStub()
+	dummyTransaction := getDefaultTransactionBeforeTransact()
+	logger.MuteLogger()
+
+	//Act
+	_, actualErr := accountRepositoryStub.Transact(dummyTransaction)
+
+	//Assert
+	if actualErr == nil {
+		t.Fatal("Expected error but got none while testing transaction on non-existent account")
+	}
+	if history, _ := accountRepositoryStub.FindTransactions(dummyTransaction.AccountId); len(history) != 0 {
+		t.Error("Expected no transaction to be recorded but got some")
+	}
+}
+
+func TestAccountRepositoryStub_Transact_loses_no_updates_when_concurrent(t *testing.T) {
+	//Arrange
+	accountRepositoryStub := NewAccountRepositoryStub()
+	copyOfStub := accountRepositoryStub
+	deposit := Transaction{AccountId: "95470", Amount: 1, TransactionType: dto.TransactionTypeDeposit, TransactionDate: dummyDate}
+	var wg sync.WaitGroup
+
+	//Act
+	for i := 0; i < 100; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, _ = accountRepositoryStub.Transact(deposit)
+		}()
+		go func() {
+			defer wg.Done()
+			_, _ = copyOfStub.Transact(deposit)
+		}()
+	}
+	wg.Wait()
+
+	//Assert
+	account, _ := accountRepositoryStub.FindById("95470")
+	if account.Amount != 6823.23+200 {
+		t.Errorf("Expected balance %.2f but got %.2f", 6823.23+200, account.Amount)
+	}
+	if history, _ := accountRepositoryStub.FindTransactions("95470"); len(history) != 200 {
+		t.Errorf("Expected 200 transactions in history but got %d", len(history))
+	}
+}
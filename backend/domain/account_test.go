@@ -1,6 +1,9 @@
 package domain
 
-import "testing"
+import (
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"testing"
+)
 
 func TestAccount_CanWithdraw_returns_true_when_accountBalance_sufficient(t *testing.T) {
 	//Arrange
@@ -32,3 +35,113 @@ func TestAccount_CanWithdraw_returns_false_when_accountBalance_insufficient(t *t
 
 	}
 }
+
+func TestAccount_CanWithdraw_returns_false_when_availableBalance_insufficient(t *testing.T) {
+	//Arrange
+	account := Account{Amount: 1000, HeldAmount: 300}
+	var withdrawalAmount float64 = 800
+	expectedResult := false
+
+	//Act
+	actualResult := account.CanWithdraw(withdrawalAmount)
+
+	//Assert
+	if actualResult != expectedResult {
+		t.Errorf("expected %v but got %v while testing withdrawal of funds on hold", expectedResult, actualResult)
+	}
+}
+
+func TestAccount_CanWithdraw_allows_overdraft_only_on_checkingAccounts(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name             string
+		account          Account
+		withdrawalAmount float64
+		expectedResult   bool
+	}{
+		{"checking within limit", Account{AccountType: dto.AccountTypeChecking, Amount: 100, OverdraftLimit: 500}, 600, true},
+		{"checking beyond limit", Account{AccountType: dto.AccountTypeChecking, Amount: 100, OverdraftLimit: 500}, 600.01, false},
+		{"checking already overdrawn", Account{AccountType: dto.AccountTypeChecking, Amount: -400, OverdraftLimit: 500}, 100, true},
+		{"checking with funds on hold", Account{AccountType: dto.AccountTypeChecking, Amount: 100, OverdraftLimit: 500, HeldAmount: 200}, 500, false},
+		{"saving", Account{AccountType: dto.AccountTypeSaving, Amount: 100, OverdraftLimit: 500}, 100.01, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Act
+			actualResult := tc.account.CanWithdraw(tc.withdrawalAmount)
+
+			//Assert
+			if actualResult != tc.expectedResult {
+				t.Errorf("expected %v but got %v", tc.expectedResult, actualResult)
+			}
+		})
+	}
+}
+
+func TestAccount_ChargeableFee_caps_fee_at_balance_of_savingAccount(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name           string
+		account        Account
+		fee            float64
+		expectedResult float64
+	}{
+		{"checking overdrawn by fee", Account{AccountType: dto.AccountTypeChecking, Amount: 2}, 5, 5},
+		{"saving with balance for fee", Account{AccountType: dto.AccountTypeSaving, Amount: 100}, 5, 5},
+		{"saving short of fee", Account{AccountType: dto.AccountTypeSaving, Amount: 2}, 5, 2},
+		{"saving empty", Account{AccountType: dto.AccountTypeSaving, Amount: 0}, 5, 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Act
+			actualResult := tc.account.ChargeableFee(tc.fee)
+
+			//Assert
+			if actualResult != tc.expectedResult {
+				t.Errorf("expected %v but got %v", tc.expectedResult, actualResult)
+			}
+		})
+	}
+}
+
+func TestAccount_AvailableOverdraft_returns_unusedOverdraftLimit(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name           string
+		account        Account
+		expectedResult float64
+	}{
+		{"in credit", Account{AccountType: dto.AccountTypeChecking, Amount: 100, OverdraftLimit: 500}, 500},
+		{"in credit with funds on hold", Account{AccountType: dto.AccountTypeChecking, Amount: 100, OverdraftLimit: 500, HeldAmount: 300}, 300},
+		{"overdrawn", Account{AccountType: dto.AccountTypeChecking, Amount: -150, OverdraftLimit: 500}, 350},
+		{"over limit", Account{AccountType: dto.AccountTypeChecking, Amount: -450, OverdraftLimit: 500, HeldAmount: 100}, 0},
+		{"saving", Account{AccountType: dto.AccountTypeSaving, Amount: 100, OverdraftLimit: 500}, 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Act
+			actualResult := tc.account.AvailableOverdraft()
+
+			//Assert
+			if actualResult != tc.expectedResult {
+				t.Errorf("expected %v but got %v", tc.expectedResult, actualResult)
+			}
+		})
+	}
+}
+
+func TestAccount_ToDTO_returns_ledgerAndAvailableBalance(t *testing.T) {
+	//Arrange
+	account := Account{AccountId: "1977", Amount: 1000, HeldAmount: 300}
+
+	//Act
+	response := account.ToDTO()
+
+	//Assert
+	if response.Amount != 1000 || response.AvailableAmount != 700 {
+		t.Errorf("expected ledger balance 1000 and available balance 700 but got %v", response)
+	}
+}
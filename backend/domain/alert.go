@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/dto"
+)
+
+//Business Domain
+
+// AlertRule is the preference of a customer to be alerted about transactions on one of their accounts. An account has
+// at most one rule of each type.
+type AlertRule struct { //business/domain object
+	RuleId    string  `db:"rule_id"`
+	AccountId string  `db:"account_id"`
+	RuleType  string  `db:"rule_type"`
+	Threshold float64 `db:"threshold"`
+	CreatedOn string  `db:"created_on"`
+}
+
+func NewAlertRule(request dto.NewAlertRuleRequest, c clock.Clock) AlertRule {
+	return AlertRule{
+		AccountId: request.AccountId,
+		RuleType:  request.RuleType,
+		Threshold: request.Threshold,
+		CreatedOn: c.NowAsString(),
+	}
+}
+
+// Evaluate checks the given completed transaction against the rule and returns the message to alert the customer
+// with, if the rule was triggered. A low balance rule is only triggered by the withdrawal that takes the balance from
+// at or above the threshold to below it, so that the customer is not alerted again for every further withdrawal.
+func (r AlertRule) Evaluate(t Transaction) (string, bool) {
+	if !t.IsWithdrawal() {
+		return "", false
+	}
+
+	switch r.RuleType {
+	case dto.AlertRuleTypeLargeWithdrawal:
+		if t.Amount > r.Threshold {
+			return fmt.Sprintf("A withdrawal of %.2f was made from account %s, above your alert threshold of %.2f.",
+				t.Amount, t.AccountId, r.Threshold), true
+		}
+	case dto.AlertRuleTypeLowBalance:
+		if t.Balance < r.Threshold && t.Balance+t.Amount >= r.Threshold {
+			return fmt.Sprintf("The balance of account %s has dropped to %.2f, below your alert floor of %.2f.",
+				t.AccountId, t.Balance, r.Threshold), true
+		}
+	}
+	return "", false
+}
+
+func (r AlertRule) ToDTO() dto.AlertRuleResponse {
+	return dto.AlertRuleResponse{
+		RuleId:    r.RuleId,
+		AccountId: r.AccountId,
+		RuleType:  r.RuleType,
+		Threshold: r.Threshold,
+		CreatedOn: r.CreatedOn,
+	}
+}
+
+// Alert is a message to a customer raised by one of their alert rules.
+type Alert struct { //business/domain object
+	AlertId    string `db:"alert_id"`
+	CustomerId string `db:"customer_id"`
+	AccountId  string `db:"account_id"`
+	RuleType   string `db:"rule_type"`
+	Message    string `db:"message"`
+	CreatedOn  string `db:"created_on"`
+}
+
+func NewAlert(customerId string, rule AlertRule, message string, c clock.Clock) Alert {
+	return Alert{
+		CustomerId: customerId,
+		AccountId:  rule.AccountId,
+		RuleType:   rule.RuleType,
+		Message:    message,
+		CreatedOn:  c.NowAsString(),
+	}
+}
+
+func (a Alert) ToDTO() dto.AlertResponse {
+	return dto.AlertResponse{
+		AlertId:   a.AlertId,
+		AccountId: a.AccountId,
+		RuleType:  a.RuleType,
+		Message:   a.Message,
+		CreatedOn: a.CreatedOn,
+	}
+}
+
+//Server
+
+//go:generate mockgen -destination=../mocks/domain/mock_alertRepository.go -package=domain github.com/aliciatay-zls/banking/backend/domain AlertRepository
+type AlertRepository interface { //repo (secondary port)
+	SaveRule(AlertRule) (*AlertRule, *errs.AppError)
+	FindRules(string) ([]AlertRule, *errs.AppError)
+	DeleteRule(string, string) *errs.AppError
+	SaveAlert(Alert) (*Alert, *errs.AppError)
+	FindAlerts(string) ([]Alert, *errs.AppError)
+}
+
+//go:generate mockgen -destination=../mocks/domain/mock_notifier.go -package=domain github.com/aliciatay-zls/banking/backend/domain Notifier
+type Notifier interface { //notifier (secondary port)
+	Notify(Alert) *errs.AppError
+}
@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/problem"
+	"github.com/jmoiron/sqlx"
+	"strconv"
+)
+
+//Server
+
+type AlertRepositoryDb struct { //DB (adapter)
+	client *sqlx.DB
+}
+
+func NewAlertRepositoryDb(dbClient *sqlx.DB) AlertRepositoryDb {
+	return AlertRepositoryDb{dbClient}
+}
+
+// SaveRule creates a new entry in the database for the given alert rule and returns it with its database-generated
+// ID set.
+func (d AlertRepositoryDb) SaveRule(r AlertRule) (*AlertRule, *errs.AppError) {
+	insertSql := "INSERT INTO alert_rules (account_id, rule_type, threshold, created_on) VALUES (?, ?, ?, ?)"
+	result, err := execInsert(d.client, insertSql, "rule_id", r.AccountId, r.RuleType, r.Threshold, r.CreatedOn)
+	if err != nil {
+		logger.Error("Error while creating new alert rule: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		logger.Error("Error while getting id of newly inserted alert rule: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	r.RuleId = strconv.FormatInt(id, 10)
+
+	return &r, nil
+}
+
+// FindRules retrieves all alert rules of the account with the given id.
+func (d AlertRepositoryDb) FindRules(accountId string) ([]AlertRule, *errs.AppError) {
+	rules := make([]AlertRule, 0)
+	findSql := "SELECT rule_id, account_id, rule_type, threshold, " + dateTimeColumn(d.client.DriverName(), "created_on") +
+		" FROM alert_rules WHERE account_id = ? ORDER BY rule_id"
+	if err := d.client.Select(&rules, d.client.Rebind(findSql), accountId); err != nil {
+		logger.Error("Error while retrieving alert rules: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return rules, nil
+}
+
+// DeleteRule deletes the alert rule with the given id of the account with the given id.
+func (d AlertRepositoryDb) DeleteRule(accountId string, ruleId string) *errs.AppError {
+	deleteSql := "DELETE FROM alert_rules WHERE rule_id = ? AND account_id = ?"
+	result, err := d.client.Exec(d.client.Rebind(deleteSql), ruleId, accountId)
+	if err != nil {
+		logger.Error("Error while deleting alert rule: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	if rowsAffected, err := result.RowsAffected(); err != nil || rowsAffected == 0 {
+		logger.Error("Error while deleting alert rule: rule not found")
+		return problem.NewNotFoundError(problem.AlertRuleNotFound, "Alert rule not found")
+	}
+
+	return nil
+}
+
+// SaveAlert creates a new entry in the database for the given alert, which puts it in the customer's inbox, and
+// returns it with its database-generated ID set.
+func (d AlertRepositoryDb) SaveAlert(a Alert) (*Alert, *errs.AppError) {
+	insertSql := "INSERT INTO alerts (customer_id, account_id, rule_type, message, created_on) VALUES (?, ?, ?, ?, ?)"
+	result, err := execInsert(d.client, insertSql, "alert_id", a.CustomerId, a.AccountId, a.RuleType, a.Message, a.CreatedOn)
+	if err != nil {
+		logger.Error("Error while creating new alert: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		logger.Error("Error while getting id of newly inserted alert: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	a.AlertId = strconv.FormatInt(id, 10)
+
+	return &a, nil
+}
+
+// FindAlerts retrieves the inbox of the customer with the given id, newest first.
+func (d AlertRepositoryDb) FindAlerts(customerId string) ([]Alert, *errs.AppError) {
+	alerts := make([]Alert, 0)
+	findSql := "SELECT alert_id, customer_id, account_id, rule_type, message, " +
+		dateTimeColumn(d.client.DriverName(), "created_on") +
+		" FROM alerts WHERE customer_id = ? ORDER BY alert_id DESC"
+	if err := d.client.Select(&alerts, d.client.Rebind(findSql), customerId); err != nil {
+		logger.Error("Error while retrieving alerts: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return alerts, nil
+}
@@ -0,0 +1,165 @@
+package domain
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/jmoiron/sqlx"
+	"net/http"
+	"testing"
+)
+
+// Test common variables and inputs
+var alertRepoDb AlertRepositoryDb
+var alertRulesTableColumns = []string{"rule_id", "account_id", "rule_type", "threshold", "created_on"}
+
+const dummyRuleId = "3"
+
+const insertAlertRulesSql = "INSERT INTO alert_rules (account_id, rule_type, threshold, created_on) VALUES (?, ?, ?, ?)"
+const selectAlertRulesSql = "SELECT rule_id, account_id, rule_type, threshold, created_on FROM alert_rules WHERE account_id = ? ORDER BY rule_id"
+const deleteAlertRulesSql = "DELETE FROM alert_rules WHERE rule_id = ? AND account_id = ?"
+const insertAlertsSql = "INSERT INTO alerts (customer_id, account_id, rule_type, message, created_on) VALUES (?, ?, ?, ?, ?)"
+const selectAlertsSql = "SELECT alert_id, customer_id, account_id, rule_type, message, created_on FROM alerts WHERE customer_id = ? ORDER BY alert_id DESC"
+
+const insertAlertRulesPostgresSql = "INSERT INTO alert_rules (account_id, rule_type, threshold, created_on) VALUES ($1, $2, $3, $4) RETURNING rule_id"
+
+func setupAlertRepoDbTest(t *testing.T, driverName string) func() {
+	teardown := setupDB(t)
+	alertRepoDb = NewAlertRepositoryDb(sqlx.NewDb(db, driverName))
+	return teardown
+}
+
+// getDefaultAlertRule returns a low balance rule with a floor of 500 for the account with id 1977
+func getDefaultAlertRule() AlertRule {
+	return AlertRule{
+		AccountId: dummyAccountId,
+		RuleType:  dto.AlertRuleTypeLowBalance,
+		Threshold: 500,
+		CreatedOn: dummyDate,
+	}
+}
+
+func TestAlertRepositoryDb_SaveRule_returns_rule_with_newId(t *testing.T) {
+	tests := []struct {
+		driverName string
+		insertSql  string
+	}{
+		{DriverMySQL, insertAlertRulesSql},
+		{DriverPostgres, insertAlertRulesPostgresSql},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.driverName, func(t *testing.T) {
+			//Arrange
+			teardown := setupAlertRepoDbTest(t, tc.driverName)
+			defer teardown()
+
+			rule := getDefaultAlertRule()
+			expectInsert(tc.driverName, tc.insertSql, "rule_id", 3, rule.AccountId, rule.RuleType, rule.Threshold, rule.CreatedOn)
+
+			//Act
+			actualRule, err := alertRepoDb.SaveRule(rule)
+
+			//Assert
+			if err != nil {
+				t.Fatal("Expected no error but got error while testing successful saving of alert rule: " + err.Message)
+			}
+			if actualRule.RuleId != dummyRuleId {
+				t.Errorf("Expected rule id %s but got %s", dummyRuleId, actualRule.RuleId)
+			}
+		})
+	}
+}
+
+func TestAlertRepositoryDb_FindRules_returns_rules_of_account(t *testing.T) {
+	//Arrange
+	teardown := setupAlertRepoDbTest(t, driverName)
+	defer teardown()
+
+	expectedRule := getDefaultAlertRule()
+	expectedRule.RuleId = dummyRuleId
+	dummyRows := sqlmock.NewRows(alertRulesTableColumns).
+		AddRow(expectedRule.RuleId, expectedRule.AccountId, expectedRule.RuleType, expectedRule.Threshold, expectedRule.CreatedOn)
+	mockDB.ExpectQuery(selectAlertRulesSql).WithArgs(dummyAccountId).WillReturnRows(dummyRows)
+
+	//Act
+	actualRules, err := alertRepoDb.FindRules(dummyAccountId)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing successful retrieval of alert rules: " + err.Message)
+	}
+	if len(actualRules) != 1 || actualRules[0] != expectedRule {
+		t.Errorf("Expected rules %v but got %v", []AlertRule{expectedRule}, actualRules)
+	}
+}
+
+func TestAlertRepositoryDb_DeleteRule_returns_notFoundError_when_no_rule_deleted(t *testing.T) {
+	//Arrange
+	teardown := setupAlertRepoDbTest(t, driverName)
+	defer teardown()
+
+	mockDB.ExpectExec(deleteAlertRulesSql).WithArgs(dummyRuleId, dummyAccountId).WillReturnResult(sqlmock.NewResult(0, 0))
+	logger.MuteLogger()
+
+	//Act
+	err := alertRepoDb.DeleteRule(dummyAccountId, dummyRuleId)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing deletion of nonexistent alert rule")
+	}
+	if err.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d but got %d", http.StatusNotFound, err.Code)
+	}
+}
+
+func TestAlertRepositoryDb_SaveAlert_returns_error_when_insert_fails(t *testing.T) {
+	//Arrange
+	teardown := setupAlertRepoDbTest(t, driverName)
+	defer teardown()
+
+	alert := Alert{CustomerId: "2", AccountId: dummyAccountId, RuleType: dto.AlertRuleTypeLowBalance, Message: "Low balance", CreatedOn: dummyDate}
+	dummyDbErr := errors.New("some error message")
+	mockDB.ExpectExec(insertAlertsSql).
+		WithArgs(alert.CustomerId, alert.AccountId, alert.RuleType, alert.Message, alert.CreatedOn).
+		WillReturnError(dummyDbErr)
+
+	logs := logger.ReplaceWithTestLogger()
+	expectedLogMessage := "Error while creating new alert: " + dummyDbErr.Error()
+
+	//Act
+	_, err := alertRepoDb.SaveAlert(alert)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing failed saving of alert")
+	}
+	if err.Message != defaultExpectedErrMessage {
+		t.Errorf("Expected error message to be \"%s\" but got \"%s\"", defaultExpectedErrMessage, err.Message)
+	}
+	if logs.Len() != 1 || logs.All()[0].Message != expectedLogMessage {
+		t.Errorf("Expected log message to be \"%s\" but got %v", expectedLogMessage, logs.All())
+	}
+}
+
+func TestAlertRepositoryDb_FindAlerts_returns_error_when_select_fails(t *testing.T) {
+	//Arrange
+	teardown := setupAlertRepoDbTest(t, driverName)
+	defer teardown()
+
+	mockDB.ExpectQuery(selectAlertsSql).WithArgs("2").WillReturnError(errors.New("some error message"))
+	logger.MuteLogger()
+
+	//Act
+	_, err := alertRepoDb.FindAlerts("2")
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing failed retrieval of alerts")
+	}
+	if err.Message != defaultExpectedErrMessage {
+		t.Errorf("Expected error message to be \"%s\" but got \"%s\"", defaultExpectedErrMessage, err.Message)
+	}
+}
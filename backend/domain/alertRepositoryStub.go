@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/problem"
+	"strconv"
+	"sync"
+)
+
+//Server
+
+type AlertRepositoryStub struct { //stub (adapter)
+	store *alertStore //shared by all copies of the stub, so that changes made through one copy are seen by all
+}
+
+// alertStore holds the alert rules and alerts of an AlertRepositoryStub in memory. It is safe for concurrent use.
+type alertStore struct {
+	mu          sync.Mutex
+	rules       []AlertRule
+	alerts      []Alert
+	nextRuleId  int64
+	nextAlertId int64
+}
+
+func NewAlertRepositoryStub() AlertRepositoryStub { //helper function to create and initialize a stub
+	return AlertRepositoryStub{&alertStore{
+		rules:       make([]AlertRule, 0),
+		alerts:      make([]Alert, 0),
+		nextRuleId:  1,
+		nextAlertId: 1,
+	}}
+}
+
+func (s AlertRepositoryStub) SaveRule(rule AlertRule) (*AlertRule, *errs.AppError) { //stub implements repo
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	rule.RuleId = strconv.FormatInt(s.store.nextRuleId, 10)
+	s.store.nextRuleId++
+	s.store.rules = append(s.store.rules, rule)
+
+	return &rule, nil
+}
+
+func (s AlertRepositoryStub) FindRules(accountId string) ([]AlertRule, *errs.AppError) { //stub implements repo
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	rules := make([]AlertRule, 0)
+	for _, r := range s.store.rules {
+		if r.AccountId == accountId {
+			rules = append(rules, r)
+		}
+	}
+	return rules, nil
+}
+
+func (s AlertRepositoryStub) DeleteRule(accountId string, ruleId string) *errs.AppError { //stub implements repo
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	for i, r := range s.store.rules {
+		if r.RuleId == ruleId && r.AccountId == accountId {
+			s.store.rules = append(s.store.rules[:i], s.store.rules[i+1:]...)
+			return nil
+		}
+	}
+	logger.Error("Error while deleting alert rule using stub for AlertRepository: not found")
+	return problem.NewNotFoundError(problem.AlertRuleNotFound, "Alert rule not found")
+}
+
+func (s AlertRepositoryStub) SaveAlert(alert Alert) (*Alert, *errs.AppError) { //stub implements repo
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	alert.AlertId = strconv.FormatInt(s.store.nextAlertId, 10)
+	s.store.nextAlertId++
+	s.store.alerts = append(s.store.alerts, alert)
+
+	return &alert, nil
+}
+
+// FindAlerts returns the inbox of the customer with the given id, newest first like the database.
+func (s AlertRepositoryStub) FindAlerts(customerId string) ([]Alert, *errs.AppError) { //stub implements repo
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	alerts := make([]Alert, 0)
+	for i := len(s.store.alerts) - 1; i >= 0; i-- {
+		if s.store.alerts[i].CustomerId == customerId {
+			alerts = append(alerts, s.store.alerts[i])
+		}
+	}
+	return alerts, nil
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"net/http"
+	"testing"
+)
+
+func TestAlertRepositoryStub_DeleteRule_removes_only_rule_of_given_account(t *testing.T) {
+	//Arrange
+	stub := NewAlertRepositoryStub()
+	rule, _ := stub.SaveRule(getDefaultAlertRule())
+	logger.MuteLogger()
+
+	//Act
+	otherAccountErr := stub.DeleteRule("1978", rule.RuleId)
+	err := stub.DeleteRule(dummyAccountId, rule.RuleId)
+	rules, _ := stub.FindRules(dummyAccountId)
+
+	//Assert
+	if otherAccountErr == nil || otherAccountErr.Code != http.StatusNotFound {
+		t.Errorf("Expected not found error when deleting rule of another account but got %v", otherAccountErr)
+	}
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing deletion of alert rule: " + err.Message)
+	}
+	if len(rules) != 0 {
+		t.Errorf("Expected no rules left but got %v", rules)
+	}
+}
+
+func TestAlertRepositoryStub_FindAlerts_returns_alerts_of_customer_newestFirst(t *testing.T) {
+	//Arrange
+	stub := NewAlertRepositoryStub()
+	for _, customerId := range []string{"2", "3", "2"} {
+		stub.SaveAlert(Alert{CustomerId: customerId, AccountId: dummyAccountId, RuleType: dto.AlertRuleTypeLowBalance})
+	}
+
+	//Act
+	alerts, err := stub.FindAlerts("2")
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing retrieval of alerts: " + err.Message)
+	}
+	if len(alerts) != 2 || alerts[0].AlertId != "3" || alerts[1].AlertId != "1" {
+		t.Errorf("Expected alerts 3 and 1 but got %v", alerts)
+	}
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"testing"
+)
+
+func TestAlertRule_Evaluate_triggers_only_on_matching_withdrawals(t *testing.T) {
+	tests := []struct {
+		name            string
+		ruleType        string
+		transactionType string
+		amount          float64
+		balance         float64
+		isTriggered     bool
+	}{
+		{"large withdrawal above threshold", dto.AlertRuleTypeLargeWithdrawal, dto.TransactionTypeWithdrawal, 1000.01, 5000, true},
+		{"large withdrawal at threshold", dto.AlertRuleTypeLargeWithdrawal, dto.TransactionTypeWithdrawal, 1000, 5000, false},
+		{"large deposit above threshold", dto.AlertRuleTypeLargeWithdrawal, dto.TransactionTypeDeposit, 5000, 5000, false},
+		{"balance drops below floor", dto.AlertRuleTypeLowBalance, dto.TransactionTypeWithdrawal, 100, 950, true},
+		{"balance drops below floor from floor", dto.AlertRuleTypeLowBalance, dto.TransactionTypeWithdrawal, 50, 950, true},
+		{"balance stays at floor", dto.AlertRuleTypeLowBalance, dto.TransactionTypeWithdrawal, 100, 1000, false},
+		{"balance already below floor", dto.AlertRuleTypeLowBalance, dto.TransactionTypeWithdrawal, 10, 900, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Arrange
+			rule := AlertRule{AccountId: dummyAccountId, RuleType: tc.ruleType, Threshold: 1000}
+			transaction := Transaction{AccountId: dummyAccountId, Amount: tc.amount, Balance: tc.balance, TransactionType: tc.transactionType}
+
+			//Act
+			message, isTriggered := rule.Evaluate(transaction)
+
+			//Assert
+			if isTriggered != tc.isTriggered {
+				t.Fatalf("Expected rule to be triggered: %t but got %t", tc.isTriggered, isTriggered)
+			}
+			if isTriggered == (message == "") {
+				t.Errorf("Expected a message only when triggered but got \"%s\"", message)
+			}
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"time"
+)
+
+//Business Domain
+
+// Groupings of the transactions of a customer that the analytics are aggregated by.
+const AnalyticsGroupingTotal = "" //all transactions as one group
+const AnalyticsGroupingMonth = "month"
+const AnalyticsGroupingCategory = "category"
+const AnalyticsGroupingType = "type"
+
+// TransactionTotals holds the sums and counts of the deposits (income) and of the debits (spending) of a group of
+// transactions: a month ("2006-01"), a category, a transaction type or all transactions, aggregated by the database.
+type TransactionTotals struct { //business/domain object
+	Group         string  `db:"group_key"`
+	Income        float64 `db:"income"`
+	Spending      float64 `db:"spending"` //including the fees and interest charged by the bank
+	IncomeCount   int     `db:"income_count"`
+	SpendingCount int     `db:"spending_count"`
+}
+
+func (t TransactionTotals) Net() float64 {
+	return roundToCents(t.Income - t.Spending)
+}
+
+func (t TransactionTotals) Count() int {
+	return t.IncomeCount + t.SpendingCount
+}
+
+// AverageIncome is the average amount of the deposits of the group, 0 if there are none.
+func (t TransactionTotals) AverageIncome() float64 {
+	return average(t.Income, t.IncomeCount)
+}
+
+// AverageSpending is the average amount of the debits of the group, 0 if there are none.
+func (t TransactionTotals) AverageSpending() float64 {
+	return average(t.Spending, t.SpendingCount)
+}
+
+func average(total float64, count int) float64 {
+	if count == 0 {
+		return 0
+	}
+	return roundToCents(total / float64(count))
+}
+
+// AnalyticsPeriod is the range of calendar months that the analytics of a customer cover.
+type AnalyticsPeriod struct {
+	Start time.Time
+	End   time.Time //exclusive, the start of the month after the last month covered
+}
+
+// NewAnalyticsPeriod returns the months from the given month up to and including the given month, both given as
+// "2006-01", or an error if either is not a month in that format.
+func NewAnalyticsPeriod(from string, to string) (AnalyticsPeriod, error) {
+	start, err := time.Parse(formatPeriod, from)
+	if err != nil {
+		return AnalyticsPeriod{}, err
+	}
+	last, err := time.Parse(formatPeriod, to)
+	if err != nil {
+		return AnalyticsPeriod{}, err
+	}
+	return AnalyticsPeriod{start, last.AddDate(0, 1, 0)}, nil
+}
+
+// NewAnalyticsPeriodEndingIn returns the given number of months up to and including the given month, given as
+// "2006-01", or an error if it is not a month in that format.
+func NewAnalyticsPeriodEndingIn(to string, months int) (AnalyticsPeriod, error) {
+	period, err := NewAnalyticsPeriod(to, to)
+	if err != nil {
+		return AnalyticsPeriod{}, err
+	}
+	period.Start = period.End.AddDate(0, -months, 0)
+	return period, nil
+}
+
+// AnalyticsMonthOf returns the month that the given "2006-01-02 15:04:05" date is in, as "2006-01".
+func AnalyticsMonthOf(date string) string {
+	return date[:len(formatPeriod)]
+}
+
+// Months returns the months covered, oldest first, as "2006-01".
+func (p AnalyticsPeriod) Months() []string {
+	months := make([]string, 0)
+	for m := p.Start; m.Before(p.End); m = m.AddDate(0, 1, 0) {
+		months = append(months, m.Format(formatPeriod))
+	}
+	return months
+}
+
+func (p AnalyticsPeriod) From() string {
+	return p.Start.Format(formatPeriod)
+}
+
+func (p AnalyticsPeriod) To() string {
+	return p.End.AddDate(0, -1, 0).Format(formatPeriod)
+}
+
+func (p AnalyticsPeriod) StartAsString() string {
+	return p.Start.Format(clock.FormatDateTime)
+}
+
+func (p AnalyticsPeriod) EndAsString() string {
+	return p.End.Format(clock.FormatDateTime)
+}
+
+// CustomerAnalytics holds the transaction totals of all accounts of a customer over a period, by month, by category and
+// by transaction type, together with the largest debits.
+type CustomerAnalytics struct { //business/domain object
+	CustomerId      string
+	Period          AnalyticsPeriod
+	Totals          TransactionTotals
+	ByMonth         []TransactionTotals //only the months with transactions
+	ByCategory      []TransactionTotals //the transactions without a category are grouped under ""
+	ByType          []TransactionTotals
+	TopTransactions []Transaction
+}
+
+// ToDTO lists every month of the period, with those without transactions at zero, and works out the change of each
+// month from the month before.
+func (a CustomerAnalytics) ToDTO() dto.AnalyticsResponse {
+	months := a.Period.Months()
+	response := dto.AnalyticsResponse{
+		CustomerId: a.CustomerId,
+		From:       a.Period.From(),
+		To:         a.Period.To(),
+		Totals: dto.AnalyticsTotalsResponse{
+			Income:                 roundToCents(a.Totals.Income),
+			Spending:               roundToCents(a.Totals.Spending),
+			Net:                    a.Totals.Net(),
+			TransactionCount:       a.Totals.Count(),
+			AverageIncome:          a.Totals.AverageIncome(),
+			AverageSpending:        a.Totals.AverageSpending(),
+			AverageMonthlyIncome:   average(a.Totals.Income, len(months)),
+			AverageMonthlySpending: average(a.Totals.Spending, len(months)),
+		},
+		ByMonth:         make([]dto.MonthlyAnalyticsResponse, 0, len(months)),
+		ByCategory:      make([]dto.CategoryAnalyticsResponse, 0, len(a.ByCategory)),
+		ByType:          make([]dto.TransactionTypeAnalyticsResponse, 0, len(a.ByType)),
+		TopTransactions: make([]dto.AnalyticsTransactionResponse, 0, len(a.TopTransactions)),
+	}
+
+	byMonth := make(map[string]TransactionTotals)
+	for _, m := range a.ByMonth {
+		byMonth[m.Group] = m
+	}
+	for i, month := range months {
+		totals := byMonth[month]
+		monthly := dto.MonthlyAnalyticsResponse{
+			Month:            month,
+			Income:           roundToCents(totals.Income),
+			Spending:         roundToCents(totals.Spending),
+			Net:              totals.Net(),
+			TransactionCount: totals.Count(),
+		}
+		if i > 0 {
+			previous := byMonth[months[i-1]]
+			incomeChange := roundToCents(totals.Income - previous.Income)
+			spendingChange := roundToCents(totals.Spending - previous.Spending)
+			monthly.IncomeChange, monthly.SpendingChange = &incomeChange, &spendingChange
+			if previous.Spending != 0 {
+				percent := roundToCents(spendingChange / previous.Spending * 100)
+				monthly.SpendingChangePercent = &percent
+			}
+		}
+		response.ByMonth = append(response.ByMonth, monthly)
+	}
+
+	for _, c := range a.ByCategory {
+		category := c.Group
+		if category == "" {
+			category = dto.AnalyticsUncategorized
+		}
+		response.ByCategory = append(response.ByCategory, dto.CategoryAnalyticsResponse{
+			Category:         category,
+			Income:           roundToCents(c.Income),
+			Spending:         roundToCents(c.Spending),
+			Net:              c.Net(),
+			TransactionCount: c.Count(),
+			AverageSpending:  c.AverageSpending(),
+		})
+	}
+
+	for _, t := range a.ByType {
+		response.ByType = append(response.ByType, dto.TransactionTypeAnalyticsResponse{
+			TransactionType:  t.Group,
+			Total:            roundToCents(t.Income + t.Spending),
+			TransactionCount: t.Count(),
+			AverageAmount:    average(t.Income+t.Spending, t.Count()),
+		})
+	}
+
+	for _, t := range a.TopTransactions {
+		response.TopTransactions = append(response.TopTransactions, dto.AnalyticsTransactionResponse{
+			TransactionId:   t.TransactionId,
+			AccountId:       t.AccountId,
+			TransactionType: t.TransactionType,
+			Amount:          t.Amount,
+			TransactionDate: t.TransactionDate,
+			Description:     t.Description,
+			Category:        t.Category,
+		})
+	}
+
+	return response
+}
+
+//Server
+
+//go:generate mockgen -destination=../mocks/domain/mock_analyticsRepository.go -package=domain github.com/aliciatay-zls/banking/backend/domain AnalyticsRepository
+type AnalyticsRepository interface { //repo (secondary port)
+	SumTransactions([]string, AnalyticsPeriod, string) ([]TransactionTotals, *errs.AppError)
+	FindLargestDebits([]string, AnalyticsPeriod, int) ([]Transaction, *errs.AppError)
+}
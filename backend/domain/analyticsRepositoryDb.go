@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/jmoiron/sqlx"
+)
+
+//Server
+
+type AnalyticsRepositoryDb struct { //DB (adapter)
+	client *sqlx.DB
+}
+
+func NewAnalyticsRepositoryDb(dbClient *sqlx.DB) AnalyticsRepositoryDb {
+	return AnalyticsRepositoryDb{dbClient}
+}
+
+// SumTransactions retrieves the sums and counts of the deposits and of the debits made on the accounts with the given
+// ids during the given period, aggregated by the database into one group for each month, category or transaction type
+// with transactions, or into a single group for AnalyticsGroupingTotal. The groups are ordered by their key.
+func (d AnalyticsRepositoryDb) SumTransactions(accountIds []string, period AnalyticsPeriod, grouping string) ([]TransactionTotals, *errs.AppError) {
+	var groupExpr string
+	switch grouping {
+	case AnalyticsGroupingTotal:
+		groupExpr = "''"
+	case AnalyticsGroupingMonth:
+		groupExpr = monthExpression(d.client.DriverName(), "transaction_date")
+	case AnalyticsGroupingCategory:
+		groupExpr = "category"
+	case AnalyticsGroupingType:
+		groupExpr = "transaction_type"
+	default:
+		logger.Error("Error while summing transactions for analytics: unknown grouping " + grouping)
+		return nil, errs.NewUnexpectedError("Unexpected server-side error")
+	}
+
+	sumSql := "SELECT " + groupExpr + " AS group_key, " +
+		"COALESCE(SUM(CASE WHEN transaction_type = ? THEN amount ELSE 0 END), 0) AS income, " +
+		"COALESCE(SUM(CASE WHEN transaction_type <> ? THEN amount ELSE 0 END), 0) AS spending, " +
+		"COALESCE(SUM(CASE WHEN transaction_type = ? THEN 1 ELSE 0 END), 0) AS income_count, " +
+		"COALESCE(SUM(CASE WHEN transaction_type <> ? THEN 1 ELSE 0 END), 0) AS spending_count " +
+		"FROM transactions WHERE account_id IN (?) AND transaction_date >= ? AND transaction_date < ?"
+	if grouping != AnalyticsGroupingTotal {
+		sumSql += " GROUP BY " + groupExpr + " ORDER BY group_key"
+	}
+	sumSql, args, err := sqlx.In(sumSql, dto.TransactionTypeDeposit, dto.TransactionTypeDeposit, dto.TransactionTypeDeposit,
+		dto.TransactionTypeDeposit, accountIds, period.StartAsString(), period.EndAsString())
+	if err != nil {
+		logger.Error("Error while building query for summing transactions for analytics: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	totals := make([]TransactionTotals, 0)
+	if err = d.client.Select(&totals, d.client.Rebind(sumSql), args...); err != nil {
+		logger.Error("Error while summing transactions for analytics: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return totals, nil
+}
+
+// FindLargestDebits retrieves up to the given number of the largest debits made on the accounts with the given ids
+// during the given period, largest first.
+func (d AnalyticsRepositoryDb) FindLargestDebits(accountIds []string, period AnalyticsPeriod, limit int) ([]Transaction, *errs.AppError) {
+	findSql, args, err := sqlx.In("SELECT transaction_id, account_id, amount, transaction_type, "+
+		dateTimeColumn(d.client.DriverName(), "transaction_date")+", description, reference, category, related_transaction_id "+
+		"FROM transactions WHERE account_id IN (?) AND transaction_type <> ? AND transaction_date >= ? AND transaction_date < ? "+
+		"ORDER BY amount DESC, transaction_id LIMIT ?",
+		accountIds, dto.TransactionTypeDeposit, period.StartAsString(), period.EndAsString(), limit)
+	if err != nil {
+		logger.Error("Error while building query for retrieving largest debits for analytics: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	transactions := make([]Transaction, 0)
+	if err = d.client.Select(&transactions, d.client.Rebind(findSql), args...); err != nil {
+		logger.Error("Error while retrieving largest debits for analytics: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return transactions, nil
+}
@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/jmoiron/sqlx"
+	"testing"
+)
+
+// Test common variables and inputs
+var analyticsRepoDb AnalyticsRepositoryDb
+var transactionTotalsColumns = []string{"group_key", "income", "spending", "income_count", "spending_count"}
+
+const sumTransactionsSql = "AS group_key, " +
+	"COALESCE(SUM(CASE WHEN transaction_type = ? THEN amount ELSE 0 END), 0) AS income, " +
+	"COALESCE(SUM(CASE WHEN transaction_type <> ? THEN amount ELSE 0 END), 0) AS spending, " +
+	"COALESCE(SUM(CASE WHEN transaction_type = ? THEN 1 ELSE 0 END), 0) AS income_count, " +
+	"COALESCE(SUM(CASE WHEN transaction_type <> ? THEN 1 ELSE 0 END), 0) AS spending_count " +
+	"FROM transactions WHERE account_id IN (?, ?) AND transaction_date >= ? AND transaction_date < ?"
+const selectLargestDebitsSql = "SELECT transaction_id, account_id, amount, transaction_type, transaction_date, " +
+	"description, reference, category, related_transaction_id FROM transactions WHERE account_id IN (?, ?) " +
+	"AND transaction_type <> ? AND transaction_date >= ? AND transaction_date < ? ORDER BY amount DESC, transaction_id LIMIT ?"
+
+var dummyAnalyticsAccountIds = []string{dummyAccountId, "1978"}
+
+func setupAnalyticsRepoDbTest(t *testing.T) func() {
+	teardown := setupDB(t)
+	analyticsRepoDb = NewAnalyticsRepositoryDb(sqlx.NewDb(db, driverName))
+	return teardown
+}
+
+// getDefaultAnalyticsPeriod returns the period from January to March 2006
+func getDefaultAnalyticsPeriod() AnalyticsPeriod {
+	period, _ := NewAnalyticsPeriod("2006-01", "2006-03")
+	return period
+}
+
+func TestAnalyticsRepositoryDb_SumTransactions_aggregates_by_grouping(t *testing.T) {
+	tests := []struct {
+		grouping    string
+		expectedSql string
+	}{
+		{AnalyticsGroupingTotal, "SELECT '' " + sumTransactionsSql},
+		{AnalyticsGroupingMonth, "SELECT DATE_FORMAT(transaction_date, '%Y-%m') " + sumTransactionsSql +
+			" GROUP BY DATE_FORMAT(transaction_date, '%Y-%m') ORDER BY group_key"},
+		{AnalyticsGroupingCategory, "SELECT category " + sumTransactionsSql + " GROUP BY category ORDER BY group_key"},
+		{AnalyticsGroupingType, "SELECT transaction_type " + sumTransactionsSql + " GROUP BY transaction_type ORDER BY group_key"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.grouping, func(t *testing.T) {
+			//Arrange
+			teardown := setupAnalyticsRepoDbTest(t)
+			defer teardown()
+
+			mockDB.ExpectQuery(tc.expectedSql).
+				WithArgs(dto.TransactionTypeDeposit, dto.TransactionTypeDeposit, dto.TransactionTypeDeposit,
+					dto.TransactionTypeDeposit, dummyAccountId, "1978", "2006-01-01 00:00:00", "2006-04-01 00:00:00").
+				WillReturnRows(sqlmock.NewRows(transactionTotalsColumns).AddRow("x", 300, 120.5, 1, 2))
+
+			//Act
+			totals, err := analyticsRepoDb.SumTransactions(dummyAnalyticsAccountIds, getDefaultAnalyticsPeriod(), tc.grouping)
+
+			//Assert
+			if err != nil {
+				t.Fatal("Expected no error but got error while testing summing of transactions: " + err.Message)
+			}
+			expectedTotals := TransactionTotals{Group: "x", Income: 300, Spending: 120.5, IncomeCount: 1, SpendingCount: 2}
+			if len(totals) != 1 || totals[0] != expectedTotals {
+				t.Errorf("Expected %v but got %v", expectedTotals, totals)
+			}
+		})
+	}
+}
+
+func TestAnalyticsRepositoryDb_SumTransactions_returns_error_when_select_fails(t *testing.T) {
+	//Arrange
+	teardown := setupAnalyticsRepoDbTest(t)
+	defer teardown()
+
+	dummyDbErr := errors.New("some error message")
+	mockDB.ExpectQuery("SELECT category " + sumTransactionsSql + " GROUP BY category ORDER BY group_key").
+		WillReturnError(dummyDbErr)
+
+	logs := logger.ReplaceWithTestLogger()
+	expectedLogMessage := "Error while summing transactions for analytics: " + dummyDbErr.Error()
+
+	//Act
+	_, err := analyticsRepoDb.SumTransactions(dummyAnalyticsAccountIds, getDefaultAnalyticsPeriod(), AnalyticsGroupingCategory)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing failed summing of transactions")
+	}
+	if err.Message != defaultExpectedErrMessage {
+		t.Errorf("Expected error message to be \"%s\" but got \"%s\"", defaultExpectedErrMessage, err.Message)
+	}
+	if logs.Len() != 1 || logs.All()[0].Message != expectedLogMessage {
+		t.Errorf("Expected log message \"%s\" but got %v", expectedLogMessage, logs.All())
+	}
+}
+
+func TestAnalyticsRepositoryDb_FindLargestDebits_returns_transactions_largestFirst(t *testing.T) {
+	//Arrange
+	teardown := setupAnalyticsRepoDbTest(t)
+	defer teardown()
+
+	mockDB.ExpectQuery(selectLargestDebitsSql).
+		WithArgs(dummyAccountId, "1978", dto.TransactionTypeDeposit, "2006-01-01 00:00:00", "2006-04-01 00:00:00", 2).
+		WillReturnRows(sqlmock.NewRows([]string{"transaction_id", "account_id", "amount", "transaction_type",
+			"transaction_date", "description", "reference", "category", "related_transaction_id"}).
+			AddRow("7", "1978", 900, dto.TransactionTypeWithdrawal, "2006-02-03 10:00:00", "Rent", "", dto.TransactionCategoryBills, nil).
+			AddRow("3", dummyAccountId, 40, dto.TransactionTypeWithdrawal, "2006-01-05 10:00:00", "Lunch", "", "", nil))
+
+	//Act
+	transactions, err := analyticsRepoDb.FindLargestDebits(dummyAnalyticsAccountIds, getDefaultAnalyticsPeriod(), 2)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing retrieval of largest debits: " + err.Message)
+	}
+	if len(transactions) != 2 || transactions[0].TransactionId != "7" || transactions[0].Category != dto.TransactionCategoryBills {
+		t.Errorf("Expected rent of 900 then lunch of 40 but got %v", transactions)
+	}
+}
@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"sort"
+)
+
+//Server
+
+type AnalyticsRepositoryStub struct { //stub (adapter)
+	accounts AccountRepositoryStub //the transaction history is read from here
+}
+
+func NewAnalyticsRepositoryStub(accounts AccountRepositoryStub) AnalyticsRepositoryStub { //helper function to create and initialize a stub
+	return AnalyticsRepositoryStub{accounts}
+}
+
+// SumTransactions adds up the deposits and the debits made on the accounts with the given ids during the given period
+// into groups ordered by their key, like AnalyticsRepositoryDb.
+func (s AnalyticsRepositoryStub) SumTransactions(accountIds []string, period AnalyticsPeriod, grouping string) ([]TransactionTotals, *errs.AppError) { //stub implements repo
+	var groupOf func(Transaction) string
+	switch grouping {
+	case AnalyticsGroupingTotal:
+		groupOf = func(Transaction) string { return "" }
+	case AnalyticsGroupingMonth:
+		groupOf = func(t Transaction) string { return t.TransactionDate[:len(formatPeriod)] }
+	case AnalyticsGroupingCategory:
+		groupOf = func(t Transaction) string { return t.Category }
+	case AnalyticsGroupingType:
+		groupOf = func(t Transaction) string { return t.TransactionType }
+	default:
+		logger.Error("Error while summing transactions using stub for AnalyticsRepository: unknown grouping " + grouping)
+		return nil, errs.NewUnexpectedError("Unexpected server-side error")
+	}
+
+	groups := make(map[string]*TransactionTotals)
+	if grouping == AnalyticsGroupingTotal { //like the database, all transactions make one group even if there are none
+		groups[""] = &TransactionTotals{}
+	}
+	for _, t := range s.findTransactions(accountIds, period) {
+		key := groupOf(t)
+		if groups[key] == nil {
+			groups[key] = &TransactionTotals{Group: key}
+		}
+		if t.IsDebit() {
+			groups[key].Spending += t.Amount
+			groups[key].SpendingCount++
+		} else {
+			groups[key].Income += t.Amount
+			groups[key].IncomeCount++
+		}
+	}
+
+	totals := make([]TransactionTotals, 0, len(groups))
+	for _, g := range groups {
+		totals = append(totals, *g)
+	}
+	sort.Slice(totals, func(i, j int) bool {
+		return totals[i].Group < totals[j].Group
+	})
+	return totals, nil
+}
+
+// FindLargestDebits returns up to the given number of the largest debits made on the accounts with the given ids
+// during the given period, largest first.
+func (s AnalyticsRepositoryStub) FindLargestDebits(accountIds []string, period AnalyticsPeriod, limit int) ([]Transaction, *errs.AppError) { //stub implements repo
+	debits := make([]Transaction, 0)
+	for _, t := range s.findTransactions(accountIds, period) {
+		if t.IsDebit() {
+			debits = append(debits, t)
+		}
+	}
+	sort.SliceStable(debits, func(i, j int) bool { //the transactions are in the order they were made
+		return debits[i].Amount > debits[j].Amount
+	})
+	if len(debits) > limit {
+		debits = debits[:limit]
+	}
+	return debits, nil
+}
+
+// findTransactions returns the transactions made on the accounts with the given ids during the given period.
+func (s AnalyticsRepositoryStub) findTransactions(accountIds []string, period AnalyticsPeriod) []Transaction {
+	s.accounts.store.mu.Lock()
+	defer s.accounts.store.mu.Unlock()
+
+	isAnalyzed := make(map[string]bool)
+	for _, id := range accountIds {
+		isAnalyzed[id] = true
+	}
+	start, end := period.StartAsString(), period.EndAsString()
+	transactions := make([]Transaction, 0)
+	for _, t := range s.accounts.store.transactions {
+		if isAnalyzed[t.AccountId] && t.TransactionDate >= start && t.TransactionDate < end { //dates in the same format sort in time order
+			transactions = append(transactions, t)
+		}
+	}
+	return transactions
+}
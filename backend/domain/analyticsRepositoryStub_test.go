@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"testing"
+)
+
+// getAnalyzedAccountsStub returns an AccountRepositoryStub whose account with id 95470 has a deposit in January 2006,
+// a withdrawal and a fee in February 2006 and a withdrawal in April 2006, after the default analytics period, and
+// whose account with id 95471 has a withdrawal in January 2006.
+func getAnalyzedAccountsStub() AccountRepositoryStub {
+	accounts := NewAccountRepositoryStub()
+	for _, t := range []Transaction{
+		{AccountId: "95470", Amount: 500, TransactionType: dto.TransactionTypeDeposit, TransactionDate: "2006-01-02 15:04:05", Category: dto.TransactionCategoryIncome},
+		{AccountId: "95470", Amount: 80, TransactionType: dto.TransactionTypeWithdrawal, TransactionDate: "2006-02-03 10:00:00", Category: dto.TransactionCategoryDining},
+		{AccountId: "95470", Amount: 2.5, TransactionType: dto.TransactionTypeFee, TransactionDate: "2006-02-28 23:59:59"},
+		{AccountId: "95470", Amount: 999, TransactionType: dto.TransactionTypeWithdrawal, TransactionDate: "2006-04-01 00:00:00"},
+		{AccountId: "95471", Amount: 40, TransactionType: dto.TransactionTypeWithdrawal, TransactionDate: "2006-01-10 09:00:00", Category: dto.TransactionCategoryDining},
+	} {
+		accounts.Transact(t)
+	}
+	return accounts
+}
+
+func TestAnalyticsRepositoryStub_SumTransactions_aggregates_transactions_of_given_accounts_in_period(t *testing.T) {
+	//Arrange
+	stub := NewAnalyticsRepositoryStub(getAnalyzedAccountsStub())
+
+	//Act
+	byMonth, monthErr := stub.SumTransactions([]string{"95470"}, getDefaultAnalyticsPeriod(), AnalyticsGroupingMonth)
+	byCategory, categoryErr := stub.SumTransactions([]string{"95470", "95471"}, getDefaultAnalyticsPeriod(), AnalyticsGroupingCategory)
+
+	//Assert
+	if monthErr != nil || categoryErr != nil {
+		t.Fatalf("Expected no error but got %v and %v while testing summing of transactions", monthErr, categoryErr)
+	}
+	expectedByMonth := []TransactionTotals{
+		{Group: "2006-01", Income: 500, IncomeCount: 1},
+		{Group: "2006-02", Spending: 82.5, SpendingCount: 2},
+	}
+	if len(byMonth) != len(expectedByMonth) || byMonth[0] != expectedByMonth[0] || byMonth[1] != expectedByMonth[1] {
+		t.Errorf("Expected %v but got %v", expectedByMonth, byMonth)
+	}
+	expectedByCategory := []TransactionTotals{
+		{Group: "", Spending: 2.5, SpendingCount: 1},
+		{Group: dto.TransactionCategoryDining, Spending: 120, SpendingCount: 2},
+		{Group: dto.TransactionCategoryIncome, Income: 500, IncomeCount: 1},
+	}
+	if len(byCategory) != len(expectedByCategory) || byCategory[0] != expectedByCategory[0] ||
+		byCategory[1] != expectedByCategory[1] || byCategory[2] != expectedByCategory[2] {
+		t.Errorf("Expected %v but got %v", expectedByCategory, byCategory)
+	}
+}
+
+func TestAnalyticsRepositoryStub_SumTransactions_returns_one_emptyGroup_for_total_without_transactions(t *testing.T) {
+	//Arrange
+	stub := NewAnalyticsRepositoryStub(NewAccountRepositoryStub())
+
+	//Act
+	totals, err := stub.SumTransactions([]string{"95470"}, getDefaultAnalyticsPeriod(), AnalyticsGroupingTotal)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing summing of no transactions: " + err.Message)
+	}
+	if len(totals) != 1 || totals[0] != (TransactionTotals{}) {
+		t.Errorf("Expected a single empty group but got %v", totals)
+	}
+}
+
+func TestAnalyticsRepositoryStub_FindLargestDebits_returns_debits_in_period_largestFirst(t *testing.T) {
+	//Arrange
+	stub := NewAnalyticsRepositoryStub(getAnalyzedAccountsStub())
+
+	//Act
+	debits, err := stub.FindLargestDebits([]string{"95470", "95471"}, getDefaultAnalyticsPeriod(), 2)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing retrieval of largest debits: " + err.Message)
+	}
+	if len(debits) != 2 || debits[0].Amount != 80 || debits[1].Amount != 40 {
+		t.Errorf("Expected debits of 80 and 40 but got %v", debits)
+	}
+}
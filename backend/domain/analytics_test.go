@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"testing"
+)
+
+func TestNewAnalyticsPeriod_covers_months_from_start_up_to_and_including_end(t *testing.T) {
+	//Act
+	period, err := NewAnalyticsPeriod("2005-11", "2006-02")
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing creation of analytics period: " + err.Error())
+	}
+	months := period.Months()
+	expectedMonths := []string{"2005-11", "2005-12", "2006-01", "2006-02"}
+	if len(months) != len(expectedMonths) {
+		t.Fatalf("Expected months %v but got %v", expectedMonths, months)
+	}
+	for i := range expectedMonths {
+		if months[i] != expectedMonths[i] {
+			t.Errorf("Expected months %v but got %v", expectedMonths, months)
+		}
+	}
+	if period.From() != "2005-11" || period.To() != "2006-02" || period.EndAsString() != "2006-03-01 00:00:00" {
+		t.Errorf("Expected period from 2005-11 to 2006-02 ending before 2006-03-01 but got %v", period)
+	}
+}
+
+func TestNewAnalyticsPeriodEndingIn_covers_given_number_of_months_up_to_month_of_date(t *testing.T) {
+	//Act
+	period, err := NewAnalyticsPeriodEndingIn(AnalyticsMonthOf(dummyDate), 12)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing creation of analytics period: " + err.Error())
+	}
+	if period.From() != "2005-02" || period.To() != "2006-01" || len(period.Months()) != 12 {
+		t.Errorf("Expected 12 months from 2005-02 to 2006-01 but got %v", period.Months())
+	}
+}
+
+func TestCustomerAnalytics_ToDTO_lists_every_month_with_change_from_month_before(t *testing.T) {
+	//Arrange
+	analytics := CustomerAnalytics{
+		CustomerId: "2",
+		Period:     getDefaultAnalyticsPeriod(),
+		Totals:     TransactionTotals{Income: 500, Spending: 300, IncomeCount: 1, SpendingCount: 3},
+		ByMonth: []TransactionTotals{
+			{Group: "2006-01", Income: 500, Spending: 200, IncomeCount: 1, SpendingCount: 2},
+			{Group: "2006-03", Spending: 100, SpendingCount: 1},
+		},
+	}
+
+	//Act
+	response := analytics.ToDTO()
+
+	//Assert
+	if response.Totals.Net != 200 || response.Totals.AverageSpending != 100 || response.Totals.AverageMonthlySpending != 100 {
+		t.Errorf("Expected net of 200 and average spending of 100 per debit and per month but got %v", response.Totals)
+	}
+	if len(response.ByMonth) != 3 {
+		t.Fatalf("Expected January to March but got %v", response.ByMonth)
+	}
+	january, february, march := response.ByMonth[0], response.ByMonth[1], response.ByMonth[2]
+	if january.IncomeChange != nil || january.SpendingChange != nil {
+		t.Errorf("Expected no change for the first month but got %v", january)
+	}
+	if february.Month != "2006-02" || february.TransactionCount != 0 || *february.SpendingChange != -200 ||
+		*february.SpendingChangePercent != -100 {
+		t.Errorf("Expected February without transactions, spending 200 (100%%) less, but got %v", february)
+	}
+	if *march.SpendingChange != 100 || *march.IncomeChange != 0 || march.SpendingChangePercent != nil {
+		t.Errorf("Expected March spending 100 more without percentage but got %v", march)
+	}
+}
+
+func TestCustomerAnalytics_ToDTO_groups_uncategorized_transactions_and_averages_by_type(t *testing.T) {
+	//Arrange
+	analytics := CustomerAnalytics{
+		Period:     getDefaultAnalyticsPeriod(),
+		ByCategory: []TransactionTotals{{Group: "", Spending: 2.5, SpendingCount: 1}},
+		ByType:     []TransactionTotals{{Group: dto.TransactionTypeWithdrawal, Spending: 100, SpendingCount: 3}},
+	}
+
+	//Act
+	response := analytics.ToDTO()
+
+	//Assert
+	if len(response.ByCategory) != 1 || response.ByCategory[0].Category != dto.AnalyticsUncategorized {
+		t.Errorf("Expected transactions without category to be uncategorized but got %v", response.ByCategory)
+	}
+	if len(response.ByType) != 1 || response.ByType[0].Total != 100 || response.ByType[0].AverageAmount != 33.33 {
+		t.Errorf("Expected withdrawals of 100 averaging 33.33 but got %v", response.ByType)
+	}
+	if response.TopTransactions == nil || len(response.TopTransactions) != 0 {
+		t.Errorf("Expected empty list of top transactions but got %v", response.TopTransactions)
+	}
+}
@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"database/sql"
+	"encoding/json"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"time"
+)
+
+//Business Domain
+
+// The operations that an admin can only carry out with the approval of a second admin.
+const ApprovalOperationTransaction = "transaction"
+const ApprovalOperationTransactionImport = "transaction_import"
+const ApprovalOperationTransactionReversal = "transaction_reversal"
+const ApprovalOperationFreezeAccounts = "freeze_accounts"
+const ApprovalOperationNewWebhookSubscription = "new_webhook_subscription"
+const ApprovalOperationDeleteWebhookSubscription = "delete_webhook_subscription"
+
+// ApprovalLifetime is how long an approval request waits for a second admin before it expires.
+const ApprovalLifetime = 24 * time.Hour
+
+// ApprovalRequest is an operation requested by one admin that is only carried out once a different admin approves it.
+type ApprovalRequest struct { //business/domain object
+	ApprovalId  string         `db:"approval_id"`
+	Operation   string         `db:"operation"`
+	Description string         `db:"description"` //what the operation does, shown to the approving admin
+	Payload     string         `db:"payload"`     //JSON input of the operation, never shown as it may hold secrets
+	Status      string         `db:"status"`
+	RequestedBy string         `db:"requested_by"` //username of the admin who requested the operation
+	RequestedOn string         `db:"requested_on"`
+	ExpiresOn   string         `db:"expires_on"`
+	DecidedBy   string         `db:"decided_by"` //empty while pending or if it expired
+	DecidedOn   sql.NullString `db:"decided_on"` //null while pending
+	Result      string         `db:"result"`     //JSON response of the operation, or its error if it failed
+}
+
+func NewApprovalRequest(operation string, description string, payload string, requestedBy string, c clock.Clock) ApprovalRequest {
+	return ApprovalRequest{
+		Operation:   operation,
+		Description: description,
+		Payload:     payload,
+		Status:      dto.ApprovalStatusPending,
+		RequestedBy: requestedBy,
+		RequestedOn: c.NowAsString(),
+		ExpiresOn:   c.Now().Add(ApprovalLifetime).Format(clock.FormatDateTime),
+	}
+}
+
+func (a ApprovalRequest) IsPending() bool {
+	return a.Status == dto.ApprovalStatusPending
+}
+
+// Decide returns the request as decided with the given status by the given admin at the current time.
+func (a ApprovalRequest) Decide(status string, decidedBy string, c clock.Clock) ApprovalRequest {
+	a.Status = status
+	a.DecidedBy = decidedBy
+	a.DecidedOn = sql.NullString{String: c.NowAsString(), Valid: true}
+	return a
+}
+
+// Complete returns the approved request as executed with the given response of the operation, or as failed if the
+// operation returned an error.
+func (a ApprovalRequest) Complete(response interface{}, appErr *errs.AppError) ApprovalRequest {
+	a.Status = dto.ApprovalStatusExecuted
+	if appErr != nil {
+		a.Status = dto.ApprovalStatusFailed
+		response = appErr.AsMessage()
+	}
+	if result, err := json.Marshal(response); err == nil {
+		a.Result = string(result)
+	}
+	return a
+}
+
+func (a ApprovalRequest) ToDTO() dto.ApprovalResponse {
+	response := dto.ApprovalResponse{
+		ApprovalId:  a.ApprovalId,
+		Operation:   a.Operation,
+		Description: a.Description,
+		Status:      a.Status,
+		RequestedBy: a.RequestedBy,
+		RequestedOn: a.RequestedOn,
+		ExpiresOn:   a.ExpiresOn,
+		DecidedBy:   a.DecidedBy,
+		DecidedOn:   a.DecidedOn.String,
+	}
+	if a.Result != "" {
+		response.Result = json.RawMessage(a.Result)
+	}
+	return response
+}
+
+//Server
+
+//go:generate mockgen -destination=../mocks/domain/mock_approvalRepository.go -package=domain github.com/aliciatay-zls/banking/backend/domain ApprovalRepository
+type ApprovalRepository interface { //repo (secondary port)
+	Save(ApprovalRequest) (*ApprovalRequest, *errs.AppError)
+	FindById(string) (*ApprovalRequest, *errs.AppError)
+	FindPending() ([]ApprovalRequest, *errs.AppError)
+	ExpirePending(string) *errs.AppError
+	UpdateStatus(ApprovalRequest, string) *errs.AppError
+}
@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/problem"
+	"github.com/jmoiron/sqlx"
+	"strconv"
+)
+
+//Server
+
+type ApprovalRepositoryDb struct { //DB (adapter)
+	client *sqlx.DB
+}
+
+func NewApprovalRepositoryDb(dbClient *sqlx.DB) ApprovalRepositoryDb {
+	return ApprovalRepositoryDb{dbClient}
+}
+
+// Save creates a new entry in the database for the given approval request and returns it with its database-generated
+// ID set.
+func (d ApprovalRepositoryDb) Save(a ApprovalRequest) (*ApprovalRequest, *errs.AppError) {
+	insertSql := "INSERT INTO approval_requests (operation, description, payload, status, requested_by, requested_on, expires_on, result) " +
+		"VALUES (?, ?, ?, ?, ?, ?, ?, ?)"
+	result, err := execInsert(d.client, insertSql, "approval_id",
+		a.Operation, a.Description, a.Payload, a.Status, a.RequestedBy, a.RequestedOn, a.ExpiresOn, a.Result)
+	if err != nil {
+		logger.Error("Error while creating new approval request: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		logger.Error("Error while getting id of newly inserted approval request: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	a.ApprovalId = strconv.FormatInt(id, 10)
+
+	return &a, nil
+}
+
+func (d ApprovalRepositoryDb) FindById(approvalId string) (*ApprovalRequest, *errs.AppError) {
+	var approval ApprovalRequest
+	findSql := d.selectApprovalsSql() + " WHERE approval_id = ?"
+	if err := d.client.Get(&approval, d.client.Rebind(findSql), approvalId); err != nil {
+		logger.Error("Error while retrieving approval request: " + err.Error())
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, problem.NewNotFoundError(problem.ApprovalNotFound, "Approval request not found")
+		}
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return &approval, nil
+}
+
+// FindPending retrieves the approval requests waiting for a second admin, oldest first.
+func (d ApprovalRepositoryDb) FindPending() ([]ApprovalRequest, *errs.AppError) {
+	approvals := make([]ApprovalRequest, 0)
+	findSql := d.selectApprovalsSql() + " WHERE status = ? ORDER BY approval_id"
+	if err := d.client.Select(&approvals, d.client.Rebind(findSql), dto.ApprovalStatusPending); err != nil {
+		logger.Error("Error while retrieving pending approval requests: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return approvals, nil
+}
+
+// ExpirePending marks the pending approval requests that expire at or before the given time as expired, so that they
+// can no longer be approved.
+func (d ApprovalRepositoryDb) ExpirePending(now string) *errs.AppError {
+	expireSql := "UPDATE approval_requests SET status = ?, decided_on = expires_on WHERE status = ? AND expires_on <= ?"
+	_, err := d.client.Exec(d.client.Rebind(expireSql), dto.ApprovalStatusExpired, dto.ApprovalStatusPending, now)
+	if err != nil {
+		logger.Error("Error while expiring approval requests: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
+
+// UpdateStatus sets the status, decision and result of the given approval request, provided that it is still in the
+// given status. This way, a request approved by two admins at once is only approved, and executed, by the first one.
+func (d ApprovalRepositoryDb) UpdateStatus(a ApprovalRequest, fromStatus string) *errs.AppError {
+	updateSql := "UPDATE approval_requests SET status = ?, decided_by = ?, decided_on = ?, result = ? " +
+		"WHERE approval_id = ? AND status = ?"
+	result, err := d.client.Exec(d.client.Rebind(updateSql),
+		a.Status, a.DecidedBy, a.DecidedOn, a.Result, a.ApprovalId, fromStatus)
+	if err != nil {
+		logger.Error("Error while updating approval request: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	if rowsAffected, err := result.RowsAffected(); err != nil || rowsAffected == 0 {
+		logger.Error("Error while updating approval request: request is no longer " + fromStatus)
+		return problem.NewConflictError(problem.ApprovalAlreadyDecided, "Approval request has already been decided")
+	}
+
+	return nil
+}
+
+func (d ApprovalRepositoryDb) selectApprovalsSql() string {
+	return "SELECT approval_id, operation, description, payload, status, requested_by, " +
+		dateTimeColumn(d.client.DriverName(), "requested_on") + ", " +
+		dateTimeColumn(d.client.DriverName(), "expires_on") + ", decided_by, " +
+		dateTimeColumn(d.client.DriverName(), "decided_on") + ", result FROM approval_requests"
+}
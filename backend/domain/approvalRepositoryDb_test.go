@@ -0,0 +1,148 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/jmoiron/sqlx"
+	"net/http"
+	"testing"
+)
+
+// Test common variables and inputs
+var approvalRepoDb ApprovalRepositoryDb
+
+const insertApprovalRequestsSql = "INSERT INTO approval_requests (operation, description, payload, status, requested_by, requested_on, expires_on, result) VALUES (?, ?, ?, ?, ?, ?, ?, ?)"
+const insertApprovalRequestsPostgresSql = "INSERT INTO approval_requests (operation, description, payload, status, requested_by, requested_on, expires_on, result) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING approval_id"
+const selectApprovalRequestByIdPostgresSql = "SELECT approval_id, operation, description, payload, status, requested_by, to_char(requested_on, 'YYYY-MM-DD HH24:MI:SS') AS requested_on, to_char(expires_on, 'YYYY-MM-DD HH24:MI:SS') AS expires_on, decided_by, to_char(decided_on, 'YYYY-MM-DD HH24:MI:SS') AS decided_on, result FROM approval_requests WHERE approval_id = $1"
+const expireApprovalRequestsSql = "UPDATE approval_requests SET status = ?, decided_on = expires_on WHERE status = ? AND expires_on <= ?"
+const updateApprovalRequestsSql = "UPDATE approval_requests SET status = ?, decided_by = ?, decided_on = ?, result = ? WHERE approval_id = ? AND status = ?"
+
+func setupApprovalRepoDbTest(t *testing.T, driverName string) func() {
+	teardown := setupDB(t)
+	approvalRepoDb = NewApprovalRepositoryDb(sqlx.NewDb(db, driverName))
+	return teardown
+}
+
+// getDefaultApprovalRequest returns a deposit of 6000 to the account with id 1977 requested by the admin "admin"
+func getDefaultApprovalRequest() ApprovalRequest {
+	return ApprovalRequest{
+		Operation:   ApprovalOperationTransaction,
+		Description: "deposit of 6000.00 on account 1977 of customer 2",
+		Payload:     `{"account_id":"1977","amount":6000,"transaction_type":"deposit","customer_id":"2"}`,
+		Status:      dto.ApprovalStatusPending,
+		RequestedBy: "admin",
+		RequestedOn: dummyDate,
+		ExpiresOn:   "2006-01-03 15:04:05",
+	}
+}
+
+func TestApprovalRepositoryDb_Save_returns_approvalRequest_with_newId(t *testing.T) {
+	tests := []struct {
+		driverName string
+		insertSql  string
+	}{
+		{DriverMySQL, insertApprovalRequestsSql},
+		{DriverPostgres, insertApprovalRequestsPostgresSql},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.driverName, func(t *testing.T) {
+			//Arrange
+			teardown := setupApprovalRepoDbTest(t, tc.driverName)
+			defer teardown()
+
+			a := getDefaultApprovalRequest()
+			expectInsert(tc.driverName, tc.insertSql, "approval_id", 3, a.Operation, a.Description, a.Payload, a.Status,
+				a.RequestedBy, a.RequestedOn, a.ExpiresOn, a.Result)
+
+			//Act
+			savedApproval, err := approvalRepoDb.Save(a)
+
+			//Assert
+			if err != nil {
+				t.Fatal("Expected no error but got error while testing successful saving of approval request: " + err.Message)
+			}
+			if savedApproval.ApprovalId != "3" {
+				t.Errorf("Expected approval id 3 but got %s", savedApproval.ApprovalId)
+			}
+		})
+	}
+}
+
+func TestApprovalRepositoryDb_FindById_returns_notFoundError_when_noRows(t *testing.T) {
+	//Arrange
+	teardown := setupApprovalRepoDbTest(t, DriverPostgres)
+	defer teardown()
+
+	mockDB.ExpectQuery(selectApprovalRequestByIdPostgresSql).WithArgs("3").WillReturnError(sql.ErrNoRows)
+	logger.MuteLogger()
+
+	//Act
+	_, err := approvalRepoDb.FindById("3")
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing retrieval of missing approval request")
+	}
+	if err.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d but got %d", http.StatusNotFound, err.Code)
+	}
+}
+
+func TestApprovalRepositoryDb_ExpirePending_returns_error_when_update_fails(t *testing.T) {
+	//Arrange
+	teardown := setupApprovalRepoDbTest(t, driverName)
+	defer teardown()
+
+	dummyDbErr := errors.New("some error message")
+	mockDB.ExpectExec(expireApprovalRequestsSql).
+		WithArgs(dto.ApprovalStatusExpired, dto.ApprovalStatusPending, dummyDate).
+		WillReturnError(dummyDbErr)
+
+	logs := logger.ReplaceWithTestLogger()
+	expectedLogMessage := "Error while expiring approval requests: " + dummyDbErr.Error()
+
+	//Act
+	err := approvalRepoDb.ExpirePending(dummyDate)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing failed expiry of approval requests")
+	}
+	if err.Message != defaultExpectedErrMessage {
+		t.Errorf("Expected error message to be \"%s\" but got \"%s\"", defaultExpectedErrMessage, err.Message)
+	}
+	if logs.Len() != 1 || logs.All()[0].Message != expectedLogMessage {
+		t.Errorf("Expected log message to be \"%s\" but got %v", expectedLogMessage, logs.All())
+	}
+}
+
+func TestApprovalRepositoryDb_UpdateStatus_returns_conflictError_when_request_no_longer_inStatus(t *testing.T) {
+	//Arrange
+	teardown := setupApprovalRepoDbTest(t, driverName)
+	defer teardown()
+
+	a := getDefaultApprovalRequest()
+	a.ApprovalId = "3"
+	a.Status = dto.ApprovalStatusApproved
+	a.DecidedBy = "admin2"
+	a.DecidedOn = sql.NullString{String: dummyDate, Valid: true}
+	mockDB.ExpectExec(updateApprovalRequestsSql).
+		WithArgs(a.Status, a.DecidedBy, a.DecidedOn, a.Result, a.ApprovalId, dto.ApprovalStatusPending).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	logger.MuteLogger()
+
+	//Act
+	err := approvalRepoDb.UpdateStatus(a, dto.ApprovalStatusPending)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing update of already decided approval request")
+	}
+	if err.Code != http.StatusConflict {
+		t.Errorf("Expected status code %d but got %d", http.StatusConflict, err.Code)
+	}
+}
@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"database/sql"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/problem"
+	"strconv"
+	"sync"
+)
+
+//Server
+
+type ApprovalRepositoryStub struct { //stub (adapter)
+	store *approvalStore //shared by all copies of the stub, so that changes made through one copy are seen by all
+}
+
+// approvalStore holds the approval requests of an ApprovalRepositoryStub in memory. It is safe for concurrent use.
+type approvalStore struct {
+	mu             sync.Mutex
+	approvals      []ApprovalRequest
+	nextApprovalId int64
+}
+
+func NewApprovalRepositoryStub() ApprovalRepositoryStub { //helper function to create and initialize a stub
+	return ApprovalRepositoryStub{&approvalStore{approvals: make([]ApprovalRequest, 0), nextApprovalId: 1}}
+}
+
+func (s ApprovalRepositoryStub) Save(a ApprovalRequest) (*ApprovalRequest, *errs.AppError) { //stub implements repo
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	a.ApprovalId = strconv.FormatInt(s.store.nextApprovalId, 10)
+	s.store.nextApprovalId++
+	s.store.approvals = append(s.store.approvals, a)
+
+	return &a, nil
+}
+
+func (s ApprovalRepositoryStub) FindById(approvalId string) (*ApprovalRequest, *errs.AppError) { //stub implements repo
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	for _, a := range s.store.approvals {
+		if a.ApprovalId == approvalId {
+			return &a, nil
+		}
+	}
+	logger.Error("Error while retrieving approval request using stub for ApprovalRepository: request not found")
+	return nil, problem.NewNotFoundError(problem.ApprovalNotFound, "Approval request not found")
+}
+
+func (s ApprovalRepositoryStub) FindPending() ([]ApprovalRequest, *errs.AppError) { //stub implements repo
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	approvals := make([]ApprovalRequest, 0)
+	for _, a := range s.store.approvals {
+		if a.IsPending() {
+			approvals = append(approvals, a)
+		}
+	}
+	return approvals, nil
+}
+
+func (s ApprovalRepositoryStub) ExpirePending(now string) *errs.AppError { //stub implements repo
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	for i, a := range s.store.approvals {
+		if a.IsPending() && a.ExpiresOn <= now {
+			s.store.approvals[i].Status = dto.ApprovalStatusExpired
+			s.store.approvals[i].DecidedOn = sql.NullString{String: a.ExpiresOn, Valid: true}
+		}
+	}
+	return nil
+}
+
+func (s ApprovalRepositoryStub) UpdateStatus(a ApprovalRequest, fromStatus string) *errs.AppError { //stub implements repo
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	for i, stored := range s.store.approvals {
+		if stored.ApprovalId != a.ApprovalId {
+			continue
+		}
+		if stored.Status != fromStatus {
+			break
+		}
+		s.store.approvals[i].Status = a.Status
+		s.store.approvals[i].DecidedBy = a.DecidedBy
+		s.store.approvals[i].DecidedOn = a.DecidedOn
+		s.store.approvals[i].Result = a.Result
+		return nil
+	}
+	logger.Error("Error while updating approval request using stub for ApprovalRepository: request is no longer " + fromStatus)
+	return problem.NewConflictError(problem.ApprovalAlreadyDecided, "Approval request has already been decided")
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"net/http"
+	"testing"
+)
+
+func TestApprovalRepositoryStub_ExpirePending_expires_pendingRequests_past_expiry_only(t *testing.T) {
+	//Arrange
+	stub := NewApprovalRepositoryStub()
+	expiring := getDefaultApprovalRequest()
+	expiring.ExpiresOn = dummyDate
+	later := getDefaultApprovalRequest()
+	for _, a := range []ApprovalRequest{expiring, later, expiring} {
+		stub.Save(a)
+	}
+	rejected := expiring.Decide(dto.ApprovalStatusRejected, "admin2", clock.StaticClock{})
+	rejected.ApprovalId = "3"
+	stub.UpdateStatus(rejected, dto.ApprovalStatusPending)
+
+	//Act
+	err := stub.ExpirePending(dummyDate)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing expiry of approval requests: " + err.Message)
+	}
+	pending, _ := stub.FindPending()
+	if len(pending) != 1 || pending[0].ApprovalId != "2" {
+		t.Errorf("Expected only request 2 to be pending but got %v", pending)
+	}
+	first, _ := stub.FindById("1")
+	third, _ := stub.FindById("3")
+	if first.Status != dto.ApprovalStatusExpired || first.DecidedOn.String != dummyDate || third.Status != dto.ApprovalStatusRejected {
+		t.Errorf("Expected request 1 expired and request 3 left rejected but got %v and %v", first, third)
+	}
+}
+
+func TestApprovalRepositoryStub_UpdateStatus_returns_conflictError_when_already_decided(t *testing.T) {
+	//Arrange
+	stub := NewApprovalRepositoryStub()
+	saved, _ := stub.Save(getDefaultApprovalRequest())
+	approved := saved.Decide(dto.ApprovalStatusApproved, "admin2", clock.StaticClock{})
+	logger.MuteLogger()
+
+	//Act
+	firstErr := stub.UpdateStatus(approved, dto.ApprovalStatusPending)
+	secondErr := stub.UpdateStatus(approved, dto.ApprovalStatusPending)
+
+	//Assert
+	if firstErr != nil {
+		t.Fatal("Expected no error but got error while testing first approval: " + firstErr.Message)
+	}
+	if secondErr == nil || secondErr.Code != http.StatusConflict {
+		t.Errorf("Expected conflict error for second approval but got %v", secondErr)
+	}
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"testing"
+)
+
+func TestNewApprovalRequest_is_pending_and_expires_after_approvalLifetime(t *testing.T) {
+	//Act
+	approval := NewApprovalRequest(ApprovalOperationFreezeAccounts, "freeze", "null", "admin", clock.StaticClock{})
+
+	//Assert
+	if !approval.IsPending() || approval.RequestedBy != "admin" || approval.RequestedOn != dummyDate {
+		t.Errorf("Expected request pending from admin on %s but got %v", dummyDate, approval)
+	}
+	if approval.ExpiresOn != "2006-01-03 15:04:05" {
+		t.Errorf("Expected request to expire a day later but got %s", approval.ExpiresOn)
+	}
+	if approval.DecidedOn.Valid || approval.ToDTO().Result != nil {
+		t.Errorf("Expected pending request not to be decided yet but got %v", approval)
+	}
+}
+
+func TestApprovalRequest_Complete_records_result_of_operation(t *testing.T) {
+	//Arrange
+	approved := ApprovalRequest{ApprovalId: "3", Status: dto.ApprovalStatusPending}.
+		Decide(dto.ApprovalStatusApproved, "admin2", clock.StaticClock{})
+
+	tests := []struct {
+		name           string
+		response       interface{}
+		appErr         *errs.AppError
+		expectedStatus string
+		expectedResult string
+	}{
+		{"executed", dto.TransactionResponse{TransactionId: "7791", Status: dto.TransactionStatusPosted},
+			nil, dto.ApprovalStatusExecuted, `{"transaction_id":"7791","status":"posted","new_balance":0,"transaction_date":""}`},
+		{"failed", nil, errs.NewValidationError("Account balance insufficient"),
+			dto.ApprovalStatusFailed, `{"message":"Account balance insufficient"}`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Act
+			completed := approved.Complete(tc.response, tc.appErr)
+
+			//Assert
+			response := completed.ToDTO()
+			if response.Status != tc.expectedStatus || string(response.Result) != tc.expectedResult {
+				t.Errorf("Expected status %s with result %s but got %s with %s",
+					tc.expectedStatus, tc.expectedResult, response.Status, response.Result)
+			}
+			if response.DecidedBy != "admin2" || response.DecidedOn != dummyDate {
+				t.Errorf("Expected decision by admin2 on %s to be kept but got %v", dummyDate, response)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"fmt"
+	"github.com/aliciatay-zls/banking/backend/dto"
+)
+
+//Business Domain
+
+// The kinds of records whose changes are kept in the audit trail.
+const AuditEntityPayee = "payee"
+
+const AuditActionAdded = "added"
+const AuditActionRenamed = "renamed"
+const AuditActionConfirmed = "confirmed"
+const AuditActionDeleted = "deleted"
+
+// AuditEntry records a change made to a record of a customer, by whom and when. Entries are written in the same
+// database transaction as the change they record and are never changed afterwards.
+type AuditEntry struct { //business/domain object
+	AuditId    string `db:"audit_id"`
+	CustomerId string `db:"customer_id"`
+	EntityType string `db:"entity_type"`
+	EntityId   string `db:"entity_id"`
+	Action     string `db:"action"`
+	Details    string `db:"details"`
+	ChangedBy  string `db:"changed_by"` //the user who made the change
+	ChangedOn  string `db:"changed_on"`
+}
+
+// NewPayeeAuditEntry records the given change to the given payee, whose ID must already be set.
+func NewPayeeAuditEntry(p Payee, action string, details string, changedBy string, changedOn string) AuditEntry {
+	return AuditEntry{
+		CustomerId: p.CustomerId,
+		EntityType: AuditEntityPayee,
+		EntityId:   p.PayeeId,
+		Action:     action,
+		Details:    details,
+		ChangedBy:  changedBy,
+		ChangedOn:  changedOn,
+	}
+}
+
+// payeeDetails describes the given payee in an audit entry.
+func payeeDetails(p Payee) string {
+	return fmt.Sprintf("%q (account %s)", p.Nickname, p.AccountId)
+}
+
+func (e AuditEntry) ToDTO() dto.AuditEntryResponse {
+	return dto.AuditEntryResponse{
+		AuditId:    e.AuditId,
+		EntityType: e.EntityType,
+		EntityId:   e.EntityId,
+		Action:     e.Action,
+		Details:    e.Details,
+		ChangedBy:  e.ChangedBy,
+		ChangedOn:  e.ChangedOn,
+	}
+}
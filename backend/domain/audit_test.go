@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"testing"
+)
+
+func TestNewPayeeAuditEntry_records_change_to_payee(t *testing.T) {
+	//Act
+	entry := NewPayeeAuditEntry(getDummyPayee(), AuditActionAdded, payeeDetails(getDummyPayee()), "2", dummyDate)
+
+	//Assert
+	expectedEntry := AuditEntry{CustomerId: "2", EntityType: AuditEntityPayee, EntityId: "3", Action: AuditActionAdded,
+		Details: "\"Mum\" (account 95470)", ChangedBy: "2", ChangedOn: dummyDate}
+	if entry != expectedEntry {
+		t.Errorf("Expected entry %v but got %v", expectedEntry, entry)
+	}
+	expectedResponse := dto.AuditEntryResponse{EntityType: AuditEntityPayee, EntityId: "3", Action: AuditActionAdded,
+		Details: "\"Mum\" (account 95470)", ChangedBy: "2", ChangedOn: dummyDate}
+	if response := entry.ToDTO(); response != expectedResponse {
+		t.Errorf("Expected response %v but got %v", expectedResponse, response)
+	}
+}
@@ -1,9 +1,11 @@
 package domain
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"github.com/aliciatay-zls/banking-lib/errs"
 	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/problem"
 	"net/http"
 	"net/url"
 	"os"
@@ -12,6 +14,27 @@ import (
 
 const AuthorizationHeaderPrefix = "Bearer "
 
+const AuthRoleAdmin = "admin"
+
+// AuthClaims identifies the client that a token was issued to by the auth server.
+type AuthClaims struct {
+	CustomerId string `json:"customer_id"` //empty for an admin
+	Username   string `json:"username"`
+	Role       string `json:"role"`
+}
+
+func (c AuthClaims) IsAdmin() bool {
+	return c.Role == AuthRoleAdmin
+}
+
+// authErrorCodes are the codes of the errors that the auth server responds with, by message. The auth server only
+// sends the message, which is one of those defined by errs.
+var authErrorCodes = map[string]string{
+	errs.MessageMissingToken:       problem.TokenMissing,
+	errs.MessageExpiredAccessToken: problem.TokenExpired,
+	errs.MessageInvalidAccessToken: problem.TokenInvalid,
+}
+
 //go:generate mockgen -destination=../mocks/domain/mock_authRepository.go -package=domain github.com/aliciatay-zls/banking/backend/domain AuthRepository
 type AuthRepository interface { //repo (secondary port)
 	IsAuthorized(string, string, map[string]string) *errs.AppError
@@ -42,13 +65,40 @@ func (r DefaultAuthRepository) IsAuthorized(tokenString string, routeName string
 			return errs.NewUnexpectedError("Internal server error")
 		}
 
-		logger.Error("Verification failed: " + responseData["message"])
-		return errs.NewAppError(response.StatusCode, responseData["message"])
+		message := responseData["message"]
+		logger.Error("Verification failed: " + message)
+		if code, ok := authErrorCodes[message]; ok {
+			return problem.NewAppError(code, response.StatusCode, message)
+		}
+		return errs.NewAppError(response.StatusCode, message)
 	}
 
 	return nil
 }
 
+// ParseAuthClaims reads the claims from the payload of the given token without checking its signature, so it must
+// only be used on a token that the auth server has already verified.
+func ParseAuthClaims(tokenString string) (*AuthClaims, *errs.AppError) {
+	parts := strings.Split(extractToken(tokenString), ".")
+	if len(parts) != 3 {
+		logger.Error("Error while parsing token claims: token is not made of 3 parts")
+		return nil, problem.NewAuthenticationError(problem.TokenInvalid, errs.MessageInvalidAccessToken)
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		logger.Error("Error while decoding token payload: " + err.Error())
+		return nil, problem.NewAuthenticationError(problem.TokenInvalid, errs.MessageInvalidAccessToken)
+	}
+	var claims AuthClaims
+	if err = json.Unmarshal(payload, &claims); err != nil {
+		logger.Error("Error while reading token claims: " + err.Error())
+		return nil, problem.NewAuthenticationError(problem.TokenInvalid, errs.MessageInvalidAccessToken)
+	}
+
+	return &claims, nil
+}
+
 // extractToken converts the value of the Authorization header from the form "Bearer <token>" to "<token>"
 func extractToken(tokenString string) string {
 	if strings.Contains(tokenString, AuthorizationHeaderPrefix) {
@@ -278,3 +278,35 @@ func Test_buildURL_returns_correctURL(t *testing.T) {
 		}
 	}
 }
+
+func TestParseAuthClaims_returns_claims_from_tokenPayload(t *testing.T) {
+	//Arrange
+	adminToken := "Bearer eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9." +
+		"eyJjdXN0b21lcl9pZCI6IiIsInVzZXJuYW1lIjoiYWRtaW4iLCJyb2xlIjoiYWRtaW4ifQ.signature"
+
+	//Act
+	claims, err := ParseAuthClaims(adminToken)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing parsing of token claims: " + err.Message)
+	}
+	if claims.Username != "admin" || !claims.IsAdmin() || claims.CustomerId != "" {
+		t.Errorf("Expected claims of admin \"admin\" but got %v", claims)
+	}
+}
+
+func TestParseAuthClaims_returns_error_when_tokenPayload_unreadable(t *testing.T) {
+	//Arrange
+	logger.MuteLogger()
+
+	for _, token := range []string{"not-a-token", dummyToken, "header.bm90IGpzb24.signature"} {
+		//Act
+		_, err := ParseAuthClaims(token)
+
+		//Assert
+		if err == nil || err.Code != http.StatusUnauthorized {
+			t.Errorf("Expected authentication error for token %s but got %v", token, err)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"github.com/aliciatay-zls/banking-lib/errs"
 	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/problem"
 	"github.com/jmoiron/sqlx"
 )
 
@@ -19,17 +20,24 @@ func NewCustomerRepositoryDb(dbClient *sqlx.DB) CustomerRepositoryDb { //helper
 	return CustomerRepositoryDb{dbClient}
 }
 
+// selectCustomersSql returns the query selecting every column of the customers table, to which a WHERE clause can be
+// appended.
+func (d CustomerRepositoryDb) selectCustomersSql() string {
+	return "SELECT customer_id, name, " + dateColumn(d.client.DriverName(), "date_of_birth") +
+		", email, country, zipcode, status FROM customers"
+}
+
 // FindAll retrieves from database all customers with the given status.
 func (d CustomerRepositoryDb) FindAll(status string) ([]Customer, *errs.AppError) { //DB implements repo
 	var err error
 	customers := make([]Customer, 0)
 
 	if status == "" {
-		findAllSql := "SELECT customer_id, name, date_of_birth, email, country, zipcode, status FROM customers"
+		findAllSql := d.selectCustomersSql()
 		err = d.client.Select(&customers, findAllSql)
 	} else {
-		findAllSql := "SELECT customer_id, name, date_of_birth, email, country, zipcode, status FROM customers WHERE status = ?"
-		err = d.client.Select(&customers, findAllSql, status)
+		findAllSql := d.selectCustomersSql() + " WHERE status = ?"
+		err = d.client.Select(&customers, d.client.Rebind(findAllSql), status)
 	}
 	if err != nil {
 		logger.Error("Error while querying/scanning customer table: " + err.Error())
@@ -42,12 +50,12 @@ func (d CustomerRepositoryDb) FindAll(status string) ([]Customer, *errs.AppError
 func (d CustomerRepositoryDb) FindById(id string) (*Customer, *errs.AppError) {
 	var c Customer
 
-	findCustomerSql := "SELECT customer_id, name, date_of_birth, email, country, zipcode, status FROM customers WHERE customer_id = ?"
-	err := d.client.Get(&c, findCustomerSql, id) // (**)
+	findCustomerSql := d.selectCustomersSql() + " WHERE customer_id = ?"
+	err := d.client.Get(&c, d.client.Rebind(findCustomerSql), id) // (**)
 	if err != nil {
 		logger.Error("Error while querying/scanning customer: " + err.Error())
 		if errors.Is(err, sql.ErrNoRows) { // (*)
-			return nil, errs.NewNotFoundError("Customer not found")
+			return nil, problem.NewNotFoundError(problem.CustomerNotFound, "Customer not found")
 		} else {
 			return nil, errs.NewUnexpectedError("Unexpected database error")
 		}
@@ -24,6 +24,21 @@ const selectAllCustomersSql = "SELECT  customer_id, name, date_of_birth, email,
 const selectSpecificCustomersSql = "SELECT  customer_id, name, date_of_birth, email, country, zipcode, status FROM customers WHERE status = ?"
 const selectCustomersSql = "SELECT  customer_id, name, date_of_birth, email, country, zipcode, status FROM customers WHERE customer_id = ?"
 
+const selectAllCustomersPostgresSql = "SELECT customer_id, name, to_char(date_of_birth, 'YYYY-MM-DD') AS date_of_birth, email, country, zipcode, status FROM customers"
+const selectSpecificCustomersPostgresSql = "SELECT customer_id, name, to_char(date_of_birth, 'YYYY-MM-DD') AS date_of_birth, email, country, zipcode, status FROM customers WHERE status = $1"
+const selectCustomersPostgresSql = "SELECT customer_id, name, to_char(date_of_birth, 'YYYY-MM-DD') AS date_of_birth, email, country, zipcode, status FROM customers WHERE customer_id = $1"
+
+// customerRepoDbDialects holds the SQL that the customer repository is expected to send for each supported driver.
+var customerRepoDbDialects = []struct {
+	driverName                 string
+	selectAllCustomersSql      string
+	selectSpecificCustomersSql string
+	selectCustomersSql         string
+}{
+	{DriverMySQL, selectAllCustomersSql, selectSpecificCustomersSql, selectCustomersSql},
+	{DriverPostgres, selectAllCustomersPostgresSql, selectSpecificCustomersPostgresSql, selectCustomersPostgresSql},
+}
+
 func setupDB(t *testing.T) func() {
 	var err error
 	db, mockDB, err = sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
@@ -37,6 +52,10 @@ func setupDB(t *testing.T) func() {
 }
 
 func setupCustomerRepositoryDbTest(t *testing.T) func() {
+	return setupCustomerRepositoryDbTestWithDriver(t, driverName)
+}
+
+func setupCustomerRepositoryDbTestWithDriver(t *testing.T, driverName string) func() {
 	teardown := setupDB(t)
 	cusRepoDb = NewCustomerRepositoryDb(sqlx.NewDb(db, driverName))
 	return teardown
@@ -73,58 +92,66 @@ func TestCustomerRepositoryDb_FindAll_returns_error_when_selectCustomers_fails(t
 }
 
 func TestCustomerRepositoryDb_FindAll_returns_all_customers_when_selectCustomers_succeed_and_status_empty(t *testing.T) {
-	//Arrange
-	teardown := setupCustomerRepositoryDbTest(t)
-	defer teardown()
-
-	dummyCustomers := getDefaultCustomers()
-	dummyRows := sqlmock.NewRows(customersTableColumns)
-	for _, v := range dummyCustomers {
-		dummyRows.AddRow(v.Id, v.Name, v.DateOfBirth, v.Email, v.Country, v.Zipcode, v.Status)
-	}
-	mockDB.ExpectQuery(selectAllCustomersSql).WillReturnRows(dummyRows)
+	for _, dialect := range customerRepoDbDialects {
+		t.Run(dialect.driverName, func(t *testing.T) {
+			//Arrange
+			teardown := setupCustomerRepositoryDbTestWithDriver(t, dialect.driverName)
+			defer teardown()
+
+			dummyCustomers := getDefaultCustomers()
+			dummyRows := sqlmock.NewRows(customersTableColumns)
+			for _, v := range dummyCustomers {
+				dummyRows.AddRow(v.Id, v.Name, v.DateOfBirth, v.Email, v.Country, v.Zipcode, v.Status)
+			}
+			mockDB.ExpectQuery(dialect.selectAllCustomersSql).WillReturnRows(dummyRows)
 
-	//Act
-	actualCustomers, err := cusRepoDb.FindAll(dummyStatus)
+			//Act
+			actualCustomers, err := cusRepoDb.FindAll(dummyStatus)
 
-	//Assert
-	if err != nil {
-		t.Errorf("Expected no error but got error while testing finding all customers successfully: " + err.Message)
-	}
-	if len(actualCustomers) != len(dummyCustomers) {
-		t.Fatalf("Expected %d customers to be returned but got %d customers", len(dummyCustomers), len(actualCustomers))
-	}
-	for k, v := range dummyCustomers {
-		if actualCustomers[k] != v {
-			t.Errorf("Expected customer %v but got %v", v, actualCustomers[k])
-		}
+			//Assert
+			if err != nil {
+				t.Errorf("Expected no error but got error while testing finding all customers successfully: " + err.Message)
+			}
+			if len(actualCustomers) != len(dummyCustomers) {
+				t.Fatalf("Expected %d customers to be returned but got %d customers", len(dummyCustomers), len(actualCustomers))
+			}
+			for k, v := range dummyCustomers {
+				if actualCustomers[k] != v {
+					t.Errorf("Expected customer %v but got %v", v, actualCustomers[k])
+				}
+			}
+		})
 	}
 }
 
 func TestCustomerRepositoryDb_FindAll_returns_specific_customers_when_selectCustomers_succeed_and_status_nonEmpty(t *testing.T) {
-	//Arrange
-	teardown := setupCustomerRepositoryDbTest(t)
-	defer teardown()
-
-	dummyActiveCustomer := getDefaultCustomers()[0]
-	dummyRows := sqlmock.NewRows(customersTableColumns).
-		AddRow(dummyActiveCustomer.Id, dummyActiveCustomer.Name, dummyActiveCustomer.DateOfBirth, dummyActiveCustomer.Email, dummyActiveCustomer.Country, dummyActiveCustomer.Zipcode, dummyActiveCustomer.Status)
-	mockDB.ExpectQuery(selectSpecificCustomersSql).
-		WithArgs("1").
-		WillReturnRows(dummyRows)
+	for _, dialect := range customerRepoDbDialects {
+		t.Run(dialect.driverName, func(t *testing.T) {
+			//Arrange
+			teardown := setupCustomerRepositoryDbTestWithDriver(t, dialect.driverName)
+			defer teardown()
+
+			dummyActiveCustomer := getDefaultCustomers()[0]
+			dummyRows := sqlmock.NewRows(customersTableColumns).
+				AddRow(dummyActiveCustomer.Id, dummyActiveCustomer.Name, dummyActiveCustomer.DateOfBirth, dummyActiveCustomer.Email, dummyActiveCustomer.Country, dummyActiveCustomer.Zipcode, dummyActiveCustomer.Status)
+			mockDB.ExpectQuery(dialect.selectSpecificCustomersSql).
+				WithArgs("1").
+				WillReturnRows(dummyRows)
 
-	//Act
-	actualCustomers, err := cusRepoDb.FindAll("1")
+			//Act
+			actualCustomers, err := cusRepoDb.FindAll("1")
 
-	//Assert
-	if err != nil {
-		t.Errorf("Expected no error but got error while testing finding all customers successfully: " + err.Message)
-	}
-	if len(actualCustomers) != 1 {
-		t.Fatalf("Expected 1 active customer to be returned but got %d customers", len(actualCustomers))
-	}
-	if actualCustomers[0] != dummyActiveCustomer {
-		t.Errorf("Expected customer %v but got %v", dummyActiveCustomer, actualCustomers[0])
+			//Assert
+			if err != nil {
+				t.Errorf("Expected no error but got error while testing finding all customers successfully: " + err.Message)
+			}
+			if len(actualCustomers) != 1 {
+				t.Fatalf("Expected 1 active customer to be returned but got %d customers", len(actualCustomers))
+			}
+			if actualCustomers[0] != dummyActiveCustomer {
+				t.Errorf("Expected customer %v but got %v", dummyActiveCustomer, actualCustomers[0])
+			}
+		})
 	}
 }
 
@@ -174,25 +201,29 @@ func TestCustomerRepositoryDb_FindById_returns_error_when_selectCustomers_fails(
 }
 
 func TestCustomerRepositoryDb_FindById_returns_customer_when_selectCustomers_succeeds(t *testing.T) {
-	//Arrange
-	teardown := setupCustomerRepositoryDbTest(t)
-	defer teardown()
-
-	dummyCustomer := getDefaultCustomers()[1]
-	dummyRows := sqlmock.NewRows(customersTableColumns).
-		AddRow(dummyCustomer.Id, dummyCustomer.Name, dummyCustomer.DateOfBirth, dummyCustomer.Email, dummyCustomer.Country, dummyCustomer.Zipcode, dummyCustomer.Status)
-	mockDB.ExpectQuery(selectCustomersSql).
-		WithArgs(dummyCustomer.Id).
-		WillReturnRows(dummyRows)
+	for _, dialect := range customerRepoDbDialects {
+		t.Run(dialect.driverName, func(t *testing.T) {
+			//Arrange
+			teardown := setupCustomerRepositoryDbTestWithDriver(t, dialect.driverName)
+			defer teardown()
+
+			dummyCustomer := getDefaultCustomers()[1]
+			dummyRows := sqlmock.NewRows(customersTableColumns).
+				AddRow(dummyCustomer.Id, dummyCustomer.Name, dummyCustomer.DateOfBirth, dummyCustomer.Email, dummyCustomer.Country, dummyCustomer.Zipcode, dummyCustomer.Status)
+			mockDB.ExpectQuery(dialect.selectCustomersSql).
+				WithArgs(dummyCustomer.Id).
+				WillReturnRows(dummyRows)
 
-	//Act
-	actualCustomer, err := cusRepoDb.FindById(dummyCustomer.Id)
+			//Act
+			actualCustomer, err := cusRepoDb.FindById(dummyCustomer.Id)
 
-	//Assert
-	if err != nil {
-		t.Fatal("Expected no error but got error while testing finding all customers successfully: " + err.Message)
-	}
-	if *actualCustomer != dummyCustomer {
-		t.Errorf("Expected customer %v but got %v", dummyCustomer, *actualCustomer)
+			//Assert
+			if err != nil {
+				t.Fatal("Expected no error but got error while testing finding all customers successfully: " + err.Message)
+			}
+			if *actualCustomer != dummyCustomer {
+				t.Errorf("Expected customer %v but got %v", dummyCustomer, *actualCustomer)
+			}
+		})
 	}
 }
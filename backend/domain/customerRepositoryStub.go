@@ -3,6 +3,7 @@ package domain
 import (
 	"github.com/aliciatay-zls/banking-lib/errs"
 	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/problem"
 )
 
 //Server
@@ -30,5 +31,5 @@ func (s CustomerRepositoryStub) FindById(id string) (*Customer, *errs.AppError)
 		}
 	}
 	logger.Error("Error while finding customer by id using stub for CustomerRepository: not found")
-	return nil, errs.NewNotFoundError("Customer not found")
+	return nil, problem.NewNotFoundError(problem.CustomerNotFound, "Customer not found")
 }
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+	"strings"
+)
+
+//Server
+
+// Names of the database drivers that the DB adapters support. The SQL in the adapters is written for MySQL with ?
+// placeholders and rebound for the driver in use.
+const DriverMySQL = "mysql"
+const DriverPostgres = "pgx"  //github.com/jackc/pgx/v5/stdlib
+const DriverSQLite = "sqlite" //modernc.org/sqlite, dates are stored as TEXT in the same format as MySQL returns them
+
+// execInsert runs the given insert statement and returns a result from which the database-generated ID can be read.
+// Postgres does not support LastInsertId, so on Postgres the ID is read from a RETURNING clause for idColumn instead.
+func execInsert(e sqlx.Ext, insertSql string, idColumn string, args ...interface{}) (sql.Result, error) {
+	if e.DriverName() != DriverPostgres {
+		return e.Exec(e.Rebind(insertSql), args...)
+	}
+
+	var id int64
+	if err := e.QueryRowx(e.Rebind(insertSql+" RETURNING "+idColumn), args...).Scan(&id); err != nil {
+		return nil, err
+	}
+	return returnedIdResult(id), nil
+}
+
+// isUniqueViolation reports whether the given error is a violation of a unique key, in any of the databases supported.
+func isUniqueViolation(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	var pgErr *pgconn.PgError
+	var sqliteErr *sqlite.Error
+	switch {
+	case errors.As(err, &mysqlErr):
+		return mysqlErr.Number == 1062 //ER_DUP_ENTRY
+	case errors.As(err, &pgErr):
+		return pgErr.Code == "23505" //unique_violation
+	case errors.As(err, &sqliteErr):
+		return sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE
+	}
+	return false
+}
+
+// returnedIdResult is the result of an insert of one row whose ID was read from a RETURNING clause.
+type returnedIdResult int64
+
+func (r returnedIdResult) LastInsertId() (int64, error) {
+	return int64(r), nil
+}
+
+func (r returnedIdResult) RowsAffected() (int64, error) {
+	return 1, nil
+}
+
+// dateTimeColumn returns the select expression that reads the given datetime column as a "2006-01-02 15:04:05"
+// string, which is how MySQL returns it. Postgres returns a time.Time instead, which would otherwise be scanned into
+// a string in RFC 3339 format.
+func dateTimeColumn(driverName string, column string) string {
+	return formattedColumn(driverName, column, "YYYY-MM-DD HH24:MI:SS")
+}
+
+// dateColumn returns the select expression that reads the given date column as a "2006-01-02" string.
+func dateColumn(driverName string, column string) string {
+	return formattedColumn(driverName, column, "YYYY-MM-DD")
+}
+
+func formattedColumn(driverName string, column string, pgFormat string) string {
+	if driverName != DriverPostgres {
+		return column
+	}
+	alias := column[strings.LastIndex(column, ".")+1:]
+	return fmt.Sprintf("to_char(%s, '%s') AS %s", column, pgFormat, alias)
+}
+
+// monthExpression returns the expression that reads the given datetime column as the month it is in, as a "2006-01"
+// string, e.g. to group by.
+func monthExpression(driverName string, column string) string {
+	switch driverName {
+	case DriverPostgres:
+		return fmt.Sprintf("to_char(%s, 'YYYY-MM')", column)
+	case DriverSQLite:
+		return fmt.Sprintf("substr(%s, 1, 7)", column)
+	}
+	return fmt.Sprintf("DATE_FORMAT(%s, '%%Y-%%m')", column)
+}
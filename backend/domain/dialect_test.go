@@ -0,0 +1,160 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+	"testing"
+)
+
+// expectInsert sets up the mock database to expect the given insert, which should already be in the SQL of the given
+// driver, and to return the given database-generated id.
+func expectInsert(driverName string, insertSql string, idColumn string, id int64, args ...driver.Value) {
+	if driverName == DriverPostgres {
+		mockDB.ExpectQuery(insertSql).
+			WithArgs(args...).
+			WillReturnRows(sqlmock.NewRows([]string{idColumn}).AddRow(id))
+		return
+	}
+	mockDB.ExpectExec(insertSql).
+		WithArgs(args...).
+		WillReturnResult(sqlmock.NewResult(id, 1))
+}
+
+func Test_execInsert_returns_insertId_for_every_driver(t *testing.T) {
+	tests := []struct {
+		driverName  string
+		expectedSql string
+	}{
+		{DriverMySQL, "INSERT INTO a (b) VALUES (?)"},
+		{DriverPostgres, "INSERT INTO a (b) VALUES ($1) RETURNING a_id"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.driverName, func(t *testing.T) {
+			//Arrange
+			teardown := setupDB(t)
+			defer teardown()
+
+			expectInsert(tc.driverName, tc.expectedSql, "a_id", 7, "c")
+
+			//Act
+			result, err := execInsert(sqlx.NewDb(db, tc.driverName), "INSERT INTO a (b) VALUES (?)", "a_id", "c")
+
+			//Assert
+			if err != nil {
+				t.Fatal("Expected no error but got error while testing successful insert: " + err.Error())
+			}
+			if id, _ := result.LastInsertId(); id != 7 {
+				t.Errorf("Expected insert id 7 but got %d", id)
+			}
+			if rowsAffected, _ := result.RowsAffected(); rowsAffected != 1 {
+				t.Errorf("Expected 1 row affected but got %d", rowsAffected)
+			}
+		})
+	}
+}
+
+func Test_execInsert_returns_error_when_insertReturning_fails(t *testing.T) {
+	//Arrange
+	teardown := setupDB(t)
+	defer teardown()
+
+	dummyDbErr := errors.New("some error message")
+	mockDB.ExpectQuery("INSERT INTO a (b) VALUES ($1) RETURNING a_id").WithArgs("c").WillReturnError(dummyDbErr)
+
+	//Act
+	_, err := execInsert(sqlx.NewDb(db, DriverPostgres), "INSERT INTO a (b) VALUES (?)", "a_id", "c")
+
+	//Assert
+	if !errors.Is(err, dummyDbErr) {
+		t.Errorf("Expected error \"%v\" but got \"%v\"", dummyDbErr, err)
+	}
+}
+
+func Test_dateTimeColumn_and_dateColumn_return_selectExpression_for_every_driver(t *testing.T) {
+	tests := []struct {
+		name         string
+		actualExpr   string
+		expectedExpr string
+	}{
+		{"datetime on mysql", dateTimeColumn(DriverMySQL, "a.opening_date"), "a.opening_date"},
+		{"datetime on postgres", dateTimeColumn(DriverPostgres, "a.opening_date"),
+			"to_char(a.opening_date, 'YYYY-MM-DD HH24:MI:SS') AS opening_date"},
+		{"date on mysql", dateColumn(DriverMySQL, "date_of_birth"), "date_of_birth"},
+		{"date on postgres", dateColumn(DriverPostgres, "date_of_birth"),
+			"to_char(date_of_birth, 'YYYY-MM-DD') AS date_of_birth"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Assert
+			if tc.actualExpr != tc.expectedExpr {
+				t.Errorf("Expected \"%s\" but got \"%s\"", tc.expectedExpr, tc.actualExpr)
+			}
+		})
+	}
+}
+
+func Test_monthExpression_returns_groupExpression_for_every_driver(t *testing.T) {
+	tests := []struct {
+		driverName   string
+		expectedExpr string
+	}{
+		{DriverMySQL, "DATE_FORMAT(transaction_date, '%Y-%m')"},
+		{DriverPostgres, "to_char(transaction_date, 'YYYY-MM')"},
+		{DriverSQLite, "substr(transaction_date, 1, 7)"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.driverName, func(t *testing.T) {
+			//Act
+			actualExpr := monthExpression(tc.driverName, "transaction_date")
+
+			//Assert
+			if actualExpr != tc.expectedExpr {
+				t.Errorf("Expected \"%s\" but got \"%s\"", tc.expectedExpr, actualExpr)
+			}
+		})
+	}
+}
+
+func Test_isUniqueViolation_recognizes_duplicateKey_of_every_driver(t *testing.T) {
+	//Arrange
+	sqliteDb, err := sqlx.Open(DriverSQLite, ":memory:")
+	if err != nil {
+		t.Fatal("error while setting up test: " + err.Error())
+	}
+	defer sqliteDb.Close()
+	sqliteDb.MustExec("CREATE TABLE t (k TEXT NOT NULL UNIQUE)")
+	sqliteDb.MustExec("INSERT INTO t (k) VALUES ('a')")
+	_, sqliteErr := sqliteDb.Exec("INSERT INTO t (k) VALUES ('a')")
+
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{DriverMySQL, &mysql.MySQLError{Number: 1062}, true},
+		{DriverPostgres, fmt.Errorf("wrapped: %w", &pgconn.PgError{Code: "23505"}), true},
+		{DriverSQLite, sqliteErr, true},
+		{"other mysql error", &mysql.MySQLError{Number: 1146}, false},
+		{"other error", errors.New("some error message"), false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Act
+			actual := isUniqueViolation(tc.err)
+
+			//Assert
+			if actual != tc.expected {
+				t.Errorf("Expected %v but got %v for %v", tc.expected, actual, tc.err)
+			}
+		})
+	}
+}
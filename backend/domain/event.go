@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"encoding/json"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/dto"
+)
+
+//Business Domain
+
+const EventTypeAccountOpened = "AccountOpened"
+const EventTypeTransactionPosted = "TransactionPosted"
+const EventTypeAccountStatusChanged = "AccountStatusChanged"
+
+// Event is something that happened to an account that other systems may want to react to. Events are written to the
+// outbox table in the same database transaction as the change they describe, and published from there afterwards.
+type Event struct { //business/domain object
+	EventId   string `db:"event_id"`
+	AccountId string `db:"account_id"`
+	EventType string `db:"event_type"`
+	Payload   string `db:"payload"` //JSON
+	CreatedOn string `db:"created_on"`
+}
+
+func NewAccountOpenedEvent(a Account) Event {
+	return newEvent(a.AccountId, EventTypeAccountOpened, a.OpeningDate, dto.AccountOpenedPayload{
+		AccountId:   a.AccountId,
+		CustomerId:  a.CustomerId,
+		AccountType: a.AccountType,
+		Amount:      a.Amount,
+		OpeningDate: a.OpeningDate,
+	})
+}
+
+// NewTransactionPostedEvent creates the event for the given bank transaction, whose ID and resulting balance must
+// already be set.
+func NewTransactionPostedEvent(t Transaction) Event {
+	return newEvent(t.AccountId, EventTypeTransactionPosted, t.TransactionDate, dto.TransactionPostedPayload{
+		TransactionId:   t.TransactionId,
+		AccountId:       t.AccountId,
+		TransactionType: t.TransactionType,
+		Amount:          t.Amount,
+		Balance:         t.Balance,
+		TransactionDate: t.TransactionDate,
+	})
+}
+
+func NewAccountStatusChangedEvent(accountId string, status string, reason string, changedOn string) Event {
+	return newEvent(accountId, EventTypeAccountStatusChanged, changedOn, dto.AccountStatusChangedPayload{
+		AccountId: accountId,
+		Status:    AccountStatusName(status),
+		Reason:    reason,
+	})
+}
+
+func newEvent(accountId string, eventType string, createdOn string, payload interface{}) Event {
+	payloadJson, _ := json.Marshal(payload) //cannot fail for the payload structs
+	return Event{
+		AccountId: accountId,
+		EventType: eventType,
+		Payload:   string(payloadJson),
+		CreatedOn: createdOn,
+	}
+}
+
+func (e Event) ToDTO() dto.EventMessage {
+	return dto.EventMessage{
+		EventId:   e.EventId,
+		EventType: e.EventType,
+		AccountId: e.AccountId,
+		CreatedOn: e.CreatedOn,
+		Payload:   json.RawMessage(e.Payload),
+	}
+}
+
+//Server
+
+//go:generate mockgen -destination=../mocks/domain/mock_outboxRepository.go -package=domain github.com/aliciatay-zls/banking/backend/domain OutboxRepository
+type OutboxRepository interface { //repo (secondary port)
+	FindUnpublished(int) ([]Event, *errs.AppError)
+	MarkPublished(string, string) *errs.AppError
+}
+
+//go:generate mockgen -destination=../mocks/domain/mock_eventPublisher.go -package=domain github.com/aliciatay-zls/banking/backend/domain EventPublisher
+type EventPublisher interface { //secondary port
+	Publish(Event) *errs.AppError
+}
@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"encoding/json"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"os"
+)
+
+//Server
+
+type LogEventPublisher struct{} //log (adapter)
+
+func NewLogEventPublisher() LogEventPublisher {
+	return LogEventPublisher{}
+}
+
+// Publish logs the event as a JSON message.
+func (p LogEventPublisher) Publish(event Event) *errs.AppError { //log implements publisher
+	message, err := json.Marshal(event.ToDTO())
+	if err != nil {
+		logger.Error("Error while marshalling event: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected event publishing error")
+	}
+
+	logger.Info("Event published: " + string(message))
+	return nil
+}
+
+type FileEventPublisher struct { //file (adapter)
+	path string
+}
+
+func NewFileEventPublisher(path string) FileEventPublisher {
+	return FileEventPublisher{path}
+}
+
+// Publish appends the event as a line of JSON to the file, creating the file if it does not exist yet.
+func (p FileEventPublisher) Publish(event Event) *errs.AppError { //file implements publisher
+	message, err := json.Marshal(event.ToDTO())
+	if err != nil {
+		logger.Error("Error while marshalling event: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected event publishing error")
+	}
+
+	file, err := os.OpenFile(p.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		logger.Error("Error while opening event file: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected event publishing error")
+	}
+	defer file.Close()
+
+	if _, err = file.Write(append(message, '\n')); err != nil {
+		logger.Error("Error while writing event to file: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected event publishing error")
+	}
+	return nil
+}
+
+type ChannelEventPublisher struct { //in-process channel (adapter)
+	events chan Event
+}
+
+// NewChannelEventPublisher creates a publisher that buffers up to size events for in-process consumers such as tests.
+func NewChannelEventPublisher(size int) ChannelEventPublisher {
+	return ChannelEventPublisher{make(chan Event, size)}
+}
+
+// Publish sends the event on the channel without blocking, failing if the buffer is full so that the event is retried
+// later instead of holding up the relay.
+func (p ChannelEventPublisher) Publish(event Event) *errs.AppError { //channel implements publisher
+	select {
+	case p.events <- event:
+		return nil
+	default:
+		logger.Error("Error while publishing event to channel: buffer full")
+		return errs.NewUnexpectedError("Unexpected event publishing error")
+	}
+}
+
+// Events returns the channel on which published events are received.
+func (p ChannelEventPublisher) Events() <-chan Event {
+	return p.events
+}
+
+type WebhookEventPublisher struct { //webhook (adapter)
+	repo WebhookRepository
+	clk  clock.Clock
+}
+
+func NewWebhookEventPublisher(repo WebhookRepository, clk clock.Clock) WebhookEventPublisher {
+	return WebhookEventPublisher{repo, clk}
+}
+
+// Publish enqueues a delivery of the event for every webhook subscribed to it. The deliveries are then made, and
+// retried, separately from the outbox relay so that a slow or failing receiver does not hold up other events.
+func (p WebhookEventPublisher) Publish(event Event) *errs.AppError { //webhook implements publisher
+	return p.repo.EnqueueDeliveries(event, p.clk.NowAsString())
+}
+
+type MultiEventPublisher struct {
+	publishers []EventPublisher
+}
+
+func NewMultiEventPublisher(publishers ...EventPublisher) MultiEventPublisher {
+	return MultiEventPublisher{publishers}
+}
+
+// Publish publishes the event with every publisher in turn, stopping at the first that fails. The event is then
+// published again with all of them, which the at-least-once delivery of events already allows for.
+func (p MultiEventPublisher) Publish(event Event) *errs.AppError {
+	for _, publisher := range p.publishers {
+		if appErr := publisher.Publish(event); appErr != nil {
+			return appErr
+		}
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"bufio"
+	"encoding/json"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileEventPublisher_Publish_appends_oneJsonLine_per_event(t *testing.T) {
+	//Arrange
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	publisher := NewFileEventPublisher(path)
+	events := []Event{
+		NewAccountOpenedEvent(getDefaultAccountAfterSave()),
+		NewTransactionPostedEvent(getDefaultTransactionAfterTransact()),
+	}
+
+	//Act
+	for _, event := range events {
+		if err := publisher.Publish(event); err != nil {
+			t.Fatal("Expected no error but got error while publishing event to file: " + err.Message)
+		}
+	}
+
+	//Assert
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal("Expected event file to be created but got error while opening it: " + err.Error())
+	}
+	defer file.Close()
+	var actualEventTypes []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var message dto.EventMessage
+		if err = json.Unmarshal(scanner.Bytes(), &message); err != nil {
+			t.Fatal("Expected a JSON event per line but got error while decoding line: " + err.Error())
+		}
+		actualEventTypes = append(actualEventTypes, message.EventType)
+	}
+	if len(actualEventTypes) != 2 || actualEventTypes[0] != EventTypeAccountOpened || actualEventTypes[1] != EventTypeTransactionPosted {
+		t.Errorf("Expected AccountOpened then TransactionPosted events but got %v", actualEventTypes)
+	}
+}
+
+func TestFileEventPublisher_Publish_returns_error_when_file_cannotBeOpened(t *testing.T) {
+	//Arrange
+	publisher := NewFileEventPublisher(filepath.Join(t.TempDir(), "missing", "events.jsonl"))
+	logger.MuteLogger()
+
+	//Act
+	err := publisher.Publish(NewAccountOpenedEvent(getDefaultAccountAfterSave()))
+
+	//Assert
+	if err == nil {
+		t.Error("Expected error but got none while testing publishing to a file in a non-existent directory")
+	}
+}
+
+func TestChannelEventPublisher_Publish_returns_error_when_buffer_full(t *testing.T) {
+	//Arrange
+	publisher := NewChannelEventPublisher(1)
+	event := NewAccountOpenedEvent(getDefaultAccountAfterSave())
+	logger.MuteLogger()
+
+	//Act
+	firstErr := publisher.Publish(event)
+	secondErr := publisher.Publish(event)
+
+	//Assert
+	if firstErr != nil {
+		t.Fatal("Expected no error but got error while publishing to channel with free buffer: " + firstErr.Message)
+	}
+	if secondErr == nil {
+		t.Error("Expected error but got none while testing publishing to channel with full buffer")
+	}
+	if actualEvent := <-publisher.Events(); actualEvent != event {
+		t.Errorf("Expected event %v on channel but got %v", event, actualEvent)
+	}
+}
+
+func TestMultiEventPublisher_Publish_stops_at_firstFailedPublisher(t *testing.T) {
+	//Arrange
+	first := NewChannelEventPublisher(1)
+	full := NewChannelEventPublisher(0)
+	last := NewChannelEventPublisher(1)
+	publisher := NewMultiEventPublisher(first, full, last)
+	event := NewAccountOpenedEvent(getDefaultAccountAfterSave())
+	logger.MuteLogger()
+
+	//Act
+	err := publisher.Publish(event)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing publishing with a failing publisher")
+	}
+	if len(first.Events()) != 1 || len(last.Events()) != 0 {
+		t.Errorf("Expected event to be published by the first publisher only but got %d and %d events",
+			len(first.Events()), len(last.Events()))
+	}
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"encoding/json"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"testing"
+)
+
+func TestNewTransactionPostedEvent_returns_event_with_transactionAsPayload(t *testing.T) {
+	//Arrange
+	dummyTransaction := getDefaultTransactionAfterTransact()
+	expectedPayload := dto.TransactionPostedPayload{
+		TransactionId:   dummyTransactionId,
+		AccountId:       dummyAccountId,
+		TransactionType: dummyTransactionType,
+		Amount:          dummyAmount,
+		Balance:         dummyBalance,
+		TransactionDate: dummyDate,
+	}
+
+	//Act
+	event := NewTransactionPostedEvent(dummyTransaction)
+
+	//Assert
+	if event.AccountId != dummyAccountId || event.EventType != EventTypeTransactionPosted || event.CreatedOn != dummyDate {
+		t.Errorf("Expected TransactionPosted event for account %s created on %s but got %v", dummyAccountId, dummyDate, event)
+	}
+	var actualPayload dto.TransactionPostedPayload
+	if err := json.Unmarshal([]byte(event.Payload), &actualPayload); err != nil {
+		t.Fatal("Expected JSON payload but got error while decoding it: " + err.Error())
+	}
+	if actualPayload != expectedPayload {
+		t.Errorf("Expected payload %v but got %v", expectedPayload, actualPayload)
+	}
+}
+
+func TestNewAccountStatusChangedEvent_returns_event_with_statusName(t *testing.T) {
+	//Arrange
+	expectedPayload := `{"account_id":"1977","status":"frozen","reason":"` + FreezeReasonReconciliation + `"}`
+
+	//Act
+	event := NewAccountStatusChangedEvent(dummyAccountId, AccountStatusFrozen, FreezeReasonReconciliation, dummyDate)
+
+	//Assert
+	if event.EventType != EventTypeAccountStatusChanged || event.Payload != expectedPayload {
+		t.Errorf("Expected AccountStatusChanged event with payload %s but got %v", expectedPayload, event)
+	}
+}
+
+func TestEvent_ToDTO_keeps_payload_as_rawJson(t *testing.T) {
+	//Arrange
+	event := NewAccountOpenedEvent(getDefaultAccountAfterSave())
+	event.EventId = dummyEventId
+
+	//Act
+	message, err := json.Marshal(event.ToDTO())
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while marshalling event message: " + err.Error())
+	}
+	expectedMessage := `{"event_id":"31","event_type":"AccountOpened","account_id":"1977","created_on":"` + dummyDate +
+		`","payload":` + event.Payload + `}`
+	if string(message) != expectedMessage {
+		t.Errorf("Expected message %s but got %s", expectedMessage, string(message))
+	}
+}
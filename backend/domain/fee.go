@@ -0,0 +1,223 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"time"
+)
+
+//Business Domain
+
+// formatPeriod is the format of a calendar month, such as a fee period.
+const formatPeriod = "2006-01"
+
+// formatDate is the format of the date that a fee schedule takes effect on.
+const formatDate = "2006-01-02"
+
+// FeeSchedule is the fees charged on the accounts of one account type, from the date it takes effect until the next
+// schedule for that account type takes effect.
+type FeeSchedule struct { //business/domain object
+	ScheduleId      string  `db:"schedule_id"`
+	AccountType     string  `db:"account_type"`
+	MonthlyFee      float64 `db:"monthly_fee"`      //maintenance fee, charged by the monthly fee job
+	MinimumBalance  float64 `db:"minimum_balance"`  //average balance over the month that waives the monthly fee, 0 for none
+	FreeWithdrawals int     `db:"free_withdrawals"` //withdrawals in a month before the withdrawal fee is charged
+	WithdrawalFee   float64 `db:"withdrawal_fee"`
+	EffectiveFrom   string  `db:"effective_from"`
+	CreatedBy       string  `db:"created_by"`
+	CreatedOn       string  `db:"created_on"`
+}
+
+func NewFeeSchedule(request dto.NewFeeScheduleRequest, c clock.Clock) FeeSchedule {
+	return FeeSchedule{
+		AccountType:     request.AccountType,
+		MonthlyFee:      request.MonthlyFee,
+		MinimumBalance:  request.MinimumBalance,
+		FreeWithdrawals: request.FreeWithdrawals,
+		WithdrawalFee:   request.WithdrawalFee,
+		EffectiveFrom:   request.EffectiveFrom + " 00:00:00",
+		CreatedBy:       request.CreatedBy,
+		CreatedOn:       c.NowAsString(),
+	}
+}
+
+// IsEffective reports whether the schedule has taken effect at the given time.
+func (s FeeSchedule) IsEffective(at string) bool {
+	return s.EffectiveFrom <= at //dates in the same format sort in time order
+}
+
+// IsBackdated reports whether the schedule takes effect before the day of the given time.
+func (s FeeSchedule) IsBackdated(now time.Time) bool {
+	return s.EffectiveFrom < now.Format(formatDate)
+}
+
+// MonthlyFeeFor returns the monthly fee of an account with the given average balance over the month, which is 0 if
+// the average balance waives it.
+func (s FeeSchedule) MonthlyFeeFor(averageBalance float64) float64 {
+	if s.MinimumBalance > 0 && averageBalance >= s.MinimumBalance {
+		return 0
+	}
+	return s.MonthlyFee
+}
+
+// WithdrawalFeeFor returns the fee for the withdrawal that is the given number in its month, which is 0 for the free
+// withdrawals.
+func (s FeeSchedule) WithdrawalFeeFor(withdrawalsInMonth int) float64 {
+	if withdrawalsInMonth <= s.FreeWithdrawals {
+		return 0
+	}
+	return s.WithdrawalFee
+}
+
+// ToDTO returns the schedule with the given status.
+func (s FeeSchedule) ToDTO(status string) dto.FeeScheduleResponse {
+	return dto.FeeScheduleResponse{
+		ScheduleId:      s.ScheduleId,
+		AccountType:     s.AccountType,
+		MonthlyFee:      s.MonthlyFee,
+		MinimumBalance:  s.MinimumBalance,
+		FreeWithdrawals: s.FreeWithdrawals,
+		WithdrawalFee:   s.WithdrawalFee,
+		EffectiveFrom:   s.EffectiveFrom,
+		Status:          status,
+		CreatedBy:       s.CreatedBy,
+		CreatedOn:       s.CreatedOn,
+	}
+}
+
+// FeePeriod is the calendar month that fees are counted and charged over.
+type FeePeriod struct {
+	Start time.Time
+	End   time.Time //exclusive, the start of the next month
+}
+
+// NewFeePeriod returns the month given as "2006-01", or an error if it is not a month in that format.
+func NewFeePeriod(month string) (FeePeriod, error) {
+	start, err := time.Parse(formatPeriod, month)
+	if err != nil {
+		return FeePeriod{}, err
+	}
+	return FeePeriod{start, start.AddDate(0, 1, 0)}, nil
+}
+
+// FeePeriodOf returns the month that the given "2006-01-02 15:04:05" date is in.
+func FeePeriodOf(date string) FeePeriod {
+	period, _ := NewFeePeriod(date[:len(formatPeriod)])
+	return period
+}
+
+// Previous returns the month before the period.
+func (p FeePeriod) Previous() FeePeriod {
+	start := p.Start.AddDate(0, -1, 0)
+	return FeePeriod{start, p.Start}
+}
+
+func (p FeePeriod) String() string {
+	return p.Start.Format(formatPeriod)
+}
+
+func (p FeePeriod) StartAsString() string {
+	return p.Start.Format(clock.FormatDateTime)
+}
+
+func (p FeePeriod) EndAsString() string {
+	return p.End.Format(clock.FormatDateTime)
+}
+
+// LastSecondAsString returns the last time in the period, at which the schedule that the period is charged on has to
+// be in effect.
+func (p FeePeriod) LastSecondAsString() string {
+	return p.End.Add(-time.Second).Format(clock.FormatDateTime)
+}
+
+// HasEnded reports whether the whole period is before the given time.
+func (p FeePeriod) HasEnded(now time.Time) bool {
+	return !now.Before(p.End)
+}
+
+// AverageBalance returns the average of the balance of the given account over the part of the given period in which
+// it was open, weighted by how long each balance was held, given the current balance of the account and every
+// transaction made on it since the period started, oldest first.
+func AverageBalance(a Account, p FeePeriod, since []Transaction) float64 {
+	from := p.Start
+	if opened, err := time.Parse(clock.FormatDateTime, a.OpeningDate); err == nil && opened.After(from) {
+		from = opened
+	}
+	if !p.End.After(from) {
+		return a.Amount
+	}
+
+	balance := a.Amount
+	for _, t := range since {
+		balance -= t.BalanceChange()
+	}
+
+	var weightedSum float64
+	last := from
+	for _, t := range since {
+		at, err := time.Parse(clock.FormatDateTime, t.TransactionDate)
+		if err != nil || !at.Before(p.End) {
+			break
+		}
+		if at.After(last) {
+			weightedSum += balance * at.Sub(last).Seconds()
+			last = at
+		}
+		balance += t.BalanceChange()
+	}
+	weightedSum += balance * p.End.Sub(last).Seconds()
+
+	return roundToCents(weightedSum / p.End.Sub(from).Seconds())
+}
+
+// MonthlyFeeCharge records that the monthly fee of an account has been charged, or waived, for a period, so that it
+// is only charged once.
+type MonthlyFeeCharge struct { //business/domain object
+	ChargeId       string  `db:"charge_id"`
+	AccountId      string  `db:"account_id"`
+	Period         string  `db:"period"` //the month charged for, as "2006-01"
+	ScheduleId     string  `db:"schedule_id"`
+	AverageBalance float64 `db:"average_balance"`
+	Fee            float64 `db:"fee"` //0 if waived
+	ChargedOn      string  `db:"charged_on"`
+}
+
+// NewMonthlyFeeCharge returns the monthly fee charge of the account with the given id and average balance over the
+// given period, on the given schedule.
+func NewMonthlyFeeCharge(accountId string, p FeePeriod, s FeeSchedule, averageBalance float64, c clock.Clock) MonthlyFeeCharge {
+	return MonthlyFeeCharge{
+		AccountId:      accountId,
+		Period:         p.String(),
+		ScheduleId:     s.ScheduleId,
+		AverageBalance: averageBalance,
+		Fee:            s.MonthlyFeeFor(averageBalance),
+		ChargedOn:      c.NowAsString(),
+	}
+}
+
+func (m MonthlyFeeCharge) IsWaived() bool {
+	return m.Fee == 0
+}
+
+// ToTransaction returns the transaction charging the fee, dated at the current time.
+func (m MonthlyFeeCharge) ToTransaction(c clock.Clock) Transaction {
+	return NewTransaction(m.AccountId, m.Fee, dto.TransactionTypeFee, c)
+}
+
+//Server
+
+//go:generate mockgen -destination=../mocks/domain/mock_feeRepository.go -package=domain github.com/aliciatay-zls/banking/backend/domain FeeRepository
+type FeeRepository interface { //repo (secondary port)
+	SaveSchedule(FeeSchedule) (*FeeSchedule, *errs.AppError)
+	FindSchedules() ([]FeeSchedule, *errs.AppError)
+	FindScheduleById(string) (*FeeSchedule, *errs.AppError)
+	FindEffectiveSchedule(string, string) (*FeeSchedule, *errs.AppError)
+	DeleteSchedule(string, string) *errs.AppError
+	CountWithdrawalsSince(string, string, string) (int, *errs.AppError)
+	FindTransactionsSince(string, string) ([]Transaction, *errs.AppError)
+	FindActiveAccountsOpenedBefore(string) ([]Account, *errs.AppError)
+	ExistsMonthlyCharge(string, string) (bool, *errs.AppError)
+	SaveMonthlyCharge(MonthlyFeeCharge) (*MonthlyFeeCharge, *errs.AppError)
+	DeleteMonthlyCharge(string) *errs.AppError
+}
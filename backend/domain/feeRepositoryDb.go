@@ -0,0 +1,198 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/problem"
+	"github.com/jmoiron/sqlx"
+	"strconv"
+)
+
+//Server
+
+type FeeRepositoryDb struct { //DB (adapter)
+	client *sqlx.DB
+}
+
+func NewFeeRepositoryDb(dbClient *sqlx.DB) FeeRepositoryDb {
+	return FeeRepositoryDb{dbClient}
+}
+
+// SaveSchedule creates a new entry in the database for the given fee schedule and returns it with its
+// database-generated ID set.
+func (d FeeRepositoryDb) SaveSchedule(s FeeSchedule) (*FeeSchedule, *errs.AppError) {
+	insertSql := "INSERT INTO fee_schedules (account_type, monthly_fee, minimum_balance, free_withdrawals, withdrawal_fee, effective_from, created_by, created_on) " +
+		"VALUES (?, ?, ?, ?, ?, ?, ?, ?)"
+	result, err := execInsert(d.client, insertSql, "schedule_id", s.AccountType, s.MonthlyFee, s.MinimumBalance,
+		s.FreeWithdrawals, s.WithdrawalFee, s.EffectiveFrom, s.CreatedBy, s.CreatedOn)
+	if err != nil {
+		logger.Error("Error while creating new fee schedule: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		logger.Error("Error while getting id of newly inserted fee schedule: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	s.ScheduleId = strconv.FormatInt(id, 10)
+
+	return &s, nil
+}
+
+// FindSchedules retrieves all fee schedules, by account type and then in the order they take effect.
+func (d FeeRepositoryDb) FindSchedules() ([]FeeSchedule, *errs.AppError) {
+	schedules := make([]FeeSchedule, 0)
+	findSql := d.selectSchedulesSql() + " ORDER BY account_type, effective_from, schedule_id"
+	if err := d.client.Select(&schedules, findSql); err != nil {
+		logger.Error("Error while retrieving fee schedules: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return schedules, nil
+}
+
+// FindScheduleById retrieves the fee schedule with the given id.
+func (d FeeRepositoryDb) FindScheduleById(scheduleId string) (*FeeSchedule, *errs.AppError) {
+	return d.findSchedule("Error while retrieving fee schedule: ", "Fee schedule not found",
+		d.selectSchedulesSql()+" WHERE schedule_id = ?", scheduleId)
+}
+
+// FindEffectiveSchedule retrieves the fee schedule of the given account type that is in effect at the given time: the
+// one that took effect last, and of those the last created.
+func (d FeeRepositoryDb) FindEffectiveSchedule(accountType string, at string) (*FeeSchedule, *errs.AppError) {
+	return d.findSchedule("Error while retrieving fee schedule in effect: ", "No fee schedule in effect",
+		d.selectSchedulesSql()+" WHERE account_type = ? AND effective_from <= ? ORDER BY effective_from DESC, schedule_id DESC LIMIT 1",
+		accountType, at)
+}
+
+func (d FeeRepositoryDb) findSchedule(logMessage string, notFoundMessage string, findSql string, args ...interface{}) (*FeeSchedule, *errs.AppError) {
+	var schedule FeeSchedule
+	if err := d.client.Get(&schedule, d.client.Rebind(findSql), args...); err != nil {
+		logger.Error(logMessage + err.Error())
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, problem.NewNotFoundError(problem.FeeScheduleNotFound, notFoundMessage)
+		}
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return &schedule, nil
+}
+
+// selectSchedulesSql returns the query selecting every column of the fee_schedules table, to which a WHERE or ORDER BY
+// clause can be appended.
+func (d FeeRepositoryDb) selectSchedulesSql() string {
+	driverName := d.client.DriverName()
+	return "SELECT schedule_id, account_type, monthly_fee, minimum_balance, free_withdrawals, withdrawal_fee, " +
+		dateTimeColumn(driverName, "effective_from") + ", created_by, " + dateTimeColumn(driverName, "created_on") +
+		" FROM fee_schedules"
+}
+
+// DeleteSchedule deletes the fee schedule with the given id, provided that it has not taken effect by the given time.
+// This way, a schedule that took effect in the meantime is kept, as fees may have been charged on it.
+func (d FeeRepositoryDb) DeleteSchedule(scheduleId string, now string) *errs.AppError {
+	deleteSql := "DELETE FROM fee_schedules WHERE schedule_id = ? AND effective_from > ?"
+	result, err := d.client.Exec(d.client.Rebind(deleteSql), scheduleId, now)
+	if err != nil {
+		logger.Error("Error while deleting fee schedule: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	if rowsAffected, err := result.RowsAffected(); err != nil || rowsAffected == 0 {
+		logger.Error("Error while deleting fee schedule: schedule not found or already in effect")
+		return problem.NewConflictError(problem.FeeScheduleInEffect, "Fee schedule has already taken effect")
+	}
+
+	return nil
+}
+
+// CountWithdrawalsSince counts the withdrawals made on the account with the given id at or after the given date, up to
+// and including the withdrawal with the given transaction id. Withdrawals reversing a deposit are not counted.
+func (d FeeRepositoryDb) CountWithdrawalsSince(accountId string, since string, upToTransactionId string) (int, *errs.AppError) {
+	var count int
+	countSql := "SELECT COUNT(*) FROM transactions WHERE account_id = ? AND transaction_type = ? AND transaction_date >= ? AND transaction_id <= ? " +
+		"AND related_transaction_id IS NULL"
+	if err := d.client.Get(&count, d.client.Rebind(countSql), accountId, dto.TransactionTypeWithdrawal, since, upToTransactionId); err != nil {
+		logger.Error("Error while counting withdrawals of account: " + err.Error())
+		return 0, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return count, nil
+}
+
+// FindTransactionsSince retrieves the transactions made on the account with the given id at or after the given date,
+// oldest first.
+func (d FeeRepositoryDb) FindTransactionsSince(accountId string, since string) ([]Transaction, *errs.AppError) {
+	transactions := make([]Transaction, 0)
+	findSql := "SELECT transaction_id, account_id, amount, transaction_type, " +
+		dateTimeColumn(d.client.DriverName(), "transaction_date") +
+		" FROM transactions WHERE account_id = ? AND transaction_date >= ? ORDER BY transaction_date, transaction_id"
+	if err := d.client.Select(&transactions, d.client.Rebind(findSql), accountId, since); err != nil {
+		logger.Error("Error while retrieving transactions for average balance: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return transactions, nil
+}
+
+// FindActiveAccountsOpenedBefore retrieves the accounts that are not inactive or frozen and were opened before the
+// given date, which are the accounts to charge the monthly fees of a period ending at that date.
+func (d FeeRepositoryDb) FindActiveAccountsOpenedBefore(before string) ([]Account, *errs.AppError) {
+	accounts := make([]Account, 0)
+	findSql := AccountRepositoryDb{d.client}.selectAccountsSql() + " WHERE status = ? AND opening_date < ? ORDER BY account_id"
+	if err := d.client.Select(&accounts, d.client.Rebind(findSql), AccountStatusActive, before); err != nil {
+		logger.Error("Error while retrieving accounts to charge monthly fees: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return accounts, nil
+}
+
+// ExistsMonthlyCharge checks whether the monthly fee of the account with the given id has already been charged or
+// waived for the given period.
+func (d FeeRepositoryDb) ExistsMonthlyCharge(accountId string, period string) (bool, *errs.AppError) {
+	var count int
+	countSql := "SELECT COUNT(*) FROM monthly_fee_charges WHERE account_id = ? AND period = ?"
+	if err := d.client.Get(&count, d.client.Rebind(countSql), accountId, period); err != nil {
+		logger.Error("Error while checking for monthly fee charge: " + err.Error())
+		return false, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return count > 0, nil
+}
+
+// SaveMonthlyCharge creates a new entry in the database for the given monthly fee charge and returns it with its
+// database-generated ID set. There can only be one charge per account and period, so of two jobs charging the same
+// period at once, only the first can save it.
+func (d FeeRepositoryDb) SaveMonthlyCharge(m MonthlyFeeCharge) (*MonthlyFeeCharge, *errs.AppError) {
+	insertSql := "INSERT INTO monthly_fee_charges (account_id, period, schedule_id, average_balance, fee, charged_on) VALUES (?, ?, ?, ?, ?, ?)"
+	result, err := execInsert(d.client, insertSql, "charge_id",
+		m.AccountId, m.Period, m.ScheduleId, m.AverageBalance, m.Fee, m.ChargedOn)
+	if err != nil {
+		logger.Error("Error while creating new monthly fee charge: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		logger.Error("Error while getting id of newly inserted monthly fee charge: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	m.ChargeId = strconv.FormatInt(id, 10)
+
+	return &m, nil
+}
+
+// DeleteMonthlyCharge deletes the monthly fee charge with the given id, e.g. when its fee could not be posted, so that
+// the next run of the job charges it again.
+func (d FeeRepositoryDb) DeleteMonthlyCharge(chargeId string) *errs.AppError {
+	deleteSql := "DELETE FROM monthly_fee_charges WHERE charge_id = ?"
+	if _, err := d.client.Exec(d.client.Rebind(deleteSql), chargeId); err != nil {
+		logger.Error("Error while deleting monthly fee charge: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
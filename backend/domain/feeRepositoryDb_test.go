@@ -0,0 +1,222 @@
+package domain
+
+import (
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/jmoiron/sqlx"
+	"net/http"
+	"testing"
+)
+
+// Test common variables and inputs
+var feeRepoDb FeeRepositoryDb
+
+var feeSchedulesTableColumns = []string{"schedule_id", "account_type", "monthly_fee", "minimum_balance", "free_withdrawals", "withdrawal_fee", "effective_from", "created_by", "created_on"}
+
+const insertFeeSchedulesSql = "INSERT INTO fee_schedules (account_type, monthly_fee, minimum_balance, free_withdrawals, withdrawal_fee, effective_from, created_by, created_on) " +
+	"VALUES (?, ?, ?, ?, ?, ?, ?, ?)"
+const insertFeeSchedulesPostgresSql = "INSERT INTO fee_schedules (account_type, monthly_fee, minimum_balance, free_withdrawals, withdrawal_fee, effective_from, created_by, created_on) " +
+	"VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING schedule_id"
+const selectAllFeeSchedulesSql = "SELECT schedule_id, account_type, monthly_fee, minimum_balance, free_withdrawals, withdrawal_fee, effective_from, created_by, created_on FROM fee_schedules " +
+	"ORDER BY account_type, effective_from, schedule_id"
+const selectEffectiveFeeScheduleSql = "SELECT schedule_id, account_type, monthly_fee, minimum_balance, free_withdrawals, withdrawal_fee, effective_from, created_by, created_on FROM fee_schedules " +
+	"WHERE account_type = ? AND effective_from <= ? ORDER BY effective_from DESC, schedule_id DESC LIMIT 1"
+const selectEffectiveFeeSchedulePostgresSql = "SELECT schedule_id, account_type, monthly_fee, minimum_balance, free_withdrawals, withdrawal_fee, " +
+	"to_char(effective_from, 'YYYY-MM-DD HH24:MI:SS') AS effective_from, created_by, to_char(created_on, 'YYYY-MM-DD HH24:MI:SS') AS created_on FROM fee_schedules " +
+	"WHERE account_type = $1 AND effective_from <= $2 ORDER BY effective_from DESC, schedule_id DESC LIMIT 1"
+const deleteFeeSchedulesSql = "DELETE FROM fee_schedules WHERE schedule_id = ? AND effective_from > ?"
+const countWithdrawalsSinceSql = "SELECT COUNT(*) FROM transactions WHERE account_id = ? AND transaction_type = ? AND transaction_date >= ? AND transaction_id <= ? " +
+	"AND related_transaction_id IS NULL"
+const countMonthlyFeeChargesSql = "SELECT COUNT(*) FROM monthly_fee_charges WHERE account_id = ? AND period = ?"
+const insertMonthlyFeeChargesSql = "INSERT INTO monthly_fee_charges (account_id, period, schedule_id, average_balance, fee, charged_on) VALUES (?, ?, ?, ?, ?, ?)"
+
+func setupFeeRepoDbTest(t *testing.T, driverName string) func() {
+	teardown := setupDB(t)
+	feeRepoDb = NewFeeRepositoryDb(sqlx.NewDb(db, driverName))
+	return teardown
+}
+
+func TestFeeRepositoryDb_SaveSchedule_returns_schedule_with_newId(t *testing.T) {
+	tests := []struct {
+		driverName string
+		insertSql  string
+	}{
+		{DriverMySQL, insertFeeSchedulesSql},
+		{DriverPostgres, insertFeeSchedulesPostgresSql},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.driverName, func(t *testing.T) {
+			//Arrange
+			teardown := setupFeeRepoDbTest(t, tc.driverName)
+			defer teardown()
+
+			s := getDefaultFeeSchedule()
+			expectInsert(tc.driverName, tc.insertSql, "schedule_id", 4, s.AccountType, s.MonthlyFee, s.MinimumBalance,
+				s.FreeWithdrawals, s.WithdrawalFee, s.EffectiveFrom, s.CreatedBy, s.CreatedOn)
+
+			//Act
+			savedSchedule, err := feeRepoDb.SaveSchedule(s)
+
+			//Assert
+			if err != nil {
+				t.Fatal("Expected no error but got error while testing successful saving of fee schedule: " + err.Message)
+			}
+			if savedSchedule.ScheduleId != "4" {
+				t.Errorf("Expected schedule id 4 but got %s", savedSchedule.ScheduleId)
+			}
+		})
+	}
+}
+
+func TestFeeRepositoryDb_FindSchedules_returns_schedules(t *testing.T) {
+	//Arrange
+	teardown := setupFeeRepoDbTest(t, driverName)
+	defer teardown()
+
+	mockDB.ExpectQuery(selectAllFeeSchedulesSql).
+		WillReturnRows(sqlmock.NewRows(feeSchedulesTableColumns).
+			AddRow("4", "checking", 10, 0, 0, 1, dummyMonthStart, "admin", dummyDate).
+			AddRow("5", "saving", 5, 1000, 2, 0.5, dummyMonthStart, "admin", dummyDate))
+
+	//Act
+	schedules, err := feeRepoDb.FindSchedules()
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing retrieval of fee schedules: " + err.Message)
+	}
+	if len(schedules) != 2 || schedules[1].FreeWithdrawals != 2 || schedules[1].WithdrawalFee != 0.5 {
+		t.Errorf("Expected 2 fee schedules but got %v", schedules)
+	}
+}
+
+func TestFeeRepositoryDb_FindEffectiveSchedule_returns_notFoundError_when_noRows(t *testing.T) {
+	//Arrange
+	teardown := setupFeeRepoDbTest(t, DriverPostgres)
+	defer teardown()
+
+	mockDB.ExpectQuery(selectEffectiveFeeSchedulePostgresSql).WithArgs("saving", dummyDate).WillReturnError(sql.ErrNoRows)
+	logger.MuteLogger()
+
+	//Act
+	_, err := feeRepoDb.FindEffectiveSchedule("saving", dummyDate)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing retrieval of fee schedule when none is in effect")
+	}
+	if err.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d but got %d", http.StatusNotFound, err.Code)
+	}
+}
+
+func TestFeeRepositoryDb_FindEffectiveSchedule_returns_schedule_when_select_succeeds(t *testing.T) {
+	//Arrange
+	teardown := setupFeeRepoDbTest(t, driverName)
+	defer teardown()
+
+	expectedSchedule := getDefaultFeeSchedule()
+	expectedSchedule.ScheduleId = "5"
+	mockDB.ExpectQuery(selectEffectiveFeeScheduleSql).
+		WithArgs("saving", dummyDate).
+		WillReturnRows(sqlmock.NewRows(feeSchedulesTableColumns).AddRow("5", "saving", 5, 1000, 2, 0.5, dummyMonthStart, "admin", dummyDate))
+
+	//Act
+	schedule, err := feeRepoDb.FindEffectiveSchedule("saving", dummyDate)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing retrieval of fee schedule in effect: " + err.Message)
+	}
+	if *schedule != expectedSchedule {
+		t.Errorf("Expected fee schedule %v but got %v", expectedSchedule, *schedule)
+	}
+}
+
+func TestFeeRepositoryDb_DeleteSchedule_returns_conflictError_when_schedule_in_effect(t *testing.T) {
+	//Arrange
+	teardown := setupFeeRepoDbTest(t, driverName)
+	defer teardown()
+
+	mockDB.ExpectExec(deleteFeeSchedulesSql).WithArgs("5", dummyDate).WillReturnResult(sqlmock.NewResult(0, 0))
+	logger.MuteLogger()
+
+	//Act
+	err := feeRepoDb.DeleteSchedule("5", dummyDate)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing deletion of fee schedule in effect")
+	}
+	if err.Code != http.StatusConflict {
+		t.Errorf("Expected status code %d but got %d", http.StatusConflict, err.Code)
+	}
+}
+
+func TestFeeRepositoryDb_CountWithdrawalsSince_returns_count(t *testing.T) {
+	//Arrange
+	teardown := setupFeeRepoDbTest(t, driverName)
+	defer teardown()
+
+	mockDB.ExpectQuery(countWithdrawalsSinceSql).
+		WithArgs(dummyAccountId, dto.TransactionTypeWithdrawal, dummyMonthStart, "7791").
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(3))
+
+	//Act
+	count, err := feeRepoDb.CountWithdrawalsSince(dummyAccountId, dummyMonthStart, "7791")
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing counting withdrawals: " + err.Message)
+	}
+	if count != 3 {
+		t.Errorf("Expected 3 withdrawals but got %d", count)
+	}
+}
+
+func TestFeeRepositoryDb_ExistsMonthlyCharge_returns_true_when_charged(t *testing.T) {
+	//Arrange
+	teardown := setupFeeRepoDbTest(t, driverName)
+	defer teardown()
+
+	mockDB.ExpectQuery(countMonthlyFeeChargesSql).
+		WithArgs(dummyAccountId, "2005-12").
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(1))
+
+	//Act
+	charged, err := feeRepoDb.ExistsMonthlyCharge(dummyAccountId, "2005-12")
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing checking for monthly fee charge: " + err.Message)
+	}
+	if !charged {
+		t.Error("Expected account to be charged but it was not")
+	}
+}
+
+func TestFeeRepositoryDb_SaveMonthlyCharge_returns_unexpectedError_when_period_already_charged(t *testing.T) {
+	//Arrange
+	teardown := setupFeeRepoDbTest(t, driverName)
+	defer teardown()
+
+	m := MonthlyFeeCharge{AccountId: dummyAccountId, Period: "2005-12", ScheduleId: "5", AverageBalance: 500, Fee: 5, ChargedOn: dummyDate}
+	mockDB.ExpectExec(insertMonthlyFeeChargesSql).
+		WithArgs(m.AccountId, m.Period, m.ScheduleId, m.AverageBalance, m.Fee, m.ChargedOn).
+		WillReturnError(sql.ErrTxDone) //stands in for the unique key violation
+	logger.MuteLogger()
+
+	//Act
+	_, err := feeRepoDb.SaveMonthlyCharge(m)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing saving of monthly fee charge for period already charged")
+	}
+	if err.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status code %d but got %d", http.StatusInternalServerError, err.Code)
+	}
+}
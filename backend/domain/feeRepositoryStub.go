@@ -0,0 +1,188 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/problem"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+//Server
+
+type FeeRepositoryStub struct { //stub (adapter)
+	accounts AccountRepositoryStub //the accounts and their transaction history are read from here
+	store    *feeStore             //shared by all copies of the stub, so that changes made through one copy are seen by all
+}
+
+// feeStore holds the fee schedules and monthly fee charges of a FeeRepositoryStub in memory. It is safe for
+// concurrent use.
+type feeStore struct {
+	mu             sync.Mutex
+	schedules      []FeeSchedule
+	charges        []MonthlyFeeCharge
+	nextScheduleId int64
+	nextChargeId   int64
+}
+
+func NewFeeRepositoryStub(accounts AccountRepositoryStub) FeeRepositoryStub { //helper function to create and initialize a stub
+	return FeeRepositoryStub{accounts, &feeStore{
+		schedules:      make([]FeeSchedule, 0),
+		charges:        make([]MonthlyFeeCharge, 0),
+		nextScheduleId: 1,
+		nextChargeId:   1,
+	}}
+}
+
+func (s FeeRepositoryStub) SaveSchedule(schedule FeeSchedule) (*FeeSchedule, *errs.AppError) { //stub implements repo
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	schedule.ScheduleId = strconv.FormatInt(s.store.nextScheduleId, 10)
+	s.store.nextScheduleId++
+	s.store.schedules = append(s.store.schedules, schedule)
+
+	return &schedule, nil
+}
+
+// FindSchedules returns all fee schedules, by account type and then in the order they take effect.
+func (s FeeRepositoryStub) FindSchedules() ([]FeeSchedule, *errs.AppError) { //stub implements repo
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	schedules := make([]FeeSchedule, len(s.store.schedules))
+	copy(schedules, s.store.schedules)
+	sort.SliceStable(schedules, func(i, j int) bool {
+		if schedules[i].AccountType != schedules[j].AccountType {
+			return schedules[i].AccountType < schedules[j].AccountType
+		}
+		return schedules[i].EffectiveFrom < schedules[j].EffectiveFrom
+	})
+	return schedules, nil
+}
+
+func (s FeeRepositoryStub) FindScheduleById(scheduleId string) (*FeeSchedule, *errs.AppError) { //stub implements repo
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	for _, schedule := range s.store.schedules {
+		if schedule.ScheduleId == scheduleId {
+			return &schedule, nil
+		}
+	}
+	logger.Error("Error while finding fee schedule using stub for FeeRepository: not found")
+	return nil, problem.NewNotFoundError(problem.FeeScheduleNotFound, "Fee schedule not found")
+}
+
+// FindEffectiveSchedule returns the fee schedule of the given account type that is in effect at the given time: the
+// one that took effect last, and of those the last created.
+func (s FeeRepositoryStub) FindEffectiveSchedule(accountType string, at string) (*FeeSchedule, *errs.AppError) { //stub implements repo
+	schedules, _ := s.FindSchedules() //the stub never fails
+	for i := len(schedules) - 1; i >= 0; i-- {
+		if schedules[i].AccountType == accountType && schedules[i].IsEffective(at) {
+			return &schedules[i], nil
+		}
+	}
+	return nil, problem.NewNotFoundError(problem.FeeScheduleNotFound, "No fee schedule in effect")
+}
+
+// DeleteSchedule deletes the fee schedule with the given id, provided that it has not taken effect by the given time.
+func (s FeeRepositoryStub) DeleteSchedule(scheduleId string, now string) *errs.AppError { //stub implements repo
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	for i, schedule := range s.store.schedules {
+		if schedule.ScheduleId == scheduleId && !schedule.IsEffective(now) {
+			s.store.schedules = append(s.store.schedules[:i], s.store.schedules[i+1:]...)
+			return nil
+		}
+	}
+	return problem.NewConflictError(problem.FeeScheduleInEffect, "Fee schedule has already taken effect")
+}
+
+func (s FeeRepositoryStub) CountWithdrawalsSince(accountId string, since string, upToTransactionId string) (int, *errs.AppError) { //stub implements repo
+	transactions, _ := s.FindTransactionsSince(accountId, since) //the stub never fails
+	count := 0
+	for _, t := range transactions {
+		if t.IsWithdrawal() && !t.RelatedTransactionId.Valid { //not a reversal
+			count++
+		}
+		if t.TransactionId == upToTransactionId {
+			break
+		}
+	}
+	return count, nil
+}
+
+func (s FeeRepositoryStub) FindTransactionsSince(accountId string, since string) ([]Transaction, *errs.AppError) { //stub implements repo
+	history, _ := s.accounts.FindTransactions(accountId) //the stub never fails
+	transactions := make([]Transaction, 0)
+	for _, t := range history {
+		if t.TransactionDate >= since { //dates in the same format sort in time order
+			transactions = append(transactions, t)
+		}
+	}
+	return transactions, nil
+}
+
+func (s FeeRepositoryStub) FindActiveAccountsOpenedBefore(before string) ([]Account, *errs.AppError) { //stub implements repo
+	s.accounts.store.mu.Lock()
+	defer s.accounts.store.mu.Unlock()
+
+	accounts := make([]Account, 0)
+	for _, a := range s.accounts.store.accounts {
+		if a.Status == AccountStatusActive && a.OpeningDate < before {
+			accounts = append(accounts, a)
+		}
+	}
+	return accounts, nil
+}
+
+func (s FeeRepositoryStub) ExistsMonthlyCharge(accountId string, period string) (bool, *errs.AppError) { //stub implements repo
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	return s.store.indexOfCharge(accountId, period) >= 0, nil
+}
+
+// SaveMonthlyCharge stores the given monthly fee charge under the next free charge ID, unless the account already has
+// a charge for the period.
+func (s FeeRepositoryStub) SaveMonthlyCharge(charge MonthlyFeeCharge) (*MonthlyFeeCharge, *errs.AppError) { //stub implements repo
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	if s.store.indexOfCharge(charge.AccountId, charge.Period) >= 0 {
+		logger.Error("Error while saving monthly fee charge using stub for FeeRepository: already charged")
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	charge.ChargeId = strconv.FormatInt(s.store.nextChargeId, 10)
+	s.store.nextChargeId++
+	s.store.charges = append(s.store.charges, charge)
+
+	return &charge, nil
+}
+
+func (s FeeRepositoryStub) DeleteMonthlyCharge(chargeId string) *errs.AppError { //stub implements repo
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	for i, charge := range s.store.charges {
+		if charge.ChargeId == chargeId {
+			s.store.charges = append(s.store.charges[:i], s.store.charges[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// indexOfCharge returns the index of the charge of the given account for the given period, or -1 if there is none.
+// The caller must hold the lock.
+func (st *feeStore) indexOfCharge(accountId string, period string) int {
+	for i, charge := range st.charges {
+		if charge.AccountId == accountId && charge.Period == period {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"net/http"
+	"testing"
+)
+
+func TestFeeRepositoryStub_FindEffectiveSchedule_returns_latest_schedule_in_effect(t *testing.T) {
+	//Arrange
+	stub := NewFeeRepositoryStub(NewAccountRepositoryStub())
+	older := getDefaultFeeSchedule()
+	older.EffectiveFrom = "2005-06-01 00:00:00"
+	future := getDefaultFeeSchedule()
+	future.EffectiveFrom = "2006-02-01 00:00:00"
+	for _, s := range []FeeSchedule{getDefaultFeeSchedule(), older, future} {
+		stub.SaveSchedule(s)
+	}
+
+	//Act
+	schedule, err := stub.FindEffectiveSchedule(dto.AccountTypeSaving, dummyDate)
+	_, missingErr := stub.FindEffectiveSchedule(dto.AccountTypeChecking, dummyDate)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing retrieval of fee schedule in effect: " + err.Message)
+	}
+	if schedule.ScheduleId != "1" {
+		t.Errorf("Expected schedule 1 but got %v", *schedule)
+	}
+	if missingErr == nil || missingErr.Code != http.StatusNotFound {
+		t.Errorf("Expected not found error for account type without schedule but got %v", missingErr)
+	}
+}
+
+func TestFeeRepositoryStub_DeleteSchedule_returns_conflictError_when_schedule_in_effect(t *testing.T) {
+	//Arrange
+	stub := NewFeeRepositoryStub(NewAccountRepositoryStub())
+	future := getDefaultFeeSchedule()
+	future.EffectiveFrom = "2006-02-01 00:00:00"
+	stub.SaveSchedule(getDefaultFeeSchedule())
+	stub.SaveSchedule(future)
+
+	//Act
+	inEffectErr := stub.DeleteSchedule("1", dummyDate)
+	futureErr := stub.DeleteSchedule("2", dummyDate)
+
+	//Assert
+	if inEffectErr == nil || inEffectErr.Code != http.StatusConflict {
+		t.Errorf("Expected conflict error for schedule in effect but got %v", inEffectErr)
+	}
+	if futureErr != nil {
+		t.Error("Expected no error but got error while testing deletion of future schedule: " + futureErr.Message)
+	}
+	if schedules, _ := stub.FindSchedules(); len(schedules) != 1 {
+		t.Errorf("Expected 1 schedule left but got %v", schedules)
+	}
+}
+
+func TestFeeRepositoryStub_CountWithdrawalsSince_counts_withdrawals_up_to_transaction(t *testing.T) {
+	//Arrange
+	accounts := NewAccountRepositoryStub()
+	stub := NewFeeRepositoryStub(accounts)
+	for _, transactionType := range []string{dto.TransactionTypeWithdrawal, dto.TransactionTypeDeposit, dto.TransactionTypeWithdrawal, dto.TransactionTypeWithdrawal} {
+		accounts.Transact(NewTransaction("95470", 10, transactionType, clock.StaticClock{}))
+	}
+
+	//Act
+	count, _ := stub.CountWithdrawalsSince("95470", dummyMonthStart, "3")
+	none, _ := stub.CountWithdrawalsSince("95470", "2006-01-03 00:00:00", "4")
+
+	//Assert
+	if count != 2 || none != 0 {
+		t.Errorf("Expected 2 and 0 withdrawals but got %d and %d", count, none)
+	}
+}
+
+func TestFeeRepositoryStub_SaveMonthlyCharge_returns_error_when_period_already_charged(t *testing.T) {
+	//Arrange
+	stub := NewFeeRepositoryStub(NewAccountRepositoryStub())
+	charge := MonthlyFeeCharge{AccountId: dummyAccountId, Period: "2005-12", Fee: 5}
+	saved, _ := stub.SaveMonthlyCharge(charge)
+	logger.MuteLogger()
+
+	//Act
+	_, err := stub.SaveMonthlyCharge(charge)
+	stub.DeleteMonthlyCharge(saved.ChargeId)
+	charged, _ := stub.ExistsMonthlyCharge(dummyAccountId, "2005-12")
+
+	//Assert
+	if err == nil {
+		t.Error("Expected error but got none while testing saving of second charge for the same period")
+	}
+	if charged {
+		t.Error("Expected no charge after deleting it")
+	}
+}
@@ -0,0 +1,158 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"testing"
+)
+
+const dummyMonthStart = "2006-01-01 00:00:00"
+
+// getDefaultFeeSchedule returns a schedule for saving accounts in effect since the start of the month of the static
+// clock, charging a monthly fee of 5 waived from an average balance of 1000, and 0.5 for each withdrawal after 2 free
+// ones
+func getDefaultFeeSchedule() FeeSchedule {
+	return FeeSchedule{
+		AccountType:     dto.AccountTypeSaving,
+		MonthlyFee:      5,
+		MinimumBalance:  1000,
+		FreeWithdrawals: 2,
+		WithdrawalFee:   0.5,
+		EffectiveFrom:   dummyMonthStart,
+		CreatedBy:       "admin",
+		CreatedOn:       dummyDate,
+	}
+}
+
+func TestNewFeeSchedule_takes_effect_at_start_of_given_day(t *testing.T) {
+	//Arrange
+	request := dto.NewFeeScheduleRequest{AccountType: dto.AccountTypeSaving, MonthlyFee: 5, MinimumBalance: 1000,
+		FreeWithdrawals: 2, WithdrawalFee: 0.5, EffectiveFrom: "2006-01-01", CreatedBy: "admin"}
+
+	//Act
+	schedule := NewFeeSchedule(request, clock.StaticClock{})
+
+	//Assert
+	if schedule != getDefaultFeeSchedule() {
+		t.Errorf("Expected fee schedule %v but got %v", getDefaultFeeSchedule(), schedule)
+	}
+	if !schedule.IsBackdated(clock.StaticClock{}.Now()) {
+		t.Error("Expected schedule taking effect the day before to be backdated")
+	}
+}
+
+func TestFeeSchedule_MonthlyFeeFor_waives_fee_from_minimumBalance(t *testing.T) {
+	//Arrange
+	noMinimum := getDefaultFeeSchedule()
+	noMinimum.MinimumBalance = 0
+	tests := []struct {
+		name           string
+		schedule       FeeSchedule
+		averageBalance float64
+		expectedFee    float64
+	}{
+		{"below minimum balance", getDefaultFeeSchedule(), 999.99, 5},
+		{"at minimum balance", getDefaultFeeSchedule(), 1000, 0},
+		{"no minimum balance", noMinimum, 100000, 5},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Act
+			actualFee := tc.schedule.MonthlyFeeFor(tc.averageBalance)
+
+			//Assert
+			if actualFee != tc.expectedFee {
+				t.Errorf("Expected fee %v but got %v", tc.expectedFee, actualFee)
+			}
+		})
+	}
+}
+
+func TestFeeSchedule_WithdrawalFeeFor_charges_withdrawals_after_freeWithdrawals(t *testing.T) {
+	//Arrange
+	schedule := getDefaultFeeSchedule()
+
+	//Act
+	lastFree := schedule.WithdrawalFeeFor(2)
+	firstCharged := schedule.WithdrawalFeeFor(3)
+
+	//Assert
+	if lastFree != 0 || firstCharged != 0.5 {
+		t.Errorf("Expected fees 0 and 0.5 but got %v and %v", lastFree, firstCharged)
+	}
+}
+
+func TestFeePeriod_returns_month_boundaries(t *testing.T) {
+	//Arrange
+	period := FeePeriodOf(dummyDate)
+
+	//Act
+	previous := period.Previous()
+	_, invalidErr := NewFeePeriod("2005-13")
+
+	//Assert
+	if previous.String() != "2005-12" || previous.StartAsString() != "2005-12-01 00:00:00" ||
+		previous.EndAsString() != dummyMonthStart || previous.LastSecondAsString() != "2005-12-31 23:59:59" {
+		t.Errorf("Expected December 2005 but got %v", previous)
+	}
+	if !previous.HasEnded(clock.StaticClock{}.Now()) || period.HasEnded(clock.StaticClock{}.Now()) {
+		t.Error("Expected only the previous month to have ended")
+	}
+	if invalidErr == nil {
+		t.Error("Expected error for invalid month but got none")
+	}
+}
+
+func TestAverageBalance_weights_balances_by_time_held(t *testing.T) {
+	//Arrange
+	period, _ := NewFeePeriod("2005-12")
+	openedMidMonth := Account{OpeningDate: "2005-12-22 00:00:00", Amount: 1000}
+	tests := []struct {
+		name            string
+		account         Account
+		since           []Transaction
+		expectedAverage float64
+	}{
+		{"no transactions", Account{OpeningDate: "2005-01-01 10:00:00", Amount: 1000}, []Transaction{}, 1000},
+		{"transactions in and after period", Account{OpeningDate: "2005-01-01 10:00:00", Amount: 1000}, []Transaction{
+			{Amount: 310, TransactionType: dto.TransactionTypeDeposit, TransactionDate: "2005-12-11 00:00:00"},
+			{Amount: 100, TransactionType: dto.TransactionTypeWithdrawal, TransactionDate: "2006-01-01 10:00:00"},
+		}, 1000}, //790 for 10 days, then 1100 for 21 days
+		{"opened during period", openedMidMonth, []Transaction{
+			{Amount: 500, TransactionType: dto.TransactionTypeDeposit, TransactionDate: "2005-12-27 00:00:00"},
+		}, 750}, //500 for 5 days, then 1000 for 5 days
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Act
+			actualAverage := AverageBalance(tc.account, period, tc.since)
+
+			//Assert
+			if actualAverage != tc.expectedAverage {
+				t.Errorf("Expected average balance %v but got %v", tc.expectedAverage, actualAverage)
+			}
+		})
+	}
+}
+
+func TestNewMonthlyFeeCharge_charges_fee_of_schedule(t *testing.T) {
+	//Arrange
+	period, _ := NewFeePeriod("2005-12")
+	schedule := getDefaultFeeSchedule()
+	schedule.ScheduleId = "5"
+
+	//Act
+	charge := NewMonthlyFeeCharge(dummyAccountId, period, schedule, 500, clock.StaticClock{})
+	waived := NewMonthlyFeeCharge(dummyAccountId, period, schedule, 1500, clock.StaticClock{})
+	transaction := charge.ToTransaction(clock.StaticClock{})
+
+	//Assert
+	if charge.IsWaived() || charge.Period != "2005-12" || charge.ScheduleId != "5" || !waived.IsWaived() {
+		t.Errorf("Expected charge of 5 and waived charge but got %v and %v", charge, waived)
+	}
+	if transaction.TransactionType != dto.TransactionTypeFee || transaction.Amount != 5 || transaction.AccountId != dummyAccountId {
+		t.Errorf("Expected fee transaction of 5 but got %v", transaction)
+	}
+}
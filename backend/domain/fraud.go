@@ -0,0 +1,324 @@
+package domain
+
+import (
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"strings"
+	"time"
+)
+
+//Business Domain
+
+// The decisions of fraud screening, from least to most severe.
+const FraudDecisionAllow = "allow"
+const FraudDecisionReview = "review" //the transaction is held until an admin approves or rejects it
+const FraudDecisionBlock = "block"
+
+// The fraud rules that can be configured.
+const FraudRuleVelocity = "velocity"
+const FraudRuleNewAccountWithdrawal = "new_account_withdrawal"
+const FraudRuleNearLimitAmount = "near_limit_amount"
+const FraudRuleDormantAccount = "dormant_account"
+
+// FraudCheck is what a transaction is screened on: the transaction about to be made, its account and the account's
+// history.
+type FraudCheck struct {
+	Account             Account
+	Transaction         Transaction
+	RecentTransactions  []Transaction //made on the account within the lookback of the engine screening it
+	LastTransactionDate string        //empty if no transaction was ever made on the account
+}
+
+// FraudVerdict is the outcome of one rule for one transaction.
+type FraudVerdict struct {
+	Rule     string
+	Decision string
+	Reason   string
+}
+
+type FraudRule interface {
+	Name() string
+	Lookback() time.Duration //how far back the rule needs the recent transactions of the account, 0 if not at all
+	Evaluate(FraudCheck) FraudVerdict
+}
+
+// FraudEngine screens transactions with a set of rules.
+type FraudEngine struct {
+	rules []FraudRule
+}
+
+func NewFraudEngine(rules ...FraudRule) FraudEngine {
+	return FraudEngine{rules}
+}
+
+// Lookback returns how far back the recent transactions of an account are needed by the rules of the engine.
+func (e FraudEngine) Lookback() time.Duration {
+	var lookback time.Duration
+	for _, rule := range e.rules {
+		if rule.Lookback() > lookback {
+			lookback = rule.Lookback()
+		}
+	}
+	return lookback
+}
+
+// Screen evaluates every rule of the engine against the given check and returns the most severe of their decisions,
+// together with the names and reasons of the rules that did not allow the transaction.
+func (e FraudEngine) Screen(check FraudCheck, c clock.Clock) FraudDecision {
+	decision := FraudDecision{
+		AccountId:       check.Transaction.AccountId,
+		Amount:          check.Transaction.Amount,
+		TransactionType: check.Transaction.TransactionType,
+		Decision:        FraudDecisionAllow,
+		DecidedOn:       c.NowAsString(),
+	}
+
+	rules := make([]string, 0)
+	reasons := make([]string, 0)
+	for _, rule := range e.rules {
+		verdict := rule.Evaluate(check)
+		if verdict.Decision == FraudDecisionAllow {
+			continue
+		}
+		rules = append(rules, verdict.Rule)
+		reasons = append(reasons, verdict.Reason)
+		if fraudDecisionSeverity(verdict.Decision) > fraudDecisionSeverity(decision.Decision) {
+			decision.Decision = verdict.Decision
+		}
+	}
+	decision.TriggeredRules = strings.Join(rules, ",")
+	decision.Reasons = strings.Join(reasons, "; ")
+
+	return decision
+}
+
+func fraudDecisionSeverity(decision string) int {
+	switch decision {
+	case FraudDecisionBlock:
+		return 2
+	case FraudDecisionReview:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// FraudDecision is the outcome of screening a transaction, kept for later analysis.
+type FraudDecision struct { //business/domain object
+	DecisionId      string  `db:"decision_id"`
+	AccountId       string  `db:"account_id"`
+	Amount          float64 `db:"amount"`
+	TransactionType string  `db:"transaction_type"`
+	Decision        string  `db:"decision"`
+	TriggeredRules  string  `db:"triggered_rules"` //comma-separated names of the rules that did not allow the transaction
+	Reasons         string  `db:"reasons"`
+	DecidedOn       string  `db:"decided_on"`
+}
+
+func (d FraudDecision) IsBlocked() bool {
+	return d.Decision == FraudDecisionBlock
+}
+
+func (d FraudDecision) IsFlaggedForReview() bool {
+	return d.Decision == FraudDecisionReview
+}
+
+// VelocityRule is triggered by a withdrawal that would make more than MaxWithdrawals withdrawals on the account
+// within Window.
+type VelocityRule struct {
+	MaxWithdrawals int
+	Window         time.Duration
+	Decision       string
+}
+
+func (r VelocityRule) Name() string {
+	return FraudRuleVelocity
+}
+
+func (r VelocityRule) Lookback() time.Duration {
+	return r.Window
+}
+
+func (r VelocityRule) Evaluate(check FraudCheck) FraudVerdict {
+	if !check.Transaction.IsWithdrawal() {
+		return allowFraudVerdict(r)
+	}
+
+	now, _ := time.Parse(clock.FormatDateTime, check.Transaction.TransactionDate)
+	since := now.Add(-r.Window).Format(clock.FormatDateTime)
+	count := 1 //the withdrawal being screened
+	for _, t := range check.RecentTransactions {
+		if t.IsWithdrawal() && t.TransactionDate >= since {
+			count++
+		}
+	}
+	if count <= r.MaxWithdrawals {
+		return allowFraudVerdict(r)
+	}
+	return FraudVerdict{r.Name(), r.Decision,
+		fmt.Sprintf("%d withdrawals within %s", count, r.Window)}
+}
+
+// NewAccountWithdrawalRule is triggered by a withdrawal made within Window of the account being opened.
+type NewAccountWithdrawalRule struct {
+	Window   time.Duration
+	Decision string
+}
+
+func (r NewAccountWithdrawalRule) Name() string {
+	return FraudRuleNewAccountWithdrawal
+}
+
+func (r NewAccountWithdrawalRule) Lookback() time.Duration {
+	return 0
+}
+
+func (r NewAccountWithdrawalRule) Evaluate(check FraudCheck) FraudVerdict {
+	if !check.Transaction.IsWithdrawal() {
+		return allowFraudVerdict(r)
+	}
+
+	elapsed, isParsed := elapsedBetween(check.Account.OpeningDate, check.Transaction.TransactionDate)
+	if !isParsed || elapsed < 0 || elapsed >= r.Window {
+		return allowFraudVerdict(r)
+	}
+	return FraudVerdict{r.Name(), r.Decision,
+		fmt.Sprintf("withdrawal within %s of account opening", r.Window)}
+}
+
+// NearLimitAmountRule is triggered by a transaction whose amount is within Margin below the maximum amount allowed
+// for a transaction, as is typical of attempts to stay under the limit.
+type NearLimitAmountRule struct {
+	Margin   float64
+	Decision string
+}
+
+func (r NearLimitAmountRule) Name() string {
+	return FraudRuleNearLimitAmount
+}
+
+func (r NearLimitAmountRule) Lookback() time.Duration {
+	return 0
+}
+
+func (r NearLimitAmountRule) Evaluate(check FraudCheck) FraudVerdict {
+	amount := check.Transaction.Amount
+	if amount < dto.TransactionMaxAmountAllowed-r.Margin || amount > dto.TransactionMaxAmountAllowed {
+		return allowFraudVerdict(r)
+	}
+	return FraudVerdict{r.Name(), r.Decision,
+		fmt.Sprintf("amount %.2f just under the limit of %.2f", amount, dto.TransactionMaxAmountAllowed)}
+}
+
+// DormantAccountRule is triggered by the first transaction on an account that has had no transaction, or has been
+// open without any transaction, for longer than DormantAfter.
+type DormantAccountRule struct {
+	DormantAfter time.Duration
+	Decision     string
+}
+
+func (r DormantAccountRule) Name() string {
+	return FraudRuleDormantAccount
+}
+
+func (r DormantAccountRule) Lookback() time.Duration {
+	return 0
+}
+
+func (r DormantAccountRule) Evaluate(check FraudCheck) FraudVerdict {
+	lastActiveOn := check.LastTransactionDate
+	if lastActiveOn == "" {
+		lastActiveOn = check.Account.OpeningDate
+	}
+
+	elapsed, isParsed := elapsedBetween(lastActiveOn, check.Transaction.TransactionDate)
+	if !isParsed || elapsed <= r.DormantAfter {
+		return allowFraudVerdict(r)
+	}
+	return FraudVerdict{r.Name(), r.Decision,
+		fmt.Sprintf("first transaction after %d days without activity", int(elapsed.Hours()/24))}
+}
+
+func allowFraudVerdict(rule FraudRule) FraudVerdict {
+	return FraudVerdict{Rule: rule.Name(), Decision: FraudDecisionAllow}
+}
+
+// elapsedBetween returns the time from one date to another, both in the clock.FormatDateTime format, and whether
+// both could be parsed.
+func elapsedBetween(from string, to string) (time.Duration, bool) {
+	fromTime, fromErr := time.Parse(clock.FormatDateTime, from)
+	toTime, toErr := time.Parse(clock.FormatDateTime, to)
+	if fromErr != nil || toErr != nil {
+		return 0, false
+	}
+	return toTime.Sub(fromTime), true
+}
+
+// FraudRuleConfig configures one fraud rule. Only the settings of its rule are used.
+type FraudRuleConfig struct {
+	Rule           string  `json:"rule"`
+	Decision       string  `json:"decision"`
+	MaxWithdrawals int     `json:"max_withdrawals"` //velocity
+	WindowMinutes  int     `json:"window_minutes"`  //velocity, new_account_withdrawal
+	Margin         float64 `json:"margin"`          //near_limit_amount
+	DormantDays    int     `json:"dormant_days"`    //dormant_account
+}
+
+// DefaultFraudRuleConfigs returns the fraud rules used unless others are configured.
+func DefaultFraudRuleConfigs() []FraudRuleConfig {
+	return []FraudRuleConfig{
+		{Rule: FraudRuleVelocity, Decision: FraudDecisionBlock, MaxWithdrawals: 5, WindowMinutes: 10},
+		{Rule: FraudRuleNewAccountWithdrawal, Decision: FraudDecisionReview, WindowMinutes: 60},
+		{Rule: FraudRuleNearLimitAmount, Decision: FraudDecisionReview, Margin: 500},
+		{Rule: FraudRuleDormantAccount, Decision: FraudDecisionReview, DormantDays: 180},
+	}
+}
+
+// NewFraudRules creates the rules configured by the given configs, returning an error for an unknown rule or
+// decision or a setting that is out of range.
+func NewFraudRules(configs []FraudRuleConfig) ([]FraudRule, error) {
+	rules := make([]FraudRule, 0, len(configs))
+	for _, c := range configs {
+		if c.Decision != FraudDecisionReview && c.Decision != FraudDecisionBlock {
+			return nil, fmt.Errorf("fraud rule %s: decision must be %s or %s", c.Rule, FraudDecisionReview, FraudDecisionBlock)
+		}
+
+		window := time.Duration(c.WindowMinutes) * time.Minute
+		switch c.Rule {
+		case FraudRuleVelocity:
+			if c.MaxWithdrawals < 1 || c.WindowMinutes < 1 {
+				return nil, fmt.Errorf("fraud rule %s: max_withdrawals and window_minutes must be at least 1", c.Rule)
+			}
+			rules = append(rules, VelocityRule{c.MaxWithdrawals, window, c.Decision})
+		case FraudRuleNewAccountWithdrawal:
+			if c.WindowMinutes < 1 {
+				return nil, fmt.Errorf("fraud rule %s: window_minutes must be at least 1", c.Rule)
+			}
+			rules = append(rules, NewAccountWithdrawalRule{window, c.Decision})
+		case FraudRuleNearLimitAmount:
+			if c.Margin <= 0 || c.Margin > dto.TransactionMaxAmountAllowed {
+				return nil, fmt.Errorf("fraud rule %s: margin must be above 0 and at most %.2f", c.Rule, dto.TransactionMaxAmountAllowed)
+			}
+			rules = append(rules, NearLimitAmountRule{c.Margin, c.Decision})
+		case FraudRuleDormantAccount:
+			if c.DormantDays < 1 {
+				return nil, fmt.Errorf("fraud rule %s: dormant_days must be at least 1", c.Rule)
+			}
+			rules = append(rules, DormantAccountRule{time.Duration(c.DormantDays) * 24 * time.Hour, c.Decision})
+		default:
+			return nil, fmt.Errorf("unknown fraud rule %s", c.Rule)
+		}
+	}
+	return rules, nil
+}
+
+//Server
+
+//go:generate mockgen -destination=../mocks/domain/mock_fraudRepository.go -package=domain github.com/aliciatay-zls/banking/backend/domain FraudRepository
+type FraudRepository interface { //repo (secondary port)
+	FindTransactionsSince(string, string) ([]Transaction, *errs.AppError)
+	FindLastTransactionDate(string) (string, *errs.AppError)
+	SaveDecision(FraudDecision) (*FraudDecision, *errs.AppError)
+}
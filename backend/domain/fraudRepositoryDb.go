@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/jmoiron/sqlx"
+	"strconv"
+)
+
+//Server
+
+type FraudRepositoryDb struct { //DB (adapter)
+	client *sqlx.DB
+}
+
+func NewFraudRepositoryDb(dbClient *sqlx.DB) FraudRepositoryDb {
+	return FraudRepositoryDb{dbClient}
+}
+
+// FindTransactionsSince retrieves the transactions made on the account with the given id at or after the given date,
+// oldest first.
+func (d FraudRepositoryDb) FindTransactionsSince(accountId string, since string) ([]Transaction, *errs.AppError) {
+	transactions := make([]Transaction, 0)
+	findSql := "SELECT transaction_id, account_id, amount, transaction_type, " +
+		dateTimeColumn(d.client.DriverName(), "transaction_date") +
+		" FROM transactions WHERE account_id = ? AND transaction_date >= ? ORDER BY transaction_id"
+	if err := d.client.Select(&transactions, d.client.Rebind(findSql), accountId, since); err != nil {
+		logger.Error("Error while retrieving recent transactions for fraud screening: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return transactions, nil
+}
+
+// FindLastTransactionDate retrieves the date of the latest transaction made on the account with the given id, which
+// is empty if none was ever made.
+func (d FraudRepositoryDb) FindLastTransactionDate(accountId string) (string, *errs.AppError) {
+	var lastTransactionDate string
+	findSql := "SELECT " + dateTimeColumn(d.client.DriverName(), "transaction_date") +
+		" FROM transactions WHERE account_id = ? ORDER BY transaction_date DESC LIMIT 1"
+	if err := d.client.Get(&lastTransactionDate, d.client.Rebind(findSql), accountId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+		logger.Error("Error while retrieving last transaction date for fraud screening: " + err.Error())
+		return "", errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return lastTransactionDate, nil
+}
+
+// SaveDecision creates a new entry in the database for the given fraud screening decision and returns it with its
+// database-generated ID set.
+func (d FraudRepositoryDb) SaveDecision(fd FraudDecision) (*FraudDecision, *errs.AppError) {
+	insertSql := "INSERT INTO fraud_decisions (account_id, amount, transaction_type, decision, triggered_rules, reasons, decided_on) " +
+		"VALUES (?, ?, ?, ?, ?, ?, ?)"
+	result, err := execInsert(d.client, insertSql, "decision_id",
+		fd.AccountId, fd.Amount, fd.TransactionType, fd.Decision, fd.TriggeredRules, fd.Reasons, fd.DecidedOn)
+	if err != nil {
+		logger.Error("Error while creating new fraud decision: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		logger.Error("Error while getting id of newly inserted fraud decision: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	fd.DecisionId = strconv.FormatInt(id, 10)
+
+	return &fd, nil
+}
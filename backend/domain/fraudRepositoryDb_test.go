@@ -0,0 +1,142 @@
+package domain
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/jmoiron/sqlx"
+	"testing"
+)
+
+// Test common variables and inputs
+var fraudRepoDb FraudRepositoryDb
+
+const selectTransactionsSinceSql = "SELECT transaction_id, account_id, amount, transaction_type, transaction_date FROM transactions WHERE account_id = ? AND transaction_date >= ? ORDER BY transaction_id"
+const selectLastTransactionDateSql = "SELECT transaction_date FROM transactions WHERE account_id = ? ORDER BY transaction_date DESC LIMIT 1"
+const insertFraudDecisionsSql = "INSERT INTO fraud_decisions (account_id, amount, transaction_type, decision, triggered_rules, reasons, decided_on) VALUES (?, ?, ?, ?, ?, ?, ?)"
+
+const selectTransactionsSincePostgresSql = "SELECT transaction_id, account_id, amount, transaction_type, to_char(transaction_date, 'YYYY-MM-DD HH24:MI:SS') AS transaction_date FROM transactions WHERE account_id = $1 AND transaction_date >= $2 ORDER BY transaction_id"
+const insertFraudDecisionsPostgresSql = "INSERT INTO fraud_decisions (account_id, amount, transaction_type, decision, triggered_rules, reasons, decided_on) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING decision_id"
+
+func setupFraudRepoDbTest(t *testing.T, driverName string) func() {
+	teardown := setupDB(t)
+	fraudRepoDb = NewFraudRepositoryDb(sqlx.NewDb(db, driverName))
+	return teardown
+}
+
+func TestFraudRepositoryDb_FindTransactionsSince_returns_transactions(t *testing.T) {
+	tests := []struct {
+		driverName string
+		selectSql  string
+	}{
+		{DriverMySQL, selectTransactionsSinceSql},
+		{DriverPostgres, selectTransactionsSincePostgresSql},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.driverName, func(t *testing.T) {
+			//Arrange
+			teardown := setupFraudRepoDbTest(t, tc.driverName)
+			defer teardown()
+
+			expectedTransaction := Transaction{TransactionId: "7", AccountId: dummyAccountId, Amount: 100,
+				TransactionType: dto.TransactionTypeWithdrawal, TransactionDate: dummyDate}
+			dummyRows := sqlmock.NewRows([]string{"transaction_id", "account_id", "amount", "transaction_type", "transaction_date"}).
+				AddRow(expectedTransaction.TransactionId, expectedTransaction.AccountId, expectedTransaction.Amount,
+					expectedTransaction.TransactionType, expectedTransaction.TransactionDate)
+			mockDB.ExpectQuery(tc.selectSql).WithArgs(dummyAccountId, "2006-01-02 14:55:05").WillReturnRows(dummyRows)
+
+			//Act
+			actualTransactions, err := fraudRepoDb.FindTransactionsSince(dummyAccountId, "2006-01-02 14:55:05")
+
+			//Assert
+			if err != nil {
+				t.Fatal("Expected no error but got error while testing successful retrieval of recent transactions: " + err.Message)
+			}
+			if len(actualTransactions) != 1 || actualTransactions[0] != expectedTransaction {
+				t.Errorf("Expected transactions %v but got %v", []Transaction{expectedTransaction}, actualTransactions)
+			}
+		})
+	}
+}
+
+func TestFraudRepositoryDb_FindLastTransactionDate_returns_empty_when_no_transactions(t *testing.T) {
+	//Arrange
+	teardown := setupFraudRepoDbTest(t, driverName)
+	defer teardown()
+
+	mockDB.ExpectQuery(selectLastTransactionDateSql).WithArgs(dummyAccountId).
+		WillReturnRows(sqlmock.NewRows([]string{"transaction_date"}))
+
+	//Act
+	lastTransactionDate, err := fraudRepoDb.FindLastTransactionDate(dummyAccountId)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing account without transactions: " + err.Message)
+	}
+	if lastTransactionDate != "" {
+		t.Errorf("Expected no last transaction date but got %s", lastTransactionDate)
+	}
+}
+
+func TestFraudRepositoryDb_FindLastTransactionDate_returns_error_when_select_fails(t *testing.T) {
+	//Arrange
+	teardown := setupFraudRepoDbTest(t, driverName)
+	defer teardown()
+
+	dummyDbErr := errors.New("some error message")
+	mockDB.ExpectQuery(selectLastTransactionDateSql).WithArgs(dummyAccountId).WillReturnError(dummyDbErr)
+
+	logs := logger.ReplaceWithTestLogger()
+	expectedLogMessage := "Error while retrieving last transaction date for fraud screening: " + dummyDbErr.Error()
+
+	//Act
+	_, err := fraudRepoDb.FindLastTransactionDate(dummyAccountId)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing failed retrieval of last transaction date")
+	}
+	if err.Message != defaultExpectedErrMessage {
+		t.Errorf("Expected error message to be \"%s\" but got \"%s\"", defaultExpectedErrMessage, err.Message)
+	}
+	if logs.Len() != 1 || logs.All()[0].Message != expectedLogMessage {
+		t.Errorf("Expected log message to be \"%s\" but got %v", expectedLogMessage, logs.All())
+	}
+}
+
+func TestFraudRepositoryDb_SaveDecision_returns_decision_with_newId(t *testing.T) {
+	tests := []struct {
+		driverName string
+		insertSql  string
+	}{
+		{DriverMySQL, insertFraudDecisionsSql},
+		{DriverPostgres, insertFraudDecisionsPostgresSql},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.driverName, func(t *testing.T) {
+			//Arrange
+			teardown := setupFraudRepoDbTest(t, tc.driverName)
+			defer teardown()
+
+			decision := FraudDecision{AccountId: dummyAccountId, Amount: 9600, TransactionType: dto.TransactionTypeWithdrawal,
+				Decision: FraudDecisionReview, TriggeredRules: FraudRuleNearLimitAmount, Reasons: "amount just under the limit", DecidedOn: dummyDate}
+			expectInsert(tc.driverName, tc.insertSql, "decision_id", 12, decision.AccountId, decision.Amount,
+				decision.TransactionType, decision.Decision, decision.TriggeredRules, decision.Reasons, decision.DecidedOn)
+
+			//Act
+			actualDecision, err := fraudRepoDb.SaveDecision(decision)
+
+			//Assert
+			if err != nil {
+				t.Fatal("Expected no error but got error while testing successful saving of fraud decision: " + err.Message)
+			}
+			if actualDecision.DecisionId != "12" {
+				t.Errorf("Expected decision id 12 but got %s", actualDecision.DecisionId)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"strconv"
+	"sync"
+)
+
+//Server
+
+type FraudRepositoryStub struct { //stub (adapter)
+	accounts AccountRepositoryStub //the transaction history of accounts is read from here
+	store    *fraudStore           //shared by all copies of the stub, so that changes made through one copy are seen by all
+}
+
+// fraudStore holds the fraud decisions of a FraudRepositoryStub in memory. It is safe for concurrent use.
+type fraudStore struct {
+	mu             sync.Mutex
+	decisions      []FraudDecision
+	nextDecisionId int64
+}
+
+func NewFraudRepositoryStub(accounts AccountRepositoryStub) FraudRepositoryStub { //helper function to create and initialize a stub
+	return FraudRepositoryStub{accounts, &fraudStore{decisions: make([]FraudDecision, 0), nextDecisionId: 1}}
+}
+
+func (s FraudRepositoryStub) FindTransactionsSince(accountId string, since string) ([]Transaction, *errs.AppError) { //stub implements repo
+	history, _ := s.accounts.FindTransactions(accountId) //the stub never fails
+	transactions := make([]Transaction, 0)
+	for _, t := range history {
+		if t.TransactionDate >= since { //dates in the same format sort in time order
+			transactions = append(transactions, t)
+		}
+	}
+	return transactions, nil
+}
+
+func (s FraudRepositoryStub) FindLastTransactionDate(accountId string) (string, *errs.AppError) { //stub implements repo
+	history, _ := s.accounts.FindTransactions(accountId) //the stub never fails
+	lastTransactionDate := ""
+	for _, t := range history {
+		if t.TransactionDate > lastTransactionDate {
+			lastTransactionDate = t.TransactionDate
+		}
+	}
+	return lastTransactionDate, nil
+}
+
+func (s FraudRepositoryStub) SaveDecision(decision FraudDecision) (*FraudDecision, *errs.AppError) { //stub implements repo
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	decision.DecisionId = strconv.FormatInt(s.store.nextDecisionId, 10)
+	s.store.nextDecisionId++
+	s.store.decisions = append(s.store.decisions, decision)
+
+	return &decision, nil
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"testing"
+)
+
+func TestFraudRepositoryStub_reads_history_from_accountRepositoryStub(t *testing.T) {
+	//Arrange
+	accounts := NewAccountRepositoryStub()
+	stub := NewFraudRepositoryStub(accounts)
+	for _, date := range []string{"2006-01-02 14:50:00", "2006-01-02 15:00:00"} {
+		accounts.Transact(Transaction{AccountId: "95471", Amount: 10, TransactionType: dto.TransactionTypeWithdrawal, TransactionDate: date})
+	}
+
+	//Act
+	recentTransactions, recentErr := stub.FindTransactionsSince("95471", "2006-01-02 14:55:05")
+	lastTransactionDate, lastErr := stub.FindLastTransactionDate("95471")
+	noTransactionDate, _ := stub.FindLastTransactionDate("95470")
+
+	//Assert
+	if recentErr != nil || lastErr != nil {
+		t.Fatal("Expected no error but got error while testing retrieval of account history")
+	}
+	if len(recentTransactions) != 1 || recentTransactions[0].TransactionDate != "2006-01-02 15:00:00" {
+		t.Errorf("Expected only the transaction made at 15:00:00 but got %v", recentTransactions)
+	}
+	if lastTransactionDate != "2006-01-02 15:00:00" {
+		t.Errorf("Expected last transaction date 2006-01-02 15:00:00 but got %s", lastTransactionDate)
+	}
+	if noTransactionDate != "" {
+		t.Errorf("Expected no last transaction date for account without transactions but got %s", noTransactionDate)
+	}
+}
+
+func TestFraudRepositoryStub_SaveDecision_assigns_newDecisionIds(t *testing.T) {
+	//Arrange
+	stub := NewFraudRepositoryStub(NewAccountRepositoryStub())
+	decision := FraudDecision{AccountId: dummyAccountId, Decision: FraudDecisionAllow}
+
+	//Act
+	first, _ := stub.SaveDecision(decision)
+	second, _ := stub.SaveDecision(decision)
+
+	//Assert
+	if first.DecisionId != "1" || second.DecisionId != "2" {
+		t.Errorf("Expected decision ids 1 and 2 but got %s and %s", first.DecisionId, second.DecisionId)
+	}
+}
@@ -0,0 +1,158 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"testing"
+	"time"
+)
+
+// getDummyFraudCheck returns a check of a withdrawal of the given amount made on dummyDate on an account opened a
+// year before, whose last transaction was a month before
+func getDummyFraudCheck(amount float64) FraudCheck {
+	return FraudCheck{
+		Account:             Account{AccountId: dummyAccountId, OpeningDate: "2005-01-02 15:04:05"},
+		Transaction:         Transaction{AccountId: dummyAccountId, Amount: amount, TransactionType: dto.TransactionTypeWithdrawal, TransactionDate: dummyDate},
+		LastTransactionDate: "2005-12-02 15:04:05",
+	}
+}
+
+func TestVelocityRule_Evaluate_counts_withdrawals_within_window_only(t *testing.T) {
+	//Arrange
+	rule := VelocityRule{MaxWithdrawals: 2, Window: 10 * time.Minute, Decision: FraudDecisionBlock}
+	tests := []struct {
+		name             string
+		recent           []Transaction
+		expectedDecision string
+	}{
+		{"one recent withdrawal", []Transaction{{TransactionType: dto.TransactionTypeWithdrawal, TransactionDate: "2006-01-02 15:00:00"}}, FraudDecisionAllow},
+		{"two recent withdrawals", []Transaction{
+			{TransactionType: dto.TransactionTypeWithdrawal, TransactionDate: "2006-01-02 14:55:00"},
+			{TransactionType: dto.TransactionTypeWithdrawal, TransactionDate: "2006-01-02 15:00:00"},
+		}, FraudDecisionBlock},
+		{"deposits and old withdrawal", []Transaction{
+			{TransactionType: dto.TransactionTypeWithdrawal, TransactionDate: "2006-01-02 14:50:00"},
+			{TransactionType: dto.TransactionTypeDeposit, TransactionDate: "2006-01-02 15:00:00"},
+			{TransactionType: dto.TransactionTypeDeposit, TransactionDate: "2006-01-02 15:01:00"},
+		}, FraudDecisionAllow},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			check := getDummyFraudCheck(100)
+			check.RecentTransactions = tc.recent
+
+			//Act
+			verdict := rule.Evaluate(check)
+
+			//Assert
+			if verdict.Decision != tc.expectedDecision {
+				t.Errorf("Expected decision %s but got %s (%s)", tc.expectedDecision, verdict.Decision, verdict.Reason)
+			}
+		})
+	}
+}
+
+func TestFraudRules_Evaluate_trigger_on_their_condition(t *testing.T) {
+	tests := []struct {
+		name             string
+		rule             FraudRule
+		modify           func(*FraudCheck)
+		expectedDecision string
+	}{
+		{"withdrawal long after opening", NewAccountWithdrawalRule{time.Hour, FraudDecisionReview}, func(c *FraudCheck) {}, FraudDecisionAllow},
+		{"withdrawal right after opening", NewAccountWithdrawalRule{time.Hour, FraudDecisionReview},
+			func(c *FraudCheck) { c.Account.OpeningDate = "2006-01-02 15:00:00" }, FraudDecisionReview},
+		{"deposit right after opening", NewAccountWithdrawalRule{time.Hour, FraudDecisionReview},
+			func(c *FraudCheck) {
+				c.Account.OpeningDate = "2006-01-02 15:00:00"
+				c.Transaction.TransactionType = dto.TransactionTypeDeposit
+			}, FraudDecisionAllow},
+		{"amount below margin", NearLimitAmountRule{500, FraudDecisionReview}, func(c *FraudCheck) { c.Transaction.Amount = 9499.99 }, FraudDecisionAllow},
+		{"amount within margin", NearLimitAmountRule{500, FraudDecisionReview}, func(c *FraudCheck) { c.Transaction.Amount = 9500 }, FraudDecisionReview},
+		{"amount at limit", NearLimitAmountRule{500, FraudDecisionReview}, func(c *FraudCheck) { c.Transaction.Amount = 10000 }, FraudDecisionReview},
+		{"recently active account", DormantAccountRule{180 * 24 * time.Hour, FraudDecisionReview}, func(c *FraudCheck) {}, FraudDecisionAllow},
+		{"dormant account", DormantAccountRule{180 * 24 * time.Hour, FraudDecisionReview},
+			func(c *FraudCheck) { c.LastTransactionDate = "2005-06-01 00:00:00" }, FraudDecisionReview},
+		{"account without transactions opened long ago", DormantAccountRule{180 * 24 * time.Hour, FraudDecisionReview},
+			func(c *FraudCheck) { c.LastTransactionDate = "" }, FraudDecisionReview},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Arrange
+			check := getDummyFraudCheck(100)
+			tc.modify(&check)
+
+			//Act
+			verdict := tc.rule.Evaluate(check)
+
+			//Assert
+			if verdict.Decision != tc.expectedDecision {
+				t.Errorf("Expected decision %s but got %s (%s)", tc.expectedDecision, verdict.Decision, verdict.Reason)
+			}
+		})
+	}
+}
+
+func TestFraudEngine_Screen_returns_mostSevere_decision_with_all_reasons(t *testing.T) {
+	//Arrange
+	engine := NewFraudEngine(
+		NearLimitAmountRule{500, FraudDecisionReview},
+		VelocityRule{1, 10 * time.Minute, FraudDecisionBlock},
+		DormantAccountRule{180 * 24 * time.Hour, FraudDecisionReview},
+	)
+	check := getDummyFraudCheck(9600)
+	check.RecentTransactions = []Transaction{{TransactionType: dto.TransactionTypeWithdrawal, TransactionDate: "2006-01-02 15:00:00"}}
+
+	//Act
+	decision := engine.Screen(check, clock.StaticClock{})
+
+	//Assert
+	if !decision.IsBlocked() {
+		t.Errorf("Expected decision %s but got %s", FraudDecisionBlock, decision.Decision)
+	}
+	if decision.TriggeredRules != FraudRuleNearLimitAmount+","+FraudRuleVelocity {
+		t.Errorf("Expected near limit and velocity rules to be triggered but got %s", decision.TriggeredRules)
+	}
+	if engine.Lookback() != 10*time.Minute {
+		t.Errorf("Expected lookback of 10m but got %s", engine.Lookback())
+	}
+}
+
+func TestNewFraudRules_returns_error_when_config_invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		config FraudRuleConfig
+	}{
+		{"unknown rule", FraudRuleConfig{Rule: "night_owl", Decision: FraudDecisionReview}},
+		{"allow decision", FraudRuleConfig{Rule: FraudRuleNearLimitAmount, Decision: FraudDecisionAllow, Margin: 500}},
+		{"velocity without window", FraudRuleConfig{Rule: FraudRuleVelocity, Decision: FraudDecisionBlock, MaxWithdrawals: 5}},
+		{"margin above limit", FraudRuleConfig{Rule: FraudRuleNearLimitAmount, Decision: FraudDecisionReview, Margin: 20000}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Act
+			_, err := NewFraudRules([]FraudRuleConfig{tc.config})
+
+			//Assert
+			if err == nil {
+				t.Errorf("Expected error but got none while testing invalid fraud rule config %v", tc.config)
+			}
+		})
+	}
+}
+
+func TestNewFraudRules_creates_default_rules(t *testing.T) {
+	//Act
+	rules, err := NewFraudRules(DefaultFraudRuleConfigs())
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing default fraud rules: " + err.Error())
+	}
+	if len(rules) != 4 {
+		t.Errorf("Expected 4 default rules but got %d", len(rules))
+	}
+}
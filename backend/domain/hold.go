@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"database/sql"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"time"
+)
+
+//Business Domain
+
+// Hold sets funds of an account aside without withdrawing them, e.g. for a pending card authorization or a cheque
+// that is clearing. While it is active, the funds are not available to withdraw. It ends when it is captured, which
+// withdraws the funds, when it is released or when it expires.
+type Hold struct { //business/domain object
+	HoldId        string         `db:"hold_id"`
+	AccountId     string         `db:"account_id"`
+	Amount        float64        `db:"amount"`
+	Reason        string         `db:"reason"`
+	Status        string         `db:"status"`
+	PlacedOn      string         `db:"placed_on"`
+	ExpiresOn     string         `db:"expires_on"`
+	ResolvedOn    sql.NullString `db:"resolved_on"`    //null while active
+	TransactionId sql.NullString `db:"transaction_id"` //the withdrawal that captured the hold, null otherwise
+}
+
+func NewHold(request dto.NewHoldRequest, c clock.Clock) Hold {
+	durationHours := request.DurationHours
+	if durationHours == 0 {
+		durationHours = dto.HoldDefaultDurationHours
+	}
+	return Hold{
+		AccountId: request.AccountId,
+		Amount:    request.Amount,
+		Reason:    request.Reason,
+		Status:    dto.HoldStatusActive,
+		PlacedOn:  c.NowAsString(),
+		ExpiresOn: c.Now().Add(time.Duration(durationHours) * time.Hour).Format(clock.FormatDateTime),
+	}
+}
+
+func (h Hold) IsActive() bool {
+	return h.Status == dto.HoldStatusActive
+}
+
+// Resolve returns the hold as ended with the given status at the current time.
+func (h Hold) Resolve(status string, c clock.Clock) Hold {
+	h.Status = status
+	h.ResolvedOn = sql.NullString{String: c.NowAsString(), Valid: true}
+	return h
+}
+
+// ToWithdrawal returns the withdrawal of the held funds to be posted on capture of the hold, dated at the current time.
+func (h Hold) ToWithdrawal(c clock.Clock) Transaction {
+	return NewTransaction(h.AccountId, h.Amount, dto.TransactionTypeWithdrawal, c)
+}
+
+func (h Hold) ToDTO() dto.HoldResponse {
+	return dto.HoldResponse{
+		HoldId:        h.HoldId,
+		AccountId:     h.AccountId,
+		Amount:        h.Amount,
+		Reason:        h.Reason,
+		Status:        h.Status,
+		PlacedOn:      h.PlacedOn,
+		ExpiresOn:     h.ExpiresOn,
+		ResolvedOn:    h.ResolvedOn.String,
+		TransactionId: h.TransactionId.String,
+	}
+}
+
+//Server
+
+//go:generate mockgen -destination=../mocks/domain/mock_holdRepository.go -package=domain github.com/aliciatay-zls/banking/backend/domain HoldRepository
+type HoldRepository interface { //repo (secondary port)
+	Save(Hold) (*Hold, *errs.AppError)
+	FindById(string) (*Hold, *errs.AppError)
+	FindAll(string) ([]Hold, *errs.AppError)
+	FindHeldAmount(string, string) (float64, *errs.AppError)
+	ExpireActive(string) *errs.AppError
+	UpdateStatus(Hold, string) *errs.AppError
+}
@@ -0,0 +1,160 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/problem"
+	"github.com/jmoiron/sqlx"
+	"strconv"
+)
+
+//Server
+
+type HoldRepositoryDb struct { //DB (adapter)
+	client *sqlx.DB
+}
+
+func NewHoldRepositoryDb(dbClient *sqlx.DB) HoldRepositoryDb {
+	return HoldRepositoryDb{dbClient}
+}
+
+// Save creates a new entry in the database for the given hold and returns it with its database-generated ID set. The
+// available balance of the account is checked again within the same database transaction, so that two holds placed
+// at once cannot together set aside more than is available.
+func (d HoldRepositoryDb) Save(h Hold) (*Hold, *errs.AppError) {
+	tx, err := d.client.Beginx()
+	if err != nil {
+		logger.Error("Error while starting db transaction for placing hold: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	canSetAside, appErr := canSetAsideFunds(tx, h.AccountId, h.Amount, h.PlacedOn)
+	if appErr != nil {
+		rollbackHold(tx)
+		return nil, appErr
+	}
+	if !canSetAside {
+		logger.Error("Amount to hold exceeds available account balance")
+		rollbackHold(tx)
+		return nil, problem.NewValidationError(problem.InsufficientFunds,
+			"Account balance insufficient to hold given amount")
+	}
+
+	insertSql := "INSERT INTO holds (account_id, amount, reason, status, placed_on, expires_on) VALUES (?, ?, ?, ?, ?, ?)"
+	result, err := execInsert(tx, insertSql, "hold_id",
+		h.AccountId, h.Amount, h.Reason, h.Status, h.PlacedOn, h.ExpiresOn)
+	if err != nil {
+		logger.Error("Error while creating new hold: " + err.Error())
+		rollbackHold(tx)
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		logger.Error("Error while getting id of newly inserted hold: " + err.Error())
+		rollbackHold(tx)
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	h.HoldId = strconv.FormatInt(id, 10)
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("Error while committing db transaction for placing hold: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return &h, nil
+}
+
+func (d HoldRepositoryDb) FindById(holdId string) (*Hold, *errs.AppError) {
+	var hold Hold
+	findSql := d.selectHoldsSql() + " WHERE hold_id = ?"
+	if err := d.client.Get(&hold, d.client.Rebind(findSql), holdId); err != nil {
+		logger.Error("Error while retrieving hold: " + err.Error())
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, problem.NewNotFoundError(problem.HoldNotFound, "Hold not found")
+		}
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return &hold, nil
+}
+
+// FindAll retrieves all holds placed on the account with the given id, oldest first.
+func (d HoldRepositoryDb) FindAll(accountId string) ([]Hold, *errs.AppError) {
+	holds := make([]Hold, 0)
+	findSql := d.selectHoldsSql() + " WHERE account_id = ? ORDER BY hold_id"
+	if err := d.client.Select(&holds, d.client.Rebind(findSql), accountId); err != nil {
+		logger.Error("Error while retrieving holds of account: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return holds, nil
+}
+
+// FindHeldAmount retrieves the total amount of the holds on the account with the given id that are active at the
+// given time, counting those that have expired as released even if they have not been marked as expired yet.
+func (d HoldRepositoryDb) FindHeldAmount(accountId string, now string) (float64, *errs.AppError) {
+	held, err := selectHeldAmount(d.client, accountId, now)
+	if err != nil {
+		logger.Error("Error while retrieving held amount of account: " + err.Error())
+		return 0, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return held, nil
+}
+
+// selectHeldAmount sums the holds on the account with the given id that are active at the given time, with the given
+// database handle or transaction.
+func selectHeldAmount(e sqlx.Ext, accountId string, now string) (float64, error) {
+	var held float64
+	findSql := "SELECT COALESCE(SUM(amount), 0) FROM holds WHERE account_id = ? AND status = ? AND expires_on > ?"
+	err := sqlx.Get(e, &held, e.Rebind(findSql), accountId, dto.HoldStatusActive, now)
+	return held, err
+}
+
+// ExpireActive marks the active holds that expire at or before the given time as expired, so that they can no longer
+// be captured.
+func (d HoldRepositoryDb) ExpireActive(now string) *errs.AppError {
+	expireSql := "UPDATE holds SET status = ?, resolved_on = expires_on WHERE status = ? AND expires_on <= ?"
+	_, err := d.client.Exec(d.client.Rebind(expireSql), dto.HoldStatusExpired, dto.HoldStatusActive, now)
+	if err != nil {
+		logger.Error("Error while expiring holds: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
+
+// UpdateStatus sets the status, resolution date and capturing transaction of the given hold, provided that it is still
+// in the given status. This way, a hold captured or released twice at once is only ended by the first request.
+func (d HoldRepositoryDb) UpdateStatus(h Hold, fromStatus string) *errs.AppError {
+	updateSql := "UPDATE holds SET status = ?, resolved_on = ?, transaction_id = ? WHERE hold_id = ? AND status = ?"
+	result, err := d.client.Exec(d.client.Rebind(updateSql),
+		h.Status, h.ResolvedOn, h.TransactionId, h.HoldId, fromStatus)
+	if err != nil {
+		logger.Error("Error while updating hold: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	if rowsAffected, err := result.RowsAffected(); err != nil || rowsAffected == 0 {
+		logger.Error("Error while updating hold: hold is no longer " + fromStatus)
+		return problem.NewConflictError(problem.HoldNotActive, "Hold is no longer active")
+	}
+
+	return nil
+}
+
+func (d HoldRepositoryDb) selectHoldsSql() string {
+	return "SELECT hold_id, account_id, amount, reason, status, " +
+		dateTimeColumn(d.client.DriverName(), "placed_on") + ", " +
+		dateTimeColumn(d.client.DriverName(), "expires_on") + ", " +
+		dateTimeColumn(d.client.DriverName(), "resolved_on") + ", transaction_id FROM holds"
+}
+
+func rollbackHold(tx *sqlx.Tx) {
+	if rollbackErr := tx.Rollback(); rollbackErr != nil {
+		logger.Fatal("Error while rolling back placing of hold: " + rollbackErr.Error())
+	}
+}
@@ -0,0 +1,172 @@
+package domain
+
+import (
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/jmoiron/sqlx"
+	"net/http"
+	"testing"
+)
+
+// Test common variables and inputs
+var holdRepoDb HoldRepositoryDb
+
+const insertHoldsSql = "INSERT INTO holds (account_id, amount, reason, status, placed_on, expires_on) VALUES (?, ?, ?, ?, ?, ?)"
+const insertHoldsPostgresSql = "INSERT INTO holds (account_id, amount, reason, status, placed_on, expires_on) VALUES ($1, $2, $3, $4, $5, $6) RETURNING hold_id"
+const selectHoldByIdPostgresSql = "SELECT hold_id, account_id, amount, reason, status, to_char(placed_on, 'YYYY-MM-DD HH24:MI:SS') AS placed_on, to_char(expires_on, 'YYYY-MM-DD HH24:MI:SS') AS expires_on, to_char(resolved_on, 'YYYY-MM-DD HH24:MI:SS') AS resolved_on, transaction_id FROM holds WHERE hold_id = $1"
+const selectHeldAmountSql = "SELECT COALESCE(SUM(amount), 0) FROM holds WHERE account_id = ? AND status = ? AND expires_on > ?"
+const updateHoldsSql = "UPDATE holds SET status = ?, resolved_on = ?, transaction_id = ? WHERE hold_id = ? AND status = ?"
+
+func setupHoldRepoDbTest(t *testing.T, driverName string) func() {
+	teardown := setupDB(t)
+	holdRepoDb = NewHoldRepositoryDb(sqlx.NewDb(db, driverName))
+	return teardown
+}
+
+// getDefaultHold returns an active hold of 500 on the account with id 1977 that expires a week after it was placed
+func getDefaultHold() Hold {
+	return Hold{
+		AccountId: dummyAccountId,
+		Amount:    500,
+		Reason:    "Card authorization",
+		Status:    dto.HoldStatusActive,
+		PlacedOn:  dummyDate,
+		ExpiresOn: "2006-01-09 15:04:05",
+	}
+}
+
+func TestHoldRepositoryDb_Save_returns_hold_with_newId(t *testing.T) {
+	tests := []struct {
+		driverName string
+		insertSql  string
+	}{
+		{DriverMySQL, insertHoldsSql},
+		{DriverPostgres, insertHoldsPostgresSql},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.driverName, func(t *testing.T) {
+			//Arrange
+			teardown := setupHoldRepoDbTest(t, tc.driverName)
+			defer teardown()
+
+			h := getDefaultHold()
+			mockDB.ExpectBegin()
+			expectCanSetAsideFunds(tc.driverName, h.AccountId, dummyBalance, 0, 0, h.PlacedOn)
+			expectInsert(tc.driverName, tc.insertSql, "hold_id", 4, h.AccountId, h.Amount, h.Reason, h.Status,
+				h.PlacedOn, h.ExpiresOn)
+			mockDB.ExpectCommit()
+
+			//Act
+			savedHold, err := holdRepoDb.Save(h)
+
+			//Assert
+			if err != nil {
+				t.Fatal("Expected no error but got error while testing successful saving of hold: " + err.Message)
+			}
+			if savedHold.HoldId != "4" {
+				t.Errorf("Expected hold id 4 but got %s", savedHold.HoldId)
+			}
+		})
+	}
+}
+
+func TestHoldRepositoryDb_Save_returns_validationError_and_rolls_back_when_amount_no_longer_available(t *testing.T) {
+	//Arrange
+	teardown := setupHoldRepoDbTest(t, driverName)
+	defer teardown()
+
+	h := getDefaultHold()
+	mockDB.ExpectBegin()
+	expectCanSetAsideFunds(driverName, h.AccountId, 1000, 400, 200, h.PlacedOn) //only 400 left for the hold of 500
+	mockDB.ExpectRollback()
+
+	logs := logger.ReplaceWithTestLogger()
+
+	//Act
+	_, err := holdRepoDb.Save(h)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing hold exceeding available balance")
+	}
+	if err.Code != http.StatusUnprocessableEntity || err.Message != "Account balance insufficient to hold given amount" {
+		t.Errorf("Expected status code %d and message \"Account balance insufficient to hold given amount\" but got %d and \"%s\"",
+			http.StatusUnprocessableEntity, err.Code, err.Message)
+	}
+	if logs.Len() != 1 {
+		t.Fatalf("Expected 1 log message but got %d", logs.Len())
+	}
+	if mockErr := mockDB.ExpectationsWereMet(); mockErr != nil {
+		t.Error(mockErr)
+	}
+}
+
+func TestHoldRepositoryDb_FindById_returns_notFoundError_when_noRows(t *testing.T) {
+	//Arrange
+	teardown := setupHoldRepoDbTest(t, DriverPostgres)
+	defer teardown()
+
+	mockDB.ExpectQuery(selectHoldByIdPostgresSql).WithArgs("4").WillReturnError(sql.ErrNoRows)
+	logger.MuteLogger()
+
+	//Act
+	_, err := holdRepoDb.FindById("4")
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing retrieval of missing hold")
+	}
+	if err.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d but got %d", http.StatusNotFound, err.Code)
+	}
+}
+
+func TestHoldRepositoryDb_FindHeldAmount_returns_total_of_unexpired_activeHolds(t *testing.T) {
+	//Arrange
+	teardown := setupHoldRepoDbTest(t, driverName)
+	defer teardown()
+
+	mockDB.ExpectQuery(selectHeldAmountSql).
+		WithArgs(dummyAccountId, dto.HoldStatusActive, dummyDate).
+		WillReturnRows(sqlmock.NewRows([]string{"COALESCE(SUM(amount), 0)"}).AddRow(750))
+
+	//Act
+	held, err := holdRepoDb.FindHeldAmount(dummyAccountId, dummyDate)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing retrieval of held amount: " + err.Message)
+	}
+	if held != 750 {
+		t.Errorf("Expected held amount 750 but got %v", held)
+	}
+}
+
+func TestHoldRepositoryDb_UpdateStatus_returns_conflictError_when_hold_no_longer_inStatus(t *testing.T) {
+	//Arrange
+	teardown := setupHoldRepoDbTest(t, driverName)
+	defer teardown()
+
+	h := getDefaultHold()
+	h.HoldId = "4"
+	h.Status = dto.HoldStatusReleased
+	h.ResolvedOn = sql.NullString{String: dummyDate, Valid: true}
+	mockDB.ExpectExec(updateHoldsSql).
+		WithArgs(h.Status, h.ResolvedOn, h.TransactionId, h.HoldId, dto.HoldStatusActive).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	logger.MuteLogger()
+
+	//Act
+	err := holdRepoDb.UpdateStatus(h, dto.HoldStatusActive)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing update of hold that has already ended")
+	}
+	if err.Code != http.StatusConflict {
+		t.Errorf("Expected status code %d but got %d", http.StatusConflict, err.Code)
+	}
+}
@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"database/sql"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/problem"
+	"strconv"
+	"sync"
+)
+
+//Server
+
+type HoldRepositoryStub struct { //stub (adapter)
+	store *holdStore //shared by all copies of the stub, so that changes made through one copy are seen by all
+}
+
+// holdStore holds the holds of a HoldRepositoryStub in memory. It is safe for concurrent use.
+type holdStore struct {
+	mu         sync.Mutex
+	holds      []Hold
+	nextHoldId int64
+}
+
+func NewHoldRepositoryStub() HoldRepositoryStub { //helper function to create and initialize a stub
+	return HoldRepositoryStub{&holdStore{holds: make([]Hold, 0), nextHoldId: 1}}
+}
+
+func (s HoldRepositoryStub) Save(h Hold) (*Hold, *errs.AppError) { //stub implements repo
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	h.HoldId = strconv.FormatInt(s.store.nextHoldId, 10)
+	s.store.nextHoldId++
+	s.store.holds = append(s.store.holds, h)
+
+	return &h, nil
+}
+
+func (s HoldRepositoryStub) FindById(holdId string) (*Hold, *errs.AppError) { //stub implements repo
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	for _, h := range s.store.holds {
+		if h.HoldId == holdId {
+			return &h, nil
+		}
+	}
+	logger.Error("Error while retrieving hold using stub for HoldRepository: hold not found")
+	return nil, problem.NewNotFoundError(problem.HoldNotFound, "Hold not found")
+}
+
+func (s HoldRepositoryStub) FindAll(accountId string) ([]Hold, *errs.AppError) { //stub implements repo
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	holds := make([]Hold, 0)
+	for _, h := range s.store.holds {
+		if h.AccountId == accountId {
+			holds = append(holds, h)
+		}
+	}
+	return holds, nil
+}
+
+func (s HoldRepositoryStub) FindHeldAmount(accountId string, now string) (float64, *errs.AppError) { //stub implements repo
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	var held float64
+	for _, h := range s.store.holds {
+		if h.AccountId == accountId && h.IsActive() && h.ExpiresOn > now {
+			held += h.Amount
+		}
+	}
+	return held, nil
+}
+
+func (s HoldRepositoryStub) ExpireActive(now string) *errs.AppError { //stub implements repo
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	for i, h := range s.store.holds {
+		if h.IsActive() && h.ExpiresOn <= now {
+			s.store.holds[i].Status = dto.HoldStatusExpired
+			s.store.holds[i].ResolvedOn = sql.NullString{String: h.ExpiresOn, Valid: true}
+		}
+	}
+	return nil
+}
+
+func (s HoldRepositoryStub) UpdateStatus(h Hold, fromStatus string) *errs.AppError { //stub implements repo
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	for i, stored := range s.store.holds {
+		if stored.HoldId != h.HoldId {
+			continue
+		}
+		if stored.Status != fromStatus {
+			break
+		}
+		s.store.holds[i].Status = h.Status
+		s.store.holds[i].ResolvedOn = h.ResolvedOn
+		s.store.holds[i].TransactionId = h.TransactionId
+		return nil
+	}
+	logger.Error("Error while updating hold using stub for HoldRepository: hold is no longer " + fromStatus)
+	return problem.NewConflictError(problem.HoldNotActive, "Hold is no longer active")
+}
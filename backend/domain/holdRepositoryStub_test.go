@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"net/http"
+	"testing"
+)
+
+func TestHoldRepositoryStub_FindHeldAmount_counts_unexpired_activeHolds_only(t *testing.T) {
+	//Arrange
+	stub := NewHoldRepositoryStub()
+	expiring := getDefaultHold()
+	expiring.ExpiresOn = dummyDate
+	otherAccount := getDefaultHold()
+	otherAccount.AccountId = "1978"
+	for _, h := range []Hold{getDefaultHold(), expiring, otherAccount, getDefaultHold()} {
+		stub.Save(h)
+	}
+	released, _ := stub.FindById("4")
+	stub.UpdateStatus(released.Resolve(dto.HoldStatusReleased, clock.StaticClock{}), dto.HoldStatusActive)
+
+	//Act
+	held, err := stub.FindHeldAmount(dummyAccountId, dummyDate)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing retrieval of held amount: " + err.Message)
+	}
+	if held != 500 {
+		t.Errorf("Expected only hold 1 to be counted but got held amount %v", held)
+	}
+}
+
+func TestHoldRepositoryStub_ExpireActive_expires_activeHolds_past_expiry_only(t *testing.T) {
+	//Arrange
+	stub := NewHoldRepositoryStub()
+	expiring := getDefaultHold()
+	expiring.ExpiresOn = dummyDate
+	for _, h := range []Hold{expiring, getDefaultHold()} {
+		stub.Save(h)
+	}
+
+	//Act
+	err := stub.ExpireActive(dummyDate)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing expiry of holds: " + err.Message)
+	}
+	holds, _ := stub.FindAll(dummyAccountId)
+	if holds[0].Status != dto.HoldStatusExpired || holds[0].ResolvedOn.String != dummyDate || !holds[1].IsActive() {
+		t.Errorf("Expected hold 1 expired and hold 2 left active but got %v", holds)
+	}
+}
+
+func TestHoldRepositoryStub_UpdateStatus_returns_conflictError_when_hold_already_ended(t *testing.T) {
+	//Arrange
+	stub := NewHoldRepositoryStub()
+	saved, _ := stub.Save(getDefaultHold())
+	captured := saved.Resolve(dto.HoldStatusCaptured, clock.StaticClock{})
+	logger.MuteLogger()
+
+	//Act
+	firstErr := stub.UpdateStatus(captured, dto.HoldStatusActive)
+	secondErr := stub.UpdateStatus(captured, dto.HoldStatusActive)
+
+	//Assert
+	if firstErr != nil {
+		t.Fatal("Expected no error but got error while testing first capture: " + firstErr.Message)
+	}
+	if secondErr == nil || secondErr.Code != http.StatusConflict {
+		t.Errorf("Expected conflict error for second capture but got %v", secondErr)
+	}
+}
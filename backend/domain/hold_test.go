@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"testing"
+)
+
+func TestNewHold_is_active_and_expires_after_requestedDuration(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name              string
+		durationHours     int
+		expectedExpiresOn string
+	}{
+		{"default duration", 0, "2006-01-09 15:04:05"},
+		{"given duration", 48, "2006-01-04 15:04:05"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := dto.NewHoldRequest{AccountId: dummyAccountId, Amount: 500, Reason: "Card authorization", DurationHours: tc.durationHours}
+
+			//Act
+			hold := NewHold(request, clock.StaticClock{})
+
+			//Assert
+			if !hold.IsActive() || hold.PlacedOn != dummyDate || hold.ExpiresOn != tc.expectedExpiresOn {
+				t.Errorf("Expected hold active from %s until %s but got %v", dummyDate, tc.expectedExpiresOn, hold)
+			}
+		})
+	}
+}
+
+func TestHold_Resolve_ends_hold_at_currentTime(t *testing.T) {
+	//Arrange
+	hold := Hold{HoldId: "4", AccountId: dummyAccountId, Amount: 500, Status: dto.HoldStatusActive}
+
+	//Act
+	released := hold.Resolve(dto.HoldStatusReleased, clock.StaticClock{})
+
+	//Assert
+	response := released.ToDTO()
+	if released.IsActive() || response.Status != dto.HoldStatusReleased || response.ResolvedOn != dummyDate {
+		t.Errorf("Expected hold released on %s but got %v", dummyDate, response)
+	}
+	if hold.ToDTO().ResolvedOn != "" {
+		t.Errorf("Expected original hold to be left active but got %v", hold)
+	}
+}
+
+func TestHold_ToWithdrawal_withdraws_heldFunds(t *testing.T) {
+	//Arrange
+	hold := Hold{HoldId: "4", AccountId: dummyAccountId, Amount: 500, Status: dto.HoldStatusActive}
+
+	//Act
+	transaction := hold.ToWithdrawal(clock.StaticClock{})
+
+	//Assert
+	if !transaction.IsWithdrawal() || transaction.AccountId != dummyAccountId || transaction.Amount != 500 {
+		t.Errorf("Expected withdrawal of 500 from account %s but got %v", dummyAccountId, transaction)
+	}
+}
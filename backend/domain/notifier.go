@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"net/smtp"
+	"strings"
+)
+
+//Server
+
+type InboxNotifier struct { //in-app inbox (adapter)
+	repo AlertRepository
+}
+
+func NewInboxNotifier(repo AlertRepository) InboxNotifier {
+	return InboxNotifier{repo}
+}
+
+// Notify puts the alert in the customer's in-app inbox.
+func (n InboxNotifier) Notify(alert Alert) *errs.AppError { //inbox implements notifier
+	_, appErr := n.repo.SaveAlert(alert)
+	return appErr
+}
+
+type SmtpNotifier struct { //SMTP (adapter)
+	address      string    //host:port of the mail server
+	auth         smtp.Auth //nil for mail servers that do not need authentication, such as MailHog
+	from         string
+	customerRepo CustomerRepository
+}
+
+// NewSmtpNotifier creates a notifier that emails alerts through the mail server at the given address. If username is
+// empty, no authentication is attempted.
+func NewSmtpNotifier(address string, username string, password string, from string, customerRepo CustomerRepository) SmtpNotifier {
+	var auth smtp.Auth
+	if username != "" {
+		host, _, _ := strings.Cut(address, ":")
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return SmtpNotifier{address, auth, from, customerRepo}
+}
+
+// Notify emails the alert to the customer's email address.
+func (n SmtpNotifier) Notify(alert Alert) *errs.AppError { //SMTP implements notifier
+	customer, appErr := n.customerRepo.FindById(alert.CustomerId)
+	if appErr != nil {
+		return appErr
+	}
+
+	message := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: Banking alert for account %s\r\n\r\n%s\r\n",
+		n.from, customer.Email, alert.AccountId, alert.Message)
+	if err := smtp.SendMail(n.address, n.auth, n.from, []string{customer.Email}, []byte(message)); err != nil {
+		logger.Error("Error while sending alert email: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected alert notification error")
+	}
+	return nil
+}
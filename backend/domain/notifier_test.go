@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+)
+
+// fakeSmtpServer accepts a single SMTP session on a local port and sends the commands and message it received on the
+// returned channel once the session ends.
+func fakeSmtpServer(t *testing.T) (string, <-chan string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("Error while starting fake SMTP server: " + err.Error())
+	}
+	received := make(chan string, 1)
+
+	go func() {
+		defer listener.Close()
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var session strings.Builder
+		tp := textproto.NewConn(conn)
+		tp.PrintfLine("220 localhost fake SMTP")
+		for {
+			line, err := tp.ReadLine()
+			if err != nil {
+				break
+			}
+			session.WriteString(line + "\n")
+			switch strings.ToUpper(strings.SplitN(line, " ", 2)[0]) {
+			case "EHLO", "HELO":
+				tp.PrintfLine("250 localhost")
+			case "DATA":
+				tp.PrintfLine("354 end data with <CR><LF>.<CR><LF>")
+				data, _ := tp.ReadDotLines()
+				session.WriteString(strings.Join(data, "\n") + "\n")
+				tp.PrintfLine("250 OK")
+			case "QUIT":
+				tp.PrintfLine("221 bye")
+				received <- session.String()
+				return
+			default:
+				tp.PrintfLine("250 OK")
+			}
+		}
+		received <- session.String()
+	}()
+
+	return listener.Addr().String(), received
+}
+
+// The customers and their email addresses are those of CustomerRepositoryStub.
+
+func TestSmtpNotifier_Notify_emails_alert_to_customer(t *testing.T) {
+	//Arrange
+	address, received := fakeSmtpServer(t)
+	notifier := NewSmtpNotifier(address, "", "", "alerts@banking.example.com", NewCustomerRepositoryStub())
+	alert := Alert{CustomerId: "2", AccountId: dummyAccountId, RuleType: dto.AlertRuleTypeLowBalance, Message: "Your balance is low."}
+
+	//Act
+	err := notifier.Notify(alert)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing emailing of alert: " + err.Message)
+	}
+	session := <-received
+	for _, expected := range []string{"RCPT TO:<luke.skywalker@tsomemail.com>", "Subject: Banking alert for account 1977", "Your balance is low."} {
+		if !strings.Contains(session, expected) {
+			t.Errorf("Expected SMTP session to contain \"%s\" but got:\n%s", expected, session)
+		}
+	}
+}
+
+func TestSmtpNotifier_Notify_returns_error_when_mailServer_unreachable(t *testing.T) {
+	//Arrange
+	listener, _ := net.Listen("tcp", "127.0.0.1:0")
+	address := listener.Addr().String()
+	listener.Close() //nothing listens on the address any more
+	notifier := NewSmtpNotifier(address, "", "", "alerts@banking.example.com", NewCustomerRepositoryStub())
+	logger.MuteLogger()
+
+	//Act
+	err := notifier.Notify(Alert{CustomerId: "2", AccountId: dummyAccountId})
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing emailing of alert to unreachable mail server")
+	}
+}
+
+func TestInboxNotifier_Notify_saves_alert_to_inbox(t *testing.T) {
+	//Arrange
+	stub := NewAlertRepositoryStub()
+	notifier := NewInboxNotifier(stub)
+
+	//Act
+	err := notifier.Notify(Alert{CustomerId: "2", AccountId: dummyAccountId, Message: "Your balance is low."})
+	alerts, _ := stub.FindAlerts("2")
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing saving of alert to inbox: " + err.Message)
+	}
+	if len(alerts) != 1 || alerts[0].Message != "Your balance is low." {
+		t.Errorf("Expected the alert in the inbox but got %v", alerts)
+	}
+}
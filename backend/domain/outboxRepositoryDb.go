@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/jmoiron/sqlx"
+)
+
+//Server
+
+type OutboxRepositoryDb struct { //DB (adapter)
+	client *sqlx.DB
+}
+
+func NewOutboxRepositoryDb(dbClient *sqlx.DB) OutboxRepositoryDb {
+	return OutboxRepositoryDb{dbClient}
+}
+
+// FindUnpublished retrieves at most limit events that have not been published yet, in the order they were written.
+func (d OutboxRepositoryDb) FindUnpublished(limit int) ([]Event, *errs.AppError) {
+	events := make([]Event, 0)
+	findSql := "SELECT event_id, account_id, event_type, payload, " +
+		dateTimeColumn(d.client.DriverName(), "created_on") +
+		" FROM outbox WHERE published_on IS NULL ORDER BY event_id LIMIT ?"
+	if err := d.client.Select(&events, d.client.Rebind(findSql), limit); err != nil {
+		logger.Error("Error while retrieving unpublished events from outbox: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return events, nil
+}
+
+// MarkPublished records that the event with the given id was published at the given time, so that it is not
+// published again.
+func (d OutboxRepositoryDb) MarkPublished(eventId string, publishedOn string) *errs.AppError {
+	markSql := "UPDATE outbox SET published_on = ? WHERE event_id = ?"
+	if _, err := d.client.Exec(d.client.Rebind(markSql), publishedOn, eventId); err != nil {
+		logger.Error("Error while marking event as published in outbox: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
+
+// insertOutboxEvent writes the given event to the outbox within the given database transaction, so that the event is
+// only recorded if the change it describes is committed.
+func insertOutboxEvent(tx *sqlx.Tx, event Event) error {
+	insertSql := "INSERT INTO outbox (account_id, event_type, payload, created_on) VALUES (?, ?, ?, ?)"
+	_, err := tx.Exec(tx.Rebind(insertSql), event.AccountId, event.EventType, event.Payload, event.CreatedOn)
+	return err
+}
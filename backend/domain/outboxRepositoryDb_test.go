@@ -0,0 +1,144 @@
+package domain
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/jmoiron/sqlx"
+	"testing"
+)
+
+// Test common variables and inputs
+var outboxRepoDb OutboxRepositoryDb
+var outboxTableColumns = []string{"event_id", "account_id", "event_type", "payload", "created_on"}
+
+const dummyEventId = "31"
+
+const insertOutboxSql = "INSERT INTO outbox (account_id, event_type, payload, created_on) VALUES (?, ?, ?, ?)"
+const selectUnpublishedSql = "SELECT event_id, account_id, event_type, payload, created_on FROM outbox WHERE published_on IS NULL ORDER BY event_id LIMIT ?"
+const updateOutboxPublishedSql = "UPDATE outbox SET published_on = ? WHERE event_id = ?"
+
+const insertOutboxPostgresSql = "INSERT INTO outbox (account_id, event_type, payload, created_on) VALUES ($1, $2, $3, $4)"
+const selectUnpublishedPostgresSql = "SELECT event_id, account_id, event_type, payload, to_char(created_on, 'YYYY-MM-DD HH24:MI:SS') AS created_on FROM outbox WHERE published_on IS NULL ORDER BY event_id LIMIT $1"
+
+func setupOutboxRepoDbTest(t *testing.T, driverName string) func() {
+	teardown := setupDB(t)
+	outboxRepoDb = NewOutboxRepositoryDb(sqlx.NewDb(db, driverName))
+	return teardown
+}
+
+// expectOutboxInsert sets up the mock database to expect the given event to be written to the outbox.
+func expectOutboxInsert(insertOutboxSql string, event Event) {
+	mockDB.ExpectExec(insertOutboxSql).
+		WithArgs(event.AccountId, event.EventType, event.Payload, event.CreatedOn).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+}
+
+func TestOutboxRepositoryDb_FindUnpublished_returns_error_when_select_fails(t *testing.T) {
+	//Arrange
+	teardown := setupOutboxRepoDbTest(t, driverName)
+	defer teardown()
+
+	dummyDbErr := errors.New("some error message")
+	mockDB.ExpectQuery(selectUnpublishedSql).WithArgs(10).WillReturnError(dummyDbErr)
+
+	logs := logger.ReplaceWithTestLogger()
+	expectedLogMessage := "Error while retrieving unpublished events from outbox: " + dummyDbErr.Error()
+
+	//Act
+	_, err := outboxRepoDb.FindUnpublished(10)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing failed selection of unpublished events")
+	}
+	if err.Message != defaultExpectedErrMessage {
+		t.Errorf("Expected error message to be \"%s\" but got \"%s\"", defaultExpectedErrMessage, err.Message)
+	}
+	if logs.Len() != 1 {
+		t.Fatalf("Expected 1 message to be logged but got %d logs", logs.Len())
+	}
+	if logs.All()[0].Message != expectedLogMessage {
+		t.Errorf("Expected log message to be \"%s\" but got \"%s\"", expectedLogMessage, logs.All()[0].Message)
+	}
+}
+
+func TestOutboxRepositoryDb_FindUnpublished_returns_events_when_select_succeeds(t *testing.T) {
+	tests := []struct {
+		driverName           string
+		selectUnpublishedSql string
+	}{
+		{DriverMySQL, selectUnpublishedSql},
+		{DriverPostgres, selectUnpublishedPostgresSql},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.driverName, func(t *testing.T) {
+			//Arrange
+			teardown := setupOutboxRepoDbTest(t, tc.driverName)
+			defer teardown()
+
+			expectedEvent := NewAccountOpenedEvent(getDefaultAccountAfterSave())
+			expectedEvent.EventId = dummyEventId
+			dummyRows := sqlmock.NewRows(outboxTableColumns).
+				AddRow(expectedEvent.EventId, expectedEvent.AccountId, expectedEvent.EventType, expectedEvent.Payload, expectedEvent.CreatedOn)
+			mockDB.ExpectQuery(tc.selectUnpublishedSql).WithArgs(10).WillReturnRows(dummyRows)
+
+			//Act
+			actualEvents, err := outboxRepoDb.FindUnpublished(10)
+
+			//Assert
+			if err != nil {
+				t.Fatal("Expected no error but got error while testing successful selection of unpublished events: " + err.Message)
+			}
+			if len(actualEvents) != 1 || actualEvents[0] != expectedEvent {
+				t.Errorf("Expected events %v but got %v", []Event{expectedEvent}, actualEvents)
+			}
+		})
+	}
+}
+
+func TestOutboxRepositoryDb_MarkPublished_returns_error_when_update_fails(t *testing.T) {
+	//Arrange
+	teardown := setupOutboxRepoDbTest(t, driverName)
+	defer teardown()
+
+	dummyDbErr := errors.New("some error message")
+	mockDB.ExpectExec(updateOutboxPublishedSql).WithArgs(dummyDate, dummyEventId).WillReturnError(dummyDbErr)
+
+	logs := logger.ReplaceWithTestLogger()
+	expectedLogMessage := "Error while marking event as published in outbox: " + dummyDbErr.Error()
+
+	//Act
+	err := outboxRepoDb.MarkPublished(dummyEventId, dummyDate)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing failed marking of event as published")
+	}
+	if err.Message != defaultExpectedErrMessage {
+		t.Errorf("Expected error message to be \"%s\" but got \"%s\"", defaultExpectedErrMessage, err.Message)
+	}
+	if logs.Len() != 1 {
+		t.Fatalf("Expected 1 message to be logged but got %d logs", logs.Len())
+	}
+	if logs.All()[0].Message != expectedLogMessage {
+		t.Errorf("Expected log message to be \"%s\" but got \"%s\"", expectedLogMessage, logs.All()[0].Message)
+	}
+}
+
+func TestOutboxRepositoryDb_MarkPublished_returns_noError_when_update_succeeds(t *testing.T) {
+	//Arrange
+	teardown := setupOutboxRepoDbTest(t, driverName)
+	defer teardown()
+
+	mockDB.ExpectExec(updateOutboxPublishedSql).WithArgs(dummyDate, dummyEventId).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	//Act
+	err := outboxRepoDb.MarkPublished(dummyEventId, dummyDate)
+
+	//Assert
+	if err != nil {
+		t.Error("Expected no error but got error while testing successful marking of event as published: " + err.Message)
+	}
+}
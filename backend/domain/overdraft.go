@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"database/sql"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"time"
+)
+
+//Business Domain
+
+// Overdraft is a period during which a checking account is overdrawn. It is opened by the transaction that takes the
+// balance below zero, which is charged the one-off overdraft fee, accrues interest for every whole day that the
+// account stays overdrawn and is closed once the balance is back at or above zero.
+type Overdraft struct { //business/domain object
+	OverdraftId       string         `db:"overdraft_id"`
+	AccountId         string         `db:"account_id"`
+	OverdrawnSince    string         `db:"overdrawn_since"`
+	InterestAccruedOn string         `db:"interest_accrued_on"` //interest has been charged up to this time
+	FeeCharged        float64        `db:"fee_charged"`
+	InterestCharged   float64        `db:"interest_charged"`
+	ClosedOn          sql.NullString `db:"closed_on"` //null while the account is overdrawn
+}
+
+// NewOverdraft returns the overdraft opened by the given transaction, which took the balance of its account below
+// zero, and charged the given fee.
+func NewOverdraft(t Transaction, fee float64) Overdraft {
+	return Overdraft{
+		AccountId:         t.AccountId,
+		OverdrawnSince:    t.TransactionDate,
+		InterestAccruedOn: t.TransactionDate,
+		FeeCharged:        fee,
+	}
+}
+
+func (o Overdraft) IsOpen() bool {
+	return !o.ClosedOn.Valid
+}
+
+// DaysToAccrue returns the number of whole days from the time up to which interest has been charged to the given time.
+func (o Overdraft) DaysToAccrue(now time.Time) int {
+	accruedOn, err := time.Parse(clock.FormatDateTime, o.InterestAccruedOn)
+	if err != nil || !now.After(accruedOn) {
+		return 0
+	}
+	return int(now.Sub(accruedOn) / (24 * time.Hour))
+}
+
+// Accrue returns the overdraft with the given interest charged for the given number of days.
+func (o Overdraft) Accrue(days int, interest float64) Overdraft {
+	accruedOn, _ := time.Parse(clock.FormatDateTime, o.InterestAccruedOn)
+	o.InterestAccruedOn = accruedOn.Add(time.Duration(days) * 24 * time.Hour).Format(clock.FormatDateTime)
+	o.InterestCharged += interest
+	return o
+}
+
+// Close returns the overdraft as closed at the current time.
+func (o Overdraft) Close(c clock.Clock) Overdraft {
+	o.ClosedOn = sql.NullString{String: c.NowAsString(), Valid: true}
+	return o
+}
+
+// ToInterest returns the transaction charging the given interest on the overdraft, dated at the current time.
+func (o Overdraft) ToInterest(interest float64, c clock.Clock) Transaction {
+	return NewTransaction(o.AccountId, interest, dto.TransactionTypeInterest, c)
+}
+
+// OverdraftTerms are what the bank charges for overdrawing a checking account.
+type OverdraftTerms struct {
+	Fee          float64 //one-off, when the account goes overdrawn
+	InterestRate float64 //yearly, in percent, charged daily on the overdrawn amount
+}
+
+// Interest returns the interest on the given overdrawn amount for the given number of days, rounded to the cent.
+func (t OverdraftTerms) Interest(overdrawnAmount float64, days int) float64 {
+	return roundToCents(overdrawnAmount * t.InterestRate / 100 / 365 * float64(days))
+}
+
+// ToOverdraftDTO returns the overdraft usage of the given account, whose held amount must have been set, with the
+// given overdraft if it is overdrawn.
+func (t OverdraftTerms) ToOverdraftDTO(a Account, o *Overdraft) dto.OverdraftResponse {
+	response := dto.OverdraftResponse{
+		AccountId:          a.AccountId,
+		OverdraftLimit:     a.OverdraftLimit,
+		OverdrawnAmount:    a.OverdrawnAmount(),
+		AvailableOverdraft: a.AvailableOverdraft(),
+		Fee:                t.Fee,
+		InterestRate:       t.InterestRate,
+	}
+	if o != nil {
+		response.OverdrawnSince = o.OverdrawnSince
+		response.FeeCharged = o.FeeCharged
+		response.InterestCharged = o.InterestCharged
+	}
+	return response
+}
+
+//Server
+
+//go:generate mockgen -destination=../mocks/domain/mock_overdraftRepository.go -package=domain github.com/aliciatay-zls/banking/backend/domain OverdraftRepository
+type OverdraftRepository interface { //repo (secondary port)
+	Save(Overdraft) (*Overdraft, *errs.AppError)
+	FindOpen(string) (*Overdraft, *errs.AppError)
+	FindAllOpen() ([]Overdraft, *errs.AppError)
+	Update(Overdraft, string) *errs.AppError
+}
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/problem"
+	"github.com/jmoiron/sqlx"
+	"strconv"
+)
+
+//Server
+
+type OverdraftRepositoryDb struct { //DB (adapter)
+	client *sqlx.DB
+}
+
+func NewOverdraftRepositoryDb(dbClient *sqlx.DB) OverdraftRepositoryDb {
+	return OverdraftRepositoryDb{dbClient}
+}
+
+// Save creates a new entry in the database for the given overdraft and returns it with its database-generated ID set.
+func (d OverdraftRepositoryDb) Save(o Overdraft) (*Overdraft, *errs.AppError) {
+	insertSql := "INSERT INTO overdrafts (account_id, overdrawn_since, interest_accrued_on, fee_charged, interest_charged) VALUES (?, ?, ?, ?, ?)"
+	result, err := execInsert(d.client, insertSql, "overdraft_id",
+		o.AccountId, o.OverdrawnSince, o.InterestAccruedOn, o.FeeCharged, o.InterestCharged)
+	if err != nil {
+		logger.Error("Error while creating new overdraft: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		logger.Error("Error while getting id of newly inserted overdraft: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	o.OverdraftId = strconv.FormatInt(id, 10)
+
+	return &o, nil
+}
+
+// FindOpen retrieves the overdraft of the account with the given id that has not been closed yet.
+func (d OverdraftRepositoryDb) FindOpen(accountId string) (*Overdraft, *errs.AppError) {
+	var overdraft Overdraft
+	findSql := d.selectOverdraftsSql() + " WHERE account_id = ? AND closed_on IS NULL ORDER BY overdraft_id DESC LIMIT 1"
+	if err := d.client.Get(&overdraft, d.client.Rebind(findSql), accountId); err != nil {
+		logger.Error("Error while retrieving open overdraft of account: " + err.Error())
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, problem.NewNotFoundError(problem.OverdraftNotFound, "Account is not overdrawn")
+		}
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return &overdraft, nil
+}
+
+// FindAllOpen retrieves the overdrafts of all accounts that have not been closed yet, oldest first.
+func (d OverdraftRepositoryDb) FindAllOpen() ([]Overdraft, *errs.AppError) {
+	overdrafts := make([]Overdraft, 0)
+	findSql := d.selectOverdraftsSql() + " WHERE closed_on IS NULL ORDER BY overdraft_id"
+	if err := d.client.Select(&overdrafts, findSql); err != nil {
+		logger.Error("Error while retrieving open overdrafts: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return overdrafts, nil
+}
+
+// Update sets the charges and closing date of the given overdraft, provided that it is still open and that interest
+// was last charged on it up to the given time. This way, interest charged twice at once is only charged by the first
+// request.
+func (d OverdraftRepositoryDb) Update(o Overdraft, fromAccruedOn string) *errs.AppError {
+	updateSql := "UPDATE overdrafts SET interest_accrued_on = ?, fee_charged = ?, interest_charged = ?, closed_on = ? " +
+		"WHERE overdraft_id = ? AND interest_accrued_on = ? AND closed_on IS NULL"
+	result, err := d.client.Exec(d.client.Rebind(updateSql),
+		o.InterestAccruedOn, o.FeeCharged, o.InterestCharged, o.ClosedOn, o.OverdraftId, fromAccruedOn)
+	if err != nil {
+		logger.Error("Error while updating overdraft: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	if rowsAffected, err := result.RowsAffected(); err != nil || rowsAffected == 0 {
+		logger.Error("Error while updating overdraft: overdraft was closed or charged in the meantime")
+		return problem.NewConflictError(problem.OverdraftClosed, "Overdraft has already been charged or closed")
+	}
+
+	return nil
+}
+
+func (d OverdraftRepositoryDb) selectOverdraftsSql() string {
+	return "SELECT overdraft_id, account_id, " +
+		dateTimeColumn(d.client.DriverName(), "overdrawn_since") + ", " +
+		dateTimeColumn(d.client.DriverName(), "interest_accrued_on") + ", fee_charged, interest_charged, " +
+		dateTimeColumn(d.client.DriverName(), "closed_on") + " FROM overdrafts"
+}
@@ -0,0 +1,173 @@
+package domain
+
+import (
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/jmoiron/sqlx"
+	"net/http"
+	"testing"
+)
+
+// Test common variables and inputs
+var overdraftRepoDb OverdraftRepositoryDb
+
+var overdraftsTableColumns = []string{"overdraft_id", "account_id", "overdrawn_since", "interest_accrued_on", "fee_charged", "interest_charged", "closed_on"}
+
+const insertOverdraftsSql = "INSERT INTO overdrafts (account_id, overdrawn_since, interest_accrued_on, fee_charged, interest_charged) VALUES (?, ?, ?, ?, ?)"
+const insertOverdraftsPostgresSql = "INSERT INTO overdrafts (account_id, overdrawn_since, interest_accrued_on, fee_charged, interest_charged) VALUES ($1, $2, $3, $4, $5) RETURNING overdraft_id"
+const selectOpenOverdraftSql = "SELECT overdraft_id, account_id, overdrawn_since, interest_accrued_on, fee_charged, interest_charged, closed_on FROM overdrafts WHERE account_id = ? AND closed_on IS NULL ORDER BY overdraft_id DESC LIMIT 1"
+const selectOpenOverdraftPostgresSql = "SELECT overdraft_id, account_id, to_char(overdrawn_since, 'YYYY-MM-DD HH24:MI:SS') AS overdrawn_since, to_char(interest_accrued_on, 'YYYY-MM-DD HH24:MI:SS') AS interest_accrued_on, fee_charged, interest_charged, to_char(closed_on, 'YYYY-MM-DD HH24:MI:SS') AS closed_on FROM overdrafts WHERE account_id = $1 AND closed_on IS NULL ORDER BY overdraft_id DESC LIMIT 1"
+const selectAllOpenOverdraftsSql = "SELECT overdraft_id, account_id, overdrawn_since, interest_accrued_on, fee_charged, interest_charged, closed_on FROM overdrafts WHERE closed_on IS NULL ORDER BY overdraft_id"
+const updateOverdraftsSql = "UPDATE overdrafts SET interest_accrued_on = ?, fee_charged = ?, interest_charged = ?, closed_on = ? " +
+	"WHERE overdraft_id = ? AND interest_accrued_on = ? AND closed_on IS NULL"
+
+func setupOverdraftRepoDbTest(t *testing.T, driverName string) func() {
+	teardown := setupDB(t)
+	overdraftRepoDb = NewOverdraftRepositoryDb(sqlx.NewDb(db, driverName))
+	return teardown
+}
+
+func TestOverdraftRepositoryDb_Save_returns_overdraft_with_newId(t *testing.T) {
+	tests := []struct {
+		driverName string
+		insertSql  string
+	}{
+		{DriverMySQL, insertOverdraftsSql},
+		{DriverPostgres, insertOverdraftsPostgresSql},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.driverName, func(t *testing.T) {
+			//Arrange
+			teardown := setupOverdraftRepoDbTest(t, tc.driverName)
+			defer teardown()
+
+			o := getDefaultOverdraft()
+			expectInsert(tc.driverName, tc.insertSql, "overdraft_id", 3, o.AccountId, o.OverdrawnSince,
+				o.InterestAccruedOn, o.FeeCharged, o.InterestCharged)
+
+			//Act
+			savedOverdraft, err := overdraftRepoDb.Save(o)
+
+			//Assert
+			if err != nil {
+				t.Fatal("Expected no error but got error while testing successful saving of overdraft: " + err.Message)
+			}
+			if savedOverdraft.OverdraftId != "3" {
+				t.Errorf("Expected overdraft id 3 but got %s", savedOverdraft.OverdraftId)
+			}
+		})
+	}
+}
+
+func TestOverdraftRepositoryDb_FindOpen_returns_notFoundError_when_noRows(t *testing.T) {
+	//Arrange
+	teardown := setupOverdraftRepoDbTest(t, DriverPostgres)
+	defer teardown()
+
+	mockDB.ExpectQuery(selectOpenOverdraftPostgresSql).WithArgs(dummyAccountId).WillReturnError(sql.ErrNoRows)
+	logger.MuteLogger()
+
+	//Act
+	_, err := overdraftRepoDb.FindOpen(dummyAccountId)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing retrieval of overdraft of account in credit")
+	}
+	if err.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d but got %d", http.StatusNotFound, err.Code)
+	}
+}
+
+func TestOverdraftRepositoryDb_FindOpen_returns_overdraft_when_select_succeeds(t *testing.T) {
+	//Arrange
+	teardown := setupOverdraftRepoDbTest(t, driverName)
+	defer teardown()
+
+	expectedOverdraft := getDefaultOverdraft()
+	expectedOverdraft.OverdraftId = "3"
+	mockDB.ExpectQuery(selectOpenOverdraftSql).
+		WithArgs(dummyAccountId).
+		WillReturnRows(sqlmock.NewRows(overdraftsTableColumns).AddRow("3", dummyAccountId, dummyDate, dummyDate, 25, 0, nil))
+
+	//Act
+	overdraft, err := overdraftRepoDb.FindOpen(dummyAccountId)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing retrieval of open overdraft: " + err.Message)
+	}
+	if *overdraft != expectedOverdraft {
+		t.Errorf("Expected overdraft %v but got %v", expectedOverdraft, *overdraft)
+	}
+}
+
+func TestOverdraftRepositoryDb_FindAllOpen_returns_openOverdrafts(t *testing.T) {
+	//Arrange
+	teardown := setupOverdraftRepoDbTest(t, driverName)
+	defer teardown()
+
+	mockDB.ExpectQuery(selectAllOpenOverdraftsSql).
+		WillReturnRows(sqlmock.NewRows(overdraftsTableColumns).
+			AddRow("3", dummyAccountId, dummyDate, dummyDate, 25, 0, nil).
+			AddRow("4", "1978", dummyDate, dummyDate, 25, 1.5, nil))
+
+	//Act
+	overdrafts, err := overdraftRepoDb.FindAllOpen()
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing retrieval of open overdrafts: " + err.Message)
+	}
+	if len(overdrafts) != 2 || overdrafts[1].AccountId != "1978" || overdrafts[1].InterestCharged != 1.5 {
+		t.Errorf("Expected 2 open overdrafts but got %v", overdrafts)
+	}
+}
+
+func TestOverdraftRepositoryDb_Update_returns_conflictError_when_overdraft_chargedOrClosed(t *testing.T) {
+	//Arrange
+	teardown := setupOverdraftRepoDbTest(t, driverName)
+	defer teardown()
+
+	o := getDefaultOverdraft()
+	o.OverdraftId = "3"
+	accrued := o.Accrue(1, 0.5)
+	mockDB.ExpectExec(updateOverdraftsSql).
+		WithArgs(accrued.InterestAccruedOn, accrued.FeeCharged, accrued.InterestCharged, accrued.ClosedOn, "3", dummyDate).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	logger.MuteLogger()
+
+	//Act
+	err := overdraftRepoDb.Update(accrued, o.InterestAccruedOn)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing update of overdraft charged in the meantime")
+	}
+	if err.Code != http.StatusConflict {
+		t.Errorf("Expected status code %d but got %d", http.StatusConflict, err.Code)
+	}
+}
+
+func TestOverdraftRepositoryDb_Update_returns_nil_when_overdraftUpdated(t *testing.T) {
+	//Arrange
+	teardown := setupOverdraftRepoDbTest(t, driverName)
+	defer teardown()
+
+	o := getDefaultOverdraft()
+	o.OverdraftId = "3"
+	accrued := o.Accrue(1, 0.5)
+	mockDB.ExpectExec(updateOverdraftsSql).
+		WithArgs(accrued.InterestAccruedOn, accrued.FeeCharged, accrued.InterestCharged, accrued.ClosedOn, "3", dummyDate).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	//Act
+	err := overdraftRepoDb.Update(accrued, o.InterestAccruedOn)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing successful update of overdraft: " + err.Message)
+	}
+}
@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/problem"
+	"strconv"
+	"sync"
+)
+
+//Server
+
+type OverdraftRepositoryStub struct { //stub (adapter)
+	store *overdraftStore //shared by all copies of the stub, so that changes made through one copy are seen by all
+}
+
+// overdraftStore holds the overdrafts of an OverdraftRepositoryStub in memory. It is safe for concurrent use.
+type overdraftStore struct {
+	mu              sync.Mutex
+	overdrafts      []Overdraft
+	nextOverdraftId int64
+}
+
+func NewOverdraftRepositoryStub() OverdraftRepositoryStub { //helper function to create and initialize a stub
+	return OverdraftRepositoryStub{&overdraftStore{overdrafts: make([]Overdraft, 0), nextOverdraftId: 1}}
+}
+
+func (s OverdraftRepositoryStub) Save(o Overdraft) (*Overdraft, *errs.AppError) { //stub implements repo
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	o.OverdraftId = strconv.FormatInt(s.store.nextOverdraftId, 10)
+	s.store.nextOverdraftId++
+	s.store.overdrafts = append(s.store.overdrafts, o)
+
+	return &o, nil
+}
+
+// FindOpen returns the latest overdraft of the account with the given id that has not been closed yet.
+func (s OverdraftRepositoryStub) FindOpen(accountId string) (*Overdraft, *errs.AppError) { //stub implements repo
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	for i := len(s.store.overdrafts) - 1; i >= 0; i-- {
+		o := s.store.overdrafts[i]
+		if o.AccountId == accountId && o.IsOpen() {
+			return &o, nil
+		}
+	}
+	logger.Error("Error while retrieving open overdraft using stub for OverdraftRepository: account is not overdrawn")
+	return nil, problem.NewNotFoundError(problem.OverdraftNotFound, "Account is not overdrawn")
+}
+
+func (s OverdraftRepositoryStub) FindAllOpen() ([]Overdraft, *errs.AppError) { //stub implements repo
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	overdrafts := make([]Overdraft, 0)
+	for _, o := range s.store.overdrafts {
+		if o.IsOpen() {
+			overdrafts = append(overdrafts, o)
+		}
+	}
+	return overdrafts, nil
+}
+
+func (s OverdraftRepositoryStub) Update(o Overdraft, fromAccruedOn string) *errs.AppError { //stub implements repo
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	for i, stored := range s.store.overdrafts {
+		if stored.OverdraftId != o.OverdraftId {
+			continue
+		}
+		if !stored.IsOpen() || stored.InterestAccruedOn != fromAccruedOn {
+			break
+		}
+		s.store.overdrafts[i].InterestAccruedOn = o.InterestAccruedOn
+		s.store.overdrafts[i].FeeCharged = o.FeeCharged
+		s.store.overdrafts[i].InterestCharged = o.InterestCharged
+		s.store.overdrafts[i].ClosedOn = o.ClosedOn
+		return nil
+	}
+	logger.Error("Error while updating overdraft using stub for OverdraftRepository: overdraft was closed or charged in the meantime")
+	return problem.NewConflictError(problem.OverdraftClosed, "Overdraft has already been charged or closed")
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"net/http"
+	"testing"
+)
+
+func TestOverdraftRepositoryStub_FindOpen_returns_latest_openOverdraft_of_account(t *testing.T) {
+	//Arrange
+	stub := NewOverdraftRepositoryStub()
+	otherAccount := getDefaultOverdraft()
+	otherAccount.AccountId = "1978"
+	for _, o := range []Overdraft{getDefaultOverdraft(), otherAccount} {
+		stub.Save(o)
+	}
+	closed, _ := stub.FindOpen(dummyAccountId)
+	stub.Update(closed.Close(clock.StaticClock{}), closed.InterestAccruedOn)
+	stub.Save(getDefaultOverdraft())
+	logger.MuteLogger()
+
+	//Act
+	overdraft, err := stub.FindOpen(dummyAccountId)
+	_, missingErr := stub.FindOpen("1979")
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing retrieval of open overdraft: " + err.Message)
+	}
+	if overdraft.OverdraftId != "3" {
+		t.Errorf("Expected overdraft 3 but got %v", *overdraft)
+	}
+	if missingErr == nil || missingErr.Code != http.StatusNotFound {
+		t.Errorf("Expected not found error for account in credit but got %v", missingErr)
+	}
+	if open, _ := stub.FindAllOpen(); len(open) != 2 {
+		t.Errorf("Expected 2 open overdrafts but got %v", open)
+	}
+}
+
+func TestOverdraftRepositoryStub_Update_returns_conflictError_when_overdraft_chargedInTheMeantime(t *testing.T) {
+	//Arrange
+	stub := NewOverdraftRepositoryStub()
+	saved, _ := stub.Save(getDefaultOverdraft())
+	stub.Update(saved.Accrue(1, 0.5), saved.InterestAccruedOn)
+	logger.MuteLogger()
+
+	//Act
+	err := stub.Update(saved.Accrue(1, 0.5), saved.InterestAccruedOn)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing interest charged twice")
+	}
+	if err.Code != http.StatusConflict {
+		t.Errorf("Expected status code %d but got %d", http.StatusConflict, err.Code)
+	}
+	if overdraft, _ := stub.FindOpen(dummyAccountId); overdraft.InterestCharged != 0.5 {
+		t.Errorf("Expected interest to be charged once but got %v", overdraft.InterestCharged)
+	}
+}
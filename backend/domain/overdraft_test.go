@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"testing"
+	"time"
+)
+
+// getDefaultOverdraft returns an open overdraft of the account with id 1977, overdrawn and last charged interest on
+// 2 Jan 2006
+func getDefaultOverdraft() Overdraft {
+	return Overdraft{
+		AccountId:         dummyAccountId,
+		OverdrawnSince:    dummyDate,
+		InterestAccruedOn: dummyDate,
+		FeeCharged:        25,
+	}
+}
+
+func TestNewOverdraft_opens_overdraft_on_transactionDate(t *testing.T) {
+	//Arrange
+	transaction := Transaction{AccountId: dummyAccountId, Amount: 100, Balance: -50,
+		TransactionType: dto.TransactionTypeWithdrawal, TransactionDate: dummyDate}
+
+	//Act
+	overdraft := NewOverdraft(transaction, 25)
+
+	//Assert
+	if overdraft != getDefaultOverdraft() {
+		t.Errorf("Expected overdraft %v but got %v", getDefaultOverdraft(), overdraft)
+	}
+	if !overdraft.IsOpen() {
+		t.Error("Expected new overdraft to be open")
+	}
+}
+
+func TestOverdraft_DaysToAccrue_counts_wholeDays_only(t *testing.T) {
+	//Arrange
+	now := clock.StaticClock{}.Now()
+	tests := []struct {
+		name         string
+		now          time.Time
+		expectedDays int
+	}{
+		{"same time", now, 0},
+		{"less than a day", now.Add(23 * time.Hour), 0},
+		{"one day", now.Add(24 * time.Hour), 1},
+		{"almost three days", now.Add(71 * time.Hour), 2},
+		{"in the past", now.Add(-48 * time.Hour), 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Act
+			days := getDefaultOverdraft().DaysToAccrue(tc.now)
+
+			//Assert
+			if days != tc.expectedDays {
+				t.Errorf("Expected %d days but got %d", tc.expectedDays, days)
+			}
+		})
+	}
+}
+
+func TestOverdraft_Accrue_moves_accrualDate_by_wholeDays(t *testing.T) {
+	//Arrange
+	overdraft := getDefaultOverdraft()
+	overdraft.InterestCharged = 1.5
+
+	//Act
+	accrued := overdraft.Accrue(2, 0.25)
+
+	//Assert
+	if accrued.InterestAccruedOn != "2006-01-04 15:04:05" || accrued.InterestCharged != 1.75 {
+		t.Errorf("Expected interest 1.75 charged up to 2006-01-04 15:04:05 but got %v", accrued)
+	}
+}
+
+func TestOverdraftTerms_Interest_returns_dailyInterest_roundedToCents(t *testing.T) {
+	//Arrange
+	terms := OverdraftTerms{Fee: 25, InterestRate: 18.25}
+
+	//Act
+	oneDay := terms.Interest(1000, 1)
+	threeDays := terms.Interest(333, 3)
+
+	//Assert
+	if oneDay != 0.5 || threeDays != 0.5 {
+		t.Errorf("Expected interest of 0.50 for 1000 over a day and 333 over 3 days but got %v and %v", oneDay, threeDays)
+	}
+}
+
+func TestOverdraftTerms_ToOverdraftDTO_returns_usage_of_account(t *testing.T) {
+	//Arrange
+	terms := OverdraftTerms{Fee: 25, InterestRate: 18}
+	account := Account{AccountId: dummyAccountId, AccountType: dto.AccountTypeChecking, Amount: -200,
+		OverdraftLimit: 500, HeldAmount: 100}
+	overdraft := getDefaultOverdraft()
+	overdraft.InterestCharged = 0.1
+	expectedResponse := dto.OverdraftResponse{
+		AccountId:          dummyAccountId,
+		OverdraftLimit:     500,
+		OverdrawnAmount:    200,
+		AvailableOverdraft: 200,
+		OverdrawnSince:     dummyDate,
+		FeeCharged:         25,
+		InterestCharged:    0.1,
+		Fee:                25,
+		InterestRate:       18,
+	}
+
+	//Act
+	response := terms.ToOverdraftDTO(account, &overdraft)
+	inCredit := terms.ToOverdraftDTO(Account{AccountId: dummyAccountId, AccountType: dto.AccountTypeChecking, Amount: 10}, nil)
+
+	//Assert
+	if response != expectedResponse {
+		t.Errorf("Expected usage %v but got %v", expectedResponse, response)
+	}
+	if inCredit.OverdrawnSince != "" || inCredit.OverdrawnAmount != 0 || inCredit.FeeCharged != 0 {
+		t.Errorf("Expected no overdraft usage of account in credit but got %v", inCredit)
+	}
+}
@@ -0,0 +1,142 @@
+package domain
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"strings"
+	"time"
+)
+
+//Business Domain
+
+// Payee is an account that a customer saved under a nickname to send transfers to. The first transfer to a payee has to
+// be confirmed by the customer, and large transfers to a new payee are refused during a cooling-off period set in the
+// PayeeTerms.
+type Payee struct { //business/domain object
+	PayeeId     string         `db:"payee_id"`
+	CustomerId  string         `db:"customer_id"`
+	Nickname    string         `db:"nickname"`
+	AccountId   string         `db:"account_id"`
+	OwnerName   string         `db:"owner_name"` //empty unless it was given and verified against the owner of the account
+	CreatedOn   string         `db:"created_on"`
+	ConfirmedOn sql.NullString `db:"confirmed_on"` //null until the first transfer to the payee
+}
+
+func NewPayee(request dto.NewPayeeRequest, c clock.Clock) Payee {
+	return Payee{
+		CustomerId: request.CustomerId,
+		Nickname:   request.Nickname,
+		AccountId:  request.AccountId,
+		OwnerName:  request.OwnerName,
+		CreatedOn:  c.NowAsString(),
+	}
+}
+
+func (p Payee) IsConfirmed() bool {
+	return p.ConfirmedOn.Valid
+}
+
+// Confirm returns the payee as confirmed by the customer at the current time.
+func (p Payee) Confirm(c clock.Clock) Payee {
+	p.ConfirmedOn = sql.NullString{String: c.NowAsString(), Valid: true}
+	return p
+}
+
+// ToTransfer returns the withdrawal from the given account of the customer and the deposit to the account of the payee
+// that make up the requested transfer, dated at the current time and filed under transfers. The deposit is linked to
+// the withdrawal once the withdrawal is posted.
+func (p Payee) ToTransfer(request dto.TransferRequest, c clock.Clock) (Transaction, Transaction) {
+	withdrawal := NewTransaction(request.AccountId, request.Amount, dto.TransactionTypeWithdrawal, c)
+	withdrawal.Description = request.Description
+	if withdrawal.Description == "" {
+		withdrawal.Description = "Transfer to " + p.Nickname
+	}
+	withdrawal.Reference = request.Reference
+	withdrawal.Category = dto.TransactionCategoryTransfers
+
+	deposit := NewTransaction(p.AccountId, request.Amount, dto.TransactionTypeDeposit, c)
+	deposit.Description = request.Description
+	if deposit.Description == "" {
+		deposit.Description = "Transfer from account " + request.AccountId
+	}
+	deposit.Reference = request.Reference
+	deposit.Category = dto.TransactionCategoryTransfers
+	return withdrawal, deposit
+}
+
+func (p Payee) ToDTO(terms PayeeTerms, c clock.Clock) dto.PayeeResponse {
+	response := dto.PayeeResponse{
+		PayeeId:     p.PayeeId,
+		Nickname:    p.Nickname,
+		AccountId:   p.AccountId,
+		OwnerName:   p.OwnerName,
+		Status:      dto.PayeeStatusPendingConfirmation,
+		CreatedOn:   p.CreatedOn,
+		ConfirmedOn: p.ConfirmedOn.String,
+	}
+	if p.IsConfirmed() {
+		response.Status = dto.PayeeStatusActive
+	}
+	if until := terms.CoolingOffUntil(p); c.Now().Before(until) {
+		response.CoolingOffUntil = until.Format(clock.FormatDateTime)
+	}
+	return response
+}
+
+// ToTransferResponseDTO describes the transfer to the payee made of the given posted withdrawal.
+func (p Payee) ToTransferResponseDTO(withdrawal Transaction) *dto.TransferResponse {
+	return &dto.TransferResponse{
+		TransactionId:   withdrawal.TransactionId,
+		PayeeId:         p.PayeeId,
+		ToAccountId:     p.AccountId,
+		Amount:          withdrawal.Amount,
+		Balance:         withdrawal.Balance,
+		TransactionDate: withdrawal.TransactionDate,
+	}
+}
+
+// OwnerNameMatches reports whether the given name is the name of the owner of an account, regardless of case and
+// spacing.
+func OwnerNameMatches(given string, owner string) bool {
+	return strings.EqualFold(strings.Join(strings.Fields(given), " "), strings.Join(strings.Fields(owner), " "))
+}
+
+// PayeeTerms limit the transfers to new payees, so that a payee added by someone who took over the account of a
+// customer cannot be sent large amounts straight away.
+type PayeeTerms struct {
+	CoolingOffPeriod time.Duration //from when the payee is added
+	CoolingOffLimit  float64       //the largest transfer allowed to a payee during its cooling-off period
+}
+
+// CoolingOffUntil returns the end of the cooling-off period of the given payee.
+func (t PayeeTerms) CoolingOffUntil(p Payee) time.Time {
+	createdOn, _ := time.Parse(clock.FormatDateTime, p.CreatedOn)
+	return createdOn.Add(t.CoolingOffPeriod)
+}
+
+// Allows reports whether a transfer of the given amount can be sent to the given payee at the current time.
+func (t PayeeTerms) Allows(p Payee, amount float64, c clock.Clock) bool {
+	return amount <= t.CoolingOffLimit || !c.Now().Before(t.CoolingOffUntil(p))
+}
+
+// CoolingOffMessage is the error message for a transfer refused during the cooling-off period of the given payee.
+func (t PayeeTerms) CoolingOffMessage(p Payee) string {
+	return fmt.Sprintf("Transfers to a new payee are limited to %.2f until %s.", t.CoolingOffLimit,
+		t.CoolingOffUntil(p).Format(clock.FormatDateTime))
+}
+
+//Server
+
+//go:generate mockgen -destination=../mocks/domain/mock_payeeRepository.go -package=domain github.com/aliciatay-zls/banking/backend/domain PayeeRepository
+type PayeeRepository interface { //repo (secondary port)
+	FindAll(string) ([]Payee, *errs.AppError)
+	FindById(string) (*Payee, *errs.AppError)
+	Save(Payee, string) (*Payee, *errs.AppError)
+	UpdateNickname(Payee, string, string, string) *errs.AppError
+	Confirm(Payee, string) *errs.AppError
+	Delete(Payee, string, string) *errs.AppError
+	FindAuditTrail(string) ([]AuditEntry, *errs.AppError)
+}
@@ -0,0 +1,197 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/problem"
+	"github.com/jmoiron/sqlx"
+	"strconv"
+)
+
+//Server
+
+type PayeeRepositoryDb struct { //DB (adapter)
+	client *sqlx.DB
+}
+
+func NewPayeeRepositoryDb(dbClient *sqlx.DB) PayeeRepositoryDb {
+	return PayeeRepositoryDb{dbClient}
+}
+
+// selectPayeesSql returns the query selecting every column of the payees table, to which a WHERE clause can be
+// appended.
+func (d PayeeRepositoryDb) selectPayeesSql() string {
+	driverName := d.client.DriverName()
+	return "SELECT payee_id, customer_id, nickname, account_id, owner_name, " + dateTimeColumn(driverName, "created_on") +
+		", " + dateTimeColumn(driverName, "confirmed_on") + " FROM payees"
+}
+
+// FindAll retrieves all payees of the customer with the given id, in the order they were added.
+func (d PayeeRepositoryDb) FindAll(customerId string) ([]Payee, *errs.AppError) {
+	payees := make([]Payee, 0)
+	findSql := d.selectPayeesSql() + " WHERE customer_id = ? ORDER BY payee_id"
+	if err := d.client.Select(&payees, d.client.Rebind(findSql), customerId); err != nil {
+		logger.Error("Error while retrieving payees: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return payees, nil
+}
+
+// FindById retrieves the payee with the given id.
+func (d PayeeRepositoryDb) FindById(payeeId string) (*Payee, *errs.AppError) {
+	var payee Payee
+	findSql := d.selectPayeesSql() + " WHERE payee_id = ?"
+	if err := d.client.Get(&payee, d.client.Rebind(findSql), payeeId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Error("Error while retrieving payee: payee not found")
+			return nil, problem.NewNotFoundError(problem.PayeeNotFound, "Payee not found")
+		}
+		logger.Error("Error while retrieving payee: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return &payee, nil
+}
+
+// Save starts a database transaction, creates a new entry in the database for the given payee, sets its ID using the
+// database-generated ID, records in the audit trail that the given user added it and commits the database
+// transaction.
+// Save returns the payee.
+func (d PayeeRepositoryDb) Save(payee Payee, changedBy string) (*Payee, *errs.AppError) {
+	tx, err := d.client.Beginx()
+	if err != nil {
+		logger.Error("Error while starting db transaction for adding payee: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	insertSql := "INSERT INTO payees (customer_id, nickname, account_id, owner_name, created_on) VALUES (?, ?, ?, ?, ?)"
+	result, err := execInsert(tx, insertSql, "payee_id", payee.CustomerId, payee.Nickname, payee.AccountId, payee.OwnerName, payee.CreatedOn)
+	if err != nil {
+		logger.Error("Error while creating new payee: " + err.Error())
+		rollbackPayee(tx)
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		logger.Error("Error while getting id of newly inserted payee: " + err.Error())
+		rollbackPayee(tx)
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	payee.PayeeId = strconv.FormatInt(id, 10)
+
+	entry := NewPayeeAuditEntry(payee, AuditActionAdded, payeeDetails(payee), changedBy, payee.CreatedOn)
+	if appErr := d.commitWithAuditEntry(tx, entry); appErr != nil {
+		return nil, appErr
+	}
+
+	return &payee, nil
+}
+
+// UpdateNickname starts a database transaction, renames the given payee to the given nickname, records in the audit
+// trail that the given user renamed it at the given time and commits the database transaction.
+func (d PayeeRepositoryDb) UpdateNickname(payee Payee, nickname string, changedBy string, changedOn string) *errs.AppError {
+	tx, err := d.client.Beginx()
+	if err != nil {
+		logger.Error("Error while starting db transaction for renaming payee: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	updateSql := "UPDATE payees SET nickname = ? WHERE payee_id = ?"
+	if _, err = tx.Exec(tx.Rebind(updateSql), nickname, payee.PayeeId); err != nil {
+		logger.Error("Error while renaming payee: " + err.Error())
+		rollbackPayee(tx)
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	details := strconv.Quote(payee.Nickname) + " to " + strconv.Quote(nickname)
+	return d.commitWithAuditEntry(tx, NewPayeeAuditEntry(payee, AuditActionRenamed, details, changedBy, changedOn))
+}
+
+// Confirm starts a database transaction, saves when the given payee was confirmed with Payee.Confirm, records in the
+// audit trail that the given user confirmed it and commits the database transaction.
+func (d PayeeRepositoryDb) Confirm(payee Payee, changedBy string) *errs.AppError {
+	tx, err := d.client.Beginx()
+	if err != nil {
+		logger.Error("Error while starting db transaction for confirming payee: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	updateSql := "UPDATE payees SET confirmed_on = ? WHERE payee_id = ?"
+	if _, err = tx.Exec(tx.Rebind(updateSql), payee.ConfirmedOn.String, payee.PayeeId); err != nil {
+		logger.Error("Error while confirming payee: " + err.Error())
+		rollbackPayee(tx)
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	entry := NewPayeeAuditEntry(payee, AuditActionConfirmed, payeeDetails(payee), changedBy, payee.ConfirmedOn.String)
+	return d.commitWithAuditEntry(tx, entry)
+}
+
+// Delete starts a database transaction, deletes the given payee, records in the audit trail that the given user
+// deleted it at the given time and commits the database transaction.
+func (d PayeeRepositoryDb) Delete(payee Payee, changedBy string, changedOn string) *errs.AppError {
+	tx, err := d.client.Beginx()
+	if err != nil {
+		logger.Error("Error while starting db transaction for deleting payee: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	deleteSql := "DELETE FROM payees WHERE payee_id = ?"
+	if _, err = tx.Exec(tx.Rebind(deleteSql), payee.PayeeId); err != nil {
+		logger.Error("Error while deleting payee: " + err.Error())
+		rollbackPayee(tx)
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return d.commitWithAuditEntry(tx, NewPayeeAuditEntry(payee, AuditActionDeleted, payeeDetails(payee), changedBy, changedOn))
+}
+
+// FindAuditTrail retrieves the changes made to the records of the customer with the given id, newest first.
+func (d PayeeRepositoryDb) FindAuditTrail(customerId string) ([]AuditEntry, *errs.AppError) {
+	entries := make([]AuditEntry, 0)
+	findSql := "SELECT audit_id, customer_id, entity_type, entity_id, action, details, changed_by, " +
+		dateTimeColumn(d.client.DriverName(), "changed_on") +
+		" FROM audit_trail WHERE customer_id = ? ORDER BY audit_id DESC"
+	if err := d.client.Select(&entries, d.client.Rebind(findSql), customerId); err != nil {
+		logger.Error("Error while retrieving audit trail: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return entries, nil
+}
+
+// commitWithAuditEntry writes the given audit entry within the given database transaction and commits it, so that the
+// change and its record are saved together.
+func (d PayeeRepositoryDb) commitWithAuditEntry(tx *sqlx.Tx, entry AuditEntry) *errs.AppError {
+	if err := insertAuditEntry(tx, entry); err != nil {
+		logger.Error("Error while writing payee change to audit trail: " + err.Error())
+		rollbackPayee(tx)
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Error("Error while committing db transaction: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
+
+// insertAuditEntry writes the given entry to the audit trail within the given database transaction.
+func insertAuditEntry(tx *sqlx.Tx, entry AuditEntry) error {
+	insertSql := "INSERT INTO audit_trail (customer_id, entity_type, entity_id, action, details, changed_by, changed_on) " +
+		"VALUES (?, ?, ?, ?, ?, ?, ?)"
+	_, err := tx.Exec(tx.Rebind(insertSql), entry.CustomerId, entry.EntityType, entry.EntityId, entry.Action,
+		entry.Details, entry.ChangedBy, entry.ChangedOn)
+	return err
+}
+
+func rollbackPayee(tx *sqlx.Tx) {
+	if rollbackErr := tx.Rollback(); rollbackErr != nil {
+		logger.Fatal("Error while rolling back changes to payee: " + rollbackErr.Error())
+	}
+}
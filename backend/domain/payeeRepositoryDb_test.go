@@ -0,0 +1,270 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/jmoiron/sqlx"
+	"net/http"
+	"testing"
+)
+
+// Test common variables and inputs
+var payeeRepoDb PayeeRepositoryDb
+var payeesTableColumns = []string{"payee_id", "customer_id", "nickname", "account_id", "owner_name", "created_on", "confirmed_on"}
+
+const dummyPayeeIdAsInt int64 = 3
+
+const insertPayeesSql = "INSERT INTO payees (customer_id, nickname, account_id, owner_name, created_on) VALUES (?, ?, ?, ?, ?)"
+const selectPayeesSql = "SELECT payee_id, customer_id, nickname, account_id, owner_name, created_on, confirmed_on FROM payees"
+const updatePayeeNicknameSql = "UPDATE payees SET nickname = ? WHERE payee_id = ?"
+const updatePayeeConfirmedOnSql = "UPDATE payees SET confirmed_on = ? WHERE payee_id = ?"
+const deletePayeesSql = "DELETE FROM payees WHERE payee_id = ?"
+const insertAuditTrailSql = "INSERT INTO audit_trail (customer_id, entity_type, entity_id, action, details, changed_by, changed_on) VALUES (?, ?, ?, ?, ?, ?, ?)"
+const selectAuditTrailSql = "SELECT audit_id, customer_id, entity_type, entity_id, action, details, changed_by, changed_on FROM audit_trail WHERE customer_id = ? ORDER BY audit_id DESC"
+
+const selectPayeesPostgresSql = "SELECT payee_id, customer_id, nickname, account_id, owner_name, " +
+	"to_char(created_on, 'YYYY-MM-DD HH24:MI:SS') AS created_on, to_char(confirmed_on, 'YYYY-MM-DD HH24:MI:SS') AS confirmed_on FROM payees"
+
+func setupPayeeRepoDbTest(t *testing.T, driverName string) func() {
+	teardown := setupDB(t)
+	payeeRepoDb = NewPayeeRepositoryDb(sqlx.NewDb(db, driverName))
+	return teardown
+}
+
+func expectAuditInsert(entry AuditEntry) {
+	mockDB.ExpectExec(insertAuditTrailSql).
+		WithArgs(entry.CustomerId, entry.EntityType, entry.EntityId, entry.Action, entry.Details, entry.ChangedBy, entry.ChangedOn).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+}
+
+func TestPayeeRepositoryDb_FindAll_returns_payees_of_customer_for_every_driver(t *testing.T) {
+	tests := []struct {
+		driverName  string
+		expectedSql string
+	}{
+		{DriverMySQL, selectPayeesSql + " WHERE customer_id = ? ORDER BY payee_id"},
+		{DriverPostgres, selectPayeesPostgresSql + " WHERE customer_id = $1 ORDER BY payee_id"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.driverName, func(t *testing.T) {
+			//Arrange
+			teardown := setupPayeeRepoDbTest(t, tc.driverName)
+			defer teardown()
+
+			mockDB.ExpectQuery(tc.expectedSql).
+				WithArgs("2").
+				WillReturnRows(sqlmock.NewRows(payeesTableColumns).
+					AddRow("3", "2", "Mum", "95470", "", dummyDate, nil).
+					AddRow("4", "2", "Dad", "95471", "Steve", dummyDate, dummyDate))
+
+			//Act
+			payees, err := payeeRepoDb.FindAll("2")
+
+			//Assert
+			if err != nil {
+				t.Fatal("Expected no error but got error while testing retrieving payees: " + err.Message)
+			}
+			if len(payees) != 2 || payees[0] != getDummyPayee() || !payees[1].IsConfirmed() {
+				t.Errorf("Expected payee 3 pending confirmation and payee 4 confirmed but got %v", payees)
+			}
+		})
+	}
+}
+
+func TestPayeeRepositoryDb_FindById_returns_notFoundError_when_noPayee(t *testing.T) {
+	//Arrange
+	teardown := setupPayeeRepoDbTest(t, driverName)
+	defer teardown()
+
+	mockDB.ExpectQuery(selectPayeesSql + " WHERE payee_id = ?").
+		WithArgs("3").
+		WillReturnError(sql.ErrNoRows)
+	logger.MuteLogger()
+
+	//Act
+	_, err := payeeRepoDb.FindById("3")
+
+	//Assert
+	if err == nil || err.Code != http.StatusNotFound || err.Message != "Payee not found" {
+		t.Errorf("Expected not found error but got %v", err)
+	}
+}
+
+func TestPayeeRepositoryDb_Save_returns_error_and_rolls_back_when_insertAuditTrail_fails(t *testing.T) {
+	//Arrange
+	teardown := setupPayeeRepoDbTest(t, driverName)
+	defer teardown()
+
+	payee := getDummyPayee()
+	mockDB.ExpectBegin()
+	expectInsert(driverName, insertPayeesSql, "payee_id", dummyPayeeIdAsInt,
+		payee.CustomerId, payee.Nickname, payee.AccountId, payee.OwnerName, payee.CreatedOn)
+	dummyDbErr := errors.New("some error message")
+	mockDB.ExpectExec(insertAuditTrailSql).WillReturnError(dummyDbErr)
+	mockDB.ExpectRollback()
+
+	logs := logger.ReplaceWithTestLogger()
+	expectedLogMessage := "Error while writing payee change to audit trail: " + dummyDbErr.Error()
+
+	//Act
+	_, err := payeeRepoDb.Save(payee, "2")
+
+	//Assert
+	if err == nil || err.Message != defaultExpectedErrMessage {
+		t.Fatalf("Expected error \"%s\" but got %v", defaultExpectedErrMessage, err)
+	}
+	if logs.Len() != 1 || logs.All()[0].Message != expectedLogMessage {
+		t.Errorf("Expected log message \"%s\" but got %v", expectedLogMessage, logs.All())
+	}
+	if err := mockDB.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPayeeRepositoryDb_Save_returns_payee_and_records_addition_in_auditTrail(t *testing.T) {
+	//Arrange
+	teardown := setupPayeeRepoDbTest(t, driverName)
+	defer teardown()
+
+	payee := getDummyPayee()
+	payee.PayeeId = ""
+	mockDB.ExpectBegin()
+	expectInsert(driverName, insertPayeesSql, "payee_id", dummyPayeeIdAsInt,
+		payee.CustomerId, payee.Nickname, payee.AccountId, payee.OwnerName, payee.CreatedOn)
+	expectAuditInsert(NewPayeeAuditEntry(getDummyPayee(), AuditActionAdded, "\"Mum\" (account 95470)", "2", dummyDate))
+	mockDB.ExpectCommit()
+
+	//Act
+	actualPayee, err := payeeRepoDb.Save(payee, "2")
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing saving payee: " + err.Message)
+	}
+	if *actualPayee != getDummyPayee() {
+		t.Errorf("Expected payee %v but got %v", getDummyPayee(), *actualPayee)
+	}
+	if err := mockDB.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPayeeRepositoryDb_UpdateNickname_records_old_and_new_nickname_in_auditTrail(t *testing.T) {
+	//Arrange
+	teardown := setupPayeeRepoDbTest(t, driverName)
+	defer teardown()
+
+	payee := getDummyPayee()
+	mockDB.ExpectBegin()
+	mockDB.ExpectExec(updatePayeeNicknameSql).WithArgs("Mother", "3").WillReturnResult(sqlmock.NewResult(0, 1))
+	expectAuditInsert(NewPayeeAuditEntry(payee, AuditActionRenamed, "\"Mum\" to \"Mother\"", "2", dummyDate))
+	mockDB.ExpectCommit()
+
+	//Act
+	err := payeeRepoDb.UpdateNickname(payee, "Mother", "2", dummyDate)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing renaming payee: " + err.Message)
+	}
+	if err := mockDB.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPayeeRepositoryDb_Confirm_saves_confirmation_and_records_it_in_auditTrail(t *testing.T) {
+	//Arrange
+	teardown := setupPayeeRepoDbTest(t, driverName)
+	defer teardown()
+
+	payee := getDummyPayee()
+	payee.ConfirmedOn = sql.NullString{String: "2006-01-03 10:00:00", Valid: true}
+	mockDB.ExpectBegin()
+	mockDB.ExpectExec(updatePayeeConfirmedOnSql).WithArgs("2006-01-03 10:00:00", "3").WillReturnResult(sqlmock.NewResult(0, 1))
+	expectAuditInsert(NewPayeeAuditEntry(payee, AuditActionConfirmed, "\"Mum\" (account 95470)", "2", "2006-01-03 10:00:00"))
+	mockDB.ExpectCommit()
+
+	//Act
+	err := payeeRepoDb.Confirm(payee, "2")
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing confirming payee: " + err.Message)
+	}
+	if err := mockDB.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPayeeRepositoryDb_Delete_returns_error_and_rolls_back_when_delete_fails(t *testing.T) {
+	//Arrange
+	teardown := setupPayeeRepoDbTest(t, driverName)
+	defer teardown()
+
+	dummyDbErr := errors.New("some error message")
+	mockDB.ExpectBegin()
+	mockDB.ExpectExec(deletePayeesSql).WithArgs("3").WillReturnError(dummyDbErr)
+	mockDB.ExpectRollback()
+
+	logs := logger.ReplaceWithTestLogger()
+	expectedLogMessage := "Error while deleting payee: " + dummyDbErr.Error()
+
+	//Act
+	err := payeeRepoDb.Delete(getDummyPayee(), "2", dummyDate)
+
+	//Assert
+	if err == nil || err.Message != defaultExpectedErrMessage {
+		t.Fatalf("Expected error \"%s\" but got %v", defaultExpectedErrMessage, err)
+	}
+	if logs.Len() != 1 || logs.All()[0].Message != expectedLogMessage {
+		t.Errorf("Expected log message \"%s\" but got %v", expectedLogMessage, logs.All())
+	}
+}
+
+func TestPayeeRepositoryDb_Delete_deletes_payee_and_records_deletion_in_auditTrail(t *testing.T) {
+	//Arrange
+	teardown := setupPayeeRepoDbTest(t, driverName)
+	defer teardown()
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectExec(deletePayeesSql).WithArgs("3").WillReturnResult(sqlmock.NewResult(0, 1))
+	expectAuditInsert(NewPayeeAuditEntry(getDummyPayee(), AuditActionDeleted, "\"Mum\" (account 95470)", "2", dummyDate))
+	mockDB.ExpectCommit()
+
+	//Act
+	err := payeeRepoDb.Delete(getDummyPayee(), "2", dummyDate)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing deleting payee: " + err.Message)
+	}
+	if err := mockDB.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPayeeRepositoryDb_FindAuditTrail_returns_entries_of_customer(t *testing.T) {
+	//Arrange
+	teardown := setupPayeeRepoDbTest(t, driverName)
+	defer teardown()
+
+	mockDB.ExpectQuery(selectAuditTrailSql).
+		WithArgs("2").
+		WillReturnRows(sqlmock.NewRows([]string{"audit_id", "customer_id", "entity_type", "entity_id", "action", "details", "changed_by", "changed_on"}).
+			AddRow("2", "2", AuditEntityPayee, "3", AuditActionRenamed, "\"Mum\" to \"Mother\"", "2", dummyDate).
+			AddRow("1", "2", AuditEntityPayee, "3", AuditActionAdded, "\"Mum\" (account 95470)", "2", dummyDate))
+
+	//Act
+	entries, err := payeeRepoDb.FindAuditTrail("2")
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing retrieving audit trail: " + err.Message)
+	}
+	if len(entries) != 2 || entries[0].Action != AuditActionRenamed || entries[1].Action != AuditActionAdded {
+		t.Errorf("Expected renaming then addition but got %v", entries)
+	}
+}
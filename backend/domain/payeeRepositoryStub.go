@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/problem"
+	"strconv"
+	"sync"
+)
+
+//Server
+
+type PayeeRepositoryStub struct { //stub (adapter)
+	store *payeeStore //shared by all copies of the stub, so that changes made through one copy are seen by all
+}
+
+// payeeStore holds the payees and audit trail of a PayeeRepositoryStub in memory. It is safe for concurrent use.
+type payeeStore struct {
+	mu          sync.Mutex
+	payees      []Payee
+	auditTrail  []AuditEntry
+	nextPayeeId int64
+	nextAuditId int64
+}
+
+func NewPayeeRepositoryStub() PayeeRepositoryStub { //helper function to create and initialize a stub
+	return PayeeRepositoryStub{&payeeStore{
+		payees:      make([]Payee, 0),
+		auditTrail:  make([]AuditEntry, 0),
+		nextPayeeId: 1,
+		nextAuditId: 1,
+	}}
+}
+
+func (s PayeeRepositoryStub) FindAll(customerId string) ([]Payee, *errs.AppError) { //stub implements repo
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	payees := make([]Payee, 0)
+	for _, p := range s.store.payees {
+		if p.CustomerId == customerId {
+			payees = append(payees, p)
+		}
+	}
+	return payees, nil
+}
+
+func (s PayeeRepositoryStub) FindById(payeeId string) (*Payee, *errs.AppError) { //stub implements repo
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	i := s.store.indexOf(payeeId)
+	if i < 0 {
+		logger.Error("Error while finding payee by id using stub for PayeeRepository: not found")
+		return nil, problem.NewNotFoundError(problem.PayeeNotFound, "Payee not found")
+	}
+	payee := s.store.payees[i]
+	return &payee, nil
+}
+
+func (s PayeeRepositoryStub) Save(payee Payee, changedBy string) (*Payee, *errs.AppError) { //stub implements repo
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	payee.PayeeId = strconv.FormatInt(s.store.nextPayeeId, 10)
+	s.store.nextPayeeId++
+	s.store.payees = append(s.store.payees, payee)
+	s.store.audit(NewPayeeAuditEntry(payee, AuditActionAdded, payeeDetails(payee), changedBy, payee.CreatedOn))
+
+	return &payee, nil
+}
+
+func (s PayeeRepositoryStub) UpdateNickname(payee Payee, nickname string, changedBy string, changedOn string) *errs.AppError { //stub implements repo
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	if i := s.store.indexOf(payee.PayeeId); i >= 0 {
+		s.store.payees[i].Nickname = nickname
+	}
+	details := strconv.Quote(payee.Nickname) + " to " + strconv.Quote(nickname)
+	s.store.audit(NewPayeeAuditEntry(payee, AuditActionRenamed, details, changedBy, changedOn))
+	return nil
+}
+
+func (s PayeeRepositoryStub) Confirm(payee Payee, changedBy string) *errs.AppError { //stub implements repo
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	if i := s.store.indexOf(payee.PayeeId); i >= 0 {
+		s.store.payees[i].ConfirmedOn = payee.ConfirmedOn
+	}
+	s.store.audit(NewPayeeAuditEntry(payee, AuditActionConfirmed, payeeDetails(payee), changedBy, payee.ConfirmedOn.String))
+	return nil
+}
+
+func (s PayeeRepositoryStub) Delete(payee Payee, changedBy string, changedOn string) *errs.AppError { //stub implements repo
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	if i := s.store.indexOf(payee.PayeeId); i >= 0 {
+		s.store.payees = append(s.store.payees[:i], s.store.payees[i+1:]...)
+	}
+	s.store.audit(NewPayeeAuditEntry(payee, AuditActionDeleted, payeeDetails(payee), changedBy, changedOn))
+	return nil
+}
+
+// FindAuditTrail returns the changes made to the records of the customer with the given id, newest first.
+func (s PayeeRepositoryStub) FindAuditTrail(customerId string) ([]AuditEntry, *errs.AppError) { //stub implements repo
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	entries := make([]AuditEntry, 0)
+	for i := len(s.store.auditTrail) - 1; i >= 0; i-- {
+		if s.store.auditTrail[i].CustomerId == customerId {
+			entries = append(entries, s.store.auditTrail[i])
+		}
+	}
+	return entries, nil
+}
+
+// indexOf returns the index of the payee with the given id, or -1 if there is none. The caller must hold the lock.
+func (st *payeeStore) indexOf(payeeId string) int {
+	for i, p := range st.payees {
+		if p.PayeeId == payeeId {
+			return i
+		}
+	}
+	return -1
+}
+
+// audit records the given entry in the audit trail under the next free audit ID. The caller must hold the lock.
+func (st *payeeStore) audit(entry AuditEntry) {
+	entry.AuditId = strconv.FormatInt(st.nextAuditId, 10)
+	st.nextAuditId++
+	st.auditTrail = append(st.auditTrail, entry)
+}
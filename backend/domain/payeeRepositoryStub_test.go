@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"net/http"
+	"testing"
+)
+
+func TestPayeeRepositoryStub_Save_assigns_payeeIds_and_keeps_payees_of_each_customer_apart(t *testing.T) {
+	//Arrange
+	payeeRepositoryStub := NewPayeeRepositoryStub()
+	payee := getDummyPayee()
+	otherPayee := getDummyPayee()
+	otherPayee.CustomerId = "1"
+
+	//Act
+	first, _ := payeeRepositoryStub.Save(payee, "2")
+	second, _ := payeeRepositoryStub.Save(otherPayee, "1")
+
+	//Assert
+	if first.PayeeId != "1" || second.PayeeId != "2" {
+		t.Errorf("Expected payee ids 1 and 2 but got %s and %s", first.PayeeId, second.PayeeId)
+	}
+	payees, _ := payeeRepositoryStub.FindAll("2")
+	if len(payees) != 1 || payees[0] != *first {
+		t.Errorf("Expected only payee 1 of customer 2 but got %v", payees)
+	}
+}
+
+func TestPayeeRepositoryStub_records_changes_in_auditTrail_newest_first(t *testing.T) {
+	//Arrange
+	payeeRepositoryStub := NewPayeeRepositoryStub()
+	payee, _ := payeeRepositoryStub.Save(getDummyPayee(), "2")
+
+	//Act
+	_ = payeeRepositoryStub.UpdateNickname(*payee, "Mother", "2", dummyDate)
+	_ = payeeRepositoryStub.Confirm(payee.Confirm(clock.StaticClock{}), "2")
+	_ = payeeRepositoryStub.Delete(*payee, "2", dummyDate)
+
+	//Assert
+	entries, _ := payeeRepositoryStub.FindAuditTrail("2")
+	expectedActions := []string{AuditActionDeleted, AuditActionConfirmed, AuditActionRenamed, AuditActionAdded}
+	if len(entries) != len(expectedActions) {
+		t.Fatalf("Expected %d audit entries but got %v", len(expectedActions), entries)
+	}
+	for i, action := range expectedActions {
+		if entries[i].Action != action || entries[i].EntityId != payee.PayeeId {
+			t.Errorf("Expected entry %d to record payee %s %s but got %v", i, payee.PayeeId, action, entries[i])
+		}
+	}
+	if _, err := payeeRepositoryStub.FindById(payee.PayeeId); err == nil {
+		t.Error("Expected deleted payee to be gone but found it")
+	}
+}
+
+func TestPayeeRepositoryStub_FindById_returns_notFoundError_when_nonExistentPayee(t *testing.T) {
+	//Arrange
+	payeeRepositoryStub := NewPayeeRepositoryStub()
+	logger.MuteLogger()
+
+	//Act
+	_, err := payeeRepositoryStub.FindById("3")
+
+	//Assert
+	if err == nil || err.Code != http.StatusNotFound {
+		t.Errorf("Expected not found error but got %v", err)
+	}
+}
@@ -0,0 +1,181 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"testing"
+	"time"
+)
+
+var dummyPayeeTerms = PayeeTerms{CoolingOffPeriod: 24 * time.Hour, CoolingOffLimit: 1000}
+
+func getDummyPayee() Payee {
+	return Payee{PayeeId: "3", CustomerId: "2", Nickname: "Mum", AccountId: "95470", CreatedOn: dummyDate}
+}
+
+func TestNewPayee_is_pending_confirmation_and_cooling_off(t *testing.T) {
+	//Arrange
+	request := dto.NewPayeeRequest{CustomerId: "2", Nickname: "Mum", AccountId: "95470", OwnerName: "Steve"}
+
+	//Act
+	payee := NewPayee(request, clock.StaticClock{})
+
+	//Assert
+	response := payee.ToDTO(dummyPayeeTerms, clock.StaticClock{})
+	expectedResponse := dto.PayeeResponse{
+		Nickname:        "Mum",
+		AccountId:       "95470",
+		OwnerName:       "Steve",
+		Status:          dto.PayeeStatusPendingConfirmation,
+		CreatedOn:       dummyDate,
+		CoolingOffUntil: "2006-01-03 15:04:05",
+	}
+	if payee.IsConfirmed() || response != expectedResponse {
+		t.Errorf("Expected payee %v but got %v", expectedResponse, response)
+	}
+}
+
+func TestPayee_Confirm_makes_payee_active_at_currentTime(t *testing.T) {
+	//Arrange
+	payee := getDummyPayee()
+	payee.CreatedOn = "2005-01-02 15:04:05"
+
+	//Act
+	confirmed := payee.Confirm(clock.StaticClock{})
+
+	//Assert
+	response := confirmed.ToDTO(dummyPayeeTerms, clock.StaticClock{})
+	if response.Status != dto.PayeeStatusActive || response.ConfirmedOn != dummyDate || response.CoolingOffUntil != "" {
+		t.Errorf("Expected payee active since %s and past cooling-off but got %v", dummyDate, response)
+	}
+	if payee.IsConfirmed() {
+		t.Error("Expected original payee to be left pending confirmation")
+	}
+}
+
+func TestPayeeTerms_Allows_amountsOverLimit_only_after_coolingOff(t *testing.T) {
+	tests := []struct {
+		name      string
+		createdOn string
+		amount    float64
+		expected  bool
+	}{
+		{"amount within limit while cooling off", "2006-01-02 10:00:00", 1000, true},
+		{"amount over limit while cooling off", "2006-01-02 10:00:00", 1000.01, false},
+		{"amount over limit just before end of cooling-off", "2006-01-01 15:04:06", 5000, false},
+		{"amount over limit at end of cooling-off", "2006-01-01 15:04:05", 5000, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Arrange
+			payee := getDummyPayee()
+			payee.CreatedOn = tc.createdOn
+
+			//Act
+			actual := dummyPayeeTerms.Allows(payee, tc.amount, clock.StaticClock{})
+
+			//Assert
+			if actual != tc.expected {
+				t.Errorf("Expected %t but got %t", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestPayeeTerms_CoolingOffMessage_gives_limit_and_endOfCoolingOff(t *testing.T) {
+	//Act
+	message := dummyPayeeTerms.CoolingOffMessage(getDummyPayee())
+
+	//Assert
+	expectedMessage := "Transfers to a new payee are limited to 1000.00 until 2006-01-03 15:04:05."
+	if message != expectedMessage {
+		t.Errorf("Expected message \"%s\" but got \"%s\"", expectedMessage, message)
+	}
+}
+
+func TestOwnerNameMatches_ignores_case_and_spacing(t *testing.T) {
+	tests := []struct {
+		given    string
+		expected bool
+	}{
+		{"Steve Jobs", true},
+		{"  steve   JOBS ", true},
+		{"Steve", false},
+		{"Steven Jobs", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.given, func(t *testing.T) {
+			//Act
+			actual := OwnerNameMatches(tc.given, "Steve Jobs")
+
+			//Assert
+			if actual != tc.expected {
+				t.Errorf("Expected %t but got %t", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestPayee_ToTransfer_returns_withdrawal_and_deposit_filed_under_transfers(t *testing.T) {
+	tests := []struct {
+		name                          string
+		description                   string
+		expectedWithdrawalDescription string
+		expectedDepositDescription    string
+	}{
+		{"default descriptions", "", "Transfer to Mum", "Transfer from account 95471"},
+		{"given description", "Birthday", "Birthday", "Birthday"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Arrange
+			request := dto.TransferRequest{AccountId: "95471", PayeeId: "3", Amount: 50, Description: tc.description, Reference: "INV-1"}
+
+			//Act
+			withdrawal, deposit := getDummyPayee().ToTransfer(request, clock.StaticClock{})
+
+			//Assert
+			expectedWithdrawal := Transaction{
+				AccountId:       "95471",
+				Amount:          50,
+				TransactionType: dto.TransactionTypeWithdrawal,
+				TransactionDate: dummyDate,
+				Description:     tc.expectedWithdrawalDescription,
+				Reference:       "INV-1",
+				Category:        dto.TransactionCategoryTransfers,
+			}
+			expectedDeposit := Transaction{
+				AccountId:       "95470",
+				Amount:          50,
+				TransactionType: dto.TransactionTypeDeposit,
+				TransactionDate: dummyDate,
+				Description:     tc.expectedDepositDescription,
+				Reference:       "INV-1",
+				Category:        dto.TransactionCategoryTransfers,
+			}
+			if withdrawal != expectedWithdrawal {
+				t.Errorf("Expected withdrawal %v but got %v", expectedWithdrawal, withdrawal)
+			}
+			if deposit != expectedDeposit {
+				t.Errorf("Expected deposit %v but got %v", expectedDeposit, deposit)
+			}
+		})
+	}
+}
+
+func TestPayee_ToTransferResponseDTO_describes_withdrawal_to_payee(t *testing.T) {
+	//Arrange
+	withdrawal := Transaction{TransactionId: "12", AccountId: "95471", Amount: 50, TransactionDate: dummyDate, Balance: 3292.96}
+
+	//Act
+	response := getDummyPayee().ToTransferResponseDTO(withdrawal)
+
+	//Assert
+	expectedResponse := dto.TransferResponse{TransactionId: "12", PayeeId: "3", ToAccountId: "95470", Amount: 50, Balance: 3292.96, TransactionDate: dummyDate}
+	if *response != expectedResponse {
+		t.Errorf("Expected response %v but got %v", expectedResponse, *response)
+	}
+}
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"database/sql"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"math"
+)
+
+//Business Domain
+
+// FreezeReasonReconciliation is the reason given in the AccountStatusChanged event of an account frozen because of a
+// balance mismatch.
+const FreezeReasonReconciliation = "Balance mismatch found by reconciliation"
+
+// AccountBalanceSummary holds the stored balance of an account together with the totals of its transaction history,
+// which is everything needed to check that the stored balance has not drifted from the transactions. Accounts opened
+// before opening amounts were recorded have no opening amount, so their expected balance cannot be recomputed.
+type AccountBalanceSummary struct { //business/domain object
+	AccountId        string          `db:"account_id"`
+	CustomerId       string          `db:"customer_id"`
+	Status           string          `db:"status"`
+	OpeningAmount    sql.NullFloat64 `db:"opening_amount"`
+	StoredBalance    float64         `db:"amount"`
+	TotalDeposits    float64         `db:"total_deposits"`
+	TotalWithdrawals float64         `db:"total_withdrawals"` //including the fees and interest charged by the bank
+}
+
+// IsReconcilable reports whether the account has an opening amount to recompute its expected balance from.
+func (s AccountBalanceSummary) IsReconcilable() bool {
+	return s.OpeningAmount.Valid
+}
+
+// ExpectedBalance recomputes the balance of the account from its opening amount and transaction history. It is only
+// meaningful if the account is reconcilable.
+func (s AccountBalanceSummary) ExpectedBalance() float64 {
+	return roundToCents(s.OpeningAmount.Float64 + s.TotalDeposits - s.TotalWithdrawals)
+}
+
+// Delta is the difference between the stored balance and the expected balance, in cents precision. A positive delta
+// means the stored balance is higher than what the transaction history accounts for.
+func (s AccountBalanceSummary) Delta() float64 {
+	return roundToCents(s.StoredBalance - s.ExpectedBalance())
+}
+
+func (s AccountBalanceSummary) IsMismatched() bool {
+	return s.Delta() != 0
+}
+
+func (s AccountBalanceSummary) ToUnreconciledDTO() dto.ReconciliationUnreconciledResponse {
+	return dto.ReconciliationUnreconciledResponse{
+		AccountId:     s.AccountId,
+		CustomerId:    s.CustomerId,
+		StoredBalance: s.StoredBalance,
+		IsFrozen:      s.Status == AccountStatusFrozen,
+	}
+}
+
+func (s AccountBalanceSummary) ToMismatchDTO() dto.ReconciliationMismatchResponse {
+	return dto.ReconciliationMismatchResponse{
+		AccountId:       s.AccountId,
+		CustomerId:      s.CustomerId,
+		StoredBalance:   s.StoredBalance,
+		ExpectedBalance: s.ExpectedBalance(),
+		Delta:           s.Delta(),
+		IsFrozen:        s.Status == AccountStatusFrozen,
+	}
+}
+
+// roundToCents avoids reporting float64 rounding noise from the sums as mismatches.
+func roundToCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+//Server
+
+//go:generate mockgen -destination=../mocks/domain/mock_reconciliationRepository.go -package=domain github.com/aliciatay-zls/banking/backend/domain ReconciliationRepository
+type ReconciliationRepository interface { //repo (secondary port)
+	FindAllBalanceSummaries() ([]AccountBalanceSummary, *errs.AppError)
+	FreezeAccounts([]string, string) *errs.AppError
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/jmoiron/sqlx"
+)
+
+//Server
+
+type ReconciliationRepositoryDb struct { //DB (adapter)
+	client *sqlx.DB
+}
+
+func NewReconciliationRepositoryDb(dbClient *sqlx.DB) ReconciliationRepositoryDb {
+	return ReconciliationRepositoryDb{dbClient}
+}
+
+// FindAllBalanceSummaries retrieves every account with the sums of its deposits and of its debits (withdrawals and
+// charges), aggregated by the database.
+func (d ReconciliationRepositoryDb) FindAllBalanceSummaries() ([]AccountBalanceSummary, *errs.AppError) {
+	summaries := make([]AccountBalanceSummary, 0)
+	summarySql := "SELECT a.account_id, a.customer_id, a.status, a.opening_amount, a.amount, " +
+		"COALESCE(SUM(CASE WHEN t.transaction_type = ? THEN t.amount ELSE 0 END), 0) AS total_deposits, " +
+		"COALESCE(SUM(CASE WHEN t.transaction_type <> ? THEN t.amount ELSE 0 END), 0) AS total_withdrawals " +
+		"FROM accounts a LEFT JOIN transactions t ON t.account_id = a.account_id " +
+		"GROUP BY a.account_id, a.customer_id, a.status, a.opening_amount, a.amount ORDER BY a.account_id"
+	err := d.client.Select(&summaries, d.client.Rebind(summarySql), dto.TransactionTypeDeposit, dto.TransactionTypeDeposit)
+	if err != nil {
+		logger.Error("Error while retrieving account balance summaries: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return summaries, nil
+}
+
+// FreezeAccounts starts a database transaction, sets the status of all accounts with the given ids to frozen, writes
+// an AccountStatusChanged event for each of them to the outbox and commits the database transaction.
+func (d ReconciliationRepositoryDb) FreezeAccounts(accountIds []string, frozenOn string) *errs.AppError {
+	if len(accountIds) == 0 {
+		return nil
+	}
+
+	freezeSql, args, err := sqlx.In("UPDATE accounts SET status = ? WHERE account_id IN (?)", AccountStatusFrozen, accountIds)
+	if err != nil {
+		logger.Error("Error while building query for freezing accounts: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	tx, err := d.client.Beginx()
+	if err != nil {
+		logger.Error("Error while starting db transaction for freezing accounts: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	if _, err = tx.Exec(tx.Rebind(freezeSql), args...); err != nil {
+		logger.Error("Error while freezing accounts: " + err.Error())
+		rollbackFreeze(tx)
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	for _, accountId := range accountIds {
+		event := NewAccountStatusChangedEvent(accountId, AccountStatusFrozen, FreezeReasonReconciliation, frozenOn)
+		if err = insertOutboxEvent(tx, event); err != nil {
+			logger.Error("Error while writing account status changed event to outbox: " + err.Error())
+			rollbackFreeze(tx)
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("Error while committing db transaction for freezing accounts: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
+
+func rollbackFreeze(tx *sqlx.Tx) {
+	if rollbackErr := tx.Rollback(); rollbackErr != nil {
+		logger.Fatal("Error while rolling back freezing of accounts: " + rollbackErr.Error())
+	}
+}
@@ -0,0 +1,156 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/jmoiron/sqlx"
+	"testing"
+)
+
+// Test common variables and inputs
+var reconRepoDb ReconciliationRepositoryDb
+var balanceSummaryColumns = []string{"account_id", "customer_id", "status", "opening_amount", "amount", "total_deposits", "total_withdrawals"}
+
+const selectBalanceSummariesSql = "SELECT a.account_id, a.customer_id, a.status, a.opening_amount, a.amount, " +
+	"COALESCE(SUM(CASE WHEN t.transaction_type = ? THEN t.amount ELSE 0 END), 0) AS total_deposits, " +
+	"COALESCE(SUM(CASE WHEN t.transaction_type <> ? THEN t.amount ELSE 0 END), 0) AS total_withdrawals " +
+	"FROM accounts a LEFT JOIN transactions t ON t.account_id = a.account_id " +
+	"GROUP BY a.account_id, a.customer_id, a.status, a.opening_amount, a.amount ORDER BY a.account_id"
+const updateAccountsFreezeSql = "UPDATE accounts SET status = ? WHERE account_id IN (?, ?)"
+
+func setupReconciliationRepoDbTest(t *testing.T) func() {
+	teardown := setupDB(t)
+	reconRepoDb = NewReconciliationRepositoryDb(sqlx.NewDb(db, driverName))
+	return teardown
+}
+
+func TestReconciliationRepositoryDb_FindAllBalanceSummaries_returns_error_when_select_fails(t *testing.T) {
+	//Arrange
+	teardown := setupReconciliationRepoDbTest(t)
+	defer teardown()
+
+	dummyDbErr := errors.New("some error message")
+	mockDB.ExpectQuery(selectBalanceSummariesSql).
+		WithArgs(dto.TransactionTypeDeposit, dto.TransactionTypeDeposit).
+		WillReturnError(dummyDbErr)
+
+	logs := logger.ReplaceWithTestLogger()
+	expectedLogMessage := "Error while retrieving account balance summaries: " + dummyDbErr.Error()
+
+	//Act
+	_, err := reconRepoDb.FindAllBalanceSummaries()
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing failed selection of balance summaries")
+	}
+	if err.Message != defaultExpectedErrMessage {
+		t.Errorf("Expected error message to be \"%s\" but got \"%s\"", defaultExpectedErrMessage, err.Message)
+	}
+	if logs.Len() != 1 {
+		t.Fatalf("Expected 1 message to be logged but got %d logs", logs.Len())
+	}
+	if logs.All()[0].Message != expectedLogMessage {
+		t.Errorf("Expected log message to be \"%s\" but got \"%s\"", expectedLogMessage, logs.All()[0].Message)
+	}
+}
+
+func TestReconciliationRepositoryDb_FindAllBalanceSummaries_returns_summaries_when_select_succeeds(t *testing.T) {
+	//Arrange
+	teardown := setupReconciliationRepoDbTest(t)
+	defer teardown()
+
+	expectedSummaries := []AccountBalanceSummary{
+		{dummyAccountId, dummyCustomerId, AccountStatusActive, sql.NullFloat64{Float64: 6000, Valid: true}, 12000, 6000, 0},
+		{"1980", dummyCustomerId, AccountStatusFrozen, sql.NullFloat64{Float64: 5000, Valid: true}, 4000, 0, 500},
+		{"1981", dummyCustomerId, AccountStatusActive, sql.NullFloat64{}, 3000, 0, 0}, //legacy account
+	}
+	dummyRows := sqlmock.NewRows(balanceSummaryColumns)
+	for _, s := range expectedSummaries {
+		openingAmount, _ := s.OpeningAmount.Value()
+		dummyRows.AddRow(s.AccountId, s.CustomerId, s.Status, openingAmount, s.StoredBalance, s.TotalDeposits, s.TotalWithdrawals)
+	}
+	mockDB.ExpectQuery(selectBalanceSummariesSql).
+		WithArgs(dto.TransactionTypeDeposit, dto.TransactionTypeDeposit).
+		WillReturnRows(dummyRows)
+
+	//Act
+	actualSummaries, err := reconRepoDb.FindAllBalanceSummaries()
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing successful selection of balance summaries: " + err.Message)
+	}
+	if len(actualSummaries) != len(expectedSummaries) {
+		t.Fatalf("Expected %d summaries but got %d", len(expectedSummaries), len(actualSummaries))
+	}
+	for i := range expectedSummaries {
+		if actualSummaries[i] != expectedSummaries[i] {
+			t.Errorf("Expected summary %v but got %v", expectedSummaries[i], actualSummaries[i])
+		}
+	}
+}
+
+func TestReconciliationRepositoryDb_FreezeAccounts_returns_error_and_rollsBack_when_insertOutbox_fails(t *testing.T) {
+	//Arrange
+	teardown := setupReconciliationRepoDbTest(t)
+	defer teardown()
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectExec(updateAccountsFreezeSql).
+		WithArgs(AccountStatusFrozen, dummyAccountId, "1980").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	dummyDbErr := errors.New("some error message")
+	mockDB.ExpectExec(insertOutboxSql).WillReturnError(dummyDbErr)
+	mockDB.ExpectRollback()
+
+	logs := logger.ReplaceWithTestLogger()
+	expectedLogMessage := "Error while writing account status changed event to outbox: " + dummyDbErr.Error()
+
+	//Act
+	err := reconRepoDb.FreezeAccounts([]string{dummyAccountId, "1980"}, dummyDate)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing failed writing of event to outbox")
+	}
+	if err.Message != defaultExpectedErrMessage {
+		t.Errorf("Expected error message to be \"%s\" but got \"%s\"", defaultExpectedErrMessage, err.Message)
+	}
+	if logs.Len() != 1 {
+		t.Fatalf("Expected 1 message to be logged but got %d logs", logs.Len())
+	}
+	if logs.All()[0].Message != expectedLogMessage {
+		t.Errorf("Expected log message to be \"%s\" but got \"%s\"", expectedLogMessage, logs.All()[0].Message)
+	}
+}
+
+func TestReconciliationRepositoryDb_FreezeAccounts_updates_status_of_givenAccounts(t *testing.T) {
+	//Arrange
+	teardown := setupReconciliationRepoDbTest(t)
+	defer teardown()
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectExec(updateAccountsFreezeSql).
+		WithArgs(AccountStatusFrozen, dummyAccountId, "1980").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	for _, accountId := range []string{dummyAccountId, "1980"} {
+		expectOutboxInsert(insertOutboxSql,
+			NewAccountStatusChangedEvent(accountId, AccountStatusFrozen, FreezeReasonReconciliation, dummyDate))
+	}
+	mockDB.ExpectCommit()
+
+	//Act
+	err := reconRepoDb.FreezeAccounts([]string{dummyAccountId, "1980"}, dummyDate)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing freezing of accounts: " + err.Message)
+	}
+	if err := mockDB.ExpectationsWereMet(); err != nil {
+		t.Error("Expected accounts to be frozen but were not: " + err.Error())
+	}
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"database/sql"
+	"testing"
+)
+
+func TestAccountBalanceSummary_Delta_returns_correctDelta(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name          string
+		summary       AccountBalanceSummary
+		expectedDelta float64
+	}{
+		{"balanced", AccountBalanceSummary{OpeningAmount: sql.NullFloat64{Float64: 5000, Valid: true}, StoredBalance: 5200.1, TotalDeposits: 300.3, TotalWithdrawals: 100.2}, 0},
+		{"stored balance too high", AccountBalanceSummary{OpeningAmount: sql.NullFloat64{Float64: 5000, Valid: true}, StoredBalance: 5500, TotalDeposits: 300}, 200},
+		{"stored balance too low", AccountBalanceSummary{OpeningAmount: sql.NullFloat64{Float64: 5000, Valid: true}, StoredBalance: 4899.99, TotalWithdrawals: 100}, -0.01},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Act
+			actualDelta := tc.summary.Delta()
+
+			//Assert
+			if actualDelta != tc.expectedDelta {
+				t.Errorf("expected delta %v but got %v", tc.expectedDelta, actualDelta)
+			}
+			if tc.summary.IsMismatched() != (tc.expectedDelta != 0) {
+				t.Errorf("expected mismatch to be %v but was not", tc.expectedDelta != 0)
+			}
+		})
+	}
+}
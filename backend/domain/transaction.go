@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"database/sql"
 	"github.com/aliciatay-zls/banking-lib/clock"
 	"github.com/aliciatay-zls/banking/backend/dto"
 )
@@ -14,6 +15,14 @@ type Transaction struct { //business/domain object
 	Balance         float64
 	TransactionType string `db:"transaction_type"`
 	TransactionDate string `db:"transaction_date"`
+	Description     string `db:"description"`
+	Reference       string `db:"reference"` //given by the customer, e.g. an invoice number
+	Category        string `db:"category"`  //empty while uncategorized
+	//the transaction that a fee was charged for or that a reversal undoes, null for other transactions and fees not
+	//charged for a transaction
+	RelatedTransactionId sql.NullString `db:"related_transaction_id"`
+	//the reversal that undid the transaction, null unless the transaction was reversed
+	ReversedByTransactionId sql.NullString `db:"reversed_by_transaction_id"`
 }
 
 func NewTransaction(accountId string, amount float64, transactionType string, c clock.Clock) Transaction {
@@ -25,14 +34,102 @@ func NewTransaction(accountId string, amount float64, transactionType string, c
 	}
 }
 
+// NewCustomerTransaction returns the transaction requested by a customer, with the description, reference and category
+// they gave, dated at the current time.
+func NewCustomerTransaction(request dto.TransactionRequest, c clock.Clock) Transaction {
+	transaction := NewTransaction(request.AccountId, request.Amount, request.TransactionType, c)
+	transaction.Description = request.Description
+	transaction.Reference = request.Reference
+	transaction.Category = request.Category
+	return transaction
+}
+
+// ToFee returns the transaction charging the given fee for the posted transaction, dated at the current time.
+func (t Transaction) ToFee(fee float64, c clock.Clock) Transaction {
+	feeTransaction := NewTransaction(t.AccountId, fee, dto.TransactionTypeFee, c)
+	feeTransaction.RelatedTransactionId = sql.NullString{String: t.TransactionId, Valid: true}
+	return feeTransaction
+}
+
+// ToReversal returns the compensating transaction undoing the posted transaction, dated at the current time. It moves
+// the same amount in the opposite direction: a deposit for a debit and a withdrawal for a deposit, in the same category.
+func (t Transaction) ToReversal(c clock.Clock) Transaction {
+	transactionType := dto.TransactionTypeWithdrawal
+	if t.IsDebit() {
+		transactionType = dto.TransactionTypeDeposit
+	}
+	reversal := NewTransaction(t.AccountId, t.Amount, transactionType, c)
+	reversal.RelatedTransactionId = sql.NullString{String: t.TransactionId, Valid: true}
+	reversal.Category = t.Category
+	return reversal
+}
+
 func (t Transaction) ToTransactionResponseDTO() *dto.TransactionResponse {
 	return &dto.TransactionResponse{
 		TransactionId:   t.TransactionId,
+		Status:          dto.TransactionStatusPosted,
 		Balance:         t.Balance,
 		TransactionDate: t.TransactionDate,
+		Description:     t.Description,
+		Reference:       t.Reference,
+		Category:        t.Category,
+	}
+}
+
+// ToAccountTransactionDTO returns the transaction as it is shown in the transaction list of its account.
+func (t Transaction) ToAccountTransactionDTO() dto.AccountTransactionResponse {
+	status := dto.TransactionStatusPosted
+	if t.IsReversed() {
+		status = dto.TransactionStatusReversed
+	}
+	return dto.AccountTransactionResponse{
+		TransactionId:           t.TransactionId,
+		TransactionType:         t.TransactionType,
+		Amount:                  t.Amount,
+		TransactionDate:         t.TransactionDate,
+		Status:                  status,
+		Description:             t.Description,
+		Reference:               t.Reference,
+		Category:                t.Category,
+		RelatedTransactionId:    t.RelatedTransactionId.String,
+		ReversedByTransactionId: t.ReversedByTransactionId.String,
 	}
 }
 
+func (t Transaction) IsReversed() bool {
+	return t.ReversedByTransactionId.Valid
+}
+
 func (t Transaction) IsWithdrawal() bool {
 	return t.TransactionType == dto.TransactionTypeWithdrawal
 }
+
+// IsDebit reports whether the transaction takes money out of its account: a withdrawal or a charge of the bank.
+func (t Transaction) IsDebit() bool {
+	return t.TransactionType != dto.TransactionTypeDeposit
+}
+
+// BalanceChange returns the amount by which the transaction changes the balance of its account, which is negative for
+// a debit.
+func (t Transaction) BalanceChange() float64 {
+	if t.IsDebit() {
+		return -t.Amount
+	}
+	return t.Amount
+}
+
+// PreviousBalance returns the balance of the account before the posted transaction.
+func (t Transaction) PreviousBalance() float64 {
+	return t.Balance - t.BalanceChange()
+}
+
+// Overdraws reports whether the posted transaction took the balance of its account below zero.
+func (t Transaction) Overdraws() bool {
+	return t.PreviousBalance() >= 0 && t.Balance < 0
+}
+
+// RepaysOverdraft reports whether the posted transaction took the balance of its account from below zero back to zero
+// or above.
+func (t Transaction) RepaysOverdraft() bool {
+	return t.PreviousBalance() < 0 && t.Balance >= 0
+}
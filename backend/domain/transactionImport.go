@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+)
+
+//Business Domain
+
+type TransactionImport struct { //business/domain object
+	ImportId   string `db:"import_id"`
+	FileHash   string `db:"file_hash"`
+	RowCount   int    `db:"row_count"`
+	ImportedOn string `db:"imported_on"`
+}
+
+// TransactionImportEntry is one row of an uploaded file, holding the bank transaction to be posted together with
+// the row number and the reference given for it in the file.
+type TransactionImportEntry struct {
+	RowNumber   int
+	Reference   string
+	Transaction Transaction
+}
+
+//Server
+
+//go:generate mockgen -destination=../mocks/domain/mock_transactionImportRepository.go -package=domain github.com/aliciatay-zls/banking/backend/domain TransactionImportRepository
+type TransactionImportRepository interface { //repo (secondary port)
+	ExistsByFileHash(string) (bool, *errs.AppError)
+	Save(TransactionImport, []TransactionImportEntry) (*TransactionImport, []TransactionImportEntry, *errs.AppError)
+}
@@ -0,0 +1,149 @@
+package domain
+
+import (
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/problem"
+	"github.com/jmoiron/sqlx"
+	"strconv"
+)
+
+//Server
+
+type TransactionImportRepositoryDb struct { //DB (adapter)
+	client *sqlx.DB
+}
+
+func NewTransactionImportRepositoryDb(dbClient *sqlx.DB) TransactionImportRepositoryDb {
+	return TransactionImportRepositoryDb{dbClient}
+}
+
+// ExistsByFileHash checks whether a file with the given SHA-256 hash has already been imported.
+func (d TransactionImportRepositoryDb) ExistsByFileHash(fileHash string) (bool, *errs.AppError) {
+	var count int
+	countSql := "SELECT COUNT(*) FROM transaction_imports WHERE file_hash = ?"
+	if err := d.client.Get(&count, d.client.Rebind(countSql), fileHash); err != nil {
+		logger.Error("Error while checking for previous import of file: " + err.Error())
+		return false, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return count > 0, nil
+}
+
+// Save starts a database transaction, records the import, then for each entry updates the account balance, creates a
+// new entry in the database for the bank transaction and its reference and writes a TransactionPosted event to the
+// outbox. The database transaction is only committed if every entry succeeds, so either all of the entries are posted
+// or none of them are. A withdrawal that would make the account balance negative causes the whole import to be rolled
+// back, and so does a file that has been imported in the meantime, e.g. by a concurrent upload of the same file.
+// Save returns the import and entries with their database-generated IDs and the resulting account balances set.
+func (d TransactionImportRepositoryDb) Save(transactionImport TransactionImport, entries []TransactionImportEntry) (*TransactionImport, []TransactionImportEntry, *errs.AppError) {
+	tx, err := d.client.Beginx()
+	if err != nil {
+		logger.Error("Error while starting db transaction for importing transactions: " + err.Error())
+		return nil, nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	insertImportSql := "INSERT INTO transaction_imports (file_hash, row_count, imported_on) VALUES (?, ?, ?)"
+	result, err := execInsert(tx, insertImportSql, "import_id", transactionImport.FileHash, transactionImport.RowCount, transactionImport.ImportedOn)
+	if err != nil {
+		logger.Error("Error while creating new transaction import: " + err.Error())
+		rollbackImport(tx)
+		if isUniqueViolation(err) {
+			return nil, nil, problem.NewConflictError(problem.ImportDuplicate, "This file has already been imported")
+		}
+		return nil, nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	importId, err := result.LastInsertId()
+	if err != nil {
+		logger.Error("Error while getting id of newly inserted transaction import: " + err.Error())
+		rollbackImport(tx)
+		return nil, nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	transactionImport.ImportId = strconv.FormatInt(importId, 10)
+
+	postedEntries := make([]TransactionImportEntry, 0, len(entries))
+	for _, entry := range entries {
+		postedTransaction, appErr := postImportEntry(tx, importId, entry)
+		if appErr != nil {
+			rollbackImport(tx)
+			return nil, nil, appErr
+		}
+		entry.Transaction = *postedTransaction
+		postedEntries = append(postedEntries, entry)
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("Error while committing db transaction for importing transactions: " + err.Error())
+		return nil, nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return &transactionImport, postedEntries, nil
+}
+
+// postImportEntry updates the account balance, creates the bank transaction and import entry and writes the
+// TransactionPosted event for a single row within the given database transaction, returning the bank transaction with
+// its ID and the resulting account balance set.
+func postImportEntry(tx *sqlx.Tx, importId int64, entry TransactionImportEntry) (*Transaction, *errs.AppError) {
+	transaction := entry.Transaction
+
+	if transaction.IsWithdrawal() {
+		withdrawSql := "UPDATE accounts SET amount = amount - ? WHERE account_id = ? AND amount + overdraft_limit >= ?"
+		result, err := tx.Exec(tx.Rebind(withdrawSql), transaction.Amount, transaction.AccountId, transaction.Amount)
+		if err != nil {
+			logger.Error("Error while updating account for imported transaction: " + err.Error())
+			return nil, errs.NewUnexpectedError("Unexpected database error")
+		}
+		if rowsAffected, err := result.RowsAffected(); err != nil || rowsAffected != 1 {
+			logger.Error(fmt.Sprintf("Imported withdrawal on row %d exceeds account balance", entry.RowNumber))
+			return nil, problem.NewValidationError(problem.InsufficientFunds,
+				fmt.Sprintf("Account balance insufficient to withdraw given amount (row %d)", entry.RowNumber))
+		}
+	} else {
+		depositSql := "UPDATE accounts SET amount = amount + ? WHERE account_id = ?"
+		if _, err := tx.Exec(tx.Rebind(depositSql), transaction.Amount, transaction.AccountId); err != nil {
+			logger.Error("Error while updating account for imported transaction: " + err.Error())
+			return nil, errs.NewUnexpectedError("Unexpected database error")
+		}
+	}
+
+	addTransactionSql := "INSERT INTO transactions (account_id, amount, transaction_type, transaction_date) VALUES (?, ?, ?, ?)"
+	result, err := execInsert(tx, addTransactionSql, "transaction_id",
+		transaction.AccountId, transaction.Amount, transaction.TransactionType, transaction.TransactionDate)
+	if err != nil {
+		logger.Error("Error while creating new bank account transaction for import: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	transactionId, err := result.LastInsertId()
+	if err != nil {
+		logger.Error("Error while getting id of newly inserted transaction for import: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	addEntrySql := "INSERT INTO transaction_import_entries (import_id, row_num, transaction_id, reference) VALUES (?, ?, ?, ?)"
+	if _, err = tx.Exec(tx.Rebind(addEntrySql), importId, entry.RowNumber, transactionId, entry.Reference); err != nil {
+		logger.Error("Error while creating new transaction import entry: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	transaction.TransactionId = strconv.FormatInt(transactionId, 10)
+
+	balanceSql := "SELECT amount FROM accounts WHERE account_id = ?"
+	if err = tx.Get(&transaction.Balance, tx.Rebind(balanceSql), transaction.AccountId); err != nil {
+		logger.Error("Error while retrieving new account balance for import: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	if err = insertOutboxEvent(tx, NewTransactionPostedEvent(transaction)); err != nil {
+		logger.Error("Error while writing transaction posted event to outbox for import: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return &transaction, nil
+}
+
+func rollbackImport(tx *sqlx.Tx) {
+	if rollbackErr := tx.Rollback(); rollbackErr != nil {
+		logger.Fatal("Error while rolling back importing of transactions: " + rollbackErr.Error())
+	}
+}
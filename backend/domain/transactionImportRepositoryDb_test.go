@@ -0,0 +1,211 @@
+package domain
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"net/http"
+	"testing"
+)
+
+// Test common variables and inputs
+var importRepoDb TransactionImportRepositoryDb
+
+const dummyFileHash = "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+const dummyImportIdAsInt int64 = 11
+const dummyReference = "PAYROLL-0001"
+
+const countImportsSql = "SELECT COUNT(*) FROM transaction_imports WHERE file_hash = ?"
+const insertImportsSql = "INSERT INTO transaction_imports (file_hash, row_count, imported_on) VALUES (?, ?, ?)"
+const insertImportEntriesSql = "INSERT INTO transaction_import_entries (import_id, row_num, transaction_id, reference) VALUES (?, ?, ?, ?)"
+
+func setupTransactionImportRepoDbTest(t *testing.T) func() {
+	teardown := setupDB(t)
+	importRepoDb = NewTransactionImportRepositoryDb(sqlx.NewDb(db, driverName))
+	return teardown
+}
+
+// getDefaultImportEntry returns a TransactionImportEntry on row 2 of the file for a deposit of amount 6000 on the
+// account with id 1977 at 2006-01-02 15:04:05
+func getDefaultImportEntry() TransactionImportEntry {
+	return TransactionImportEntry{RowNumber: 2, Reference: dummyReference, Transaction: getDefaultTransactionBeforeTransact()}
+}
+
+func getDefaultTransactionImport() TransactionImport {
+	return TransactionImport{FileHash: dummyFileHash, RowCount: 1, ImportedOn: dummyDate}
+}
+
+func TestTransactionImportRepositoryDb_ExistsByFileHash_returns_true_when_hash_found(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionImportRepoDbTest(t)
+	defer teardown()
+
+	mockDB.ExpectQuery(countImportsSql).
+		WithArgs(dummyFileHash).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	//Act
+	exists, err := importRepoDb.ExistsByFileHash(dummyFileHash)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing previously imported file: " + err.Message)
+	}
+	if !exists {
+		t.Error("Expected file to be reported as previously imported but was not")
+	}
+}
+
+func TestTransactionImportRepositoryDb_Save_returns_error_and_rollsBack_when_insertTransactions_fails(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionImportRepoDbTest(t)
+	defer teardown()
+
+	dummyImport := getDefaultTransactionImport()
+	dummyEntry := getDefaultImportEntry()
+	dummyDbErr := errors.New("some error message")
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectExec(insertImportsSql).
+		WithArgs(dummyImport.FileHash, dummyImport.RowCount, dummyImport.ImportedOn).
+		WillReturnResult(sqlmock.NewResult(dummyImportIdAsInt, 1))
+	mockDB.ExpectExec(updateAccountsDepositSql).
+		WithArgs(dummyEntry.Transaction.Amount, dummyEntry.Transaction.AccountId).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mockDB.ExpectExec(insertTransactionsSql).
+		WithArgs(dummyEntry.Transaction.AccountId, dummyEntry.Transaction.Amount, dummyEntry.Transaction.TransactionType, dummyEntry.Transaction.TransactionDate).
+		WillReturnError(dummyDbErr)
+	mockDB.ExpectRollback()
+
+	logs := logger.ReplaceWithTestLogger()
+	expectedLogMessage := "Error while creating new bank account transaction for import: " + dummyDbErr.Error()
+
+	//Act
+	_, _, actualErr := importRepoDb.Save(dummyImport, []TransactionImportEntry{dummyEntry})
+
+	//Assert
+	if actualErr == nil {
+		t.Fatal("Expected error but got none while testing failed insertion of imported transaction")
+	}
+	if actualErr.Message != defaultExpectedErrMessage {
+		t.Errorf("Expected error message to be \"%s\" but got \"%s\"", defaultExpectedErrMessage, actualErr.Message)
+	}
+	if logs.Len() != 1 {
+		t.Fatalf("Expected 1 message to be logged but got %d logs", logs.Len())
+	}
+	if logs.All()[0].Message != expectedLogMessage {
+		t.Errorf("Expected log message to be \"%s\" but got \"%s\"", expectedLogMessage, logs.All()[0].Message)
+	}
+	if err := mockDB.ExpectationsWereMet(); err != nil {
+		t.Error("Expected db transaction to be rolled back but was not: " + err.Error())
+	}
+}
+
+func TestTransactionImportRepositoryDb_Save_returns_conflictError_when_file_imported_concurrently(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionImportRepoDbTest(t)
+	defer teardown()
+
+	dummyImport := getDefaultTransactionImport()
+	dummyEntry := getDefaultImportEntry()
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectExec(insertImportsSql).
+		WithArgs(dummyImport.FileHash, dummyImport.RowCount, dummyImport.ImportedOn).
+		WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry for key 'transaction_imports_file_hash'"})
+	mockDB.ExpectRollback()
+	logger.MuteLogger()
+
+	//Act
+	_, _, actualErr := importRepoDb.Save(dummyImport, []TransactionImportEntry{dummyEntry})
+
+	//Assert
+	if actualErr == nil {
+		t.Fatal("Expected error but got none while testing concurrent import of the same file")
+	}
+	if actualErr.Code != http.StatusConflict {
+		t.Errorf("Expected status code %d but got %d", http.StatusConflict, actualErr.Code)
+	}
+	if err := mockDB.ExpectationsWereMet(); err != nil {
+		t.Error("Expected db transaction to be rolled back but was not: " + err.Error())
+	}
+}
+
+func TestTransactionImportRepositoryDb_Save_returns_error_and_rollsBack_when_withdrawal_exceeds_balance(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionImportRepoDbTest(t)
+	defer teardown()
+
+	dummyImport := getDefaultTransactionImport()
+	dummyEntry := getDefaultImportEntry()
+	dummyEntry.Transaction.TransactionType = dto.TransactionTypeWithdrawal
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectExec(insertImportsSql).
+		WithArgs(dummyImport.FileHash, dummyImport.RowCount, dummyImport.ImportedOn).
+		WillReturnResult(sqlmock.NewResult(dummyImportIdAsInt, 1))
+	mockDB.ExpectExec(updateAccountsWithdrawalSql).
+		WithArgs(dummyEntry.Transaction.Amount, dummyEntry.Transaction.AccountId, dummyEntry.Transaction.Amount).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mockDB.ExpectRollback()
+
+	//Act
+	_, _, actualErr := importRepoDb.Save(dummyImport, []TransactionImportEntry{dummyEntry})
+
+	//Assert
+	if actualErr == nil {
+		t.Fatal("Expected error but got none while testing imported withdrawal exceeding balance")
+	}
+	if actualErr.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status code %d but got %d", http.StatusUnprocessableEntity, actualErr.Code)
+	}
+	if err := mockDB.ExpectationsWereMet(); err != nil {
+		t.Error("Expected db transaction to be rolled back but was not: " + err.Error())
+	}
+}
+
+func TestTransactionImportRepositoryDb_Save_returns_importAndEntries_when_allEntries_posted(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionImportRepoDbTest(t)
+	defer teardown()
+
+	dummyImport := getDefaultTransactionImport()
+	dummyEntry := getDefaultImportEntry()
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectExec(insertImportsSql).
+		WithArgs(dummyImport.FileHash, dummyImport.RowCount, dummyImport.ImportedOn).
+		WillReturnResult(sqlmock.NewResult(dummyImportIdAsInt, 1))
+	mockDB.ExpectExec(updateAccountsDepositSql).
+		WithArgs(dummyEntry.Transaction.Amount, dummyEntry.Transaction.AccountId).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mockDB.ExpectExec(insertTransactionsSql).
+		WithArgs(dummyEntry.Transaction.AccountId, dummyEntry.Transaction.Amount, dummyEntry.Transaction.TransactionType, dummyEntry.Transaction.TransactionDate).
+		WillReturnResult(sqlmock.NewResult(dummyTransactionIdAsInt, 1))
+	mockDB.ExpectExec(insertImportEntriesSql).
+		WithArgs(dummyImportIdAsInt, dummyEntry.RowNumber, dummyTransactionIdAsInt, dummyEntry.Reference).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mockDB.ExpectQuery(selectBalanceSql).
+		WithArgs(dummyEntry.Transaction.AccountId).
+		WillReturnRows(sqlmock.NewRows([]string{"amount"}).AddRow(dummyBalance))
+	expectOutboxInsert(insertOutboxSql, NewTransactionPostedEvent(getDefaultTransactionAfterTransact()))
+	mockDB.ExpectCommit()
+
+	//Act
+	actualImport, actualEntries, err := importRepoDb.Save(dummyImport, []TransactionImportEntry{dummyEntry})
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing successful import: " + err.Message)
+	}
+	if actualImport.ImportId != "11" {
+		t.Errorf("Expected import id to be 11 but got %s", actualImport.ImportId)
+	}
+	if len(actualEntries) != 1 || actualEntries[0].Transaction != getDefaultTransactionAfterTransact() {
+		t.Errorf("Expected 1 entry with transaction id %s and balance %.2f but got %v",
+			dummyTransactionId, dummyBalance, actualEntries)
+	}
+}
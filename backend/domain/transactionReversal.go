@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/dto"
+)
+
+//Business Domain
+
+// TransactionReversal records that an admin undid a posted transaction by posting a compensating transaction. A
+// transaction can only be reversed once, but a reversal is itself a transaction and can be reversed in turn.
+type TransactionReversal struct { //business/domain object
+	ReversalId            string `db:"reversal_id"`
+	TransactionId         string `db:"transaction_id"`          //the transaction that was reversed
+	ReversalTransactionId string `db:"reversal_transaction_id"` //the compensating transaction
+	Reason                string `db:"reason"`
+	Forced                bool   `db:"forced"` //whether the reversal was allowed to take the balance below zero
+	ReversedBy            string `db:"reversed_by"`
+	ReversedOn            string `db:"reversed_on"`
+}
+
+func NewTransactionReversal(request dto.TransactionReversalRequest, c clock.Clock) TransactionReversal {
+	return TransactionReversal{
+		TransactionId: request.TransactionId,
+		Reason:        request.Reason,
+		Forced:        request.Force,
+		ReversedBy:    request.ReversedBy,
+		ReversedOn:    c.NowAsString(),
+	}
+}
+
+// ToDTO returns the reversal together with the compensating transaction that was posted for it.
+func (r TransactionReversal) ToDTO(reversal Transaction) dto.TransactionReversalResponse {
+	return dto.TransactionReversalResponse{
+		ReversalId:            r.ReversalId,
+		TransactionId:         r.TransactionId,
+		ReversalTransactionId: r.ReversalTransactionId,
+		TransactionType:       reversal.TransactionType,
+		Amount:                reversal.Amount,
+		Balance:               reversal.Balance,
+		Reason:                r.Reason,
+		Forced:                r.Forced,
+		ReversedBy:            r.ReversedBy,
+		ReversedOn:            r.ReversedOn,
+	}
+}
+
+//Server
+
+//go:generate mockgen -destination=../mocks/domain/mock_transactionReversalRepository.go -package=domain github.com/aliciatay-zls/banking/backend/domain TransactionReversalRepository
+type TransactionReversalRepository interface { //repo (secondary port)
+	FindTransactionById(string) (*Transaction, *errs.AppError)
+	Save(TransactionReversal, Transaction) (*TransactionReversal, *Transaction, *errs.AppError)
+}
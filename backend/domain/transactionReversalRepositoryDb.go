@@ -0,0 +1,151 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/problem"
+	"github.com/jmoiron/sqlx"
+	"strconv"
+)
+
+//Server
+
+type TransactionReversalRepositoryDb struct { //DB (adapter)
+	client *sqlx.DB
+}
+
+func NewTransactionReversalRepositoryDb(dbClient *sqlx.DB) TransactionReversalRepositoryDb {
+	return TransactionReversalRepositoryDb{dbClient}
+}
+
+// FindTransactionById retrieves the bank transaction with the given id, together with its reversal if it was reversed.
+func (d TransactionReversalRepositoryDb) FindTransactionById(transactionId string) (*Transaction, *errs.AppError) {
+	var transaction Transaction
+	findSql := selectTransactionsSql(d.client.DriverName()) + " WHERE transaction_id = ?"
+	if err := d.client.Get(&transaction, d.client.Rebind(findSql), transactionId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Error("Error while retrieving transaction to reverse: transaction not found")
+			return nil, problem.NewNotFoundError(problem.TransactionNotFound, "Transaction not found")
+		}
+		logger.Error("Error while retrieving transaction to reverse: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return &transaction, nil
+}
+
+// Save starts a database transaction, checks that the reversed transaction has not already been reversed, updates the
+// account balance, creates a new entry in the database for the compensating transaction and one for the reversal,
+// reads the new account balance, writes a TransactionPosted event to the outbox and commits the database transaction.
+// Unless the reversal is forced, a compensating withdrawal that would take the account balance below its overdraft
+// limit causes the database transaction to be rolled back.
+// Save returns the reversal and the compensating transaction with their database-generated IDs and the new account
+// balance set.
+func (d TransactionReversalRepositoryDb) Save(reversal TransactionReversal, transaction Transaction) (*TransactionReversal, *Transaction, *errs.AppError) {
+	tx, err := d.client.Beginx()
+	if err != nil {
+		logger.Error("Error while starting db transaction for reversing transaction: " + err.Error())
+		return nil, nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	var count int
+	countSql := "SELECT COUNT(*) FROM transaction_reversals WHERE transaction_id = ?"
+	if err = tx.Get(&count, tx.Rebind(countSql), reversal.TransactionId); err != nil {
+		logger.Error("Error while checking for previous reversal of transaction: " + err.Error())
+		rollbackReversal(tx)
+		return nil, nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	if count > 0 {
+		logger.Error("Error while reversing transaction: transaction has already been reversed")
+		rollbackReversal(tx)
+		return nil, nil, problem.NewConflictError(problem.TransactionAlreadyReversed,
+			"Transaction has already been reversed")
+	}
+
+	if transaction.IsDebit() && !reversal.Forced {
+		withdrawSql := "UPDATE accounts SET amount = amount - ? WHERE account_id = ? AND amount + overdraft_limit >= ?"
+		result, err := tx.Exec(tx.Rebind(withdrawSql), transaction.Amount, transaction.AccountId, transaction.Amount)
+		if err != nil {
+			logger.Error("Error while updating account for reversal: " + err.Error())
+			rollbackReversal(tx)
+			return nil, nil, errs.NewUnexpectedError("Unexpected database error")
+		}
+		if rowsAffected, err := result.RowsAffected(); err != nil || rowsAffected != 1 {
+			logger.Error("Error while reversing transaction: reversal exceeds account balance")
+			rollbackReversal(tx)
+			return nil, nil, problem.NewValidationError(problem.InsufficientFunds,
+				"Account balance insufficient to reverse the transaction")
+		}
+	} else {
+		var updateAccountSql string
+		if transaction.IsDebit() {
+			updateAccountSql = "UPDATE accounts SET amount = amount - ? WHERE account_id = ?"
+		} else {
+			updateAccountSql = "UPDATE accounts SET amount = amount + ? WHERE account_id = ?"
+		}
+		if _, err = tx.Exec(tx.Rebind(updateAccountSql), transaction.Amount, transaction.AccountId); err != nil {
+			logger.Error("Error while updating account for reversal: " + err.Error())
+			rollbackReversal(tx)
+			return nil, nil, errs.NewUnexpectedError("Unexpected database error")
+		}
+	}
+
+	result, err := insertTransaction(tx, transaction)
+	if err != nil {
+		logger.Error("Error while creating compensating transaction for reversal: " + err.Error())
+		rollbackReversal(tx)
+		return nil, nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	transactionId, err := result.LastInsertId()
+	if err != nil {
+		logger.Error("Error while getting id of newly inserted compensating transaction: " + err.Error())
+		rollbackReversal(tx)
+		return nil, nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	transaction.TransactionId = strconv.FormatInt(transactionId, 10)
+	reversal.ReversalTransactionId = transaction.TransactionId
+
+	addReversalSql := "INSERT INTO transaction_reversals (transaction_id, reversal_transaction_id, reason, forced, reversed_by, reversed_on) VALUES (?, ?, ?, ?, ?, ?)"
+	result, err = execInsert(tx, addReversalSql, "reversal_id", reversal.TransactionId, reversal.ReversalTransactionId,
+		reversal.Reason, reversal.Forced, reversal.ReversedBy, reversal.ReversedOn)
+	if err != nil {
+		logger.Error("Error while creating new transaction reversal: " + err.Error())
+		rollbackReversal(tx)
+		return nil, nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	reversalId, err := result.LastInsertId()
+	if err != nil {
+		logger.Error("Error while getting id of newly inserted transaction reversal: " + err.Error())
+		rollbackReversal(tx)
+		return nil, nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	reversal.ReversalId = strconv.FormatInt(reversalId, 10)
+
+	balanceSql := "SELECT amount FROM accounts WHERE account_id = ?"
+	if err = tx.Get(&transaction.Balance, tx.Rebind(balanceSql), transaction.AccountId); err != nil {
+		logger.Error("Error while retrieving new account balance for reversal: " + err.Error())
+		rollbackReversal(tx)
+		return nil, nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	if err = insertOutboxEvent(tx, NewTransactionPostedEvent(transaction)); err != nil {
+		logger.Error("Error while writing transaction posted event to outbox for reversal: " + err.Error())
+		rollbackReversal(tx)
+		return nil, nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("Error while committing db transaction for reversing transaction: " + err.Error())
+		return nil, nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return &reversal, &transaction, nil
+}
+
+func rollbackReversal(tx *sqlx.Tx) {
+	if rollbackErr := tx.Rollback(); rollbackErr != nil {
+		logger.Fatal("Error while rolling back reversing of transaction: " + rollbackErr.Error())
+	}
+}
@@ -0,0 +1,180 @@
+package domain
+
+import (
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/jmoiron/sqlx"
+	"net/http"
+	"testing"
+)
+
+// Test common variables and inputs
+var reversalRepoDb TransactionReversalRepositoryDb
+
+const dummyReversalTransactionIdAsInt int64 = 7792
+const dummyReversalIdAsInt int64 = 3
+
+const selectTransactionByIdSql = selectTransactionsWithReversalSql + " WHERE transaction_id = ?"
+const countTransactionReversalsSql = "SELECT COUNT(*) FROM transaction_reversals WHERE transaction_id = ?"
+const insertTransactionReversalsSql = "INSERT INTO transaction_reversals (transaction_id, reversal_transaction_id, reason, forced, reversed_by, reversed_on) VALUES (?, ?, ?, ?, ?, ?)"
+
+func setupTransactionReversalRepoDbTest(t *testing.T) func() {
+	teardown := setupDB(t)
+	reversalRepoDb = NewTransactionReversalRepositoryDb(sqlx.NewDb(db, driverName))
+	return teardown
+}
+
+// getDefaultTransactionReversal returns a TransactionReversal of the transaction with id 7791, made by an admin at
+// 2006-01-02 15:04:05 without force
+func getDefaultTransactionReversal() TransactionReversal {
+	return NewTransactionReversal(dto.TransactionReversalRequest{TransactionId: dummyTransactionId,
+		Reason: "Duplicate deposit", ReversedBy: "admin"}, clock.StaticClock{})
+}
+
+func TestTransactionReversalRepositoryDb_FindTransactionById_returns_notFoundError_when_noRows(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionReversalRepoDbTest(t)
+	defer teardown()
+
+	mockDB.ExpectQuery(selectTransactionByIdSql).WithArgs(dummyTransactionId).WillReturnError(sql.ErrNoRows)
+	logger.MuteLogger()
+
+	//Act
+	_, err := reversalRepoDb.FindTransactionById(dummyTransactionId)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing retrieval of non-existent transaction")
+	}
+	if err.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d but got %d", http.StatusNotFound, err.Code)
+	}
+}
+
+func TestTransactionReversalRepositoryDb_FindTransactionById_returns_transaction_with_its_reversal(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionReversalRepoDbTest(t)
+	defer teardown()
+
+	dummyTransaction := getDefaultTransactionBeforeTransact()
+	mockDB.ExpectQuery(selectTransactionByIdSql).
+		WithArgs(dummyTransactionId).
+		WillReturnRows(sqlmock.NewRows(transactionsTableColumns).
+			AddRow(dummyTransactionId, dummyTransaction.AccountId, dummyTransaction.Amount, dummyTransaction.TransactionType,
+				dummyTransaction.TransactionDate, "", "", "", nil, dummyReversalTransactionIdAsInt))
+
+	//Act
+	transaction, err := reversalRepoDb.FindTransactionById(dummyTransactionId)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing retrieval of transaction: " + err.Message)
+	}
+	if !transaction.IsReversed() || transaction.ReversedByTransactionId.String != "7792" {
+		t.Errorf("Expected transaction reversed by transaction 7792 but got %v", transaction.ReversedByTransactionId)
+	}
+}
+
+func TestTransactionReversalRepositoryDb_Save_returns_conflictError_and_rollsBack_when_already_reversed(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionReversalRepoDbTest(t)
+	defer teardown()
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectQuery(countTransactionReversalsSql).
+		WithArgs(dummyTransactionId).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mockDB.ExpectRollback()
+	logger.MuteLogger()
+
+	compensating := getDefaultTransactionAfterTransact().ToReversal(clock.StaticClock{})
+
+	//Act
+	_, _, err := reversalRepoDb.Save(getDefaultTransactionReversal(), compensating)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing reversal of transaction already reversed")
+	}
+	if err.Code != http.StatusConflict {
+		t.Errorf("Expected status code %d but got %d", http.StatusConflict, err.Code)
+	}
+}
+
+func TestTransactionReversalRepositoryDb_Save_returns_validationError_and_rollsBack_when_reversal_exceeds_balance(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionReversalRepoDbTest(t)
+	defer teardown()
+
+	compensating := getDefaultTransactionAfterTransact().ToReversal(clock.StaticClock{})
+	mockDB.ExpectBegin()
+	mockDB.ExpectQuery(countTransactionReversalsSql).
+		WithArgs(dummyTransactionId).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mockDB.ExpectExec(updateAccountsWithdrawalSql).
+		WithArgs(compensating.Amount, compensating.AccountId, compensating.Amount).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mockDB.ExpectRollback()
+	logger.MuteLogger()
+
+	//Act
+	_, _, err := reversalRepoDb.Save(getDefaultTransactionReversal(), compensating)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing reversal exceeding account balance")
+	}
+	if err.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status code %d but got %d", http.StatusUnprocessableEntity, err.Code)
+	}
+}
+
+func TestTransactionReversalRepositoryDb_Save_returns_reversal_and_transaction_when_forced(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionReversalRepoDbTest(t)
+	defer teardown()
+
+	reversal := getDefaultTransactionReversal()
+	reversal.Forced = true
+	compensating := getDefaultTransactionAfterTransact().ToReversal(clock.StaticClock{})
+	expectedTransaction := compensating
+	expectedTransaction.TransactionId = "7792"
+	expectedTransaction.Balance = -100
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectQuery(countTransactionReversalsSql).
+		WithArgs(dummyTransactionId).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mockDB.ExpectExec(updateAccountsDebitSql).
+		WithArgs(compensating.Amount, compensating.AccountId).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mockDB.ExpectExec(insertTransactionsWithDetailsSql).
+		WithArgs(compensating.AccountId, compensating.Amount, dto.TransactionTypeWithdrawal, compensating.TransactionDate,
+			compensating.Description, compensating.Reference, compensating.Category, compensating.RelatedTransactionId).
+		WillReturnResult(sqlmock.NewResult(dummyReversalTransactionIdAsInt, 1))
+	mockDB.ExpectExec(insertTransactionReversalsSql).
+		WithArgs(dummyTransactionId, "7792", reversal.Reason, true, reversal.ReversedBy, reversal.ReversedOn).
+		WillReturnResult(sqlmock.NewResult(dummyReversalIdAsInt, 1))
+	mockDB.ExpectQuery(selectBalanceSql).
+		WithArgs(compensating.AccountId).
+		WillReturnRows(sqlmock.NewRows([]string{"amount"}).AddRow(-100))
+	expectOutboxInsert(insertOutboxSql, NewTransactionPostedEvent(expectedTransaction))
+	mockDB.ExpectCommit()
+
+	//Act
+	actualReversal, actualTransaction, err := reversalRepoDb.Save(reversal, compensating)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing forced reversal: " + err.Message)
+	}
+	if actualReversal.ReversalId != "3" || actualReversal.ReversalTransactionId != "7792" {
+		t.Errorf("Expected reversal 3 posting transaction 7792 but got %v", *actualReversal)
+	}
+	if *actualTransaction != expectedTransaction {
+		t.Errorf("Expected transaction %v but got %v", expectedTransaction, *actualTransaction)
+	}
+}
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"database/sql"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/problem"
+	"strconv"
+	"sync"
+)
+
+//Server
+
+type TransactionReversalRepositoryStub struct { //stub (adapter)
+	accounts AccountRepositoryStub //the accounts and their transaction history are read and updated here
+	store    *reversalStore        //shared by all copies of the stub, so that changes made through one copy are seen by all
+}
+
+// reversalStore holds the transaction reversals of a TransactionReversalRepositoryStub in memory. It is safe for
+// concurrent use.
+type reversalStore struct {
+	mu             sync.Mutex
+	reversals      []TransactionReversal
+	nextReversalId int64
+}
+
+func NewTransactionReversalRepositoryStub(accounts AccountRepositoryStub) TransactionReversalRepositoryStub { //helper function to create and initialize a stub
+	return TransactionReversalRepositoryStub{accounts, &reversalStore{
+		reversals:      make([]TransactionReversal, 0),
+		nextReversalId: 1,
+	}}
+}
+
+func (s TransactionReversalRepositoryStub) FindTransactionById(transactionId string) (*Transaction, *errs.AppError) { //stub implements repo
+	s.accounts.store.mu.Lock()
+	defer s.accounts.store.mu.Unlock()
+
+	i := s.accounts.store.indexOfTransaction(transactionId)
+	if i < 0 {
+		logger.Error("Error while finding transaction using stub for TransactionReversalRepository: not found")
+		return nil, problem.NewNotFoundError(problem.TransactionNotFound, "Transaction not found")
+	}
+	transaction := s.accounts.store.transactions[i]
+	return &transaction, nil
+}
+
+// Save posts the compensating transaction and records the reversal, all while holding the lock of the accounts so that
+// a transaction cannot be reversed twice. Like TransactionReversalRepositoryDb, it refuses a compensating withdrawal
+// that would take the account balance below its overdraft limit unless the reversal is forced.
+// Save returns the reversal and the compensating transaction with their IDs and the new account balance set.
+func (s TransactionReversalRepositoryStub) Save(reversal TransactionReversal, transaction Transaction) (*TransactionReversal, *Transaction, *errs.AppError) { //stub implements repo
+	s.accounts.store.mu.Lock()
+	defer s.accounts.store.mu.Unlock()
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	reversed := s.accounts.store.indexOfTransaction(reversal.TransactionId)
+	if reversed < 0 {
+		logger.Error("Error while reversing transaction using stub for TransactionReversalRepository: transaction not found")
+		return nil, nil, problem.NewNotFoundError(problem.TransactionNotFound, "Transaction not found")
+	}
+	if s.accounts.store.transactions[reversed].IsReversed() {
+		logger.Error("Error while reversing transaction using stub for TransactionReversalRepository: already reversed")
+		return nil, nil, problem.NewConflictError(problem.TransactionAlreadyReversed,
+			"Transaction has already been reversed")
+	}
+	i := s.accounts.store.indexOf(transaction.AccountId)
+	if i < 0 {
+		logger.Error("Error while reversing transaction using stub for TransactionReversalRepository: account not found")
+		return nil, nil, problem.NewNotFoundError(problem.AccountNotFound, "Account not found")
+	}
+
+	if transaction.IsDebit() {
+		if !reversal.Forced && !s.accounts.store.accounts[i].CanWithdraw(transaction.Amount) {
+			logger.Error("Error while reversing transaction using stub for TransactionReversalRepository: reversal exceeds account balance")
+			return nil, nil, problem.NewValidationError(problem.InsufficientFunds,
+				"Account balance insufficient to reverse the transaction")
+		}
+		s.accounts.store.accounts[i].Amount -= transaction.Amount
+	} else {
+		s.accounts.store.accounts[i].Amount += transaction.Amount
+	}
+	transaction.TransactionId = strconv.FormatInt(s.accounts.store.nextTransactionId, 10)
+	s.accounts.store.nextTransactionId++
+	transaction.Balance = s.accounts.store.accounts[i].Amount
+	s.accounts.store.transactions = append(s.accounts.store.transactions, transaction)
+	s.accounts.store.transactions[reversed].ReversedByTransactionId = sql.NullString{String: transaction.TransactionId, Valid: true}
+
+	reversal.ReversalId = strconv.FormatInt(s.store.nextReversalId, 10)
+	s.store.nextReversalId++
+	reversal.ReversalTransactionId = transaction.TransactionId
+	s.store.reversals = append(s.store.reversals, reversal)
+
+	return &reversal, &transaction, nil
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"net/http"
+	"testing"
+)
+
+func TestTransactionReversalRepositoryStub_Save_reverses_transaction_once(t *testing.T) {
+	//Arrange
+	accounts := NewAccountRepositoryStub()
+	stub := NewTransactionReversalRepositoryStub(accounts)
+	withdrawal, _ := accounts.Transact(NewTransaction("95470", 100, dto.TransactionTypeWithdrawal, clock.StaticClock{}))
+	reversal := getDefaultTransactionReversal()
+	reversal.TransactionId = withdrawal.TransactionId
+	logger.MuteLogger()
+
+	//Act
+	savedReversal, compensating, err := stub.Save(reversal, withdrawal.ToReversal(clock.StaticClock{}))
+	_, _, secondErr := stub.Save(reversal, withdrawal.ToReversal(clock.StaticClock{}))
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing reversal of withdrawal: " + err.Message)
+	}
+	if savedReversal.ReversalTransactionId != compensating.TransactionId || compensating.Balance != 6823.23 {
+		t.Errorf("Expected deposit restoring balance of 6823.23 but got %v", *compensating)
+	}
+	if reversed, _ := stub.FindTransactionById(withdrawal.TransactionId); reversed.ReversedByTransactionId.String != compensating.TransactionId {
+		t.Errorf("Expected withdrawal to be reversed by transaction %s but got %v", compensating.TransactionId, *reversed)
+	}
+	if secondErr == nil || secondErr.Code != http.StatusConflict {
+		t.Errorf("Expected conflict error for second reversal but got %v", secondErr)
+	}
+}
+
+func TestTransactionReversalRepositoryStub_Save_returns_validationError_when_reversal_exceeds_balance(t *testing.T) {
+	//Arrange
+	accounts := NewAccountRepositoryStub()
+	stub := NewTransactionReversalRepositoryStub(accounts)
+	deposit, _ := accounts.Transact(NewTransaction("95470", 100, dto.TransactionTypeDeposit, clock.StaticClock{}))
+	accounts.Transact(NewTransaction("95470", 6900, dto.TransactionTypeWithdrawal, clock.StaticClock{}))
+	reversal := getDefaultTransactionReversal()
+	reversal.TransactionId = deposit.TransactionId
+	logger.MuteLogger()
+
+	//Act
+	_, _, err := stub.Save(reversal, deposit.ToReversal(clock.StaticClock{}))
+	reversal.Forced = true
+	_, forced, forcedErr := stub.Save(reversal, deposit.ToReversal(clock.StaticClock{}))
+
+	//Assert
+	if err == nil || err.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected validation error for reversal exceeding balance but got %v", err)
+	}
+	if forcedErr != nil {
+		t.Fatal("Expected no error but got error while testing forced reversal: " + forcedErr.Message)
+	}
+	if forced.Balance >= 0 {
+		t.Errorf("Expected forced reversal to overdraw the account but got balance %v", forced.Balance)
+	}
+}
@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"database/sql"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/dto"
+)
+
+//Business Domain
+
+// TransactionReview is a transaction that the fraud rules flagged for review. It is held, instead of being made, until
+// an admin approves it, which posts it, or rejects it. While it is pending, the funds of a held withdrawal are
+// reserved so that they cannot be withdrawn by another transaction.
+type TransactionReview struct { //business/domain object
+	ReviewId        string         `db:"review_id"`
+	AccountId       string         `db:"account_id"`
+	Amount          float64        `db:"amount"`
+	TransactionType string         `db:"transaction_type"`
+	Description     string         `db:"description"`
+	Reference       string         `db:"reference"`
+	Category        string         `db:"category"`
+	DecisionId      string         `db:"decision_id"` //the fraud decision that held the transaction
+	Reasons         string         `db:"reasons"`
+	Status          string         `db:"status"` //dto.TransactionStatusPendingReview, Posted or Rejected
+	RequestedOn     string         `db:"requested_on"`
+	ReviewedOn      sql.NullString `db:"reviewed_on"` //null while pending
+	Comment         string         `db:"review_comment"`
+}
+
+func NewTransactionReview(transaction Transaction, decision FraudDecision) TransactionReview {
+	return TransactionReview{
+		AccountId:       transaction.AccountId,
+		Amount:          transaction.Amount,
+		TransactionType: transaction.TransactionType,
+		Description:     transaction.Description,
+		Reference:       transaction.Reference,
+		Category:        transaction.Category,
+		DecisionId:      decision.DecisionId,
+		Reasons:         decision.Reasons,
+		Status:          dto.TransactionStatusPendingReview,
+		RequestedOn:     transaction.TransactionDate,
+	}
+}
+
+func (r TransactionReview) IsPending() bool {
+	return r.Status == dto.TransactionStatusPendingReview
+}
+
+// reservesFunds reports whether the review reserves the funds of its transaction, i.e. it is a withdrawal pending
+// review.
+func (r TransactionReview) reservesFunds() bool {
+	return r.IsPending() && r.TransactionType == dto.TransactionTypeWithdrawal
+}
+
+// Resolve returns the review as resolved with the given status and comment at the current time.
+func (r TransactionReview) Resolve(status string, comment string, c clock.Clock) TransactionReview {
+	r.Status = status
+	r.Comment = comment
+	r.ReviewedOn = sql.NullString{String: c.NowAsString(), Valid: true}
+	return r
+}
+
+// ToTransaction returns the transaction to be posted on approval of the review, dated at the current time.
+func (r TransactionReview) ToTransaction(c clock.Clock) Transaction {
+	transaction := NewTransaction(r.AccountId, r.Amount, r.TransactionType, c)
+	transaction.Description = r.Description
+	transaction.Reference = r.Reference
+	transaction.Category = r.Category
+	return transaction
+}
+
+func (r TransactionReview) ToDTO() dto.TransactionReviewResponse {
+	return dto.TransactionReviewResponse{
+		ReviewId:        r.ReviewId,
+		AccountId:       r.AccountId,
+		TransactionType: r.TransactionType,
+		Amount:          r.Amount,
+		Reasons:         r.Reasons,
+		Status:          r.Status,
+		RequestedOn:     r.RequestedOn,
+		ReviewedOn:      r.ReviewedOn.String,
+		Comment:         r.Comment,
+	}
+}
+
+// ToAccountTransactionDTO returns the review as it is shown in the transaction list of the account.
+func (r TransactionReview) ToAccountTransactionDTO() dto.AccountTransactionResponse {
+	return dto.AccountTransactionResponse{
+		ReviewId:        r.ReviewId,
+		TransactionType: r.TransactionType,
+		Amount:          r.Amount,
+		TransactionDate: r.RequestedOn,
+		Status:          r.Status,
+		Description:     r.Description,
+		Reference:       r.Reference,
+		Category:        r.Category,
+	}
+}
+
+//Server
+
+//go:generate mockgen -destination=../mocks/domain/mock_transactionReviewRepository.go -package=domain github.com/aliciatay-zls/banking/backend/domain TransactionReviewRepository
+type TransactionReviewRepository interface { //repo (secondary port)
+	Save(TransactionReview) (*TransactionReview, *errs.AppError)
+	FindById(string) (*TransactionReview, *errs.AppError)
+	FindPending() ([]TransactionReview, *errs.AppError)
+	FindAll(string) ([]TransactionReview, *errs.AppError)
+	FindReservedAmount(string) (float64, *errs.AppError)
+	UpdateStatus(TransactionReview, string) *errs.AppError
+}
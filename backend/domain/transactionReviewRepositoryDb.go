@@ -0,0 +1,165 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/problem"
+	"github.com/jmoiron/sqlx"
+	"strconv"
+)
+
+//Server
+
+type TransactionReviewRepositoryDb struct { //DB (adapter)
+	client *sqlx.DB
+}
+
+func NewTransactionReviewRepositoryDb(dbClient *sqlx.DB) TransactionReviewRepositoryDb {
+	return TransactionReviewRepositoryDb{dbClient}
+}
+
+// Save creates a new entry in the database for the given review and returns it with its database-generated ID set. The
+// available balance of the account is checked again within the same database transaction for a withdrawal pending
+// review, so that two withdrawals sent for review at once cannot together reserve more than is available.
+func (d TransactionReviewRepositoryDb) Save(review TransactionReview) (*TransactionReview, *errs.AppError) {
+	tx, err := d.client.Beginx()
+	if err != nil {
+		logger.Error("Error while starting db transaction for creating transaction review: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	if review.reservesFunds() {
+		canSetAside, appErr := canSetAsideFunds(tx, review.AccountId, review.Amount, review.RequestedOn)
+		if appErr != nil {
+			rollbackReview(tx)
+			return nil, appErr
+		}
+		if !canSetAside {
+			logger.Error("Withdrawal amount exceeds available account balance")
+			rollbackReview(tx)
+			return nil, problem.NewValidationError(problem.InsufficientFunds,
+				"Account balance insufficient to withdraw given amount")
+		}
+	}
+
+	insertSql := "INSERT INTO transaction_reviews (account_id, amount, transaction_type, description, reference, category, decision_id, reasons, status, requested_on) " +
+		"VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	result, err := execInsert(tx, insertSql, "review_id", review.AccountId, review.Amount, review.TransactionType,
+		review.Description, review.Reference, review.Category, review.DecisionId, review.Reasons, review.Status, review.RequestedOn)
+	if err != nil {
+		logger.Error("Error while creating new transaction review: " + err.Error())
+		rollbackReview(tx)
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		logger.Error("Error while getting id of newly inserted transaction review: " + err.Error())
+		rollbackReview(tx)
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	review.ReviewId = strconv.FormatInt(id, 10)
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("Error while committing db transaction for creating transaction review: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return &review, nil
+}
+
+func (d TransactionReviewRepositoryDb) FindById(reviewId string) (*TransactionReview, *errs.AppError) {
+	var review TransactionReview
+	findSql := d.selectReviewsSql() + " WHERE review_id = ?"
+	if err := d.client.Get(&review, d.client.Rebind(findSql), reviewId); err != nil {
+		logger.Error("Error while retrieving transaction review: " + err.Error())
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, problem.NewNotFoundError(problem.TransactionReviewNotFound, "Transaction review not found")
+		}
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return &review, nil
+}
+
+// FindPending retrieves the reviews waiting for an admin, oldest first.
+func (d TransactionReviewRepositoryDb) FindPending() ([]TransactionReview, *errs.AppError) {
+	reviews := make([]TransactionReview, 0)
+	findSql := d.selectReviewsSql() + " WHERE status = ? ORDER BY review_id"
+	if err := d.client.Select(&reviews, d.client.Rebind(findSql), dto.TransactionStatusPendingReview); err != nil {
+		logger.Error("Error while retrieving pending transaction reviews: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return reviews, nil
+}
+
+// FindAll retrieves all reviews of transactions on the account with the given id, oldest first.
+func (d TransactionReviewRepositoryDb) FindAll(accountId string) ([]TransactionReview, *errs.AppError) {
+	reviews := make([]TransactionReview, 0)
+	findSql := d.selectReviewsSql() + " WHERE account_id = ? ORDER BY review_id"
+	if err := d.client.Select(&reviews, d.client.Rebind(findSql), accountId); err != nil {
+		logger.Error("Error while retrieving transaction reviews of account: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return reviews, nil
+}
+
+// FindReservedAmount retrieves the total amount of the withdrawals on the account with the given id that are pending
+// review.
+func (d TransactionReviewRepositoryDb) FindReservedAmount(accountId string) (float64, *errs.AppError) {
+	reserved, err := selectReservedAmount(d.client, accountId)
+	if err != nil {
+		logger.Error("Error while retrieving reserved amount of account: " + err.Error())
+		return 0, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return reserved, nil
+}
+
+// selectReservedAmount sums the withdrawals on the account with the given id that are pending review, with the given
+// database handle or transaction.
+func selectReservedAmount(e sqlx.Ext, accountId string) (float64, error) {
+	var reserved float64
+	findSql := "SELECT COALESCE(SUM(amount), 0) FROM transaction_reviews " +
+		"WHERE account_id = ? AND transaction_type = ? AND status = ?"
+	err := sqlx.Get(e, &reserved, e.Rebind(findSql),
+		accountId, dto.TransactionTypeWithdrawal, dto.TransactionStatusPendingReview)
+	return reserved, err
+}
+
+// UpdateStatus sets the status, review date and comment of the given review, provided that it is still in the given
+// status. This way, a review resolved by two admins at once is only resolved by the first one.
+func (d TransactionReviewRepositoryDb) UpdateStatus(review TransactionReview, fromStatus string) *errs.AppError {
+	updateSql := "UPDATE transaction_reviews SET status = ?, reviewed_on = ?, review_comment = ? " +
+		"WHERE review_id = ? AND status = ?"
+	result, err := d.client.Exec(d.client.Rebind(updateSql),
+		review.Status, review.ReviewedOn, review.Comment, review.ReviewId, fromStatus)
+	if err != nil {
+		logger.Error("Error while updating transaction review: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	if rowsAffected, err := result.RowsAffected(); err != nil || rowsAffected == 0 {
+		logger.Error("Error while updating transaction review: review is no longer " + fromStatus)
+		return problem.NewConflictError(problem.TransactionReviewResolved,
+			"Transaction review has already been resolved")
+	}
+
+	return nil
+}
+
+func (d TransactionReviewRepositoryDb) selectReviewsSql() string {
+	return "SELECT review_id, account_id, amount, transaction_type, description, reference, category, decision_id, reasons, status, " +
+		dateTimeColumn(d.client.DriverName(), "requested_on") + ", " +
+		dateTimeColumn(d.client.DriverName(), "reviewed_on") + ", review_comment FROM transaction_reviews"
+}
+
+func rollbackReview(tx *sqlx.Tx) {
+	if rollbackErr := tx.Rollback(); rollbackErr != nil {
+		logger.Fatal("Error while rolling back creating of transaction review: " + rollbackErr.Error())
+	}
+}
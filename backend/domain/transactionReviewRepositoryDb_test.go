@@ -0,0 +1,213 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/jmoiron/sqlx"
+	"net/http"
+	"testing"
+)
+
+// Test common variables and inputs
+var reviewRepoDb TransactionReviewRepositoryDb
+
+const insertTransactionReviewsSql = "INSERT INTO transaction_reviews (account_id, amount, transaction_type, description, reference, category, decision_id, reasons, status, requested_on) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+const insertTransactionReviewsPostgresSql = "INSERT INTO transaction_reviews (account_id, amount, transaction_type, description, reference, category, decision_id, reasons, status, requested_on) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING review_id"
+const selectPendingTransactionReviewsSql = "SELECT review_id, account_id, amount, transaction_type, description, reference, category, decision_id, reasons, status, requested_on, reviewed_on, review_comment FROM transaction_reviews WHERE status = ? ORDER BY review_id"
+const selectReservedAmountSql = "SELECT COALESCE(SUM(amount), 0) FROM transaction_reviews WHERE account_id = ? AND transaction_type = ? AND status = ?"
+const updateTransactionReviewsSql = "UPDATE transaction_reviews SET status = ?, reviewed_on = ?, review_comment = ? WHERE review_id = ? AND status = ?"
+
+func setupTransactionReviewRepoDbTest(t *testing.T, driverName string) func() {
+	teardown := setupDB(t)
+	reviewRepoDb = NewTransactionReviewRepositoryDb(sqlx.NewDb(db, driverName))
+	return teardown
+}
+
+// getDefaultTransactionReview returns a withdrawal of 6000 from the account with id 1977 held for review
+func getDefaultTransactionReview() TransactionReview {
+	return TransactionReview{
+		AccountId:       dummyAccountId,
+		Amount:          dummyAmount,
+		TransactionType: dto.TransactionTypeWithdrawal,
+		Description:     "Rent",
+		Category:        dto.TransactionCategoryBills,
+		DecisionId:      "4",
+		Reasons:         "withdrawal within 1h0m0s of account opening",
+		Status:          dto.TransactionStatusPendingReview,
+		RequestedOn:     dummyDate,
+	}
+}
+
+func TestTransactionReviewRepositoryDb_Save_returns_review_with_newId(t *testing.T) {
+	tests := []struct {
+		driverName string
+		insertSql  string
+	}{
+		{DriverMySQL, insertTransactionReviewsSql},
+		{DriverPostgres, insertTransactionReviewsPostgresSql},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.driverName, func(t *testing.T) {
+			//Arrange
+			teardown := setupTransactionReviewRepoDbTest(t, tc.driverName)
+			defer teardown()
+
+			review := getDefaultTransactionReview()
+			mockDB.ExpectBegin()
+			expectCanSetAsideFunds(tc.driverName, review.AccountId, dummyBalance, 0, 0, review.RequestedOn)
+			expectInsert(tc.driverName, tc.insertSql, "review_id", 5, review.AccountId, review.Amount, review.TransactionType,
+				review.Description, review.Reference, review.Category, review.DecisionId, review.Reasons, review.Status, review.RequestedOn)
+			mockDB.ExpectCommit()
+
+			//Act
+			savedReview, err := reviewRepoDb.Save(review)
+
+			//Assert
+			if err != nil {
+				t.Fatal("Expected no error but got error while testing successful saving of transaction review: " + err.Message)
+			}
+			if savedReview.ReviewId != "5" {
+				t.Errorf("Expected review id 5 but got %s", savedReview.ReviewId)
+			}
+		})
+	}
+}
+
+func TestTransactionReviewRepositoryDb_Save_returns_validationError_and_rolls_back_when_withdrawal_no_longer_available(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionReviewRepoDbTest(t, driverName)
+	defer teardown()
+
+	review := getDefaultTransactionReview()
+	mockDB.ExpectBegin()
+	expectCanSetAsideFunds(driverName, review.AccountId, dummyBalance, 0, 7000, review.RequestedOn) //reserved by another review
+	mockDB.ExpectRollback()
+
+	logs := logger.ReplaceWithTestLogger()
+
+	//Act
+	_, err := reviewRepoDb.Save(review)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing withdrawal exceeding available balance")
+	}
+	if err.Code != http.StatusUnprocessableEntity || err.Message != "Account balance insufficient to withdraw given amount" {
+		t.Errorf("Expected status code %d and message \"Account balance insufficient to withdraw given amount\" but got %d and \"%s\"",
+			http.StatusUnprocessableEntity, err.Code, err.Message)
+	}
+	if logs.Len() != 1 {
+		t.Fatalf("Expected 1 log message but got %d", logs.Len())
+	}
+	if mockErr := mockDB.ExpectationsWereMet(); mockErr != nil {
+		t.Error(mockErr)
+	}
+}
+
+func TestTransactionReviewRepositoryDb_Save_does_not_check_balance_when_deposit(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionReviewRepoDbTest(t, driverName)
+	defer teardown()
+
+	review := getDefaultTransactionReview()
+	review.TransactionType = dto.TransactionTypeDeposit
+	mockDB.ExpectBegin()
+	expectInsert(driverName, insertTransactionReviewsSql, "review_id", 5, review.AccountId, review.Amount, review.TransactionType,
+		review.Description, review.Reference, review.Category, review.DecisionId, review.Reasons, review.Status, review.RequestedOn)
+	mockDB.ExpectCommit()
+
+	//Act
+	_, err := reviewRepoDb.Save(review)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing saving of deposit review: " + err.Message)
+	}
+	if mockErr := mockDB.ExpectationsWereMet(); mockErr != nil {
+		t.Error(mockErr)
+	}
+}
+
+func TestTransactionReviewRepositoryDb_FindPending_returns_pendingReviews(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionReviewRepoDbTest(t, driverName)
+	defer teardown()
+
+	expectedReview := getDefaultTransactionReview()
+	expectedReview.ReviewId = "5"
+	mockDB.ExpectQuery(selectPendingTransactionReviewsSql).WithArgs(dto.TransactionStatusPendingReview).
+		WillReturnRows(sqlmock.NewRows([]string{"review_id", "account_id", "amount", "transaction_type", "description",
+			"reference", "category", "decision_id", "reasons", "status", "requested_on", "reviewed_on", "review_comment"}).
+			AddRow(expectedReview.ReviewId, expectedReview.AccountId, expectedReview.Amount, expectedReview.TransactionType,
+				expectedReview.Description, expectedReview.Reference, expectedReview.Category, expectedReview.DecisionId, expectedReview.Reasons, expectedReview.Status, expectedReview.RequestedOn, nil, ""))
+
+	//Act
+	reviews, err := reviewRepoDb.FindPending()
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing retrieval of pending reviews: " + err.Message)
+	}
+	if len(reviews) != 1 || reviews[0] != expectedReview {
+		t.Errorf("Expected reviews %v but got %v", []TransactionReview{expectedReview}, reviews)
+	}
+}
+
+func TestTransactionReviewRepositoryDb_FindReservedAmount_returns_error_when_select_fails(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionReviewRepoDbTest(t, driverName)
+	defer teardown()
+
+	dummyDbErr := errors.New("some error message")
+	mockDB.ExpectQuery(selectReservedAmountSql).
+		WithArgs(dummyAccountId, dto.TransactionTypeWithdrawal, dto.TransactionStatusPendingReview).
+		WillReturnError(dummyDbErr)
+
+	logs := logger.ReplaceWithTestLogger()
+	expectedLogMessage := "Error while retrieving reserved amount of account: " + dummyDbErr.Error()
+
+	//Act
+	_, err := reviewRepoDb.FindReservedAmount(dummyAccountId)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing failed retrieval of reserved amount")
+	}
+	if err.Message != defaultExpectedErrMessage {
+		t.Errorf("Expected error message to be \"%s\" but got \"%s\"", defaultExpectedErrMessage, err.Message)
+	}
+	if logs.Len() != 1 || logs.All()[0].Message != expectedLogMessage {
+		t.Errorf("Expected log message to be \"%s\" but got %v", expectedLogMessage, logs.All())
+	}
+}
+
+func TestTransactionReviewRepositoryDb_UpdateStatus_returns_conflictError_when_review_no_longer_inStatus(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionReviewRepoDbTest(t, driverName)
+	defer teardown()
+
+	review := getDefaultTransactionReview()
+	review.ReviewId = "5"
+	review.Status = dto.TransactionStatusRejected
+	review.ReviewedOn = sql.NullString{String: dummyDate, Valid: true}
+	review.Comment = "Card reported stolen"
+	mockDB.ExpectExec(updateTransactionReviewsSql).
+		WithArgs(review.Status, review.ReviewedOn, review.Comment, review.ReviewId, dto.TransactionStatusPendingReview).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	logger.MuteLogger()
+
+	//Act
+	err := reviewRepoDb.UpdateStatus(review, dto.TransactionStatusPendingReview)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing update of already resolved review")
+	}
+	if err.Code != http.StatusConflict {
+		t.Errorf("Expected status code %d but got %d", http.StatusConflict, err.Code)
+	}
+}
@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/problem"
+	"strconv"
+	"sync"
+)
+
+//Server
+
+type TransactionReviewRepositoryStub struct { //stub (adapter)
+	store *transactionReviewStore //shared by all copies of the stub, so that changes made through one copy are seen by all
+}
+
+// transactionReviewStore holds the reviews of a TransactionReviewRepositoryStub in memory. It is safe for concurrent
+// use.
+type transactionReviewStore struct {
+	mu           sync.Mutex
+	reviews      []TransactionReview
+	nextReviewId int64
+}
+
+func NewTransactionReviewRepositoryStub() TransactionReviewRepositoryStub { //helper function to create and initialize a stub
+	return TransactionReviewRepositoryStub{&transactionReviewStore{reviews: make([]TransactionReview, 0), nextReviewId: 1}}
+}
+
+func (s TransactionReviewRepositoryStub) Save(review TransactionReview) (*TransactionReview, *errs.AppError) { //stub implements repo
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	review.ReviewId = strconv.FormatInt(s.store.nextReviewId, 10)
+	s.store.nextReviewId++
+	s.store.reviews = append(s.store.reviews, review)
+
+	return &review, nil
+}
+
+func (s TransactionReviewRepositoryStub) FindById(reviewId string) (*TransactionReview, *errs.AppError) { //stub implements repo
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	for _, r := range s.store.reviews {
+		if r.ReviewId == reviewId {
+			return &r, nil
+		}
+	}
+	logger.Error("Error while retrieving transaction review using stub for TransactionReviewRepository: review not found")
+	return nil, problem.NewNotFoundError(problem.TransactionReviewNotFound, "Transaction review not found")
+}
+
+func (s TransactionReviewRepositoryStub) FindPending() ([]TransactionReview, *errs.AppError) { //stub implements repo
+	return s.filter(func(r TransactionReview) bool { return r.IsPending() }), nil
+}
+
+func (s TransactionReviewRepositoryStub) FindAll(accountId string) ([]TransactionReview, *errs.AppError) { //stub implements repo
+	return s.filter(func(r TransactionReview) bool { return r.AccountId == accountId }), nil
+}
+
+func (s TransactionReviewRepositoryStub) FindReservedAmount(accountId string) (float64, *errs.AppError) { //stub implements repo
+	var reserved float64
+	for _, r := range s.filter(func(r TransactionReview) bool { return r.AccountId == accountId && r.IsPending() }) {
+		if r.TransactionType == dto.TransactionTypeWithdrawal {
+			reserved += r.Amount
+		}
+	}
+	return reserved, nil
+}
+
+func (s TransactionReviewRepositoryStub) UpdateStatus(review TransactionReview, fromStatus string) *errs.AppError { //stub implements repo
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	for i, r := range s.store.reviews {
+		if r.ReviewId != review.ReviewId {
+			continue
+		}
+		if r.Status != fromStatus {
+			break
+		}
+		s.store.reviews[i].Status = review.Status
+		s.store.reviews[i].ReviewedOn = review.ReviewedOn
+		s.store.reviews[i].Comment = review.Comment
+		return nil
+	}
+	logger.Error("Error while updating transaction review using stub for TransactionReviewRepository: review is no longer " + fromStatus)
+	return problem.NewConflictError(problem.TransactionReviewResolved, "Transaction review has already been resolved")
+}
+
+// filter returns the reviews for which keep returns true, oldest first.
+func (s TransactionReviewRepositoryStub) filter(keep func(TransactionReview) bool) []TransactionReview {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	reviews := make([]TransactionReview, 0)
+	for _, r := range s.store.reviews {
+		if keep(r) {
+			reviews = append(reviews, r)
+		}
+	}
+	return reviews
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"net/http"
+	"testing"
+)
+
+func TestTransactionReviewRepositoryStub_FindReservedAmount_counts_pendingWithdrawals_only(t *testing.T) {
+	//Arrange
+	stub := NewTransactionReviewRepositoryStub()
+	withdrawal := getDefaultTransactionReview()
+	deposit := getDefaultTransactionReview()
+	deposit.TransactionType = dto.TransactionTypeDeposit
+	otherAccountWithdrawal := getDefaultTransactionReview()
+	otherAccountWithdrawal.AccountId = "1978"
+	for _, r := range []TransactionReview{withdrawal, withdrawal, deposit, otherAccountWithdrawal} {
+		stub.Save(r)
+	}
+	rejected := TransactionReview{ReviewId: "1"}.Resolve(dto.TransactionStatusRejected, "Card reported stolen", clock.StaticClock{})
+	stub.UpdateStatus(rejected, dto.TransactionStatusPendingReview)
+
+	//Act
+	reserved, err := stub.FindReservedAmount(dummyAccountId)
+	pending, _ := stub.FindPending()
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing retrieval of reserved amount: " + err.Message)
+	}
+	if reserved != dummyAmount {
+		t.Errorf("Expected reserved amount %f but got %f", dummyAmount, reserved)
+	}
+	if len(pending) != 3 || pending[0].ReviewId != "2" {
+		t.Errorf("Expected reviews 2 to 4 to be pending but got %v", pending)
+	}
+}
+
+func TestTransactionReviewRepositoryStub_UpdateStatus_returns_conflictError_when_already_resolved(t *testing.T) {
+	//Arrange
+	stub := NewTransactionReviewRepositoryStub()
+	review, _ := stub.Save(getDefaultTransactionReview())
+	posted := review.Resolve(dto.TransactionStatusPosted, "Customer confirmed", clock.StaticClock{})
+	rejected := review.Resolve(dto.TransactionStatusRejected, "Card reported stolen", clock.StaticClock{})
+	logger.MuteLogger()
+
+	//Act
+	firstErr := stub.UpdateStatus(posted, dto.TransactionStatusPendingReview)
+	secondErr := stub.UpdateStatus(rejected, dto.TransactionStatusPendingReview)
+	storedReview, _ := stub.FindById(review.ReviewId)
+
+	//Assert
+	if firstErr != nil {
+		t.Fatal("Expected no error but got error while testing first resolution of review: " + firstErr.Message)
+	}
+	if secondErr == nil || secondErr.Code != http.StatusConflict {
+		t.Errorf("Expected conflict error when resolving review again but got %v", secondErr)
+	}
+	if storedReview.Status != dto.TransactionStatusPosted {
+		t.Errorf("Expected review to stay posted but got %s", storedReview.Status)
+	}
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"testing"
+)
+
+func TestNewTransactionReview_holds_transaction_pendingReview_with_reasons_of_decision(t *testing.T) {
+	//Arrange
+	transaction := Transaction{AccountId: dummyAccountId, Amount: 9600, TransactionType: dto.TransactionTypeWithdrawal, TransactionDate: dummyDate}
+	decision := FraudDecision{DecisionId: "4", Decision: FraudDecisionReview, Reasons: "amount 9600.00 just under the limit of 10000.00"}
+
+	//Act
+	review := NewTransactionReview(transaction, decision)
+
+	//Assert
+	if !review.IsPending() || review.DecisionId != "4" || review.Reasons != decision.Reasons || review.RequestedOn != dummyDate {
+		t.Errorf("Expected review pending for decision 4 requested on %s but got %v", dummyDate, review)
+	}
+	if review.ReviewedOn.Valid || review.ToDTO().ReviewedOn != "" {
+		t.Errorf("Expected pending review not to be reviewed yet but got %v", review.ReviewedOn)
+	}
+}
+
+func TestTransactionReview_Resolve_sets_status_comment_and_reviewDate(t *testing.T) {
+	//Arrange
+	review := TransactionReview{ReviewId: "5", AccountId: dummyAccountId, Status: dto.TransactionStatusPendingReview}
+
+	//Act
+	rejected := review.Resolve(dto.TransactionStatusRejected, "Card reported stolen", clock.StaticClock{})
+
+	//Assert
+	response := rejected.ToDTO()
+	if response.Status != dto.TransactionStatusRejected || response.Comment != "Card reported stolen" || response.ReviewedOn != dummyDate {
+		t.Errorf("Expected review rejected on %s with comment but got %v", dummyDate, response)
+	}
+	if !review.IsPending() {
+		t.Error("Expected original review to be left unchanged")
+	}
+}
+
+func TestTransactionReview_ToTransaction_keeps_details_of_held_transaction(t *testing.T) {
+	//Arrange
+	request := dto.TransactionRequest{AccountId: dummyAccountId, Amount: 9600, TransactionType: dto.TransactionTypeWithdrawal,
+		Description: "Car deposit", Reference: "INV-42", Category: dto.TransactionCategoryTransport}
+	review := NewTransactionReview(NewCustomerTransaction(request, clock.StaticClock{}), FraudDecision{DecisionId: "4"})
+
+	//Act
+	transaction := review.ToTransaction(clock.StaticClock{})
+
+	//Assert
+	if transaction.Description != "Car deposit" || transaction.Reference != "INV-42" || transaction.Category != dto.TransactionCategoryTransport {
+		t.Errorf("Expected description, reference and category of request but got %v", transaction)
+	}
+	if history := review.ToAccountTransactionDTO(); history.Description != "Car deposit" || history.Category != dto.TransactionCategoryTransport {
+		t.Errorf("Expected held transaction to be listed with its details but got %v", history)
+	}
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"github.com/aliciatay-zls/banking-lib/clock"
 	"github.com/aliciatay-zls/banking/backend/dto"
 	"testing"
 )
@@ -28,3 +29,99 @@ func TestTransaction_IsWithdrawal_returns_correctResult(t *testing.T) {
 		})
 	}
 }
+
+func TestTransaction_Overdraws_and_RepaysOverdraft_return_correctResult(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name                    string
+		transaction             Transaction
+		expectedOverdraws       bool
+		expectedRepaysOverdraft bool
+	}{
+		{"withdrawal staying in credit", Transaction{Amount: 100, Balance: 0, TransactionType: dto.TransactionTypeWithdrawal}, false, false},
+		{"withdrawal going overdrawn", Transaction{Amount: 100, Balance: -50, TransactionType: dto.TransactionTypeWithdrawal}, true, false},
+		{"withdrawal already overdrawn", Transaction{Amount: 100, Balance: -150, TransactionType: dto.TransactionTypeWithdrawal}, false, false},
+		{"interest already overdrawn", Transaction{Amount: 1, Balance: -151, TransactionType: dto.TransactionTypeInterest}, false, false},
+		{"deposit repaying overdraft", Transaction{Amount: 100, Balance: 0, TransactionType: dto.TransactionTypeDeposit}, false, true},
+		{"deposit still overdrawn", Transaction{Amount: 100, Balance: -50, TransactionType: dto.TransactionTypeDeposit}, false, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Act
+			actualOverdraws := tc.transaction.Overdraws()
+			actualRepaysOverdraft := tc.transaction.RepaysOverdraft()
+
+			//Assert
+			if actualOverdraws != tc.expectedOverdraws || actualRepaysOverdraft != tc.expectedRepaysOverdraft {
+				t.Errorf("expected overdraws %v and repays overdraft %v but got %v and %v", tc.expectedOverdraws,
+					tc.expectedRepaysOverdraft, actualOverdraws, actualRepaysOverdraft)
+			}
+		})
+	}
+}
+
+func TestTransaction_ToFee_links_fee_to_transaction(t *testing.T) {
+	//Arrange
+	withdrawal := Transaction{TransactionId: "7791", AccountId: dummyAccountId, Amount: 100, Balance: 900,
+		TransactionType: dto.TransactionTypeWithdrawal, TransactionDate: dummyDate}
+
+	//Act
+	fee := withdrawal.ToFee(0.5, clock.StaticClock{})
+
+	//Assert
+	if fee.TransactionType != dto.TransactionTypeFee || fee.Amount != 0.5 || fee.AccountId != dummyAccountId {
+		t.Errorf("Expected fee of 0.5 on account %s but got %v", dummyAccountId, fee)
+	}
+	if !fee.RelatedTransactionId.Valid || fee.RelatedTransactionId.String != "7791" {
+		t.Errorf("Expected fee linked to transaction 7791 but got %v", fee.RelatedTransactionId)
+	}
+	if fee.BalanceChange() != -0.5 || withdrawal.ToAccountTransactionDTO().RelatedTransactionId != "" {
+		t.Error("Expected fee to lower balance and withdrawal not to be linked")
+	}
+}
+
+func TestTransaction_ToReversal_moves_amount_in_opposite_direction(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name         string
+		transaction  Transaction
+		expectedType string
+	}{
+		{"deposit", Transaction{TransactionId: "7791", AccountId: dummyAccountId, Amount: 100, TransactionType: dto.TransactionTypeDeposit}, dto.TransactionTypeWithdrawal},
+		{"withdrawal", Transaction{TransactionId: "7791", AccountId: dummyAccountId, Amount: 100, TransactionType: dto.TransactionTypeWithdrawal, Category: dto.TransactionCategoryDining}, dto.TransactionTypeDeposit},
+		{"fee", Transaction{TransactionId: "7791", AccountId: dummyAccountId, Amount: 100, TransactionType: dto.TransactionTypeFee}, dto.TransactionTypeDeposit},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Act
+			reversal := tc.transaction.ToReversal(clock.StaticClock{})
+
+			//Assert
+			if reversal.TransactionType != tc.expectedType || reversal.BalanceChange() != -tc.transaction.BalanceChange() {
+				t.Errorf("Expected %s undoing the %s but got %v", tc.expectedType, tc.name, reversal)
+			}
+			if reversal.RelatedTransactionId.String != "7791" || reversal.TransactionDate != dummyDate {
+				t.Errorf("Expected reversal of transaction 7791 at %s but got %v", dummyDate, reversal)
+			}
+			if reversal.Category != tc.transaction.Category || reversal.Description != "" {
+				t.Errorf("Expected reversal in category \"%s\" without description but got %v", tc.transaction.Category, reversal)
+			}
+		})
+	}
+}
+
+func TestTransaction_ToAccountTransactionDTO_shows_reversed_transaction(t *testing.T) {
+	//Arrange
+	transaction := getDefaultTransactionAfterTransact()
+	transaction.ReversedByTransactionId.String, transaction.ReversedByTransactionId.Valid = "7792", true
+
+	//Act
+	response := transaction.ToAccountTransactionDTO()
+
+	//Assert
+	if response.Status != dto.TransactionStatusReversed || response.ReversedByTransactionId != "7792" {
+		t.Errorf("Expected transaction reversed by 7792 but got %v", response)
+	}
+}
@@ -0,0 +1,156 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"strings"
+	"time"
+)
+
+//Business Domain
+
+const WebhookSubscriptionStatusActive = "active"
+const WebhookSubscriptionStatusDeleted = "deleted"
+
+// WebhookMaxAttempts is the number of times a delivery is attempted automatically before it is given up as failed.
+// With WebhookRetryBaseDelay, the attempts are spread over about an hour.
+const WebhookMaxAttempts = 8
+
+// WebhookRetryBaseDelay is the delay before the second attempt of a delivery. Each further attempt waits twice as long
+// as the previous one.
+const WebhookRetryBaseDelay = 30 * time.Second
+
+type WebhookSubscription struct { //business/domain object
+	SubscriptionId string `db:"subscription_id"`
+	Url            string `db:"url"`
+	EventTypes     string `db:"event_types"` //comma-separated
+	Secret         string `db:"secret"`
+	Status         string `db:"status"`
+	CreatedOn      string `db:"created_on"`
+}
+
+func NewWebhookSubscription(request dto.NewWebhookSubscriptionRequest, c clock.Clock) WebhookSubscription {
+	return WebhookSubscription{
+		Url:        request.Url,
+		EventTypes: strings.Join(request.EventTypes, ","),
+		Secret:     request.Secret,
+		Status:     WebhookSubscriptionStatusActive,
+		CreatedOn:  c.NowAsString(),
+	}
+}
+
+func (s WebhookSubscription) IsSubscribedTo(eventType string) bool {
+	for _, t := range strings.Split(s.EventTypes, ",") {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// ToDTO leaves out the secret, which is only ever given by the admin and never sent back.
+func (s WebhookSubscription) ToDTO() dto.WebhookSubscriptionResponse {
+	return dto.WebhookSubscriptionResponse{
+		SubscriptionId: s.SubscriptionId,
+		Url:            s.Url,
+		EventTypes:     strings.Split(s.EventTypes, ","),
+		CreatedOn:      s.CreatedOn,
+	}
+}
+
+// WebhookDelivery is an event to be POSTed to the URL of a subscription. Url and Secret are those of the subscription.
+type WebhookDelivery struct { //business/domain object
+	DeliveryId     string `db:"delivery_id"`
+	SubscriptionId string `db:"subscription_id"`
+	EventId        string `db:"event_id"`
+	EventType      string `db:"event_type"`
+	Payload        string `db:"payload"` //JSON event message, the body of every attempt
+	Status         string `db:"status"`
+	AttemptCount   int    `db:"attempt_count"`
+	NextAttemptOn  string `db:"next_attempt_on"`
+	Url            string `db:"url"`
+	Secret         string `db:"secret"`
+}
+
+// RecordAttempt updates the delivery after the given attempt: it has succeeded, or it is retried after a delay that
+// doubles with every attempt, or it has failed for good after WebhookMaxAttempts attempts.
+func (d *WebhookDelivery) RecordAttempt(attempt WebhookDeliveryAttempt, attemptedAt time.Time) {
+	d.AttemptCount++
+	if attempt.IsSuccessful() {
+		d.Status = dto.WebhookDeliveryStatusSucceeded
+	} else if d.AttemptCount >= WebhookMaxAttempts {
+		d.Status = dto.WebhookDeliveryStatusFailed
+	} else {
+		d.Status = dto.WebhookDeliveryStatusPending
+		d.NextAttemptOn = attemptedAt.Add(WebhookRetryDelay(d.AttemptCount)).UTC().Format(clock.FormatDateTime)
+	}
+}
+
+// WebhookRetryDelay returns how long to wait after the given number of failed attempts before attempting again.
+func WebhookRetryDelay(failedAttempts int) time.Duration {
+	return WebhookRetryBaseDelay << (failedAttempts - 1)
+}
+
+func (d WebhookDelivery) ToDTO() dto.WebhookDeliveryResponse {
+	response := dto.WebhookDeliveryResponse{
+		DeliveryId:     d.DeliveryId,
+		SubscriptionId: d.SubscriptionId,
+		EventId:        d.EventId,
+		EventType:      d.EventType,
+		Status:         d.Status,
+		AttemptCount:   d.AttemptCount,
+	}
+	if d.Status == dto.WebhookDeliveryStatusPending {
+		response.NextAttemptOn = d.NextAttemptOn
+	}
+	return response
+}
+
+type WebhookDeliveryAttempt struct { //business/domain object
+	AttemptId    string `db:"attempt_id"`
+	DeliveryId   string `db:"delivery_id"`
+	AttemptedOn  string `db:"attempted_on"`
+	StatusCode   int    `db:"status_code"`   //0 if no response was received
+	ErrorMessage string `db:"error_message"` //empty if a response was received
+}
+
+// IsSuccessful reports whether the receiver acknowledged the delivery with a 2xx response.
+func (a WebhookDeliveryAttempt) IsSuccessful() bool {
+	return a.StatusCode >= 200 && a.StatusCode < 300
+}
+
+func (a WebhookDeliveryAttempt) ToDTO() dto.WebhookDeliveryAttemptResponse {
+	return dto.WebhookDeliveryAttemptResponse{
+		AttemptedOn:  a.AttemptedOn,
+		StatusCode:   a.StatusCode,
+		ErrorMessage: a.ErrorMessage,
+		IsSuccessful: a.IsSuccessful(),
+	}
+}
+
+// SignWebhookPayload returns the hex-encoded HMAC-SHA256, keyed with the subscription secret, of the timestamp and
+// payload joined by a ".". Receivers recompute it to check that a delivery came from us and was not replayed.
+func SignWebhookPayload(secret string, timestamp string, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "." + payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+//Server
+
+//go:generate mockgen -destination=../mocks/domain/mock_webhookRepository.go -package=domain github.com/aliciatay-zls/banking/backend/domain WebhookRepository
+type WebhookRepository interface { //repo (secondary port)
+	SaveSubscription(WebhookSubscription) (*WebhookSubscription, *errs.AppError)
+	FindAllSubscriptions() ([]WebhookSubscription, *errs.AppError)
+	DeleteSubscription(string) *errs.AppError
+	EnqueueDeliveries(Event, string) *errs.AppError
+	FindDueDeliveries(string, int) ([]WebhookDelivery, *errs.AppError)
+	FindDeliveryById(string) (*WebhookDelivery, *errs.AppError)
+	FindDeliveries(string) ([]WebhookDelivery, *errs.AppError)
+	FindDeliveryAttempts(string) ([]WebhookDeliveryAttempt, *errs.AppError)
+	SaveDeliveryAttempt(WebhookDelivery, WebhookDeliveryAttempt) *errs.AppError
+}
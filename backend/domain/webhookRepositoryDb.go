@@ -0,0 +1,242 @@
+package domain
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/problem"
+	"github.com/jmoiron/sqlx"
+	"strconv"
+)
+
+//Server
+
+type WebhookRepositoryDb struct { //DB (adapter)
+	client *sqlx.DB
+}
+
+func NewWebhookRepositoryDb(dbClient *sqlx.DB) WebhookRepositoryDb {
+	return WebhookRepositoryDb{dbClient}
+}
+
+// SaveSubscription creates a new entry in the database for the given subscription and returns it with its
+// database-generated ID set.
+func (d WebhookRepositoryDb) SaveSubscription(s WebhookSubscription) (*WebhookSubscription, *errs.AppError) {
+	insertSql := "INSERT INTO webhook_subscriptions (url, event_types, secret, status, created_on) VALUES (?, ?, ?, ?, ?)"
+	result, err := execInsert(d.client, insertSql, "subscription_id", s.Url, s.EventTypes, s.Secret, s.Status, s.CreatedOn)
+	if err != nil {
+		logger.Error("Error while creating new webhook subscription: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		logger.Error("Error while getting id of newly inserted webhook subscription: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+	s.SubscriptionId = strconv.FormatInt(id, 10)
+
+	return &s, nil
+}
+
+// FindAllSubscriptions retrieves all subscriptions that have not been deleted.
+func (d WebhookRepositoryDb) FindAllSubscriptions() ([]WebhookSubscription, *errs.AppError) {
+	subscriptions := make([]WebhookSubscription, 0)
+	findSql := "SELECT subscription_id, url, event_types, secret, status, " +
+		dateTimeColumn(d.client.DriverName(), "created_on") +
+		" FROM webhook_subscriptions WHERE status = ? ORDER BY subscription_id"
+	if err := d.client.Select(&subscriptions, d.client.Rebind(findSql), WebhookSubscriptionStatusActive); err != nil {
+		logger.Error("Error while retrieving webhook subscriptions: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return subscriptions, nil
+}
+
+// DeleteSubscription marks the subscription with the given id as deleted, which stops any further deliveries to it
+// while keeping its delivery history.
+func (d WebhookRepositoryDb) DeleteSubscription(subscriptionId string) *errs.AppError {
+	deleteSql := "UPDATE webhook_subscriptions SET status = ? WHERE subscription_id = ? AND status = ?"
+	result, err := d.client.Exec(d.client.Rebind(deleteSql),
+		WebhookSubscriptionStatusDeleted, subscriptionId, WebhookSubscriptionStatusActive)
+	if err != nil {
+		logger.Error("Error while deleting webhook subscription: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	if rowsAffected, err := result.RowsAffected(); err != nil || rowsAffected == 0 {
+		logger.Error("Error while deleting webhook subscription: subscription not found")
+		return problem.NewNotFoundError(problem.WebhookSubscriptionNotFound, "Webhook subscription not found")
+	}
+
+	return nil
+}
+
+// EnqueueDeliveries creates a pending delivery of the given event for every subscription to its event type, due
+// immediately. An event that was already enqueued for a subscription is skipped, so that an event published again by
+// the outbox relay is not delivered twice.
+func (d WebhookRepositoryDb) EnqueueDeliveries(event Event, enqueuedOn string) *errs.AppError {
+	subscriptions, appErr := d.FindAllSubscriptions()
+	if appErr != nil {
+		return appErr
+	}
+	subscribed := make([]WebhookSubscription, 0, len(subscriptions))
+	for _, s := range subscriptions {
+		if s.IsSubscribedTo(event.EventType) {
+			subscribed = append(subscribed, s)
+		}
+	}
+	if len(subscribed) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(event.ToDTO())
+	if err != nil {
+		logger.Error("Error while marshalling event for webhook delivery: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	tx, err := d.client.Beginx()
+	if err != nil {
+		logger.Error("Error while starting db transaction for enqueueing webhook deliveries: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	enqueued := make([]string, 0)
+	enqueuedSql := "SELECT subscription_id FROM webhook_deliveries WHERE event_id = ?"
+	if err = tx.Select(&enqueued, tx.Rebind(enqueuedSql), event.EventId); err != nil {
+		logger.Error("Error while checking for previous webhook deliveries of event: " + err.Error())
+		rollbackWebhook(tx)
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+	isEnqueued := make(map[string]bool)
+	for _, subscriptionId := range enqueued {
+		isEnqueued[subscriptionId] = true
+	}
+
+	insertSql := "INSERT INTO webhook_deliveries " +
+		"(subscription_id, event_id, event_type, payload, status, attempt_count, next_attempt_on, created_on) " +
+		"VALUES (?, ?, ?, ?, ?, ?, ?, ?)"
+	for _, s := range subscribed {
+		if isEnqueued[s.SubscriptionId] {
+			continue
+		}
+		_, err = tx.Exec(tx.Rebind(insertSql), s.SubscriptionId, event.EventId, event.EventType, string(payload),
+			dto.WebhookDeliveryStatusPending, 0, enqueuedOn, enqueuedOn)
+		if err != nil {
+			logger.Error("Error while creating new webhook delivery: " + err.Error())
+			rollbackWebhook(tx)
+			return errs.NewUnexpectedError("Unexpected database error")
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("Error while committing db transaction for enqueueing webhook deliveries: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
+
+// FindDueDeliveries retrieves at most limit pending deliveries to subscriptions that have not been deleted whose next
+// attempt is due at the given time, oldest first.
+func (d WebhookRepositoryDb) FindDueDeliveries(now string, limit int) ([]WebhookDelivery, *errs.AppError) {
+	deliveries := make([]WebhookDelivery, 0)
+	findSql := d.selectDeliveriesSql() +
+		" WHERE d.status = ? AND s.status = ? AND d.next_attempt_on <= ? ORDER BY d.delivery_id LIMIT ?"
+	err := d.client.Select(&deliveries, d.client.Rebind(findSql),
+		dto.WebhookDeliveryStatusPending, WebhookSubscriptionStatusActive, now, limit)
+	if err != nil {
+		logger.Error("Error while retrieving due webhook deliveries: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return deliveries, nil
+}
+
+func (d WebhookRepositoryDb) FindDeliveryById(deliveryId string) (*WebhookDelivery, *errs.AppError) {
+	var delivery WebhookDelivery
+	findSql := d.selectDeliveriesSql() + " WHERE d.delivery_id = ?"
+	if err := d.client.Get(&delivery, d.client.Rebind(findSql), deliveryId); err != nil {
+		logger.Error("Error while retrieving webhook delivery: " + err.Error())
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, problem.NewNotFoundError(problem.WebhookDeliveryNotFound, "Webhook delivery not found")
+		}
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return &delivery, nil
+}
+
+// FindDeliveries retrieves all deliveries to the subscription with the given id, oldest first.
+func (d WebhookRepositoryDb) FindDeliveries(subscriptionId string) ([]WebhookDelivery, *errs.AppError) {
+	deliveries := make([]WebhookDelivery, 0)
+	findSql := d.selectDeliveriesSql() + " WHERE d.subscription_id = ? ORDER BY d.delivery_id"
+	if err := d.client.Select(&deliveries, d.client.Rebind(findSql), subscriptionId); err != nil {
+		logger.Error("Error while retrieving webhook deliveries: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return deliveries, nil
+}
+
+// FindDeliveryAttempts retrieves all attempts of the delivery with the given id, oldest first.
+func (d WebhookRepositoryDb) FindDeliveryAttempts(deliveryId string) ([]WebhookDeliveryAttempt, *errs.AppError) {
+	attempts := make([]WebhookDeliveryAttempt, 0)
+	findSql := "SELECT attempt_id, delivery_id, " + dateTimeColumn(d.client.DriverName(), "attempted_on") +
+		", status_code, error_message FROM webhook_delivery_attempts WHERE delivery_id = ? ORDER BY attempt_id"
+	if err := d.client.Select(&attempts, d.client.Rebind(findSql), deliveryId); err != nil {
+		logger.Error("Error while retrieving webhook delivery attempts: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return attempts, nil
+}
+
+// SaveDeliveryAttempt starts a database transaction, creates a new entry in the database for the given attempt,
+// updates the status and next attempt of the given delivery and commits the database transaction.
+func (d WebhookRepositoryDb) SaveDeliveryAttempt(delivery WebhookDelivery, attempt WebhookDeliveryAttempt) *errs.AppError {
+	tx, err := d.client.Beginx()
+	if err != nil {
+		logger.Error("Error while starting db transaction for recording webhook delivery attempt: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	insertSql := "INSERT INTO webhook_delivery_attempts (delivery_id, attempted_on, status_code, error_message) VALUES (?, ?, ?, ?)"
+	_, err = tx.Exec(tx.Rebind(insertSql), delivery.DeliveryId, attempt.AttemptedOn, attempt.StatusCode, attempt.ErrorMessage)
+	if err != nil {
+		logger.Error("Error while creating new webhook delivery attempt: " + err.Error())
+		rollbackWebhook(tx)
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	updateSql := "UPDATE webhook_deliveries SET status = ?, attempt_count = ?, next_attempt_on = ? WHERE delivery_id = ?"
+	_, err = tx.Exec(tx.Rebind(updateSql), delivery.Status, delivery.AttemptCount, delivery.NextAttemptOn, delivery.DeliveryId)
+	if err != nil {
+		logger.Error("Error while updating webhook delivery: " + err.Error())
+		rollbackWebhook(tx)
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("Error while committing db transaction for recording webhook delivery attempt: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected database error")
+	}
+
+	return nil
+}
+
+// selectDeliveriesSql returns the select of deliveries together with the URL and secret of their subscription.
+func (d WebhookRepositoryDb) selectDeliveriesSql() string {
+	return "SELECT d.delivery_id, d.subscription_id, d.event_id, d.event_type, d.payload, d.status, d.attempt_count, " +
+		dateTimeColumn(d.client.DriverName(), "d.next_attempt_on") + ", s.url, s.secret " +
+		"FROM webhook_deliveries d JOIN webhook_subscriptions s ON s.subscription_id = d.subscription_id"
+}
+
+func rollbackWebhook(tx *sqlx.Tx) {
+	if rollbackErr := tx.Rollback(); rollbackErr != nil {
+		logger.Fatal("Error while rolling back changes to webhook deliveries: " + rollbackErr.Error())
+	}
+}
@@ -0,0 +1,299 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/jmoiron/sqlx"
+	"net/http"
+	"testing"
+)
+
+// Test common variables and inputs
+var webhookRepoDb WebhookRepositoryDb
+var webhookSubscriptionsTableColumns = []string{"subscription_id", "url", "event_types", "secret", "status", "created_on"}
+var webhookDeliveriesColumns = []string{"delivery_id", "subscription_id", "event_id", "event_type", "payload", "status", "attempt_count", "next_attempt_on", "url", "secret"}
+
+const dummySubscriptionId = "5"
+const dummyDeliveryId = "8"
+const dummyWebhookUrl = "https://partner.example.com/hooks"
+const dummyWebhookSecret = "0123456789abcdef"
+
+const insertWebhookSubscriptionsSql = "INSERT INTO webhook_subscriptions (url, event_types, secret, status, created_on) VALUES (?, ?, ?, ?, ?)"
+const selectWebhookSubscriptionsSql = "SELECT subscription_id, url, event_types, secret, status, created_on FROM webhook_subscriptions WHERE status = ? ORDER BY subscription_id"
+const updateWebhookSubscriptionsDeleteSql = "UPDATE webhook_subscriptions SET status = ? WHERE subscription_id = ? AND status = ?"
+const selectEnqueuedWebhookDeliveriesSql = "SELECT subscription_id FROM webhook_deliveries WHERE event_id = ?"
+const insertWebhookDeliveriesSql = "INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload, status, attempt_count, next_attempt_on, created_on) VALUES (?, ?, ?, ?, ?, ?, ?, ?)"
+const selectWebhookDeliveriesSql = "SELECT d.delivery_id, d.subscription_id, d.event_id, d.event_type, d.payload, d.status, d.attempt_count, d.next_attempt_on, s.url, s.secret FROM webhook_deliveries d JOIN webhook_subscriptions s ON s.subscription_id = d.subscription_id"
+const insertWebhookDeliveryAttemptsSql = "INSERT INTO webhook_delivery_attempts (delivery_id, attempted_on, status_code, error_message) VALUES (?, ?, ?, ?)"
+const updateWebhookDeliveriesSql = "UPDATE webhook_deliveries SET status = ?, attempt_count = ?, next_attempt_on = ? WHERE delivery_id = ?"
+
+const selectWebhookDeliveriesPostgresSql = "SELECT d.delivery_id, d.subscription_id, d.event_id, d.event_type, d.payload, d.status, d.attempt_count, to_char(d.next_attempt_on, 'YYYY-MM-DD HH24:MI:SS') AS next_attempt_on, s.url, s.secret FROM webhook_deliveries d JOIN webhook_subscriptions s ON s.subscription_id = d.subscription_id"
+
+func setupWebhookRepoDbTest(t *testing.T, driverName string) func() {
+	teardown := setupDB(t)
+	webhookRepoDb = NewWebhookRepositoryDb(sqlx.NewDb(db, driverName))
+	return teardown
+}
+
+// getDefaultWebhookSubscription returns an active subscription with id 5 to AccountOpened and TransactionPosted events
+func getDefaultWebhookSubscription() WebhookSubscription {
+	return WebhookSubscription{
+		SubscriptionId: dummySubscriptionId,
+		Url:            dummyWebhookUrl,
+		EventTypes:     EventTypeAccountOpened + "," + EventTypeTransactionPosted,
+		Secret:         dummyWebhookSecret,
+		Status:         WebhookSubscriptionStatusActive,
+		CreatedOn:      dummyDate,
+	}
+}
+
+// getDefaultWebhookDelivery returns the pending delivery with id 8 of the AccountOpened event with id 31 to the
+// default subscription
+func getDefaultWebhookDelivery() WebhookDelivery {
+	return WebhookDelivery{
+		DeliveryId:     dummyDeliveryId,
+		SubscriptionId: dummySubscriptionId,
+		EventId:        dummyEventId,
+		EventType:      EventTypeAccountOpened,
+		Payload:        `{"event_id":"31"}`,
+		Status:         dto.WebhookDeliveryStatusPending,
+		NextAttemptOn:  dummyDate,
+		Url:            dummyWebhookUrl,
+		Secret:         dummyWebhookSecret,
+	}
+}
+
+func expectSelectWebhookSubscriptions(subscriptions ...WebhookSubscription) {
+	dummyRows := sqlmock.NewRows(webhookSubscriptionsTableColumns)
+	for _, s := range subscriptions {
+		dummyRows.AddRow(s.SubscriptionId, s.Url, s.EventTypes, s.Secret, s.Status, s.CreatedOn)
+	}
+	mockDB.ExpectQuery(selectWebhookSubscriptionsSql).WithArgs(WebhookSubscriptionStatusActive).WillReturnRows(dummyRows)
+}
+
+func TestWebhookRepositoryDb_SaveSubscription_returns_subscription_with_newId(t *testing.T) {
+	//Arrange
+	teardown := setupWebhookRepoDbTest(t, driverName)
+	defer teardown()
+
+	expectedSubscription := getDefaultWebhookSubscription()
+	dummySubscription := expectedSubscription
+	dummySubscription.SubscriptionId = ""
+	mockDB.ExpectExec(insertWebhookSubscriptionsSql).
+		WithArgs(dummySubscription.Url, dummySubscription.EventTypes, dummySubscription.Secret, dummySubscription.Status, dummySubscription.CreatedOn).
+		WillReturnResult(sqlmock.NewResult(5, 1))
+
+	//Act
+	actualSubscription, err := webhookRepoDb.SaveSubscription(dummySubscription)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing successful saving of subscription: " + err.Message)
+	}
+	if *actualSubscription != expectedSubscription {
+		t.Errorf("Expected subscription %v but got %v", expectedSubscription, *actualSubscription)
+	}
+}
+
+func TestWebhookRepositoryDb_DeleteSubscription_returns_notFoundError_when_noActiveSubscription(t *testing.T) {
+	//Arrange
+	teardown := setupWebhookRepoDbTest(t, driverName)
+	defer teardown()
+
+	mockDB.ExpectExec(updateWebhookSubscriptionsDeleteSql).
+		WithArgs(WebhookSubscriptionStatusDeleted, dummySubscriptionId, WebhookSubscriptionStatusActive).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	logger.MuteLogger()
+
+	//Act
+	err := webhookRepoDb.DeleteSubscription(dummySubscriptionId)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing deletion of non-existent subscription")
+	}
+	if err.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d but got %d", http.StatusNotFound, err.Code)
+	}
+}
+
+func TestWebhookRepositoryDb_EnqueueDeliveries_skips_unsubscribed_and_alreadyEnqueued_subscriptions(t *testing.T) {
+	//Arrange
+	teardown := setupWebhookRepoDbTest(t, driverName)
+	defer teardown()
+
+	event := NewAccountOpenedEvent(getDefaultAccountAfterSave())
+	event.EventId = dummyEventId
+	enqueuedSubscription := getDefaultWebhookSubscription()
+	unsubscribedSubscription := getDefaultWebhookSubscription()
+	unsubscribedSubscription.SubscriptionId = "6"
+	unsubscribedSubscription.EventTypes = EventTypeAccountStatusChanged
+	newSubscription := getDefaultWebhookSubscription()
+	newSubscription.SubscriptionId = "7"
+	expectSelectWebhookSubscriptions(enqueuedSubscription, unsubscribedSubscription, newSubscription)
+
+	mockDB.ExpectBegin()
+	mockDB.ExpectQuery(selectEnqueuedWebhookDeliveriesSql).
+		WithArgs(dummyEventId).
+		WillReturnRows(sqlmock.NewRows([]string{"subscription_id"}).AddRow(enqueuedSubscription.SubscriptionId))
+	expectedPayload, _ := json.Marshal(event.ToDTO())
+	mockDB.ExpectExec(insertWebhookDeliveriesSql).
+		WithArgs(newSubscription.SubscriptionId, dummyEventId, EventTypeAccountOpened, string(expectedPayload),
+			dto.WebhookDeliveryStatusPending, 0, dummyDate, dummyDate).
+		WillReturnResult(sqlmock.NewResult(9, 1))
+	mockDB.ExpectCommit()
+
+	//Act
+	err := webhookRepoDb.EnqueueDeliveries(event, dummyDate)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing enqueueing of deliveries: " + err.Message)
+	}
+	if err := mockDB.ExpectationsWereMet(); err != nil {
+		t.Error("Expected only one delivery to be enqueued but was not: " + err.Error())
+	}
+}
+
+func TestWebhookRepositoryDb_EnqueueDeliveries_does_nothing_when_noSubscriptions(t *testing.T) {
+	//Arrange
+	teardown := setupWebhookRepoDbTest(t, driverName)
+	defer teardown()
+
+	expectSelectWebhookSubscriptions()
+
+	//Act
+	err := webhookRepoDb.EnqueueDeliveries(NewAccountOpenedEvent(getDefaultAccountAfterSave()), dummyDate)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing enqueueing without subscriptions: " + err.Message)
+	}
+}
+
+func TestWebhookRepositoryDb_FindDueDeliveries_returns_deliveries_when_select_succeeds(t *testing.T) {
+	tests := []struct {
+		driverName  string
+		expectedSql string
+	}{
+		{DriverMySQL, selectWebhookDeliveriesSql +
+			" WHERE d.status = ? AND s.status = ? AND d.next_attempt_on <= ? ORDER BY d.delivery_id LIMIT ?"},
+		{DriverPostgres, selectWebhookDeliveriesPostgresSql +
+			" WHERE d.status = $1 AND s.status = $2 AND d.next_attempt_on <= $3 ORDER BY d.delivery_id LIMIT $4"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.driverName, func(t *testing.T) {
+			//Arrange
+			teardown := setupWebhookRepoDbTest(t, tc.driverName)
+			defer teardown()
+
+			d := getDefaultWebhookDelivery()
+			mockDB.ExpectQuery(tc.expectedSql).
+				WithArgs(dto.WebhookDeliveryStatusPending, WebhookSubscriptionStatusActive, dummyDate, 50).
+				WillReturnRows(sqlmock.NewRows(webhookDeliveriesColumns).
+					AddRow(d.DeliveryId, d.SubscriptionId, d.EventId, d.EventType, d.Payload, d.Status, d.AttemptCount, d.NextAttemptOn, d.Url, d.Secret))
+
+			//Act
+			actualDeliveries, err := webhookRepoDb.FindDueDeliveries(dummyDate, 50)
+
+			//Assert
+			if err != nil {
+				t.Fatal("Expected no error but got error while testing successful selection of due deliveries: " + err.Message)
+			}
+			if len(actualDeliveries) != 1 || actualDeliveries[0] != d {
+				t.Errorf("Expected deliveries %v but got %v", []WebhookDelivery{d}, actualDeliveries)
+			}
+		})
+	}
+}
+
+func TestWebhookRepositoryDb_FindDeliveryById_returns_notFoundError_when_noDelivery(t *testing.T) {
+	//Arrange
+	teardown := setupWebhookRepoDbTest(t, driverName)
+	defer teardown()
+
+	mockDB.ExpectQuery(selectWebhookDeliveriesSql + " WHERE d.delivery_id = ?").
+		WithArgs(dummyDeliveryId).
+		WillReturnRows(sqlmock.NewRows(webhookDeliveriesColumns))
+	logger.MuteLogger()
+
+	//Act
+	_, err := webhookRepoDb.FindDeliveryById(dummyDeliveryId)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing non-existent delivery")
+	}
+	if err.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d but got %d", http.StatusNotFound, err.Code)
+	}
+}
+
+func TestWebhookRepositoryDb_SaveDeliveryAttempt_returns_error_and_rollsBack_when_updateDeliveries_fails(t *testing.T) {
+	//Arrange
+	teardown := setupWebhookRepoDbTest(t, driverName)
+	defer teardown()
+
+	delivery := getDefaultWebhookDelivery()
+	attempt := WebhookDeliveryAttempt{DeliveryId: dummyDeliveryId, AttemptedOn: dummyDate, StatusCode: 500}
+	mockDB.ExpectBegin()
+	mockDB.ExpectExec(insertWebhookDeliveryAttemptsSql).
+		WithArgs(dummyDeliveryId, dummyDate, 500, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	dummyDbErr := errors.New("some error message")
+	mockDB.ExpectExec(updateWebhookDeliveriesSql).
+		WithArgs(delivery.Status, delivery.AttemptCount, delivery.NextAttemptOn, dummyDeliveryId).
+		WillReturnError(dummyDbErr)
+	mockDB.ExpectRollback()
+
+	logs := logger.ReplaceWithTestLogger()
+	expectedLogMessage := "Error while updating webhook delivery: " + dummyDbErr.Error()
+
+	//Act
+	err := webhookRepoDb.SaveDeliveryAttempt(delivery, attempt)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing failed updating of delivery")
+	}
+	if err.Message != defaultExpectedErrMessage {
+		t.Errorf("Expected error message to be \"%s\" but got \"%s\"", defaultExpectedErrMessage, err.Message)
+	}
+	if logs.Len() != 1 {
+		t.Fatalf("Expected 1 message to be logged but got %d logs", logs.Len())
+	}
+	if logs.All()[0].Message != expectedLogMessage {
+		t.Errorf("Expected log message to be \"%s\" but got \"%s\"", expectedLogMessage, logs.All()[0].Message)
+	}
+}
+
+func TestWebhookRepositoryDb_SaveDeliveryAttempt_records_attempt_and_updates_delivery(t *testing.T) {
+	//Arrange
+	teardown := setupWebhookRepoDbTest(t, driverName)
+	defer teardown()
+
+	delivery := getDefaultWebhookDelivery()
+	delivery.Status = dto.WebhookDeliveryStatusSucceeded
+	delivery.AttemptCount = 1
+	attempt := WebhookDeliveryAttempt{DeliveryId: dummyDeliveryId, AttemptedOn: dummyDate, StatusCode: 200}
+	mockDB.ExpectBegin()
+	mockDB.ExpectExec(insertWebhookDeliveryAttemptsSql).
+		WithArgs(dummyDeliveryId, dummyDate, 200, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mockDB.ExpectExec(updateWebhookDeliveriesSql).
+		WithArgs(dto.WebhookDeliveryStatusSucceeded, 1, dummyDate, dummyDeliveryId).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mockDB.ExpectCommit()
+
+	//Act
+	err := webhookRepoDb.SaveDeliveryAttempt(delivery, attempt)
+
+	//Assert
+	if err != nil {
+		t.Error("Expected no error but got error while testing recording of delivery attempt: " + err.Message)
+	}
+}
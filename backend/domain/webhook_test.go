@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"testing"
+	"time"
+)
+
+func TestWebhookSubscription_IsSubscribedTo_returns_correctResult(t *testing.T) {
+	//Arrange
+	subscription := WebhookSubscription{EventTypes: EventTypeAccountOpened + "," + EventTypeTransactionPosted}
+	tests := []struct {
+		eventType      string
+		expectedResult bool
+	}{
+		{EventTypeAccountOpened, true},
+		{EventTypeTransactionPosted, true},
+		{EventTypeAccountStatusChanged, false},
+		{"Account", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.eventType, func(t *testing.T) {
+			//Act
+			actualResult := subscription.IsSubscribedTo(tc.eventType)
+
+			//Assert
+			if actualResult != tc.expectedResult {
+				t.Errorf("expected \"%v\" but got \"%v\"", tc.expectedResult, actualResult)
+			}
+		})
+	}
+}
+
+func TestWebhookSubscription_ToDTO_leaves_out_secret(t *testing.T) {
+	//Arrange
+	request := dto.NewWebhookSubscriptionRequest{
+		Url:        "https://partner.example.com/hooks",
+		EventTypes: []string{EventTypeAccountOpened, EventTypeTransactionPosted},
+		Secret:     "0123456789abcdef",
+	}
+	subscription := NewWebhookSubscription(request, clock.StaticClock{})
+
+	//Act
+	response := subscription.ToDTO()
+
+	//Assert
+	if response.Url != request.Url || len(response.EventTypes) != 2 || response.EventTypes[1] != EventTypeTransactionPosted {
+		t.Errorf("Expected subscription to %s for %v but got %v", request.Url, request.EventTypes, response)
+	}
+}
+
+func TestWebhookDelivery_RecordAttempt_schedules_retries_with_exponentialBackoff(t *testing.T) {
+	//Arrange
+	attemptedAt := time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name                  string
+		previousAttempts      int
+		statusCode            int
+		expectedStatus        string
+		expectedNextAttemptOn string
+	}{
+		{"first attempt fails", 0, 0, dto.WebhookDeliveryStatusPending, "2006-01-02 15:04:35"},
+		{"second attempt rejected", 1, 500, dto.WebhookDeliveryStatusPending, "2006-01-02 15:05:05"},
+		{"seventh attempt fails", WebhookMaxAttempts - 2, 503, dto.WebhookDeliveryStatusPending, "2006-01-02 15:36:05"},
+		{"last attempt fails", WebhookMaxAttempts - 1, 404, dto.WebhookDeliveryStatusFailed, ""},
+		{"attempt succeeds", 3, 204, dto.WebhookDeliveryStatusSucceeded, ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			delivery := WebhookDelivery{Status: dto.WebhookDeliveryStatusPending, AttemptCount: tc.previousAttempts}
+
+			//Act
+			delivery.RecordAttempt(WebhookDeliveryAttempt{StatusCode: tc.statusCode}, attemptedAt)
+
+			//Assert
+			if delivery.AttemptCount != tc.previousAttempts+1 {
+				t.Errorf("Expected %d attempts but got %d", tc.previousAttempts+1, delivery.AttemptCount)
+			}
+			if delivery.Status != tc.expectedStatus {
+				t.Errorf("Expected status %s but got %s", tc.expectedStatus, delivery.Status)
+			}
+			if tc.expectedNextAttemptOn != "" && delivery.NextAttemptOn != tc.expectedNextAttemptOn {
+				t.Errorf("Expected next attempt on %s but got %s", tc.expectedNextAttemptOn, delivery.NextAttemptOn)
+			}
+		})
+	}
+}
+
+func TestSignWebhookPayload_returns_hmacSha256_of_timestamp_and_payload(t *testing.T) {
+	//Arrange
+	expectedSignature := "4a2c21a7665123a56838818cfacd8b9a69234e2a94910d6a4d3bb6eb1ba408d4" //computed with openssl dgst
+
+	//Act
+	actualSignature := SignWebhookPayload("0123456789abcdef", "1136214245", `{"event_id":"31"}`)
+
+	//Assert
+	if actualSignature != expectedSignature {
+		t.Errorf("Expected signature %s but got %s", expectedSignature, actualSignature)
+	}
+}
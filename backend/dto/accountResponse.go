@@ -1,8 +1,9 @@
 package dto
 
 type AccountResponse struct {
-	AccountId   string  `json:"account_id"`
-	OpeningDate string  `json:"opening_date"`
-	AccountType string  `json:"account_type"`
-	Amount      float64 `json:"amount"`
+	AccountId       string  `json:"account_id"`
+	OpeningDate     string  `json:"opening_date"`
+	AccountType     string  `json:"account_type"`
+	Amount          float64 `json:"amount"`           //ledger balance
+	AvailableAmount float64 `json:"available_amount"` //ledger balance less the funds on hold
 }
@@ -0,0 +1,17 @@
+package dto
+
+type AlertRuleResponse struct {
+	RuleId    string  `json:"rule_id"`
+	AccountId string  `json:"account_id"`
+	RuleType  string  `json:"rule_type"`
+	Threshold float64 `json:"threshold"`
+	CreatedOn string  `json:"created_on"`
+}
+
+type AlertResponse struct {
+	AlertId   string `json:"alert_id"`
+	AccountId string `json:"account_id"`
+	RuleType  string `json:"rule_type"`
+	Message   string `json:"message"`
+	CreatedOn string `json:"created_on"`
+}
@@ -0,0 +1,16 @@
+package dto
+
+const AlertRuleTypeLargeWithdrawal = "large_withdrawal" //alerts when a single withdrawal exceeds the threshold
+const AlertRuleTypeLowBalance = "low_balance"           //alerts when the balance drops below the threshold
+const AlertRuleMaxThresholdAllowed float64 = 99999999.99
+
+type NewAlertRuleRequest struct {
+	CustomerId string  `json:"customer_id" validate:"required,max=11,number"`
+	AccountId  string  `json:"account_id" validate:"required,max=11,number"`
+	RuleType   string  `json:"rule_type" validate:"required,oneof=large_withdrawal low_balance"`
+	Threshold  float64 `json:"threshold" validate:"number,gt=0,lte=99999999.99"`
+}
+
+func (r NewAlertRuleRequest) Validate() *ValidationError {
+	return validateStruct(r, "New alert rule")
+}
@@ -0,0 +1,82 @@
+package dto
+
+import (
+	"net/http"
+	"testing"
+)
+
+// getDefaultValidNewAlertRuleRequest returns a NewAlertRuleRequest for the customer with id 2 wanting to be alerted
+// when the balance of the account with id 1977 drops below 500
+func getDefaultValidNewAlertRuleRequest() NewAlertRuleRequest {
+	return NewAlertRuleRequest{
+		CustomerId: dummyCustomerId,
+		AccountId:  "1977",
+		RuleType:   AlertRuleTypeLowBalance,
+		Threshold:  500,
+	}
+}
+
+func TestNewAlertRuleRequest_Validate_returns_nil_when_request_valid(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name      string
+		ruleType  string
+		threshold float64
+	}{
+		{"low balance", AlertRuleTypeLowBalance, 500},
+		{"large withdrawal", AlertRuleTypeLargeWithdrawal, 0.01},
+		{"upper boundary", AlertRuleTypeLargeWithdrawal, AlertRuleMaxThresholdAllowed},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := getDefaultValidNewAlertRuleRequest()
+			request.RuleType = tc.ruleType
+			request.Threshold = tc.threshold
+
+			//Act
+			err := request.Validate()
+
+			//Assert
+			if err != nil {
+				t.Errorf("expected no error but got error while testing valid alert rule: %s", err.Message)
+			}
+		})
+	}
+}
+
+func TestNewAlertRuleRequest_Validate_returns_validationError_when_request_invalid(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name            string
+		ruleType        string
+		threshold       float64
+		expectedMessage string
+	}{
+		{"unknown type", "large_deposit", 500, "Alert type should be large_withdrawal or low_balance."},
+		{"zero threshold", AlertRuleTypeLowBalance, 0, "Alert threshold must be greater than 0."},
+		{"threshold too large", AlertRuleTypeLowBalance, 100000000, "Alert threshold must be at most 99999999.99."},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := getDefaultValidNewAlertRuleRequest()
+			request.RuleType = tc.ruleType
+			request.Threshold = tc.threshold
+
+			//Act
+			err := request.Validate()
+
+			//Assert
+			if err == nil {
+				t.Fatal("expected error but got none while testing invalid alert rule")
+			}
+			if err.Code != http.StatusUnprocessableEntity {
+				t.Errorf("expected status code %d but got %d", http.StatusUnprocessableEntity, err.Code)
+			}
+			if err.Message != tc.expectedMessage {
+				t.Errorf("expected error message \"%s\" but got \"%s\"", tc.expectedMessage, err.Message)
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package dto
+
+const AnalyticsDefaultMonths = 12 //the months covered when no start month is given, up to and including the end month
+const AnalyticsMaxMonths = 24
+const AnalyticsTopTransactions = 5
+
+type AnalyticsRequest struct {
+	CustomerId string `json:"customer_id" validate:"required,max=11,number"`
+	From       string `json:"from" validate:"omitempty,datetime=2006-01"` //first month covered
+	To         string `json:"to" validate:"omitempty,datetime=2006-01"`   //last month covered, the current month if not given
+}
+
+func (r AnalyticsRequest) Validate() *ValidationError {
+	return validateStruct(r, "Analytics")
+}
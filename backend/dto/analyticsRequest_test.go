@@ -0,0 +1,64 @@
+package dto
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestAnalyticsRequest_Validate_returns_nil_when_request_valid(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name string
+		from string
+		to   string
+	}{
+		{"default months", "", ""},
+		{"from month only", "2006-01", ""},
+		{"both months", "2006-01", "2006-03"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := AnalyticsRequest{CustomerId: dummyCustomerId, From: tc.from, To: tc.to}
+
+			//Act
+			err := request.Validate()
+
+			//Assert
+			if err != nil {
+				t.Errorf("Expected no error but got error while testing valid analytics request: %s", err.Message)
+			}
+		})
+	}
+}
+
+func TestAnalyticsRequest_Validate_returns_validationError_when_month_invalid(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name            string
+		from            string
+		to              string
+		expectedMessage string
+	}{
+		{"from as date", "2006-01-02", "", "Start month should be given as YYYY-MM."},
+		{"to out of range", "", "2006-13", "End month should be given as YYYY-MM."},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := AnalyticsRequest{CustomerId: dummyCustomerId, From: tc.from, To: tc.to}
+
+			//Act
+			err := request.Validate()
+
+			//Assert
+			if err == nil {
+				t.Fatal("Expected error but got none while testing invalid analytics request")
+			}
+			if err.Code != http.StatusUnprocessableEntity || err.Message != tc.expectedMessage {
+				t.Errorf("Expected status code %d and message \"%s\" but got %d and \"%s\"",
+					http.StatusUnprocessableEntity, tc.expectedMessage, err.Code, err.Message)
+			}
+		})
+	}
+}
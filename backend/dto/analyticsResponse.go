@@ -0,0 +1,66 @@
+package dto
+
+// AnalyticsUncategorized is the category under which the transactions without a category are grouped.
+const AnalyticsUncategorized = "uncategorized"
+
+// AnalyticsResponse summarizes the transactions posted on all accounts of a customer over a range of months. Income is
+// made up of the deposits and spending of the withdrawals and the fees and interest charged by the bank.
+type AnalyticsResponse struct {
+	CustomerId      string                             `json:"customer_id"`
+	From            string                             `json:"from"` //first month covered
+	To              string                             `json:"to"`   //last month covered
+	Totals          AnalyticsTotalsResponse            `json:"totals"`
+	ByMonth         []MonthlyAnalyticsResponse         `json:"by_month"` //every month covered, oldest first
+	ByCategory      []CategoryAnalyticsResponse        `json:"by_category"`
+	ByType          []TransactionTypeAnalyticsResponse `json:"by_type"`
+	TopTransactions []AnalyticsTransactionResponse     `json:"top_transactions"` //the largest debits, largest first
+}
+
+type AnalyticsTotalsResponse struct {
+	Income                 float64 `json:"income"`
+	Spending               float64 `json:"spending"`
+	Net                    float64 `json:"net"`
+	TransactionCount       int     `json:"transaction_count"`
+	AverageIncome          float64 `json:"average_income"`   //per deposit
+	AverageSpending        float64 `json:"average_spending"` //per debit
+	AverageMonthlyIncome   float64 `json:"average_monthly_income"`
+	AverageMonthlySpending float64 `json:"average_monthly_spending"`
+}
+
+type MonthlyAnalyticsResponse struct {
+	Month            string  `json:"month"`
+	Income           float64 `json:"income"`
+	Spending         float64 `json:"spending"`
+	Net              float64 `json:"net"`
+	TransactionCount int     `json:"transaction_count"`
+	//the changes from the month before, not set for the first month covered
+	IncomeChange          *float64 `json:"income_change,omitempty"`
+	SpendingChange        *float64 `json:"spending_change,omitempty"`
+	SpendingChangePercent *float64 `json:"spending_change_percent,omitempty"` //not set either if nothing was spent the month before
+}
+
+type CategoryAnalyticsResponse struct {
+	Category         string  `json:"category"`
+	Income           float64 `json:"income"`
+	Spending         float64 `json:"spending"`
+	Net              float64 `json:"net"`
+	TransactionCount int     `json:"transaction_count"`
+	AverageSpending  float64 `json:"average_spending"` //per debit
+}
+
+type TransactionTypeAnalyticsResponse struct {
+	TransactionType  string  `json:"transaction_type"`
+	Total            float64 `json:"total"`
+	TransactionCount int     `json:"transaction_count"`
+	AverageAmount    float64 `json:"average_amount"`
+}
+
+type AnalyticsTransactionResponse struct {
+	TransactionId   string  `json:"transaction_id"`
+	AccountId       string  `json:"account_id"`
+	TransactionType string  `json:"transaction_type"`
+	Amount          float64 `json:"amount"`
+	TransactionDate string  `json:"transaction_date"`
+	Description     string  `json:"description,omitempty"`
+	Category        string  `json:"category,omitempty"`
+}
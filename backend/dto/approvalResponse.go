@@ -0,0 +1,23 @@
+package dto
+
+import "encoding/json"
+
+const ApprovalStatusPending = "pending"
+const ApprovalStatusApproved = "approved" //approved and being executed
+const ApprovalStatusExecuted = "executed"
+const ApprovalStatusFailed = "failed" //approved but the operation returned an error
+const ApprovalStatusRejected = "rejected"
+const ApprovalStatusExpired = "expired"
+
+type ApprovalResponse struct {
+	ApprovalId  string          `json:"approval_id"`
+	Operation   string          `json:"operation"`
+	Description string          `json:"description"`
+	Status      string          `json:"status"`
+	RequestedBy string          `json:"requested_by"`
+	RequestedOn string          `json:"requested_on"`
+	ExpiresOn   string          `json:"expires_on"`
+	DecidedBy   string          `json:"decided_by,omitempty"`
+	DecidedOn   string          `json:"decided_on,omitempty"`
+	Result      json.RawMessage `json:"result,omitempty"` //response of the operation, or its error if it failed
+}
@@ -0,0 +1,36 @@
+package dto
+
+import "encoding/json"
+
+// EventMessage is the form in which a domain event is published to other systems. Payload is one of the event
+// payloads below, depending on EventType.
+type EventMessage struct {
+	EventId   string          `json:"event_id"`
+	EventType string          `json:"event_type"`
+	AccountId string          `json:"account_id"`
+	CreatedOn string          `json:"created_on"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
+type AccountOpenedPayload struct {
+	AccountId   string  `json:"account_id"`
+	CustomerId  string  `json:"customer_id"`
+	AccountType string  `json:"account_type"`
+	Amount      float64 `json:"amount"`
+	OpeningDate string  `json:"opening_date"`
+}
+
+type TransactionPostedPayload struct {
+	TransactionId   string  `json:"transaction_id"`
+	AccountId       string  `json:"account_id"`
+	TransactionType string  `json:"transaction_type"`
+	Amount          float64 `json:"amount"`
+	Balance         float64 `json:"new_balance"`
+	TransactionDate string  `json:"transaction_date"`
+}
+
+type AccountStatusChangedPayload struct {
+	AccountId string `json:"account_id"`
+	Status    string `json:"status"`
+	Reason    string `json:"reason"`
+}
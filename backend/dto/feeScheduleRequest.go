@@ -0,0 +1,17 @@
+package dto
+
+const FeeMaxAmountAllowed float64 = 1000
+
+type NewFeeScheduleRequest struct {
+	AccountType     string  `json:"account_type" validate:"required,oneof=saving checking"`
+	MonthlyFee      float64 `json:"monthly_fee" validate:"number,gte=0,lte=1000"`
+	MinimumBalance  float64 `json:"minimum_balance" validate:"number,gte=0,lte=99999999.99"` //0 if the monthly fee is never waived
+	FreeWithdrawals int     `json:"free_withdrawals" validate:"gte=0,lte=1000"`
+	WithdrawalFee   float64 `json:"withdrawal_fee" validate:"number,gte=0,lte=1000"`
+	EffectiveFrom   string  `json:"effective_from" validate:"required,datetime=2006-01-02"`
+	CreatedBy       string  `json:"-"` //the admin creating the schedule, from their access token
+}
+
+func (r NewFeeScheduleRequest) Validate() *ValidationError {
+	return validateStruct(r, "New fee schedule")
+}
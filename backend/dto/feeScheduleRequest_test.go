@@ -0,0 +1,84 @@
+package dto
+
+import (
+	"net/http"
+	"testing"
+)
+
+// getDefaultValidNewFeeScheduleRequest returns a NewFeeScheduleRequest for checking accounts to be charged 5 a month
+// unless their average balance is at least 1000, and 0.50 for every withdrawal after the first 3 in a month
+func getDefaultValidNewFeeScheduleRequest() NewFeeScheduleRequest {
+	return NewFeeScheduleRequest{
+		AccountType:     "checking",
+		MonthlyFee:      5,
+		MinimumBalance:  1000,
+		FreeWithdrawals: 3,
+		WithdrawalFee:   0.5,
+		EffectiveFrom:   "2006-02-01",
+	}
+}
+
+func TestNewFeeScheduleRequest_Validate_returns_nil_when_request_valid(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name   string
+		modify func(*NewFeeScheduleRequest)
+	}{
+		{"default", func(r *NewFeeScheduleRequest) {}},
+		{"no fees", func(r *NewFeeScheduleRequest) {
+			r.MonthlyFee, r.MinimumBalance, r.FreeWithdrawals, r.WithdrawalFee = 0, 0, 0, 0
+		}},
+		{"upper boundary", func(r *NewFeeScheduleRequest) {
+			r.MonthlyFee, r.WithdrawalFee = FeeMaxAmountAllowed, FeeMaxAmountAllowed
+		}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := getDefaultValidNewFeeScheduleRequest()
+			tc.modify(&request)
+
+			//Act
+			err := request.Validate()
+
+			//Assert
+			if err != nil {
+				t.Errorf("expected no error but got error while testing valid fee schedule: %s", err.Message)
+			}
+		})
+	}
+}
+
+func TestNewFeeScheduleRequest_Validate_returns_validationError_when_request_invalid(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name            string
+		modify          func(*NewFeeScheduleRequest)
+		expectedMessage string
+	}{
+		{"unknown account type", func(r *NewFeeScheduleRequest) { r.AccountType = "credit" }, "Account type should be saving or checking."},
+		{"negative monthly fee", func(r *NewFeeScheduleRequest) { r.MonthlyFee = -1 }, "Monthly fee must be at least 0."},
+		{"negative free withdrawals", func(r *NewFeeScheduleRequest) { r.FreeWithdrawals = -1 }, "Free withdrawals must be at least 0."},
+		{"withdrawal fee too large", func(r *NewFeeScheduleRequest) { r.WithdrawalFee = 1000.01 }, "Withdrawal fee must be at most 1000."},
+		{"missing effective date", func(r *NewFeeScheduleRequest) { r.EffectiveFrom = "" }, "Effective date must be present."},
+		{"effective date with time", func(r *NewFeeScheduleRequest) { r.EffectiveFrom = "2006-02-01 00:00:00" }, "Effective date should be given as YYYY-MM-DD."},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := getDefaultValidNewFeeScheduleRequest()
+			tc.modify(&request)
+
+			//Act
+			err := request.Validate()
+
+			//Assert
+			if err == nil {
+				t.Fatal("expected error but got none while testing invalid fee schedule")
+			}
+			if err.Code != http.StatusUnprocessableEntity || err.Message != tc.expectedMessage {
+				t.Errorf("expected validation error \"%s\" but got %d \"%s\"", tc.expectedMessage, err.Code, err.Message)
+			}
+		})
+	}
+}
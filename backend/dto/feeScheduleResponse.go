@@ -0,0 +1,27 @@
+package dto
+
+// The states of a fee schedule.
+const FeeScheduleStatusScheduled = "scheduled"   //takes effect at a later date
+const FeeScheduleStatusInEffect = "in_effect"    //the fees currently charged on accounts of its account type
+const FeeScheduleStatusSuperseded = "superseded" //replaced by a schedule that took effect later
+
+type FeeScheduleResponse struct {
+	ScheduleId      string  `json:"schedule_id"`
+	AccountType     string  `json:"account_type"`
+	MonthlyFee      float64 `json:"monthly_fee"`
+	MinimumBalance  float64 `json:"minimum_balance"`
+	FreeWithdrawals int     `json:"free_withdrawals"`
+	WithdrawalFee   float64 `json:"withdrawal_fee"`
+	EffectiveFrom   string  `json:"effective_from"`
+	Status          string  `json:"status"`
+	CreatedBy       string  `json:"created_by"`
+	CreatedOn       string  `json:"created_on"`
+}
+
+// MonthlyFeeReport is the result of a run of the monthly fee job.
+type MonthlyFeeReport struct {
+	Period          string  `json:"period"` //the month charged for, as YYYY-MM
+	AccountsCharged int     `json:"accounts_charged"`
+	FeesCharged     float64 `json:"fees_charged"`
+	AccountsWaived  int     `json:"accounts_waived"` //whose average balance over the month reached the minimum balance
+}
@@ -0,0 +1,16 @@
+package dto
+
+const HoldDefaultDurationHours = 168 //a week, long enough for a card authorization to be captured or a cheque to clear
+const HoldMaxDurationHours = 720
+
+type NewHoldRequest struct {
+	CustomerId    string  `json:"customer_id" validate:"required,max=11,number"`
+	AccountId     string  `json:"account_id" validate:"required,max=11,number"`
+	Amount        float64 `json:"amount" validate:"number,gt=0,lte=10000"`
+	Reason        string  `json:"reason" validate:"required,max=255"`
+	DurationHours int     `json:"duration_hours" validate:"gte=0,lte=720"` //0 for HoldDefaultDurationHours
+}
+
+func (r NewHoldRequest) Validate() *ValidationError {
+	return validateStruct(r, "New hold")
+}
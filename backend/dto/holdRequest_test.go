@@ -0,0 +1,84 @@
+package dto
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// getDefaultValidNewHoldRequest returns a NewHoldRequest to hold 500 on the account with id 1977 of the customer with
+// id 2 for the default duration
+func getDefaultValidNewHoldRequest() NewHoldRequest {
+	return NewHoldRequest{
+		CustomerId: dummyCustomerId,
+		AccountId:  "1977",
+		Amount:     500,
+		Reason:     "Card authorization",
+	}
+}
+
+func TestNewHoldRequest_Validate_returns_nil_when_request_valid(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name          string
+		amount        float64
+		durationHours int
+	}{
+		{"default duration", 500, 0},
+		{"lower boundary", 0.01, 1},
+		{"upper boundary", TransactionMaxAmountAllowed, HoldMaxDurationHours},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := getDefaultValidNewHoldRequest()
+			request.Amount = tc.amount
+			request.DurationHours = tc.durationHours
+
+			//Act
+			err := request.Validate()
+
+			//Assert
+			if err != nil {
+				t.Errorf("expected no error but got error while testing valid hold: %s", err.Message)
+			}
+		})
+	}
+}
+
+func TestNewHoldRequest_Validate_returns_validationError_when_request_invalid(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name            string
+		modify          func(*NewHoldRequest)
+		expectedMessage string
+	}{
+		{"zero amount", func(r *NewHoldRequest) { r.Amount = 0 }, "Amount must be greater than 0."},
+		{"amount too large", func(r *NewHoldRequest) { r.Amount = TransactionMaxAmountAllowed + 1 }, "Amount must be at most 10000."},
+		{"no reason", func(r *NewHoldRequest) { r.Reason = "" }, "Reason must be present."},
+		{"reason too long", func(r *NewHoldRequest) { r.Reason = strings.Repeat("a", 256) }, "Reason should be at most 255 characters long."},
+		{"duration too long", func(r *NewHoldRequest) { r.DurationHours = HoldMaxDurationHours + 1 }, "Hold duration in hours must be at most 720."},
+		{"negative duration", func(r *NewHoldRequest) { r.DurationHours = -1 }, "Hold duration in hours must be at least 0."},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := getDefaultValidNewHoldRequest()
+			tc.modify(&request)
+
+			//Act
+			err := request.Validate()
+
+			//Assert
+			if err == nil {
+				t.Fatal("expected error but got none while testing invalid hold")
+			}
+			if err.Code != http.StatusUnprocessableEntity {
+				t.Errorf("expected status code %d but got %d", http.StatusUnprocessableEntity, err.Code)
+			}
+			if err.Message != tc.expectedMessage {
+				t.Errorf("expected error message \"%s\" but got \"%s\"", tc.expectedMessage, err.Message)
+			}
+		})
+	}
+}
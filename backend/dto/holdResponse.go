@@ -0,0 +1,18 @@
+package dto
+
+const HoldStatusActive = "active"
+const HoldStatusCaptured = "captured" //posted as a withdrawal
+const HoldStatusReleased = "released"
+const HoldStatusExpired = "expired"
+
+type HoldResponse struct {
+	HoldId        string  `json:"hold_id"`
+	AccountId     string  `json:"account_id"`
+	Amount        float64 `json:"amount"`
+	Reason        string  `json:"reason"`
+	Status        string  `json:"status"`
+	PlacedOn      string  `json:"placed_on"`
+	ExpiresOn     string  `json:"expires_on"`
+	ResolvedOn    string  `json:"resolved_on,omitempty"`
+	TransactionId string  `json:"transaction_id,omitempty"` //the withdrawal that captured the hold
+}
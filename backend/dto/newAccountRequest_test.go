@@ -4,6 +4,7 @@ import (
 	"github.com/aliciatay-zls/banking-lib/formValidator"
 	"github.com/aliciatay-zls/banking-lib/logger"
 	"net/http"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -54,16 +55,16 @@ func TestNewAccountRequest_Validate_returns_nil_when_amount_valid(t *testing.T)
 func TestNewAccountRequest_Validate_returns_error_when_amount_invalid(t *testing.T) {
 	//Arrange
 	tests := []struct {
-		name       string
-		invalidAmt float64
+		name               string
+		invalidAmt         float64
+		expectedErrMessage string
 	}{
-		{"below lower boundary", 4999.99},
-		{"above upper boundary", 100000000.00},
-		{"zero", 0},
+		{"below lower boundary", 4999.99, "Amount must be at least 5000."},
+		{"above upper boundary", 100000000.00, "Amount must be at most 99999999.99."},
+		{"zero", 0, "Amount must be present."},
 	}
 	request := getDefaultValidNewAccountRequest()
 
-	expectedErrMessage := "Please check that the initial amount is valid."
 	expectedCode := http.StatusUnprocessableEntity
 
 	for _, tc := range tests {
@@ -77,8 +78,8 @@ func TestNewAccountRequest_Validate_returns_error_when_amount_invalid(t *testing
 			if actualErr == nil {
 				t.Fatal("expected error but got none while testing invalid new account amount")
 			}
-			if actualErr.Message != expectedErrMessage {
-				t.Errorf("expected message: \"%s\", actual message: \"%s\"", expectedErrMessage, actualErr.Message)
+			if actualErr.Message != tc.expectedErrMessage {
+				t.Errorf("expected message: \"%s\", actual message: \"%s\"", tc.expectedErrMessage, actualErr.Message)
 			}
 			if actualErr.Code != expectedCode {
 				t.Errorf("expected status code: \"%d\", actual status code: \"%d\"", expectedCode, actualErr.Code)
@@ -117,9 +118,9 @@ func TestNewAccountRequest_Validate_returns_error_when_field_empty(t *testing.T)
 		request            NewAccountRequest
 		expectedErrMessage string
 	}{
-		{"amount empty", NewAccountRequest{CustomerId: "2000", AccountType: AccountTypeSaving}, "Please check that the initial amount is valid."},
-		{"type empty", NewAccountRequest{CustomerId: dummyCustomerId, Amount: NewAccountMinAmountAllowed}, "Account type should be saving or checking."},
-		{"customer id empty", NewAccountRequest{Amount: NewAccountMinAmountAllowed, AccountType: AccountTypeSaving}, "Customer ID must be present and a number."},
+		{"amount empty", NewAccountRequest{CustomerId: "2000", AccountType: AccountTypeSaving}, "Amount must be present."},
+		{"type empty", NewAccountRequest{CustomerId: dummyCustomerId, Amount: NewAccountMinAmountAllowed}, "Account type must be present."},
+		{"customer id empty", NewAccountRequest{Amount: NewAccountMinAmountAllowed, AccountType: AccountTypeSaving}, "Customer ID must be present."},
 	}
 
 	expectedCode := http.StatusUnprocessableEntity
@@ -143,7 +144,7 @@ func TestNewAccountRequest_Validate_returns_error_when_field_empty(t *testing.T)
 	}
 }
 
-func TestNewAccountRequest_Validate_returns_first_error_when_field_multiple_errors(t *testing.T) {
+func TestNewAccountRequest_Validate_returns_every_error_when_fields_invalid(t *testing.T) {
 	//Arrange
 	request := NewAccountRequest{
 		CustomerId:  "aaaaaaaaaaaa",      //12 'a's and not a number so max tag and number tag both violated
@@ -151,18 +152,27 @@ func TestNewAccountRequest_Validate_returns_first_error_when_field_multiple_erro
 		Amount:      -1.0,                //gte tag violated
 	}
 
-	expectedErrMessage := "Customer ID must be present and a number."
+	expectedErrors := []FieldError{
+		{Field: "customer_id", Rule: "max", Message: "Customer ID should be at most 11 characters long."},
+		{Field: "account_type", Rule: "oneof", Message: "Account type should be saving or checking."},
+		{Field: "amount", Rule: "gte", Message: "Amount must be at least 5000."},
+	}
+	expectedErrMessage := "Customer ID should be at most 11 characters long. Account type should be saving or checking. " +
+		"Amount must be at least 5000."
 	expectedCode := http.StatusUnprocessableEntity
 
 	logs := logger.ReplaceWithTestLogger()
-	expectedLogMessageParts := []string{"New account request is invalid", "max"}
+	expectedLogMessageParts := []string{"New account request is invalid", "max", "oneof", "gte"}
 
 	//Act
 	actualErr := request.Validate()
 
 	//Assert
 	if actualErr == nil {
-		t.Fatal("expected error but got none while testing empty fields in new account")
+		t.Fatal("expected error but got none while testing invalid fields in new account")
+	}
+	if !reflect.DeepEqual(actualErr.Errors, expectedErrors) {
+		t.Errorf("expected errors: %v, actual errors: %v", expectedErrors, actualErr.Errors)
 	}
 	if actualErr.Message != expectedErrMessage {
 		t.Errorf("expected message: \"%s\", actual message: \"%s\"", expectedErrMessage, actualErr.Message)
@@ -0,0 +1,13 @@
+package dto
+
+const OverdraftMaxLimitAllowed float64 = 10000
+
+type OverdraftLimitRequest struct {
+	CustomerId string  `json:"customer_id" validate:"required,max=11,number"`
+	AccountId  string  `json:"account_id" validate:"required,max=11,number"`
+	Limit      float64 `json:"overdraft_limit" validate:"number,gte=0,lte=10000"` //0 to withdraw the overdraft facility
+}
+
+func (r OverdraftLimitRequest) Validate() *ValidationError {
+	return validateStruct(r, "Overdraft limit")
+}
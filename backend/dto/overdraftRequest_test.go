@@ -0,0 +1,64 @@
+package dto
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestOverdraftLimitRequest_Validate_returns_nil_when_limit_valid(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name  string
+		limit float64
+	}{
+		{"zero", 0},
+		{"in range", 500},
+		{"upper boundary", OverdraftMaxLimitAllowed},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := OverdraftLimitRequest{CustomerId: dummyCustomerId, AccountId: dummyAccountId, Limit: tc.limit}
+
+			//Act
+			err := request.Validate()
+
+			//Assert
+			if err != nil {
+				t.Errorf("expected no error but got error while testing valid overdraft limit %v: %s", tc.limit, err.Message)
+			}
+		})
+	}
+}
+
+func TestOverdraftLimitRequest_Validate_returns_validationError_when_limit_invalid(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name               string
+		limit              float64
+		expectedErrMessage string
+	}{
+		{"negative", -1, "Overdraft limit must be at least 0."},
+		{"above upper boundary", OverdraftMaxLimitAllowed + 0.01, "Overdraft limit must be at most 10000."},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := OverdraftLimitRequest{CustomerId: dummyCustomerId, AccountId: dummyAccountId, Limit: tc.limit}
+
+			//Act
+			err := request.Validate()
+
+			//Assert
+			if err == nil {
+				t.Fatal("expected error but got none while testing invalid overdraft limit")
+			}
+			if err.Code != http.StatusUnprocessableEntity {
+				t.Errorf("expected status code %d but got %d", http.StatusUnprocessableEntity, err.Code)
+			}
+			if err.Message != tc.expectedErrMessage {
+				t.Errorf("expected error message \"%s\" but got \"%s\"", tc.expectedErrMessage, err.Message)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package dto
+
+type OverdraftResponse struct {
+	AccountId          string  `json:"account_id"`
+	OverdraftLimit     float64 `json:"overdraft_limit"`
+	OverdrawnAmount    float64 `json:"overdrawn_amount"`    //how far the ledger balance is below zero
+	AvailableOverdraft float64 `json:"available_overdraft"` //what is left of the limit after the overdrawn amount and the funds on hold
+	OverdrawnSince     string  `json:"overdrawn_since,omitempty"`
+	FeeCharged         float64 `json:"fee_charged"`      //since the account was last overdrawn
+	InterestCharged    float64 `json:"interest_charged"` //since the account was last overdrawn
+	Fee                float64 `json:"fee"`
+	InterestRate       float64 `json:"interest_rate"` //yearly, in percent
+}
+
+// OverdraftInterestReport is the outcome of a run of the daily overdraft interest job.
+type OverdraftInterestReport struct {
+	RunOn            string  `json:"run_on"`
+	AccountsCharged  int     `json:"accounts_charged"`
+	InterestCharged  float64 `json:"interest_charged"`
+	OverdraftsClosed int     `json:"overdrafts_closed"` //of accounts no longer overdrawn
+}
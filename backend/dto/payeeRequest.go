@@ -0,0 +1,29 @@
+package dto
+
+const PayeeMaxNicknameLength = 50
+const PayeeMaxOwnerNameLength = 100
+
+type NewPayeeRequest struct {
+	CustomerId string `json:"-"` //taken from the request path
+	Nickname   string `json:"nickname" validate:"required,max=50,printascii"`
+	AccountId  string `json:"account_id" validate:"required,max=11,number"` //the account that transfers to the payee go to
+	OwnerName  string `json:"owner_name" validate:"omitempty,max=100"`      //checked against the owner of the account if given
+	CreatedBy  string `json:"-"`                                            //the user adding the payee, from their access token
+}
+
+func (r NewPayeeRequest) Validate() *ValidationError {
+	return validateStruct(r, "New payee")
+}
+
+// PayeeNicknameRequest asks for a payee of a customer to be renamed. The account of a payee cannot be changed: a new
+// payee has to be added instead.
+type PayeeNicknameRequest struct {
+	CustomerId string `json:"-"` //taken from the request path
+	PayeeId    string `json:"-"` //taken from the request path
+	Nickname   string `json:"nickname" validate:"required,max=50,printascii"`
+	ChangedBy  string `json:"-"` //the user renaming the payee, from their access token
+}
+
+func (r PayeeNicknameRequest) Validate() *ValidationError {
+	return validateStruct(r, "Payee nickname")
+}
@@ -0,0 +1,98 @@
+package dto
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// getDefaultValidNewPayeeRequest returns a NewPayeeRequest of the customer with id 2 to add the account with id 95471
+// of Jane Doe as "Jane"
+func getDefaultValidNewPayeeRequest() NewPayeeRequest {
+	return NewPayeeRequest{
+		CustomerId: dummyCustomerId,
+		Nickname:   "Jane",
+		AccountId:  "95471",
+		OwnerName:  "Jane Doe",
+	}
+}
+
+func TestNewPayeeRequest_Validate_returns_nil_when_request_valid(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name      string
+		nickname  string
+		ownerName string
+	}{
+		{"with owner name", "Jane", "Jane Doe"},
+		{"without owner name", "Jane", ""},
+		{"longest nickname", strings.Repeat("a", PayeeMaxNicknameLength), ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := getDefaultValidNewPayeeRequest()
+			request.Nickname = tc.nickname
+			request.OwnerName = tc.ownerName
+
+			//Act
+			err := request.Validate()
+
+			//Assert
+			if err != nil {
+				t.Errorf("Expected no error but got error while testing valid new payee request: %s", err.Message)
+			}
+		})
+	}
+}
+
+func TestNewPayeeRequest_Validate_returns_validationError_when_request_invalid(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name            string
+		modify          func(*NewPayeeRequest)
+		expectedMessage string
+	}{
+		{"missing nickname", func(r *NewPayeeRequest) { r.Nickname = "" },
+			"Nickname must be present."},
+		{"account id not a number", func(r *NewPayeeRequest) { r.AccountId = "abc" },
+			"Account ID must be a number."},
+		{"owner name too long", func(r *NewPayeeRequest) { r.OwnerName = strings.Repeat("a", PayeeMaxOwnerNameLength+1) },
+			"Owner name should be at most 100 characters long."},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := getDefaultValidNewPayeeRequest()
+			tc.modify(&request)
+
+			//Act
+			err := request.Validate()
+
+			//Assert
+			if err == nil {
+				t.Fatal("Expected error but got none while testing invalid new payee request")
+			}
+			if err.Code != http.StatusUnprocessableEntity || err.Message != tc.expectedMessage {
+				t.Errorf("Expected status code %d and message \"%s\" but got %d and \"%s\"",
+					http.StatusUnprocessableEntity, tc.expectedMessage, err.Code, err.Message)
+			}
+		})
+	}
+}
+
+func TestPayeeNicknameRequest_Validate_returns_validationError_when_nickname_missing(t *testing.T) {
+	//Arrange
+	request := PayeeNicknameRequest{CustomerId: dummyCustomerId, PayeeId: "1"}
+
+	//Act
+	err := request.Validate()
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing payee nickname request without nickname")
+	}
+	if err.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status code %d but got %d", http.StatusUnprocessableEntity, err.Code)
+	}
+}
@@ -0,0 +1,25 @@
+package dto
+
+const PayeeStatusPendingConfirmation = "pending_confirmation" //until the customer confirms it on the first transfer to it
+const PayeeStatusActive = "active"
+
+type PayeeResponse struct {
+	PayeeId         string `json:"payee_id"`
+	Nickname        string `json:"nickname"`
+	AccountId       string `json:"account_id"`
+	OwnerName       string `json:"owner_name,omitempty"` //only set if it was verified against the owner of the account
+	Status          string `json:"status"`
+	CreatedOn       string `json:"created_on"`
+	ConfirmedOn     string `json:"confirmed_on,omitempty"`
+	CoolingOffUntil string `json:"cooling_off_until,omitempty"` //until when large transfers to the payee are refused, while it is new
+}
+
+type AuditEntryResponse struct {
+	AuditId    string `json:"audit_id"`
+	EntityType string `json:"entity_type"`
+	EntityId   string `json:"entity_id"`
+	Action     string `json:"action"`
+	Details    string `json:"details,omitempty"`
+	ChangedBy  string `json:"changed_by"`
+	ChangedOn  string `json:"changed_on"`
+}
@@ -0,0 +1,21 @@
+package dto
+
+// ProblemResponse is an error as problem details (RFC 7807), sent instead of {"message": ...} to clients that accept
+// application/problem+json.
+type ProblemResponse struct {
+	Type      string       `json:"type"` //description of the type of the error, under /problems
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail"` //same as the message
+	Instance  string       `json:"instance"`
+	Code      string       `json:"code"`
+	RequestId string       `json:"request_id"`
+	Errors    []FieldError `json:"errors,omitempty"` //every field in error if the request failed validation
+}
+
+type ProblemTypeResponse struct {
+	Type   string `json:"type"`
+	Code   string `json:"code"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+}
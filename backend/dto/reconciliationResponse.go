@@ -0,0 +1,24 @@
+package dto
+
+type ReconciliationReportResponse struct {
+	RunOn           string                               `json:"run_on"`
+	AccountsChecked int                                  `json:"accounts_checked"`
+	Mismatches      []ReconciliationMismatchResponse     `json:"mismatches"`
+	Unreconciled    []ReconciliationUnreconciledResponse `json:"unreconciled"` //accounts without an opening amount
+}
+
+type ReconciliationMismatchResponse struct {
+	AccountId       string  `json:"account_id"`
+	CustomerId      string  `json:"customer_id"`
+	StoredBalance   float64 `json:"stored_balance"`
+	ExpectedBalance float64 `json:"expected_balance"`
+	Delta           float64 `json:"delta"`
+	IsFrozen        bool    `json:"is_frozen"`
+}
+
+type ReconciliationUnreconciledResponse struct {
+	AccountId     string  `json:"account_id"`
+	CustomerId    string  `json:"customer_id"`
+	StoredBalance float64 `json:"stored_balance"`
+	IsFrozen      bool    `json:"is_frozen"`
+}
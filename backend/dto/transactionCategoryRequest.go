@@ -0,0 +1,14 @@
+package dto
+
+// TransactionCategoryRequest asks for a posted transaction on an account of a customer to be filed under another
+// category.
+type TransactionCategoryRequest struct {
+	CustomerId    string `json:"-"` //taken from the request path
+	AccountId     string `json:"-"` //taken from the request path
+	TransactionId string `json:"-"` //taken from the request path
+	Category      string `json:"category" validate:"required,oneof=groceries dining transport shopping bills entertainment health travel income transfers other"`
+}
+
+func (r TransactionCategoryRequest) Validate() *ValidationError {
+	return validateStruct(r, "Transaction category")
+}
@@ -0,0 +1,49 @@
+package dto
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestTransactionCategoryRequest_Validate_returns_validationError_when_category_invalid(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name     string
+		category string
+	}{
+		{"no category", ""},
+		{"unknown category", "gambling"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := TransactionCategoryRequest{CustomerId: dummyCustomerId, AccountId: dummyAccountId, TransactionId: "7791",
+				Category: tc.category}
+
+			//Act
+			err := request.Validate()
+
+			//Assert
+			if err == nil {
+				t.Fatal("expected error but got none while testing invalid category")
+			}
+			if err.Code != http.StatusUnprocessableEntity {
+				t.Errorf("expected status code %d but got %d", http.StatusUnprocessableEntity, err.Code)
+			}
+		})
+	}
+}
+
+func TestTransactionCategoryRequest_Validate_returns_nil_when_category_valid(t *testing.T) {
+	//Arrange
+	request := TransactionCategoryRequest{CustomerId: dummyCustomerId, AccountId: dummyAccountId, TransactionId: "7791",
+		Category: TransactionCategoryGroceries}
+
+	//Act
+	err := request.Validate()
+
+	//Assert
+	if err != nil {
+		t.Errorf("expected no error but got error while testing valid category: %s", err.Message)
+	}
+}
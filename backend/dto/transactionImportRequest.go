@@ -0,0 +1,34 @@
+package dto
+
+import (
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/logger"
+)
+
+const TransactionImportModeDryRun = "dry_run"
+const TransactionImportModeCommit = "commit"
+const TransactionImportMaxRows = 1000
+const TransactionImportMaxReferenceLength = 50
+
+// TransactionImportHeader is the header row expected as the first line of every uploaded CSV file.
+var TransactionImportHeader = []string{"account_id", "amount", "type", "reference"}
+
+type TransactionImportRequest struct {
+	Mode        string
+	FileContent []byte
+}
+
+func (r TransactionImportRequest) Validate() *ValidationError {
+	var valErr *ValidationError
+	if r.Mode != TransactionImportModeDryRun && r.Mode != TransactionImportModeCommit {
+		logger.Error(fmt.Sprintf("Transaction import request is invalid (unknown mode %s)", r.Mode))
+		valErr = valErr.with(FieldError{Field: "mode", Rule: "oneof",
+			Message: fmt.Sprintf("Import mode should be %s or %s.", TransactionImportModeDryRun, TransactionImportModeCommit)})
+	}
+	if len(r.FileContent) == 0 {
+		logger.Error("Transaction import request is invalid (empty file)")
+		valErr = valErr.with(FieldError{Field: "file", Rule: "required", Message: "Please upload a non-empty CSV file."})
+	}
+
+	return valErr
+}
@@ -0,0 +1,64 @@
+package dto
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestTransactionImportRequest_Validate_returns_nil_when_mode_and_file_valid(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name string
+		mode string
+	}{
+		{"dry run", TransactionImportModeDryRun},
+		{"commit", TransactionImportModeCommit},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := TransactionImportRequest{Mode: tc.mode, FileContent: []byte("account_id,amount,type,reference")}
+
+			//Act
+			err := request.Validate()
+
+			//Assert
+			if err != nil {
+				t.Errorf("expected no error but got error while testing valid import mode %s: %s", tc.mode, err.Message)
+			}
+		})
+	}
+}
+
+func TestTransactionImportRequest_Validate_returns_error_when_mode_or_file_invalid(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name               string
+		request            TransactionImportRequest
+		expectedErrMessage string
+	}{
+		{"unknown mode", TransactionImportRequest{Mode: "some mode", FileContent: []byte("a")},
+			"Import mode should be dry_run or commit."},
+		{"empty file", TransactionImportRequest{Mode: TransactionImportModeCommit},
+			"Please upload a non-empty CSV file."},
+	}
+	expectedCode := http.StatusUnprocessableEntity
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Act
+			actualErr := tc.request.Validate()
+
+			//Assert
+			if actualErr == nil {
+				t.Fatal("expected error but got none while testing invalid import request")
+			}
+			if actualErr.Message != tc.expectedErrMessage {
+				t.Errorf("expected message: \"%s\", actual message: \"%s\"", tc.expectedErrMessage, actualErr.Message)
+			}
+			if actualErr.Code != expectedCode {
+				t.Errorf("expected status code: \"%d\", actual status code: \"%d\"", expectedCode, actualErr.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package dto
+
+const TransactionImportRowStatusValid = "valid"
+const TransactionImportRowStatusInvalid = "invalid"
+const TransactionImportRowStatusPosted = "posted"
+
+type TransactionImportResponse struct {
+	ImportId    string                         `json:"import_id,omitempty"`
+	FileHash    string                         `json:"file_hash"`
+	Mode        string                         `json:"mode"`
+	IsDuplicate bool                           `json:"is_duplicate"`
+	IsCommitted bool                           `json:"is_committed"`
+	TotalRows   int                            `json:"total_rows"`
+	InvalidRows int                            `json:"invalid_rows"`
+	Rows        []TransactionImportRowResponse `json:"rows"`
+}
+
+type TransactionImportRowResponse struct {
+	Row             int     `json:"row"`
+	AccountId       string  `json:"account_id"`
+	Amount          float64 `json:"amount"`
+	TransactionType string  `json:"transaction_type"`
+	Reference       string  `json:"reference"`
+	Status          string  `json:"status"`
+	Message         string  `json:"message,omitempty"`
+	TransactionId   string  `json:"transaction_id,omitempty"`
+}
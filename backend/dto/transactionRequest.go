@@ -1,36 +1,41 @@
 package dto
 
-import (
-	"fmt"
-	"github.com/aliciatay-zls/banking-lib/errs"
-	"github.com/aliciatay-zls/banking-lib/formValidator"
-	"github.com/aliciatay-zls/banking-lib/logger"
-)
-
 const TransactionTypeWithdrawal = "withdrawal"
 const TransactionTypeDeposit = "deposit"
+const TransactionTypeFee = "fee"           //charged by the bank, never requested by customers
+const TransactionTypeInterest = "interest" //charged by the bank on an overdrawn balance, never requested by customers
 const TransactionMinAmountAllowed float64 = 0
 const TransactionMaxAmountAllowed float64 = 10000
+const TransactionMaxDescriptionLength = 140
+const TransactionMaxReferenceLength = 35
+
+// The categories that customers can file their transactions under. A transaction without one is uncategorized.
+const TransactionCategoryGroceries = "groceries"
+const TransactionCategoryDining = "dining"
+const TransactionCategoryTransport = "transport"
+const TransactionCategoryShopping = "shopping"
+const TransactionCategoryBills = "bills"
+const TransactionCategoryEntertainment = "entertainment"
+const TransactionCategoryHealth = "health"
+const TransactionCategoryTravel = "travel"
+const TransactionCategoryIncome = "income"
+const TransactionCategoryTransfers = "transfers"
+const TransactionCategoryOther = "other"
+
+var TransactionCategories = []string{TransactionCategoryGroceries, TransactionCategoryDining, TransactionCategoryTransport,
+	TransactionCategoryShopping, TransactionCategoryBills, TransactionCategoryEntertainment, TransactionCategoryHealth,
+	TransactionCategoryTravel, TransactionCategoryIncome, TransactionCategoryTransfers, TransactionCategoryOther}
 
 type TransactionRequest struct {
 	AccountId       string  `json:"account_id" validate:"required,max=11,number"`
 	Amount          float64 `json:"amount" validate:"number,gte=0,lte=10000"`
-	TransactionType string  `json:"transaction_type" validate:"required,alpha,oneof=withdrawal deposit"`
+	TransactionType string  `json:"transaction_type" validate:"required,oneof=withdrawal deposit"`
 	CustomerId      string  `json:"customer_id" validate:"required,max=11,number"`
+	Description     string  `json:"description" validate:"omitempty,max=140,printascii"`
+	Reference       string  `json:"reference" validate:"omitempty,max=35,printascii"`
+	Category        string  `json:"category" validate:"omitempty,oneof=groceries dining transport shopping bills entertainment health travel income transfers other"`
 }
 
-func (r TransactionRequest) Validate() *errs.AppError {
-	errMsg := map[string]string{
-		"AccountId":       "Account ID must be present and a number.",
-		"Amount":          fmt.Sprintf("Please check that the transaction amount is valid."),
-		"TransactionType": fmt.Sprintf("Transaction type should be %s or %s.", TransactionTypeWithdrawal, TransactionTypeDeposit),
-		"CustomerId":      "Customer ID must be present and a number.",
-	}
-	if errsArr := formValidator.Struct(r); errsArr != nil {
-		logger.Error(fmt.Sprintf("Transaction request is invalid (%s) (%s)",
-			errsArr[0].Error(), errsArr[0].ActualTag()))
-		return errs.NewValidationError(errMsg[errsArr[0].Field()])
-	}
-
-	return nil
+func (r TransactionRequest) Validate() *ValidationError {
+	return validateStruct(r, "Transaction")
 }
@@ -4,6 +4,7 @@ import (
 	"github.com/aliciatay-zls/banking-lib/formValidator"
 	"github.com/aliciatay-zls/banking-lib/logger"
 	"net/http"
+	"strings"
 	"testing"
 )
 
@@ -60,15 +61,15 @@ func TestTransactionRequest_Validate_returns_nil_when_amount_valid(t *testing.T)
 func TestTransactionRequest_Validate_returns_error_when_amount_invalid(t *testing.T) {
 	//Arrange
 	tests := []struct {
-		name   string
-		amount float64
+		name               string
+		amount             float64
+		expectedErrMessage string
 	}{
-		{"below lower boundary", -1},
-		{"above upper boundary", 10000.10},
+		{"below lower boundary", -1, "Amount must be at least 0."},
+		{"above upper boundary", 10000.10, "Amount must be at most 10000."},
 	}
 	request := getDefaultValidTransactionRequest()
 
-	expectedErrMessage := "Please check that the transaction amount is valid."
 	expectedCode := http.StatusUnprocessableEntity
 
 	for _, tc := range tests {
@@ -82,8 +83,8 @@ func TestTransactionRequest_Validate_returns_error_when_amount_invalid(t *testin
 			if actualErr == nil {
 				t.Fatal("expected error but got none while testing transaction amount")
 			}
-			if actualErr.Message != expectedErrMessage {
-				t.Errorf("expected message: \"%s\", actual message: \"%s\"", expectedErrMessage, actualErr.Message)
+			if actualErr.Message != tc.expectedErrMessage {
+				t.Errorf("expected message: \"%s\", actual message: \"%s\"", tc.expectedErrMessage, actualErr.Message)
 			}
 			if actualErr.Code != expectedCode {
 				t.Errorf("expected status code: \"%d\", actual status code: \"%d\"", expectedCode, actualErr.Code)
@@ -98,14 +99,14 @@ func TestTransactionRequest_Validate_returns_error_when_transactionType_invalid_
 	req1.TransactionType = "some transaction type"
 	req2 := TransactionRequest{AccountId: dummyAccountId, Amount: dummyAmount, CustomerId: dummyCustomerId}
 	tests := []struct {
-		name    string
-		request TransactionRequest
+		name               string
+		request            TransactionRequest
+		expectedErrMessage string
 	}{
-		{"type is invalid", req1},
-		{"type is empty", req2},
+		{"type is invalid", req1, "Transaction type should be withdrawal or deposit."},
+		{"type is empty", req2, "Transaction type must be present."},
 	}
 
-	expectedErrMessage := "Transaction type should be withdrawal or deposit."
 	expectedCode := http.StatusUnprocessableEntity
 
 	for _, tc := range tests {
@@ -116,11 +117,65 @@ func TestTransactionRequest_Validate_returns_error_when_transactionType_invalid_
 		if actualErr == nil {
 			t.Fatal("expected error but got none while testing transaction type")
 		}
-		if actualErr.Message != expectedErrMessage {
-			t.Errorf("expected message: \"%s\", actual message: \"%s\"", expectedErrMessage, actualErr.Message)
+		if actualErr.Message != tc.expectedErrMessage {
+			t.Errorf("expected message: \"%s\", actual message: \"%s\"", tc.expectedErrMessage, actualErr.Message)
 		}
 		if actualErr.Code != expectedCode {
 			t.Errorf("expected status code: \"%d\", actual status code: \"%d\"", expectedCode, actualErr.Code)
 		}
 	}
 }
+
+func TestTransactionRequest_Validate_returns_nil_when_details_valid(t *testing.T) {
+	//Arrange
+	request := getDefaultValidTransactionRequest()
+	request.Description = strings.Repeat("a", TransactionMaxDescriptionLength)
+	request.Reference = "INV-2006/01 #7"
+	request.Category = TransactionCategoryIncome
+
+	//Act
+	err := request.Validate()
+
+	//Assert
+	if err != nil {
+		t.Errorf("expected no error but got error while testing valid transaction details: %s", err.Message)
+	}
+}
+
+func TestTransactionRequest_Validate_returns_error_when_details_invalid(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name            string
+		modify          func(*TransactionRequest)
+		expectedMessage string
+	}{
+		{"description too long", func(r *TransactionRequest) { r.Description = strings.Repeat("a", 141) },
+			"Description should be at most 140 characters long."},
+		{"description with control character", func(r *TransactionRequest) { r.Description = "Rent\nJanuary" },
+			"Description must only contain printable ASCII characters."},
+		{"reference too long", func(r *TransactionRequest) { r.Reference = strings.Repeat("1", 36) },
+			"Reference should be at most 35 characters long."},
+		{"reference not ascii", func(r *TransactionRequest) { r.Reference = "RÉF-1" },
+			"Reference must only contain printable ASCII characters."},
+		{"unknown category", func(r *TransactionRequest) { r.Category = "gambling" },
+			"Category should be groceries, dining, transport, shopping, bills, entertainment, health, travel, income, transfers or other."},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := getDefaultValidTransactionRequest()
+			tc.modify(&request)
+
+			//Act
+			err := request.Validate()
+
+			//Assert
+			if err == nil {
+				t.Fatal("expected error but got none while testing invalid transaction details")
+			}
+			if err.Message != tc.expectedMessage {
+				t.Errorf("expected error message \"%s\" but got \"%s\"", tc.expectedMessage, err.Message)
+			}
+		})
+	}
+}
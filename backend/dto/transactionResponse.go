@@ -1,7 +1,35 @@
 package dto
 
+// The states of a transaction.
+const TransactionStatusPosted = "posted"
+const TransactionStatusPendingReview = "pending_review" //held for an admin to approve or reject, withdrawn funds are reserved
+const TransactionStatusRejected = "rejected"
+const TransactionStatusReversed = "reversed" //posted, then undone by a compensating transaction
+
 type TransactionResponse struct {
-	TransactionId   string  `json:"transaction_id"`
+	TransactionId   string  `json:"transaction_id,omitempty"` //not set while the transaction is pending review
+	ReviewId        string  `json:"review_id,omitempty"`      //only set while the transaction is pending review
+	Status          string  `json:"status"`
 	Balance         float64 `json:"new_balance"`
 	TransactionDate string  `json:"transaction_date"`
+	Description     string  `json:"description,omitempty"`
+	Reference       string  `json:"reference,omitempty"`
+	Category        string  `json:"category,omitempty"` //not set while uncategorized
+}
+
+// AccountTransactionResponse is an entry in the transaction list of an account: a posted transaction or a transaction
+// that was held for review and is pending or was rejected.
+type AccountTransactionResponse struct {
+	TransactionId        string  `json:"transaction_id,omitempty"`
+	ReviewId             string  `json:"review_id,omitempty"`
+	TransactionType      string  `json:"transaction_type"`
+	Amount               float64 `json:"amount"`
+	TransactionDate      string  `json:"transaction_date"`
+	Status               string  `json:"status"`
+	Description          string  `json:"description,omitempty"`
+	Reference            string  `json:"reference,omitempty"`
+	Category             string  `json:"category,omitempty"`               //not set while uncategorized
+	RelatedTransactionId string  `json:"related_transaction_id,omitempty"` //the transaction that a fee was charged for or a reversal undoes
+	//the compensating transaction that undid the transaction, only set once it is reversed
+	ReversedByTransactionId string `json:"reversed_by_transaction_id,omitempty"`
 }
@@ -0,0 +1,24 @@
+package dto
+
+// TransactionReversalRequest asks for a posted transaction to be undone by a compensating transaction. A reversal
+// that would take the balance of the account below zero is refused unless Force is set.
+type TransactionReversalRequest struct {
+	TransactionId string `json:"-"` //taken from the request path
+	Reason        string `json:"reason" validate:"required,max=255"`
+	Force         bool   `json:"force"`
+	ReversedBy    string `json:"-"` //the admin making the request
+}
+
+func (r TransactionReversalRequest) Validate() *ValidationError {
+	return validateStruct(r, "Transaction reversal")
+}
+
+// TransactionReversalApproval is a reversal waiting for the approval of a second admin, as kept in the approval
+// request. It has the fields of TransactionReversalRequest, so that either converts to the other, but also keeps those
+// that are not read from the body of the request.
+type TransactionReversalApproval struct {
+	TransactionId string `json:"transaction_id"`
+	Reason        string `json:"reason"`
+	Force         bool   `json:"force"`
+	ReversedBy    string `json:"reversed_by"`
+}
@@ -0,0 +1,48 @@
+package dto
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestTransactionReversalRequest_Validate_returns_nil_when_request_valid(t *testing.T) {
+	//Arrange
+	request := TransactionReversalRequest{TransactionId: "7791", Reason: strings.Repeat("a", 255), Force: true, ReversedBy: "admin"}
+
+	//Act
+	err := request.Validate()
+
+	//Assert
+	if err != nil {
+		t.Errorf("expected no error but got error while testing valid reversal: %s", err.Message)
+	}
+}
+
+func TestTransactionReversalRequest_Validate_returns_validationError_when_reason_invalid(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name   string
+		reason string
+	}{
+		{"no reason", ""},
+		{"reason too long", strings.Repeat("a", 256)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := TransactionReversalRequest{TransactionId: "7791", Reason: tc.reason, ReversedBy: "admin"}
+
+			//Act
+			err := request.Validate()
+
+			//Assert
+			if err == nil {
+				t.Fatal("expected error but got none while testing invalid reversal")
+			}
+			if err.Code != http.StatusUnprocessableEntity {
+				t.Errorf("expected status code %d but got %d", http.StatusUnprocessableEntity, err.Code)
+			}
+		})
+	}
+}
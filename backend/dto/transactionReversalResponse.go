@@ -0,0 +1,14 @@
+package dto
+
+type TransactionReversalResponse struct {
+	ReversalId            string  `json:"reversal_id"`
+	TransactionId         string  `json:"transaction_id"`          //the transaction that was reversed
+	ReversalTransactionId string  `json:"reversal_transaction_id"` //the compensating transaction
+	TransactionType       string  `json:"transaction_type"`        //of the compensating transaction
+	Amount                float64 `json:"amount"`
+	Balance               float64 `json:"new_balance"`
+	Reason                string  `json:"reason"`
+	Forced                bool    `json:"forced"`
+	ReversedBy            string  `json:"reversed_by"`
+	ReversedOn            string  `json:"reversed_on"`
+}
@@ -0,0 +1,24 @@
+package dto
+
+import (
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"reflect"
+	"strings"
+)
+
+// TransactionReviewRequest approves or rejects a transaction held for review.
+type TransactionReviewRequest struct {
+	ReviewId string `json:"review_id" validate:"required,max=11,number"`
+	Comment  string `json:"comment" validate:"required,max=255"`
+}
+
+func (r TransactionReviewRequest) Validate() *ValidationError {
+	valErr := validateStruct(r, "Transaction review")
+	if strings.TrimSpace(r.Comment) == "" && !valErr.hasField("comment") { //blank, which the required rule lets through
+		logger.Error("Transaction review request is invalid (comment is blank)")
+		return valErr.with(FieldError{Field: "comment", Rule: "required",
+			Message: ruleMessage(fieldLabel("comment"), "required", "", reflect.String)})
+	}
+
+	return valErr
+}
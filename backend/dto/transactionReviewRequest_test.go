@@ -0,0 +1,49 @@
+package dto
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestTransactionReviewRequest_Validate_returns_nil_when_request_valid(t *testing.T) {
+	//Arrange
+	request := TransactionReviewRequest{ReviewId: "5", Comment: strings.Repeat("a", 255)}
+
+	//Act
+	err := request.Validate()
+
+	//Assert
+	if err != nil {
+		t.Errorf("Expected no error but got error while testing valid review request: %s", err.Message)
+	}
+}
+
+func TestTransactionReviewRequest_Validate_returns_validationError_when_comment_invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		comment string
+	}{
+		{"missing", ""},
+		{"blank", "   "},
+		{"too long", strings.Repeat("a", 256)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Arrange
+			request := TransactionReviewRequest{ReviewId: "5", Comment: tc.comment}
+
+			//Act
+			err := request.Validate()
+
+			//Assert
+			if err == nil {
+				t.Fatal("Expected error but got none while testing invalid review comment")
+			}
+			if err.Code != http.StatusUnprocessableEntity {
+				t.Errorf("Expected status code %d but got %d", http.StatusUnprocessableEntity, err.Code)
+			}
+		})
+	}
+}
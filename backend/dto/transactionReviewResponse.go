@@ -0,0 +1,13 @@
+package dto
+
+type TransactionReviewResponse struct {
+	ReviewId        string  `json:"review_id"`
+	AccountId       string  `json:"account_id"`
+	TransactionType string  `json:"transaction_type"`
+	Amount          float64 `json:"amount"`
+	Reasons         string  `json:"reasons"` //why the fraud rules held the transaction
+	Status          string  `json:"status"`
+	RequestedOn     string  `json:"requested_on"`
+	ReviewedOn      string  `json:"reviewed_on,omitempty"`
+	Comment         string  `json:"comment,omitempty"`
+}
@@ -0,0 +1,17 @@
+package dto
+
+// TransferRequest asks for money to be sent from an account of a customer to the account of one of their payees.
+type TransferRequest struct {
+	CustomerId   string  `json:"-"` //taken from the request path
+	AccountId    string  `json:"-"` //taken from the request path
+	PayeeId      string  `json:"payee_id" validate:"required,max=11,number"`
+	Amount       float64 `json:"amount" validate:"number,gt=0,lte=10000"`
+	Description  string  `json:"description" validate:"omitempty,max=140,printascii"`
+	Reference    string  `json:"reference" validate:"omitempty,max=35,printascii"`
+	ConfirmPayee bool    `json:"confirm_payee"` //must be set for the first transfer to a payee
+	RequestedBy  string  `json:"-"`             //the user sending the transfer, from their access token
+}
+
+func (r TransferRequest) Validate() *ValidationError {
+	return validateStruct(r, "Transfer")
+}
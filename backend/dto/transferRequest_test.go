@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestTransferRequest_Validate_returns_nil_when_request_valid(t *testing.T) {
+	//Arrange
+	request := TransferRequest{CustomerId: dummyCustomerId, AccountId: "95470", PayeeId: "1", Amount: TransactionMaxAmountAllowed,
+		Description: "Dinner split", Reference: "INV-0042", ConfirmPayee: true}
+
+	//Act
+	err := request.Validate()
+
+	//Assert
+	if err != nil {
+		t.Errorf("Expected no error but got error while testing valid transfer request: %s", err.Message)
+	}
+}
+
+func TestTransferRequest_Validate_returns_validationError_when_request_invalid(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name            string
+		payeeId         string
+		amount          float64
+		expectedMessage string
+	}{
+		{"missing payee", "", 100, "Payee ID must be present."},
+		{"zero amount", "1", 0, "Amount must be greater than 0."},
+		{"amount above maximum", "1", TransactionMaxAmountAllowed + 1, "Amount must be at most 10000."},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := TransferRequest{CustomerId: dummyCustomerId, AccountId: "95470", PayeeId: tc.payeeId, Amount: tc.amount}
+
+			//Act
+			err := request.Validate()
+
+			//Assert
+			if err == nil {
+				t.Fatal("Expected error but got none while testing invalid transfer request")
+			}
+			if err.Code != http.StatusUnprocessableEntity || err.Message != tc.expectedMessage {
+				t.Errorf("Expected status code %d and message \"%s\" but got %d and \"%s\"",
+					http.StatusUnprocessableEntity, tc.expectedMessage, err.Code, err.Message)
+			}
+		})
+	}
+}
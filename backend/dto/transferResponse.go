@@ -0,0 +1,10 @@
+package dto
+
+type TransferResponse struct {
+	TransactionId   string  `json:"transaction_id"` //the withdrawal from the account of the customer
+	PayeeId         string  `json:"payee_id"`
+	ToAccountId     string  `json:"to_account_id"`
+	Amount          float64 `json:"amount"`
+	Balance         float64 `json:"balance"` //of the account of the customer
+	TransactionDate string  `json:"transaction_date"`
+}
@@ -0,0 +1,230 @@
+package dto
+
+import (
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/formValidator"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/go-playground/validator/v10"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// FieldError is a field of a request that failed a validation rule.
+type FieldError struct {
+	Field   string `json:"field"` //path of the field in the JSON body, e.g. "amount" or "event_types[1]"
+	Rule    string `json:"rule"`  //validate rule that failed, e.g. "lte"
+	Message string `json:"message"`
+}
+
+// ValidationError is a request that failed validation. It is a 422 whose message is made of the messages of all the
+// fields in error, for clients that only show the message.
+type ValidationError struct {
+	*errs.AppError
+	Errors []FieldError
+}
+
+// ValidationErrorResponse is the body of a response to a request that failed validation.
+type ValidationErrorResponse struct {
+	Message string       `json:"message"`
+	Errors  []FieldError `json:"errors"`
+}
+
+// newValidationError returns a ValidationError for the given fields in error.
+func newValidationError(fieldErrors ...FieldError) *ValidationError {
+	messages := make([]string, 0, len(fieldErrors))
+	for _, fieldError := range fieldErrors {
+		messages = append(messages, fieldError.Message)
+	}
+	return &ValidationError{
+		AppError: errs.NewValidationError(strings.Join(messages, " ")),
+		Errors:   fieldErrors,
+	}
+}
+
+// with returns a ValidationError with the given fields in error added to those of e, which may be nil, for checks that
+// validate tags cannot express.
+func (e *ValidationError) with(fieldErrors ...FieldError) *ValidationError {
+	if e != nil {
+		fieldErrors = append(append([]FieldError{}, e.Errors...), fieldErrors...)
+	}
+	return newValidationError(fieldErrors...)
+}
+
+// hasField reports whether the field at the given path is in error.
+func (e *ValidationError) hasField(path string) bool {
+	if e == nil {
+		return false
+	}
+	for _, fieldError := range e.Errors {
+		if fieldError.Field == path {
+			return true
+		}
+	}
+	return false
+}
+
+// ruleMessages are the templates of the messages of the validate rules, given the label of the field and the
+// parameter of the rule, e.g. "Amount must be at most 10000.". Rules whose meaning depends on what the field holds,
+// such as max, are keyed by rule and kind. They are shared by all requests so that the same rule always reads the
+// same.
+var ruleMessages = map[string]string{
+	"required":   "%[1]s must be present.",
+	"number":     "%[1]s must be a number.",
+	"alpha":      "%[1]s must only contain letters.",
+	"printascii": "%[1]s must only contain printable ASCII characters.",
+	"url":        "%[1]s must be a valid http or https URL.",
+	"oneof":      "%[1]s should be %[2]s.",
+	"datetime":   "%[1]s should be given as %[2]s.",
+	"min:string": "%[1]s must be at least %[2]s characters long.",
+	"max:string": "%[1]s should be at most %[2]s characters long.",
+	"min:list":   "%[1]s must have at least %[2]s item(s).",
+	"max:list":   "%[1]s should have at most %[2]s item(s).",
+	"min:number": "%[1]s must be at least %[2]s.",
+	"max:number": "%[1]s must be at most %[2]s.",
+	"gt:number":  "%[1]s must be greater than %[2]s.",
+	"lt:number":  "%[1]s must be less than %[2]s.",
+}
+
+// boundRules are the rules whose parameter is a bound, with the rule whose message they share.
+var boundRules = map[string]string{"min": "min", "gte": "min", "max": "max", "lte": "max", "gt": "gt", "lt": "lt"}
+
+// fieldLabels are the labels of fields whose names do not read well in messages, by JSON name.
+var fieldLabels = map[string]string{
+	"from":           "Start month",
+	"to":             "End month",
+	"effective_from": "Effective date",
+	"rule_type":      "Alert type",
+	"threshold":      "Alert threshold",
+	"duration_hours": "Hold duration in hours",
+	"url":            "Webhook URL",
+	"secret":         "Webhook secret",
+}
+
+// labelWords are the words of field names that are not written as is in messages.
+var labelWords = map[string]string{"id": "ID", "url": "URL"}
+
+// datetimeLayouts are the datetime rule layouts as shown to users.
+var datetimeLayouts = map[string]string{"2006-01": "YYYY-MM", "2006-01-02": "YYYY-MM-DD"}
+
+// validateStruct validates the given request with its validate tags and returns a ValidationError with every field
+// in error, or nil if it is valid. The name of the request is used in the log.
+func validateStruct(request interface{}, name string) *ValidationError {
+	errsArr := formValidator.Struct(request)
+	if errsArr == nil {
+		return nil
+	}
+
+	fieldErrors := make([]FieldError, 0, len(errsArr))
+	logParts := make([]string, 0, len(errsArr))
+	for _, e := range errsArr {
+		fieldErrors = append(fieldErrors, newFieldError(reflect.TypeOf(request), e))
+		logParts = append(logParts, fmt.Sprintf("(%s) (%s)", e.Error(), e.ActualTag()))
+	}
+	logger.Error(fmt.Sprintf("%s request is invalid %s", name, strings.Join(logParts, " ")))
+	return newValidationError(fieldErrors...)
+}
+
+func newFieldError(requestType reflect.Type, e validator.FieldError) FieldError {
+	path := jsonPath(requestType, e.StructNamespace())
+	return FieldError{
+		Field:   path,
+		Rule:    e.Tag(),
+		Message: ruleMessage(fieldLabel(path), e.Tag(), e.Param(), e.Kind()),
+	}
+}
+
+// ruleMessage returns the message for a field with the given label that failed the given rule, e.g. "Amount must be
+// at most 10000." for the rule lte=10000 on a number.
+func ruleMessage(label string, rule string, param string, kind reflect.Kind) string {
+	key := rule
+	if boundRule, ok := boundRules[rule]; ok {
+		key = boundRule + ":" + kindGroup(kind)
+	}
+	template, ok := ruleMessages[key]
+	if !ok {
+		return label + " is invalid."
+	}
+
+	switch rule {
+	case "oneof":
+		param = joinChoices(strings.Fields(param))
+	case "datetime":
+		if layout, ok := datetimeLayouts[param]; ok {
+			param = layout
+		}
+	default:
+		if f, err := strconv.ParseFloat(param, 64); err == nil {
+			param = strconv.FormatFloat(f, 'f', -1, 64)
+		}
+	}
+	return fmt.Sprintf(template, label, param)
+}
+
+func kindGroup(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return "list"
+	default:
+		return "number"
+	}
+}
+
+// joinChoices joins the given choices as in "a, b or c".
+func joinChoices(choices []string) string {
+	if len(choices) < 2 {
+		return strings.Join(choices, "")
+	}
+	return strings.Join(choices[:len(choices)-1], ", ") + " or " + choices[len(choices)-1]
+}
+
+// jsonPath returns the path in the JSON body of the field with the given namespace in the given struct type, e.g.
+// "event_types[1]" for "NewWebhookSubscriptionRequest.EventTypes[1]".
+func jsonPath(t reflect.Type, namespace string) string {
+	segments := strings.Split(namespace, ".")[1:] //the first is the name of the struct
+	path := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		fieldName, index, _ := strings.Cut(segment, "[")
+		for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+			t = t.Elem()
+		}
+		field, ok := t.FieldByName(fieldName)
+		if !ok {
+			path = append(path, segment)
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			name = fieldName
+		}
+		if index != "" {
+			name += "[" + index
+		}
+		path = append(path, name)
+		t = field.Type
+	}
+	return strings.Join(path, ".")
+}
+
+// fieldLabel returns the label of the field at the given path in messages, e.g. "Customer ID" for "customer_id".
+func fieldLabel(path string) string {
+	name := path[strings.LastIndex(path, ".")+1:]
+	name, _, _ = strings.Cut(name, "[")
+	if label, ok := fieldLabels[name]; ok {
+		return label
+	}
+	words := strings.Split(name, "_")
+	for i, word := range words {
+		if label, ok := labelWords[word]; ok {
+			words[i] = label
+		}
+	}
+	label := strings.Join(words, " ")
+	if label == "" {
+		return label
+	}
+	return strings.ToUpper(label[:1]) + label[1:]
+}
@@ -0,0 +1,116 @@
+package dto
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestValidateStruct_returns_jsonPath_of_every_element_in_error(t *testing.T) {
+	//Arrange
+	request := getDefaultValidNewWebhookSubscriptionRequest()
+	request.EventTypes = []string{"AccountOpened", "AccountClosed", "Unknown"}
+	expectedFields := []string{"event_types[1]", "event_types[2]"}
+
+	//Act
+	err := request.Validate()
+
+	//Assert
+	if err == nil {
+		t.Fatal("expected error but got none while testing invalid event types")
+	}
+	actualFields := make([]string, 0, len(err.Errors))
+	for _, fieldError := range err.Errors {
+		actualFields = append(actualFields, fieldError.Field)
+	}
+	if !reflect.DeepEqual(actualFields, expectedFields) {
+		t.Errorf("expected fields %v but got %v", expectedFields, actualFields)
+	}
+}
+
+func TestValidateStruct_returns_messages_with_bounds_of_constants(t *testing.T) {
+	//Arrange
+	format := func(f float64) string { return strconv.FormatFloat(f, 'f', -1, 64) }
+	newAccount := getDefaultValidNewAccountRequest()
+	newAccount.Amount = NewAccountMaxAmountAllowed + 1
+	transaction := getDefaultValidTransactionRequest()
+	transaction.Amount = TransactionMaxAmountAllowed + 1
+	alertRule := getDefaultValidNewAlertRuleRequest()
+	alertRule.Threshold = AlertRuleMaxThresholdAllowed + 1
+	tests := []struct {
+		name            string
+		err             *ValidationError
+		expectedMessage string
+	}{
+		{"new account", newAccount.Validate(), "Amount must be at most " + format(NewAccountMaxAmountAllowed) + "."},
+		{"transaction", transaction.Validate(), "Amount must be at most " + format(TransactionMaxAmountAllowed) + "."},
+		{"alert rule", alertRule.Validate(), "Alert threshold must be at most " + format(AlertRuleMaxThresholdAllowed) + "."},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Assert
+			if tc.err == nil {
+				t.Fatal("expected error but got none while testing amount above the maximum")
+			}
+			if tc.err.Message != tc.expectedMessage {
+				t.Errorf("expected message \"%s\" but got \"%s\"", tc.expectedMessage, tc.err.Message)
+			}
+		})
+	}
+}
+
+func TestRuleMessage_returns_message_for_rule_and_kind(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		rule     string
+		param    string
+		kind     reflect.Kind
+		expected string
+	}{
+		{"lte", "10000.00", reflect.Float64, "Field must be at most 10000."},
+		{"max", "50", reflect.String, "Field should be at most 50 characters long."},
+		{"min", "1", reflect.Slice, "Field must have at least 1 item(s)."},
+		{"oneof", "a b c", reflect.String, "Field should be a, b or c."},
+		{"datetime", "2006-01-02", reflect.String, "Field should be given as YYYY-MM-DD."},
+		{"unknown", "", reflect.String, "Field is invalid."},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.rule, func(t *testing.T) {
+			//Act
+			actual := ruleMessage("Field", tc.rule, tc.param, tc.kind)
+
+			//Assert
+			if actual != tc.expected {
+				t.Errorf("expected message \"%s\" but got \"%s\"", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestValidationError_with_adds_fieldErrors_to_nil_or_existing_error(t *testing.T) {
+	//Arrange
+	first := FieldError{Field: "a", Rule: "required", Message: "A must be present."}
+	second := FieldError{Field: "b", Rule: "url", Message: "B must be a valid http or https URL."}
+	var valErr *ValidationError
+
+	//Act
+	valErr = valErr.with(first)
+	valErr = valErr.with(second)
+
+	//Assert
+	if valErr.Code != http.StatusUnprocessableEntity {
+		t.Errorf("expected status code %d but got %d", http.StatusUnprocessableEntity, valErr.Code)
+	}
+	if !reflect.DeepEqual(valErr.Errors, []FieldError{first, second}) {
+		t.Errorf("expected errors %v but got %v", []FieldError{first, second}, valErr.Errors)
+	}
+	if expected := first.Message + " " + second.Message; valErr.Message != expected {
+		t.Errorf("expected message \"%s\" but got \"%s\"", expected, valErr.Message)
+	}
+	if !valErr.hasField("b") || valErr.hasField("c") {
+		t.Error("expected only fields a and b to be in error")
+	}
+}
@@ -0,0 +1,29 @@
+package dto
+
+import (
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"net/url"
+	"reflect"
+)
+
+const WebhookSecretMinLength = 16
+
+type NewWebhookSubscriptionRequest struct {
+	Url        string   `json:"url" validate:"required,max=2048,url"`
+	EventTypes []string `json:"event_types" validate:"required,min=1,dive,oneof=AccountOpened TransactionPosted AccountStatusChanged"`
+	Secret     string   `json:"secret" validate:"required,min=16,max=255"`
+}
+
+func (r NewWebhookSubscriptionRequest) Validate() *ValidationError {
+	valErr := validateStruct(r, "New webhook subscription")
+	if valErr.hasField("url") {
+		return valErr
+	}
+	if u, _ := url.Parse(r.Url); u.Scheme != "http" && u.Scheme != "https" {
+		logger.Error("New webhook subscription request is invalid (url scheme " + u.Scheme + ")")
+		return valErr.with(FieldError{Field: "url", Rule: "url",
+			Message: ruleMessage(fieldLabel("url"), "url", "", reflect.String)})
+	}
+
+	return valErr
+}
@@ -0,0 +1,68 @@
+package dto
+
+import (
+	"net/http"
+	"testing"
+)
+
+func getDefaultValidNewWebhookSubscriptionRequest() NewWebhookSubscriptionRequest {
+	return NewWebhookSubscriptionRequest{
+		Url:        "https://partner.example.com/hooks",
+		EventTypes: []string{"AccountOpened", "TransactionPosted"},
+		Secret:     "0123456789abcdef",
+	}
+}
+
+func TestNewWebhookSubscriptionRequest_Validate_returns_nil_when_request_valid(t *testing.T) {
+	//Arrange
+	request := getDefaultValidNewWebhookSubscriptionRequest()
+
+	//Act
+	err := request.Validate()
+
+	//Assert
+	if err != nil {
+		t.Errorf("expected no error but got error: %s", err.Message)
+	}
+}
+
+func TestNewWebhookSubscriptionRequest_Validate_returns_error_when_field_invalid(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name               string
+		modify             func(*NewWebhookSubscriptionRequest)
+		expectedErrMessage string
+	}{
+		{"url empty", func(r *NewWebhookSubscriptionRequest) { r.Url = "" },
+			"Webhook URL must be present."},
+		{"url not http", func(r *NewWebhookSubscriptionRequest) { r.Url = "ftp://partner.example.com/hooks" },
+			"Webhook URL must be a valid http or https URL."},
+		{"no event types", func(r *NewWebhookSubscriptionRequest) { r.EventTypes = nil },
+			"Event types must be present."},
+		{"unknown event type", func(r *NewWebhookSubscriptionRequest) { r.EventTypes = []string{"AccountOpened", "AccountClosed"} },
+			"Event types should be AccountOpened, TransactionPosted or AccountStatusChanged."},
+		{"secret too short", func(r *NewWebhookSubscriptionRequest) { r.Secret = "short" },
+			"Webhook secret must be at least 16 characters long."},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := getDefaultValidNewWebhookSubscriptionRequest()
+			tc.modify(&request)
+
+			//Act
+			actualErr := request.Validate()
+
+			//Assert
+			if actualErr == nil {
+				t.Fatal("expected error but got none while testing invalid webhook subscription request")
+			}
+			if actualErr.Message != tc.expectedErrMessage {
+				t.Errorf("expected message: \"%s\", actual message: \"%s\"", tc.expectedErrMessage, actualErr.Message)
+			}
+			if actualErr.Code != http.StatusUnprocessableEntity {
+				t.Errorf("expected status code: \"%d\", actual status code: \"%d\"", http.StatusUnprocessableEntity, actualErr.Code)
+			}
+		})
+	}
+}
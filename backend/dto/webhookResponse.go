@@ -0,0 +1,30 @@
+package dto
+
+const WebhookDeliveryStatusPending = "pending"
+const WebhookDeliveryStatusSucceeded = "succeeded"
+const WebhookDeliveryStatusFailed = "failed"
+
+type WebhookSubscriptionResponse struct {
+	SubscriptionId string   `json:"subscription_id"`
+	Url            string   `json:"url"`
+	EventTypes     []string `json:"event_types"`
+	CreatedOn      string   `json:"created_on"`
+}
+
+type WebhookDeliveryResponse struct {
+	DeliveryId     string                           `json:"delivery_id"`
+	SubscriptionId string                           `json:"subscription_id"`
+	EventId        string                           `json:"event_id"`
+	EventType      string                           `json:"event_type"`
+	Status         string                           `json:"status"`
+	AttemptCount   int                              `json:"attempt_count"`
+	NextAttemptOn  string                           `json:"next_attempt_on,omitempty"`
+	Attempts       []WebhookDeliveryAttemptResponse `json:"attempts,omitempty"`
+}
+
+type WebhookDeliveryAttemptResponse struct {
+	AttemptedOn  string `json:"attempted_on"`
+	StatusCode   int    `json:"status_code"`
+	ErrorMessage string `json:"error_message,omitempty"`
+	IsSuccessful bool   `json:"is_successful"`
+}
@@ -4,10 +4,15 @@ import (
 	"github.com/aliciatay-zls/banking-lib/formValidator"
 	"github.com/aliciatay-zls/banking-lib/logger"
 	"github.com/aliciatay-zls/banking/backend/app"
+	"os"
 )
 
 func main() {
 	logger.Info("Starting the app...")
 	formValidator.Create()
+	if len(os.Args) > 1 {
+		app.RunCommand(os.Args[1:])
+		return
+	}
 	app.Start()
 }
@@ -0,0 +1,114 @@
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//go:embed mysql/*.sql postgres/*.sql sqlite/*.sql seeds/*.sql
+var files embed.FS
+
+// Migration is one numbered schema change, read from the pair of files <version>_<name>.up.sql and
+// <version>_<name>.down.sql in the directory of the database dialect.
+type Migration struct {
+	Version int
+	Name    string
+	UpSql   string
+	DownSql string
+}
+
+// dialects maps database driver names to the directory holding the migrations written for that database.
+var dialects = map[string]string{
+	"mysql":  "mysql",
+	"pgx":    "postgres",
+	"sqlite": "sqlite",
+}
+
+// DialectOf returns the migrations dialect for the given database driver name.
+func DialectOf(driverName string) (string, error) {
+	dialect, ok := dialects[driverName]
+	if !ok {
+		return "", fmt.Errorf("no migrations for database driver %s", driverName)
+	}
+	return dialect, nil
+}
+
+// Load returns all migrations for the given dialect (see DialectOf), sorted by version. It checks that versions start
+// at 1 without gaps and that every migration has both an up and a down file.
+func Load(dialect string) ([]Migration, error) {
+	upFiles, err := fs.Glob(files, path.Join(dialect, "*.up.sql"))
+	if err != nil {
+		return nil, err
+	}
+	if len(upFiles) == 0 {
+		return nil, fmt.Errorf("no migrations found for database dialect %s", dialect)
+	}
+
+	migrations := make([]Migration, 0, len(upFiles))
+	for _, upFile := range upFiles {
+		base := strings.TrimSuffix(path.Base(upFile), ".up.sql")
+		versionString, name, found := strings.Cut(base, "_")
+		version, err := strconv.Atoi(versionString)
+		if !found || err != nil {
+			return nil, fmt.Errorf("migration file %s is not named <version>_<name>.up.sql", upFile)
+		}
+
+		upSql, err := files.ReadFile(upFile)
+		if err != nil {
+			return nil, err
+		}
+		downSql, err := files.ReadFile(path.Join(dialect, base+".down.sql"))
+		if err != nil {
+			return nil, fmt.Errorf("migration %d has no down file: %w", version, err)
+		}
+
+		migrations = append(migrations, Migration{version, name, string(upSql), string(downSql)})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	for i, m := range migrations {
+		if m.Version != i+1 {
+			return nil, fmt.Errorf("expected migration %d but found migration %d (%s)", i+1, m.Version, m.Name)
+		}
+	}
+
+	return migrations, nil
+}
+
+// LoadSeed returns the demo data script for the given dialect.
+func LoadSeed(dialect string) (string, error) {
+	seedSql, err := files.ReadFile(path.Join("seeds", dialect+".sql"))
+	if err != nil {
+		return "", fmt.Errorf("no seed data found for database dialect %s: %w", dialect, err)
+	}
+	return string(seedSql), nil
+}
+
+// splitStatements splits a script into its statements, which must each end with a semicolon at the end of a line.
+// Lines starting with "--" are comments and are dropped.
+func splitStatements(script string) []string {
+	statements := make([]string, 0)
+	var current strings.Builder
+	for _, line := range strings.Split(script, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+		current.WriteString(line)
+		current.WriteString("\n")
+		if strings.HasSuffix(trimmed, ";") {
+			statements = append(statements, strings.TrimSpace(current.String()))
+			current.Reset()
+		}
+	}
+	if rest := strings.TrimSpace(current.String()); rest != "" {
+		statements = append(statements, rest)
+	}
+
+	return statements
+}
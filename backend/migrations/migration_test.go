@@ -0,0 +1,142 @@
+package migrations
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoad_returns_sortedMigrations_with_upAndDown_when_dialect_known(t *testing.T) {
+	for _, dialect := range []string{"mysql", "postgres", "sqlite"} {
+		t.Run(dialect, func(t *testing.T) {
+			//Act
+			migrations, err := Load(dialect)
+
+			//Assert
+			if err != nil {
+				t.Fatalf("Expected no error but got error while loading %s migrations: %s", dialect, err.Error())
+			}
+			if len(migrations) == 0 {
+				t.Fatal("Expected migrations to be loaded but got none")
+			}
+			for i, m := range migrations {
+				if m.Version != i+1 {
+					t.Errorf("Expected migration %d but got migration %d", i+1, m.Version)
+				}
+				if strings.TrimSpace(m.UpSql) == "" || strings.TrimSpace(m.DownSql) == "" {
+					t.Errorf("Expected migration %d (%s) to have up and down sql but did not", m.Version, m.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestLoad_returns_sameMigrations_for_every_dialect(t *testing.T) {
+	//Arrange
+	mysqlMigrations, err := Load("mysql")
+	if err != nil {
+		t.Fatal("Expected no error but got error while loading mysql migrations: " + err.Error())
+	}
+
+	for _, dialect := range []string{"postgres", "sqlite"} {
+		t.Run(dialect, func(t *testing.T) {
+			//Act
+			dialectMigrations, err := Load(dialect)
+
+			//Assert
+			if err != nil {
+				t.Fatalf("Expected no error but got error while loading %s migrations: %s", dialect, err.Error())
+			}
+			if len(dialectMigrations) != len(mysqlMigrations) {
+				t.Fatalf("Expected %d %s migrations but got %d", len(mysqlMigrations), dialect, len(dialectMigrations))
+			}
+			for i := range mysqlMigrations {
+				if dialectMigrations[i].Name != mysqlMigrations[i].Name {
+					t.Errorf("Expected %s migration %d to be %s but got %s",
+						dialect, i+1, mysqlMigrations[i].Name, dialectMigrations[i].Name)
+				}
+			}
+		})
+	}
+}
+
+func TestLoad_returns_error_when_dialect_unknown(t *testing.T) {
+	//Act
+	_, err := Load("some_dialect")
+
+	//Assert
+	if err == nil {
+		t.Error("Expected error but got none while loading migrations of unknown dialect")
+	}
+}
+
+func TestLoadSeed_returns_seed_when_dialect_known(t *testing.T) {
+	for _, dialect := range []string{"mysql", "postgres", "sqlite"} {
+		t.Run(dialect, func(t *testing.T) {
+			//Act
+			seedSql, err := LoadSeed(dialect)
+
+			//Assert
+			if err != nil {
+				t.Fatalf("Expected no error but got error while loading %s seed: %s", dialect, err.Error())
+			}
+			if len(splitStatements(seedSql)) == 0 {
+				t.Error("Expected seed to contain statements but got none")
+			}
+		})
+	}
+}
+
+func TestDialectOf_returns_dialect_when_driver_supported(t *testing.T) {
+	tests := []struct {
+		driverName      string
+		expectedDialect string
+	}{
+		{"mysql", "mysql"},
+		{"pgx", "postgres"},
+		{"sqlite", "sqlite"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.driverName, func(t *testing.T) {
+			//Act
+			actualDialect, err := DialectOf(tc.driverName)
+
+			//Assert
+			if err != nil {
+				t.Fatal("Expected no error but got error: " + err.Error())
+			}
+			if actualDialect != tc.expectedDialect {
+				t.Errorf("Expected dialect %s but got %s", tc.expectedDialect, actualDialect)
+			}
+		})
+	}
+}
+
+func TestDialectOf_returns_error_when_driver_unsupported(t *testing.T) {
+	//Act
+	_, err := DialectOf("some_driver")
+
+	//Assert
+	if err == nil {
+		t.Error("Expected error but got none while getting dialect of unsupported driver")
+	}
+}
+
+func Test_splitStatements_returns_statements_without_comments(t *testing.T) {
+	//Arrange
+	script := "-- a comment\nCREATE TABLE a (\n  id int\n);\n\nINSERT INTO a VALUES (1);\n-- trailing comment\n"
+	expectedStatements := []string{"CREATE TABLE a (\n  id int\n);", "INSERT INTO a VALUES (1);"}
+
+	//Act
+	actualStatements := splitStatements(script)
+
+	//Assert
+	if len(actualStatements) != len(expectedStatements) {
+		t.Fatalf("Expected %d statements but got %d: %v", len(expectedStatements), len(actualStatements), actualStatements)
+	}
+	for i := range expectedStatements {
+		if actualStatements[i] != expectedStatements[i] {
+			t.Errorf("Expected statement \"%s\" but got \"%s\"", expectedStatements[i], actualStatements[i])
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package migrations
+
+import (
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/jmoiron/sqlx"
+)
+
+const createSchemaMigrationsSql = "CREATE TABLE IF NOT EXISTS schema_migrations (" +
+	"version int NOT NULL, name varchar(100) NOT NULL, applied_on varchar(19) NOT NULL, PRIMARY KEY (version))"
+
+// baselineTable is a table of the initial schema, whose presence in a database without schema_migrations rows shows
+// that the schema was created before versioned migrations, e.g. from the old 01-banking.sql.
+const baselineTable = "customers"
+
+// tableExistsSql counts the tables of the current database with a given name, for each dialect.
+var tableExistsSql = map[string]string{
+	"mysql":    "SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = ?",
+	"postgres": "SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = current_schema() AND table_name = ?",
+	"sqlite":   "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?",
+}
+
+// MigrationStatus reports whether a migration has been applied to the database, and when.
+type MigrationStatus struct {
+	Migration
+	IsApplied bool
+	AppliedOn string
+}
+
+type Migrator struct {
+	client     *sqlx.DB
+	dialect    string
+	migrations []Migration
+	clk        clock.Clock
+}
+
+// NewMigrator loads the migrations for the dialect of the given database handle.
+func NewMigrator(dbClient *sqlx.DB, clk clock.Clock) (Migrator, error) {
+	dialect, err := DialectOf(dbClient.DriverName())
+	if err != nil {
+		return Migrator{}, err
+	}
+	migrations, err := Load(dialect)
+	if err != nil {
+		return Migrator{}, err
+	}
+	return Migrator{dbClient, dialect, migrations, clk}, nil
+}
+
+// Up applies all pending migrations in order, each in its own database transaction together with the recording of
+// its version, and returns the migrations that were applied. Note that MySQL commits DDL statements implicitly, so
+// a migration that fails halfway on MySQL may need to be cleaned up by hand before retrying.
+func (m Migrator) Up() ([]Migration, error) {
+	pending, err := m.Pending()
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make([]Migration, 0, len(pending))
+	for _, migration := range pending {
+		if err = m.run(migration.UpSql,
+			"INSERT INTO schema_migrations (version, name, applied_on) VALUES (?, ?, ?)",
+			migration.Version, migration.Name, m.clk.NowAsString()); err != nil {
+			return applied, fmt.Errorf("migration %d (%s) failed: %w", migration.Version, migration.Name, err)
+		}
+		logger.Info(fmt.Sprintf("Applied migration %d (%s)", migration.Version, migration.Name))
+		applied = append(applied, migration)
+	}
+
+	return applied, nil
+}
+
+// Down reverts the given number of most recently applied migrations, newest first, and returns the migrations that
+// were reverted.
+func (m Migrator) Down(steps int) ([]Migration, error) {
+	statuses, err := m.Status()
+	if err != nil {
+		return nil, err
+	}
+
+	reverted := make([]Migration, 0, steps)
+	for i := len(statuses) - 1; i >= 0 && len(reverted) < steps; i-- {
+		migration := statuses[i].Migration
+		if !statuses[i].IsApplied {
+			continue
+		}
+		if err = m.run(migration.DownSql, "DELETE FROM schema_migrations WHERE version = ?", migration.Version); err != nil {
+			return reverted, fmt.Errorf("reverting migration %d (%s) failed: %w", migration.Version, migration.Name, err)
+		}
+		logger.Info(fmt.Sprintf("Reverted migration %d (%s)", migration.Version, migration.Name))
+		reverted = append(reverted, migration)
+	}
+
+	return reverted, nil
+}
+
+// Status returns every known migration with whether it has been applied. It is an error for the database to have
+// a migration applied that this binary does not know about, as that means the binary is older than the schema.
+// The schema_migrations table is created if it does not exist yet.
+func (m Migrator) Status() ([]MigrationStatus, error) {
+	if _, err := m.client.Exec(createSchemaMigrationsSql); err != nil {
+		return nil, fmt.Errorf("error while creating schema_migrations table: %w", err)
+	}
+
+	appliedRows := make([]struct {
+		Version   int    `db:"version"`
+		AppliedOn string `db:"applied_on"`
+	}, 0)
+	if err := m.client.Select(&appliedRows, "SELECT version, applied_on FROM schema_migrations ORDER BY version"); err != nil {
+		return nil, fmt.Errorf("error while reading schema_migrations table: %w", err)
+	}
+	appliedOn := map[int]string{}
+	for _, row := range appliedRows {
+		appliedOn[row.Version] = row.AppliedOn
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		on, ok := appliedOn[migration.Version]
+		statuses = append(statuses, MigrationStatus{migration, ok, on})
+		delete(appliedOn, migration.Version)
+	}
+	if len(appliedOn) > 0 {
+		return nil, fmt.Errorf("database has %d migration(s) applied that this binary does not know about", len(appliedOn))
+	}
+
+	return statuses, nil
+}
+
+// IsUnversioned reports whether the database has a schema that was created before versioned migrations, that is the
+// tables of the initial schema but no migrations recorded. Such a database must be baselined before migrating up.
+func (m Migrator) IsUnversioned() (bool, error) {
+	statuses, err := m.Status()
+	if err != nil {
+		return false, err
+	}
+	for _, s := range statuses {
+		if s.IsApplied {
+			return false, nil
+		}
+	}
+
+	var count int
+	if err = m.client.Get(&count, m.client.Rebind(tableExistsSql[m.dialect]), baselineTable); err != nil {
+		return false, fmt.Errorf("error while looking for table %s: %w", baselineTable, err)
+	}
+	return count > 0, nil
+}
+
+// Baseline records the migrations up to the given version as applied without running them, for a database whose
+// schema was created before versioned migrations, and returns the migrations that were recorded. The remaining
+// migrations are then applied by Up as usual.
+func (m Migrator) Baseline(version int) ([]Migration, error) {
+	unversioned, err := m.IsUnversioned()
+	if err != nil {
+		return nil, err
+	}
+	if !unversioned {
+		return nil, fmt.Errorf("database has migrations recorded or no table %s, so there is nothing to baseline", baselineTable)
+	}
+
+	recorded := make([]Migration, 0, version)
+	for _, migration := range m.migrations {
+		if migration.Version > version {
+			break
+		}
+		if err = m.run("", "INSERT INTO schema_migrations (version, name, applied_on) VALUES (?, ?, ?)",
+			migration.Version, migration.Name, m.clk.NowAsString()); err != nil {
+			return recorded, fmt.Errorf("baselining migration %d (%s) failed: %w", migration.Version, migration.Name, err)
+		}
+		logger.Info(fmt.Sprintf("Recorded migration %d (%s) as applied", migration.Version, migration.Name))
+		recorded = append(recorded, migration)
+	}
+
+	return recorded, nil
+}
+
+// Pending returns the migrations that have not been applied yet, in the order they should be applied.
+func (m Migrator) Pending() ([]Migration, error) {
+	statuses, err := m.Status()
+	if err != nil {
+		return nil, err
+	}
+
+	pending := make([]Migration, 0)
+	for _, s := range statuses {
+		if !s.IsApplied {
+			pending = append(pending, s.Migration)
+		}
+	}
+	return pending, nil
+}
+
+// Seed applies the demo data script, which only inserts rows that do not exist yet.
+func (m Migrator) Seed() error {
+	seedSql, err := LoadSeed(m.dialect)
+	if err != nil {
+		return err
+	}
+	return m.run(seedSql, "")
+}
+
+// run executes every statement of the script followed by the bookkeeping statement (if any) in one database
+// transaction.
+func (m Migrator) run(script string, bookkeepingSql string, bookkeepingArgs ...interface{}) error {
+	tx, err := m.client.Beginx()
+	if err != nil {
+		return err
+	}
+
+	for _, statement := range splitStatements(script) {
+		if _, err = tx.Exec(statement); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+	if bookkeepingSql != "" {
+		if _, err = tx.Exec(m.client.Rebind(bookkeepingSql), bookkeepingArgs...); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
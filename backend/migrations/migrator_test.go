@@ -0,0 +1,216 @@
+package migrations
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/jmoiron/sqlx"
+	_ "modernc.org/sqlite"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockDB sqlmock.Sqlmock
+var migrator Migrator
+
+const dummyDate = "2006-01-02 15:04:05"
+
+const selectSchemaMigrationsSql = "SELECT version, applied_on FROM schema_migrations ORDER BY version"
+const insertSchemaMigrationsSql = "INSERT INTO schema_migrations (version, name, applied_on) VALUES (?, ?, ?)"
+const deleteSchemaMigrationsSql = "DELETE FROM schema_migrations WHERE version = ?"
+
+func setupMigratorTest(t *testing.T) func() {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatal("error while setting up test")
+	}
+	mockDB = mock
+	migrator = Migrator{
+		client:  sqlx.NewDb(db, "mysql"),
+		dialect: "mysql",
+		migrations: []Migration{
+			{1, "create_a", "CREATE TABLE a (id int);", "DROP TABLE a;"},
+			{2, "create_b", "CREATE TABLE b (id int);", "DROP TABLE b;"},
+		},
+		clk: clock.StaticClock{},
+	}
+
+	return func() {
+		defer db.Close()
+	}
+}
+
+func expectAppliedVersions(versions ...int) {
+	mockDB.ExpectExec(createSchemaMigrationsSql).WillReturnResult(sqlmock.NewResult(0, 0))
+	rows := sqlmock.NewRows([]string{"version", "applied_on"})
+	for _, v := range versions {
+		rows.AddRow(v, dummyDate)
+	}
+	mockDB.ExpectQuery(selectSchemaMigrationsSql).WillReturnRows(rows)
+}
+
+func TestMigrator_Up_applies_only_pendingMigrations(t *testing.T) {
+	//Arrange
+	teardown := setupMigratorTest(t)
+	defer teardown()
+
+	expectAppliedVersions(1)
+	mockDB.ExpectBegin()
+	mockDB.ExpectExec("CREATE TABLE b (id int);").WillReturnResult(sqlmock.NewResult(0, 0))
+	mockDB.ExpectExec(insertSchemaMigrationsSql).WithArgs(2, "create_b", dummyDate).WillReturnResult(sqlmock.NewResult(0, 1))
+	mockDB.ExpectCommit()
+
+	//Act
+	applied, err := migrator.Up()
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while applying migrations: " + err.Error())
+	}
+	if len(applied) != 1 || applied[0].Version != 2 {
+		t.Errorf("Expected only migration 2 to be applied but got %v", applied)
+	}
+	if err = mockDB.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMigrator_Down_reverts_latestAppliedMigration(t *testing.T) {
+	//Arrange
+	teardown := setupMigratorTest(t)
+	defer teardown()
+
+	expectAppliedVersions(1, 2)
+	mockDB.ExpectBegin()
+	mockDB.ExpectExec("DROP TABLE b;").WillReturnResult(sqlmock.NewResult(0, 0))
+	mockDB.ExpectExec(deleteSchemaMigrationsSql).WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mockDB.ExpectCommit()
+
+	//Act
+	reverted, err := migrator.Down(1)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while reverting migration: " + err.Error())
+	}
+	if len(reverted) != 1 || reverted[0].Version != 2 {
+		t.Errorf("Expected only migration 2 to be reverted but got %v", reverted)
+	}
+	if err = mockDB.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMigrator_Status_returns_error_when_database_ahead_of_binary(t *testing.T) {
+	//Arrange
+	teardown := setupMigratorTest(t)
+	defer teardown()
+
+	expectAppliedVersions(1, 2, 3)
+
+	//Act
+	_, err := migrator.Status()
+
+	//Assert
+	if err == nil {
+		t.Error("Expected error but got none while testing database with unknown migration applied")
+	}
+}
+
+func TestMigrator_runs_every_sqliteMigration_up_and_down_against_embeddedDb(t *testing.T) {
+	//Arrange
+	dbClient, err := sqlx.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal("error while setting up test: " + err.Error())
+	}
+	defer dbClient.Close()
+	dbClient.SetMaxOpenConns(1)
+	sqliteMigrator, err := NewMigrator(dbClient, clock.StaticClock{})
+	if err != nil {
+		t.Fatal("error while setting up test: " + err.Error())
+	}
+
+	//Act
+	applied, upErr := sqliteMigrator.Up()
+	seedErr := sqliteMigrator.Seed()
+	reseedErr := sqliteMigrator.Seed()
+	var accountCount int
+	countErr := dbClient.Get(&accountCount, "SELECT COUNT(*) FROM accounts")
+	reverted, downErr := sqliteMigrator.Down(len(applied))
+
+	//Assert
+	for _, err = range []error{upErr, seedErr, reseedErr, countErr, downErr} {
+		if err != nil {
+			t.Fatal("Expected no error but got error: " + err.Error())
+		}
+	}
+	if len(applied) != len(sqliteMigrator.migrations) || len(reverted) != len(applied) {
+		t.Errorf("Expected all %d migrations to be applied and reverted but got %d applied and %d reverted",
+			len(sqliteMigrator.migrations), len(applied), len(reverted))
+	}
+	if accountCount != 4 {
+		t.Errorf("Expected 4 seeded accounts after seeding twice but got %d", accountCount)
+	}
+}
+
+func TestMigrator_Baseline_returns_error_when_migrations_recorded(t *testing.T) {
+	//Arrange
+	teardown := setupMigratorTest(t)
+	defer teardown()
+
+	expectAppliedVersions(1)
+
+	//Act
+	recorded, err := migrator.Baseline(1)
+
+	//Assert
+	if err == nil {
+		t.Error("Expected error but got none while baselining database with migrations recorded")
+	}
+	if len(recorded) != 0 {
+		t.Errorf("Expected no migrations to be recorded but got %v", recorded)
+	}
+	if err = mockDB.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMigrator_Baseline_adopts_schema_created_before_versionedMigrations(t *testing.T) {
+	//Arrange
+	dbClient, err := sqlx.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal("error while setting up test: " + err.Error())
+	}
+	defer dbClient.Close()
+	dbClient.SetMaxOpenConns(1)
+	sqliteMigrator, err := NewMigrator(dbClient, clock.StaticClock{})
+	if err != nil {
+		t.Fatal("error while setting up test: " + err.Error())
+	}
+	for _, statement := range splitStatements(sqliteMigrator.migrations[0].UpSql) { //as the old 01-banking.sql did
+		if _, err = dbClient.Exec(statement); err != nil {
+			t.Fatal("error while setting up test: " + err.Error())
+		}
+	}
+
+	//Act
+	unversioned, unversionedErr := sqliteMigrator.IsUnversioned()
+	recorded, baselineErr := sqliteMigrator.Baseline(1)
+	applied, upErr := sqliteMigrator.Up()
+	stillUnversioned, _ := sqliteMigrator.IsUnversioned()
+
+	//Assert
+	for _, err = range []error{unversionedErr, baselineErr, upErr} {
+		if err != nil {
+			t.Fatal("Expected no error but got error: " + err.Error())
+		}
+	}
+	if !unversioned || stillUnversioned {
+		t.Errorf("Expected database to be unversioned only before baselining but got %v and %v", unversioned, stillUnversioned)
+	}
+	if len(recorded) != 1 || recorded[0].Version != 1 {
+		t.Errorf("Expected only migration 1 to be recorded but got %v", recorded)
+	}
+	if len(applied) != len(sqliteMigrator.migrations)-1 {
+		t.Errorf("Expected the other %d migrations to be applied but got %d", len(sqliteMigrator.migrations)-1, len(applied))
+	}
+}
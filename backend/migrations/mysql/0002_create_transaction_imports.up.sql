@@ -0,0 +1,19 @@
+CREATE TABLE `transaction_imports` (
+  `import_id` int(11) NOT NULL AUTO_INCREMENT,
+  `file_hash` char(64) NOT NULL,
+  `row_count` int(11) NOT NULL,
+  `imported_on` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`import_id`),
+  UNIQUE KEY `transaction_imports_file_hash` (`file_hash`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
+
+CREATE TABLE `transaction_import_entries` (
+  `import_id` int(11) NOT NULL,
+  `row_num` int(11) NOT NULL,
+  `transaction_id` int(11) NOT NULL,
+  `reference` varchar(50) NOT NULL,
+  PRIMARY KEY (`import_id`, `row_num`),
+  KEY `transaction_import_entries_FK_2` (`transaction_id`),
+  CONSTRAINT `transaction_import_entries_FK` FOREIGN KEY (`import_id`) REFERENCES `transaction_imports` (`import_id`),
+  CONSTRAINT `transaction_import_entries_FK_2` FOREIGN KEY (`transaction_id`) REFERENCES `transactions` (`transaction_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
@@ -0,0 +1,4 @@
+-- Existing accounts are left without an opening amount: it cannot be derived from their current balance without
+-- assuming that balance is already consistent with the transaction history, which is what reconciliation checks.
+-- Reconciliation reports such accounts as not reconciled until their opening amount has been set after review.
+ALTER TABLE `accounts` ADD COLUMN `opening_amount` decimal(10,2) NULL DEFAULT NULL;
@@ -0,0 +1,10 @@
+CREATE TABLE `outbox` (
+  `event_id` int(11) NOT NULL AUTO_INCREMENT,
+  `account_id` int(11) NOT NULL,
+  `event_type` varchar(50) NOT NULL,
+  `payload` text NOT NULL,
+  `created_on` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `published_on` datetime DEFAULT NULL,
+  PRIMARY KEY (`event_id`),
+  KEY `outbox_published_on` (`published_on`, `event_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS `webhook_delivery_attempts`;
+DROP TABLE IF EXISTS `webhook_deliveries`;
+DROP TABLE IF EXISTS `webhook_subscriptions`;
@@ -0,0 +1,37 @@
+CREATE TABLE `webhook_subscriptions` (
+  `subscription_id` int(11) NOT NULL AUTO_INCREMENT,
+  `url` varchar(2048) NOT NULL,
+  `event_types` varchar(255) NOT NULL,
+  `secret` varchar(255) NOT NULL,
+  `status` varchar(10) NOT NULL DEFAULT 'active',
+  `created_on` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`subscription_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
+
+CREATE TABLE `webhook_deliveries` (
+  `delivery_id` int(11) NOT NULL AUTO_INCREMENT,
+  `subscription_id` int(11) NOT NULL,
+  `event_id` int(11) NOT NULL,
+  `event_type` varchar(50) NOT NULL,
+  `payload` text NOT NULL,
+  `status` varchar(10) NOT NULL DEFAULT 'pending',
+  `attempt_count` int(11) NOT NULL DEFAULT '0',
+  `next_attempt_on` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `created_on` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`delivery_id`),
+  UNIQUE KEY `webhook_deliveries_subscription_event` (`subscription_id`, `event_id`),
+  KEY `webhook_deliveries_due` (`status`, `next_attempt_on`),
+  CONSTRAINT `webhook_deliveries_FK` FOREIGN KEY (`subscription_id`) REFERENCES `webhook_subscriptions` (`subscription_id`),
+  CONSTRAINT `webhook_deliveries_FK_2` FOREIGN KEY (`event_id`) REFERENCES `outbox` (`event_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
+
+CREATE TABLE `webhook_delivery_attempts` (
+  `attempt_id` int(11) NOT NULL AUTO_INCREMENT,
+  `delivery_id` int(11) NOT NULL,
+  `attempted_on` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `status_code` int(11) NOT NULL DEFAULT '0',
+  `error_message` varchar(255) NOT NULL DEFAULT '',
+  PRIMARY KEY (`attempt_id`),
+  KEY `webhook_delivery_attempts_FK` (`delivery_id`),
+  CONSTRAINT `webhook_delivery_attempts_FK` FOREIGN KEY (`delivery_id`) REFERENCES `webhook_deliveries` (`delivery_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
@@ -0,0 +1,23 @@
+CREATE TABLE `alert_rules` (
+  `rule_id` int(11) NOT NULL AUTO_INCREMENT,
+  `account_id` int(11) NOT NULL,
+  `rule_type` varchar(20) NOT NULL,
+  `threshold` decimal(10,2) NOT NULL,
+  `created_on` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`rule_id`),
+  UNIQUE KEY `alert_rules_account_type` (`account_id`, `rule_type`),
+  CONSTRAINT `alert_rules_FK` FOREIGN KEY (`account_id`) REFERENCES `accounts` (`account_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
+
+CREATE TABLE `alerts` (
+  `alert_id` int(11) NOT NULL AUTO_INCREMENT,
+  `customer_id` int(11) NOT NULL,
+  `account_id` int(11) NOT NULL,
+  `rule_type` varchar(20) NOT NULL,
+  `message` varchar(255) NOT NULL,
+  `created_on` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`alert_id`),
+  KEY `alerts_FK` (`customer_id`),
+  CONSTRAINT `alerts_FK` FOREIGN KEY (`customer_id`) REFERENCES `customers` (`customer_id`),
+  CONSTRAINT `alerts_FK_2` FOREIGN KEY (`account_id`) REFERENCES `accounts` (`account_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
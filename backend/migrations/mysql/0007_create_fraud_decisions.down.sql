@@ -0,0 +1,2 @@
+DROP INDEX `transactions_account_date` ON `transactions`;
+DROP TABLE IF EXISTS `fraud_decisions`;
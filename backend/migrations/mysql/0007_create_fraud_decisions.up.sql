@@ -0,0 +1,16 @@
+CREATE TABLE `fraud_decisions` (
+  `decision_id` int(11) NOT NULL AUTO_INCREMENT,
+  `account_id` int(11) NOT NULL,
+  `amount` decimal(10,2) NOT NULL,
+  `transaction_type` varchar(10) NOT NULL,
+  `decision` varchar(10) NOT NULL,
+  `triggered_rules` varchar(255) NOT NULL DEFAULT '',
+  `reasons` text NOT NULL,
+  `decided_on` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`decision_id`),
+  KEY `fraud_decisions_FK` (`account_id`),
+  KEY `fraud_decisions_decision` (`decision`, `decided_on`),
+  CONSTRAINT `fraud_decisions_FK` FOREIGN KEY (`account_id`) REFERENCES `accounts` (`account_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
+
+CREATE INDEX `transactions_account_date` ON `transactions` (`account_id`, `transaction_date`);
@@ -0,0 +1,18 @@
+CREATE TABLE `transaction_reviews` (
+  `review_id` int(11) NOT NULL AUTO_INCREMENT,
+  `account_id` int(11) NOT NULL,
+  `amount` decimal(10,2) NOT NULL,
+  `transaction_type` varchar(10) NOT NULL,
+  `decision_id` int(11) NOT NULL,
+  `reasons` text NOT NULL,
+  `status` varchar(15) NOT NULL,
+  `requested_on` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `reviewed_on` datetime DEFAULT NULL,
+  `review_comment` varchar(255) NOT NULL DEFAULT '',
+  PRIMARY KEY (`review_id`),
+  KEY `transaction_reviews_FK` (`account_id`),
+  KEY `transaction_reviews_decision_FK` (`decision_id`),
+  KEY `transaction_reviews_status` (`status`, `review_id`),
+  CONSTRAINT `transaction_reviews_FK` FOREIGN KEY (`account_id`) REFERENCES `accounts` (`account_id`),
+  CONSTRAINT `transaction_reviews_decision_FK` FOREIGN KEY (`decision_id`) REFERENCES `fraud_decisions` (`decision_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
@@ -0,0 +1,15 @@
+CREATE TABLE `approval_requests` (
+  `approval_id` int(11) NOT NULL AUTO_INCREMENT,
+  `operation` varchar(50) NOT NULL,
+  `description` varchar(2048) NOT NULL,
+  `payload` mediumtext NOT NULL,
+  `status` varchar(10) NOT NULL,
+  `requested_by` varchar(50) NOT NULL,
+  `requested_on` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `expires_on` datetime NOT NULL,
+  `decided_by` varchar(50) NOT NULL DEFAULT '',
+  `decided_on` datetime DEFAULT NULL,
+  `result` mediumtext NOT NULL,
+  PRIMARY KEY (`approval_id`),
+  KEY `approval_requests_status` (`status`, `approval_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
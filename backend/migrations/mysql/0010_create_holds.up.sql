@@ -0,0 +1,16 @@
+CREATE TABLE `holds` (
+  `hold_id` int(11) NOT NULL AUTO_INCREMENT,
+  `account_id` int(11) NOT NULL,
+  `amount` decimal(10,2) NOT NULL,
+  `reason` varchar(255) NOT NULL,
+  `status` varchar(10) NOT NULL,
+  `placed_on` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `expires_on` datetime NOT NULL,
+  `resolved_on` datetime DEFAULT NULL,
+  `transaction_id` int(11) DEFAULT NULL,
+  PRIMARY KEY (`hold_id`),
+  KEY `holds_FK` (`account_id`, `status`),
+  KEY `holds_transaction_FK` (`transaction_id`),
+  CONSTRAINT `holds_FK` FOREIGN KEY (`account_id`) REFERENCES `accounts` (`account_id`),
+  CONSTRAINT `holds_transaction_FK` FOREIGN KEY (`transaction_id`) REFERENCES `transactions` (`transaction_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
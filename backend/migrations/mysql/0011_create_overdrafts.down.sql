@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS `overdrafts`;
+ALTER TABLE `accounts` DROP COLUMN `overdraft_limit`;
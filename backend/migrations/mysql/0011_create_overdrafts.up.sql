@@ -0,0 +1,14 @@
+ALTER TABLE `accounts` ADD COLUMN `overdraft_limit` decimal(10,2) NOT NULL DEFAULT '0.00';
+
+CREATE TABLE `overdrafts` (
+  `overdraft_id` int(11) NOT NULL AUTO_INCREMENT,
+  `account_id` int(11) NOT NULL,
+  `overdrawn_since` datetime NOT NULL,
+  `interest_accrued_on` datetime NOT NULL,
+  `fee_charged` decimal(10,2) NOT NULL DEFAULT '0.00',
+  `interest_charged` decimal(10,2) NOT NULL DEFAULT '0.00',
+  `closed_on` datetime DEFAULT NULL,
+  PRIMARY KEY (`overdraft_id`),
+  KEY `overdrafts_FK` (`account_id`, `closed_on`),
+  CONSTRAINT `overdrafts_FK` FOREIGN KEY (`account_id`) REFERENCES `accounts` (`account_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
@@ -0,0 +1,30 @@
+ALTER TABLE `transactions` ADD COLUMN `related_transaction_id` int(11) DEFAULT NULL;
+ALTER TABLE `transactions` ADD CONSTRAINT `transactions_related_FK` FOREIGN KEY (`related_transaction_id`) REFERENCES `transactions` (`transaction_id`);
+
+CREATE TABLE `fee_schedules` (
+  `schedule_id` int(11) NOT NULL AUTO_INCREMENT,
+  `account_type` varchar(10) NOT NULL,
+  `monthly_fee` decimal(10,2) NOT NULL DEFAULT '0.00',
+  `minimum_balance` decimal(10,2) NOT NULL DEFAULT '0.00',
+  `free_withdrawals` int(11) NOT NULL DEFAULT '0',
+  `withdrawal_fee` decimal(10,2) NOT NULL DEFAULT '0.00',
+  `effective_from` datetime NOT NULL,
+  `created_by` varchar(20) NOT NULL,
+  `created_on` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`schedule_id`),
+  KEY `fee_schedules_effective` (`account_type`, `effective_from`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
+
+CREATE TABLE `monthly_fee_charges` (
+  `charge_id` int(11) NOT NULL AUTO_INCREMENT,
+  `account_id` int(11) NOT NULL,
+  `period` char(7) NOT NULL,
+  `schedule_id` int(11) NOT NULL,
+  `average_balance` decimal(10,2) NOT NULL,
+  `fee` decimal(10,2) NOT NULL,
+  `charged_on` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`charge_id`),
+  UNIQUE KEY `monthly_fee_charges_period` (`account_id`, `period`),
+  CONSTRAINT `monthly_fee_charges_FK` FOREIGN KEY (`account_id`) REFERENCES `accounts` (`account_id`),
+  CONSTRAINT `monthly_fee_charges_schedule_FK` FOREIGN KEY (`schedule_id`) REFERENCES `fee_schedules` (`schedule_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
@@ -0,0 +1,13 @@
+CREATE TABLE `transaction_reversals` (
+  `reversal_id` int(11) NOT NULL AUTO_INCREMENT,
+  `transaction_id` int(11) NOT NULL,
+  `reversal_transaction_id` int(11) NOT NULL,
+  `reason` varchar(255) NOT NULL,
+  `forced` tinyint(1) NOT NULL DEFAULT '0',
+  `reversed_by` varchar(20) NOT NULL,
+  `reversed_on` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`reversal_id`),
+  UNIQUE KEY `transaction_reversals_transaction` (`transaction_id`),
+  CONSTRAINT `transaction_reversals_FK` FOREIGN KEY (`transaction_id`) REFERENCES `transactions` (`transaction_id`),
+  CONSTRAINT `transaction_reversals_reversal_FK` FOREIGN KEY (`reversal_transaction_id`) REFERENCES `transactions` (`transaction_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
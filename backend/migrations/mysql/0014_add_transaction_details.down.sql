@@ -0,0 +1,7 @@
+ALTER TABLE `transaction_reviews` DROP COLUMN `category`;
+ALTER TABLE `transaction_reviews` DROP COLUMN `reference`;
+ALTER TABLE `transaction_reviews` DROP COLUMN `description`;
+
+ALTER TABLE `transactions` DROP COLUMN `category`;
+ALTER TABLE `transactions` DROP COLUMN `reference`;
+ALTER TABLE `transactions` DROP COLUMN `description`;
@@ -0,0 +1,7 @@
+ALTER TABLE `transactions` ADD COLUMN `description` varchar(140) NOT NULL DEFAULT '';
+ALTER TABLE `transactions` ADD COLUMN `reference` varchar(35) NOT NULL DEFAULT '';
+ALTER TABLE `transactions` ADD COLUMN `category` varchar(20) NOT NULL DEFAULT '';
+
+ALTER TABLE `transaction_reviews` ADD COLUMN `description` varchar(140) NOT NULL DEFAULT '';
+ALTER TABLE `transaction_reviews` ADD COLUMN `reference` varchar(35) NOT NULL DEFAULT '';
+ALTER TABLE `transaction_reviews` ADD COLUMN `category` varchar(20) NOT NULL DEFAULT '';
@@ -0,0 +1,27 @@
+CREATE TABLE `payees` (
+  `payee_id` int(11) NOT NULL AUTO_INCREMENT,
+  `customer_id` int(11) NOT NULL,
+  `nickname` varchar(50) NOT NULL,
+  `account_id` int(11) NOT NULL,
+  `owner_name` varchar(100) NOT NULL DEFAULT '',
+  `created_on` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `confirmed_on` datetime DEFAULT NULL,
+  PRIMARY KEY (`payee_id`),
+  UNIQUE KEY `payees_customer_account` (`customer_id`, `account_id`),
+  CONSTRAINT `payees_FK` FOREIGN KEY (`customer_id`) REFERENCES `customers` (`customer_id`),
+  CONSTRAINT `payees_account_FK` FOREIGN KEY (`account_id`) REFERENCES `accounts` (`account_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
+
+CREATE TABLE `audit_trail` (
+  `audit_id` int(11) NOT NULL AUTO_INCREMENT,
+  `customer_id` int(11) NOT NULL,
+  `entity_type` varchar(20) NOT NULL,
+  `entity_id` int(11) NOT NULL,
+  `action` varchar(20) NOT NULL,
+  `details` varchar(255) NOT NULL DEFAULT '',
+  `changed_by` varchar(20) NOT NULL,
+  `changed_on` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`audit_id`),
+  KEY `audit_trail_customer` (`customer_id`),
+  CONSTRAINT `audit_trail_FK` FOREIGN KEY (`customer_id`) REFERENCES `customers` (`customer_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=latin1;
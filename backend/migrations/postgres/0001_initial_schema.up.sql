@@ -0,0 +1,69 @@
+-- Tables of the resource server (customers, accounts, transactions) and of the auth server (users, registrations,
+-- refresh_token_store), which share the same database.
+
+CREATE TABLE customers (
+  customer_id SERIAL NOT NULL,
+  name varchar(100) NOT NULL,
+  date_of_birth date NOT NULL,
+  email varchar(100) NOT NULL,
+  country varchar(100) NOT NULL,
+  zipcode varchar(10) NOT NULL,
+  status smallint NOT NULL DEFAULT 1,
+  PRIMARY KEY (customer_id)
+);
+
+CREATE TABLE accounts (
+  account_id SERIAL NOT NULL,
+  customer_id int NOT NULL,
+  opening_date timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  account_type varchar(10) NOT NULL,
+  amount numeric(10,2) NOT NULL,
+  status smallint NOT NULL DEFAULT 1,
+  PRIMARY KEY (account_id),
+  CONSTRAINT accounts_FK FOREIGN KEY (customer_id) REFERENCES customers (customer_id)
+);
+CREATE INDEX accounts_FK ON accounts (customer_id);
+
+CREATE TABLE transactions (
+  transaction_id SERIAL NOT NULL,
+  account_id int NOT NULL,
+  amount numeric(10,2) NOT NULL,
+  transaction_type varchar(10) NOT NULL,
+  transaction_date timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (transaction_id),
+  CONSTRAINT transactions_FK FOREIGN KEY (account_id) REFERENCES accounts (account_id)
+);
+CREATE INDEX transactions_FK ON transactions (account_id);
+
+CREATE TABLE users (
+  username varchar(20) NOT NULL,
+  password varchar(64) NOT NULL,
+  role varchar(20) NOT NULL,
+  customer_id int DEFAULT NULL,
+  created_on timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (username)
+);
+
+CREATE TABLE registrations (
+  email varchar(100) NOT NULL,
+  customer_id int DEFAULT NULL,
+  name varchar(100) NOT NULL,
+  date_of_birth date NOT NULL,
+  country varchar(100) NOT NULL,
+  zipcode varchar(10) NOT NULL,
+  status smallint NOT NULL DEFAULT 0,
+  username varchar(20) NOT NULL,
+  password varchar(64) NOT NULL,
+  role varchar(20) NOT NULL,
+  email_attempts smallint NOT NULL DEFAULT 0,
+  created_on timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  last_emailed_on timestamp DEFAULT NULL,
+  confirmed_on timestamp DEFAULT NULL,
+  PRIMARY KEY (email)
+);
+
+CREATE TABLE refresh_token_store (
+  refresh_token char(64) NOT NULL,
+  created_on timestamp DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (refresh_token)
+);
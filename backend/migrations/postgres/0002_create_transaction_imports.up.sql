@@ -0,0 +1,19 @@
+CREATE TABLE transaction_imports (
+  import_id SERIAL NOT NULL,
+  file_hash char(64) NOT NULL,
+  row_count int NOT NULL,
+  imported_on timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (import_id),
+  CONSTRAINT transaction_imports_file_hash UNIQUE (file_hash)
+);
+
+CREATE TABLE transaction_import_entries (
+  import_id int NOT NULL,
+  row_num int NOT NULL,
+  transaction_id int NOT NULL,
+  reference varchar(50) NOT NULL,
+  PRIMARY KEY (import_id, row_num),
+  CONSTRAINT transaction_import_entries_FK FOREIGN KEY (import_id) REFERENCES transaction_imports (import_id),
+  CONSTRAINT transaction_import_entries_FK_2 FOREIGN KEY (transaction_id) REFERENCES transactions (transaction_id)
+);
+CREATE INDEX transaction_import_entries_FK_2 ON transaction_import_entries (transaction_id);
@@ -0,0 +1,10 @@
+CREATE TABLE outbox (
+  event_id SERIAL NOT NULL,
+  account_id int NOT NULL,
+  event_type varchar(50) NOT NULL,
+  payload text NOT NULL,
+  created_on timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  published_on timestamp DEFAULT NULL,
+  PRIMARY KEY (event_id)
+);
+CREATE INDEX outbox_published_on ON outbox (published_on, event_id);
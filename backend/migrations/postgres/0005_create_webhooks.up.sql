@@ -0,0 +1,37 @@
+CREATE TABLE webhook_subscriptions (
+  subscription_id SERIAL NOT NULL,
+  url varchar(2048) NOT NULL,
+  event_types varchar(255) NOT NULL,
+  secret varchar(255) NOT NULL,
+  status varchar(10) NOT NULL DEFAULT 'active',
+  created_on timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (subscription_id)
+);
+
+CREATE TABLE webhook_deliveries (
+  delivery_id SERIAL NOT NULL,
+  subscription_id int NOT NULL,
+  event_id int NOT NULL,
+  event_type varchar(50) NOT NULL,
+  payload text NOT NULL,
+  status varchar(10) NOT NULL DEFAULT 'pending',
+  attempt_count int NOT NULL DEFAULT 0,
+  next_attempt_on timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  created_on timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (delivery_id),
+  CONSTRAINT webhook_deliveries_subscription_event UNIQUE (subscription_id, event_id),
+  CONSTRAINT webhook_deliveries_FK FOREIGN KEY (subscription_id) REFERENCES webhook_subscriptions (subscription_id),
+  CONSTRAINT webhook_deliveries_FK_2 FOREIGN KEY (event_id) REFERENCES outbox (event_id)
+);
+CREATE INDEX webhook_deliveries_due ON webhook_deliveries (status, next_attempt_on);
+
+CREATE TABLE webhook_delivery_attempts (
+  attempt_id SERIAL NOT NULL,
+  delivery_id int NOT NULL,
+  attempted_on timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  status_code int NOT NULL DEFAULT 0,
+  error_message varchar(255) NOT NULL DEFAULT '',
+  PRIMARY KEY (attempt_id),
+  CONSTRAINT webhook_delivery_attempts_FK FOREIGN KEY (delivery_id) REFERENCES webhook_deliveries (delivery_id)
+);
+CREATE INDEX webhook_delivery_attempts_FK ON webhook_delivery_attempts (delivery_id);
@@ -0,0 +1,23 @@
+CREATE TABLE alert_rules (
+  rule_id SERIAL NOT NULL,
+  account_id int NOT NULL,
+  rule_type varchar(20) NOT NULL,
+  threshold decimal(10,2) NOT NULL,
+  created_on timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (rule_id),
+  CONSTRAINT alert_rules_account_type UNIQUE (account_id, rule_type),
+  CONSTRAINT alert_rules_FK FOREIGN KEY (account_id) REFERENCES accounts (account_id)
+);
+
+CREATE TABLE alerts (
+  alert_id SERIAL NOT NULL,
+  customer_id int NOT NULL,
+  account_id int NOT NULL,
+  rule_type varchar(20) NOT NULL,
+  message varchar(255) NOT NULL,
+  created_on timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (alert_id),
+  CONSTRAINT alerts_FK FOREIGN KEY (customer_id) REFERENCES customers (customer_id),
+  CONSTRAINT alerts_FK_2 FOREIGN KEY (account_id) REFERENCES accounts (account_id)
+);
+CREATE INDEX alerts_FK ON alerts (customer_id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS transactions_account_date;
+DROP TABLE IF EXISTS fraud_decisions;
@@ -0,0 +1,16 @@
+CREATE TABLE fraud_decisions (
+  decision_id SERIAL NOT NULL,
+  account_id int NOT NULL,
+  amount decimal(10,2) NOT NULL,
+  transaction_type varchar(10) NOT NULL,
+  decision varchar(10) NOT NULL,
+  triggered_rules varchar(255) NOT NULL DEFAULT '',
+  reasons text NOT NULL,
+  decided_on timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (decision_id),
+  CONSTRAINT fraud_decisions_FK FOREIGN KEY (account_id) REFERENCES accounts (account_id)
+);
+CREATE INDEX fraud_decisions_FK ON fraud_decisions (account_id);
+CREATE INDEX fraud_decisions_decision ON fraud_decisions (decision, decided_on);
+
+CREATE INDEX transactions_account_date ON transactions (account_id, transaction_date);
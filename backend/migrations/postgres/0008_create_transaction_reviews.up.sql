@@ -0,0 +1,18 @@
+CREATE TABLE transaction_reviews (
+  review_id SERIAL NOT NULL,
+  account_id int NOT NULL,
+  amount decimal(10,2) NOT NULL,
+  transaction_type varchar(10) NOT NULL,
+  decision_id int NOT NULL,
+  reasons text NOT NULL,
+  status varchar(15) NOT NULL,
+  requested_on timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  reviewed_on timestamp DEFAULT NULL,
+  review_comment varchar(255) NOT NULL DEFAULT '',
+  PRIMARY KEY (review_id),
+  CONSTRAINT transaction_reviews_FK FOREIGN KEY (account_id) REFERENCES accounts (account_id),
+  CONSTRAINT transaction_reviews_decision_FK FOREIGN KEY (decision_id) REFERENCES fraud_decisions (decision_id)
+);
+CREATE INDEX transaction_reviews_FK ON transaction_reviews (account_id);
+CREATE INDEX transaction_reviews_decision_FK ON transaction_reviews (decision_id);
+CREATE INDEX transaction_reviews_status ON transaction_reviews (status, review_id);
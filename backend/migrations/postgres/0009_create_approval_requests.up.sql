@@ -0,0 +1,15 @@
+CREATE TABLE approval_requests (
+  approval_id SERIAL NOT NULL,
+  operation varchar(50) NOT NULL,
+  description varchar(2048) NOT NULL,
+  payload text NOT NULL,
+  status varchar(10) NOT NULL,
+  requested_by varchar(50) NOT NULL,
+  requested_on timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  expires_on timestamp NOT NULL,
+  decided_by varchar(50) NOT NULL DEFAULT '',
+  decided_on timestamp DEFAULT NULL,
+  result text NOT NULL DEFAULT '',
+  PRIMARY KEY (approval_id)
+);
+CREATE INDEX approval_requests_status ON approval_requests (status, approval_id);
@@ -0,0 +1,16 @@
+CREATE TABLE holds (
+  hold_id SERIAL NOT NULL,
+  account_id int NOT NULL,
+  amount decimal(10,2) NOT NULL,
+  reason varchar(255) NOT NULL,
+  status varchar(10) NOT NULL,
+  placed_on timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  expires_on timestamp NOT NULL,
+  resolved_on timestamp DEFAULT NULL,
+  transaction_id int DEFAULT NULL,
+  PRIMARY KEY (hold_id),
+  CONSTRAINT holds_FK FOREIGN KEY (account_id) REFERENCES accounts (account_id),
+  CONSTRAINT holds_transaction_FK FOREIGN KEY (transaction_id) REFERENCES transactions (transaction_id)
+);
+CREATE INDEX holds_FK ON holds (account_id, status);
+CREATE INDEX holds_transaction_FK ON holds (transaction_id);
@@ -0,0 +1,14 @@
+ALTER TABLE accounts ADD COLUMN overdraft_limit numeric(10,2) NOT NULL DEFAULT 0.00;
+
+CREATE TABLE overdrafts (
+  overdraft_id SERIAL NOT NULL,
+  account_id int NOT NULL,
+  overdrawn_since timestamp NOT NULL,
+  interest_accrued_on timestamp NOT NULL,
+  fee_charged decimal(10,2) NOT NULL DEFAULT 0.00,
+  interest_charged decimal(10,2) NOT NULL DEFAULT 0.00,
+  closed_on timestamp DEFAULT NULL,
+  PRIMARY KEY (overdraft_id),
+  CONSTRAINT overdrafts_FK FOREIGN KEY (account_id) REFERENCES accounts (account_id)
+);
+CREATE INDEX overdrafts_FK ON overdrafts (account_id, closed_on);
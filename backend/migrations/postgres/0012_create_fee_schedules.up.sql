@@ -0,0 +1,30 @@
+ALTER TABLE transactions ADD COLUMN related_transaction_id int DEFAULT NULL
+  CONSTRAINT transactions_related_FK REFERENCES transactions (transaction_id);
+
+CREATE TABLE fee_schedules (
+  schedule_id SERIAL NOT NULL,
+  account_type varchar(10) NOT NULL,
+  monthly_fee decimal(10,2) NOT NULL DEFAULT 0.00,
+  minimum_balance decimal(10,2) NOT NULL DEFAULT 0.00,
+  free_withdrawals int NOT NULL DEFAULT 0,
+  withdrawal_fee decimal(10,2) NOT NULL DEFAULT 0.00,
+  effective_from timestamp NOT NULL,
+  created_by varchar(20) NOT NULL,
+  created_on timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (schedule_id)
+);
+CREATE INDEX fee_schedules_effective ON fee_schedules (account_type, effective_from);
+
+CREATE TABLE monthly_fee_charges (
+  charge_id SERIAL NOT NULL,
+  account_id int NOT NULL,
+  period char(7) NOT NULL,
+  schedule_id int NOT NULL,
+  average_balance decimal(10,2) NOT NULL,
+  fee decimal(10,2) NOT NULL,
+  charged_on timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (charge_id),
+  CONSTRAINT monthly_fee_charges_period UNIQUE (account_id, period),
+  CONSTRAINT monthly_fee_charges_FK FOREIGN KEY (account_id) REFERENCES accounts (account_id),
+  CONSTRAINT monthly_fee_charges_schedule_FK FOREIGN KEY (schedule_id) REFERENCES fee_schedules (schedule_id)
+);
@@ -0,0 +1,13 @@
+CREATE TABLE transaction_reversals (
+  reversal_id SERIAL NOT NULL,
+  transaction_id int NOT NULL,
+  reversal_transaction_id int NOT NULL,
+  reason varchar(255) NOT NULL,
+  forced boolean NOT NULL DEFAULT false,
+  reversed_by varchar(20) NOT NULL,
+  reversed_on timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (reversal_id),
+  CONSTRAINT transaction_reversals_transaction UNIQUE (transaction_id),
+  CONSTRAINT transaction_reversals_FK FOREIGN KEY (transaction_id) REFERENCES transactions (transaction_id),
+  CONSTRAINT transaction_reversals_reversal_FK FOREIGN KEY (reversal_transaction_id) REFERENCES transactions (transaction_id)
+);
@@ -0,0 +1,28 @@
+CREATE TABLE payees (
+  payee_id SERIAL NOT NULL,
+  customer_id int NOT NULL,
+  nickname varchar(50) NOT NULL,
+  account_id int NOT NULL,
+  owner_name varchar(100) NOT NULL DEFAULT '',
+  created_on timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  confirmed_on timestamp DEFAULT NULL,
+  PRIMARY KEY (payee_id),
+  CONSTRAINT payees_customer_account UNIQUE (customer_id, account_id),
+  CONSTRAINT payees_FK FOREIGN KEY (customer_id) REFERENCES customers (customer_id),
+  CONSTRAINT payees_account_FK FOREIGN KEY (account_id) REFERENCES accounts (account_id)
+);
+
+CREATE TABLE audit_trail (
+  audit_id SERIAL NOT NULL,
+  customer_id int NOT NULL,
+  entity_type varchar(20) NOT NULL,
+  entity_id int NOT NULL,
+  action varchar(20) NOT NULL,
+  details varchar(255) NOT NULL DEFAULT '',
+  changed_by varchar(20) NOT NULL,
+  changed_on timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (audit_id),
+  CONSTRAINT audit_trail_FK FOREIGN KEY (customer_id) REFERENCES customers (customer_id)
+);
+
+CREATE INDEX audit_trail_customer ON audit_trail (customer_id);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS refresh_token_store;
+DROP TABLE IF EXISTS registrations;
+DROP TABLE IF EXISTS users;
+DROP TABLE IF EXISTS transactions;
+DROP TABLE IF EXISTS accounts;
+DROP TABLE IF EXISTS customers;
@@ -0,0 +1,62 @@
+-- Tables of the resource server (customers, accounts, transactions) and of the auth server (users, registrations,
+-- refresh_token_store), which share the same database.
+-- Dates are stored as TEXT in the same format as MySQL returns them ("2006-01-02" and "2006-01-02 15:04:05").
+
+CREATE TABLE customers (
+  customer_id INTEGER PRIMARY KEY,
+  name TEXT NOT NULL,
+  date_of_birth TEXT NOT NULL,
+  email TEXT NOT NULL,
+  country TEXT NOT NULL,
+  zipcode TEXT NOT NULL,
+  status INTEGER NOT NULL DEFAULT 1
+);
+
+CREATE TABLE accounts (
+  account_id INTEGER PRIMARY KEY,
+  customer_id INTEGER NOT NULL REFERENCES customers (customer_id),
+  opening_date TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  account_type TEXT NOT NULL,
+  amount REAL NOT NULL,
+  status INTEGER NOT NULL DEFAULT 1
+);
+CREATE INDEX accounts_FK ON accounts (customer_id);
+
+CREATE TABLE transactions (
+  transaction_id INTEGER PRIMARY KEY,
+  account_id INTEGER NOT NULL REFERENCES accounts (account_id),
+  amount REAL NOT NULL,
+  transaction_type TEXT NOT NULL,
+  transaction_date TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX transactions_FK ON transactions (account_id);
+
+CREATE TABLE users (
+  username TEXT NOT NULL PRIMARY KEY,
+  password TEXT NOT NULL,
+  role TEXT NOT NULL,
+  customer_id INTEGER DEFAULT NULL,
+  created_on TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE registrations (
+  email TEXT NOT NULL PRIMARY KEY,
+  customer_id INTEGER DEFAULT NULL,
+  name TEXT NOT NULL,
+  date_of_birth TEXT NOT NULL,
+  country TEXT NOT NULL,
+  zipcode TEXT NOT NULL,
+  status INTEGER NOT NULL DEFAULT 0,
+  username TEXT NOT NULL,
+  password TEXT NOT NULL,
+  role TEXT NOT NULL,
+  email_attempts INTEGER NOT NULL DEFAULT 0,
+  created_on TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  last_emailed_on TEXT DEFAULT NULL,
+  confirmed_on TEXT DEFAULT NULL
+);
+
+CREATE TABLE refresh_token_store (
+  refresh_token TEXT NOT NULL PRIMARY KEY,
+  created_on TEXT DEFAULT CURRENT_TIMESTAMP
+);
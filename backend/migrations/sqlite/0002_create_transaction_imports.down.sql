@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS transaction_import_entries;
+DROP TABLE IF EXISTS transaction_imports;
@@ -0,0 +1,15 @@
+CREATE TABLE transaction_imports (
+  import_id INTEGER PRIMARY KEY,
+  file_hash TEXT NOT NULL UNIQUE,
+  row_count INTEGER NOT NULL,
+  imported_on TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE transaction_import_entries (
+  import_id INTEGER NOT NULL REFERENCES transaction_imports (import_id),
+  row_num INTEGER NOT NULL,
+  transaction_id INTEGER NOT NULL REFERENCES transactions (transaction_id),
+  reference TEXT NOT NULL,
+  PRIMARY KEY (import_id, row_num)
+);
+CREATE INDEX transaction_import_entries_FK_2 ON transaction_import_entries (transaction_id);
@@ -0,0 +1,9 @@
+CREATE TABLE outbox (
+  event_id INTEGER PRIMARY KEY,
+  account_id INTEGER NOT NULL,
+  event_type TEXT NOT NULL,
+  payload TEXT NOT NULL,
+  created_on TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  published_on TEXT DEFAULT NULL
+);
+CREATE INDEX outbox_published_on ON outbox (published_on, event_id);
@@ -0,0 +1,31 @@
+CREATE TABLE webhook_subscriptions (
+  subscription_id INTEGER PRIMARY KEY,
+  url TEXT NOT NULL,
+  event_types TEXT NOT NULL,
+  secret TEXT NOT NULL,
+  status TEXT NOT NULL DEFAULT 'active',
+  created_on TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE webhook_deliveries (
+  delivery_id INTEGER PRIMARY KEY,
+  subscription_id INTEGER NOT NULL REFERENCES webhook_subscriptions (subscription_id),
+  event_id INTEGER NOT NULL REFERENCES outbox (event_id),
+  event_type TEXT NOT NULL,
+  payload TEXT NOT NULL,
+  status TEXT NOT NULL DEFAULT 'pending',
+  attempt_count INTEGER NOT NULL DEFAULT 0,
+  next_attempt_on TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  created_on TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE (subscription_id, event_id)
+);
+CREATE INDEX webhook_deliveries_due ON webhook_deliveries (status, next_attempt_on);
+
+CREATE TABLE webhook_delivery_attempts (
+  attempt_id INTEGER PRIMARY KEY,
+  delivery_id INTEGER NOT NULL REFERENCES webhook_deliveries (delivery_id),
+  attempted_on TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  status_code INTEGER NOT NULL DEFAULT 0,
+  error_message TEXT NOT NULL DEFAULT ''
+);
+CREATE INDEX webhook_delivery_attempts_FK ON webhook_delivery_attempts (delivery_id);
@@ -0,0 +1,18 @@
+CREATE TABLE alert_rules (
+  rule_id INTEGER PRIMARY KEY,
+  account_id INTEGER NOT NULL REFERENCES accounts (account_id),
+  rule_type TEXT NOT NULL,
+  threshold REAL NOT NULL,
+  created_on TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE (account_id, rule_type)
+);
+
+CREATE TABLE alerts (
+  alert_id INTEGER PRIMARY KEY,
+  customer_id INTEGER NOT NULL REFERENCES customers (customer_id),
+  account_id INTEGER NOT NULL REFERENCES accounts (account_id),
+  rule_type TEXT NOT NULL,
+  message TEXT NOT NULL,
+  created_on TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX alerts_FK ON alerts (customer_id);
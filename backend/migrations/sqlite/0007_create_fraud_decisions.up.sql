@@ -0,0 +1,14 @@
+CREATE TABLE fraud_decisions (
+  decision_id INTEGER PRIMARY KEY,
+  account_id INTEGER NOT NULL REFERENCES accounts (account_id),
+  amount REAL NOT NULL,
+  transaction_type TEXT NOT NULL,
+  decision TEXT NOT NULL,
+  triggered_rules TEXT NOT NULL DEFAULT '',
+  reasons TEXT NOT NULL,
+  decided_on TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX fraud_decisions_FK ON fraud_decisions (account_id);
+CREATE INDEX fraud_decisions_decision ON fraud_decisions (decision, decided_on);
+
+CREATE INDEX transactions_account_date ON transactions (account_id, transaction_date);
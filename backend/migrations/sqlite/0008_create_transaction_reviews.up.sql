@@ -0,0 +1,15 @@
+CREATE TABLE transaction_reviews (
+  review_id INTEGER PRIMARY KEY,
+  account_id INTEGER NOT NULL REFERENCES accounts (account_id),
+  amount REAL NOT NULL,
+  transaction_type TEXT NOT NULL,
+  decision_id INTEGER NOT NULL REFERENCES fraud_decisions (decision_id),
+  reasons TEXT NOT NULL,
+  status TEXT NOT NULL,
+  requested_on TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  reviewed_on TEXT DEFAULT NULL,
+  review_comment TEXT NOT NULL DEFAULT ''
+);
+CREATE INDEX transaction_reviews_FK ON transaction_reviews (account_id);
+CREATE INDEX transaction_reviews_decision_FK ON transaction_reviews (decision_id);
+CREATE INDEX transaction_reviews_status ON transaction_reviews (status, review_id);
@@ -0,0 +1,14 @@
+CREATE TABLE approval_requests (
+  approval_id INTEGER PRIMARY KEY,
+  operation TEXT NOT NULL,
+  description TEXT NOT NULL,
+  payload TEXT NOT NULL,
+  status TEXT NOT NULL,
+  requested_by TEXT NOT NULL,
+  requested_on TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  expires_on TEXT NOT NULL,
+  decided_by TEXT NOT NULL DEFAULT '',
+  decided_on TEXT DEFAULT NULL,
+  result TEXT NOT NULL DEFAULT ''
+);
+CREATE INDEX approval_requests_status ON approval_requests (status, approval_id);
@@ -0,0 +1,13 @@
+CREATE TABLE holds (
+  hold_id INTEGER PRIMARY KEY,
+  account_id INTEGER NOT NULL REFERENCES accounts (account_id),
+  amount REAL NOT NULL,
+  reason TEXT NOT NULL,
+  status TEXT NOT NULL,
+  placed_on TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  expires_on TEXT NOT NULL,
+  resolved_on TEXT DEFAULT NULL,
+  transaction_id INTEGER DEFAULT NULL REFERENCES transactions (transaction_id)
+);
+CREATE INDEX holds_FK ON holds (account_id, status);
+CREATE INDEX holds_transaction_FK ON holds (transaction_id);
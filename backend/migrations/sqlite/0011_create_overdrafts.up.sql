@@ -0,0 +1,12 @@
+ALTER TABLE accounts ADD COLUMN overdraft_limit REAL NOT NULL DEFAULT 0;
+
+CREATE TABLE overdrafts (
+  overdraft_id INTEGER PRIMARY KEY,
+  account_id INTEGER NOT NULL REFERENCES accounts (account_id),
+  overdrawn_since TEXT NOT NULL,
+  interest_accrued_on TEXT NOT NULL,
+  fee_charged REAL NOT NULL DEFAULT 0,
+  interest_charged REAL NOT NULL DEFAULT 0,
+  closed_on TEXT DEFAULT NULL
+);
+CREATE INDEX overdrafts_FK ON overdrafts (account_id, closed_on);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS monthly_fee_charges;
+DROP TABLE IF EXISTS fee_schedules;
+ALTER TABLE transactions DROP COLUMN related_transaction_id;
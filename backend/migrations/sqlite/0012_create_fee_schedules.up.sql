@@ -0,0 +1,26 @@
+-- related_transaction_id has no REFERENCES clause, as SQLite cannot drop a column used in a foreign key.
+ALTER TABLE transactions ADD COLUMN related_transaction_id INTEGER DEFAULT NULL;
+
+CREATE TABLE fee_schedules (
+  schedule_id INTEGER PRIMARY KEY,
+  account_type TEXT NOT NULL,
+  monthly_fee REAL NOT NULL DEFAULT 0,
+  minimum_balance REAL NOT NULL DEFAULT 0,
+  free_withdrawals INTEGER NOT NULL DEFAULT 0,
+  withdrawal_fee REAL NOT NULL DEFAULT 0,
+  effective_from TEXT NOT NULL,
+  created_by TEXT NOT NULL,
+  created_on TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX fee_schedules_effective ON fee_schedules (account_type, effective_from);
+
+CREATE TABLE monthly_fee_charges (
+  charge_id INTEGER PRIMARY KEY,
+  account_id INTEGER NOT NULL REFERENCES accounts (account_id),
+  period TEXT NOT NULL,
+  schedule_id INTEGER NOT NULL REFERENCES fee_schedules (schedule_id),
+  average_balance REAL NOT NULL,
+  fee REAL NOT NULL,
+  charged_on TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE (account_id, period)
+);
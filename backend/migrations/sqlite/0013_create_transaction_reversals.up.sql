@@ -0,0 +1,9 @@
+CREATE TABLE transaction_reversals (
+  reversal_id INTEGER PRIMARY KEY,
+  transaction_id INTEGER NOT NULL UNIQUE REFERENCES transactions (transaction_id),
+  reversal_transaction_id INTEGER NOT NULL REFERENCES transactions (transaction_id),
+  reason TEXT NOT NULL,
+  forced INTEGER NOT NULL DEFAULT 0,
+  reversed_by TEXT NOT NULL,
+  reversed_on TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,7 @@
+ALTER TABLE transactions ADD COLUMN description TEXT NOT NULL DEFAULT '';
+ALTER TABLE transactions ADD COLUMN reference TEXT NOT NULL DEFAULT '';
+ALTER TABLE transactions ADD COLUMN category TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE transaction_reviews ADD COLUMN description TEXT NOT NULL DEFAULT '';
+ALTER TABLE transaction_reviews ADD COLUMN reference TEXT NOT NULL DEFAULT '';
+ALTER TABLE transaction_reviews ADD COLUMN category TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,23 @@
+CREATE TABLE payees (
+  payee_id INTEGER PRIMARY KEY,
+  customer_id INTEGER NOT NULL REFERENCES customers (customer_id),
+  nickname TEXT NOT NULL,
+  account_id INTEGER NOT NULL REFERENCES accounts (account_id),
+  owner_name TEXT NOT NULL DEFAULT '',
+  created_on TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  confirmed_on TEXT DEFAULT NULL,
+  UNIQUE (customer_id, account_id)
+);
+
+CREATE TABLE audit_trail (
+  audit_id INTEGER PRIMARY KEY,
+  customer_id INTEGER NOT NULL REFERENCES customers (customer_id),
+  entity_type TEXT NOT NULL,
+  entity_id INTEGER NOT NULL,
+  action TEXT NOT NULL,
+  details TEXT NOT NULL DEFAULT '',
+  changed_by TEXT NOT NULL,
+  changed_on TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX audit_trail_customer ON audit_trail (customer_id);
@@ -65,6 +65,36 @@ func (mr *MockAccountRepositoryMockRecorder) FindById(arg0 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockAccountRepository)(nil).FindById), arg0)
 }
 
+// FindTransaction mocks base method.
+func (m *MockAccountRepository) FindTransaction(arg0, arg1 string) (*domain.Transaction, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTransaction", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Transaction)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// FindTransaction indicates an expected call of FindTransaction.
+func (mr *MockAccountRepositoryMockRecorder) FindTransaction(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTransaction", reflect.TypeOf((*MockAccountRepository)(nil).FindTransaction), arg0, arg1)
+}
+
+// FindTransactions mocks base method.
+func (m *MockAccountRepository) FindTransactions(arg0 string) ([]domain.Transaction, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTransactions", arg0)
+	ret0, _ := ret[0].([]domain.Transaction)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// FindTransactions indicates an expected call of FindTransactions.
+func (mr *MockAccountRepositoryMockRecorder) FindTransactions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTransactions", reflect.TypeOf((*MockAccountRepository)(nil).FindTransactions), arg0)
+}
+
 // Save mocks base method.
 func (m *MockAccountRepository) Save(arg0 domain.Account) (*domain.Account, *errs.AppError) {
 	m.ctrl.T.Helper()
@@ -94,3 +124,47 @@ func (mr *MockAccountRepositoryMockRecorder) Transact(arg0 interface{}) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transact", reflect.TypeOf((*MockAccountRepository)(nil).Transact), arg0)
 }
+
+// Transfer mocks base method.
+func (m *MockAccountRepository) Transfer(arg0, arg1 domain.Transaction) (*domain.Transaction, *domain.Transaction, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transfer", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Transaction)
+	ret1, _ := ret[1].(*domain.Transaction)
+	ret2, _ := ret[2].(*errs.AppError)
+	return ret0, ret1, ret2
+}
+
+// Transfer indicates an expected call of Transfer.
+func (mr *MockAccountRepositoryMockRecorder) Transfer(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transfer", reflect.TypeOf((*MockAccountRepository)(nil).Transfer), arg0, arg1)
+}
+
+// UpdateOverdraftLimit mocks base method.
+func (m *MockAccountRepository) UpdateOverdraftLimit(arg0 string, arg1 float64) *errs.AppError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOverdraftLimit", arg0, arg1)
+	ret0, _ := ret[0].(*errs.AppError)
+	return ret0
+}
+
+// UpdateOverdraftLimit indicates an expected call of UpdateOverdraftLimit.
+func (mr *MockAccountRepositoryMockRecorder) UpdateOverdraftLimit(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOverdraftLimit", reflect.TypeOf((*MockAccountRepository)(nil).UpdateOverdraftLimit), arg0, arg1)
+}
+
+// UpdateTransactionCategory mocks base method.
+func (m *MockAccountRepository) UpdateTransactionCategory(arg0, arg1 string) *errs.AppError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTransactionCategory", arg0, arg1)
+	ret0, _ := ret[0].(*errs.AppError)
+	return ret0
+}
+
+// UpdateTransactionCategory indicates an expected call of UpdateTransactionCategory.
+func (mr *MockAccountRepositoryMockRecorder) UpdateTransactionCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTransactionCategory", reflect.TypeOf((*MockAccountRepository)(nil).UpdateTransactionCategory), arg0, arg1)
+}
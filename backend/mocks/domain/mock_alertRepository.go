@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/aliciatay-zls/banking/backend/domain (interfaces: AlertRepository)
+
+// Package domain is a generated GoMock package.
+package domain
+
+import (
+	reflect "reflect"
+
+	errs "github.com/aliciatay-zls/banking-lib/errs"
+	domain "github.com/aliciatay-zls/banking/backend/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAlertRepository is a mock of AlertRepository interface.
+type MockAlertRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAlertRepositoryMockRecorder
+}
+
+// MockAlertRepositoryMockRecorder is the mock recorder for MockAlertRepository.
+type MockAlertRepositoryMockRecorder struct {
+	mock *MockAlertRepository
+}
+
+// NewMockAlertRepository creates a new mock instance.
+func NewMockAlertRepository(ctrl *gomock.Controller) *MockAlertRepository {
+	mock := &MockAlertRepository{ctrl: ctrl}
+	mock.recorder = &MockAlertRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAlertRepository) EXPECT() *MockAlertRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteRule mocks base method.
+func (m *MockAlertRepository) DeleteRule(arg0, arg1 string) *errs.AppError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRule", arg0, arg1)
+	ret0, _ := ret[0].(*errs.AppError)
+	return ret0
+}
+
+// DeleteRule indicates an expected call of DeleteRule.
+func (mr *MockAlertRepositoryMockRecorder) DeleteRule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRule", reflect.TypeOf((*MockAlertRepository)(nil).DeleteRule), arg0, arg1)
+}
+
+// FindAlerts mocks base method.
+func (m *MockAlertRepository) FindAlerts(arg0 string) ([]domain.Alert, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAlerts", arg0)
+	ret0, _ := ret[0].([]domain.Alert)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// FindAlerts indicates an expected call of FindAlerts.
+func (mr *MockAlertRepositoryMockRecorder) FindAlerts(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAlerts", reflect.TypeOf((*MockAlertRepository)(nil).FindAlerts), arg0)
+}
+
+// FindRules mocks base method.
+func (m *MockAlertRepository) FindRules(arg0 string) ([]domain.AlertRule, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRules", arg0)
+	ret0, _ := ret[0].([]domain.AlertRule)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// FindRules indicates an expected call of FindRules.
+func (mr *MockAlertRepositoryMockRecorder) FindRules(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRules", reflect.TypeOf((*MockAlertRepository)(nil).FindRules), arg0)
+}
+
+// SaveAlert mocks base method.
+func (m *MockAlertRepository) SaveAlert(arg0 domain.Alert) (*domain.Alert, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAlert", arg0)
+	ret0, _ := ret[0].(*domain.Alert)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// SaveAlert indicates an expected call of SaveAlert.
+func (mr *MockAlertRepositoryMockRecorder) SaveAlert(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAlert", reflect.TypeOf((*MockAlertRepository)(nil).SaveAlert), arg0)
+}
+
+// SaveRule mocks base method.
+func (m *MockAlertRepository) SaveRule(arg0 domain.AlertRule) (*domain.AlertRule, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveRule", arg0)
+	ret0, _ := ret[0].(*domain.AlertRule)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// SaveRule indicates an expected call of SaveRule.
+func (mr *MockAlertRepositoryMockRecorder) SaveRule(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveRule", reflect.TypeOf((*MockAlertRepository)(nil).SaveRule), arg0)
+}
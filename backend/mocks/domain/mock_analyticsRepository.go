@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/aliciatay-zls/banking/backend/domain (interfaces: AnalyticsRepository)
+
+// Package domain is a generated GoMock package.
+package domain
+
+import (
+	reflect "reflect"
+
+	errs "github.com/aliciatay-zls/banking-lib/errs"
+	domain "github.com/aliciatay-zls/banking/backend/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAnalyticsRepository is a mock of AnalyticsRepository interface.
+type MockAnalyticsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAnalyticsRepositoryMockRecorder
+}
+
+// MockAnalyticsRepositoryMockRecorder is the mock recorder for MockAnalyticsRepository.
+type MockAnalyticsRepositoryMockRecorder struct {
+	mock *MockAnalyticsRepository
+}
+
+// NewMockAnalyticsRepository creates a new mock instance.
+func NewMockAnalyticsRepository(ctrl *gomock.Controller) *MockAnalyticsRepository {
+	mock := &MockAnalyticsRepository{ctrl: ctrl}
+	mock.recorder = &MockAnalyticsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAnalyticsRepository) EXPECT() *MockAnalyticsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindLargestDebits mocks base method.
+func (m *MockAnalyticsRepository) FindLargestDebits(arg0 []string, arg1 domain.AnalyticsPeriod, arg2 int) ([]domain.Transaction, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLargestDebits", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]domain.Transaction)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// FindLargestDebits indicates an expected call of FindLargestDebits.
+func (mr *MockAnalyticsRepositoryMockRecorder) FindLargestDebits(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLargestDebits", reflect.TypeOf((*MockAnalyticsRepository)(nil).FindLargestDebits), arg0, arg1, arg2)
+}
+
+// SumTransactions mocks base method.
+func (m *MockAnalyticsRepository) SumTransactions(arg0 []string, arg1 domain.AnalyticsPeriod, arg2 string) ([]domain.TransactionTotals, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumTransactions", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]domain.TransactionTotals)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// SumTransactions indicates an expected call of SumTransactions.
+func (mr *MockAnalyticsRepositoryMockRecorder) SumTransactions(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumTransactions", reflect.TypeOf((*MockAnalyticsRepository)(nil).SumTransactions), arg0, arg1, arg2)
+}
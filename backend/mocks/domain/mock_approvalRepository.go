@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/aliciatay-zls/banking/backend/domain (interfaces: ApprovalRepository)
+
+// Package domain is a generated GoMock package.
+package domain
+
+import (
+	reflect "reflect"
+
+	errs "github.com/aliciatay-zls/banking-lib/errs"
+	domain "github.com/aliciatay-zls/banking/backend/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockApprovalRepository is a mock of ApprovalRepository interface.
+type MockApprovalRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockApprovalRepositoryMockRecorder
+}
+
+// MockApprovalRepositoryMockRecorder is the mock recorder for MockApprovalRepository.
+type MockApprovalRepositoryMockRecorder struct {
+	mock *MockApprovalRepository
+}
+
+// NewMockApprovalRepository creates a new mock instance.
+func NewMockApprovalRepository(ctrl *gomock.Controller) *MockApprovalRepository {
+	mock := &MockApprovalRepository{ctrl: ctrl}
+	mock.recorder = &MockApprovalRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApprovalRepository) EXPECT() *MockApprovalRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ExpirePending mocks base method.
+func (m *MockApprovalRepository) ExpirePending(arg0 string) *errs.AppError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpirePending", arg0)
+	ret0, _ := ret[0].(*errs.AppError)
+	return ret0
+}
+
+// ExpirePending indicates an expected call of ExpirePending.
+func (mr *MockApprovalRepositoryMockRecorder) ExpirePending(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpirePending", reflect.TypeOf((*MockApprovalRepository)(nil).ExpirePending), arg0)
+}
+
+// FindById mocks base method.
+func (m *MockApprovalRepository) FindById(arg0 string) (*domain.ApprovalRequest, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", arg0)
+	ret0, _ := ret[0].(*domain.ApprovalRequest)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockApprovalRepositoryMockRecorder) FindById(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockApprovalRepository)(nil).FindById), arg0)
+}
+
+// FindPending mocks base method.
+func (m *MockApprovalRepository) FindPending() ([]domain.ApprovalRequest, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPending")
+	ret0, _ := ret[0].([]domain.ApprovalRequest)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// FindPending indicates an expected call of FindPending.
+func (mr *MockApprovalRepositoryMockRecorder) FindPending() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPending", reflect.TypeOf((*MockApprovalRepository)(nil).FindPending))
+}
+
+// Save mocks base method.
+func (m *MockApprovalRepository) Save(arg0 domain.ApprovalRequest) (*domain.ApprovalRequest, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0)
+	ret0, _ := ret[0].(*domain.ApprovalRequest)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockApprovalRepositoryMockRecorder) Save(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockApprovalRepository)(nil).Save), arg0)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockApprovalRepository) UpdateStatus(arg0 domain.ApprovalRequest, arg1 string) *errs.AppError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", arg0, arg1)
+	ret0, _ := ret[0].(*errs.AppError)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockApprovalRepositoryMockRecorder) UpdateStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockApprovalRepository)(nil).UpdateStatus), arg0, arg1)
+}
@@ -0,0 +1,199 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/aliciatay-zls/banking/backend/domain (interfaces: FeeRepository)
+
+// Package domain is a generated GoMock package.
+package domain
+
+import (
+	reflect "reflect"
+
+	errs "github.com/aliciatay-zls/banking-lib/errs"
+	domain "github.com/aliciatay-zls/banking/backend/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFeeRepository is a mock of FeeRepository interface.
+type MockFeeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeeRepositoryMockRecorder
+}
+
+// MockFeeRepositoryMockRecorder is the mock recorder for MockFeeRepository.
+type MockFeeRepositoryMockRecorder struct {
+	mock *MockFeeRepository
+}
+
+// NewMockFeeRepository creates a new mock instance.
+func NewMockFeeRepository(ctrl *gomock.Controller) *MockFeeRepository {
+	mock := &MockFeeRepository{ctrl: ctrl}
+	mock.recorder = &MockFeeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeeRepository) EXPECT() *MockFeeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountWithdrawalsSince mocks base method.
+func (m *MockFeeRepository) CountWithdrawalsSince(arg0, arg1, arg2 string) (int, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountWithdrawalsSince", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// CountWithdrawalsSince indicates an expected call of CountWithdrawalsSince.
+func (mr *MockFeeRepositoryMockRecorder) CountWithdrawalsSince(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountWithdrawalsSince", reflect.TypeOf((*MockFeeRepository)(nil).CountWithdrawalsSince), arg0, arg1, arg2)
+}
+
+// DeleteMonthlyCharge mocks base method.
+func (m *MockFeeRepository) DeleteMonthlyCharge(arg0 string) *errs.AppError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMonthlyCharge", arg0)
+	ret0, _ := ret[0].(*errs.AppError)
+	return ret0
+}
+
+// DeleteMonthlyCharge indicates an expected call of DeleteMonthlyCharge.
+func (mr *MockFeeRepositoryMockRecorder) DeleteMonthlyCharge(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMonthlyCharge", reflect.TypeOf((*MockFeeRepository)(nil).DeleteMonthlyCharge), arg0)
+}
+
+// DeleteSchedule mocks base method.
+func (m *MockFeeRepository) DeleteSchedule(arg0, arg1 string) *errs.AppError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSchedule", arg0, arg1)
+	ret0, _ := ret[0].(*errs.AppError)
+	return ret0
+}
+
+// DeleteSchedule indicates an expected call of DeleteSchedule.
+func (mr *MockFeeRepositoryMockRecorder) DeleteSchedule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSchedule", reflect.TypeOf((*MockFeeRepository)(nil).DeleteSchedule), arg0, arg1)
+}
+
+// ExistsMonthlyCharge mocks base method.
+func (m *MockFeeRepository) ExistsMonthlyCharge(arg0, arg1 string) (bool, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistsMonthlyCharge", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// ExistsMonthlyCharge indicates an expected call of ExistsMonthlyCharge.
+func (mr *MockFeeRepositoryMockRecorder) ExistsMonthlyCharge(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsMonthlyCharge", reflect.TypeOf((*MockFeeRepository)(nil).ExistsMonthlyCharge), arg0, arg1)
+}
+
+// FindActiveAccountsOpenedBefore mocks base method.
+func (m *MockFeeRepository) FindActiveAccountsOpenedBefore(arg0 string) ([]domain.Account, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActiveAccountsOpenedBefore", arg0)
+	ret0, _ := ret[0].([]domain.Account)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// FindActiveAccountsOpenedBefore indicates an expected call of FindActiveAccountsOpenedBefore.
+func (mr *MockFeeRepositoryMockRecorder) FindActiveAccountsOpenedBefore(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActiveAccountsOpenedBefore", reflect.TypeOf((*MockFeeRepository)(nil).FindActiveAccountsOpenedBefore), arg0)
+}
+
+// FindEffectiveSchedule mocks base method.
+func (m *MockFeeRepository) FindEffectiveSchedule(arg0, arg1 string) (*domain.FeeSchedule, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEffectiveSchedule", arg0, arg1)
+	ret0, _ := ret[0].(*domain.FeeSchedule)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// FindEffectiveSchedule indicates an expected call of FindEffectiveSchedule.
+func (mr *MockFeeRepositoryMockRecorder) FindEffectiveSchedule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEffectiveSchedule", reflect.TypeOf((*MockFeeRepository)(nil).FindEffectiveSchedule), arg0, arg1)
+}
+
+// FindScheduleById mocks base method.
+func (m *MockFeeRepository) FindScheduleById(arg0 string) (*domain.FeeSchedule, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindScheduleById", arg0)
+	ret0, _ := ret[0].(*domain.FeeSchedule)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// FindScheduleById indicates an expected call of FindScheduleById.
+func (mr *MockFeeRepositoryMockRecorder) FindScheduleById(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindScheduleById", reflect.TypeOf((*MockFeeRepository)(nil).FindScheduleById), arg0)
+}
+
+// FindSchedules mocks base method.
+func (m *MockFeeRepository) FindSchedules() ([]domain.FeeSchedule, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSchedules")
+	ret0, _ := ret[0].([]domain.FeeSchedule)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// FindSchedules indicates an expected call of FindSchedules.
+func (mr *MockFeeRepositoryMockRecorder) FindSchedules() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSchedules", reflect.TypeOf((*MockFeeRepository)(nil).FindSchedules))
+}
+
+// FindTransactionsSince mocks base method.
+func (m *MockFeeRepository) FindTransactionsSince(arg0, arg1 string) ([]domain.Transaction, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTransactionsSince", arg0, arg1)
+	ret0, _ := ret[0].([]domain.Transaction)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// FindTransactionsSince indicates an expected call of FindTransactionsSince.
+func (mr *MockFeeRepositoryMockRecorder) FindTransactionsSince(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTransactionsSince", reflect.TypeOf((*MockFeeRepository)(nil).FindTransactionsSince), arg0, arg1)
+}
+
+// SaveMonthlyCharge mocks base method.
+func (m *MockFeeRepository) SaveMonthlyCharge(arg0 domain.MonthlyFeeCharge) (*domain.MonthlyFeeCharge, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveMonthlyCharge", arg0)
+	ret0, _ := ret[0].(*domain.MonthlyFeeCharge)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// SaveMonthlyCharge indicates an expected call of SaveMonthlyCharge.
+func (mr *MockFeeRepositoryMockRecorder) SaveMonthlyCharge(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveMonthlyCharge", reflect.TypeOf((*MockFeeRepository)(nil).SaveMonthlyCharge), arg0)
+}
+
+// SaveSchedule mocks base method.
+func (m *MockFeeRepository) SaveSchedule(arg0 domain.FeeSchedule) (*domain.FeeSchedule, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSchedule", arg0)
+	ret0, _ := ret[0].(*domain.FeeSchedule)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// SaveSchedule indicates an expected call of SaveSchedule.
+func (mr *MockFeeRepositoryMockRecorder) SaveSchedule(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSchedule", reflect.TypeOf((*MockFeeRepository)(nil).SaveSchedule), arg0)
+}
@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/aliciatay-zls/banking/backend/domain (interfaces: FraudRepository)
+
+// Package domain is a generated GoMock package.
+package domain
+
+import (
+	reflect "reflect"
+
+	errs "github.com/aliciatay-zls/banking-lib/errs"
+	domain "github.com/aliciatay-zls/banking/backend/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFraudRepository is a mock of FraudRepository interface.
+type MockFraudRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFraudRepositoryMockRecorder
+}
+
+// MockFraudRepositoryMockRecorder is the mock recorder for MockFraudRepository.
+type MockFraudRepositoryMockRecorder struct {
+	mock *MockFraudRepository
+}
+
+// NewMockFraudRepository creates a new mock instance.
+func NewMockFraudRepository(ctrl *gomock.Controller) *MockFraudRepository {
+	mock := &MockFraudRepository{ctrl: ctrl}
+	mock.recorder = &MockFraudRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFraudRepository) EXPECT() *MockFraudRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindLastTransactionDate mocks base method.
+func (m *MockFraudRepository) FindLastTransactionDate(arg0 string) (string, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLastTransactionDate", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// FindLastTransactionDate indicates an expected call of FindLastTransactionDate.
+func (mr *MockFraudRepositoryMockRecorder) FindLastTransactionDate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLastTransactionDate", reflect.TypeOf((*MockFraudRepository)(nil).FindLastTransactionDate), arg0)
+}
+
+// FindTransactionsSince mocks base method.
+func (m *MockFraudRepository) FindTransactionsSince(arg0, arg1 string) ([]domain.Transaction, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTransactionsSince", arg0, arg1)
+	ret0, _ := ret[0].([]domain.Transaction)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// FindTransactionsSince indicates an expected call of FindTransactionsSince.
+func (mr *MockFraudRepositoryMockRecorder) FindTransactionsSince(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTransactionsSince", reflect.TypeOf((*MockFraudRepository)(nil).FindTransactionsSince), arg0, arg1)
+}
+
+// SaveDecision mocks base method.
+func (m *MockFraudRepository) SaveDecision(arg0 domain.FraudDecision) (*domain.FraudDecision, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveDecision", arg0)
+	ret0, _ := ret[0].(*domain.FraudDecision)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// SaveDecision indicates an expected call of SaveDecision.
+func (mr *MockFraudRepositoryMockRecorder) SaveDecision(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveDecision", reflect.TypeOf((*MockFraudRepository)(nil).SaveDecision), arg0)
+}
@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/aliciatay-zls/banking/backend/domain (interfaces: OverdraftRepository)
+
+// Package domain is a generated GoMock package.
+package domain
+
+import (
+	reflect "reflect"
+
+	errs "github.com/aliciatay-zls/banking-lib/errs"
+	domain "github.com/aliciatay-zls/banking/backend/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOverdraftRepository is a mock of OverdraftRepository interface.
+type MockOverdraftRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOverdraftRepositoryMockRecorder
+}
+
+// MockOverdraftRepositoryMockRecorder is the mock recorder for MockOverdraftRepository.
+type MockOverdraftRepositoryMockRecorder struct {
+	mock *MockOverdraftRepository
+}
+
+// NewMockOverdraftRepository creates a new mock instance.
+func NewMockOverdraftRepository(ctrl *gomock.Controller) *MockOverdraftRepository {
+	mock := &MockOverdraftRepository{ctrl: ctrl}
+	mock.recorder = &MockOverdraftRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOverdraftRepository) EXPECT() *MockOverdraftRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindAllOpen mocks base method.
+func (m *MockOverdraftRepository) FindAllOpen() ([]domain.Overdraft, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllOpen")
+	ret0, _ := ret[0].([]domain.Overdraft)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// FindAllOpen indicates an expected call of FindAllOpen.
+func (mr *MockOverdraftRepositoryMockRecorder) FindAllOpen() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllOpen", reflect.TypeOf((*MockOverdraftRepository)(nil).FindAllOpen))
+}
+
+// FindOpen mocks base method.
+func (m *MockOverdraftRepository) FindOpen(arg0 string) (*domain.Overdraft, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOpen", arg0)
+	ret0, _ := ret[0].(*domain.Overdraft)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// FindOpen indicates an expected call of FindOpen.
+func (mr *MockOverdraftRepositoryMockRecorder) FindOpen(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOpen", reflect.TypeOf((*MockOverdraftRepository)(nil).FindOpen), arg0)
+}
+
+// Save mocks base method.
+func (m *MockOverdraftRepository) Save(arg0 domain.Overdraft) (*domain.Overdraft, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0)
+	ret0, _ := ret[0].(*domain.Overdraft)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockOverdraftRepositoryMockRecorder) Save(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockOverdraftRepository)(nil).Save), arg0)
+}
+
+// Update mocks base method.
+func (m *MockOverdraftRepository) Update(arg0 domain.Overdraft, arg1 string) *errs.AppError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(*errs.AppError)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockOverdraftRepositoryMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockOverdraftRepository)(nil).Update), arg0, arg1)
+}
@@ -0,0 +1,138 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/aliciatay-zls/banking/backend/domain (interfaces: PayeeRepository)
+
+// Package domain is a generated GoMock package.
+package domain
+
+import (
+	reflect "reflect"
+
+	errs "github.com/aliciatay-zls/banking-lib/errs"
+	domain "github.com/aliciatay-zls/banking/backend/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPayeeRepository is a mock of PayeeRepository interface.
+type MockPayeeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPayeeRepositoryMockRecorder
+}
+
+// MockPayeeRepositoryMockRecorder is the mock recorder for MockPayeeRepository.
+type MockPayeeRepositoryMockRecorder struct {
+	mock *MockPayeeRepository
+}
+
+// NewMockPayeeRepository creates a new mock instance.
+func NewMockPayeeRepository(ctrl *gomock.Controller) *MockPayeeRepository {
+	mock := &MockPayeeRepository{ctrl: ctrl}
+	mock.recorder = &MockPayeeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPayeeRepository) EXPECT() *MockPayeeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Confirm mocks base method.
+func (m *MockPayeeRepository) Confirm(arg0 domain.Payee, arg1 string) *errs.AppError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Confirm", arg0, arg1)
+	ret0, _ := ret[0].(*errs.AppError)
+	return ret0
+}
+
+// Confirm indicates an expected call of Confirm.
+func (mr *MockPayeeRepositoryMockRecorder) Confirm(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Confirm", reflect.TypeOf((*MockPayeeRepository)(nil).Confirm), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockPayeeRepository) Delete(arg0 domain.Payee, arg1, arg2 string) *errs.AppError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*errs.AppError)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPayeeRepositoryMockRecorder) Delete(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPayeeRepository)(nil).Delete), arg0, arg1, arg2)
+}
+
+// FindAll mocks base method.
+func (m *MockPayeeRepository) FindAll(arg0 string) ([]domain.Payee, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", arg0)
+	ret0, _ := ret[0].([]domain.Payee)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockPayeeRepositoryMockRecorder) FindAll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockPayeeRepository)(nil).FindAll), arg0)
+}
+
+// FindAuditTrail mocks base method.
+func (m *MockPayeeRepository) FindAuditTrail(arg0 string) ([]domain.AuditEntry, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAuditTrail", arg0)
+	ret0, _ := ret[0].([]domain.AuditEntry)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// FindAuditTrail indicates an expected call of FindAuditTrail.
+func (mr *MockPayeeRepositoryMockRecorder) FindAuditTrail(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAuditTrail", reflect.TypeOf((*MockPayeeRepository)(nil).FindAuditTrail), arg0)
+}
+
+// FindById mocks base method.
+func (m *MockPayeeRepository) FindById(arg0 string) (*domain.Payee, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", arg0)
+	ret0, _ := ret[0].(*domain.Payee)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockPayeeRepositoryMockRecorder) FindById(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockPayeeRepository)(nil).FindById), arg0)
+}
+
+// Save mocks base method.
+func (m *MockPayeeRepository) Save(arg0 domain.Payee, arg1 string) (*domain.Payee, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Payee)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockPayeeRepositoryMockRecorder) Save(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockPayeeRepository)(nil).Save), arg0, arg1)
+}
+
+// UpdateNickname mocks base method.
+func (m *MockPayeeRepository) UpdateNickname(arg0 domain.Payee, arg1, arg2, arg3 string) *errs.AppError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNickname", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*errs.AppError)
+	return ret0
+}
+
+// UpdateNickname indicates an expected call of UpdateNickname.
+func (mr *MockPayeeRepositoryMockRecorder) UpdateNickname(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNickname", reflect.TypeOf((*MockPayeeRepository)(nil).UpdateNickname), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/aliciatay-zls/banking/backend/domain (interfaces: TransactionImportRepository)
+
+// Package domain is a generated GoMock package.
+package domain
+
+import (
+	reflect "reflect"
+
+	errs "github.com/aliciatay-zls/banking-lib/errs"
+	domain "github.com/aliciatay-zls/banking/backend/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTransactionImportRepository is a mock of TransactionImportRepository interface.
+type MockTransactionImportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactionImportRepositoryMockRecorder
+}
+
+// MockTransactionImportRepositoryMockRecorder is the mock recorder for MockTransactionImportRepository.
+type MockTransactionImportRepositoryMockRecorder struct {
+	mock *MockTransactionImportRepository
+}
+
+// NewMockTransactionImportRepository creates a new mock instance.
+func NewMockTransactionImportRepository(ctrl *gomock.Controller) *MockTransactionImportRepository {
+	mock := &MockTransactionImportRepository{ctrl: ctrl}
+	mock.recorder = &MockTransactionImportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactionImportRepository) EXPECT() *MockTransactionImportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ExistsByFileHash mocks base method.
+func (m *MockTransactionImportRepository) ExistsByFileHash(arg0 string) (bool, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistsByFileHash", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// ExistsByFileHash indicates an expected call of ExistsByFileHash.
+func (mr *MockTransactionImportRepositoryMockRecorder) ExistsByFileHash(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsByFileHash", reflect.TypeOf((*MockTransactionImportRepository)(nil).ExistsByFileHash), arg0)
+}
+
+// Save mocks base method.
+func (m *MockTransactionImportRepository) Save(arg0 domain.TransactionImport, arg1 []domain.TransactionImportEntry) (*domain.TransactionImport, []domain.TransactionImportEntry, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0, arg1)
+	ret0, _ := ret[0].(*domain.TransactionImport)
+	ret1, _ := ret[1].([]domain.TransactionImportEntry)
+	ret2, _ := ret[2].(*errs.AppError)
+	return ret0, ret1, ret2
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockTransactionImportRepositoryMockRecorder) Save(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockTransactionImportRepository)(nil).Save), arg0, arg1)
+}
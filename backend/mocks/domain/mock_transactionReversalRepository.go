@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/aliciatay-zls/banking/backend/domain (interfaces: TransactionReversalRepository)
+
+// Package domain is a generated GoMock package.
+package domain
+
+import (
+	reflect "reflect"
+
+	errs "github.com/aliciatay-zls/banking-lib/errs"
+	domain "github.com/aliciatay-zls/banking/backend/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTransactionReversalRepository is a mock of TransactionReversalRepository interface.
+type MockTransactionReversalRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactionReversalRepositoryMockRecorder
+}
+
+// MockTransactionReversalRepositoryMockRecorder is the mock recorder for MockTransactionReversalRepository.
+type MockTransactionReversalRepositoryMockRecorder struct {
+	mock *MockTransactionReversalRepository
+}
+
+// NewMockTransactionReversalRepository creates a new mock instance.
+func NewMockTransactionReversalRepository(ctrl *gomock.Controller) *MockTransactionReversalRepository {
+	mock := &MockTransactionReversalRepository{ctrl: ctrl}
+	mock.recorder = &MockTransactionReversalRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactionReversalRepository) EXPECT() *MockTransactionReversalRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindTransactionById mocks base method.
+func (m *MockTransactionReversalRepository) FindTransactionById(arg0 string) (*domain.Transaction, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTransactionById", arg0)
+	ret0, _ := ret[0].(*domain.Transaction)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// FindTransactionById indicates an expected call of FindTransactionById.
+func (mr *MockTransactionReversalRepositoryMockRecorder) FindTransactionById(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTransactionById", reflect.TypeOf((*MockTransactionReversalRepository)(nil).FindTransactionById), arg0)
+}
+
+// Save mocks base method.
+func (m *MockTransactionReversalRepository) Save(arg0 domain.TransactionReversal, arg1 domain.Transaction) (*domain.TransactionReversal, *domain.Transaction, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0, arg1)
+	ret0, _ := ret[0].(*domain.TransactionReversal)
+	ret1, _ := ret[1].(*domain.Transaction)
+	ret2, _ := ret[2].(*errs.AppError)
+	return ret0, ret1, ret2
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockTransactionReversalRepositoryMockRecorder) Save(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockTransactionReversalRepository)(nil).Save), arg0, arg1)
+}
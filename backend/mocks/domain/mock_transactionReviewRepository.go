@@ -0,0 +1,125 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/aliciatay-zls/banking/backend/domain (interfaces: TransactionReviewRepository)
+
+// Package domain is a generated GoMock package.
+package domain
+
+import (
+	reflect "reflect"
+
+	errs "github.com/aliciatay-zls/banking-lib/errs"
+	domain "github.com/aliciatay-zls/banking/backend/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTransactionReviewRepository is a mock of TransactionReviewRepository interface.
+type MockTransactionReviewRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactionReviewRepositoryMockRecorder
+}
+
+// MockTransactionReviewRepositoryMockRecorder is the mock recorder for MockTransactionReviewRepository.
+type MockTransactionReviewRepositoryMockRecorder struct {
+	mock *MockTransactionReviewRepository
+}
+
+// NewMockTransactionReviewRepository creates a new mock instance.
+func NewMockTransactionReviewRepository(ctrl *gomock.Controller) *MockTransactionReviewRepository {
+	mock := &MockTransactionReviewRepository{ctrl: ctrl}
+	mock.recorder = &MockTransactionReviewRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactionReviewRepository) EXPECT() *MockTransactionReviewRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindAll mocks base method.
+func (m *MockTransactionReviewRepository) FindAll(arg0 string) ([]domain.TransactionReview, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", arg0)
+	ret0, _ := ret[0].([]domain.TransactionReview)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockTransactionReviewRepositoryMockRecorder) FindAll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockTransactionReviewRepository)(nil).FindAll), arg0)
+}
+
+// FindById mocks base method.
+func (m *MockTransactionReviewRepository) FindById(arg0 string) (*domain.TransactionReview, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", arg0)
+	ret0, _ := ret[0].(*domain.TransactionReview)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockTransactionReviewRepositoryMockRecorder) FindById(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockTransactionReviewRepository)(nil).FindById), arg0)
+}
+
+// FindPending mocks base method.
+func (m *MockTransactionReviewRepository) FindPending() ([]domain.TransactionReview, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPending")
+	ret0, _ := ret[0].([]domain.TransactionReview)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// FindPending indicates an expected call of FindPending.
+func (mr *MockTransactionReviewRepositoryMockRecorder) FindPending() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPending", reflect.TypeOf((*MockTransactionReviewRepository)(nil).FindPending))
+}
+
+// FindReservedAmount mocks base method.
+func (m *MockTransactionReviewRepository) FindReservedAmount(arg0 string) (float64, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindReservedAmount", arg0)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// FindReservedAmount indicates an expected call of FindReservedAmount.
+func (mr *MockTransactionReviewRepositoryMockRecorder) FindReservedAmount(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindReservedAmount", reflect.TypeOf((*MockTransactionReviewRepository)(nil).FindReservedAmount), arg0)
+}
+
+// Save mocks base method.
+func (m *MockTransactionReviewRepository) Save(arg0 domain.TransactionReview) (*domain.TransactionReview, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0)
+	ret0, _ := ret[0].(*domain.TransactionReview)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockTransactionReviewRepositoryMockRecorder) Save(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockTransactionReviewRepository)(nil).Save), arg0)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockTransactionReviewRepository) UpdateStatus(arg0 domain.TransactionReview, arg1 string) *errs.AppError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", arg0, arg1)
+	ret0, _ := ret[0].(*errs.AppError)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockTransactionReviewRepositoryMockRecorder) UpdateStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockTransactionReviewRepository)(nil).UpdateStatus), arg0, arg1)
+}
@@ -65,6 +65,21 @@ func (mr *MockAccountServiceMockRecorder) GetAllAccounts(arg0 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllAccounts", reflect.TypeOf((*MockAccountService)(nil).GetAllAccounts), arg0)
 }
 
+// GetTransactions mocks base method.
+func (m *MockAccountService) GetTransactions(arg0, arg1 string) ([]dto.AccountTransactionResponse, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactions", arg0, arg1)
+	ret0, _ := ret[0].([]dto.AccountTransactionResponse)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// GetTransactions indicates an expected call of GetTransactions.
+func (mr *MockAccountServiceMockRecorder) GetTransactions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactions", reflect.TypeOf((*MockAccountService)(nil).GetTransactions), arg0, arg1)
+}
+
 // MakeTransaction mocks base method.
 func (m *MockAccountService) MakeTransaction(arg0 dto.TransactionRequest) (*dto.TransactionResponse, *errs.AppError) {
 	m.ctrl.T.Helper()
@@ -79,3 +94,18 @@ func (mr *MockAccountServiceMockRecorder) MakeTransaction(arg0 interface{}) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MakeTransaction", reflect.TypeOf((*MockAccountService)(nil).MakeTransaction), arg0)
 }
+
+// UpdateTransactionCategory mocks base method.
+func (m *MockAccountService) UpdateTransactionCategory(arg0 dto.TransactionCategoryRequest) (*dto.AccountTransactionResponse, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTransactionCategory", arg0)
+	ret0, _ := ret[0].(*dto.AccountTransactionResponse)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// UpdateTransactionCategory indicates an expected call of UpdateTransactionCategory.
+func (mr *MockAccountServiceMockRecorder) UpdateTransactionCategory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTransactionCategory", reflect.TypeOf((*MockAccountService)(nil).UpdateTransactionCategory), arg0)
+}
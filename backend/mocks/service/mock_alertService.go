@@ -0,0 +1,108 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/aliciatay-zls/banking/backend/service (interfaces: AlertService)
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	reflect "reflect"
+
+	errs "github.com/aliciatay-zls/banking-lib/errs"
+	domain "github.com/aliciatay-zls/banking/backend/domain"
+	dto "github.com/aliciatay-zls/banking/backend/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAlertService is a mock of AlertService interface.
+type MockAlertService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAlertServiceMockRecorder
+}
+
+// MockAlertServiceMockRecorder is the mock recorder for MockAlertService.
+type MockAlertServiceMockRecorder struct {
+	mock *MockAlertService
+}
+
+// NewMockAlertService creates a new mock instance.
+func NewMockAlertService(ctrl *gomock.Controller) *MockAlertService {
+	mock := &MockAlertService{ctrl: ctrl}
+	mock.recorder = &MockAlertServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAlertService) EXPECT() *MockAlertServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateRule mocks base method.
+func (m *MockAlertService) CreateRule(arg0 dto.NewAlertRuleRequest) (*dto.AlertRuleResponse, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRule", arg0)
+	ret0, _ := ret[0].(*dto.AlertRuleResponse)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// CreateRule indicates an expected call of CreateRule.
+func (mr *MockAlertServiceMockRecorder) CreateRule(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRule", reflect.TypeOf((*MockAlertService)(nil).CreateRule), arg0)
+}
+
+// DeleteRule mocks base method.
+func (m *MockAlertService) DeleteRule(arg0, arg1, arg2 string) *errs.AppError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRule", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*errs.AppError)
+	return ret0
+}
+
+// DeleteRule indicates an expected call of DeleteRule.
+func (mr *MockAlertServiceMockRecorder) DeleteRule(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRule", reflect.TypeOf((*MockAlertService)(nil).DeleteRule), arg0, arg1, arg2)
+}
+
+// EvaluateTransaction mocks base method.
+func (m *MockAlertService) EvaluateTransaction(arg0 string, arg1 domain.Transaction) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "EvaluateTransaction", arg0, arg1)
+}
+
+// EvaluateTransaction indicates an expected call of EvaluateTransaction.
+func (mr *MockAlertServiceMockRecorder) EvaluateTransaction(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EvaluateTransaction", reflect.TypeOf((*MockAlertService)(nil).EvaluateTransaction), arg0, arg1)
+}
+
+// GetAlerts mocks base method.
+func (m *MockAlertService) GetAlerts(arg0 string) ([]dto.AlertResponse, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlerts", arg0)
+	ret0, _ := ret[0].([]dto.AlertResponse)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// GetAlerts indicates an expected call of GetAlerts.
+func (mr *MockAlertServiceMockRecorder) GetAlerts(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlerts", reflect.TypeOf((*MockAlertService)(nil).GetAlerts), arg0)
+}
+
+// GetRules mocks base method.
+func (m *MockAlertService) GetRules(arg0, arg1 string) ([]dto.AlertRuleResponse, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRules", arg0, arg1)
+	ret0, _ := ret[0].([]dto.AlertRuleResponse)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// GetRules indicates an expected call of GetRules.
+func (mr *MockAlertServiceMockRecorder) GetRules(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRules", reflect.TypeOf((*MockAlertService)(nil).GetRules), arg0, arg1)
+}
@@ -0,0 +1,125 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/aliciatay-zls/banking/backend/service (interfaces: ApprovalService)
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	reflect "reflect"
+
+	errs "github.com/aliciatay-zls/banking-lib/errs"
+	domain "github.com/aliciatay-zls/banking/backend/domain"
+	dto "github.com/aliciatay-zls/banking/backend/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockApprovalService is a mock of ApprovalService interface.
+type MockApprovalService struct {
+	ctrl     *gomock.Controller
+	recorder *MockApprovalServiceMockRecorder
+}
+
+// MockApprovalServiceMockRecorder is the mock recorder for MockApprovalService.
+type MockApprovalServiceMockRecorder struct {
+	mock *MockApprovalService
+}
+
+// NewMockApprovalService creates a new mock instance.
+func NewMockApprovalService(ctrl *gomock.Controller) *MockApprovalService {
+	mock := &MockApprovalService{ctrl: ctrl}
+	mock.recorder = &MockApprovalServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApprovalService) EXPECT() *MockApprovalServiceMockRecorder {
+	return m.recorder
+}
+
+// Approve mocks base method.
+func (m *MockApprovalService) Approve(arg0, arg1 string) (*dto.ApprovalResponse, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Approve", arg0, arg1)
+	ret0, _ := ret[0].(*dto.ApprovalResponse)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// Approve indicates an expected call of Approve.
+func (mr *MockApprovalServiceMockRecorder) Approve(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Approve", reflect.TypeOf((*MockApprovalService)(nil).Approve), arg0, arg1)
+}
+
+// GetPendingApprovals mocks base method.
+func (m *MockApprovalService) GetPendingApprovals() ([]dto.ApprovalResponse, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingApprovals")
+	ret0, _ := ret[0].([]dto.ApprovalResponse)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// GetPendingApprovals indicates an expected call of GetPendingApprovals.
+func (mr *MockApprovalServiceMockRecorder) GetPendingApprovals() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingApprovals", reflect.TypeOf((*MockApprovalService)(nil).GetPendingApprovals))
+}
+
+// IsReversalApprovalRequired mocks base method.
+func (m *MockApprovalService) IsReversalApprovalRequired(arg0 dto.TransactionReversalRequest, arg1 float64) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsReversalApprovalRequired", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsReversalApprovalRequired indicates an expected call of IsReversalApprovalRequired.
+func (mr *MockApprovalServiceMockRecorder) IsReversalApprovalRequired(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsReversalApprovalRequired", reflect.TypeOf((*MockApprovalService)(nil).IsReversalApprovalRequired), arg0, arg1)
+}
+
+// IsTransactionApprovalRequired mocks base method.
+func (m *MockApprovalService) IsTransactionApprovalRequired(arg0 domain.AuthClaims, arg1 dto.TransactionRequest) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsTransactionApprovalRequired", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsTransactionApprovalRequired indicates an expected call of IsTransactionApprovalRequired.
+func (mr *MockApprovalServiceMockRecorder) IsTransactionApprovalRequired(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsTransactionApprovalRequired", reflect.TypeOf((*MockApprovalService)(nil).IsTransactionApprovalRequired), arg0, arg1)
+}
+
+// Reject mocks base method.
+func (m *MockApprovalService) Reject(arg0, arg1 string) (*dto.ApprovalResponse, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reject", arg0, arg1)
+	ret0, _ := ret[0].(*dto.ApprovalResponse)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// Reject indicates an expected call of Reject.
+func (mr *MockApprovalServiceMockRecorder) Reject(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reject", reflect.TypeOf((*MockApprovalService)(nil).Reject), arg0, arg1)
+}
+
+// RequestApproval mocks base method.
+func (m *MockApprovalService) RequestApproval(arg0, arg1 string, arg2 interface{}, arg3 string) (*dto.ApprovalResponse, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestApproval", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*dto.ApprovalResponse)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// RequestApproval indicates an expected call of RequestApproval.
+func (mr *MockApprovalServiceMockRecorder) RequestApproval(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestApproval", reflect.TypeOf((*MockApprovalService)(nil).RequestApproval), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,108 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/aliciatay-zls/banking/backend/service (interfaces: FeeService)
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	reflect "reflect"
+
+	errs "github.com/aliciatay-zls/banking-lib/errs"
+	domain "github.com/aliciatay-zls/banking/backend/domain"
+	dto "github.com/aliciatay-zls/banking/backend/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFeeService is a mock of FeeService interface.
+type MockFeeService struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeeServiceMockRecorder
+}
+
+// MockFeeServiceMockRecorder is the mock recorder for MockFeeService.
+type MockFeeServiceMockRecorder struct {
+	mock *MockFeeService
+}
+
+// NewMockFeeService creates a new mock instance.
+func NewMockFeeService(ctrl *gomock.Controller) *MockFeeService {
+	mock := &MockFeeService{ctrl: ctrl}
+	mock.recorder = &MockFeeServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeeService) EXPECT() *MockFeeServiceMockRecorder {
+	return m.recorder
+}
+
+// ChargeForTransaction mocks base method.
+func (m *MockFeeService) ChargeForTransaction(arg0 domain.Transaction) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ChargeForTransaction", arg0)
+}
+
+// ChargeForTransaction indicates an expected call of ChargeForTransaction.
+func (mr *MockFeeServiceMockRecorder) ChargeForTransaction(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChargeForTransaction", reflect.TypeOf((*MockFeeService)(nil).ChargeForTransaction), arg0)
+}
+
+// ChargeMonthlyFees mocks base method.
+func (m *MockFeeService) ChargeMonthlyFees(arg0 string) (*dto.MonthlyFeeReport, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChargeMonthlyFees", arg0)
+	ret0, _ := ret[0].(*dto.MonthlyFeeReport)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// ChargeMonthlyFees indicates an expected call of ChargeMonthlyFees.
+func (mr *MockFeeServiceMockRecorder) ChargeMonthlyFees(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChargeMonthlyFees", reflect.TypeOf((*MockFeeService)(nil).ChargeMonthlyFees), arg0)
+}
+
+// CreateSchedule mocks base method.
+func (m *MockFeeService) CreateSchedule(arg0 dto.NewFeeScheduleRequest) (*dto.FeeScheduleResponse, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSchedule", arg0)
+	ret0, _ := ret[0].(*dto.FeeScheduleResponse)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// CreateSchedule indicates an expected call of CreateSchedule.
+func (mr *MockFeeServiceMockRecorder) CreateSchedule(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSchedule", reflect.TypeOf((*MockFeeService)(nil).CreateSchedule), arg0)
+}
+
+// DeleteSchedule mocks base method.
+func (m *MockFeeService) DeleteSchedule(arg0 string) *errs.AppError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSchedule", arg0)
+	ret0, _ := ret[0].(*errs.AppError)
+	return ret0
+}
+
+// DeleteSchedule indicates an expected call of DeleteSchedule.
+func (mr *MockFeeServiceMockRecorder) DeleteSchedule(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSchedule", reflect.TypeOf((*MockFeeService)(nil).DeleteSchedule), arg0)
+}
+
+// GetSchedules mocks base method.
+func (m *MockFeeService) GetSchedules() ([]dto.FeeScheduleResponse, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchedules")
+	ret0, _ := ret[0].([]dto.FeeScheduleResponse)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// GetSchedules indicates an expected call of GetSchedules.
+func (mr *MockFeeServiceMockRecorder) GetSchedules() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchedules", reflect.TypeOf((*MockFeeService)(nil).GetSchedules))
+}
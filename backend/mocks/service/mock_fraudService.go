@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/aliciatay-zls/banking/backend/service (interfaces: FraudService)
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	reflect "reflect"
+
+	errs "github.com/aliciatay-zls/banking-lib/errs"
+	domain "github.com/aliciatay-zls/banking/backend/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFraudService is a mock of FraudService interface.
+type MockFraudService struct {
+	ctrl     *gomock.Controller
+	recorder *MockFraudServiceMockRecorder
+}
+
+// MockFraudServiceMockRecorder is the mock recorder for MockFraudService.
+type MockFraudServiceMockRecorder struct {
+	mock *MockFraudService
+}
+
+// NewMockFraudService creates a new mock instance.
+func NewMockFraudService(ctrl *gomock.Controller) *MockFraudService {
+	mock := &MockFraudService{ctrl: ctrl}
+	mock.recorder = &MockFraudServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFraudService) EXPECT() *MockFraudServiceMockRecorder {
+	return m.recorder
+}
+
+// Screen mocks base method.
+func (m *MockFraudService) Screen(arg0 domain.Account, arg1 domain.Transaction) (*domain.FraudDecision, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Screen", arg0, arg1)
+	ret0, _ := ret[0].(*domain.FraudDecision)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// Screen indicates an expected call of Screen.
+func (mr *MockFraudServiceMockRecorder) Screen(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Screen", reflect.TypeOf((*MockFraudService)(nil).Screen), arg0, arg1)
+}
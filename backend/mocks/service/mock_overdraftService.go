@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/aliciatay-zls/banking/backend/service (interfaces: OverdraftService)
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	reflect "reflect"
+
+	errs "github.com/aliciatay-zls/banking-lib/errs"
+	domain "github.com/aliciatay-zls/banking/backend/domain"
+	dto "github.com/aliciatay-zls/banking/backend/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOverdraftService is a mock of OverdraftService interface.
+type MockOverdraftService struct {
+	ctrl     *gomock.Controller
+	recorder *MockOverdraftServiceMockRecorder
+}
+
+// MockOverdraftServiceMockRecorder is the mock recorder for MockOverdraftService.
+type MockOverdraftServiceMockRecorder struct {
+	mock *MockOverdraftService
+}
+
+// NewMockOverdraftService creates a new mock instance.
+func NewMockOverdraftService(ctrl *gomock.Controller) *MockOverdraftService {
+	mock := &MockOverdraftService{ctrl: ctrl}
+	mock.recorder = &MockOverdraftServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOverdraftService) EXPECT() *MockOverdraftServiceMockRecorder {
+	return m.recorder
+}
+
+// ChargeForTransaction mocks base method.
+func (m *MockOverdraftService) ChargeForTransaction(arg0 domain.Transaction) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ChargeForTransaction", arg0)
+}
+
+// ChargeForTransaction indicates an expected call of ChargeForTransaction.
+func (mr *MockOverdraftServiceMockRecorder) ChargeForTransaction(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChargeForTransaction", reflect.TypeOf((*MockOverdraftService)(nil).ChargeForTransaction), arg0)
+}
+
+// ChargeInterest mocks base method.
+func (m *MockOverdraftService) ChargeInterest() (*dto.OverdraftInterestReport, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChargeInterest")
+	ret0, _ := ret[0].(*dto.OverdraftInterestReport)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// ChargeInterest indicates an expected call of ChargeInterest.
+func (mr *MockOverdraftServiceMockRecorder) ChargeInterest() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChargeInterest", reflect.TypeOf((*MockOverdraftService)(nil).ChargeInterest))
+}
+
+// GetOverdraft mocks base method.
+func (m *MockOverdraftService) GetOverdraft(arg0, arg1 string) (*dto.OverdraftResponse, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOverdraft", arg0, arg1)
+	ret0, _ := ret[0].(*dto.OverdraftResponse)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// GetOverdraft indicates an expected call of GetOverdraft.
+func (mr *MockOverdraftServiceMockRecorder) GetOverdraft(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOverdraft", reflect.TypeOf((*MockOverdraftService)(nil).GetOverdraft), arg0, arg1)
+}
+
+// SetLimit mocks base method.
+func (m *MockOverdraftService) SetLimit(arg0 dto.OverdraftLimitRequest) (*dto.OverdraftResponse, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLimit", arg0)
+	ret0, _ := ret[0].(*dto.OverdraftResponse)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// SetLimit indicates an expected call of SetLimit.
+func (mr *MockOverdraftServiceMockRecorder) SetLimit(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLimit", reflect.TypeOf((*MockOverdraftService)(nil).SetLimit), arg0)
+}
@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/aliciatay-zls/banking/backend/service (interfaces: PayeeService)
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	reflect "reflect"
+
+	errs "github.com/aliciatay-zls/banking-lib/errs"
+	dto "github.com/aliciatay-zls/banking/backend/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPayeeService is a mock of PayeeService interface.
+type MockPayeeService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPayeeServiceMockRecorder
+}
+
+// MockPayeeServiceMockRecorder is the mock recorder for MockPayeeService.
+type MockPayeeServiceMockRecorder struct {
+	mock *MockPayeeService
+}
+
+// NewMockPayeeService creates a new mock instance.
+func NewMockPayeeService(ctrl *gomock.Controller) *MockPayeeService {
+	mock := &MockPayeeService{ctrl: ctrl}
+	mock.recorder = &MockPayeeServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPayeeService) EXPECT() *MockPayeeServiceMockRecorder {
+	return m.recorder
+}
+
+// AddPayee mocks base method.
+func (m *MockPayeeService) AddPayee(arg0 dto.NewPayeeRequest) (*dto.PayeeResponse, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPayee", arg0)
+	ret0, _ := ret[0].(*dto.PayeeResponse)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// AddPayee indicates an expected call of AddPayee.
+func (mr *MockPayeeServiceMockRecorder) AddPayee(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPayee", reflect.TypeOf((*MockPayeeService)(nil).AddPayee), arg0)
+}
+
+// DeletePayee mocks base method.
+func (m *MockPayeeService) DeletePayee(arg0, arg1, arg2 string) *errs.AppError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePayee", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*errs.AppError)
+	return ret0
+}
+
+// DeletePayee indicates an expected call of DeletePayee.
+func (mr *MockPayeeServiceMockRecorder) DeletePayee(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePayee", reflect.TypeOf((*MockPayeeService)(nil).DeletePayee), arg0, arg1, arg2)
+}
+
+// GetAuditTrail mocks base method.
+func (m *MockPayeeService) GetAuditTrail(arg0 string) ([]dto.AuditEntryResponse, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditTrail", arg0)
+	ret0, _ := ret[0].([]dto.AuditEntryResponse)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// GetAuditTrail indicates an expected call of GetAuditTrail.
+func (mr *MockPayeeServiceMockRecorder) GetAuditTrail(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditTrail", reflect.TypeOf((*MockPayeeService)(nil).GetAuditTrail), arg0)
+}
+
+// GetPayees mocks base method.
+func (m *MockPayeeService) GetPayees(arg0 string) ([]dto.PayeeResponse, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPayees", arg0)
+	ret0, _ := ret[0].([]dto.PayeeResponse)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// GetPayees indicates an expected call of GetPayees.
+func (mr *MockPayeeServiceMockRecorder) GetPayees(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayees", reflect.TypeOf((*MockPayeeService)(nil).GetPayees), arg0)
+}
+
+// RenamePayee mocks base method.
+func (m *MockPayeeService) RenamePayee(arg0 dto.PayeeNicknameRequest) (*dto.PayeeResponse, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenamePayee", arg0)
+	ret0, _ := ret[0].(*dto.PayeeResponse)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// RenamePayee indicates an expected call of RenamePayee.
+func (mr *MockPayeeServiceMockRecorder) RenamePayee(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenamePayee", reflect.TypeOf((*MockPayeeService)(nil).RenamePayee), arg0)
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/aliciatay-zls/banking/backend/service (interfaces: TransactionImportService)
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	reflect "reflect"
+
+	errs "github.com/aliciatay-zls/banking-lib/errs"
+	dto "github.com/aliciatay-zls/banking/backend/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTransactionImportService is a mock of TransactionImportService interface.
+type MockTransactionImportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactionImportServiceMockRecorder
+}
+
+// MockTransactionImportServiceMockRecorder is the mock recorder for MockTransactionImportService.
+type MockTransactionImportServiceMockRecorder struct {
+	mock *MockTransactionImportService
+}
+
+// NewMockTransactionImportService creates a new mock instance.
+func NewMockTransactionImportService(ctrl *gomock.Controller) *MockTransactionImportService {
+	mock := &MockTransactionImportService{ctrl: ctrl}
+	mock.recorder = &MockTransactionImportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactionImportService) EXPECT() *MockTransactionImportServiceMockRecorder {
+	return m.recorder
+}
+
+// ImportTransactions mocks base method.
+func (m *MockTransactionImportService) ImportTransactions(arg0 dto.TransactionImportRequest) (*dto.TransactionImportResponse, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportTransactions", arg0)
+	ret0, _ := ret[0].(*dto.TransactionImportResponse)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// ImportTransactions indicates an expected call of ImportTransactions.
+func (mr *MockTransactionImportServiceMockRecorder) ImportTransactions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportTransactions", reflect.TypeOf((*MockTransactionImportService)(nil).ImportTransactions), arg0)
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/aliciatay-zls/banking/backend/service (interfaces: TransactionReversalService)
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	reflect "reflect"
+
+	errs "github.com/aliciatay-zls/banking-lib/errs"
+	dto "github.com/aliciatay-zls/banking/backend/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTransactionReversalService is a mock of TransactionReversalService interface.
+type MockTransactionReversalService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactionReversalServiceMockRecorder
+}
+
+// MockTransactionReversalServiceMockRecorder is the mock recorder for MockTransactionReversalService.
+type MockTransactionReversalServiceMockRecorder struct {
+	mock *MockTransactionReversalService
+}
+
+// NewMockTransactionReversalService creates a new mock instance.
+func NewMockTransactionReversalService(ctrl *gomock.Controller) *MockTransactionReversalService {
+	mock := &MockTransactionReversalService{ctrl: ctrl}
+	mock.recorder = &MockTransactionReversalServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactionReversalService) EXPECT() *MockTransactionReversalServiceMockRecorder {
+	return m.recorder
+}
+
+// GetReversibleTransaction mocks base method.
+func (m *MockTransactionReversalService) GetReversibleTransaction(arg0 string) (*dto.AccountTransactionResponse, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReversibleTransaction", arg0)
+	ret0, _ := ret[0].(*dto.AccountTransactionResponse)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// GetReversibleTransaction indicates an expected call of GetReversibleTransaction.
+func (mr *MockTransactionReversalServiceMockRecorder) GetReversibleTransaction(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReversibleTransaction", reflect.TypeOf((*MockTransactionReversalService)(nil).GetReversibleTransaction), arg0)
+}
+
+// Reverse mocks base method.
+func (m *MockTransactionReversalService) Reverse(arg0 dto.TransactionReversalRequest) (*dto.TransactionReversalResponse, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reverse", arg0)
+	ret0, _ := ret[0].(*dto.TransactionReversalResponse)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// Reverse indicates an expected call of Reverse.
+func (mr *MockTransactionReversalServiceMockRecorder) Reverse(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reverse", reflect.TypeOf((*MockTransactionReversalService)(nil).Reverse), arg0)
+}
@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/aliciatay-zls/banking/backend/service (interfaces: TransactionReviewService)
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	reflect "reflect"
+
+	errs "github.com/aliciatay-zls/banking-lib/errs"
+	dto "github.com/aliciatay-zls/banking/backend/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTransactionReviewService is a mock of TransactionReviewService interface.
+type MockTransactionReviewService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactionReviewServiceMockRecorder
+}
+
+// MockTransactionReviewServiceMockRecorder is the mock recorder for MockTransactionReviewService.
+type MockTransactionReviewServiceMockRecorder struct {
+	mock *MockTransactionReviewService
+}
+
+// NewMockTransactionReviewService creates a new mock instance.
+func NewMockTransactionReviewService(ctrl *gomock.Controller) *MockTransactionReviewService {
+	mock := &MockTransactionReviewService{ctrl: ctrl}
+	mock.recorder = &MockTransactionReviewServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactionReviewService) EXPECT() *MockTransactionReviewServiceMockRecorder {
+	return m.recorder
+}
+
+// Approve mocks base method.
+func (m *MockTransactionReviewService) Approve(arg0 dto.TransactionReviewRequest) (*dto.TransactionReviewResponse, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Approve", arg0)
+	ret0, _ := ret[0].(*dto.TransactionReviewResponse)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// Approve indicates an expected call of Approve.
+func (mr *MockTransactionReviewServiceMockRecorder) Approve(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Approve", reflect.TypeOf((*MockTransactionReviewService)(nil).Approve), arg0)
+}
+
+// GetPendingReviews mocks base method.
+func (m *MockTransactionReviewService) GetPendingReviews() ([]dto.TransactionReviewResponse, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingReviews")
+	ret0, _ := ret[0].([]dto.TransactionReviewResponse)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// GetPendingReviews indicates an expected call of GetPendingReviews.
+func (mr *MockTransactionReviewServiceMockRecorder) GetPendingReviews() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingReviews", reflect.TypeOf((*MockTransactionReviewService)(nil).GetPendingReviews))
+}
+
+// Reject mocks base method.
+func (m *MockTransactionReviewService) Reject(arg0 dto.TransactionReviewRequest) (*dto.TransactionReviewResponse, *errs.AppError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reject", arg0)
+	ret0, _ := ret[0].(*dto.TransactionReviewResponse)
+	ret1, _ := ret[1].(*errs.AppError)
+	return ret0, ret1
+}
+
+// Reject indicates an expected call of Reject.
+func (mr *MockTransactionReviewServiceMockRecorder) Reject(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reject", reflect.TypeOf((*MockTransactionReviewService)(nil).Reject), arg0)
+}
@@ -0,0 +1,143 @@
+// Package openapi builds an OpenAPI 3 document of the API from the mux routes and the DTOs, so that the document
+// cannot drift from the code, and validates requests against it.
+package openapi
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const Version = "3.0.3"
+
+type Document struct {
+	OpenApi    string                `json:"openapi"`
+	Info       Info                  `json:"info"`
+	Servers    []Server              `json:"servers,omitempty"`
+	Paths      map[string]PathItem   `json:"paths"`
+	Components Components            `json:"components"`
+	Security   []SecurityRequirement `json:"security,omitempty"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+type Server struct {
+	Url string `json:"url"`
+}
+
+// PathItem holds the operations of a path by lowercase method.
+type PathItem map[string]*Operation
+
+type Operation struct {
+	OperationId string                 `json:"operationId"`
+	Summary     string                 `json:"summary,omitempty"`
+	Tags        []string               `json:"tags,omitempty"`
+	Parameters  []Parameter            `json:"parameters,omitempty"`
+	RequestBody *RequestBody           `json:"requestBody,omitempty"`
+	Responses   map[string]Response    `json:"responses"`
+	Security    *[]SecurityRequirement `json:"security,omitempty"` //an empty list makes the operation public
+	Deprecated  bool                   `json:"deprecated,omitempty"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"` //path or query
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required,omitempty"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Headers     map[string]Header    `json:"headers,omitempty"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type Header struct {
+	Description string  `json:"description,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type SecurityScheme struct {
+	Type        string `json:"type"`
+	In          string `json:"in,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// SecurityRequirement lists the security schemes, by name, that a request must satisfy.
+type SecurityRequirement map[string][]string
+
+func NewDocument(info Info) *Document {
+	return &Document{
+		OpenApi:    Version,
+		Info:       info,
+		Paths:      make(map[string]PathItem),
+		Components: Components{Schemas: make(map[string]*Schema)},
+	}
+}
+
+// routeVarPattern matches a variable in a mux path template, e.g. {customer_id:[0-9]+}.
+var routeVarPattern = regexp.MustCompile(`\{([^{}:]+)(?::([^{}]+))?}`)
+
+// AddOperation adds the given operation to the document under the given method and mux path template, adding a path
+// parameter for each variable in the template. It returns the path in the document, which has no variable patterns.
+func (d *Document) AddOperation(method string, pathTemplate string, op *Operation) string {
+	for _, match := range routeVarPattern.FindAllStringSubmatch(pathTemplate, -1) {
+		schema := &Schema{Type: TypeString}
+		if match[2] != "" {
+			schema.Pattern = "^" + match[2] + "$"
+		}
+		op.Parameters = append(op.Parameters, Parameter{Name: match[1], In: "path", Required: true, Schema: schema})
+	}
+
+	path := PathOf(pathTemplate)
+	if d.Paths[path] == nil {
+		d.Paths[path] = make(PathItem)
+	}
+	d.Paths[path][strings.ToLower(method)] = op
+	return path
+}
+
+// Operation returns the operation with the given method and path in the document, if there is one.
+func (d *Document) Operation(method string, path string) (*Operation, bool) {
+	op, ok := d.Paths[path][strings.ToLower(method)]
+	return op, ok
+}
+
+// PathOf returns the path in the document of the given mux path template.
+func PathOf(pathTemplate string) string {
+	return routeVarPattern.ReplaceAllString(pathTemplate, "{$1}")
+}
+
+// Resolve returns the component schema that the given schema refers to, or the schema itself if it is not a
+// reference.
+func (d *Document) Resolve(s *Schema) (*Schema, error) {
+	if s == nil || s.Ref == "" {
+		return s, nil
+	}
+	name := strings.TrimPrefix(s.Ref, componentSchemaPrefix)
+	component, ok := d.Components.Schemas[name]
+	if !ok {
+		return nil, fmt.Errorf("schema %s is not in the document", s.Ref)
+	}
+	return component, nil
+}
@@ -0,0 +1,53 @@
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestDocument_AddOperation_adds_pathParameters_from_routeVars(t *testing.T) {
+	//Arrange
+	doc := NewDocument(Info{Title: "Test", Version: "1"})
+	op := &Operation{OperationId: "GetHold"}
+	expectedParameters := []Parameter{
+		{Name: "customer_id", In: "path", Required: true, Schema: &Schema{Type: TypeString, Pattern: "^[0-9]+$"}},
+		{Name: "hold_id", In: "path", Required: true, Schema: &Schema{Type: TypeString}},
+	}
+
+	//Act
+	path := doc.AddOperation(http.MethodGet, "/customers/{customer_id:[0-9]+}/holds/{hold_id}", op)
+
+	//Assert
+	if path != "/customers/{customer_id}/holds/{hold_id}" {
+		t.Errorf("Expected path without patterns but got %s", path)
+	}
+	if !reflect.DeepEqual(op.Parameters, expectedParameters) {
+		t.Errorf("Expected parameters %v but got %v", expectedParameters, op.Parameters)
+	}
+	if actual, ok := doc.Operation(http.MethodGet, path); !ok || actual != op {
+		t.Errorf("Expected operation to be found under GET %s but it was not", path)
+	}
+	if _, ok := doc.Operation(http.MethodPost, path); ok {
+		t.Errorf("Expected no operation under POST %s but found one", path)
+	}
+}
+
+func TestDocument_Resolve_returns_componentSchema_of_reference(t *testing.T) {
+	//Arrange
+	doc := NewDocument(Info{Title: "Test", Version: "1"})
+	component := &Schema{Type: TypeObject}
+	doc.Components.Schemas["Thing"] = component
+
+	//Act
+	actual, err := doc.Resolve(RefTo("Thing"))
+	_, missingErr := doc.Resolve(RefTo("Missing"))
+
+	//Assert
+	if err != nil || actual != component {
+		t.Errorf("Expected component schema but got %v and error %v", actual, err)
+	}
+	if missingErr == nil {
+		t.Error("Expected an error for a schema not in the document but got none")
+	}
+}
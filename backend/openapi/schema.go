@@ -0,0 +1,216 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const TypeString = "string"
+const TypeNumber = "number"
+const TypeInteger = "integer"
+const TypeBoolean = "boolean"
+const TypeArray = "array"
+const TypeObject = "object"
+
+const componentSchemaPrefix = "#/components/schemas/"
+
+// Schema is the subset of the OpenAPI schema object that the DTOs need.
+type Schema struct {
+	Ref              string             `json:"$ref,omitempty"`
+	Type             string             `json:"type,omitempty"`
+	Format           string             `json:"format,omitempty"`
+	Description      string             `json:"description,omitempty"`
+	Nullable         bool               `json:"nullable,omitempty"`
+	Enum             []string           `json:"enum,omitempty"`
+	Pattern          string             `json:"pattern,omitempty"`
+	MinLength        *int               `json:"minLength,omitempty"`
+	MaxLength        *int               `json:"maxLength,omitempty"`
+	Minimum          *float64           `json:"minimum,omitempty"`
+	ExclusiveMinimum bool               `json:"exclusiveMinimum,omitempty"`
+	Maximum          *float64           `json:"maximum,omitempty"`
+	MinItems         *int               `json:"minItems,omitempty"`
+	MaxItems         *int               `json:"maxItems,omitempty"`
+	Items            *Schema            `json:"items,omitempty"`
+	Properties       map[string]*Schema `json:"properties,omitempty"`
+	Required         []string           `json:"required,omitempty"`
+}
+
+// RefTo returns a reference to the component schema with the given name.
+func RefTo(name string) *Schema {
+	return &Schema{Ref: componentSchemaPrefix + name}
+}
+
+// SchemaOf returns the schema of values of the type of v, adding the schema of each struct in it to the components
+// of the document and referring to it by the name of the struct. Struct fields are named by their json tag and
+// constrained by their validate tag, as checked by the formValidator.
+func (d *Document) SchemaOf(v interface{}) *Schema {
+	return d.schemaOfType(reflect.TypeOf(v))
+}
+
+var rawMessageType = reflect.TypeOf(json.RawMessage{})
+
+func (d *Document) schemaOfType(t reflect.Type) *Schema {
+	switch t.Kind() {
+	case reflect.Pointer:
+		s := d.schemaOfType(t.Elem())
+		if s.Ref != "" { //a reference cannot have other fields
+			return s
+		}
+		s.Nullable = true
+		return s
+	case reflect.Slice:
+		if t == rawMessageType {
+			return &Schema{} //any JSON value
+		}
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: TypeString, Format: "binary"}
+		}
+		return &Schema{Type: TypeArray, Items: d.schemaOfType(t.Elem())}
+	case reflect.Struct:
+		if _, ok := d.Components.Schemas[t.Name()]; !ok {
+			d.Components.Schemas[t.Name()] = &Schema{} //placeholder in case the struct refers to itself
+			d.Components.Schemas[t.Name()] = d.structSchema(t)
+		}
+		return RefTo(t.Name())
+	case reflect.String:
+		return &Schema{Type: TypeString}
+	case reflect.Bool:
+		return &Schema{Type: TypeBoolean}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: TypeInteger}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: TypeNumber, Format: "double"}
+	case reflect.Map:
+		return &Schema{Type: TypeObject}
+	default:
+		return &Schema{}
+	}
+}
+
+func (d *Document) structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: TypeObject, Properties: make(map[string]*Schema)}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		fieldSchema := d.schemaOfType(field.Type)
+		if fieldSchema.Ref == "" && applyValidateTag(fieldSchema, field.Tag.Get("validate")) {
+			s.Required = append(s.Required, name)
+		}
+		s.Properties[name] = fieldSchema
+	}
+	return s
+}
+
+// applyValidateTag adds the rules of the given validate tag to the schema of the field, and reports whether the tag
+// makes the field required. Rules after "dive" apply to the items of an array.
+func applyValidateTag(s *Schema, tag string) bool {
+	if tag == "" {
+		return false
+	}
+	isRequired, isOmitEmpty := false, false
+	target := s
+	var pattern string
+	for _, rule := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "required":
+			isRequired = target == s
+		case "omitempty":
+			isOmitEmpty = true
+		case "dive":
+			if target.Items != nil {
+				target = target.Items
+			}
+		case "min", "gte", "gt":
+			setLowerBound(target, param, name == "gt")
+		case "max", "lte":
+			setUpperBound(target, param)
+		case "oneof":
+			target.Enum = strings.Fields(param)
+			if isOmitEmpty {
+				target.Enum = append(target.Enum, "")
+			}
+		case "number":
+			if target.Type == TypeString {
+				pattern = "[0-9]+"
+			}
+		case "alpha":
+			pattern = "[a-zA-Z]+"
+		case "printascii":
+			pattern = "[ -~]*"
+		case "datetime":
+			if param == "2006-01-02" {
+				target.Format = "date"
+			} else {
+				pattern = datetimePattern(param)
+			}
+		case "url":
+			target.Format = "uri"
+		}
+	}
+
+	if pattern != "" && target.Enum == nil { //an enum is stricter than any pattern
+		if isOmitEmpty && !strings.HasSuffix(pattern, "*") { //an empty string is left out, so it must match too
+			pattern = "(" + pattern + ")?"
+		}
+		target.Pattern = "^" + pattern + "$"
+	}
+	return isRequired
+}
+
+// setLowerBound sets the given bound as the least length of a string, number of items of an array, or value of a
+// number.
+func setLowerBound(s *Schema, param string, isExclusive bool) {
+	switch s.Type {
+	case TypeString:
+		if n, err := strconv.Atoi(param); err == nil {
+			s.MinLength = &n
+		}
+	case TypeArray:
+		if n, err := strconv.Atoi(param); err == nil {
+			s.MinItems = &n
+		}
+	case TypeNumber, TypeInteger:
+		if f, err := strconv.ParseFloat(param, 64); err == nil {
+			s.Minimum = &f
+			s.ExclusiveMinimum = isExclusive
+		}
+	}
+}
+
+// setUpperBound sets the given bound as the greatest length of a string, number of items of an array, or value of a
+// number.
+func setUpperBound(s *Schema, param string) {
+	switch s.Type {
+	case TypeString:
+		if n, err := strconv.Atoi(param); err == nil {
+			s.MaxLength = &n
+		}
+	case TypeArray:
+		if n, err := strconv.Atoi(param); err == nil {
+			s.MaxItems = &n
+		}
+	case TypeNumber, TypeInteger:
+		if f, err := strconv.ParseFloat(param, 64); err == nil {
+			s.Maximum = &f
+		}
+	}
+}
+
+var layoutDigit = regexp.MustCompile(`[0-9]`)
+
+// datetimePattern returns a pattern for times in the given Go layout made only of numbers, e.g. 2006-01.
+func datetimePattern(layout string) string {
+	return layoutDigit.ReplaceAllString(regexp.QuoteMeta(layout), "[0-9]")
+}
@@ -0,0 +1,87 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+type dummyItem struct {
+	Name string `json:"name"`
+}
+
+type dummyRequest struct {
+	AccountId  string          `json:"account_id" validate:"required,max=11,number"`
+	Amount     float64         `json:"amount" validate:"number,gt=0,lte=10000"`
+	Type       string          `json:"type" validate:"required,alpha,oneof=withdrawal deposit"`
+	Reference  string          `json:"reference" validate:"omitempty,max=35,printascii"`
+	Month      string          `json:"month" validate:"omitempty,datetime=2006-01"`
+	Tags       []string        `json:"tags" validate:"required,min=1,dive,oneof=a b"`
+	Change     *float64        `json:"change,omitempty"`
+	Result     json.RawMessage `json:"result"`
+	Items      []dummyItem     `json:"items"`
+	CustomerId string          `json:"-"`
+	unexported string
+}
+
+func TestDocument_SchemaOf_builds_componentSchema_from_jsonTags_and_validateTags(t *testing.T) {
+	//Arrange
+	doc := NewDocument(Info{Title: "Test", Version: "1"})
+	eleven, one := 11, 1
+	zero, max := 0.0, 10000.0
+	thirtyFive := 35
+	expected := &Schema{
+		Type: TypeObject,
+		Properties: map[string]*Schema{
+			"account_id": {Type: TypeString, Pattern: "^[0-9]+$", MaxLength: &eleven},
+			"amount":     {Type: TypeNumber, Format: "double", Minimum: &zero, ExclusiveMinimum: true, Maximum: &max},
+			"type":       {Type: TypeString, Enum: []string{"withdrawal", "deposit"}},
+			"reference":  {Type: TypeString, Pattern: "^[ -~]*$", MaxLength: &thirtyFive},
+			"month":      {Type: TypeString, Pattern: "^([0-9][0-9][0-9][0-9]-[0-9][0-9])?$"},
+			"tags": {Type: TypeArray, MinItems: &one,
+				Items: &Schema{Type: TypeString, Enum: []string{"a", "b"}}},
+			"change": {Type: TypeNumber, Format: "double", Nullable: true},
+			"result": {},
+			"items":  {Type: TypeArray, Items: RefTo("dummyItem")},
+		},
+		Required: []string{"account_id", "type", "tags"},
+	}
+
+	//Act
+	actual := doc.SchemaOf(dummyRequest{})
+
+	//Assert
+	if actual.Ref != "#/components/schemas/dummyRequest" {
+		t.Errorf("Expected a reference to the component schema but got %v", actual)
+	}
+	component := doc.Components.Schemas["dummyRequest"]
+	for name, property := range expected.Properties {
+		if !reflect.DeepEqual(component.Properties[name], property) {
+			actualJson, _ := json.Marshal(component.Properties[name])
+			expectedJson, _ := json.Marshal(property)
+			t.Errorf("Expected property %s to be %s but got %s", name, expectedJson, actualJson)
+		}
+	}
+	if len(component.Properties) != len(expected.Properties) {
+		t.Errorf("Expected %d properties but got %d", len(expected.Properties), len(component.Properties))
+	}
+	if !reflect.DeepEqual(component.Required, expected.Required) {
+		t.Errorf("Expected required %v but got %v", expected.Required, component.Required)
+	}
+	if _, ok := doc.Components.Schemas["dummyItem"]; !ok {
+		t.Error("Expected nested struct to be added to the components but it was not")
+	}
+}
+
+func TestDocument_SchemaOf_returns_array_of_reference_for_slice_of_structs(t *testing.T) {
+	//Arrange
+	doc := NewDocument(Info{Title: "Test", Version: "1"})
+
+	//Act
+	actual := doc.SchemaOf([]dummyItem{})
+
+	//Assert
+	if actual.Type != TypeArray || actual.Items.Ref != "#/components/schemas/dummyItem" {
+		t.Errorf("Expected array of dummyItem but got %v", actual)
+	}
+}
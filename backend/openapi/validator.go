@@ -0,0 +1,218 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"io"
+	"math"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+const MediaTypeJson = "application/json"
+
+// ValidateRequest checks the query parameters and JSON body of the given request against the given operation of the
+// document. The path parameters are left to the router, which already matches them with the same patterns. The body
+// is read and replaced, so that the handler can still decode it.
+func (d *Document) ValidateRequest(op *Operation, r *http.Request) *errs.AppError {
+	for _, param := range op.Parameters {
+		if param.In != "query" {
+			continue
+		}
+		value, isGiven := r.URL.Query()[param.Name]
+		if !isGiven {
+			if param.Required {
+				return newValidationError(fmt.Sprintf("query parameter %s is required", param.Name))
+			}
+			continue
+		}
+		if err := d.ValidateValue(param.Schema, value[0], param.Name); err != nil {
+			return newValidationError("query parameter " + err.Error())
+		}
+	}
+
+	if op.RequestBody == nil {
+		return nil
+	}
+	mediaType, isJson := op.RequestBody.Content[MediaTypeJson]
+	if !isJson {
+		return nil
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		logger.Error("Error while reading request body for validation: " + err.Error())
+		return errs.NewAppError(http.StatusBadRequest, "Please check that all fields are correctly filled.")
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		if op.RequestBody.Required {
+			return newValidationError("request body is required")
+		}
+		return nil
+	}
+	var value interface{}
+	if err = json.Unmarshal(body, &value); err != nil {
+		logger.Error("Error while decoding request body for validation: " + err.Error())
+		return errs.NewAppError(http.StatusBadRequest, "Please check that all fields are correctly filled.")
+	}
+	if err = d.ValidateValue(mediaType.Schema, value, ""); err != nil {
+		return newValidationError(err.Error())
+	}
+	return nil
+}
+
+func newValidationError(problem string) *errs.AppError {
+	logger.Error("Request does not match the API specification (" + problem + ")")
+	return errs.NewValidationError("Request does not match the API specification: " + problem + ".")
+}
+
+// ValidateValue checks a value, as decoded from JSON, against the given schema and describes the first problem found
+// with the value of the given name, e.g. "amount must be at most 10000". An empty name stands for the whole request
+// body.
+func (d *Document) ValidateValue(schema *Schema, value interface{}, name string) error {
+	s, err := d.Resolve(schema)
+	if err != nil || s == nil {
+		return err
+	}
+	if name == "" {
+		name = "request body"
+	}
+
+	if value == nil {
+		if s.Nullable || s.Type == "" {
+			return nil
+		}
+		return fmt.Errorf("%s must not be null", name)
+	}
+
+	switch s.Type {
+	case TypeString:
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%s must be a string", name)
+		}
+		return validateString(s, str, name)
+	case TypeNumber, TypeInteger:
+		number, ok := value.(float64)
+		if !ok || s.Type == TypeInteger && number != math.Trunc(number) {
+			return fmt.Errorf("%s must be a %s", name, s.Type)
+		}
+		return validateNumber(s, number, name)
+	case TypeBoolean:
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s must be a boolean", name)
+		}
+	case TypeArray:
+		items, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("%s must be an array", name)
+		}
+		if s.MinItems != nil && len(items) < *s.MinItems {
+			return fmt.Errorf("%s must have at least %d items", name, *s.MinItems)
+		}
+		if s.MaxItems != nil && len(items) > *s.MaxItems {
+			return fmt.Errorf("%s must have at most %d items", name, *s.MaxItems)
+		}
+		for i, item := range items {
+			if err = d.ValidateValue(s.Items, item, fmt.Sprintf("%s[%d]", name, i)); err != nil {
+				return err
+			}
+		}
+	case TypeObject:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s must be an object", name)
+		}
+		return d.validateObject(s, object, name)
+	}
+	return nil
+}
+
+func validateString(s *Schema, str string, name string) error {
+	if s.Enum != nil && !contains(s.Enum, str) {
+		return fmt.Errorf("%s must be one of %s", name, strings.Join(nonEmpty(s.Enum), ", "))
+	}
+	length := utf8.RuneCountInString(str)
+	if s.MinLength != nil && length < *s.MinLength {
+		return fmt.Errorf("%s must be at least %d characters", name, *s.MinLength)
+	}
+	if s.MaxLength != nil && length > *s.MaxLength {
+		return fmt.Errorf("%s must be at most %d characters", name, *s.MaxLength)
+	}
+	if s.Pattern != "" {
+		if matched, err := regexp.MatchString(s.Pattern, str); err != nil || !matched {
+			return fmt.Errorf("%s must match %s", name, s.Pattern)
+		}
+	}
+	return nil
+}
+
+func validateNumber(s *Schema, number float64, name string) error {
+	if s.Minimum != nil && s.ExclusiveMinimum && number <= *s.Minimum {
+		return fmt.Errorf("%s must be more than %v", name, *s.Minimum)
+	}
+	if s.Minimum != nil && number < *s.Minimum {
+		return fmt.Errorf("%s must be at least %v", name, *s.Minimum)
+	}
+	if s.Maximum != nil && number > *s.Maximum {
+		return fmt.Errorf("%s must be at most %v", name, *s.Maximum)
+	}
+	return nil
+}
+
+// validateObject checks the required properties of the object first, then each of the given properties in name order
+// so that the same problem is always reported first. Properties that the schema does not have are ignored, as they
+// are by the handlers.
+func (d *Document) validateObject(s *Schema, object map[string]interface{}, name string) error {
+	prefix := ""
+	if name != "request body" {
+		prefix = name + "."
+	}
+	for _, required := range s.Required {
+		if _, ok := object[required]; !ok {
+			return fmt.Errorf("%s%s is required", prefix, required)
+		}
+	}
+
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if property, ok := s.Properties[key]; ok {
+			if err := d.ValidateValue(property, object[key], prefix+key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// nonEmpty returns the given list without empty strings, which stand for leaving out an optional value.
+func nonEmpty(list []string) []string {
+	var result []string
+	for _, item := range list {
+		if item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
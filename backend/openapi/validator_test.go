@@ -0,0 +1,136 @@
+package openapi
+
+import (
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func setupValidatorTest() (*Document, *Operation) {
+	logger.MuteLogger()
+	doc := NewDocument(Info{Title: "Test", Version: "1"})
+	op := &Operation{
+		OperationId: "NewThing",
+		Parameters: []Parameter{{Name: "mode", In: "query",
+			Schema: &Schema{Type: TypeString, Enum: []string{"dry_run", "commit"}}}},
+		RequestBody: &RequestBody{Required: true,
+			Content: map[string]MediaType{MediaTypeJson: {Schema: doc.SchemaOf(dummyRequest{})}}},
+	}
+	return doc, op
+}
+
+func TestDocument_ValidateValue_describes_first_problem(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected string
+	}{
+		{"valid", `{"account_id": "1", "type": "deposit", "tags": ["a"], "amount": 10}`, ""},
+		{"missing", `{"type": "deposit", "tags": ["a"]}`, "account_id is required"},
+		{"wrong type", `{"account_id": 1, "type": "deposit", "tags": ["a"]}`, "account_id must be a string"},
+		{"pattern", `{"account_id": "abc", "type": "deposit", "tags": ["a"]}`, "account_id must match ^[0-9]+$"},
+		{"too long", `{"account_id": "123456789012", "type": "deposit", "tags": ["a"]}`,
+			"account_id must be at most 11 characters"},
+		{"enum", `{"account_id": "1", "type": "loan", "tags": ["a"]}`, "type must be one of withdrawal, deposit"},
+		{"exclusive minimum", `{"account_id": "1", "type": "deposit", "tags": ["a"], "amount": 0}`,
+			"amount must be more than 0"},
+		{"maximum", `{"account_id": "1", "type": "deposit", "tags": ["a"], "amount": 10001}`,
+			"amount must be at most 10000"},
+		{"min items", `{"account_id": "1", "type": "deposit", "tags": []}`, "tags must have at least 1 items"},
+		{"item", `{"account_id": "1", "type": "deposit", "tags": ["a", "c"]}`, "tags[1] must be one of a, b"},
+		{"nested", `{"account_id": "1", "type": "deposit", "tags": ["a"], "items": [{"name": 5}]}`,
+			"items[0].name must be a string"},
+		{"null", `{"account_id": null, "type": "deposit", "tags": ["a"]}`, "account_id must not be null"},
+		{"nullable", `{"account_id": "1", "type": "deposit", "tags": ["a"], "change": null}`, ""},
+		{"not object", `[]`, "request body must be an object"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Arrange
+			doc, op := setupValidatorTest()
+			request := httptest.NewRequest(http.MethodPost, "/things", strings.NewReader(tc.body))
+
+			//Act
+			appErr := doc.ValidateRequest(op, request)
+
+			//Assert
+			if tc.expected == "" {
+				if appErr != nil {
+					t.Errorf("Expected no error but got %s", appErr.Message)
+				}
+				return
+			}
+			if appErr == nil {
+				t.Fatalf("Expected error %q but got none", tc.expected)
+			}
+			if appErr.Code != http.StatusUnprocessableEntity || !strings.Contains(appErr.Message, tc.expected+".") {
+				t.Errorf("Expected status code 422 and error %q but got %d and %q", tc.expected, appErr.Code, appErr.Message)
+			}
+		})
+	}
+}
+
+func TestDocument_ValidateRequest_returns_400_when_body_malformed(t *testing.T) {
+	//Arrange
+	doc, op := setupValidatorTest()
+	request := httptest.NewRequest(http.MethodPost, "/things", strings.NewReader(`{"account_id": `))
+
+	//Act
+	appErr := doc.ValidateRequest(op, request)
+
+	//Assert
+	if appErr == nil || appErr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code 400 but got %v", appErr)
+	}
+}
+
+func TestDocument_ValidateRequest_returns_422_when_body_missing(t *testing.T) {
+	//Arrange
+	doc, op := setupValidatorTest()
+	request := httptest.NewRequest(http.MethodPost, "/things", nil)
+
+	//Act
+	appErr := doc.ValidateRequest(op, request)
+
+	//Assert
+	if appErr == nil || appErr.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status code 422 but got %v", appErr)
+	}
+}
+
+func TestDocument_ValidateRequest_checks_queryParameters(t *testing.T) {
+	//Arrange
+	doc, op := setupValidatorTest()
+	body := `{"account_id": "1", "type": "deposit", "tags": ["a"]}`
+	request := httptest.NewRequest(http.MethodPost, "/things?mode=publish", strings.NewReader(body))
+
+	//Act
+	appErr := doc.ValidateRequest(op, request)
+
+	//Assert
+	if appErr == nil || !strings.Contains(appErr.Message, "query parameter mode must be one of dry_run, commit") {
+		t.Errorf("Expected error about query parameter mode but got %v", appErr)
+	}
+}
+
+func TestDocument_ValidateRequest_leaves_body_for_handler(t *testing.T) {
+	//Arrange
+	doc, op := setupValidatorTest()
+	body := `{"account_id": "1", "type": "deposit", "tags": ["a"]}`
+	request := httptest.NewRequest(http.MethodPost, "/things", strings.NewReader(body))
+
+	//Act
+	appErr := doc.ValidateRequest(op, request)
+
+	//Assert
+	if appErr != nil {
+		t.Fatalf("Expected no error but got %s", appErr.Message)
+	}
+	actualBody, _ := io.ReadAll(request.Body)
+	if string(actualBody) != body {
+		t.Errorf("Expected body %s to be left for the handler but got %s", body, actualBody)
+	}
+}
@@ -0,0 +1,210 @@
+// Package problem gives every error of the API a stable code that clients can branch on instead of the message, and
+// describes errors as problem details (RFC 7807).
+package problem
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"net/http"
+)
+
+const MediaType = "application/problem+json"
+
+// TypeUriPrefix is the path under which each type of problem is described, e.g. /problems/INSUFFICIENT_FUNDS.
+const TypeUriPrefix = "/problems/"
+
+// Type is a kind of error, identified by its code. An error is of the type whose code it was given where it was
+// created, or else of the general type of its status.
+type Type struct {
+	Code   string
+	Status int
+	Title  string
+}
+
+// Uri returns the reference to the description of the type, which is the "type" of its problem details.
+func (t Type) Uri() string {
+	return TypeUriPrefix + t.Code
+}
+
+const MalformedRequest = "MALFORMED_REQUEST"
+const ValidationFailed = "VALIDATION_FAILED"
+const Unauthenticated = "UNAUTHENTICATED"
+const Forbidden = "FORBIDDEN"
+const NotFound = "NOT_FOUND"
+const MethodNotAllowed = "METHOD_NOT_ALLOWED"
+const Conflict = "CONFLICT"
+const RateLimited = "RATE_LIMITED"
+const InternalError = "INTERNAL_ERROR"
+
+const TokenMissing = "TOKEN_MISSING"
+const TokenExpired = "TOKEN_EXPIRED"
+const TokenInvalid = "TOKEN_INVALID"
+const CorsOriginNotAllowed = "CORS_ORIGIN_NOT_ALLOWED"
+const CorsMethodNotAllowed = "CORS_METHOD_NOT_ALLOWED"
+const CorsHeadersNotAllowed = "CORS_HEADERS_NOT_ALLOWED"
+const RouteNotFound = "ROUTE_NOT_FOUND"
+const ProblemTypeNotFound = "PROBLEM_TYPE_NOT_FOUND"
+const FileTooLarge = "FILE_TOO_LARGE"
+
+const CustomerNotFound = "CUSTOMER_NOT_FOUND"
+const CustomerStatusInvalid = "CUSTOMER_STATUS_INVALID"
+const AccountNotFound = "ACCOUNT_NOT_FOUND"
+const AccountFrozen = "ACCOUNT_FROZEN"
+const InsufficientFunds = "INSUFFICIENT_FUNDS"
+const TransactionNotFound = "TRANSACTION_NOT_FOUND"
+const TransactionDeclined = "TRANSACTION_DECLINED"
+const TransactionAlreadyReversed = "TRANSACTION_ALREADY_REVERSED"
+const TransactionReviewNotFound = "TRANSACTION_REVIEW_NOT_FOUND"
+const TransactionReviewResolved = "TRANSACTION_REVIEW_RESOLVED"
+
+const HoldNotFound = "HOLD_NOT_FOUND"
+const HoldNotActive = "HOLD_NOT_ACTIVE"
+const HoldExpired = "HOLD_EXPIRED"
+
+const ApprovalNotFound = "APPROVAL_NOT_FOUND"
+const ApprovalAlreadyDecided = "APPROVAL_ALREADY_DECIDED"
+const ApprovalExpired = "APPROVAL_EXPIRED"
+const ApprovalByRequester = "APPROVAL_BY_REQUESTER"
+const AdminUnidentified = "ADMIN_UNIDENTIFIED"
+
+const OverdraftNotFound = "OVERDRAFT_NOT_FOUND"
+const OverdraftClosed = "OVERDRAFT_CLOSED"
+const OverdraftNotAllowed = "OVERDRAFT_NOT_ALLOWED"
+const OverdraftLimitTooLow = "OVERDRAFT_LIMIT_TOO_LOW"
+
+const FeeScheduleNotFound = "FEE_SCHEDULE_NOT_FOUND"
+const FeeScheduleInEffect = "FEE_SCHEDULE_IN_EFFECT"
+
+const AlertRuleNotFound = "ALERT_RULE_NOT_FOUND"
+const AlertRuleExists = "ALERT_RULE_EXISTS"
+
+const PayeeNotFound = "PAYEE_NOT_FOUND"
+const PayeeExists = "PAYEE_EXISTS"
+const PayeeOwnerMismatch = "PAYEE_OWNER_MISMATCH"
+const PayeeNotConfirmed = "PAYEE_NOT_CONFIRMED"
+const PayeeCoolingOff = "PAYEE_COOLING_OFF"
+const PayeeAccountUnavailable = "PAYEE_ACCOUNT_UNAVAILABLE"
+const TransferToSameAccount = "TRANSFER_TO_SAME_ACCOUNT"
+
+const ImportFileInvalid = "IMPORT_FILE_INVALID"
+const ImportDuplicate = "IMPORT_DUPLICATE"
+const ImportNotCommitted = "IMPORT_NOT_COMMITTED"
+
+const NoAccountsToFreeze = "NO_ACCOUNTS_TO_FREEZE"
+
+const WebhookSubscriptionNotFound = "WEBHOOK_SUBSCRIPTION_NOT_FOUND"
+const WebhookDeliveryNotFound = "WEBHOOK_DELIVERY_NOT_FOUND"
+
+// generalTypes are the types of the errors that no type in Catalog describes more closely, by status.
+var generalTypes = []Type{
+	{Code: MalformedRequest, Status: http.StatusBadRequest, Title: "Malformed request"},
+	{Code: Unauthenticated, Status: http.StatusUnauthorized, Title: "Not authenticated"},
+	{Code: Forbidden, Status: http.StatusForbidden, Title: "Not allowed"},
+	{Code: NotFound, Status: http.StatusNotFound, Title: "Not found"},
+	{Code: MethodNotAllowed, Status: http.StatusMethodNotAllowed, Title: "Method not allowed"},
+	{Code: Conflict, Status: http.StatusConflict, Title: "Conflict with the current state"},
+	{Code: ValidationFailed, Status: http.StatusUnprocessableEntity, Title: "Validation failed"},
+	{Code: RateLimited, Status: http.StatusTooManyRequests, Title: "Too many requests"},
+	{Code: InternalError, Status: http.StatusInternalServerError, Title: "Internal server error"},
+}
+
+// Catalog is every type of error of the API. Codes are never changed or reused once released, so that clients can
+// rely on them.
+var Catalog = append(append([]Type{}, generalTypes...), []Type{
+	{Code: TokenMissing, Status: http.StatusUnauthorized, Title: "Access token missing"},
+	{Code: TokenExpired, Status: http.StatusUnauthorized, Title: "Access token expired"},
+	{Code: TokenInvalid, Status: http.StatusUnauthorized, Title: "Access token invalid"},
+	{Code: CorsOriginNotAllowed, Status: http.StatusForbidden, Title: "Origin not allowed"},
+	{Code: CorsMethodNotAllowed, Status: http.StatusForbidden, Title: "Method not allowed for cross-origin requests"},
+	{Code: CorsHeadersNotAllowed, Status: http.StatusForbidden, Title: "Headers not allowed for cross-origin requests"},
+	{Code: RouteNotFound, Status: http.StatusNotFound, Title: "Route not found"},
+	{Code: ProblemTypeNotFound, Status: http.StatusNotFound, Title: "Problem type not found"},
+	{Code: FileTooLarge, Status: http.StatusBadRequest, Title: "File too large"},
+
+	{Code: CustomerNotFound, Status: http.StatusNotFound, Title: "Customer not found"},
+	{Code: CustomerStatusInvalid, Status: http.StatusNotFound, Title: "Customer status invalid"},
+	{Code: AccountNotFound, Status: http.StatusNotFound, Title: "Account not found"},
+	{Code: AccountFrozen, Status: http.StatusForbidden, Title: "Account frozen"},
+	{Code: InsufficientFunds, Status: http.StatusUnprocessableEntity, Title: "Insufficient funds"},
+	{Code: TransactionNotFound, Status: http.StatusNotFound, Title: "Transaction not found"},
+	{Code: TransactionDeclined, Status: http.StatusForbidden, Title: "Transaction declined"},
+	{Code: TransactionAlreadyReversed, Status: http.StatusConflict, Title: "Transaction already reversed"},
+	{Code: TransactionReviewNotFound, Status: http.StatusNotFound, Title: "Transaction review not found"},
+	{Code: TransactionReviewResolved, Status: http.StatusConflict, Title: "Transaction review already resolved"},
+
+	{Code: HoldNotFound, Status: http.StatusNotFound, Title: "Hold not found"},
+	{Code: HoldNotActive, Status: http.StatusConflict, Title: "Hold no longer active"},
+	{Code: HoldExpired, Status: http.StatusConflict, Title: "Hold expired"},
+
+	{Code: ApprovalNotFound, Status: http.StatusNotFound, Title: "Approval request not found"},
+	{Code: ApprovalAlreadyDecided, Status: http.StatusConflict, Title: "Approval request already decided"},
+	{Code: ApprovalExpired, Status: http.StatusConflict, Title: "Approval request expired"},
+	{Code: ApprovalByRequester, Status: http.StatusForbidden, Title: "Approval by the requesting admin"},
+	{Code: AdminUnidentified, Status: http.StatusForbidden, Title: "Admin not identified"},
+
+	{Code: OverdraftNotFound, Status: http.StatusNotFound, Title: "Account not overdrawn"},
+	{Code: OverdraftClosed, Status: http.StatusConflict, Title: "Overdraft already charged or closed"},
+	{Code: OverdraftNotAllowed, Status: http.StatusUnprocessableEntity, Title: "Overdraft not allowed"},
+	{Code: OverdraftLimitTooLow, Status: http.StatusUnprocessableEntity, Title: "Overdraft limit too low"},
+
+	{Code: FeeScheduleNotFound, Status: http.StatusNotFound, Title: "Fee schedule not found"},
+	{Code: FeeScheduleInEffect, Status: http.StatusConflict, Title: "Fee schedule already in effect"},
+
+	{Code: AlertRuleNotFound, Status: http.StatusNotFound, Title: "Alert rule not found"},
+	{Code: AlertRuleExists, Status: http.StatusConflict, Title: "Alert rule already exists"},
+
+	{Code: PayeeNotFound, Status: http.StatusNotFound, Title: "Payee not found"},
+	{Code: PayeeExists, Status: http.StatusConflict, Title: "Payee already exists"},
+	{Code: PayeeOwnerMismatch, Status: http.StatusUnprocessableEntity, Title: "Payee owner does not match"},
+	{Code: PayeeNotConfirmed, Status: http.StatusConflict, Title: "Payee not confirmed"},
+	{Code: PayeeCoolingOff, Status: http.StatusUnprocessableEntity, Title: "Payee in cooling-off period"},
+	{Code: PayeeAccountUnavailable, Status: http.StatusForbidden, Title: "Payee account unavailable"},
+	{Code: TransferToSameAccount, Status: http.StatusUnprocessableEntity, Title: "Transfer to the same account"},
+
+	{Code: ImportFileInvalid, Status: http.StatusUnprocessableEntity, Title: "Import file invalid"},
+	{Code: ImportDuplicate, Status: http.StatusConflict, Title: "File already imported"},
+	{Code: ImportNotCommitted, Status: http.StatusUnprocessableEntity, Title: "Import could not be committed"},
+
+	{Code: NoAccountsToFreeze, Status: http.StatusConflict, Title: "No accounts to freeze"},
+
+	{Code: WebhookSubscriptionNotFound, Status: http.StatusNotFound, Title: "Webhook subscription not found"},
+	{Code: WebhookDeliveryNotFound, Status: http.StatusNotFound, Title: "Webhook delivery not found"},
+}...)
+
+// Of returns the type of the given error: the type of the code it was created with if it has the same status, or else
+// the general type of its status.
+func Of(appErr *errs.AppError) Type {
+	if code, ok := codeOf(appErr); ok {
+		if t, ok := Find(code); ok && t.Status == appErr.Code {
+			return t
+		}
+	}
+	return generalType(appErr.Code)
+}
+
+// Find returns the type with the given code.
+func Find(code string) (Type, bool) {
+	for _, t := range Catalog {
+		if t.Code == code {
+			return t, true
+		}
+	}
+	return Type{}, false
+}
+
+// generalType returns the general type of errors with the given status, treating any other status as malformed
+// request if it is a client error or internal error if not.
+func generalType(status int) Type {
+	for _, t := range generalTypes {
+		if t.Status == status {
+			return t
+		}
+	}
+	if status >= http.StatusBadRequest && status < http.StatusInternalServerError {
+		t, _ := Find(MalformedRequest)
+		t.Status = status
+		return t
+	}
+	t, _ := Find(InternalError)
+	t.Status = status
+	return t
+}
@@ -0,0 +1,176 @@
+package problem
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestOf_returns_type_of_code_error_was_created_with(t *testing.T) {
+	tests := []struct {
+		name     string
+		appErr   *errs.AppError
+		expected string
+	}{
+		{"coded", NewValidationError(InsufficientFunds, "Account balance insufficient to withdraw given amount"),
+			InsufficientFunds},
+		{"coded with any message", NewNotFoundError(AccountNotFound, "No accounts found for this customer"),
+			AccountNotFound},
+		{"coded auth error", NewAuthenticationError(TokenExpired, errs.MessageExpiredAccessToken), TokenExpired},
+		{"coded with other status", NewAppError(AccountNotFound, http.StatusInternalServerError, "Account not found"),
+			InternalError},
+		{"unknown code", NewConflictError("NOT_A_CODE", "Conflict"), Conflict},
+		{"not coded", errs.NewValidationError("Account balance insufficient to withdraw given amount"),
+			ValidationFailed},
+		{"not coded bad request", errs.NewAppError(http.StatusBadRequest, "Bad request"), MalformedRequest},
+		{"not coded other client error", errs.NewAppError(http.StatusTeapot, "I'm a teapot"), MalformedRequest},
+		{"not coded other server error", errs.NewAppError(http.StatusBadGateway, "Bad gateway"), InternalError},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Act
+			actual := Of(tc.appErr)
+
+			//Assert
+			if actual.Code != tc.expected {
+				t.Errorf("Expected code %s but got %s", tc.expected, actual.Code)
+			}
+			if actual.Status != tc.appErr.Code {
+				t.Errorf("Expected status %d but got %d", tc.appErr.Code, actual.Status)
+			}
+		})
+	}
+}
+
+func TestCatalog_has_unique_codes(t *testing.T) {
+	//Arrange
+	seen := make(map[string]bool)
+
+	//Act & Assert
+	for _, problemType := range Catalog {
+		if seen[problemType.Code] {
+			t.Errorf("Expected code %s to appear once in the catalog but it appears more than once", problemType.Code)
+		}
+		seen[problemType.Code] = true
+		if found, ok := Find(problemType.Code); !ok || found.Title != problemType.Title {
+			t.Errorf("Expected to find type %s by its code but got %v", problemType.Code, found)
+		}
+	}
+}
+
+// TestCatalog_has_code_for_every_error goes through the errors created in the handlers, services and repositories
+// and fails for a not found, conflict or authorization error created without a code, or an error created with a
+// code that is not in the catalog or is of another status. Other errors may be of the general type of their status,
+// e.g. VALIDATION_FAILED.
+func TestCatalog_has_code_for_every_error(t *testing.T) {
+	//Arrange
+	uncoded := map[string]bool{"NewNotFoundError": true, "NewConflictError": true, "NewAuthorizationError": true}
+	coded := map[string]int{
+		"NewAppError":            0, //status given with the error
+		"NewNotFoundError":       http.StatusNotFound,
+		"NewConflictError":       http.StatusConflict,
+		"NewAuthenticationError": http.StatusUnauthorized,
+		"NewAuthorizationError":  http.StatusForbidden,
+		"NewValidationError":     http.StatusUnprocessableEntity,
+	}
+	codes := codeConstants(t)
+	var files []string
+	for _, dir := range []string{"../app", "../domain", "../dto", "../service"} {
+		matches, err := filepath.Glob(filepath.Join(dir, "*.go"))
+		if err != nil {
+			t.Fatalf("Unexpected error while listing files of %s: %s", dir, err.Error())
+		}
+		files = append(files, matches...)
+	}
+
+	//Act & Assert
+	checked := 0
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(token.NewFileSet(), file, nil, 0)
+		if err != nil {
+			t.Fatalf("Unexpected error while parsing %s: %s", file, err.Error())
+		}
+		ast.Inspect(f, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			pkg, name, ok := selectorNames(call.Fun)
+			if !ok {
+				return true
+			}
+			if pkg == "errs" && uncoded[name] {
+				t.Errorf("Expected errs.%s in %s to be problem.%s with a code", name, file, name)
+				return true
+			}
+			status, ok := coded[name]
+			if pkg != "problem" || !ok || len(call.Args) == 0 {
+				return true
+			}
+			_, constant, ok := selectorNames(call.Args[0])
+			if !ok {
+				return true //code looked up, e.g. from the message of the auth server
+			}
+			checked++
+			code, ok := codes[constant]
+			if !ok {
+				t.Errorf("Expected problem.%s in %s to be given a code of the catalog", name, file)
+				return true
+			}
+			if problemType, ok := Find(code); !ok || (status != 0 && problemType.Status != status) {
+				t.Errorf("Expected problem.%s in %s to be given a code of status %d but got %s",
+					name, file, status, code)
+			}
+			return true
+		})
+	}
+	if checked == 0 {
+		t.Error("Expected errors with codes to be found in the source files but found none")
+	}
+}
+
+// selectorNames returns the names in the selector expression pkg.name.
+func selectorNames(expr ast.Expr) (string, string, bool) {
+	selector, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return "", "", false
+	}
+	pkg, ok := selector.X.(*ast.Ident)
+	if !ok {
+		return "", "", false
+	}
+	return pkg.Name, selector.Sel.Name, true
+}
+
+// codeConstants returns the values of the string constants of catalog.go, by name.
+func codeConstants(t *testing.T) map[string]string {
+	f, err := parser.ParseFile(token.NewFileSet(), "catalog.go", nil, 0)
+	if err != nil {
+		t.Fatalf("Unexpected error while parsing catalog.go: %s", err.Error())
+	}
+	constants := make(map[string]string)
+	for _, decl := range f.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			valueSpec := spec.(*ast.ValueSpec)
+			for i, name := range valueSpec.Names {
+				if literal, ok := valueSpec.Values[i].(*ast.BasicLit); ok && literal.Kind == token.STRING {
+					constants[name.Name], _ = strconv.Unquote(literal.Value)
+				}
+			}
+		}
+	}
+	return constants
+}
@@ -0,0 +1,60 @@
+package problem
+
+import (
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"runtime"
+	"sync"
+	"unsafe"
+)
+
+// codes are the codes that errors were created with, keyed by the address of the error so that they do not keep it
+// from being garbage collected. errs.AppError has no field for the code. The code of an error is removed when the
+// error is collected, before its address can be reused.
+var codes sync.Map
+
+// NewAppError is errs.NewAppError for an error with the given code.
+func NewAppError(code string, status int, message string) *errs.AppError {
+	return withCode(code, errs.NewAppError(status, message))
+}
+
+// NewNotFoundError is errs.NewNotFoundError for an error with the given code.
+func NewNotFoundError(code string, message string) *errs.AppError {
+	return withCode(code, errs.NewNotFoundError(message))
+}
+
+// NewConflictError is errs.NewConflictError for an error with the given code.
+func NewConflictError(code string, message string) *errs.AppError {
+	return withCode(code, errs.NewConflictError(message))
+}
+
+// NewAuthenticationError is errs.NewAuthenticationError for an error with the given code.
+func NewAuthenticationError(code string, message string) *errs.AppError {
+	return withCode(code, errs.NewAuthenticationError(message))
+}
+
+// NewAuthorizationError is errs.NewAuthorizationError for an error with the given code.
+func NewAuthorizationError(code string, message string) *errs.AppError {
+	return withCode(code, errs.NewAuthorizationError(message))
+}
+
+// NewValidationError is errs.NewValidationError for an error with the given code.
+func NewValidationError(code string, message string) *errs.AppError {
+	return withCode(code, errs.NewValidationError(message))
+}
+
+func withCode(code string, appErr *errs.AppError) *errs.AppError {
+	codes.Store(uintptr(unsafe.Pointer(appErr)), code)
+	runtime.SetFinalizer(appErr, func(e *errs.AppError) {
+		codes.Delete(uintptr(unsafe.Pointer(e)))
+	})
+	return appErr
+}
+
+// codeOf returns the code that the given error was created with, if any.
+func codeOf(appErr *errs.AppError) (string, bool) {
+	code, ok := codes.Load(uintptr(unsafe.Pointer(appErr)))
+	if !ok {
+		return "", false
+	}
+	return code.(string), true
+}
@@ -11,11 +11,34 @@ export AUTH_SERVER_DOMAIN="localhost:8181"
 export FRONTEND_SERVER_ADDRESS="localhost"
 export FRONTEND_SERVER_PORT="3000"
 export FRONTEND_SERVER_DOMAIN="localhost:3000"
+export DB_DRIVER="mysql" # or "postgres" (then also set DB_SSL_MODE, default "prefer"), "sqlite" (DB_NAME is then a file path) or "stub" (no database)
 export DB_USER="root"
 export DB_PASSWORD="codecamp"
 export DB_HOST="localhost"
 export DB_PORT="3306"
 export DB_NAME="banking"
+export EVENT_PUBLISHER="log" # or "file" (then also set EVENT_FILE to the path of the file to append events to)
+export ALERT_NOTIFIER="inbox" # or "smtp" (then also set SMTP_ADDRESS, e.g. "localhost:1025" for MailHog, SMTP_FROM and if needed SMTP_USERNAME and SMTP_PASSWORD)
+# export FRAUD_RULES_FILE="fraud_rules.json" # optional, JSON list of fraud rules to use instead of the default ones
+# export APPROVAL_THRESHOLD="5000" # optional, amount above which a transaction made by an admin needs a second admin's approval
+# export OVERDRAFT_FEE="25" # optional, one-off fee charged when a checking account goes overdrawn
+# export OVERDRAFT_INTEREST_RATE="18" # optional, yearly interest rate in percent charged daily on overdrawn balances
+# export PAYEE_COOLING_OFF_HOURS="24" # optional, how long a new payee can only receive transfers of up to PAYEE_COOLING_OFF_LIMIT
+# export PAYEE_COOLING_OFF_LIMIT="1000" # optional, largest transfer to a payee added less than PAYEE_COOLING_OFF_HOURS ago
+# export RATE_LIMIT_DEFAULT="120/1m" # optional, budget of each client IP and customer for the routes that only read
+# export RATE_LIMIT_MUTATING="30/1m" # optional, stricter budget for the routes that change data
+# export RATE_LIMIT_ROUTES="NewTransaction=10/1m" # optional, comma-separated budgets of their own by route name
+# export RATE_LIMIT_TRUST_FORWARDED_FOR="true" # only behind a proxy, takes the client IP from X-Forwarded-For
+# export CORS_ALLOWED_ORIGINS="https://banking-*.vercel.app" # optional, comma-separated origins allowed besides FRONTEND_SERVER_DOMAIN
+# export CORS_MAX_AGE="10m" # optional, how long browsers may cache the result of a preflight request
+# export CORS_ALLOW_CREDENTIALS="true" # optional, lets browsers send cookies
+# export OPENAPI_VALIDATE_REQUESTS="true" # optional, checks requests against the document at /openapi.json
+# export LEGACY_API_DEPRECATED_AT="2026-10-19" # optional, sent in Deprecation for the paths from before /v1
+# export LEGACY_API_SUNSET="2027-04-30" # optional, sent in Sunset for the paths from before /v1
+
+# Bring database schema up to date and load demo data (both safe to repeat)
+go run main.go migrate up
+go run main.go migrate seed
 
 # Run app
 go run main.go
\ No newline at end of file
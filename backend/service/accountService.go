@@ -6,6 +6,8 @@ import (
 	"github.com/aliciatay-zls/banking-lib/logger"
 	"github.com/aliciatay-zls/banking/backend/domain"
 	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/problem"
+	"sort"
 )
 
 //go:generate mockgen -destination=../mocks/service/mock_accountService.go -package=service github.com/aliciatay-zls/banking/backend/service AccountService
@@ -13,17 +15,27 @@ type AccountService interface { //service (primary port)
 	GetAllAccounts(string) ([]dto.AccountResponse, *errs.AppError)
 	CreateNewAccount(dto.NewAccountRequest) (*dto.NewAccountResponse, *errs.AppError)
 	MakeTransaction(dto.TransactionRequest) (*dto.TransactionResponse, *errs.AppError)
+	GetTransactions(string, string) ([]dto.AccountTransactionResponse, *errs.AppError)
+	UpdateTransactionCategory(dto.TransactionCategoryRequest) (*dto.AccountTransactionResponse, *errs.AppError)
 }
 
 type DefaultAccountService struct { //business/domain object
-	repo domain.AccountRepository //Business Domain has dependency on repo (repo is a field)
-	clk  clock.Clock
+	repo       domain.AccountRepository //Business Domain has dependency on repo (repo is a field)
+	reviews    domain.TransactionReviewRepository
+	holds      domain.HoldRepository
+	fraud      FraudService
+	alerts     AlertService
+	overdrafts OverdraftService
+	fees       FeeService
+	clk        clock.Clock
 }
 
-func NewAccountService(repo domain.AccountRepository, clk clock.Clock) DefaultAccountService {
-	return DefaultAccountService{repo, clk}
+func NewAccountService(repo domain.AccountRepository, reviews domain.TransactionReviewRepository, holds domain.HoldRepository, fraud FraudService, alerts AlertService, overdrafts OverdraftService, fees FeeService, clk clock.Clock) DefaultAccountService {
+	return DefaultAccountService{repo, reviews, holds, fraud, alerts, overdrafts, fees, clk}
 }
 
+// GetAllAccounts returns the accounts of the given customer with both their ledger balance and their available
+// balance, which leaves out the funds on hold.
 func (s DefaultAccountService) GetAllAccounts(customerId string) ([]dto.AccountResponse, *errs.AppError) {
 	accounts, err := s.repo.FindAll(customerId)
 	if err != nil {
@@ -32,6 +44,9 @@ func (s DefaultAccountService) GetAllAccounts(customerId string) ([]dto.AccountR
 
 	response := make([]dto.AccountResponse, 0)
 	for _, a := range accounts {
+		if err = applyHolds(s.holds, &a, s.clk); err != nil {
+			return nil, err
+		}
 		response = append(response, *a.ToDTO())
 	}
 	return response, nil
@@ -48,28 +63,136 @@ func (s DefaultAccountService) CreateNewAccount(request dto.NewAccountRequest) (
 	return newAccount.ToNewAccountResponseDTO(), nil
 }
 
-// MakeTransaction checks whether the values in the given request's body are valid, whether the given account exists,
-// and whether the current account balance allows for the request to be fulfilled. If so, it passes the request down
-// to the server side as an Account object and passes the returned Account DTO back up to the REST handler.
+// MakeTransaction checks whether the values in the given request's body are valid, whether the given account exists
+// and is not frozen, whether the available account balance, less the funds reserved for withdrawals pending review,
+// allows for the request to be fulfilled and whether the fraud rules let it through. If so, it passes the request down
+// to the server side as an Account object, alerts the account owner as set in their alert rules, charges the account
+// as set in the overdraft terms if the transaction took it below zero or back from below zero, charges the withdrawal
+// fee of the fee schedule in effect and passes the returned Account DTO back up to the REST handler. A transaction
+// flagged for review by the fraud rules is instead held until an admin approves or rejects it, and is returned as
+// pending review.
 func (s DefaultAccountService) MakeTransaction(request dto.TransactionRequest) (*dto.TransactionResponse, *errs.AppError) { //Business Domain implements service
 	account, err := s.repo.FindById(request.AccountId)
 	if err != nil {
 		return nil, err
 	}
 
+	if account.IsFrozen() {
+		logger.Error("Transaction attempted on frozen account " + account.AccountId)
+		return nil, problem.NewAuthorizationError(problem.AccountFrozen, "Account is frozen pending review")
+	}
+
 	if request.TransactionType == dto.TransactionTypeWithdrawal {
-		if !account.CanWithdraw(request.Amount) {
+		if err = applyHolds(s.holds, account, s.clk); err != nil {
+			return nil, err
+		}
+		reserved, err := s.reviews.FindReservedAmount(account.AccountId)
+		if err != nil {
+			return nil, err
+		}
+		if !account.CanWithdraw(request.Amount + reserved) {
 			logger.Error("Amount to withdraw exceeds account balance")
-			return nil, errs.NewValidationError("Account balance insufficient to withdraw given amount")
+			return nil, problem.NewValidationError(problem.InsufficientFunds,
+				"Account balance insufficient to withdraw given amount")
 		}
 	}
 
-	transaction := domain.NewTransaction(request.AccountId, request.Amount, request.TransactionType, s.clk)
+	transaction := domain.NewCustomerTransaction(request, s.clk)
+
+	decision, err := s.fraud.Screen(*account, transaction)
+	if err != nil {
+		return nil, err
+	}
+	if decision.IsBlocked() {
+		logger.Error("Transaction on account " + account.AccountId + " blocked by fraud rules: " + decision.Reasons)
+		return nil, problem.NewAuthorizationError(problem.TransactionDeclined,
+			"Transaction declined for security reasons. Please contact the bank.")
+	}
+	if decision.IsFlaggedForReview() {
+		review, err := s.reviews.Save(domain.NewTransactionReview(transaction, *decision))
+		if err != nil {
+			return nil, err
+		}
+		return &dto.TransactionResponse{
+			ReviewId:        review.ReviewId,
+			Status:          review.Status,
+			Balance:         account.Amount,
+			TransactionDate: review.RequestedOn,
+			Description:     review.Description,
+			Reference:       review.Reference,
+			Category:        review.Category,
+		}, nil
+	}
 
 	completedTransaction, err := s.repo.Transact(transaction)
 	if err != nil {
 		return nil, err
 	}
+	s.alerts.EvaluateTransaction(account.CustomerId, *completedTransaction)
+	chargeOverdraft(s.overdrafts, *completedTransaction)
+	s.fees.ChargeForTransaction(*completedTransaction)
 
 	return completedTransaction.ToTransactionResponseDTO(), nil
 }
+
+// GetTransactions returns the transactions of the given account of the given customer, oldest first: the posted ones
+// and those held for review that are still pending or were rejected. A held transaction that was approved is listed as
+// the transaction posted on its approval.
+func (s DefaultAccountService) GetTransactions(customerId string, accountId string) ([]dto.AccountTransactionResponse, *errs.AppError) {
+	account, err := s.repo.FindById(accountId)
+	if err != nil {
+		return nil, err
+	}
+	if account.CustomerId != customerId {
+		logger.Error("Account " + accountId + " does not belong to customer " + customerId)
+		return nil, problem.NewNotFoundError(problem.AccountNotFound, "Account not found")
+	}
+
+	transactions, err := s.repo.FindTransactions(accountId)
+	if err != nil {
+		return nil, err
+	}
+	reviews, err := s.reviews.FindAll(accountId)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.AccountTransactionResponse, 0, len(transactions)+len(reviews))
+	for _, t := range transactions {
+		response = append(response, t.ToAccountTransactionDTO())
+	}
+	for _, r := range reviews {
+		if r.Status != dto.TransactionStatusPosted {
+			response = append(response, r.ToAccountTransactionDTO())
+		}
+	}
+	sort.SliceStable(response, func(i, j int) bool { //dates in the same format sort in time order
+		return response[i].TransactionDate < response[j].TransactionDate
+	})
+	return response, nil
+}
+
+// UpdateTransactionCategory sets the category of the given posted transaction of the given account of the given
+// customer, and returns the transaction as it now appears in the transaction history of the account.
+func (s DefaultAccountService) UpdateTransactionCategory(request dto.TransactionCategoryRequest) (*dto.AccountTransactionResponse, *errs.AppError) {
+	account, err := s.repo.FindById(request.AccountId)
+	if err != nil {
+		return nil, err
+	}
+	if account.CustomerId != request.CustomerId {
+		logger.Error("Account " + request.AccountId + " does not belong to customer " + request.CustomerId)
+		return nil, problem.NewNotFoundError(problem.AccountNotFound, "Account not found")
+	}
+
+	transaction, err := s.repo.FindTransaction(request.AccountId, request.TransactionId)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.repo.UpdateTransactionCategory(transaction.TransactionId, request.Category); err != nil {
+		return nil, err
+	}
+	transaction.Category = request.Category
+
+	response := transaction.ToAccountTransactionDTO()
+	return &response, nil
+}
@@ -8,7 +8,9 @@ import (
 	"github.com/aliciatay-zls/banking/backend/domain"
 	"github.com/aliciatay-zls/banking/backend/dto"
 	mocksDomain "github.com/aliciatay-zls/banking/backend/mocks/domain"
+	mocksService "github.com/aliciatay-zls/banking/backend/mocks/service"
 	"go.uber.org/mock/gomock"
+	"net/http"
 	"testing"
 )
 
@@ -17,6 +19,12 @@ const dummyCustomerId = "2"
 
 // Test common variables and inputs
 var mockAccountRepo *mocksDomain.MockAccountRepository
+var mockTransactionReviewRepo *mocksDomain.MockTransactionReviewRepository
+var mockHoldRepo *mocksDomain.MockHoldRepository
+var mockFraudService *mocksService.MockFraudService
+var mockAlertService *mocksService.MockAlertService
+var mockOverdraftService *mocksService.MockOverdraftService
+var mockFeeService *mocksService.MockFeeService
 var mockClock clock.Clock
 var accSvc DefaultAccountService
 
@@ -29,6 +37,8 @@ const dummyAccountId = "1977"
 const dummyTransactionId = "7791"
 const dummyBalance = 0
 
+var dummyAllowDecision = domain.FraudDecision{Decision: domain.FraudDecisionAllow}
+
 func init() {
 	formValidator.Create()
 }
@@ -36,11 +46,23 @@ func init() {
 func setupAccountServiceTest(t *testing.T) func() {
 	ctrl := gomock.NewController(t)
 	mockAccountRepo = mocksDomain.NewMockAccountRepository(ctrl)
+	mockTransactionReviewRepo = mocksDomain.NewMockTransactionReviewRepository(ctrl)
+	mockHoldRepo = mocksDomain.NewMockHoldRepository(ctrl)
+	mockFraudService = mocksService.NewMockFraudService(ctrl)
+	mockAlertService = mocksService.NewMockAlertService(ctrl)
+	mockOverdraftService = mocksService.NewMockOverdraftService(ctrl)
+	mockFeeService = mocksService.NewMockFeeService(ctrl)
 	mockClock = clock.StaticClock{}
-	accSvc = NewAccountService(mockAccountRepo, mockClock) //prevents flaky tests due to minor time differences
+	accSvc = NewAccountService(mockAccountRepo, mockTransactionReviewRepo, mockHoldRepo, mockFraudService, mockAlertService, mockOverdraftService, mockFeeService, mockClock) //prevents flaky tests due to minor time differences
 
 	return func() {
 		mockAccountRepo = nil
+		mockTransactionReviewRepo = nil
+		mockHoldRepo = nil
+		mockFraudService = nil
+		mockAlertService = nil
+		mockOverdraftService = nil
+		mockFeeService = nil
 		defer ctrl.Finish()
 	}
 }
@@ -156,6 +178,8 @@ func TestDefaultAccountService_MakeTransaction_returns_error_when_cannotWithdraw
 	dummyExistentAccount.Amount = insufficientBalance
 	dummyExistentAccount.AccountId = dummyAccountId //after saving into db
 	mockAccountRepo.EXPECT().FindById(dummyTransactionRequest.AccountId).Return(&dummyExistentAccount, nil)
+	mockHoldRepo.EXPECT().FindHeldAmount(dummyAccountId, mockClock.NowAsString()).Return(float64(0), nil)
+	mockTransactionReviewRepo.EXPECT().FindReservedAmount(dummyAccountId).Return(float64(0), nil)
 
 	expectedErrMessage := "Account balance insufficient to withdraw given amount"
 
@@ -181,6 +205,61 @@ func TestDefaultAccountService_MakeTransaction_returns_error_when_cannotWithdraw
 	}
 }
 
+func TestDefaultAccountService_MakeTransaction_returns_error_when_account_frozen(t *testing.T) {
+	//Arrange
+	teardown := setupAccountServiceTest(t)
+	defer teardown()
+
+	dummyTransactionRequest := getDefaultDummyTransactionRequest()
+	dummyExistentAccount := getDefaultDummyAccount()
+	dummyExistentAccount.AccountId = dummyAccountId //after saving into db
+	dummyExistentAccount.Status = domain.AccountStatusFrozen
+	mockAccountRepo.EXPECT().FindById(dummyTransactionRequest.AccountId).Return(&dummyExistentAccount, nil)
+	mockAccountRepo.EXPECT().Transact(gomock.Any()).Times(0)
+
+	expectedErrMessage := "Account is frozen pending review"
+
+	//Act
+	_, actualErr := accSvc.MakeTransaction(dummyTransactionRequest)
+
+	//Assert
+	if actualErr == nil {
+		t.Fatal("Expected error but got none while testing transaction on frozen account")
+	}
+	if actualErr.Message != expectedErrMessage {
+		t.Errorf("Expected error message to be \"%s\" but got \"%s\"", expectedErrMessage, actualErr.Message)
+	}
+}
+
+func TestDefaultAccountService_MakeTransaction_returns_error_when_blocked_by_fraudRules(t *testing.T) {
+	//Arrange
+	teardown := setupAccountServiceTest(t)
+	defer teardown()
+
+	dummyTransactionRequest := getDefaultDummyTransactionRequest()
+	dummyExistentAccount := getDefaultDummyAccount()
+	dummyExistentAccount.AccountId = dummyAccountId
+	mockAccountRepo.EXPECT().FindById(dummyTransactionRequest.AccountId).Return(&dummyExistentAccount, nil)
+	mockHoldRepo.EXPECT().FindHeldAmount(dummyAccountId, mockClock.NowAsString()).Return(float64(0), nil)
+	mockTransactionReviewRepo.EXPECT().FindReservedAmount(dummyAccountId).Return(float64(0), nil)
+
+	dummyBlockDecision := domain.FraudDecision{Decision: domain.FraudDecisionBlock, Reasons: "6 withdrawals within 10m0s"}
+	mockFraudService.EXPECT().Screen(dummyExistentAccount, getDefaultDummyTransaction()).Return(&dummyBlockDecision, nil)
+	mockAccountRepo.EXPECT().Transact(gomock.Any()).Times(0)
+	expectedErrMessage := "Transaction declined for security reasons. Please contact the bank."
+
+	//Act
+	_, err := accSvc.MakeTransaction(dummyTransactionRequest)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing transaction blocked by fraud rules")
+	}
+	if err.Message != expectedErrMessage {
+		t.Errorf("Expected error message to be \"%s\" but got \"%s\"", expectedErrMessage, err.Message)
+	}
+}
+
 func TestDefaultAccountService_MakeTransaction_returns_error_when_repo_fails(t *testing.T) {
 	//Arrange
 	teardown := setupAccountServiceTest(t)
@@ -190,8 +269,11 @@ func TestDefaultAccountService_MakeTransaction_returns_error_when_repo_fails(t *
 	dummyExistentAccount := getDefaultDummyAccount()
 	dummyExistentAccount.AccountId = dummyAccountId
 	mockAccountRepo.EXPECT().FindById(dummyTransactionRequest.AccountId).Return(&dummyExistentAccount, nil)
+	mockHoldRepo.EXPECT().FindHeldAmount(dummyAccountId, mockClock.NowAsString()).Return(float64(0), nil)
+	mockTransactionReviewRepo.EXPECT().FindReservedAmount(dummyAccountId).Return(float64(0), nil)
 
 	dummyTransaction := getDefaultDummyTransaction()
+	mockFraudService.EXPECT().Screen(dummyExistentAccount, dummyTransaction).Return(&dummyAllowDecision, nil)
 	dummyAppErr := errs.NewUnexpectedError("some error message")
 	mockAccountRepo.EXPECT().Transact(dummyTransaction).Return(nil, dummyAppErr)
 
@@ -216,12 +298,17 @@ func TestDefaultAccountService_MakeTransaction_returns_newTransactionDetails_whe
 	dummyExistentAccount := getDefaultDummyAccount()
 	dummyExistentAccount.AccountId = dummyAccountId
 	mockAccountRepo.EXPECT().FindById(dummyTransactionRequest.AccountId).Return(&dummyExistentAccount, nil)
+	mockHoldRepo.EXPECT().FindHeldAmount(dummyAccountId, mockClock.NowAsString()).Return(float64(0), nil)
+	mockTransactionReviewRepo.EXPECT().FindReservedAmount(dummyAccountId).Return(float64(0), nil)
 
 	dummyTransaction := getDefaultDummyTransaction()
+	mockFraudService.EXPECT().Screen(dummyExistentAccount, dummyTransaction).Return(&dummyAllowDecision, nil)
 	dummyNewTransaction := dummyTransaction
 	dummyNewTransaction.TransactionId = dummyTransactionId
 	dummyNewTransaction.Balance = dummyBalance
 	mockAccountRepo.EXPECT().Transact(dummyTransaction).Return(&dummyNewTransaction, nil)
+	mockAlertService.EXPECT().EvaluateTransaction(dummyExistentAccount.CustomerId, dummyNewTransaction)
+	mockFeeService.EXPECT().ChargeForTransaction(dummyNewTransaction)
 
 	//Act
 	newTransactionResponse, err := accSvc.MakeTransaction(dummyTransactionRequest)
@@ -239,3 +326,296 @@ func TestDefaultAccountService_MakeTransaction_returns_newTransactionDetails_whe
 			dummyNewTransaction.Balance, newTransactionResponse.Balance)
 	}
 }
+
+func TestDefaultAccountService_MakeTransaction_charges_overdraft_when_withdrawal_overdraws_checkingAccount(t *testing.T) {
+	//Arrange
+	teardown := setupAccountServiceTest(t)
+	defer teardown()
+
+	dummyTransactionRequest := getDefaultDummyTransactionRequest()
+	dummyExistentAccount := getDefaultDummyAccount()
+	dummyExistentAccount.AccountId = dummyAccountId
+	dummyExistentAccount.AccountType = dto.AccountTypeChecking
+	dummyExistentAccount.Amount = 1000
+	dummyExistentAccount.OverdraftLimit = 5000
+	mockAccountRepo.EXPECT().FindById(dummyTransactionRequest.AccountId).Return(&dummyExistentAccount, nil)
+	mockHoldRepo.EXPECT().FindHeldAmount(dummyAccountId, mockClock.NowAsString()).Return(float64(0), nil)
+	mockTransactionReviewRepo.EXPECT().FindReservedAmount(dummyAccountId).Return(float64(0), nil)
+
+	dummyTransaction := getDefaultDummyTransaction()
+	mockFraudService.EXPECT().Screen(gomock.Any(), dummyTransaction).Return(&dummyAllowDecision, nil)
+	dummyNewTransaction := dummyTransaction
+	dummyNewTransaction.TransactionId = dummyTransactionId
+	dummyNewTransaction.Balance = -5000
+	mockAccountRepo.EXPECT().Transact(dummyTransaction).Return(&dummyNewTransaction, nil)
+	mockAlertService.EXPECT().EvaluateTransaction(dummyExistentAccount.CustomerId, dummyNewTransaction)
+	mockFeeService.EXPECT().ChargeForTransaction(dummyNewTransaction)
+	mockOverdraftService.EXPECT().ChargeForTransaction(dummyNewTransaction)
+
+	//Act
+	response, err := accSvc.MakeTransaction(dummyTransactionRequest)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing withdrawal within overdraft limit: " + err.Message)
+	}
+	if response.Balance != -5000 {
+		t.Errorf("Expected new balance to be -5000 but got %f", response.Balance)
+	}
+}
+
+func TestDefaultAccountService_MakeTransaction_returns_error_when_balance_reserved_for_heldWithdrawals(t *testing.T) {
+	//Arrange
+	teardown := setupAccountServiceTest(t)
+	defer teardown()
+
+	dummyTransactionRequest := getDefaultDummyTransactionRequest()
+	dummyExistentAccount := getDefaultDummyAccount()
+	dummyExistentAccount.AccountId = dummyAccountId
+	mockAccountRepo.EXPECT().FindById(dummyTransactionRequest.AccountId).Return(&dummyExistentAccount, nil)
+	mockHoldRepo.EXPECT().FindHeldAmount(dummyAccountId, mockClock.NowAsString()).Return(float64(0), nil)
+	mockTransactionReviewRepo.EXPECT().FindReservedAmount(dummyAccountId).Return(float64(1), nil)
+	mockFraudService.EXPECT().Screen(gomock.Any(), gomock.Any()).Times(0)
+	logger.MuteLogger()
+
+	//Act
+	_, err := accSvc.MakeTransaction(dummyTransactionRequest)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing withdrawal of funds reserved for held withdrawals")
+	}
+	if err.Message != "Account balance insufficient to withdraw given amount" {
+		t.Errorf("Expected insufficient balance error but got \"%s\"", err.Message)
+	}
+}
+
+func TestDefaultAccountService_MakeTransaction_returns_error_when_balance_on_hold(t *testing.T) {
+	//Arrange
+	teardown := setupAccountServiceTest(t)
+	defer teardown()
+
+	dummyTransactionRequest := getDefaultDummyTransactionRequest()
+	dummyExistentAccount := getDefaultDummyAccount()
+	dummyExistentAccount.AccountId = dummyAccountId
+	mockAccountRepo.EXPECT().FindById(dummyTransactionRequest.AccountId).Return(&dummyExistentAccount, nil)
+	mockHoldRepo.EXPECT().FindHeldAmount(dummyAccountId, mockClock.NowAsString()).Return(float64(1), nil)
+	mockTransactionReviewRepo.EXPECT().FindReservedAmount(dummyAccountId).Return(float64(0), nil)
+	mockFraudService.EXPECT().Screen(gomock.Any(), gomock.Any()).Times(0)
+	logger.MuteLogger()
+
+	//Act
+	_, err := accSvc.MakeTransaction(dummyTransactionRequest)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing withdrawal of funds on hold")
+	}
+	if err.Message != "Account balance insufficient to withdraw given amount" {
+		t.Errorf("Expected insufficient balance error but got \"%s\"", err.Message)
+	}
+}
+
+func TestDefaultAccountService_GetAllAccounts_returns_ledgerAndAvailableBalances(t *testing.T) {
+	//Arrange
+	teardown := setupAccountServiceTest(t)
+	defer teardown()
+
+	dummyAccount := getDefaultDummyAccount()
+	dummyAccount.AccountId = dummyAccountId
+	mockAccountRepo.EXPECT().FindAll(dummyCustomerId).Return([]domain.Account{dummyAccount}, nil)
+	mockHoldRepo.EXPECT().FindHeldAmount(dummyAccountId, mockClock.NowAsString()).Return(float64(500), nil)
+
+	//Act
+	accounts, err := accSvc.GetAllAccounts(dummyCustomerId)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing retrieval of accounts: " + err.Message)
+	}
+	if len(accounts) != 1 || accounts[0].Amount != dummyAmount || accounts[0].AvailableAmount != dummyAmount-500 {
+		t.Errorf("Expected ledger balance %v and available balance %v but got %v", dummyAmount, dummyAmount-500, accounts)
+	}
+}
+
+func TestDefaultAccountService_MakeTransaction_holds_transaction_when_flagged_for_review(t *testing.T) {
+	//Arrange
+	teardown := setupAccountServiceTest(t)
+	defer teardown()
+
+	dummyTransactionRequest := getDefaultDummyTransactionRequest()
+	dummyExistentAccount := getDefaultDummyAccount()
+	dummyExistentAccount.AccountId = dummyAccountId
+	mockAccountRepo.EXPECT().FindById(dummyTransactionRequest.AccountId).Return(&dummyExistentAccount, nil)
+	mockHoldRepo.EXPECT().FindHeldAmount(dummyAccountId, mockClock.NowAsString()).Return(float64(0), nil)
+	mockTransactionReviewRepo.EXPECT().FindReservedAmount(dummyAccountId).Return(float64(0), nil)
+
+	dummyTransaction := getDefaultDummyTransaction()
+	dummyReviewDecision := domain.FraudDecision{DecisionId: "3", Decision: domain.FraudDecisionReview, Reasons: "withdrawal within 1h0m0s of account opening"}
+	mockFraudService.EXPECT().Screen(dummyExistentAccount, dummyTransaction).Return(&dummyReviewDecision, nil)
+	dummyReview := domain.NewTransactionReview(dummyTransaction, dummyReviewDecision)
+	dummySavedReview := dummyReview
+	dummySavedReview.ReviewId = "5"
+	mockTransactionReviewRepo.EXPECT().Save(dummyReview).Return(&dummySavedReview, nil)
+	mockAccountRepo.EXPECT().Transact(gomock.Any()).Times(0)
+	mockAlertService.EXPECT().EvaluateTransaction(gomock.Any(), gomock.Any()).Times(0)
+
+	//Act
+	response, err := accSvc.MakeTransaction(dummyTransactionRequest)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing transaction held for review: " + err.Message)
+	}
+	if response.Status != dto.TransactionStatusPendingReview || response.ReviewId != "5" || response.TransactionId != "" {
+		t.Errorf("Expected transaction pending review 5 but got %v", *response)
+	}
+	if response.Balance != dummyExistentAccount.Amount {
+		t.Errorf("Expected balance to stay %f but got %f", dummyExistentAccount.Amount, response.Balance)
+	}
+}
+
+func TestDefaultAccountService_GetTransactions_returns_notFoundError_when_account_of_otherCustomer(t *testing.T) {
+	//Arrange
+	teardown := setupAccountServiceTest(t)
+	defer teardown()
+
+	dummyExistentAccount := getDefaultDummyAccount()
+	dummyExistentAccount.CustomerId = "3"
+	mockAccountRepo.EXPECT().FindById(dummyAccountId).Return(&dummyExistentAccount, nil)
+	mockAccountRepo.EXPECT().FindTransactions(gomock.Any()).Times(0)
+	logger.MuteLogger()
+
+	//Act
+	_, err := accSvc.GetTransactions(dummyCustomerId, dummyAccountId)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing retrieval of transactions of another customer's account")
+	}
+	if err.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d but got %d", http.StatusNotFound, err.Code)
+	}
+}
+
+func TestDefaultAccountService_GetTransactions_lists_posted_and_unapproved_heldTransactions_byDate(t *testing.T) {
+	//Arrange
+	teardown := setupAccountServiceTest(t)
+	defer teardown()
+
+	dummyExistentAccount := getDefaultDummyAccount()
+	dummyExistentAccount.AccountId = dummyAccountId
+	mockAccountRepo.EXPECT().FindById(dummyAccountId).Return(&dummyExistentAccount, nil)
+	mockAccountRepo.EXPECT().FindTransactions(dummyAccountId).Return([]domain.Transaction{
+		{TransactionId: "1", AccountId: dummyAccountId, Amount: 10, TransactionType: dto.TransactionTypeDeposit, TransactionDate: "2006-01-02 10:00:00"},
+		{TransactionId: "2", AccountId: dummyAccountId, Amount: 20, TransactionType: dto.TransactionTypeWithdrawal, TransactionDate: "2006-01-02 12:00:00"},
+	}, nil)
+	mockTransactionReviewRepo.EXPECT().FindAll(dummyAccountId).Return([]domain.TransactionReview{
+		{ReviewId: "7", AccountId: dummyAccountId, Amount: 20, Status: dto.TransactionStatusPosted, RequestedOn: "2006-01-02 09:00:00"},
+		{ReviewId: "8", AccountId: dummyAccountId, Amount: 30, Status: dto.TransactionStatusRejected, RequestedOn: "2006-01-02 11:00:00"},
+		{ReviewId: "9", AccountId: dummyAccountId, Amount: 40, Status: dto.TransactionStatusPendingReview, RequestedOn: "2006-01-02 13:00:00"},
+	}, nil)
+
+	//Act
+	transactions, err := accSvc.GetTransactions(dummyCustomerId, dummyAccountId)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing retrieval of transactions: " + err.Message)
+	}
+	expectedStatuses := []string{dto.TransactionStatusPosted, dto.TransactionStatusRejected, dto.TransactionStatusPosted,
+		dto.TransactionStatusPendingReview}
+	if len(transactions) != len(expectedStatuses) {
+		t.Fatalf("Expected %d transactions but got %v", len(expectedStatuses), transactions)
+	}
+	for i, status := range expectedStatuses {
+		if transactions[i].Status != status {
+			t.Errorf("Expected transaction %d to be %s but got %v", i, status, transactions[i])
+		}
+	}
+}
+
+func TestDefaultAccountService_UpdateTransactionCategory_returns_notFoundError_when_account_of_otherCustomer(t *testing.T) {
+	//Arrange
+	teardown := setupAccountServiceTest(t)
+	defer teardown()
+
+	dummyExistentAccount := getDefaultDummyAccount()
+	dummyExistentAccount.CustomerId = "3"
+	mockAccountRepo.EXPECT().FindById(dummyAccountId).Return(&dummyExistentAccount, nil)
+	mockAccountRepo.EXPECT().UpdateTransactionCategory(gomock.Any(), gomock.Any()).Times(0)
+	logger.MuteLogger()
+
+	//Act
+	_, err := accSvc.UpdateTransactionCategory(dto.TransactionCategoryRequest{CustomerId: dummyCustomerId,
+		AccountId: dummyAccountId, TransactionId: "1", Category: dto.TransactionCategoryDining})
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing recategorizing transaction of another customer's account")
+	}
+	if err.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d but got %d", http.StatusNotFound, err.Code)
+	}
+}
+
+func TestDefaultAccountService_UpdateTransactionCategory_returns_transaction_with_new_category(t *testing.T) {
+	//Arrange
+	teardown := setupAccountServiceTest(t)
+	defer teardown()
+
+	dummyExistentAccount := getDefaultDummyAccount()
+	mockAccountRepo.EXPECT().FindById(dummyAccountId).Return(&dummyExistentAccount, nil)
+	mockAccountRepo.EXPECT().FindTransaction(dummyAccountId, "1").Return(&domain.Transaction{TransactionId: "1",
+		AccountId: dummyAccountId, Amount: 10, TransactionType: dto.TransactionTypeWithdrawal,
+		TransactionDate: "2006-01-02 10:00:00", Description: "Lunch", Category: dto.TransactionCategoryOther}, nil)
+	mockAccountRepo.EXPECT().UpdateTransactionCategory("1", dto.TransactionCategoryDining).Return(nil)
+
+	//Act
+	transaction, err := accSvc.UpdateTransactionCategory(dto.TransactionCategoryRequest{CustomerId: dummyCustomerId,
+		AccountId: dummyAccountId, TransactionId: "1", Category: dto.TransactionCategoryDining})
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing recategorizing transaction: " + err.Message)
+	}
+	if transaction.Category != dto.TransactionCategoryDining || transaction.Description != "Lunch" {
+		t.Errorf("Expected transaction \"Lunch\" in category %s but got %v", dto.TransactionCategoryDining, *transaction)
+	}
+}
+
+func TestDefaultAccountService_with_stubRepo_creates_account_then_rejects_overdrawing_it(t *testing.T) {
+	//Arrange
+	accountRepo := domain.NewAccountRepositoryStub()
+	alertRepo := domain.NewAlertRepositoryStub()
+	alertSvc := NewAlertService(alertRepo, accountRepo, domain.NewInboxNotifier(alertRepo), clock.StaticClock{})
+	fraudRules, _ := domain.NewFraudRules(domain.DefaultFraudRuleConfigs())
+	fraudSvc := NewFraudService(domain.NewFraudRepositoryStub(accountRepo), domain.NewFraudEngine(fraudRules...), clock.StaticClock{})
+	holdRepo := domain.NewHoldRepositoryStub()
+	overdraftSvc := NewOverdraftService(domain.NewOverdraftRepositoryStub(), accountRepo, holdRepo, domain.OverdraftTerms{}, clock.StaticClock{})
+	feeSvc := NewFeeService(domain.NewFeeRepositoryStub(accountRepo), accountRepo, overdraftSvc, clock.StaticClock{})
+	stubSvc := NewAccountService(accountRepo, domain.NewTransactionReviewRepositoryStub(), holdRepo, fraudSvc, alertSvc, overdraftSvc, feeSvc, clock.StaticClock{})
+	logger.MuteLogger()
+
+	//Act
+	newAccount, createErr := stubSvc.CreateNewAccount(getDefaultDummyNewAccountRequest())
+	withdrawal := getDefaultDummyTransactionRequest()
+	withdrawal.AccountId = newAccount.AccountId
+	firstWithdrawal, firstErr := stubSvc.MakeTransaction(withdrawal)
+	_, secondErr := stubSvc.MakeTransaction(withdrawal)
+	accounts, _ := stubSvc.GetAllAccounts(dummyCustomerId)
+
+	//Assert
+	if createErr != nil || firstErr != nil {
+		t.Fatal("Expected no error but got error while testing creating and withdrawing from account")
+	}
+	if firstWithdrawal.Status != dto.TransactionStatusPendingReview || firstWithdrawal.Balance != dummyAmount {
+		t.Errorf("Expected withdrawal right after opening to be held for review but got %v", *firstWithdrawal)
+	}
+	if secondErr == nil || secondErr.Message != "Account balance insufficient to withdraw given amount" {
+		t.Errorf("Expected error for insufficient balance, as the whole amount is reserved, but got %v", secondErr)
+	}
+	if len(accounts) != 2 || accounts[1].AccountId != newAccount.AccountId || accounts[1].Amount != dummyAmount {
+		t.Errorf("Expected default and new account with unchanged balance but got %v", accounts)
+	}
+}
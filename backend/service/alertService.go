@@ -0,0 +1,133 @@
+package service
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/problem"
+)
+
+//go:generate mockgen -destination=../mocks/service/mock_alertService.go -package=service github.com/aliciatay-zls/banking/backend/service AlertService
+type AlertService interface { //service (primary port)
+	GetRules(string, string) ([]dto.AlertRuleResponse, *errs.AppError)
+	CreateRule(dto.NewAlertRuleRequest) (*dto.AlertRuleResponse, *errs.AppError)
+	DeleteRule(string, string, string) *errs.AppError
+	GetAlerts(string) ([]dto.AlertResponse, *errs.AppError)
+	EvaluateTransaction(string, domain.Transaction)
+}
+
+type DefaultAlertService struct { //business/domain object
+	repo        domain.AlertRepository
+	accountRepo domain.AccountRepository
+	notifier    domain.Notifier
+	clk         clock.Clock
+}
+
+func NewAlertService(repo domain.AlertRepository, accountRepo domain.AccountRepository, notifier domain.Notifier, clk clock.Clock) DefaultAlertService {
+	return DefaultAlertService{repo, accountRepo, notifier, clk}
+}
+
+// GetRules returns the alert rules of the given account of the given customer.
+func (s DefaultAlertService) GetRules(customerId string, accountId string) ([]dto.AlertRuleResponse, *errs.AppError) {
+	if appErr := s.checkAccountOwner(customerId, accountId); appErr != nil {
+		return nil, appErr
+	}
+
+	rules, appErr := s.repo.FindRules(accountId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	response := make([]dto.AlertRuleResponse, 0)
+	for _, rule := range rules {
+		response = append(response, rule.ToDTO())
+	}
+	return response, nil
+}
+
+// CreateRule adds an alert rule to the given account of the given customer, unless the account already has a rule of
+// the same type, which has to be deleted first.
+func (s DefaultAlertService) CreateRule(request dto.NewAlertRuleRequest) (*dto.AlertRuleResponse, *errs.AppError) {
+	if appErr := s.checkAccountOwner(request.CustomerId, request.AccountId); appErr != nil {
+		return nil, appErr
+	}
+
+	rules, appErr := s.repo.FindRules(request.AccountId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	for _, rule := range rules {
+		if rule.RuleType == request.RuleType {
+			logger.Error("Alert rule of type " + request.RuleType + " already exists for account " + request.AccountId)
+			return nil, problem.NewConflictError(problem.AlertRuleExists,
+				"This account already has an alert of this type. Please delete it first.")
+		}
+	}
+
+	rule, appErr := s.repo.SaveRule(domain.NewAlertRule(request, s.clk))
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	response := rule.ToDTO()
+	return &response, nil
+}
+
+// DeleteRule removes the alert rule with the given id from the given account of the given customer.
+func (s DefaultAlertService) DeleteRule(customerId string, accountId string, ruleId string) *errs.AppError {
+	if appErr := s.checkAccountOwner(customerId, accountId); appErr != nil {
+		return appErr
+	}
+
+	return s.repo.DeleteRule(accountId, ruleId)
+}
+
+// GetAlerts returns the in-app inbox of the given customer, newest first.
+func (s DefaultAlertService) GetAlerts(customerId string) ([]dto.AlertResponse, *errs.AppError) {
+	alerts, appErr := s.repo.FindAlerts(customerId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	response := make([]dto.AlertResponse, 0)
+	for _, alert := range alerts {
+		response = append(response, alert.ToDTO())
+	}
+	return response, nil
+}
+
+// EvaluateTransaction checks the given completed transaction against the alert rules of its account and notifies the
+// given customer of every rule triggered. Since the transaction has already been made, errors are only logged.
+func (s DefaultAlertService) EvaluateTransaction(customerId string, transaction domain.Transaction) {
+	rules, appErr := s.repo.FindRules(transaction.AccountId)
+	if appErr != nil {
+		logger.Error("Error while evaluating alert rules of transaction " + transaction.TransactionId + ": " + appErr.Message)
+		return
+	}
+
+	for _, rule := range rules {
+		message, isTriggered := rule.Evaluate(transaction)
+		if !isTriggered {
+			continue
+		}
+		if appErr = s.notifier.Notify(domain.NewAlert(customerId, rule, message, s.clk)); appErr != nil {
+			logger.Error("Error while notifying customer " + customerId + " of alert: " + appErr.Message)
+		}
+	}
+}
+
+// checkAccountOwner returns a not found error if the account with the given id does not belong to the customer with
+// the given id, so that a customer cannot tell the accounts of others apart from accounts that do not exist.
+func (s DefaultAlertService) checkAccountOwner(customerId string, accountId string) *errs.AppError {
+	account, appErr := s.accountRepo.FindById(accountId)
+	if appErr != nil {
+		return appErr
+	}
+	if account.CustomerId != customerId {
+		logger.Error("Account " + accountId + " does not belong to customer " + customerId)
+		return problem.NewNotFoundError(problem.AccountNotFound, "Account not found")
+	}
+	return nil
+}
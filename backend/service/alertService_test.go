@@ -0,0 +1,149 @@
+package service
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	mocksDomain "github.com/aliciatay-zls/banking/backend/mocks/domain"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockAlertRepo *mocksDomain.MockAlertRepository
+var mockAlertAccountRepo *mocksDomain.MockAccountRepository
+var mockNotifier *mocksDomain.MockNotifier
+var alertSvc DefaultAlertService
+
+func setupAlertServiceTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	mockAlertRepo = mocksDomain.NewMockAlertRepository(ctrl)
+	mockAlertAccountRepo = mocksDomain.NewMockAccountRepository(ctrl)
+	mockNotifier = mocksDomain.NewMockNotifier(ctrl)
+	alertSvc = NewAlertService(mockAlertRepo, mockAlertAccountRepo, mockNotifier, clock.StaticClock{})
+
+	return func() {
+		mockAlertRepo = nil
+		mockAlertAccountRepo = nil
+		mockNotifier = nil
+		defer ctrl.Finish()
+	}
+}
+
+// getDummyAlertRules returns a large withdrawal rule above 1000 and a low balance rule below 500 for the account
+// with id 1977
+func getDummyAlertRules() []domain.AlertRule {
+	return []domain.AlertRule{
+		{RuleId: "1", AccountId: dummyAccountId, RuleType: dto.AlertRuleTypeLargeWithdrawal, Threshold: 1000},
+		{RuleId: "2", AccountId: dummyAccountId, RuleType: dto.AlertRuleTypeLowBalance, Threshold: 500},
+	}
+}
+
+func TestDefaultAlertService_GetRules_returns_notFoundError_when_account_of_otherCustomer(t *testing.T) {
+	//Arrange
+	teardown := setupAlertServiceTest(t)
+	defer teardown()
+
+	account := domain.Account{AccountId: dummyAccountId, CustomerId: "3"}
+	mockAlertAccountRepo.EXPECT().FindById(dummyAccountId).Return(&account, nil)
+	mockAlertRepo.EXPECT().FindRules(gomock.Any()).Times(0)
+	logger.MuteLogger()
+
+	//Act
+	_, err := alertSvc.GetRules(dummyCustomerId, dummyAccountId)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing retrieval of rules of another customer's account")
+	}
+	if err.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d but got %d", http.StatusNotFound, err.Code)
+	}
+}
+
+func TestDefaultAlertService_CreateRule_returns_conflictError_when_rule_of_sameType_exists(t *testing.T) {
+	//Arrange
+	teardown := setupAlertServiceTest(t)
+	defer teardown()
+
+	account := domain.Account{AccountId: dummyAccountId, CustomerId: dummyCustomerId}
+	mockAlertAccountRepo.EXPECT().FindById(dummyAccountId).Return(&account, nil)
+	mockAlertRepo.EXPECT().FindRules(dummyAccountId).Return(getDummyAlertRules(), nil)
+	mockAlertRepo.EXPECT().SaveRule(gomock.Any()).Times(0)
+	logger.MuteLogger()
+
+	request := dto.NewAlertRuleRequest{
+		CustomerId: dummyCustomerId,
+		AccountId:  dummyAccountId,
+		RuleType:   dto.AlertRuleTypeLowBalance,
+		Threshold:  200,
+	}
+
+	//Act
+	_, err := alertSvc.CreateRule(request)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing creation of second rule of same type")
+	}
+	if err.Code != http.StatusConflict {
+		t.Errorf("Expected status code %d but got %d", http.StatusConflict, err.Code)
+	}
+}
+
+func TestDefaultAlertService_EvaluateTransaction_notifies_customer_of_triggeredRules_only(t *testing.T) {
+	//Arrange
+	teardown := setupAlertServiceTest(t)
+	defer teardown()
+
+	transaction := domain.Transaction{
+		TransactionId:   dummyTransactionId,
+		AccountId:       dummyAccountId,
+		Amount:          600,
+		Balance:         400,
+		TransactionType: dto.TransactionTypeWithdrawal,
+	}
+	mockAlertRepo.EXPECT().FindRules(dummyAccountId).Return(getDummyAlertRules(), nil)
+
+	var actualAlerts []domain.Alert
+	mockNotifier.EXPECT().Notify(gomock.Any()).DoAndReturn(func(alert domain.Alert) *errs.AppError {
+		actualAlerts = append(actualAlerts, alert)
+		return nil
+	})
+
+	//Act
+	alertSvc.EvaluateTransaction(dummyCustomerId, transaction)
+
+	//Assert
+	if len(actualAlerts) != 1 {
+		t.Fatalf("Expected 1 alert but got %d: %v", len(actualAlerts), actualAlerts)
+	}
+	if actualAlerts[0].CustomerId != dummyCustomerId || actualAlerts[0].RuleType != dto.AlertRuleTypeLowBalance {
+		t.Errorf("Expected low balance alert for customer %s but got %v", dummyCustomerId, actualAlerts[0])
+	}
+}
+
+func TestDefaultAlertService_EvaluateTransaction_keeps_notifying_when_notifier_fails(t *testing.T) {
+	//Arrange
+	teardown := setupAlertServiceTest(t)
+	defer teardown()
+
+	transaction := domain.Transaction{
+		AccountId:       dummyAccountId,
+		Amount:          1500,
+		Balance:         300,
+		TransactionType: dto.TransactionTypeWithdrawal,
+	}
+	mockAlertRepo.EXPECT().FindRules(dummyAccountId).Return(getDummyAlertRules(), nil)
+	mockNotifier.EXPECT().Notify(gomock.Any()).Return(errs.NewUnexpectedError("some error message")).Times(2)
+	logger.MuteLogger()
+
+	//Act
+	alertSvc.EvaluateTransaction(dummyCustomerId, transaction)
+
+	//Assert
+	//done by the mock notifier, which expects both triggered rules to be notified even though the first fails
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+)
+
+//go:generate mockgen -destination=../mocks/service/mock_analyticsService.go -package=service github.com/aliciatay-zls/banking/backend/service AnalyticsService
+type AnalyticsService interface { //service (primary port)
+	GetAnalytics(dto.AnalyticsRequest) (*dto.AnalyticsResponse, *errs.AppError)
+}
+
+type DefaultAnalyticsService struct { //business/domain object
+	repo        domain.AnalyticsRepository
+	accountRepo domain.AccountRepository
+	clk         clock.Clock
+}
+
+func NewAnalyticsService(repo domain.AnalyticsRepository, accountRepo domain.AccountRepository, clk clock.Clock) DefaultAnalyticsService {
+	return DefaultAnalyticsService{repo, accountRepo, clk}
+}
+
+// GetAnalytics summarizes the transactions posted on all accounts of the given customer over the given months, or over
+// the last dto.AnalyticsDefaultMonths months up to the current month if none are given: their totals and averages, by
+// month with the change from the month before, by category and by transaction type, and the largest debits. The
+// sums are worked out by the repository.
+func (s DefaultAnalyticsService) GetAnalytics(request dto.AnalyticsRequest) (*dto.AnalyticsResponse, *errs.AppError) {
+	period, appErr := s.period(request)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	accounts, appErr := s.accountRepo.FindAll(request.CustomerId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	analytics := domain.CustomerAnalytics{CustomerId: request.CustomerId, Period: period}
+	if len(accounts) == 0 {
+		response := analytics.ToDTO()
+		return &response, nil
+	}
+	accountIds := make([]string, 0, len(accounts))
+	for _, a := range accounts {
+		accountIds = append(accountIds, a.AccountId)
+	}
+
+	totals, appErr := s.repo.SumTransactions(accountIds, period, domain.AnalyticsGroupingTotal)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if len(totals) > 0 {
+		analytics.Totals = totals[0]
+	}
+	if analytics.ByMonth, appErr = s.repo.SumTransactions(accountIds, period, domain.AnalyticsGroupingMonth); appErr != nil {
+		return nil, appErr
+	}
+	if analytics.ByCategory, appErr = s.repo.SumTransactions(accountIds, period, domain.AnalyticsGroupingCategory); appErr != nil {
+		return nil, appErr
+	}
+	if analytics.ByType, appErr = s.repo.SumTransactions(accountIds, period, domain.AnalyticsGroupingType); appErr != nil {
+		return nil, appErr
+	}
+	if analytics.TopTransactions, appErr = s.repo.FindLargestDebits(accountIds, period, dto.AnalyticsTopTransactions); appErr != nil {
+		return nil, appErr
+	}
+
+	response := analytics.ToDTO()
+	return &response, nil
+}
+
+// period returns the months from the given first month up to the given last month or the current month, or the
+// dto.AnalyticsDefaultMonths months up to the last month if no first month is given.
+func (s DefaultAnalyticsService) period(request dto.AnalyticsRequest) (domain.AnalyticsPeriod, *errs.AppError) {
+	to := request.To
+	if to == "" {
+		to = domain.AnalyticsMonthOf(s.clk.NowAsString())
+	}
+
+	var period domain.AnalyticsPeriod
+	var err error
+	if request.From == "" {
+		period, err = domain.NewAnalyticsPeriodEndingIn(to, dto.AnalyticsDefaultMonths)
+	} else {
+		period, err = domain.NewAnalyticsPeriod(request.From, to)
+	}
+	if err != nil {
+		logger.Error("Invalid months for analytics: " + err.Error())
+		return domain.AnalyticsPeriod{}, errs.NewValidationError("Please give the months to analyze as YYYY-MM.")
+	}
+
+	months := len(period.Months())
+	if months == 0 {
+		logger.Error("Analytics requested from " + request.From + " after " + to)
+		return domain.AnalyticsPeriod{}, errs.NewValidationError("The first month to analyze cannot be after the last month.")
+	}
+	if months > dto.AnalyticsMaxMonths {
+		logger.Error(fmt.Sprintf("Analytics requested over %d months", months))
+		return domain.AnalyticsPeriod{}, errs.NewValidationError(fmt.Sprintf("At most %d months can be analyzed at once.", dto.AnalyticsMaxMonths))
+	}
+	return period, nil
+}
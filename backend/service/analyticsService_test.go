@@ -0,0 +1,127 @@
+package service
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	mocksDomain "github.com/aliciatay-zls/banking/backend/mocks/domain"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockAnalyticsRepo *mocksDomain.MockAnalyticsRepository
+var mockAnalyticsAccountRepo *mocksDomain.MockAccountRepository
+var analyticsSvc DefaultAnalyticsService
+
+func setupAnalyticsServiceTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	mockAnalyticsRepo = mocksDomain.NewMockAnalyticsRepository(ctrl)
+	mockAnalyticsAccountRepo = mocksDomain.NewMockAccountRepository(ctrl)
+	analyticsSvc = NewAnalyticsService(mockAnalyticsRepo, mockAnalyticsAccountRepo, clock.StaticClock{})
+	logger.MuteLogger()
+
+	return func() {
+		mockAnalyticsRepo = nil
+		mockAnalyticsAccountRepo = nil
+		defer ctrl.Finish()
+	}
+}
+
+func TestDefaultAnalyticsService_GetAnalytics_returns_validationError_when_months_invalid(t *testing.T) {
+	//Arrange
+	tests := []struct {
+		name string
+		from string
+		to   string
+	}{
+		{"from after to", "2006-03", "2006-01"},
+		{"from after current month", "2006-02", ""},
+		{"too many months", "2004-01", "2006-01"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			teardown := setupAnalyticsServiceTest(t)
+			defer teardown()
+
+			mockAnalyticsAccountRepo.EXPECT().FindAll(gomock.Any()).Times(0)
+
+			//Act
+			_, err := analyticsSvc.GetAnalytics(dto.AnalyticsRequest{CustomerId: dummyCustomerId, From: tc.from, To: tc.to})
+
+			//Assert
+			if err == nil {
+				t.Fatal("Expected error but got none while testing analytics over invalid months")
+			}
+			if err.Code != http.StatusUnprocessableEntity {
+				t.Errorf("Expected status code %d but got %d", http.StatusUnprocessableEntity, err.Code)
+			}
+		})
+	}
+}
+
+func TestDefaultAnalyticsService_GetAnalytics_returns_emptyAnalytics_over_defaultMonths_when_customer_has_no_accounts(t *testing.T) {
+	//Arrange
+	teardown := setupAnalyticsServiceTest(t)
+	defer teardown()
+
+	mockAnalyticsAccountRepo.EXPECT().FindAll(dummyCustomerId).Return([]domain.Account{}, nil)
+	mockAnalyticsRepo.EXPECT().SumTransactions(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	//Act
+	analytics, err := analyticsSvc.GetAnalytics(dto.AnalyticsRequest{CustomerId: dummyCustomerId})
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing analytics of customer without accounts: " + err.Message)
+	}
+	if analytics.From != "2005-02" || analytics.To != "2006-01" || len(analytics.ByMonth) != dto.AnalyticsDefaultMonths {
+		t.Errorf("Expected 12 empty months up to 2006-01 but got %v", *analytics)
+	}
+}
+
+func TestDefaultAnalyticsService_GetAnalytics_sums_transactions_of_all_accounts_of_customer(t *testing.T) {
+	//Arrange
+	teardown := setupAnalyticsServiceTest(t)
+	defer teardown()
+
+	accountIds := []string{dummyAccountId, "1978"}
+	period, _ := domain.NewAnalyticsPeriod("2005-12", "2006-01")
+	mockAnalyticsAccountRepo.EXPECT().FindAll(dummyCustomerId).Return([]domain.Account{
+		{AccountId: dummyAccountId, CustomerId: dummyCustomerId},
+		{AccountId: "1978", CustomerId: dummyCustomerId},
+	}, nil)
+	mockAnalyticsRepo.EXPECT().SumTransactions(accountIds, period, domain.AnalyticsGroupingTotal).
+		Return([]domain.TransactionTotals{{Income: 500, Spending: 150, IncomeCount: 1, SpendingCount: 2}}, nil)
+	mockAnalyticsRepo.EXPECT().SumTransactions(accountIds, period, domain.AnalyticsGroupingMonth).
+		Return([]domain.TransactionTotals{
+			{Group: "2005-12", Spending: 100, SpendingCount: 1},
+			{Group: "2006-01", Income: 500, Spending: 50, IncomeCount: 1, SpendingCount: 1},
+		}, nil)
+	mockAnalyticsRepo.EXPECT().SumTransactions(accountIds, period, domain.AnalyticsGroupingCategory).
+		Return([]domain.TransactionTotals{{Group: dto.TransactionCategoryDining, Spending: 150, SpendingCount: 2}}, nil)
+	mockAnalyticsRepo.EXPECT().SumTransactions(accountIds, period, domain.AnalyticsGroupingType).
+		Return([]domain.TransactionTotals{{Group: dto.TransactionTypeWithdrawal, Spending: 150, SpendingCount: 2}}, nil)
+	mockAnalyticsRepo.EXPECT().FindLargestDebits(accountIds, period, dto.AnalyticsTopTransactions).
+		Return([]domain.Transaction{{TransactionId: "3", AccountId: "1978", Amount: 100, TransactionType: dto.TransactionTypeWithdrawal}}, nil)
+
+	//Act
+	analytics, err := analyticsSvc.GetAnalytics(dto.AnalyticsRequest{CustomerId: dummyCustomerId, From: "2005-12"})
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing analytics: " + err.Message)
+	}
+	if analytics.Totals.Net != 350 || analytics.Totals.AverageMonthlySpending != 75 {
+		t.Errorf("Expected net of 350 and monthly spending of 75 but got %v", analytics.Totals)
+	}
+	if len(analytics.ByMonth) != 2 || *analytics.ByMonth[1].SpendingChangePercent != -50 {
+		t.Errorf("Expected spending to halve from December to January but got %v", analytics.ByMonth)
+	}
+	if len(analytics.TopTransactions) != 1 || analytics.TopTransactions[0].AccountId != "1978" {
+		t.Errorf("Expected the withdrawal from account 1978 as top transaction but got %v", analytics.TopTransactions)
+	}
+}
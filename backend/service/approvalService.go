@@ -0,0 +1,289 @@
+package service
+
+import (
+	"encoding/json"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/problem"
+	"strings"
+)
+
+// ApprovalExecutor carries out an approved operation from its JSON payload and returns the response of the operation.
+type ApprovalExecutor func(string) (interface{}, *errs.AppError)
+
+//go:generate mockgen -destination=../mocks/service/mock_approvalService.go -package=service github.com/aliciatay-zls/banking/backend/service ApprovalService
+type ApprovalService interface { //service (primary port)
+	IsTransactionApprovalRequired(domain.AuthClaims, dto.TransactionRequest) bool
+	IsReversalApprovalRequired(dto.TransactionReversalRequest, float64) bool
+	RequestApproval(string, string, interface{}, string) (*dto.ApprovalResponse, *errs.AppError)
+	GetPendingApprovals() ([]dto.ApprovalResponse, *errs.AppError)
+	Approve(string, string) (*dto.ApprovalResponse, *errs.AppError)
+	Reject(string, string) (*dto.ApprovalResponse, *errs.AppError)
+}
+
+type DefaultApprovalService struct { //business/domain object
+	repo      domain.ApprovalRepository
+	executors map[string]ApprovalExecutor //by operation
+	threshold float64                     //amount above which a transaction or reversal made by an admin needs approval
+	clk       clock.Clock
+}
+
+func NewApprovalService(repo domain.ApprovalRepository, executors map[string]ApprovalExecutor, threshold float64, clk clock.Clock) DefaultApprovalService {
+	return DefaultApprovalService{repo, executors, threshold, clk}
+}
+
+// IsTransactionApprovalRequired reports whether the given transaction, made by the client with the given claims, must
+// be approved by a second admin. Only transactions made by an admin above the threshold need approval, as customers
+// can only move their own money and are already screened by the fraud rules.
+func (s DefaultApprovalService) IsTransactionApprovalRequired(claims domain.AuthClaims, request dto.TransactionRequest) bool {
+	return claims.IsAdmin() && request.Amount > s.threshold
+}
+
+// IsReversalApprovalRequired reports whether the given reversal of a transaction of the given amount must be approved
+// by a second admin. A reversal above the threshold needs approval like a transaction, and so does any forced
+// reversal, as it may overdraw the account.
+func (s DefaultApprovalService) IsReversalApprovalRequired(request dto.TransactionReversalRequest, amount float64) bool {
+	return request.Force || amount > s.threshold
+}
+
+// RequestApproval saves the given operation, with its description and payload, as requested by the given admin. It is
+// only carried out once a different admin approves it.
+func (s DefaultApprovalService) RequestApproval(operation string, description string, payload interface{}, requestedBy string) (*dto.ApprovalResponse, *errs.AppError) {
+	if appErr := checkAdminIdentified(requestedBy); appErr != nil {
+		return nil, appErr
+	}
+	if _, ok := s.executors[operation]; !ok {
+		logger.Error("Approval requested for an operation that cannot be executed: " + operation)
+		return nil, errs.NewUnexpectedError("Unexpected server error")
+	}
+
+	content, err := json.Marshal(payload)
+	if err != nil {
+		logger.Error("Error while marshalling payload of approval request: " + err.Error())
+		return nil, errs.NewUnexpectedError("Unexpected server error")
+	}
+
+	approval, appErr := s.repo.Save(domain.NewApprovalRequest(operation, description, string(content), requestedBy, s.clk))
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	response := approval.ToDTO()
+	return &response, nil
+}
+
+// GetPendingApprovals returns the approval requests waiting for a second admin, oldest first, after expiring those
+// that have waited too long.
+func (s DefaultApprovalService) GetPendingApprovals() ([]dto.ApprovalResponse, *errs.AppError) {
+	if appErr := s.repo.ExpirePending(s.clk.NowAsString()); appErr != nil {
+		return nil, appErr
+	}
+	approvals, appErr := s.repo.FindPending()
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	response := make([]dto.ApprovalResponse, 0)
+	for _, a := range approvals {
+		response = append(response, a.ToDTO())
+	}
+	return response, nil
+}
+
+// Approve carries out the operation of the given approval request on behalf of the given admin, who must not be the
+// admin who requested it. The request is marked as approved before the operation is carried out, so that two admins
+// approving it at once cannot carry it out twice, and is then marked as executed or, if the operation returned an
+// error, as failed. A failed request is not retried: the operation has to be requested again.
+func (s DefaultApprovalService) Approve(approvalId string, approvedBy string) (*dto.ApprovalResponse, *errs.AppError) {
+	approval, appErr := s.findPending(approvalId, approvedBy)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if approval.RequestedBy == approvedBy {
+		logger.Error("Approval request " + approvalId + " approved by the admin who requested it: " + approvedBy)
+		return nil, problem.NewAuthorizationError(problem.ApprovalByRequester,
+			"An approval request must be approved by a different admin than the one who requested it")
+	}
+	execute, ok := s.executors[approval.Operation]
+	if !ok {
+		logger.Error("Approval request " + approvalId + " is for an operation that cannot be executed: " + approval.Operation)
+		return nil, errs.NewUnexpectedError("Unexpected server error")
+	}
+
+	approved := approval.Decide(dto.ApprovalStatusApproved, approvedBy, s.clk)
+	if appErr = s.repo.UpdateStatus(approved, dto.ApprovalStatusPending); appErr != nil {
+		return nil, appErr
+	}
+
+	response, executeErr := execute(approved.Payload)
+	completed := approved.Complete(response, executeErr)
+	if appErr = s.repo.UpdateStatus(completed, dto.ApprovalStatusApproved); appErr != nil {
+		logger.Error("Error while recording the outcome of approval request " + approvalId + ": " + appErr.Message)
+	}
+	if executeErr != nil {
+		return nil, executeErr
+	}
+
+	completedResponse := completed.ToDTO()
+	return &completedResponse, nil
+}
+
+// Reject drops the operation of the given approval request without carrying it out. Any admin may reject it,
+// including the one who requested it.
+func (s DefaultApprovalService) Reject(approvalId string, rejectedBy string) (*dto.ApprovalResponse, *errs.AppError) {
+	approval, appErr := s.findPending(approvalId, rejectedBy)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	rejected := approval.Decide(dto.ApprovalStatusRejected, rejectedBy, s.clk)
+	if appErr = s.repo.UpdateStatus(rejected, dto.ApprovalStatusPending); appErr != nil {
+		return nil, appErr
+	}
+
+	response := rejected.ToDTO()
+	return &response, nil
+}
+
+// findPending returns the approval request with the given id to be decided by the given admin, or a conflict error if
+// it has expired or already been decided.
+func (s DefaultApprovalService) findPending(approvalId string, decidedBy string) (*domain.ApprovalRequest, *errs.AppError) {
+	if appErr := checkAdminIdentified(decidedBy); appErr != nil {
+		return nil, appErr
+	}
+	if appErr := s.repo.ExpirePending(s.clk.NowAsString()); appErr != nil {
+		return nil, appErr
+	}
+
+	approval, appErr := s.repo.FindById(approvalId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if approval.Status == dto.ApprovalStatusExpired {
+		logger.Error("Approval request " + approvalId + " has expired")
+		return nil, problem.NewConflictError(problem.ApprovalExpired, "Approval request has expired")
+	}
+	if !approval.IsPending() {
+		logger.Error("Approval request " + approvalId + " has already been decided as " + approval.Status)
+		return nil, problem.NewConflictError(problem.ApprovalAlreadyDecided,
+			"Approval request has already been decided")
+	}
+	return approval, nil
+}
+
+// checkAdminIdentified returns an error if the admin acting on an approval request could not be identified from their
+// token, as requests must be traceable to the admins who requested and decided them.
+func checkAdminIdentified(username string) *errs.AppError {
+	if username == "" {
+		logger.Error("Admin acting on approval request could not be identified")
+		return problem.NewAuthorizationError(problem.AdminUnidentified,
+			"Unable to identify the admin making the request")
+	}
+	return nil
+}
+
+// NewTransactionApprovalExecutor returns an executor that makes the approved transaction.
+func NewTransactionApprovalExecutor(s AccountService) ApprovalExecutor {
+	return func(payload string) (interface{}, *errs.AppError) {
+		var request dto.TransactionRequest
+		if appErr := decodeApprovalPayload(payload, &request); appErr != nil {
+			return nil, appErr
+		}
+		response, appErr := s.MakeTransaction(request)
+		if appErr != nil {
+			return nil, appErr
+		}
+		return response, nil
+	}
+}
+
+// NewTransactionImportApprovalExecutor returns an executor that commits the approved transaction import.
+func NewTransactionImportApprovalExecutor(s TransactionImportService) ApprovalExecutor {
+	return func(payload string) (interface{}, *errs.AppError) {
+		var request dto.TransactionImportRequest
+		if appErr := decodeApprovalPayload(payload, &request); appErr != nil {
+			return nil, appErr
+		}
+		response, appErr := s.ImportTransactions(request)
+		if appErr != nil {
+			return nil, appErr
+		}
+		if !response.IsCommitted {
+			logger.Error("Approved transaction import was not committed: " + response.FileHash)
+			return nil, problem.NewValidationError(problem.ImportNotCommitted,
+				"Transaction import could not be committed, please check the file and request it again")
+		}
+		return response, nil
+	}
+}
+
+// NewTransactionReversalApprovalExecutor returns an executor that makes the approved reversal, on behalf of the admin
+// who requested it.
+func NewTransactionReversalApprovalExecutor(s TransactionReversalService) ApprovalExecutor {
+	return func(payload string) (interface{}, *errs.AppError) {
+		var approval dto.TransactionReversalApproval
+		if appErr := decodeApprovalPayload(payload, &approval); appErr != nil {
+			return nil, appErr
+		}
+		response, appErr := s.Reverse(dto.TransactionReversalRequest(approval))
+		if appErr != nil {
+			return nil, appErr
+		}
+		return response, nil
+	}
+}
+
+// NewFreezeApprovalExecutor returns an executor that freezes the accounts with the approved ids, which were found
+// mismatched by the reconciliation run at the time of the request.
+func NewFreezeApprovalExecutor(s ReconciliationService) ApprovalExecutor {
+	return func(payload string) (interface{}, *errs.AppError) {
+		var accountIds []string
+		if appErr := decodeApprovalPayload(payload, &accountIds); appErr != nil {
+			return nil, appErr
+		}
+		if appErr := s.FreezeAccounts(accountIds); appErr != nil {
+			return nil, appErr
+		}
+		return errs.NewMessageObject("Accounts frozen: " + strings.Join(accountIds, ", ")), nil
+	}
+}
+
+// NewWebhookSubscriptionApprovalExecutor returns an executor that creates the approved webhook subscription.
+func NewWebhookSubscriptionApprovalExecutor(s WebhookService) ApprovalExecutor {
+	return func(payload string) (interface{}, *errs.AppError) {
+		var request dto.NewWebhookSubscriptionRequest
+		if appErr := decodeApprovalPayload(payload, &request); appErr != nil {
+			return nil, appErr
+		}
+		response, appErr := s.CreateSubscription(request)
+		if appErr != nil {
+			return nil, appErr
+		}
+		return response, nil
+	}
+}
+
+// NewWebhookDeletionApprovalExecutor returns an executor that deletes the webhook subscription with the approved id.
+func NewWebhookDeletionApprovalExecutor(s WebhookService) ApprovalExecutor {
+	return func(payload string) (interface{}, *errs.AppError) {
+		var subscriptionId string
+		if appErr := decodeApprovalPayload(payload, &subscriptionId); appErr != nil {
+			return nil, appErr
+		}
+		if appErr := s.DeleteSubscription(subscriptionId); appErr != nil {
+			return nil, appErr
+		}
+		return errs.NewMessageObject("Webhook subscription deleted"), nil
+	}
+}
+
+func decodeApprovalPayload(payload string, v interface{}) *errs.AppError {
+	if err := json.Unmarshal([]byte(payload), v); err != nil {
+		logger.Error("Error while decoding payload of approval request: " + err.Error())
+		return errs.NewUnexpectedError("Unexpected server error")
+	}
+	return nil
+}
@@ -0,0 +1,360 @@
+package service
+
+import (
+	"encoding/json"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	mocksDomain "github.com/aliciatay-zls/banking/backend/mocks/domain"
+	mocksService "github.com/aliciatay-zls/banking/backend/mocks/service"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockApprovalRepo *mocksDomain.MockApprovalRepository
+var approvalSvc DefaultApprovalService
+var executedPayloads []string //payloads given to the dummy executor, in order
+var dummyExecutorErr *errs.AppError
+
+const dummyApprovalId = "3"
+const dummyApprovalThreshold float64 = 5000
+
+func setupApprovalServiceTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	mockApprovalRepo = mocksDomain.NewMockApprovalRepository(ctrl)
+	executedPayloads = nil
+	dummyExecutorErr = nil
+	executors := map[string]ApprovalExecutor{
+		domain.ApprovalOperationFreezeAccounts: func(payload string) (interface{}, *errs.AppError) {
+			executedPayloads = append(executedPayloads, payload)
+			if dummyExecutorErr != nil {
+				return nil, dummyExecutorErr
+			}
+			return errs.NewMessageObject("frozen"), nil
+		},
+	}
+	approvalSvc = NewApprovalService(mockApprovalRepo, executors, dummyApprovalThreshold, clock.StaticClock{})
+	logger.MuteLogger()
+
+	return func() {
+		mockApprovalRepo = nil
+		defer ctrl.Finish()
+	}
+}
+
+// getDummyPendingApproval returns a freeze of mismatched accounts requested by the admin "admin"
+func getDummyPendingApproval() domain.ApprovalRequest {
+	approval := domain.NewApprovalRequest(domain.ApprovalOperationFreezeAccounts, "freeze", "null", "admin", clock.StaticClock{})
+	approval.ApprovalId = dummyApprovalId
+	return approval
+}
+
+func TestDefaultApprovalService_IsTransactionApprovalRequired_only_for_admin_above_threshold(t *testing.T) {
+	//Arrange
+	teardown := setupApprovalServiceTest(t)
+	defer teardown()
+
+	admin := domain.AuthClaims{Username: "admin", Role: domain.AuthRoleAdmin}
+	customer := domain.AuthClaims{CustomerId: dummyCustomerId, Username: dummyCustomerId, Role: "user"}
+	tests := []struct {
+		name     string
+		claims   domain.AuthClaims
+		amount   float64
+		expected bool
+	}{
+		{"admin above threshold", admin, dummyApprovalThreshold + 0.01, true},
+		{"admin at threshold", admin, dummyApprovalThreshold, false},
+		{"customer above threshold", customer, 9000, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Act
+			actual := approvalSvc.IsTransactionApprovalRequired(tc.claims, dto.TransactionRequest{Amount: tc.amount})
+
+			//Assert
+			if actual != tc.expected {
+				t.Errorf("Expected %t but got %t", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestDefaultApprovalService_IsReversalApprovalRequired_when_forced_or_above_threshold(t *testing.T) {
+	//Arrange
+	teardown := setupApprovalServiceTest(t)
+	defer teardown()
+
+	tests := []struct {
+		name     string
+		force    bool
+		amount   float64
+		expected bool
+	}{
+		{"above threshold", false, dummyApprovalThreshold + 0.01, true},
+		{"at threshold", false, dummyApprovalThreshold, false},
+		{"forced below threshold", true, 10, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//Act
+			actual := approvalSvc.IsReversalApprovalRequired(dto.TransactionReversalRequest{Force: tc.force}, tc.amount)
+
+			//Assert
+			if actual != tc.expected {
+				t.Errorf("Expected %t but got %t", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestDefaultApprovalService_RequestApproval_saves_pendingRequest_with_payload(t *testing.T) {
+	//Arrange
+	teardown := setupApprovalServiceTest(t)
+	defer teardown()
+
+	expected := domain.NewApprovalRequest(domain.ApprovalOperationFreezeAccounts, "freeze", `{"id":"1"}`, "admin", clock.StaticClock{})
+	saved := expected
+	saved.ApprovalId = dummyApprovalId
+	mockApprovalRepo.EXPECT().Save(expected).Return(&saved, nil)
+
+	//Act
+	response, err := approvalSvc.RequestApproval(domain.ApprovalOperationFreezeAccounts, "freeze", map[string]string{"id": "1"}, "admin")
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing request of approval: " + err.Message)
+	}
+	if response.ApprovalId != dummyApprovalId || response.Status != dto.ApprovalStatusPending {
+		t.Errorf("Expected pending approval request %s but got %v", dummyApprovalId, response)
+	}
+}
+
+func TestDefaultApprovalService_RequestApproval_returns_error_when_admin_unidentified(t *testing.T) {
+	//Arrange
+	teardown := setupApprovalServiceTest(t)
+	defer teardown()
+
+	mockApprovalRepo.EXPECT().Save(gomock.Any()).Times(0)
+
+	//Act
+	_, err := approvalSvc.RequestApproval(domain.ApprovalOperationFreezeAccounts, "freeze", nil, "")
+
+	//Assert
+	if err == nil || err.Code != http.StatusForbidden {
+		t.Errorf("Expected authorization error but got %v", err)
+	}
+}
+
+func TestDefaultApprovalService_Approve_executes_operation_once_then_records_result(t *testing.T) {
+	//Arrange
+	teardown := setupApprovalServiceTest(t)
+	defer teardown()
+
+	approval := getDummyPendingApproval()
+	approved := approval.Decide(dto.ApprovalStatusApproved, "admin2", clock.StaticClock{})
+	executed := approved.Complete(errs.NewMessageObject("frozen"), nil)
+	mockApprovalRepo.EXPECT().ExpirePending(clock.StaticClock{}.NowAsString()).Return(nil)
+	mockApprovalRepo.EXPECT().FindById(dummyApprovalId).Return(&approval, nil)
+	gomock.InOrder(
+		mockApprovalRepo.EXPECT().UpdateStatus(approved, dto.ApprovalStatusPending).Return(nil),
+		mockApprovalRepo.EXPECT().UpdateStatus(executed, dto.ApprovalStatusApproved).Return(nil),
+	)
+
+	//Act
+	response, err := approvalSvc.Approve(dummyApprovalId, "admin2")
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing approval: " + err.Message)
+	}
+	if len(executedPayloads) != 1 || executedPayloads[0] != "null" {
+		t.Errorf("Expected operation to be executed once with its payload but got %v", executedPayloads)
+	}
+	if response.Status != dto.ApprovalStatusExecuted || response.DecidedBy != "admin2" || string(response.Result) != `{"message":"frozen"}` {
+		t.Errorf("Expected request executed by admin2 with result but got %v", response)
+	}
+}
+
+func TestDefaultApprovalService_Approve_records_failure_when_operation_fails(t *testing.T) {
+	//Arrange
+	teardown := setupApprovalServiceTest(t)
+	defer teardown()
+
+	dummyExecutorErr = errs.NewNotFoundError("Webhook subscription not found")
+	approval := getDummyPendingApproval()
+	approved := approval.Decide(dto.ApprovalStatusApproved, "admin2", clock.StaticClock{})
+	mockApprovalRepo.EXPECT().ExpirePending(clock.StaticClock{}.NowAsString()).Return(nil)
+	mockApprovalRepo.EXPECT().FindById(dummyApprovalId).Return(&approval, nil)
+	mockApprovalRepo.EXPECT().UpdateStatus(approved, dto.ApprovalStatusPending).Return(nil)
+	mockApprovalRepo.EXPECT().UpdateStatus(approved.Complete(nil, dummyExecutorErr), dto.ApprovalStatusApproved).Return(nil)
+
+	//Act
+	_, err := approvalSvc.Approve(dummyApprovalId, "admin2")
+
+	//Assert
+	if err != dummyExecutorErr {
+		t.Errorf("Expected error of operation but got %v", err)
+	}
+}
+
+func TestDefaultApprovalService_Approve_returns_error_without_executing_when_not_allowed(t *testing.T) {
+	//Arrange
+	expired := getDummyPendingApproval()
+	expired.Status = dto.ApprovalStatusExpired
+	executed := getDummyPendingApproval()
+	executed.Status = dto.ApprovalStatusExecuted
+
+	tests := []struct {
+		name               string
+		approval           domain.ApprovalRequest
+		approvedBy         string
+		expectedStatusCode int
+		expectedErrMessage string
+	}{
+		{"requested by same admin", getDummyPendingApproval(), "admin", http.StatusForbidden,
+			"An approval request must be approved by a different admin than the one who requested it"},
+		{"expired", expired, "admin2", http.StatusConflict, "Approval request has expired"},
+		{"already executed", executed, "admin2", http.StatusConflict, "Approval request has already been decided"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			teardown := setupApprovalServiceTest(t)
+			defer teardown()
+
+			mockApprovalRepo.EXPECT().ExpirePending(clock.StaticClock{}.NowAsString()).Return(nil)
+			mockApprovalRepo.EXPECT().FindById(dummyApprovalId).Return(&tc.approval, nil)
+			mockApprovalRepo.EXPECT().UpdateStatus(gomock.Any(), gomock.Any()).Times(0)
+
+			//Act
+			_, err := approvalSvc.Approve(dummyApprovalId, tc.approvedBy)
+
+			//Assert
+			if err == nil || err.Code != tc.expectedStatusCode || err.Message != tc.expectedErrMessage {
+				t.Errorf("Expected error %d \"%s\" but got %v", tc.expectedStatusCode, tc.expectedErrMessage, err)
+			}
+			if len(executedPayloads) != 0 {
+				t.Errorf("Expected operation not to be executed but got %v", executedPayloads)
+			}
+		})
+	}
+}
+
+func TestDefaultApprovalService_Approve_does_not_execute_when_approved_by_another_admin_first(t *testing.T) {
+	//Arrange
+	teardown := setupApprovalServiceTest(t)
+	defer teardown()
+
+	approval := getDummyPendingApproval()
+	mockApprovalRepo.EXPECT().ExpirePending(clock.StaticClock{}.NowAsString()).Return(nil)
+	mockApprovalRepo.EXPECT().FindById(dummyApprovalId).Return(&approval, nil)
+	mockApprovalRepo.EXPECT().UpdateStatus(gomock.Any(), dto.ApprovalStatusPending).
+		Return(errs.NewConflictError("Approval request has already been decided"))
+
+	//Act
+	_, err := approvalSvc.Approve(dummyApprovalId, "admin2")
+
+	//Assert
+	if err == nil || err.Code != http.StatusConflict {
+		t.Errorf("Expected conflict error but got %v", err)
+	}
+	if len(executedPayloads) != 0 {
+		t.Errorf("Expected operation not to be executed but got %v", executedPayloads)
+	}
+}
+
+func TestDefaultApprovalService_Reject_allows_requestingAdmin(t *testing.T) {
+	//Arrange
+	teardown := setupApprovalServiceTest(t)
+	defer teardown()
+
+	approval := getDummyPendingApproval()
+	rejected := approval.Decide(dto.ApprovalStatusRejected, "admin", clock.StaticClock{})
+	mockApprovalRepo.EXPECT().ExpirePending(clock.StaticClock{}.NowAsString()).Return(nil)
+	mockApprovalRepo.EXPECT().FindById(dummyApprovalId).Return(&approval, nil)
+	mockApprovalRepo.EXPECT().UpdateStatus(rejected, dto.ApprovalStatusPending).Return(nil)
+
+	//Act
+	response, err := approvalSvc.Reject(dummyApprovalId, "admin")
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing rejection: " + err.Message)
+	}
+	if response.Status != dto.ApprovalStatusRejected || len(executedPayloads) != 0 {
+		t.Errorf("Expected request rejected without executing it but got %v", response)
+	}
+}
+
+func TestNewTransactionReversalApprovalExecutor_makes_reversal_of_payload_as_requestingAdmin(t *testing.T) {
+	//Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockReversalService := mocksService.NewMockTransactionReversalService(ctrl)
+	request := dto.TransactionReversalRequest{TransactionId: dummyTransactionId, Reason: "Duplicate deposit", Force: true,
+		ReversedBy: "admin"}
+	mockReversalService.EXPECT().Reverse(request).
+		Return(&dto.TransactionReversalResponse{TransactionId: dummyTransactionId, ReversedBy: "admin"}, nil)
+	execute := NewTransactionReversalApprovalExecutor(mockReversalService)
+	payload, _ := json.Marshal(dto.TransactionReversalApproval(request))
+
+	//Act
+	response, err := execute(string(payload))
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing execution of reversal: " + err.Message)
+	}
+	if reversal, ok := response.(*dto.TransactionReversalResponse); !ok || reversal.TransactionId != dummyTransactionId {
+		t.Errorf("Expected reversal of transaction %s but got %v", dummyTransactionId, response)
+	}
+}
+
+func TestNewFreezeApprovalExecutor_freezes_accounts_of_payload(t *testing.T) {
+	//Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockReconciliationService := mocksService.NewMockReconciliationService(ctrl)
+	mockReconciliationService.EXPECT().Reconcile(gomock.Any()).Times(0)
+	mockReconciliationService.EXPECT().FreezeAccounts([]string{"1978", "1980"}).Return(nil)
+	execute := NewFreezeApprovalExecutor(mockReconciliationService)
+
+	//Act
+	response, err := execute(`["1978","1980"]`)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing execution of freeze: " + err.Message)
+	}
+	if message, ok := response.(errs.MessageObject); !ok || message.Message != "Accounts frozen: 1978, 1980" {
+		t.Errorf("Expected the frozen accounts to be listed but got %v", response)
+	}
+}
+
+func TestNewTransactionApprovalExecutor_makes_transaction_of_payload(t *testing.T) {
+	//Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockAccountService := mocksService.NewMockAccountService(ctrl)
+	request := dto.TransactionRequest{AccountId: dummyAccountId, Amount: 6000, TransactionType: dto.TransactionTypeDeposit, CustomerId: dummyCustomerId}
+	mockAccountService.EXPECT().MakeTransaction(request).
+		Return(&dto.TransactionResponse{TransactionId: dummyTransactionId, Status: dto.TransactionStatusPosted}, nil)
+	execute := NewTransactionApprovalExecutor(mockAccountService)
+
+	//Act
+	response, err := execute(`{"account_id":"1977","amount":6000,"transaction_type":"deposit","customer_id":"2"}`)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing execution of transaction: " + err.Message)
+	}
+	if transaction, ok := response.(*dto.TransactionResponse); !ok || transaction.TransactionId != dummyTransactionId {
+		t.Errorf("Expected transaction %s but got %v", dummyTransactionId, response)
+	}
+}
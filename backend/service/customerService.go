@@ -6,6 +6,7 @@ import (
 	"github.com/aliciatay-zls/banking-lib/logger"
 	"github.com/aliciatay-zls/banking/backend/domain"
 	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/problem"
 )
 
 //go:generate mockgen -destination=../mocks/service/mock_customerService.go -package=service github.com/aliciatay-zls/banking/backend/service CustomerService
@@ -31,7 +32,7 @@ func (s DefaultCustomerService) GetAllCustomers(status string) ([]dto.CustomerRe
 		status = "0"
 	} else {
 		logger.Error(fmt.Sprintf("Unexpected customer status: %s", status))
-		return nil, errs.NewNotFoundError("Invalid status")
+		return nil, problem.NewNotFoundError(problem.CustomerStatusInvalid, "Invalid status")
 	}
 
 	customers, err := s.repo.FindAll(status) //Business has dependency on repo (*) //connects primary port to secondary port (**)
@@ -0,0 +1,224 @@
+package service
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/problem"
+	"math"
+	"net/http"
+)
+
+//go:generate mockgen -destination=../mocks/service/mock_feeService.go -package=service github.com/aliciatay-zls/banking/backend/service FeeService
+type FeeService interface { //service (primary port)
+	GetSchedules() ([]dto.FeeScheduleResponse, *errs.AppError)
+	CreateSchedule(dto.NewFeeScheduleRequest) (*dto.FeeScheduleResponse, *errs.AppError)
+	DeleteSchedule(string) *errs.AppError
+	ChargeForTransaction(domain.Transaction)
+	ChargeMonthlyFees(string) (*dto.MonthlyFeeReport, *errs.AppError)
+}
+
+type DefaultFeeService struct { //business/domain object
+	repo        domain.FeeRepository
+	accountRepo domain.AccountRepository
+	overdrafts  OverdraftService
+	clk         clock.Clock
+}
+
+func NewFeeService(repo domain.FeeRepository, accountRepo domain.AccountRepository, overdrafts OverdraftService, clk clock.Clock) DefaultFeeService {
+	return DefaultFeeService{repo, accountRepo, overdrafts, clk}
+}
+
+// GetSchedules returns all fee schedules, by account type and then in the order they take effect, each with whether it
+// is the one in effect, has been superseded or is yet to take effect.
+func (s DefaultFeeService) GetSchedules() ([]dto.FeeScheduleResponse, *errs.AppError) {
+	schedules, appErr := s.repo.FindSchedules()
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	now := s.clk.NowAsString()
+	response := make([]dto.FeeScheduleResponse, 0)
+	for i, schedule := range schedules {
+		status := dto.FeeScheduleStatusScheduled
+		if schedule.IsEffective(now) {
+			status = dto.FeeScheduleStatusInEffect
+			if next := i + 1; next < len(schedules) && schedules[next].AccountType == schedule.AccountType &&
+				schedules[next].IsEffective(now) {
+				status = dto.FeeScheduleStatusSuperseded
+			}
+		}
+		response = append(response, schedule.ToDTO(status))
+	}
+	return response, nil
+}
+
+// CreateSchedule adds a fee schedule that replaces the one in effect for its account type from the given date, which
+// cannot be in the past so that fees already charged stay as they were.
+func (s DefaultFeeService) CreateSchedule(request dto.NewFeeScheduleRequest) (*dto.FeeScheduleResponse, *errs.AppError) {
+	schedule := domain.NewFeeSchedule(request, s.clk)
+	if schedule.IsBackdated(s.clk.Now()) {
+		logger.Error("Fee schedule attempted to take effect in the past on " + request.EffectiveFrom)
+		return nil, errs.NewValidationError("A fee schedule cannot take effect before today")
+	}
+
+	saved, appErr := s.repo.SaveSchedule(schedule)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	response := saved.ToDTO(dto.FeeScheduleStatusScheduled)
+	if saved.IsEffective(s.clk.NowAsString()) {
+		response.Status = dto.FeeScheduleStatusInEffect
+	}
+	return &response, nil
+}
+
+// DeleteSchedule deletes the given fee schedule, provided that it has not taken effect yet.
+func (s DefaultFeeService) DeleteSchedule(scheduleId string) *errs.AppError {
+	schedule, appErr := s.repo.FindScheduleById(scheduleId)
+	if appErr != nil {
+		return appErr
+	}
+	now := s.clk.NowAsString()
+	if schedule.IsEffective(now) {
+		logger.Error("Deletion attempted of fee schedule " + scheduleId + " that is already in effect")
+		return problem.NewConflictError(problem.FeeScheduleInEffect, "Fee schedule has already taken effect")
+	}
+
+	return s.repo.DeleteSchedule(scheduleId, now)
+}
+
+// ChargeForTransaction charges the withdrawal fee of the fee schedule in effect if the given posted transaction is a
+// withdrawal beyond the free withdrawals of its month. As the transaction has already been posted, errors are logged
+// rather than returned.
+func (s DefaultFeeService) ChargeForTransaction(t domain.Transaction) {
+	if !t.IsWithdrawal() {
+		return
+	}
+
+	account, appErr := s.accountRepo.FindById(t.AccountId)
+	if appErr != nil {
+		return
+	}
+	schedule, appErr := s.repo.FindEffectiveSchedule(account.AccountType, t.TransactionDate)
+	if appErr != nil || schedule.WithdrawalFee == 0 {
+		return //no fees are charged on accounts of this type
+	}
+
+	withdrawals, appErr := s.repo.CountWithdrawalsSince(t.AccountId, domain.FeePeriodOf(t.TransactionDate).StartAsString(), t.TransactionId)
+	if appErr != nil {
+		return
+	}
+	fee := account.ChargeableFee(schedule.WithdrawalFeeFor(withdrawals))
+	if fee == 0 {
+		return
+	}
+
+	s.post(t.ToFee(fee, s.clk))
+}
+
+// ChargeMonthlyFees is the monthly fee job: it charges every active account the monthly fee of the fee schedule in
+// effect at the end of the given month ("2006-01"), or of the previous month if none is given, unless the average
+// balance of the account over the month waives it. An account is only charged once per month, so the job can be run
+// again, e.g. after a failure.
+func (s DefaultFeeService) ChargeMonthlyFees(month string) (*dto.MonthlyFeeReport, *errs.AppError) {
+	period, appErr := s.period(month)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	accounts, appErr := s.repo.FindActiveAccountsOpenedBefore(period.EndAsString())
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	report := dto.MonthlyFeeReport{Period: period.String()}
+	for _, account := range accounts {
+		charge, appErr := s.chargeMonthlyFee(account, period)
+		if appErr != nil || charge == nil {
+			continue //already logged or not charged, the other accounts can still be charged
+		}
+		if charge.IsWaived() {
+			report.AccountsWaived++
+		} else {
+			report.AccountsCharged++
+			report.FeesCharged = math.Round((report.FeesCharged+charge.Fee)*100) / 100
+		}
+	}
+	return &report, nil
+}
+
+// chargeMonthlyFee charges the given account its monthly fee for the given period, and returns the charge or nil if
+// there is no monthly fee to charge it or it has already been charged. A saving account is charged no more than its
+// balance, as it cannot be overdrawn. The charge is saved before the fee is posted, so that two jobs running at once
+// cannot charge it twice, and is deleted again if the fee could not be posted.
+func (s DefaultFeeService) chargeMonthlyFee(account domain.Account, period domain.FeePeriod) (*domain.MonthlyFeeCharge, *errs.AppError) {
+	schedule, appErr := s.repo.FindEffectiveSchedule(account.AccountType, period.LastSecondAsString())
+	if appErr != nil {
+		if appErr.Code == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, appErr
+	}
+	if schedule.MonthlyFee == 0 {
+		return nil, nil
+	}
+	charged, appErr := s.repo.ExistsMonthlyCharge(account.AccountId, period.String())
+	if appErr != nil || charged {
+		return nil, appErr
+	}
+
+	transactions, appErr := s.repo.FindTransactionsSince(account.AccountId, period.StartAsString())
+	if appErr != nil {
+		return nil, appErr
+	}
+	averageBalance := domain.AverageBalance(account, period, transactions)
+	charge := domain.NewMonthlyFeeCharge(account.AccountId, period, *schedule, averageBalance, s.clk)
+	charge.Fee = account.ChargeableFee(charge.Fee)
+	saved, appErr := s.repo.SaveMonthlyCharge(charge)
+	if appErr != nil || saved.IsWaived() {
+		return saved, appErr
+	}
+
+	if appErr = s.post(saved.ToTransaction(s.clk)); appErr != nil {
+		if deleteErr := s.repo.DeleteMonthlyCharge(saved.ChargeId); deleteErr != nil {
+			logger.Error("Error while deleting monthly fee charge " + saved.ChargeId + " that was not posted: " + deleteErr.Message)
+		}
+		return nil, appErr
+	}
+	return saved, nil
+}
+
+// post posts the given fee and charges its account as set in the overdraft terms if it took the balance below zero.
+// The fee must have been capped with domain.Account.ChargeableFee, so that only a checking account is charged a fee
+// that it does not have the balance for.
+func (s DefaultFeeService) post(fee domain.Transaction) *errs.AppError {
+	posted, appErr := s.accountRepo.Transact(fee)
+	if appErr != nil {
+		logger.Error("Error while charging fee to account " + fee.AccountId + ": " + appErr.Message)
+		return appErr
+	}
+	chargeOverdraft(s.overdrafts, *posted)
+	return nil
+}
+
+// period returns the given month ("2006-01"), or the previous month if none is given, which must have ended.
+func (s DefaultFeeService) period(month string) (domain.FeePeriod, *errs.AppError) {
+	if month == "" {
+		return domain.FeePeriodOf(s.clk.NowAsString()).Previous(), nil
+	}
+
+	period, err := domain.NewFeePeriod(month)
+	if err != nil {
+		logger.Error("Invalid month for monthly fees: " + err.Error())
+		return domain.FeePeriod{}, errs.NewValidationError("Please give the month to charge as YYYY-MM.")
+	}
+	if !period.HasEnded(s.clk.Now()) {
+		logger.Error("Monthly fees attempted for month " + month + " that has not ended")
+		return domain.FeePeriod{}, errs.NewValidationError("Monthly fees can only be charged for a month that has ended.")
+	}
+	return period, nil
+}
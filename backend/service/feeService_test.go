@@ -0,0 +1,379 @@
+package service
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	mocksDomain "github.com/aliciatay-zls/banking/backend/mocks/domain"
+	mocksService "github.com/aliciatay-zls/banking/backend/mocks/service"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockFeeRepo *mocksDomain.MockFeeRepository
+var mockFeeAccountRepo *mocksDomain.MockAccountRepository
+var mockFeeOverdraftService *mocksService.MockOverdraftService
+var feeSvc DefaultFeeService
+
+const dummyScheduleId = "4"
+const dummyMonthStart = "2006-01-01 00:00:00"
+
+func setupFeeServiceTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	mockFeeRepo = mocksDomain.NewMockFeeRepository(ctrl)
+	mockFeeAccountRepo = mocksDomain.NewMockAccountRepository(ctrl)
+	mockFeeOverdraftService = mocksService.NewMockOverdraftService(ctrl)
+	feeSvc = NewFeeService(mockFeeRepo, mockFeeAccountRepo, mockFeeOverdraftService, clock.StaticClock{})
+	logger.MuteLogger()
+
+	return func() {
+		mockFeeRepo = nil
+		mockFeeAccountRepo = nil
+		mockFeeOverdraftService = nil
+		defer ctrl.Finish()
+	}
+}
+
+// getDummyFeeSchedule returns a schedule in effect for saving accounts since the start of the month of the static
+// clock, charging a monthly fee of 5 waived from an average balance of 1000, and 0.5 for each withdrawal after 2 free
+// ones
+func getDummyFeeSchedule() domain.FeeSchedule {
+	return domain.FeeSchedule{
+		ScheduleId:      dummyScheduleId,
+		AccountType:     dto.AccountTypeSaving,
+		MonthlyFee:      5,
+		MinimumBalance:  1000,
+		FreeWithdrawals: 2,
+		WithdrawalFee:   0.5,
+		EffectiveFrom:   dummyMonthStart,
+		CreatedBy:       "admin",
+		CreatedOn:       dummyTwoDaysAgo,
+	}
+}
+
+func getDummyFeeAccount(amount float64) domain.Account {
+	return domain.Account{AccountId: dummyAccountId, CustomerId: dummyCustomerId, OpeningDate: "2005-01-01 10:00:00",
+		AccountType: dto.AccountTypeSaving, Amount: amount, Status: domain.AccountStatusActive}
+}
+
+func TestDefaultFeeService_GetSchedules_returns_status_of_each_schedule(t *testing.T) {
+	//Arrange
+	teardown := setupFeeServiceTest(t)
+	defer teardown()
+
+	superseded := getDummyFeeSchedule()
+	superseded.EffectiveFrom = "2005-06-01 00:00:00"
+	inEffect := getDummyFeeSchedule()
+	scheduled := getDummyFeeSchedule()
+	scheduled.EffectiveFrom = "2006-02-01 00:00:00"
+	checking := getDummyFeeSchedule()
+	checking.AccountType = dto.AccountTypeChecking
+	mockFeeRepo.EXPECT().FindSchedules().Return([]domain.FeeSchedule{checking, superseded, inEffect, scheduled}, nil)
+	expectedStatuses := []string{dto.FeeScheduleStatusInEffect, dto.FeeScheduleStatusSuperseded,
+		dto.FeeScheduleStatusInEffect, dto.FeeScheduleStatusScheduled}
+
+	//Act
+	response, err := feeSvc.GetSchedules()
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing getting fee schedules: " + err.Message)
+	}
+	if len(response) != len(expectedStatuses) {
+		t.Fatalf("Expected %d schedules but got %d", len(expectedStatuses), len(response))
+	}
+	for i, expected := range expectedStatuses {
+		if response[i].Status != expected {
+			t.Errorf("Expected schedule %d to be %s but got %s", i, expected, response[i].Status)
+		}
+	}
+}
+
+func TestDefaultFeeService_CreateSchedule_returns_validationError_when_effectiveFrom_before_today(t *testing.T) {
+	//Arrange
+	teardown := setupFeeServiceTest(t)
+	defer teardown()
+
+	request := dto.NewFeeScheduleRequest{AccountType: dto.AccountTypeSaving, MonthlyFee: 5, EffectiveFrom: "2006-01-01"}
+	mockFeeRepo.EXPECT().SaveSchedule(gomock.Any()).Times(0)
+
+	//Act
+	_, err := feeSvc.CreateSchedule(request)
+
+	//Assert
+	if err == nil || err.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected validation error but got %v", err)
+	}
+}
+
+func TestDefaultFeeService_CreateSchedule_saves_schedule_taking_effect_today(t *testing.T) {
+	//Arrange
+	teardown := setupFeeServiceTest(t)
+	defer teardown()
+
+	request := dto.NewFeeScheduleRequest{AccountType: dto.AccountTypeSaving, MonthlyFee: 5, EffectiveFrom: "2006-01-02",
+		CreatedBy: "admin"}
+	expectedSchedule := domain.NewFeeSchedule(request, clock.StaticClock{})
+	savedSchedule := expectedSchedule
+	savedSchedule.ScheduleId = dummyScheduleId
+	mockFeeRepo.EXPECT().SaveSchedule(expectedSchedule).Return(&savedSchedule, nil)
+
+	//Act
+	response, err := feeSvc.CreateSchedule(request)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing creating fee schedule: " + err.Message)
+	}
+	if response.ScheduleId != dummyScheduleId || response.Status != dto.FeeScheduleStatusInEffect {
+		t.Errorf("Expected schedule %s in effect but got %v", dummyScheduleId, *response)
+	}
+}
+
+func TestDefaultFeeService_DeleteSchedule_returns_conflictError_when_schedule_in_effect(t *testing.T) {
+	//Arrange
+	teardown := setupFeeServiceTest(t)
+	defer teardown()
+
+	schedule := getDummyFeeSchedule()
+	mockFeeRepo.EXPECT().FindScheduleById(dummyScheduleId).Return(&schedule, nil)
+	mockFeeRepo.EXPECT().DeleteSchedule(gomock.Any(), gomock.Any()).Times(0)
+
+	//Act
+	err := feeSvc.DeleteSchedule(dummyScheduleId)
+
+	//Assert
+	if err == nil || err.Code != http.StatusConflict {
+		t.Errorf("Expected conflict error but got %v", err)
+	}
+}
+
+func TestDefaultFeeService_ChargeForTransaction_charges_withdrawalFee_after_freeWithdrawals(t *testing.T) {
+	//Arrange
+	teardown := setupFeeServiceTest(t)
+	defer teardown()
+
+	withdrawal := domain.Transaction{TransactionId: dummyTransactionId, AccountId: dummyAccountId, Amount: 100,
+		Balance: 900, TransactionType: dto.TransactionTypeWithdrawal, TransactionDate: clock.StaticClock{}.NowAsString()}
+	account := getDummyFeeAccount(900)
+	schedule := getDummyFeeSchedule()
+	expectedFee := withdrawal.ToFee(schedule.WithdrawalFee, clock.StaticClock{})
+	postedFee := expectedFee
+	postedFee.Balance = 899.5
+	mockFeeAccountRepo.EXPECT().FindById(dummyAccountId).Return(&account, nil)
+	mockFeeRepo.EXPECT().FindEffectiveSchedule(dto.AccountTypeSaving, withdrawal.TransactionDate).Return(&schedule, nil)
+	mockFeeRepo.EXPECT().CountWithdrawalsSince(dummyAccountId, dummyMonthStart, dummyTransactionId).Return(3, nil)
+	mockFeeAccountRepo.EXPECT().Transact(expectedFee).Return(&postedFee, nil)
+	mockFeeOverdraftService.EXPECT().ChargeForTransaction(gomock.Any()).Times(0)
+
+	//Act
+	feeSvc.ChargeForTransaction(withdrawal)
+}
+
+func TestDefaultFeeService_ChargeForTransaction_does_not_charge_freeWithdrawal(t *testing.T) {
+	//Arrange
+	teardown := setupFeeServiceTest(t)
+	defer teardown()
+
+	withdrawal := domain.Transaction{TransactionId: dummyTransactionId, AccountId: dummyAccountId, Amount: 100,
+		Balance: 900, TransactionType: dto.TransactionTypeWithdrawal, TransactionDate: clock.StaticClock{}.NowAsString()}
+	account := getDummyFeeAccount(900)
+	schedule := getDummyFeeSchedule()
+	mockFeeAccountRepo.EXPECT().FindById(dummyAccountId).Return(&account, nil)
+	mockFeeRepo.EXPECT().FindEffectiveSchedule(dto.AccountTypeSaving, withdrawal.TransactionDate).Return(&schedule, nil)
+	mockFeeRepo.EXPECT().CountWithdrawalsSince(dummyAccountId, dummyMonthStart, dummyTransactionId).Return(2, nil)
+	mockFeeAccountRepo.EXPECT().Transact(gomock.Any()).Times(0)
+
+	//Act
+	feeSvc.ChargeForTransaction(withdrawal)
+}
+
+func TestDefaultFeeService_ChargeForTransaction_does_nothing_for_deposit(t *testing.T) {
+	//Arrange
+	teardown := setupFeeServiceTest(t)
+	defer teardown()
+
+	deposit := domain.Transaction{TransactionId: dummyTransactionId, AccountId: dummyAccountId, Amount: 100,
+		Balance: 1100, TransactionType: dto.TransactionTypeDeposit, TransactionDate: clock.StaticClock{}.NowAsString()}
+	mockFeeAccountRepo.EXPECT().FindById(gomock.Any()).Times(0)
+	mockFeeAccountRepo.EXPECT().Transact(gomock.Any()).Times(0)
+
+	//Act
+	feeSvc.ChargeForTransaction(deposit)
+}
+
+func TestDefaultFeeService_ChargeMonthlyFees_returns_validationError_when_month_not_ended(t *testing.T) {
+	//Arrange
+	teardown := setupFeeServiceTest(t)
+	defer teardown()
+
+	mockFeeRepo.EXPECT().FindActiveAccountsOpenedBefore(gomock.Any()).Times(0)
+
+	//Act
+	_, err := feeSvc.ChargeMonthlyFees("2006-01")
+
+	//Assert
+	if err == nil || err.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected validation error but got %v", err)
+	}
+}
+
+func TestDefaultFeeService_ChargeMonthlyFees_charges_lowBalance_waives_highBalance_and_skips_charged_accounts(t *testing.T) {
+	//Arrange
+	teardown := setupFeeServiceTest(t)
+	defer teardown()
+
+	low := getDummyFeeAccount(500)
+	high := getDummyFeeAccount(2000)
+	high.AccountId = "1978"
+	charged := getDummyFeeAccount(500)
+	charged.AccountId = "1979"
+	schedule := getDummyFeeSchedule()
+	schedule.EffectiveFrom = "2005-06-01 00:00:00"
+	period, _ := domain.NewFeePeriod("2005-12")
+	lowCharge := domain.NewMonthlyFeeCharge(low.AccountId, period, schedule, 500, clock.StaticClock{})
+	highCharge := domain.NewMonthlyFeeCharge(high.AccountId, period, schedule, 2000, clock.StaticClock{})
+	expectedFee := lowCharge.ToTransaction(clock.StaticClock{})
+	postedFee := expectedFee
+	postedFee.Balance = 495
+
+	mockFeeRepo.EXPECT().FindActiveAccountsOpenedBefore("2006-01-01 00:00:00").Return([]domain.Account{low, high, charged}, nil)
+	mockFeeRepo.EXPECT().FindEffectiveSchedule(dto.AccountTypeSaving, "2005-12-31 23:59:59").Return(&schedule, nil).Times(3)
+	mockFeeRepo.EXPECT().ExistsMonthlyCharge(low.AccountId, "2005-12").Return(false, nil)
+	mockFeeRepo.EXPECT().ExistsMonthlyCharge(high.AccountId, "2005-12").Return(false, nil)
+	mockFeeRepo.EXPECT().ExistsMonthlyCharge(charged.AccountId, "2005-12").Return(true, nil)
+	mockFeeRepo.EXPECT().FindTransactionsSince(gomock.Any(), "2005-12-01 00:00:00").Return([]domain.Transaction{}, nil).Times(2)
+	mockFeeRepo.EXPECT().SaveMonthlyCharge(lowCharge).Return(&lowCharge, nil)
+	mockFeeRepo.EXPECT().SaveMonthlyCharge(highCharge).Return(&highCharge, nil)
+	mockFeeAccountRepo.EXPECT().Transact(expectedFee).Return(&postedFee, nil)
+
+	//Act
+	report, err := feeSvc.ChargeMonthlyFees("")
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing charging monthly fees: " + err.Message)
+	}
+	expectedReport := dto.MonthlyFeeReport{Period: "2005-12", AccountsCharged: 1, FeesCharged: 5, AccountsWaived: 1}
+	if *report != expectedReport {
+		t.Errorf("Expected report %v but got %v", expectedReport, *report)
+	}
+}
+
+func TestDefaultFeeService_ChargeMonthlyFees_charges_savingAccount_no_more_than_its_balance(t *testing.T) {
+	//Arrange
+	teardown := setupFeeServiceTest(t)
+	defer teardown()
+
+	empty := getDummyFeeAccount(0)
+	short := getDummyFeeAccount(3)
+	short.AccountId = "1978"
+	schedule := getDummyFeeSchedule()
+	schedule.EffectiveFrom = "2005-06-01 00:00:00"
+	period, _ := domain.NewFeePeriod("2005-12")
+	emptyCharge := domain.NewMonthlyFeeCharge(empty.AccountId, period, schedule, 0, clock.StaticClock{})
+	emptyCharge.Fee = 0
+	shortCharge := domain.NewMonthlyFeeCharge(short.AccountId, period, schedule, 3, clock.StaticClock{})
+	shortCharge.Fee = 3
+	expectedFee := shortCharge.ToTransaction(clock.StaticClock{})
+	postedFee := expectedFee
+	postedFee.Balance = 0
+
+	mockFeeRepo.EXPECT().FindActiveAccountsOpenedBefore(gomock.Any()).Return([]domain.Account{empty, short}, nil)
+	mockFeeRepo.EXPECT().FindEffectiveSchedule(gomock.Any(), gomock.Any()).Return(&schedule, nil).Times(2)
+	mockFeeRepo.EXPECT().ExistsMonthlyCharge(gomock.Any(), gomock.Any()).Return(false, nil).Times(2)
+	mockFeeRepo.EXPECT().FindTransactionsSince(gomock.Any(), gomock.Any()).Return([]domain.Transaction{}, nil).Times(2)
+	mockFeeRepo.EXPECT().SaveMonthlyCharge(emptyCharge).Return(&emptyCharge, nil)
+	mockFeeRepo.EXPECT().SaveMonthlyCharge(shortCharge).Return(&shortCharge, nil)
+	mockFeeAccountRepo.EXPECT().Transact(expectedFee).Return(&postedFee, nil)
+	mockFeeOverdraftService.EXPECT().ChargeForTransaction(gomock.Any()).Times(0)
+
+	//Act
+	report, err := feeSvc.ChargeMonthlyFees("2005-12")
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing charging monthly fees: " + err.Message)
+	}
+	expectedReport := dto.MonthlyFeeReport{Period: "2005-12", AccountsCharged: 1, FeesCharged: 3, AccountsWaived: 1}
+	if *report != expectedReport {
+		t.Errorf("Expected report %v but got %v", expectedReport, *report)
+	}
+}
+
+func TestDefaultFeeService_ChargeMonthlyFees_deletes_charge_when_posting_fails(t *testing.T) {
+	//Arrange
+	teardown := setupFeeServiceTest(t)
+	defer teardown()
+
+	account := getDummyFeeAccount(500)
+	schedule := getDummyFeeSchedule()
+	schedule.EffectiveFrom = "2005-06-01 00:00:00"
+	savedCharge := domain.MonthlyFeeCharge{ChargeId: "8", AccountId: dummyAccountId, Period: "2005-12", Fee: 5}
+	mockFeeRepo.EXPECT().FindActiveAccountsOpenedBefore(gomock.Any()).Return([]domain.Account{account}, nil)
+	mockFeeRepo.EXPECT().FindEffectiveSchedule(gomock.Any(), gomock.Any()).Return(&schedule, nil)
+	mockFeeRepo.EXPECT().ExistsMonthlyCharge(gomock.Any(), gomock.Any()).Return(false, nil)
+	mockFeeRepo.EXPECT().FindTransactionsSince(gomock.Any(), gomock.Any()).Return([]domain.Transaction{}, nil)
+	mockFeeRepo.EXPECT().SaveMonthlyCharge(gomock.Any()).Return(&savedCharge, nil)
+	mockFeeAccountRepo.EXPECT().Transact(gomock.Any()).Return(nil, errs.NewUnexpectedError("Unexpected database error"))
+	mockFeeRepo.EXPECT().DeleteMonthlyCharge("8").Return(nil)
+
+	//Act
+	report, err := feeSvc.ChargeMonthlyFees("2005-12")
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing charging monthly fees: " + err.Message)
+	}
+	if report.AccountsCharged != 0 || report.FeesCharged != 0 {
+		t.Errorf("Expected no account charged but got %v", *report)
+	}
+}
+
+func TestDefaultFeeService_with_stubRepo_charges_monthlyFee_once_per_month(t *testing.T) {
+	//Arrange
+	accountRepo := domain.NewAccountRepositoryStub()
+	feeRepo := domain.NewFeeRepositoryStub(accountRepo)
+	overdraftSvc := NewOverdraftService(domain.NewOverdraftRepositoryStub(), accountRepo, domain.NewHoldRepositoryStub(), domain.OverdraftTerms{}, clock.StaticClock{})
+	stubSvc := NewFeeService(feeRepo, accountRepo, overdraftSvc, clock.StaticClock{})
+	var accountIds []string
+	for _, accountType := range []string{dto.AccountTypeSaving, dto.AccountTypeChecking} {
+		schedule := getDummyFeeSchedule()
+		schedule.AccountType = accountType
+		schedule.MinimumBalance = 5000
+		schedule.EffectiveFrom = "2005-06-01 00:00:00"
+		feeRepo.SaveSchedule(schedule)
+
+		account := getDummyFeeAccount(6000)
+		account.AccountType = accountType
+		if accountType == dto.AccountTypeChecking {
+			account.Amount = 3000
+		}
+		saved, _ := accountRepo.Save(account) //the accounts of the stub were opened after the static clock
+		accountIds = append(accountIds, saved.AccountId)
+	}
+	logger.MuteLogger()
+
+	//Act
+	firstReport, firstErr := stubSvc.ChargeMonthlyFees("")
+	secondReport, secondErr := stubSvc.ChargeMonthlyFees("")
+	checking, _ := accountRepo.FindById(accountIds[1])
+
+	//Assert
+	if firstErr != nil || secondErr != nil {
+		t.Fatal("Expected no error but got error while testing charging monthly fees twice")
+	}
+	if firstReport.AccountsCharged != 1 || firstReport.AccountsWaived != 1 || firstReport.FeesCharged != 5 {
+		t.Errorf("Expected the checking account charged and the saving account waived but got %v", *firstReport)
+	}
+	if secondReport.AccountsCharged != 0 || secondReport.AccountsWaived != 0 {
+		t.Errorf("Expected no account charged again but got %v", *secondReport)
+	}
+	if checking.Amount != 2995 {
+		t.Errorf("Expected checking account balance 2995 but got %v", checking.Amount)
+	}
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+)
+
+//go:generate mockgen -destination=../mocks/service/mock_fraudService.go -package=service github.com/aliciatay-zls/banking/backend/service FraudService
+type FraudService interface { //service (primary port)
+	Screen(domain.Account, domain.Transaction) (*domain.FraudDecision, *errs.AppError)
+}
+
+type DefaultFraudService struct { //business/domain object
+	repo   domain.FraudRepository
+	engine domain.FraudEngine
+	clk    clock.Clock
+}
+
+func NewFraudService(repo domain.FraudRepository, engine domain.FraudEngine, clk clock.Clock) DefaultFraudService {
+	return DefaultFraudService{repo, engine, clk}
+}
+
+// Screen evaluates the given transaction, which is about to be made on the given account, with the fraud rules and
+// saves the decision. No decision is returned if the history of the account could not be retrieved or the decision
+// could not be saved, so that no transaction is made without a record of its screening.
+func (s DefaultFraudService) Screen(account domain.Account, transaction domain.Transaction) (*domain.FraudDecision, *errs.AppError) {
+	check := domain.FraudCheck{Account: account, Transaction: transaction}
+
+	var appErr *errs.AppError
+	if lookback := s.engine.Lookback(); lookback > 0 {
+		since := s.clk.Now().Add(-lookback).Format(clock.FormatDateTime)
+		if check.RecentTransactions, appErr = s.repo.FindTransactionsSince(account.AccountId, since); appErr != nil {
+			return nil, appErr
+		}
+	}
+	if check.LastTransactionDate, appErr = s.repo.FindLastTransactionDate(account.AccountId); appErr != nil {
+		return nil, appErr
+	}
+
+	decision, appErr := s.repo.SaveDecision(s.engine.Screen(check, s.clk))
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if decision.Decision != domain.FraudDecisionAllow {
+		logger.Info("Transaction on account " + account.AccountId + " screened as " + decision.Decision +
+			" by fraud rules: " + decision.Reasons)
+	}
+	return decision, nil
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	mocksDomain "github.com/aliciatay-zls/banking/backend/mocks/domain"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+// Test common variables and inputs
+var mockFraudRepo *mocksDomain.MockFraudRepository
+var fraudSvc DefaultFraudService
+
+func setupFraudServiceTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	mockFraudRepo = mocksDomain.NewMockFraudRepository(ctrl)
+	engine := domain.NewFraudEngine(domain.VelocityRule{MaxWithdrawals: 2, Window: 10 * time.Minute, Decision: domain.FraudDecisionBlock})
+	fraudSvc = NewFraudService(mockFraudRepo, engine, clock.StaticClock{})
+
+	return func() {
+		mockFraudRepo = nil
+		defer ctrl.Finish()
+	}
+}
+
+func getDummyFraudScreening() (domain.Account, domain.Transaction) {
+	account := domain.Account{AccountId: dummyAccountId, CustomerId: dummyCustomerId, OpeningDate: "2005-01-02 15:04:05"}
+	transaction := domain.Transaction{AccountId: dummyAccountId, Amount: 100, TransactionType: dto.TransactionTypeWithdrawal,
+		TransactionDate: clock.StaticClock{}.NowAsString()}
+	return account, transaction
+}
+
+func TestDefaultFraudService_Screen_blocks_and_saves_decision_when_too_many_recent_withdrawals(t *testing.T) {
+	//Arrange
+	teardown := setupFraudServiceTest(t)
+	defer teardown()
+
+	account, transaction := getDummyFraudScreening()
+	recentTransactions := []domain.Transaction{{AccountId: dummyAccountId, TransactionType: dto.TransactionTypeWithdrawal,
+		TransactionDate: "2006-01-02 15:00:00"}, {AccountId: dummyAccountId, TransactionType: dto.TransactionTypeWithdrawal,
+		TransactionDate: "2006-01-02 15:02:00"}}
+	mockFraudRepo.EXPECT().FindTransactionsSince(dummyAccountId, "2006-01-02 14:54:05").Return(recentTransactions, nil)
+	mockFraudRepo.EXPECT().FindLastTransactionDate(dummyAccountId).Return("2006-01-02 15:02:00", nil)
+	mockFraudRepo.EXPECT().SaveDecision(gomock.Any()).DoAndReturn(func(d domain.FraudDecision) (*domain.FraudDecision, *errs.AppError) {
+		d.DecisionId = "1"
+		return &d, nil
+	})
+	logger.MuteLogger()
+
+	//Act
+	decision, err := fraudSvc.Screen(account, transaction)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing screening of transaction: " + err.Message)
+	}
+	if !decision.IsBlocked() || decision.TriggeredRules != domain.FraudRuleVelocity || decision.DecisionId != "1" {
+		t.Errorf("Expected saved decision blocked by velocity rule but got %v", *decision)
+	}
+}
+
+func TestDefaultFraudService_Screen_returns_error_when_saveDecision_fails(t *testing.T) {
+	//Arrange
+	teardown := setupFraudServiceTest(t)
+	defer teardown()
+
+	account, transaction := getDummyFraudScreening()
+	mockFraudRepo.EXPECT().FindTransactionsSince(dummyAccountId, gomock.Any()).Return([]domain.Transaction{}, nil)
+	mockFraudRepo.EXPECT().FindLastTransactionDate(dummyAccountId).Return("", nil)
+	dummyAppErr := errs.NewUnexpectedError("Unexpected database error")
+	mockFraudRepo.EXPECT().SaveDecision(gomock.Any()).Return(nil, dummyAppErr)
+
+	//Act
+	decision, err := fraudSvc.Screen(account, transaction)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing failed saving of fraud decision")
+	}
+	if decision != nil {
+		t.Errorf("Expected no decision but got %v", *decision)
+	}
+}
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"database/sql"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/problem"
+)
+
+//go:generate mockgen -destination=../mocks/service/mock_holdService.go -package=service github.com/aliciatay-zls/banking/backend/service HoldService
+type HoldService interface { //service (primary port)
+	GetHolds(string, string) ([]dto.HoldResponse, *errs.AppError)
+	PlaceHold(dto.NewHoldRequest) (*dto.HoldResponse, *errs.AppError)
+	CaptureHold(string) (*dto.HoldResponse, *errs.AppError)
+	ReleaseHold(string) (*dto.HoldResponse, *errs.AppError)
+}
+
+type DefaultHoldService struct { //business/domain object
+	repo        domain.HoldRepository
+	accountRepo domain.AccountRepository
+	reviews     domain.TransactionReviewRepository
+	alerts      AlertService
+	overdrafts  OverdraftService
+	fees        FeeService
+	clk         clock.Clock
+}
+
+func NewHoldService(repo domain.HoldRepository, accountRepo domain.AccountRepository, reviews domain.TransactionReviewRepository, alerts AlertService, overdrafts OverdraftService, fees FeeService, clk clock.Clock) DefaultHoldService {
+	return DefaultHoldService{repo, accountRepo, reviews, alerts, overdrafts, fees, clk}
+}
+
+// GetHolds returns the holds placed on the given account of the given customer, oldest first, after expiring those
+// that have run out.
+func (s DefaultHoldService) GetHolds(customerId string, accountId string) ([]dto.HoldResponse, *errs.AppError) {
+	account, appErr := s.accountRepo.FindById(accountId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if account.CustomerId != customerId {
+		logger.Error("Account " + accountId + " does not belong to customer " + customerId)
+		return nil, problem.NewNotFoundError(problem.AccountNotFound, "Account not found")
+	}
+
+	if appErr = s.repo.ExpireActive(s.clk.NowAsString()); appErr != nil {
+		return nil, appErr
+	}
+	holds, appErr := s.repo.FindAll(accountId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	response := make([]dto.HoldResponse, 0)
+	for _, h := range holds {
+		response = append(response, h.ToDTO())
+	}
+	return response, nil
+}
+
+// PlaceHold sets the given amount of the given account aside until the hold is captured, released or expires, provided
+// that the account is not frozen and that the amount is available, i.e. not already on hold or reserved for
+// withdrawals pending review.
+func (s DefaultHoldService) PlaceHold(request dto.NewHoldRequest) (*dto.HoldResponse, *errs.AppError) {
+	account, appErr := s.accountRepo.FindById(request.AccountId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if account.CustomerId != request.CustomerId {
+		logger.Error("Account " + request.AccountId + " does not belong to customer " + request.CustomerId)
+		return nil, problem.NewNotFoundError(problem.AccountNotFound, "Account not found")
+	}
+	if account.IsFrozen() {
+		logger.Error("Hold attempted on frozen account " + account.AccountId)
+		return nil, problem.NewAuthorizationError(problem.AccountFrozen, "Account is frozen pending review")
+	}
+
+	if appErr = applyHolds(s.repo, account, s.clk); appErr != nil {
+		return nil, appErr
+	}
+	reserved, appErr := s.reviews.FindReservedAmount(account.AccountId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if !account.CanWithdraw(request.Amount + reserved) {
+		logger.Error("Amount to hold exceeds available account balance")
+		return nil, problem.NewValidationError(problem.InsufficientFunds,
+			"Account balance insufficient to hold given amount")
+	}
+
+	hold, appErr := s.repo.Save(domain.NewHold(request, s.clk))
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	response := hold.ToDTO()
+	return &response, nil
+}
+
+// CaptureHold withdraws the funds of the given hold, unless its account has been frozen or the funds are needed by the
+// other holds or the withdrawals pending review, alerts the account owner as set in their alert rules, charges the
+// account as set in the overdraft terms if the withdrawal overdrew it and charges the withdrawal fee of the fee
+// schedule in effect. The hold is ended before the withdrawal is posted, so that two requests capturing it at once
+// cannot withdraw its funds twice, and is made active again if the withdrawal could not be posted.
+func (s DefaultHoldService) CaptureHold(holdId string) (*dto.HoldResponse, *errs.AppError) {
+	hold, appErr := s.findActive(holdId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	account, appErr := s.accountRepo.FindById(hold.AccountId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if account.IsFrozen() {
+		logger.Error("Capture of hold attempted on frozen account " + account.AccountId)
+		return nil, problem.NewAuthorizationError(problem.AccountFrozen, "Account is frozen pending review")
+	}
+	if appErr = applyHolds(s.repo, account, s.clk); appErr != nil {
+		return nil, appErr
+	}
+	account.HeldAmount -= hold.Amount //the funds set aside by the hold are available to its own capture
+	reserved, appErr := s.reviews.FindReservedAmount(account.AccountId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if !account.CanWithdraw(hold.Amount + reserved) {
+		logger.Error("Amount of hold exceeds account balance")
+		return nil, problem.NewValidationError(problem.InsufficientFunds,
+			"Account balance insufficient to capture the hold")
+	}
+
+	captured := hold.Resolve(dto.HoldStatusCaptured, s.clk)
+	if appErr = s.repo.UpdateStatus(captured, dto.HoldStatusActive); appErr != nil {
+		return nil, appErr
+	}
+
+	completedTransaction, appErr := s.accountRepo.Transact(hold.ToWithdrawal(s.clk))
+	if appErr != nil {
+		if reopenErr := s.repo.UpdateStatus(*hold, dto.HoldStatusCaptured); reopenErr != nil {
+			logger.Error("Error while making hold " + holdId + " active again: " + reopenErr.Message)
+		}
+		return nil, appErr
+	}
+	captured.TransactionId = sql.NullString{String: completedTransaction.TransactionId, Valid: true}
+	if appErr = s.repo.UpdateStatus(captured, dto.HoldStatusCaptured); appErr != nil {
+		logger.Error("Error while recording the withdrawal that captured hold " + holdId + ": " + appErr.Message)
+	}
+	s.alerts.EvaluateTransaction(account.CustomerId, *completedTransaction)
+	chargeOverdraft(s.overdrafts, *completedTransaction)
+	s.fees.ChargeForTransaction(*completedTransaction)
+
+	response := captured.ToDTO()
+	return &response, nil
+}
+
+// ReleaseHold ends the given hold without withdrawing its funds, which makes them available again.
+func (s DefaultHoldService) ReleaseHold(holdId string) (*dto.HoldResponse, *errs.AppError) {
+	hold, appErr := s.findActive(holdId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	released := hold.Resolve(dto.HoldStatusReleased, s.clk)
+	if appErr = s.repo.UpdateStatus(released, dto.HoldStatusActive); appErr != nil {
+		return nil, appErr
+	}
+
+	response := released.ToDTO()
+	return &response, nil
+}
+
+// findActive returns the hold with the given id, or a conflict error if it has expired or already ended.
+func (s DefaultHoldService) findActive(holdId string) (*domain.Hold, *errs.AppError) {
+	if appErr := s.repo.ExpireActive(s.clk.NowAsString()); appErr != nil {
+		return nil, appErr
+	}
+
+	hold, appErr := s.repo.FindById(holdId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if hold.Status == dto.HoldStatusExpired {
+		logger.Error("Hold " + holdId + " has expired")
+		return nil, problem.NewConflictError(problem.HoldExpired, "Hold has expired")
+	}
+	if !hold.IsActive() {
+		logger.Error("Hold " + holdId + " has already ended as " + hold.Status)
+		return nil, problem.NewConflictError(problem.HoldNotActive, "Hold is no longer active")
+	}
+	return hold, nil
+}
+
+// applyHolds sets the held amount of the given account to the total of its holds that are active at the current
+// time, so that its available balance can be checked.
+func applyHolds(holds domain.HoldRepository, account *domain.Account, clk clock.Clock) *errs.AppError {
+	held, appErr := holds.FindHeldAmount(account.AccountId, clk.NowAsString())
+	if appErr != nil {
+		return appErr
+	}
+	account.HeldAmount = held
+	return nil
+}
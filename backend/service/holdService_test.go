@@ -0,0 +1,290 @@
+package service
+
+import (
+	"database/sql"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	mocksDomain "github.com/aliciatay-zls/banking/backend/mocks/domain"
+	mocksService "github.com/aliciatay-zls/banking/backend/mocks/service"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockHoldServiceRepo *mocksDomain.MockHoldRepository
+var mockHoldAccountRepo *mocksDomain.MockAccountRepository
+var mockHoldReviewRepo *mocksDomain.MockTransactionReviewRepository
+var mockHoldAlertService *mocksService.MockAlertService
+var mockHoldOverdraftService *mocksService.MockOverdraftService
+var mockHoldFeeService *mocksService.MockFeeService
+var holdSvc DefaultHoldService
+
+const dummyHoldId = "4"
+
+func setupHoldServiceTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	mockHoldServiceRepo = mocksDomain.NewMockHoldRepository(ctrl)
+	mockHoldAccountRepo = mocksDomain.NewMockAccountRepository(ctrl)
+	mockHoldReviewRepo = mocksDomain.NewMockTransactionReviewRepository(ctrl)
+	mockHoldAlertService = mocksService.NewMockAlertService(ctrl)
+	mockHoldOverdraftService = mocksService.NewMockOverdraftService(ctrl)
+	mockHoldFeeService = mocksService.NewMockFeeService(ctrl)
+	holdSvc = NewHoldService(mockHoldServiceRepo, mockHoldAccountRepo, mockHoldReviewRepo, mockHoldAlertService, mockHoldOverdraftService, mockHoldFeeService, clock.StaticClock{})
+	logger.MuteLogger()
+
+	return func() {
+		mockHoldServiceRepo = nil
+		mockHoldAccountRepo = nil
+		mockHoldReviewRepo = nil
+		mockHoldAlertService = nil
+		mockHoldOverdraftService = nil
+		mockHoldFeeService = nil
+		defer ctrl.Finish()
+	}
+}
+
+// getDummyHoldAccount returns an active account with id 1977 and balance 500 belonging to the customer with id 2
+func getDummyHoldAccount() domain.Account {
+	return domain.Account{AccountId: dummyAccountId, CustomerId: dummyCustomerId, Amount: 500, Status: domain.AccountStatusActive}
+}
+
+// getDummyActiveHold returns an active hold of 100 on the account with id 1977 placed for the default duration
+func getDummyActiveHold() domain.Hold {
+	request := dto.NewHoldRequest{CustomerId: dummyCustomerId, AccountId: dummyAccountId, Amount: 100, Reason: "Card authorization"}
+	hold := domain.NewHold(request, clock.StaticClock{})
+	hold.HoldId = dummyHoldId
+	return hold
+}
+
+func TestDefaultHoldService_PlaceHold_saves_hold_when_amount_available(t *testing.T) {
+	//Arrange
+	teardown := setupHoldServiceTest(t)
+	defer teardown()
+
+	account := getDummyHoldAccount()
+	request := dto.NewHoldRequest{CustomerId: dummyCustomerId, AccountId: dummyAccountId, Amount: 100, Reason: "Card authorization"}
+	expectedHold := domain.NewHold(request, clock.StaticClock{})
+	savedHold := getDummyActiveHold()
+	mockHoldAccountRepo.EXPECT().FindById(dummyAccountId).Return(&account, nil)
+	mockHoldServiceRepo.EXPECT().FindHeldAmount(dummyAccountId, clock.StaticClock{}.NowAsString()).Return(float64(300), nil)
+	mockHoldReviewRepo.EXPECT().FindReservedAmount(dummyAccountId).Return(float64(100), nil)
+	mockHoldServiceRepo.EXPECT().Save(expectedHold).Return(&savedHold, nil)
+
+	//Act
+	response, err := holdSvc.PlaceHold(request)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing placing of hold: " + err.Message)
+	}
+	if response.HoldId != dummyHoldId || response.Status != dto.HoldStatusActive {
+		t.Errorf("Expected active hold %s but got %v", dummyHoldId, *response)
+	}
+}
+
+func TestDefaultHoldService_PlaceHold_returns_error_without_saving_when_not_allowed(t *testing.T) {
+	//Arrange
+	frozen := getDummyHoldAccount()
+	frozen.Status = domain.AccountStatusFrozen
+	otherCustomers := getDummyHoldAccount()
+	otherCustomers.CustomerId = "3"
+
+	tests := []struct {
+		name               string
+		account            domain.Account
+		held               float64
+		expectedStatusCode int
+	}{
+		{"account of other customer", otherCustomers, 0, http.StatusNotFound},
+		{"account frozen", frozen, 0, http.StatusForbidden},
+		{"amount on hold", getDummyHoldAccount(), 450, http.StatusUnprocessableEntity},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			teardown := setupHoldServiceTest(t)
+			defer teardown()
+
+			mockHoldAccountRepo.EXPECT().FindById(dummyAccountId).Return(&tc.account, nil)
+			mockHoldServiceRepo.EXPECT().FindHeldAmount(gomock.Any(), gomock.Any()).Return(tc.held, nil).AnyTimes()
+			mockHoldReviewRepo.EXPECT().FindReservedAmount(gomock.Any()).Return(float64(0), nil).AnyTimes()
+			mockHoldServiceRepo.EXPECT().Save(gomock.Any()).Times(0)
+
+			//Act
+			_, err := holdSvc.PlaceHold(dto.NewHoldRequest{CustomerId: dummyCustomerId, AccountId: dummyAccountId, Amount: 100})
+
+			//Assert
+			if err == nil || err.Code != tc.expectedStatusCode {
+				t.Errorf("Expected error with status code %d but got %v", tc.expectedStatusCode, err)
+			}
+		})
+	}
+}
+
+func TestDefaultHoldService_CaptureHold_withdraws_heldFunds_and_alerts_customer(t *testing.T) {
+	//Arrange
+	teardown := setupHoldServiceTest(t)
+	defer teardown()
+
+	hold := getDummyActiveHold()
+	account := getDummyHoldAccount()
+	captured := hold.Resolve(dto.HoldStatusCaptured, clock.StaticClock{})
+	withdrawal := hold.ToWithdrawal(clock.StaticClock{})
+	postedWithdrawal := withdrawal
+	postedWithdrawal.TransactionId = dummyTransactionId
+	postedWithdrawal.Balance = 400
+	recorded := captured
+	recorded.TransactionId = sql.NullString{String: dummyTransactionId, Valid: true}
+
+	mockHoldServiceRepo.EXPECT().ExpireActive(clock.StaticClock{}.NowAsString()).Return(nil)
+	mockHoldServiceRepo.EXPECT().FindById(dummyHoldId).Return(&hold, nil)
+	mockHoldAccountRepo.EXPECT().FindById(dummyAccountId).Return(&account, nil)
+	mockHoldServiceRepo.EXPECT().FindHeldAmount(dummyAccountId, clock.StaticClock{}.NowAsString()).Return(float64(500), nil)
+	mockHoldReviewRepo.EXPECT().FindReservedAmount(dummyAccountId).Return(float64(0), nil)
+	gomock.InOrder(
+		mockHoldServiceRepo.EXPECT().UpdateStatus(captured, dto.HoldStatusActive).Return(nil),
+		mockHoldAccountRepo.EXPECT().Transact(withdrawal).Return(&postedWithdrawal, nil),
+		mockHoldServiceRepo.EXPECT().UpdateStatus(recorded, dto.HoldStatusCaptured).Return(nil),
+	)
+	mockHoldAlertService.EXPECT().EvaluateTransaction(dummyCustomerId, postedWithdrawal)
+	mockHoldFeeService.EXPECT().ChargeForTransaction(postedWithdrawal)
+
+	//Act
+	response, err := holdSvc.CaptureHold(dummyHoldId)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing capture of hold: " + err.Message)
+	}
+	if response.Status != dto.HoldStatusCaptured || response.TransactionId != dummyTransactionId {
+		t.Errorf("Expected hold captured by transaction %s but got %v", dummyTransactionId, *response)
+	}
+}
+
+func TestDefaultHoldService_CaptureHold_makes_hold_activeAgain_when_transact_fails(t *testing.T) {
+	//Arrange
+	teardown := setupHoldServiceTest(t)
+	defer teardown()
+
+	hold := getDummyActiveHold()
+	account := getDummyHoldAccount()
+	dummyAppErr := errs.NewUnexpectedError("Unexpected database error")
+
+	mockHoldServiceRepo.EXPECT().ExpireActive(gomock.Any()).Return(nil)
+	mockHoldServiceRepo.EXPECT().FindById(dummyHoldId).Return(&hold, nil)
+	mockHoldAccountRepo.EXPECT().FindById(dummyAccountId).Return(&account, nil)
+	mockHoldServiceRepo.EXPECT().FindHeldAmount(gomock.Any(), gomock.Any()).Return(float64(100), nil)
+	mockHoldReviewRepo.EXPECT().FindReservedAmount(dummyAccountId).Return(float64(0), nil)
+	gomock.InOrder(
+		mockHoldServiceRepo.EXPECT().UpdateStatus(gomock.Any(), dto.HoldStatusActive).Return(nil),
+		mockHoldAccountRepo.EXPECT().Transact(gomock.Any()).Return(nil, dummyAppErr),
+		mockHoldServiceRepo.EXPECT().UpdateStatus(hold, dto.HoldStatusCaptured).Return(nil),
+	)
+	mockHoldAlertService.EXPECT().EvaluateTransaction(gomock.Any(), gomock.Any()).Times(0)
+
+	//Act
+	_, err := holdSvc.CaptureHold(dummyHoldId)
+
+	//Assert
+	if err == nil || err.Message != dummyAppErr.Message {
+		t.Errorf("Expected error \"%s\" but got %v", dummyAppErr.Message, err)
+	}
+}
+
+func TestDefaultHoldService_CaptureHold_returns_error_when_balance_reserved_by_pendingReviews(t *testing.T) {
+	//Arrange
+	teardown := setupHoldServiceTest(t)
+	defer teardown()
+
+	hold := getDummyActiveHold()
+	account := getDummyHoldAccount()
+	mockHoldServiceRepo.EXPECT().ExpireActive(gomock.Any()).Return(nil)
+	mockHoldServiceRepo.EXPECT().FindById(dummyHoldId).Return(&hold, nil)
+	mockHoldAccountRepo.EXPECT().FindById(dummyAccountId).Return(&account, nil)
+	mockHoldServiceRepo.EXPECT().FindHeldAmount(gomock.Any(), gomock.Any()).Return(float64(100), nil)
+	mockHoldReviewRepo.EXPECT().FindReservedAmount(dummyAccountId).Return(float64(450), nil)
+	mockHoldServiceRepo.EXPECT().UpdateStatus(gomock.Any(), gomock.Any()).Times(0)
+	mockHoldAccountRepo.EXPECT().Transact(gomock.Any()).Times(0)
+	logger.MuteLogger()
+
+	//Act
+	_, err := holdSvc.CaptureHold(dummyHoldId)
+
+	//Assert
+	if err == nil || err.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected insufficient balance error for hold needing reserved funds but got %v", err)
+	}
+}
+
+func TestDefaultHoldService_CaptureHold_returns_conflictError_when_hold_expired(t *testing.T) {
+	//Arrange
+	teardown := setupHoldServiceTest(t)
+	defer teardown()
+
+	hold := getDummyActiveHold()
+	hold.Status = dto.HoldStatusExpired
+	mockHoldServiceRepo.EXPECT().ExpireActive(clock.StaticClock{}.NowAsString()).Return(nil)
+	mockHoldServiceRepo.EXPECT().FindById(dummyHoldId).Return(&hold, nil)
+	mockHoldServiceRepo.EXPECT().UpdateStatus(gomock.Any(), gomock.Any()).Times(0)
+	mockHoldAccountRepo.EXPECT().Transact(gomock.Any()).Times(0)
+
+	//Act
+	_, err := holdSvc.CaptureHold(dummyHoldId)
+
+	//Assert
+	if err == nil || err.Code != http.StatusConflict || err.Message != "Hold has expired" {
+		t.Errorf("Expected conflict error for expired hold but got %v", err)
+	}
+}
+
+func TestDefaultHoldService_ReleaseHold_ends_hold_without_withdrawing(t *testing.T) {
+	//Arrange
+	teardown := setupHoldServiceTest(t)
+	defer teardown()
+
+	hold := getDummyActiveHold()
+	released := hold.Resolve(dto.HoldStatusReleased, clock.StaticClock{})
+	mockHoldServiceRepo.EXPECT().ExpireActive(clock.StaticClock{}.NowAsString()).Return(nil)
+	mockHoldServiceRepo.EXPECT().FindById(dummyHoldId).Return(&hold, nil)
+	mockHoldServiceRepo.EXPECT().UpdateStatus(released, dto.HoldStatusActive).Return(nil)
+	mockHoldAccountRepo.EXPECT().Transact(gomock.Any()).Times(0)
+
+	//Act
+	response, err := holdSvc.ReleaseHold(dummyHoldId)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing release of hold: " + err.Message)
+	}
+	if response.Status != dto.HoldStatusReleased || response.ResolvedOn == "" {
+		t.Errorf("Expected hold to be released but got %v", *response)
+	}
+}
+
+func TestDefaultHoldService_GetHolds_expires_holds_before_listing_them(t *testing.T) {
+	//Arrange
+	teardown := setupHoldServiceTest(t)
+	defer teardown()
+
+	account := getDummyHoldAccount()
+	mockHoldAccountRepo.EXPECT().FindById(dummyAccountId).Return(&account, nil)
+	gomock.InOrder(
+		mockHoldServiceRepo.EXPECT().ExpireActive(clock.StaticClock{}.NowAsString()).Return(nil),
+		mockHoldServiceRepo.EXPECT().FindAll(dummyAccountId).Return([]domain.Hold{getDummyActiveHold()}, nil),
+	)
+
+	//Act
+	response, err := holdSvc.GetHolds(dummyCustomerId, dummyAccountId)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing retrieval of holds: " + err.Message)
+	}
+	if len(response) != 1 || response[0].HoldId != dummyHoldId {
+		t.Errorf("Expected hold %s to be listed but got %v", dummyHoldId, response)
+	}
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"time"
+)
+
+// outboxBatchSize is the maximum number of events relayed per poll of the outbox.
+const outboxBatchSize = 100
+
+// OutboxRelay publishes the events written to the outbox. Only one relay should run against a database, since
+// events are read in the order they were written and two relays could publish the same events out of order.
+type OutboxRelay struct {
+	repo      domain.OutboxRepository
+	publisher domain.EventPublisher
+	clk       clock.Clock
+}
+
+func NewOutboxRelay(repo domain.OutboxRepository, publisher domain.EventPublisher, clk clock.Clock) OutboxRelay {
+	return OutboxRelay{repo, publisher, clk}
+}
+
+// RelayPending publishes the unpublished events in the order they were written, marking each as published after it
+// has been published. It stops at the first event that fails, so that no later event (possibly of the same account)
+// overtakes it, and the failed event is retried on the next call. An event that was published but could not be marked
+// is published again on the next call, so events are delivered at least once.
+// RelayPending returns the number of events published.
+func (r OutboxRelay) RelayPending() (int, *errs.AppError) {
+	events, appErr := r.repo.FindUnpublished(outboxBatchSize)
+	if appErr != nil {
+		return 0, appErr
+	}
+
+	for i, event := range events {
+		if appErr = r.publisher.Publish(event); appErr != nil {
+			return i, appErr
+		}
+		if appErr = r.repo.MarkPublished(event.EventId, r.clk.NowAsString()); appErr != nil {
+			return i + 1, appErr
+		}
+	}
+
+	return len(events), nil
+}
+
+// Run relays the pending events every interval until the given context is cancelled. Full batches are relayed
+// immediately one after another so that a backlog is cleared without waiting for the next tick.
+func (r OutboxRelay) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		for {
+			count, appErr := r.RelayPending()
+			if appErr != nil {
+				logger.Error(fmt.Sprintf("Error while relaying outbox events (%d published): %s", count, appErr.Message))
+				break
+			}
+			if count < outboxBatchSize {
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	mocksDomain "github.com/aliciatay-zls/banking/backend/mocks/domain"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockOutboxRepo *mocksDomain.MockOutboxRepository
+var mockEventPublisher *mocksDomain.MockEventPublisher
+var relay OutboxRelay
+
+func setupOutboxRelayTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	mockOutboxRepo = mocksDomain.NewMockOutboxRepository(ctrl)
+	mockEventPublisher = mocksDomain.NewMockEventPublisher(ctrl)
+	relay = NewOutboxRelay(mockOutboxRepo, mockEventPublisher, clock.StaticClock{})
+
+	return func() {
+		mockOutboxRepo = nil
+		mockEventPublisher = nil
+		defer ctrl.Finish()
+	}
+}
+
+// getDummyEvents returns an event for opening account 1977 followed by an event for a deposit into it.
+func getDummyEvents() []domain.Event {
+	return []domain.Event{
+		{EventId: "1", AccountId: "1977", EventType: domain.EventTypeAccountOpened, Payload: "{}"},
+		{EventId: "2", AccountId: "1977", EventType: domain.EventTypeTransactionPosted, Payload: "{}"},
+	}
+}
+
+func TestOutboxRelay_RelayPending_returns_error_when_repo_fails(t *testing.T) {
+	//Arrange
+	teardown := setupOutboxRelayTest(t)
+	defer teardown()
+
+	dummyAppErr := errs.NewUnexpectedError("some error message")
+	mockOutboxRepo.EXPECT().FindUnpublished(outboxBatchSize).Return(nil, dummyAppErr)
+	mockEventPublisher.EXPECT().Publish(gomock.Any()).Times(0)
+
+	//Act
+	count, err := relay.RelayPending()
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing failure retrieving unpublished events")
+	}
+	if count != 0 {
+		t.Errorf("Expected 0 events published but got %d", count)
+	}
+}
+
+func TestOutboxRelay_RelayPending_stops_at_firstFailedEvent(t *testing.T) {
+	//Arrange
+	teardown := setupOutboxRelayTest(t)
+	defer teardown()
+
+	events := getDummyEvents()
+	dummyAppErr := errs.NewUnexpectedError("some error message")
+	mockOutboxRepo.EXPECT().FindUnpublished(outboxBatchSize).Return(events, nil)
+	mockEventPublisher.EXPECT().Publish(events[0]).Return(dummyAppErr)
+	mockOutboxRepo.EXPECT().MarkPublished(gomock.Any(), gomock.Any()).Times(0)
+
+	//Act
+	count, err := relay.RelayPending()
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing failure publishing event")
+	}
+	if count != 0 {
+		t.Errorf("Expected 0 events published but got %d", count)
+	}
+}
+
+func TestOutboxRelay_RelayPending_publishes_and_marks_events_inOrder(t *testing.T) {
+	//Arrange
+	teardown := setupOutboxRelayTest(t)
+	defer teardown()
+
+	events := getDummyEvents()
+	publishedOn := clock.StaticClock{}.NowAsString()
+	mockOutboxRepo.EXPECT().FindUnpublished(outboxBatchSize).Return(events, nil)
+	gomock.InOrder(
+		mockEventPublisher.EXPECT().Publish(events[0]).Return(nil),
+		mockOutboxRepo.EXPECT().MarkPublished(events[0].EventId, publishedOn).Return(nil),
+		mockEventPublisher.EXPECT().Publish(events[1]).Return(nil),
+		mockOutboxRepo.EXPECT().MarkPublished(events[1].EventId, publishedOn).Return(nil),
+	)
+
+	//Act
+	count, err := relay.RelayPending()
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing successful relaying of events: " + err.Message)
+	}
+	if count != len(events) {
+		t.Errorf("Expected %d events published but got %d", len(events), count)
+	}
+}
+
+func TestOutboxRelay_RelayPending_delivers_events_through_channelPublisher(t *testing.T) {
+	//Arrange
+	teardown := setupOutboxRelayTest(t)
+	defer teardown()
+
+	events := getDummyEvents()
+	publisher := domain.NewChannelEventPublisher(1)
+	relay = NewOutboxRelay(mockOutboxRepo, publisher, clock.StaticClock{})
+	mockOutboxRepo.EXPECT().FindUnpublished(outboxBatchSize).Return(events, nil)
+	mockOutboxRepo.EXPECT().MarkPublished(events[0].EventId, gomock.Any()).Return(nil)
+	logger.MuteLogger()
+
+	//Act
+	count, err := relay.RelayPending()
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing relaying to a full channel")
+	}
+	if count != 1 {
+		t.Errorf("Expected 1 event published before the channel was full but got %d", count)
+	}
+	if actualEvent := <-publisher.Events(); actualEvent != events[0] {
+		t.Errorf("Expected event %v on channel but got %v", events[0], actualEvent)
+	}
+}
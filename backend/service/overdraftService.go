@@ -0,0 +1,235 @@
+package service
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/problem"
+	"math"
+	"net/http"
+)
+
+//go:generate mockgen -destination=../mocks/service/mock_overdraftService.go -package=service github.com/aliciatay-zls/banking/backend/service OverdraftService
+type OverdraftService interface { //service (primary port)
+	GetOverdraft(string, string) (*dto.OverdraftResponse, *errs.AppError)
+	SetLimit(dto.OverdraftLimitRequest) (*dto.OverdraftResponse, *errs.AppError)
+	ChargeForTransaction(domain.Transaction)
+	ChargeInterest() (*dto.OverdraftInterestReport, *errs.AppError)
+}
+
+type DefaultOverdraftService struct { //business/domain object
+	repo        domain.OverdraftRepository
+	accountRepo domain.AccountRepository
+	holds       domain.HoldRepository
+	terms       domain.OverdraftTerms
+	clk         clock.Clock
+}
+
+func NewOverdraftService(repo domain.OverdraftRepository, accountRepo domain.AccountRepository, holds domain.HoldRepository, terms domain.OverdraftTerms, clk clock.Clock) DefaultOverdraftService {
+	return DefaultOverdraftService{repo, accountRepo, holds, terms, clk}
+}
+
+// GetOverdraft returns the overdraft usage of the given account of the given customer: its overdraft limit, how much of
+// it is used and, if the account is overdrawn, what it has been charged since.
+func (s DefaultOverdraftService) GetOverdraft(customerId string, accountId string) (*dto.OverdraftResponse, *errs.AppError) {
+	account, appErr := s.findAccount(customerId, accountId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	return s.usage(account)
+}
+
+// SetLimit sets the overdraft limit of the given checking account, or withdraws its overdraft facility if the limit is
+// 0. The limit cannot be set below the amount that the account is already overdrawn by.
+func (s DefaultOverdraftService) SetLimit(request dto.OverdraftLimitRequest) (*dto.OverdraftResponse, *errs.AppError) {
+	account, appErr := s.findAccount(request.CustomerId, request.AccountId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if !account.IsChecking() {
+		logger.Error("Overdraft limit attempted on " + account.AccountType + " account " + account.AccountId)
+		return nil, problem.NewValidationError(problem.OverdraftNotAllowed,
+			"An overdraft can only be granted on a checking account")
+	}
+	if request.Limit < account.OverdrawnAmount() {
+		logger.Error("Overdraft limit of account " + account.AccountId + " attempted below its overdrawn amount")
+		return nil, problem.NewValidationError(problem.OverdraftLimitTooLow,
+			"The overdraft limit cannot be lower than the amount the account is overdrawn by")
+	}
+
+	if appErr = s.accountRepo.UpdateOverdraftLimit(account.AccountId, request.Limit); appErr != nil {
+		return nil, appErr
+	}
+	account.OverdraftLimit = request.Limit
+	return s.usage(account)
+}
+
+// ChargeForTransaction opens an overdraft and charges the overdraft fee if the given posted transaction took the
+// balance of its checking account below zero, or charges the interest due and closes the overdraft if it took the
+// balance back to zero or above. A saving account has no overdraft, so nothing is charged to it. As the transaction
+// has already been posted, errors are logged rather than returned.
+func (s DefaultOverdraftService) ChargeForTransaction(t domain.Transaction) {
+	if !t.Overdraws() && !t.RepaysOverdraft() {
+		return
+	}
+	account, appErr := s.accountRepo.FindById(t.AccountId)
+	if appErr != nil {
+		return
+	}
+	if !account.IsChecking() {
+		logger.Error("Overdraft charges attempted on " + account.AccountType + " account " + account.AccountId)
+		return
+	}
+
+	if t.Overdraws() {
+		s.open(t)
+	} else {
+		s.repay(t)
+	}
+}
+
+// ChargeInterest is the daily overdraft job: it charges every open overdraft the interest due on the overdrawn amount
+// for each whole day since it was last charged, and closes the overdrafts of accounts no longer overdrawn, e.g. after
+// a transaction import.
+func (s DefaultOverdraftService) ChargeInterest() (*dto.OverdraftInterestReport, *errs.AppError) {
+	overdrafts, appErr := s.repo.FindAllOpen()
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	report := dto.OverdraftInterestReport{RunOn: s.clk.NowAsString()}
+	for _, o := range overdrafts {
+		account, appErr := s.accountRepo.FindById(o.AccountId)
+		if appErr != nil {
+			return nil, appErr
+		}
+		if account.OverdrawnAmount() == 0 {
+			if appErr = s.repo.Update(o.Close(s.clk), o.InterestAccruedOn); appErr == nil {
+				report.OverdraftsClosed++
+			}
+			continue
+		}
+
+		_, interest, appErr := s.accrue(o, account.OverdrawnAmount())
+		if appErr != nil {
+			continue //already logged, the other overdrafts can still be charged
+		}
+		if interest > 0 {
+			report.AccountsCharged++
+			report.InterestCharged = math.Round((report.InterestCharged+interest)*100) / 100
+		}
+	}
+	return &report, nil
+}
+
+// open opens the overdraft of the account that the given transaction took below zero and charges it the overdraft fee.
+// An overdraft left open by an earlier period of the account being overdrawn, which cannot accrue interest any longer,
+// is closed first.
+func (s DefaultOverdraftService) open(t domain.Transaction) {
+	if stale, appErr := s.repo.FindOpen(t.AccountId); appErr == nil {
+		if appErr = s.repo.Update(stale.Close(s.clk), stale.InterestAccruedOn); appErr != nil {
+			return
+		}
+	} else if appErr.Code != http.StatusNotFound {
+		return
+	}
+
+	overdraft, appErr := s.repo.Save(domain.NewOverdraft(t, s.terms.Fee))
+	if appErr != nil || s.terms.Fee == 0 {
+		return
+	}
+	if _, appErr = s.accountRepo.Transact(t.ToFee(s.terms.Fee, s.clk)); appErr != nil {
+		logger.Error("Error while charging overdraft fee to account " + t.AccountId + ": " + appErr.Message)
+		uncharged := *overdraft
+		uncharged.FeeCharged = 0
+		if updateErr := s.repo.Update(uncharged, overdraft.InterestAccruedOn); updateErr != nil {
+			logger.Error("Error while recording that overdraft " + overdraft.OverdraftId + " was not charged a fee: " + updateErr.Message)
+		}
+	}
+}
+
+// repay charges the overdraft of the account that the given transaction took back to zero or above the interest due
+// on the amount it was overdrawn by before, and closes it unless the interest took the balance below zero again.
+func (s DefaultOverdraftService) repay(t domain.Transaction) {
+	overdraft, appErr := s.repo.FindOpen(t.AccountId)
+	if appErr != nil {
+		return
+	}
+
+	accrued, interest, appErr := s.accrue(*overdraft, -t.PreviousBalance())
+	if appErr != nil {
+		return
+	}
+	if t.Balance < interest {
+		return //the interest took the account below zero again, so it stays overdrawn
+	}
+	if appErr = s.repo.Update(accrued.Close(s.clk), accrued.InterestAccruedOn); appErr != nil {
+		logger.Error("Error while closing overdraft " + overdraft.OverdraftId + ": " + appErr.Message)
+	}
+}
+
+// accrue charges the given overdraft the interest due on the given overdrawn amount for each whole day since it was
+// last charged, and returns the overdraft as stored afterwards with the interest charged. The overdraft is updated
+// before the interest is posted, so that two jobs running at once cannot charge it twice, and is put back as it was if
+// the interest could not be posted.
+func (s DefaultOverdraftService) accrue(o domain.Overdraft, overdrawnAmount float64) (domain.Overdraft, float64, *errs.AppError) {
+	days := o.DaysToAccrue(s.clk.Now())
+	interest := s.terms.Interest(overdrawnAmount, days)
+	if interest == 0 {
+		return o, 0, nil
+	}
+
+	accrued := o.Accrue(days, interest)
+	if appErr := s.repo.Update(accrued, o.InterestAccruedOn); appErr != nil {
+		return o, 0, appErr
+	}
+	if _, appErr := s.accountRepo.Transact(o.ToInterest(interest, s.clk)); appErr != nil {
+		logger.Error("Error while charging overdraft interest to account " + o.AccountId + ": " + appErr.Message)
+		if revertErr := s.repo.Update(o, accrued.InterestAccruedOn); revertErr != nil {
+			logger.Error("Error while putting overdraft " + o.OverdraftId + " back as uncharged: " + revertErr.Message)
+		}
+		return o, 0, appErr
+	}
+	return accrued, interest, nil
+}
+
+// findAccount returns the account with the given id, or a not found error if it does not belong to the given customer.
+func (s DefaultOverdraftService) findAccount(customerId string, accountId string) (*domain.Account, *errs.AppError) {
+	account, appErr := s.accountRepo.FindById(accountId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if account.CustomerId != customerId {
+		logger.Error("Account " + accountId + " does not belong to customer " + customerId)
+		return nil, problem.NewNotFoundError(problem.AccountNotFound, "Account not found")
+	}
+	return account, nil
+}
+
+// usage returns the overdraft usage of the given account, with its open overdraft if it is overdrawn.
+func (s DefaultOverdraftService) usage(account *domain.Account) (*dto.OverdraftResponse, *errs.AppError) {
+	if appErr := applyHolds(s.holds, account, s.clk); appErr != nil {
+		return nil, appErr
+	}
+
+	overdraft, appErr := s.repo.FindOpen(account.AccountId)
+	if appErr != nil {
+		if appErr.Code != http.StatusNotFound {
+			return nil, appErr
+		}
+		overdraft = nil
+	}
+
+	response := s.terms.ToOverdraftDTO(*account, overdraft)
+	return &response, nil
+}
+
+// chargeOverdraft passes the given posted transaction on to be charged as set in the overdraft terms, if it took the
+// balance of its account below zero or back from below zero.
+func chargeOverdraft(overdrafts OverdraftService, t domain.Transaction) {
+	if t.Overdraws() || t.RepaysOverdraft() {
+		overdrafts.ChargeForTransaction(t)
+	}
+}
@@ -0,0 +1,351 @@
+package service
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	mocksDomain "github.com/aliciatay-zls/banking/backend/mocks/domain"
+	"go.uber.org/mock/gomock"
+	"math"
+	"net/http"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockOverdraftRepo *mocksDomain.MockOverdraftRepository
+var mockOverdraftAccountRepo *mocksDomain.MockAccountRepository
+var mockOverdraftHoldRepo *mocksDomain.MockHoldRepository
+var overdraftSvc DefaultOverdraftService
+
+var dummyOverdraftTerms = domain.OverdraftTerms{Fee: 25, InterestRate: 36.5} //1.00 a day for every 1000 overdrawn
+
+const dummyOverdraftId = "3"
+const dummyTwoDaysAgo = "2005-12-31 15:04:05"
+
+func setupOverdraftServiceTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	mockOverdraftRepo = mocksDomain.NewMockOverdraftRepository(ctrl)
+	mockOverdraftAccountRepo = mocksDomain.NewMockAccountRepository(ctrl)
+	mockOverdraftHoldRepo = mocksDomain.NewMockHoldRepository(ctrl)
+	overdraftSvc = NewOverdraftService(mockOverdraftRepo, mockOverdraftAccountRepo, mockOverdraftHoldRepo, dummyOverdraftTerms, clock.StaticClock{})
+	logger.MuteLogger()
+
+	return func() {
+		mockOverdraftRepo = nil
+		mockOverdraftAccountRepo = nil
+		mockOverdraftHoldRepo = nil
+		defer ctrl.Finish()
+	}
+}
+
+// getDummyOverdrawnAccount returns a checking account with id 1977 belonging to the customer with id 2, overdrawn by
+// 1000 out of an overdraft limit of 2000
+func getDummyOverdrawnAccount() domain.Account {
+	return domain.Account{AccountId: dummyAccountId, CustomerId: dummyCustomerId, AccountType: dto.AccountTypeChecking,
+		Amount: -1000, Status: domain.AccountStatusActive, OverdraftLimit: 2000}
+}
+
+// getDummyOpenOverdraft returns the overdraft of the account with id 1977, overdrawn and last charged interest two days
+// before the static clock
+func getDummyOpenOverdraft() domain.Overdraft {
+	return domain.Overdraft{
+		OverdraftId:       dummyOverdraftId,
+		AccountId:         dummyAccountId,
+		OverdrawnSince:    dummyTwoDaysAgo,
+		InterestAccruedOn: dummyTwoDaysAgo,
+		FeeCharged:        dummyOverdraftTerms.Fee,
+	}
+}
+
+func TestDefaultOverdraftService_GetOverdraft_returns_notFoundError_when_account_of_otherCustomer(t *testing.T) {
+	//Arrange
+	teardown := setupOverdraftServiceTest(t)
+	defer teardown()
+
+	account := getDummyOverdrawnAccount()
+	mockOverdraftAccountRepo.EXPECT().FindById(dummyAccountId).Return(&account, nil)
+
+	//Act
+	_, err := overdraftSvc.GetOverdraft("3", dummyAccountId)
+
+	//Assert
+	if err == nil || err.Code != http.StatusNotFound {
+		t.Errorf("Expected not found error but got %v", err)
+	}
+}
+
+func TestDefaultOverdraftService_GetOverdraft_returns_usage_of_overdrawnAccount(t *testing.T) {
+	//Arrange
+	teardown := setupOverdraftServiceTest(t)
+	defer teardown()
+
+	account := getDummyOverdrawnAccount()
+	overdraft := getDummyOpenOverdraft()
+	mockOverdraftAccountRepo.EXPECT().FindById(dummyAccountId).Return(&account, nil)
+	mockOverdraftHoldRepo.EXPECT().FindHeldAmount(dummyAccountId, clock.StaticClock{}.NowAsString()).Return(float64(300), nil)
+	mockOverdraftRepo.EXPECT().FindOpen(dummyAccountId).Return(&overdraft, nil)
+
+	//Act
+	response, err := overdraftSvc.GetOverdraft(dummyCustomerId, dummyAccountId)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing overdraft usage: " + err.Message)
+	}
+	if response.OverdrawnAmount != 1000 || response.AvailableOverdraft != 700 || response.OverdrawnSince != dummyTwoDaysAgo ||
+		response.FeeCharged != 25 {
+		t.Errorf("Expected overdrawn amount 1000 with 700 of the limit left since %s but got %v", dummyTwoDaysAgo, *response)
+	}
+}
+
+func TestDefaultOverdraftService_SetLimit_returns_validationError_when_limit_not_allowed(t *testing.T) {
+	//Arrange
+	saving := getDummyOverdrawnAccount()
+	saving.AccountType = dto.AccountTypeSaving
+	saving.Amount = 100
+	tests := []struct {
+		name            string
+		account         domain.Account
+		limit           float64
+		expectedMessage string
+	}{
+		{"saving account", saving, 500, "An overdraft can only be granted on a checking account"},
+		{"below overdrawn amount", getDummyOverdrawnAccount(), 999.99, "The overdraft limit cannot be lower than the amount the account is overdrawn by"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			teardown := setupOverdraftServiceTest(t)
+			defer teardown()
+
+			mockOverdraftAccountRepo.EXPECT().FindById(dummyAccountId).Return(&tc.account, nil)
+			mockOverdraftAccountRepo.EXPECT().UpdateOverdraftLimit(gomock.Any(), gomock.Any()).Times(0)
+			request := dto.OverdraftLimitRequest{CustomerId: dummyCustomerId, AccountId: dummyAccountId, Limit: tc.limit}
+
+			//Act
+			_, err := overdraftSvc.SetLimit(request)
+
+			//Assert
+			if err == nil {
+				t.Fatal("Expected error but got none while testing overdraft limit that is not allowed")
+			}
+			if err.Code != http.StatusUnprocessableEntity || err.Message != tc.expectedMessage {
+				t.Errorf("Expected validation error \"%s\" but got %d \"%s\"", tc.expectedMessage, err.Code, err.Message)
+			}
+		})
+	}
+}
+
+func TestDefaultOverdraftService_SetLimit_updates_limit_and_returns_usage(t *testing.T) {
+	//Arrange
+	teardown := setupOverdraftServiceTest(t)
+	defer teardown()
+
+	account := getDummyOverdrawnAccount()
+	account.Amount = 100
+	mockOverdraftAccountRepo.EXPECT().FindById(dummyAccountId).Return(&account, nil)
+	mockOverdraftAccountRepo.EXPECT().UpdateOverdraftLimit(dummyAccountId, float64(500)).Return(nil)
+	mockOverdraftHoldRepo.EXPECT().FindHeldAmount(dummyAccountId, clock.StaticClock{}.NowAsString()).Return(float64(0), nil)
+	mockOverdraftRepo.EXPECT().FindOpen(dummyAccountId).Return(nil, errs.NewNotFoundError("Account is not overdrawn"))
+	request := dto.OverdraftLimitRequest{CustomerId: dummyCustomerId, AccountId: dummyAccountId, Limit: 500}
+
+	//Act
+	response, err := overdraftSvc.SetLimit(request)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing setting of overdraft limit: " + err.Message)
+	}
+	if response.OverdraftLimit != 500 || response.AvailableOverdraft != 500 || response.OverdrawnSince != "" {
+		t.Errorf("Expected unused overdraft limit of 500 but got %v", *response)
+	}
+}
+
+func TestDefaultOverdraftService_ChargeForTransaction_opens_overdraft_and_chargesFee_when_transaction_overdraws(t *testing.T) {
+	//Arrange
+	teardown := setupOverdraftServiceTest(t)
+	defer teardown()
+
+	withdrawal := domain.Transaction{TransactionId: dummyTransactionId, AccountId: dummyAccountId, Amount: 600, Balance: -100,
+		TransactionType: dto.TransactionTypeWithdrawal, TransactionDate: clock.StaticClock{}.NowAsString()}
+	expectedOverdraft := domain.NewOverdraft(withdrawal, dummyOverdraftTerms.Fee)
+	savedOverdraft := expectedOverdraft
+	savedOverdraft.OverdraftId = dummyOverdraftId
+	expectedFee := withdrawal.ToFee(dummyOverdraftTerms.Fee, clock.StaticClock{})
+	account := getDummyOverdrawnAccount()
+	mockOverdraftAccountRepo.EXPECT().FindById(dummyAccountId).Return(&account, nil)
+	mockOverdraftRepo.EXPECT().FindOpen(dummyAccountId).Return(nil, errs.NewNotFoundError("Account is not overdrawn"))
+	mockOverdraftRepo.EXPECT().Save(expectedOverdraft).Return(&savedOverdraft, nil)
+	mockOverdraftAccountRepo.EXPECT().Transact(expectedFee).Return(&expectedFee, nil)
+	mockOverdraftRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Times(0)
+
+	//Act
+	overdraftSvc.ChargeForTransaction(withdrawal)
+}
+
+func TestDefaultOverdraftService_ChargeForTransaction_records_fee_as_notCharged_when_posting_fails(t *testing.T) {
+	//Arrange
+	teardown := setupOverdraftServiceTest(t)
+	defer teardown()
+
+	withdrawal := domain.Transaction{AccountId: dummyAccountId, Amount: 600, Balance: -100,
+		TransactionType: dto.TransactionTypeWithdrawal, TransactionDate: clock.StaticClock{}.NowAsString()}
+	savedOverdraft := domain.NewOverdraft(withdrawal, dummyOverdraftTerms.Fee)
+	savedOverdraft.OverdraftId = dummyOverdraftId
+	uncharged := savedOverdraft
+	uncharged.FeeCharged = 0
+	account := getDummyOverdrawnAccount()
+	mockOverdraftAccountRepo.EXPECT().FindById(dummyAccountId).Return(&account, nil)
+	mockOverdraftRepo.EXPECT().FindOpen(dummyAccountId).Return(nil, errs.NewNotFoundError("Account is not overdrawn"))
+	mockOverdraftRepo.EXPECT().Save(gomock.Any()).Return(&savedOverdraft, nil)
+	mockOverdraftAccountRepo.EXPECT().Transact(gomock.Any()).Return(nil, errs.NewUnexpectedError("Unexpected database error"))
+	mockOverdraftRepo.EXPECT().Update(uncharged, savedOverdraft.InterestAccruedOn).Return(nil)
+
+	//Act
+	overdraftSvc.ChargeForTransaction(withdrawal)
+}
+
+func TestDefaultOverdraftService_ChargeForTransaction_chargesInterest_and_closes_overdraft_when_transaction_repays_it(t *testing.T) {
+	//Arrange
+	teardown := setupOverdraftServiceTest(t)
+	defer teardown()
+
+	deposit := domain.Transaction{AccountId: dummyAccountId, Amount: 1500, Balance: 500,
+		TransactionType: dto.TransactionTypeDeposit, TransactionDate: clock.StaticClock{}.NowAsString()}
+	overdraft := getDummyOpenOverdraft()
+	accrued := overdraft.Accrue(2, 2)
+	expectedInterest := overdraft.ToInterest(2, clock.StaticClock{})
+	account := getDummyOverdrawnAccount()
+	mockOverdraftAccountRepo.EXPECT().FindById(dummyAccountId).Return(&account, nil)
+	mockOverdraftRepo.EXPECT().FindOpen(dummyAccountId).Return(&overdraft, nil)
+	gomock.InOrder(
+		mockOverdraftRepo.EXPECT().Update(accrued, dummyTwoDaysAgo).Return(nil),
+		mockOverdraftAccountRepo.EXPECT().Transact(expectedInterest).Return(&expectedInterest, nil),
+		mockOverdraftRepo.EXPECT().Update(accrued.Close(clock.StaticClock{}), accrued.InterestAccruedOn).Return(nil),
+	)
+
+	//Act
+	overdraftSvc.ChargeForTransaction(deposit)
+}
+
+func TestDefaultOverdraftService_ChargeForTransaction_does_nothing_when_account_saving(t *testing.T) {
+	//Arrange
+	teardown := setupOverdraftServiceTest(t)
+	defer teardown()
+
+	fee := domain.Transaction{AccountId: dummyAccountId, Amount: 5, Balance: -5,
+		TransactionType: dto.TransactionTypeFee, TransactionDate: clock.StaticClock{}.NowAsString()}
+	account := getDummyOverdrawnAccount()
+	account.AccountType = dto.AccountTypeSaving
+	mockOverdraftAccountRepo.EXPECT().FindById(dummyAccountId).Return(&account, nil)
+	mockOverdraftRepo.EXPECT().FindOpen(gomock.Any()).Times(0)
+	mockOverdraftRepo.EXPECT().Save(gomock.Any()).Times(0)
+	mockOverdraftAccountRepo.EXPECT().Transact(gomock.Any()).Times(0)
+
+	//Act
+	overdraftSvc.ChargeForTransaction(fee)
+}
+
+func TestDefaultOverdraftService_ChargeForTransaction_does_nothing_when_balance_stays_on_sameSide_of_zero(t *testing.T) {
+	//Arrange
+	teardown := setupOverdraftServiceTest(t)
+	defer teardown()
+
+	withdrawal := domain.Transaction{AccountId: dummyAccountId, Amount: 100, Balance: -1100,
+		TransactionType: dto.TransactionTypeWithdrawal, TransactionDate: clock.StaticClock{}.NowAsString()}
+	mockOverdraftRepo.EXPECT().FindOpen(gomock.Any()).Times(0)
+	mockOverdraftAccountRepo.EXPECT().Transact(gomock.Any()).Times(0)
+
+	//Act
+	overdraftSvc.ChargeForTransaction(withdrawal)
+}
+
+func TestDefaultOverdraftService_ChargeInterest_charges_overdrawnAccounts_and_closes_repaidOnes(t *testing.T) {
+	//Arrange
+	teardown := setupOverdraftServiceTest(t)
+	defer teardown()
+
+	overdrawn := getDummyOpenOverdraft()
+	repaid := getDummyOpenOverdraft()
+	repaid.OverdraftId = "4"
+	repaid.AccountId = "1978"
+	overdrawnAccount := getDummyOverdrawnAccount()
+	repaidAccount := getDummyOverdrawnAccount()
+	repaidAccount.AccountId = "1978"
+	repaidAccount.Amount = 10
+	mockOverdraftRepo.EXPECT().FindAllOpen().Return([]domain.Overdraft{overdrawn, repaid}, nil)
+	mockOverdraftAccountRepo.EXPECT().FindById(dummyAccountId).Return(&overdrawnAccount, nil)
+	mockOverdraftAccountRepo.EXPECT().FindById("1978").Return(&repaidAccount, nil)
+	mockOverdraftRepo.EXPECT().Update(overdrawn.Accrue(2, 2), dummyTwoDaysAgo).Return(nil)
+	mockOverdraftAccountRepo.EXPECT().Transact(overdrawn.ToInterest(2, clock.StaticClock{})).Return(&domain.Transaction{}, nil)
+	mockOverdraftRepo.EXPECT().Update(repaid.Close(clock.StaticClock{}), dummyTwoDaysAgo).Return(nil)
+
+	//Act
+	report, err := overdraftSvc.ChargeInterest()
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing daily overdraft interest: " + err.Message)
+	}
+	if report.AccountsCharged != 1 || report.InterestCharged != 2 || report.OverdraftsClosed != 1 {
+		t.Errorf("Expected 1 account charged 2.00 and 1 overdraft closed but got %v", *report)
+	}
+}
+
+func TestDefaultOverdraftService_ChargeInterest_puts_overdraft_back_when_interest_posting_fails(t *testing.T) {
+	//Arrange
+	teardown := setupOverdraftServiceTest(t)
+	defer teardown()
+
+	overdraft := getDummyOpenOverdraft()
+	account := getDummyOverdrawnAccount()
+	accrued := overdraft.Accrue(2, 2)
+	mockOverdraftRepo.EXPECT().FindAllOpen().Return([]domain.Overdraft{overdraft}, nil)
+	mockOverdraftAccountRepo.EXPECT().FindById(dummyAccountId).Return(&account, nil)
+	gomock.InOrder(
+		mockOverdraftRepo.EXPECT().Update(accrued, dummyTwoDaysAgo).Return(nil),
+		mockOverdraftAccountRepo.EXPECT().Transact(gomock.Any()).Return(nil, errs.NewUnexpectedError("Unexpected database error")),
+		mockOverdraftRepo.EXPECT().Update(overdraft, accrued.InterestAccruedOn).Return(nil),
+	)
+
+	//Act
+	report, err := overdraftSvc.ChargeInterest()
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing daily overdraft interest: " + err.Message)
+	}
+	if report.AccountsCharged != 0 || report.InterestCharged != 0 {
+		t.Errorf("Expected no interest to be reported as charged but got %v", *report)
+	}
+}
+
+func TestDefaultOverdraftService_with_stubRepo_charges_fee_once_per_overdraft(t *testing.T) {
+	//Arrange
+	accountRepo := domain.NewAccountRepositoryStub()
+	overdraftRepo := domain.NewOverdraftRepositoryStub()
+	stubSvc := NewOverdraftService(overdraftRepo, accountRepo, domain.NewHoldRepositoryStub(), dummyOverdraftTerms, clock.StaticClock{})
+	withdraw := func(amount float64) {
+		posted, _ := accountRepo.Transact(domain.NewTransaction("95471", amount, dto.TransactionTypeWithdrawal, clock.StaticClock{}))
+		stubSvc.ChargeForTransaction(*posted)
+	}
+
+	//Act
+	withdraw(3400) //3342.96 - 3400 = -57.04, overdrawn
+	withdraw(100)  //further overdrawn, no fee
+
+	//Assert
+	account, _ := accountRepo.FindById("95471")
+	if math.Round(account.Amount*100) != -18204 {
+		t.Errorf("Expected balance -182.04 after two withdrawals and one fee but got %.2f", account.Amount)
+	}
+	overdraft, err := overdraftRepo.FindOpen("95471")
+	if err != nil {
+		t.Fatal("Expected overdraft to be open but got error: " + err.Message)
+	}
+	if overdraft.FeeCharged != dummyOverdraftTerms.Fee {
+		t.Errorf("Expected fee of %.2f to be recorded but got %.2f", dummyOverdraftTerms.Fee, overdraft.FeeCharged)
+	}
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/problem"
+)
+
+//go:generate mockgen -destination=../mocks/service/mock_payeeService.go -package=service github.com/aliciatay-zls/banking/backend/service PayeeService
+type PayeeService interface { //service (primary port)
+	GetPayees(string) ([]dto.PayeeResponse, *errs.AppError)
+	AddPayee(dto.NewPayeeRequest) (*dto.PayeeResponse, *errs.AppError)
+	RenamePayee(dto.PayeeNicknameRequest) (*dto.PayeeResponse, *errs.AppError)
+	DeletePayee(string, string, string) *errs.AppError
+	GetAuditTrail(string) ([]dto.AuditEntryResponse, *errs.AppError)
+}
+
+type DefaultPayeeService struct { //business/domain object
+	repo         domain.PayeeRepository
+	accountRepo  domain.AccountRepository
+	customerRepo domain.CustomerRepository
+	terms        domain.PayeeTerms
+	clk          clock.Clock
+}
+
+func NewPayeeService(repo domain.PayeeRepository, accountRepo domain.AccountRepository, customerRepo domain.CustomerRepository, terms domain.PayeeTerms, clk clock.Clock) DefaultPayeeService {
+	return DefaultPayeeService{repo, accountRepo, customerRepo, terms, clk}
+}
+
+// GetPayees returns the payee book of the given customer, in the order the payees were added.
+func (s DefaultPayeeService) GetPayees(customerId string) ([]dto.PayeeResponse, *errs.AppError) {
+	payees, appErr := s.repo.FindAll(customerId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	response := make([]dto.PayeeResponse, 0, len(payees))
+	for _, p := range payees {
+		response = append(response, p.ToDTO(s.terms, s.clk))
+	}
+	return response, nil
+}
+
+// AddPayee saves the account in the given request to the payee book of the customer, after checking that the account
+// exists, that it is not already a payee of the customer and, if an owner name is given, that it is the name of the
+// customer who owns the account. The new payee has to be confirmed on the first transfer to it.
+func (s DefaultPayeeService) AddPayee(request dto.NewPayeeRequest) (*dto.PayeeResponse, *errs.AppError) {
+	account, appErr := s.accountRepo.FindById(request.AccountId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	payees, appErr := s.repo.FindAll(request.CustomerId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	for _, p := range payees {
+		if p.AccountId == request.AccountId {
+			logger.Error("Account " + request.AccountId + " is already payee " + p.PayeeId + " of customer " + request.CustomerId)
+			return nil, problem.NewConflictError(problem.PayeeExists, "This account is already in the payee book.")
+		}
+	}
+
+	if request.OwnerName != "" {
+		owner, appErr := s.customerRepo.FindById(account.CustomerId)
+		if appErr != nil {
+			return nil, appErr
+		}
+		if !domain.OwnerNameMatches(request.OwnerName, owner.Name) {
+			logger.Error("Owner name given for payee does not match the owner of account " + request.AccountId)
+			return nil, problem.NewValidationError(problem.PayeeOwnerMismatch,
+				"The owner name does not match the owner of the account.")
+		}
+		request.OwnerName = owner.Name
+	}
+
+	payee, appErr := s.repo.Save(domain.NewPayee(request, s.clk), request.CreatedBy)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	response := payee.ToDTO(s.terms, s.clk)
+	return &response, nil
+}
+
+// RenamePayee gives the payee in the given request a new nickname.
+func (s DefaultPayeeService) RenamePayee(request dto.PayeeNicknameRequest) (*dto.PayeeResponse, *errs.AppError) {
+	payee, appErr := findPayeeOf(s.repo, request.CustomerId, request.PayeeId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if appErr = s.repo.UpdateNickname(*payee, request.Nickname, request.ChangedBy, s.clk.NowAsString()); appErr != nil {
+		return nil, appErr
+	}
+	payee.Nickname = request.Nickname
+
+	response := payee.ToDTO(s.terms, s.clk)
+	return &response, nil
+}
+
+// DeletePayee removes the payee with the given id from the payee book of the customer with the given id, on behalf of
+// the given user.
+func (s DefaultPayeeService) DeletePayee(customerId string, payeeId string, deletedBy string) *errs.AppError {
+	payee, appErr := findPayeeOf(s.repo, customerId, payeeId)
+	if appErr != nil {
+		return appErr
+	}
+
+	return s.repo.Delete(*payee, deletedBy, s.clk.NowAsString())
+}
+
+// GetAuditTrail returns the changes made to the payee book of the given customer, newest first.
+func (s DefaultPayeeService) GetAuditTrail(customerId string) ([]dto.AuditEntryResponse, *errs.AppError) {
+	entries, appErr := s.repo.FindAuditTrail(customerId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	response := make([]dto.AuditEntryResponse, 0, len(entries))
+	for _, e := range entries {
+		response = append(response, e.ToDTO())
+	}
+	return response, nil
+}
+
+// findPayeeOf returns the payee with the given id if it belongs to the customer with the given id. The payees of other
+// customers are reported as not found.
+func findPayeeOf(repo domain.PayeeRepository, customerId string, payeeId string) (*domain.Payee, *errs.AppError) {
+	payee, appErr := repo.FindById(payeeId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if payee.CustomerId != customerId {
+		logger.Error("Payee " + payeeId + " does not belong to customer " + customerId)
+		return nil, problem.NewNotFoundError(problem.PayeeNotFound, "Payee not found")
+	}
+	return payee, nil
+}
@@ -0,0 +1,207 @@
+package service
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	mocksDomain "github.com/aliciatay-zls/banking/backend/mocks/domain"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// Test common variables and inputs
+var mockPayeeRepo *mocksDomain.MockPayeeRepository
+var mockPayeeAccountRepo *mocksDomain.MockAccountRepository
+var mockPayeeCustomerRepo *mocksDomain.MockCustomerRepository
+var payeeSvc DefaultPayeeService
+
+var dummyPayeeTerms = domain.PayeeTerms{CoolingOffPeriod: 24 * time.Hour, CoolingOffLimit: 1000}
+
+const dummyPayeeId = "3"
+const dummyPayeeAccountId = "95470"
+
+func setupPayeeServiceTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	mockPayeeRepo = mocksDomain.NewMockPayeeRepository(ctrl)
+	mockPayeeAccountRepo = mocksDomain.NewMockAccountRepository(ctrl)
+	mockPayeeCustomerRepo = mocksDomain.NewMockCustomerRepository(ctrl)
+	payeeSvc = NewPayeeService(mockPayeeRepo, mockPayeeAccountRepo, mockPayeeCustomerRepo, dummyPayeeTerms, clock.StaticClock{})
+	logger.MuteLogger()
+
+	return func() {
+		mockPayeeRepo = nil
+		mockPayeeAccountRepo = nil
+		mockPayeeCustomerRepo = nil
+		defer ctrl.Finish()
+	}
+}
+
+// getDummyPayee returns the payee with id 3 of the customer with id 2, added at the current time and not yet confirmed.
+func getDummyPayee() domain.Payee {
+	return domain.Payee{
+		PayeeId:    dummyPayeeId,
+		CustomerId: dummyCustomerId,
+		Nickname:   "Mum",
+		AccountId:  dummyPayeeAccountId,
+		CreatedOn:  clock.StaticClock{}.NowAsString(),
+	}
+}
+
+func getDummyNewPayeeRequest() dto.NewPayeeRequest {
+	return dto.NewPayeeRequest{CustomerId: dummyCustomerId, Nickname: "Mum", AccountId: dummyPayeeAccountId, CreatedBy: "2"}
+}
+
+func TestDefaultPayeeService_AddPayee_returns_conflictError_when_account_already_payee(t *testing.T) {
+	//Arrange
+	teardown := setupPayeeServiceTest(t)
+	defer teardown()
+
+	mockPayeeAccountRepo.EXPECT().FindById(dummyPayeeAccountId).Return(&domain.Account{AccountId: dummyPayeeAccountId, CustomerId: "1"}, nil)
+	mockPayeeRepo.EXPECT().FindAll(dummyCustomerId).Return([]domain.Payee{getDummyPayee()}, nil)
+	mockPayeeRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(0)
+
+	//Act
+	_, err := payeeSvc.AddPayee(getDummyNewPayeeRequest())
+
+	//Assert
+	if err == nil || err.Code != http.StatusConflict {
+		t.Errorf("Expected conflict error but got %v", err)
+	}
+}
+
+func TestDefaultPayeeService_AddPayee_returns_validationError_when_ownerName_does_not_match(t *testing.T) {
+	//Arrange
+	teardown := setupPayeeServiceTest(t)
+	defer teardown()
+
+	request := getDummyNewPayeeRequest()
+	request.OwnerName = "Steve Jobs"
+	mockPayeeAccountRepo.EXPECT().FindById(dummyPayeeAccountId).Return(&domain.Account{AccountId: dummyPayeeAccountId, CustomerId: "1"}, nil)
+	mockPayeeRepo.EXPECT().FindAll(dummyCustomerId).Return([]domain.Payee{}, nil)
+	mockPayeeCustomerRepo.EXPECT().FindById("1").Return(&domain.Customer{Id: "1", Name: "Steve Wozniak"}, nil)
+	mockPayeeRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(0)
+
+	//Act
+	_, err := payeeSvc.AddPayee(request)
+
+	//Assert
+	if err == nil || err.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("Expected validation error but got %v", err)
+	}
+	if err.Message != "The owner name does not match the owner of the account." {
+		t.Errorf("Unexpected error message \"%s\"", err.Message)
+	}
+}
+
+func TestDefaultPayeeService_AddPayee_saves_payee_with_verified_ownerName(t *testing.T) {
+	//Arrange
+	teardown := setupPayeeServiceTest(t)
+	defer teardown()
+
+	request := getDummyNewPayeeRequest()
+	request.OwnerName = "steve  wozniak"
+	mockPayeeAccountRepo.EXPECT().FindById(dummyPayeeAccountId).Return(&domain.Account{AccountId: dummyPayeeAccountId, CustomerId: "1"}, nil)
+	mockPayeeRepo.EXPECT().FindAll(dummyCustomerId).Return([]domain.Payee{}, nil)
+	mockPayeeCustomerRepo.EXPECT().FindById("1").Return(&domain.Customer{Id: "1", Name: "Steve Wozniak"}, nil)
+	payee := getDummyPayee()
+	payee.PayeeId = ""
+	payee.OwnerName = "Steve Wozniak"
+	savedPayee := payee
+	savedPayee.PayeeId = dummyPayeeId
+	mockPayeeRepo.EXPECT().Save(payee, "2").Return(&savedPayee, nil)
+
+	//Act
+	response, err := payeeSvc.AddPayee(request)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing adding payee: " + err.Message)
+	}
+	if response.PayeeId != dummyPayeeId || response.OwnerName != "Steve Wozniak" || response.Status != dto.PayeeStatusPendingConfirmation {
+		t.Errorf("Expected payee %s of Steve Wozniak pending confirmation but got %v", dummyPayeeId, *response)
+	}
+	if response.CoolingOffUntil != "2006-01-03 15:04:05" {
+		t.Errorf("Expected cooling-off until 2006-01-03 15:04:05 but got \"%s\"", response.CoolingOffUntil)
+	}
+}
+
+func TestDefaultPayeeService_RenamePayee_returns_notFoundError_when_payee_of_otherCustomer(t *testing.T) {
+	//Arrange
+	teardown := setupPayeeServiceTest(t)
+	defer teardown()
+
+	payee := getDummyPayee()
+	payee.CustomerId = "1"
+	mockPayeeRepo.EXPECT().FindById(dummyPayeeId).Return(&payee, nil)
+	mockPayeeRepo.EXPECT().UpdateNickname(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	//Act
+	_, err := payeeSvc.RenamePayee(dto.PayeeNicknameRequest{CustomerId: dummyCustomerId, PayeeId: dummyPayeeId, Nickname: "Mother"})
+
+	//Assert
+	if err == nil || err.Code != http.StatusNotFound || err.Message != "Payee not found" {
+		t.Errorf("Expected payee not found error but got %v", err)
+	}
+}
+
+func TestDefaultPayeeService_RenamePayee_returns_payee_with_newNickname(t *testing.T) {
+	//Arrange
+	teardown := setupPayeeServiceTest(t)
+	defer teardown()
+
+	payee := getDummyPayee()
+	mockPayeeRepo.EXPECT().FindById(dummyPayeeId).Return(&payee, nil)
+	mockPayeeRepo.EXPECT().UpdateNickname(getDummyPayee(), "Mother", "2", clock.StaticClock{}.NowAsString()).Return(nil)
+
+	//Act
+	response, err := payeeSvc.RenamePayee(dto.PayeeNicknameRequest{CustomerId: dummyCustomerId, PayeeId: dummyPayeeId, Nickname: "Mother", ChangedBy: "2"})
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing renaming payee: " + err.Message)
+	}
+	if response.Nickname != "Mother" {
+		t.Errorf("Expected nickname Mother but got %s", response.Nickname)
+	}
+}
+
+func TestDefaultPayeeService_DeletePayee_deletes_payee_on_behalf_of_user(t *testing.T) {
+	//Arrange
+	teardown := setupPayeeServiceTest(t)
+	defer teardown()
+
+	payee := getDummyPayee()
+	mockPayeeRepo.EXPECT().FindById(dummyPayeeId).Return(&payee, nil)
+	mockPayeeRepo.EXPECT().Delete(payee, "2", clock.StaticClock{}.NowAsString()).Return(nil)
+
+	//Act
+	err := payeeSvc.DeletePayee(dummyCustomerId, dummyPayeeId, "2")
+
+	//Assert
+	if err != nil {
+		t.Error("Expected no error but got error while testing deleting payee: " + err.Message)
+	}
+}
+
+func TestDefaultPayeeService_GetAuditTrail_returns_entries_of_customer(t *testing.T) {
+	//Arrange
+	teardown := setupPayeeServiceTest(t)
+	defer teardown()
+
+	entry := domain.NewPayeeAuditEntry(getDummyPayee(), domain.AuditActionAdded, "\"Mum\" (account 95470)", "2", clock.StaticClock{}.NowAsString())
+	mockPayeeRepo.EXPECT().FindAuditTrail(dummyCustomerId).Return([]domain.AuditEntry{entry}, nil)
+
+	//Act
+	response, err := payeeSvc.GetAuditTrail(dummyCustomerId)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing retrieving audit trail: " + err.Message)
+	}
+	if len(response) != 1 || response[0] != entry.ToDTO() {
+		t.Errorf("Expected entry %v but got %v", entry.ToDTO(), response)
+	}
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+)
+
+//go:generate mockgen -destination=../mocks/service/mock_reconciliationService.go -package=service github.com/aliciatay-zls/banking/backend/service ReconciliationService
+type ReconciliationService interface { //service (primary port)
+	Reconcile(bool) (*dto.ReconciliationReportResponse, *errs.AppError)
+	FreezeAccounts([]string) *errs.AppError
+}
+
+type DefaultReconciliationService struct { //business/domain object
+	repo domain.ReconciliationRepository
+	clk  clock.Clock
+}
+
+func NewReconciliationService(repo domain.ReconciliationRepository, clk clock.Clock) DefaultReconciliationService {
+	return DefaultReconciliationService{repo, clk}
+}
+
+// Reconcile recomputes the expected balance of every account from its opening amount and transaction history, and
+// reports the accounts whose stored balance does not match. Accounts without an opening amount cannot be checked and
+// are reported separately as unreconciled, without being counted as checked or frozen. If freezeMismatched is true, the mismatched accounts that
+// are not yet frozen are frozen so that no further transactions can be made on them until they have been reviewed.
+func (s DefaultReconciliationService) Reconcile(freezeMismatched bool) (*dto.ReconciliationReportResponse, *errs.AppError) {
+	summaries, appErr := s.repo.FindAllBalanceSummaries()
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	report := dto.ReconciliationReportResponse{
+		RunOn:        s.clk.NowAsString(),
+		Mismatches:   make([]dto.ReconciliationMismatchResponse, 0),
+		Unreconciled: make([]dto.ReconciliationUnreconciledResponse, 0),
+	}
+	toFreeze := make([]string, 0)
+	for _, summary := range summaries {
+		if !summary.IsReconcilable() {
+			logger.Info("Account " + summary.AccountId + " has no opening amount so its balance cannot be reconciled")
+			report.Unreconciled = append(report.Unreconciled, summary.ToUnreconciledDTO())
+			continue
+		}
+		report.AccountsChecked++
+		if !summary.IsMismatched() {
+			continue
+		}
+		logger.Error(fmt.Sprintf("Balance mismatch for account %s: stored %.2f, expected %.2f",
+			summary.AccountId, summary.StoredBalance, summary.ExpectedBalance()))
+		report.Mismatches = append(report.Mismatches, summary.ToMismatchDTO())
+		if summary.Status != domain.AccountStatusFrozen {
+			toFreeze = append(toFreeze, summary.AccountId)
+		}
+	}
+
+	if freezeMismatched && len(toFreeze) > 0 {
+		if appErr = s.repo.FreezeAccounts(toFreeze, report.RunOn); appErr != nil {
+			return nil, appErr
+		}
+		for i := range report.Mismatches {
+			report.Mismatches[i].IsFrozen = true
+		}
+	}
+
+	return &report, nil
+}
+
+// FreezeAccounts freezes the accounts with the given ids, e.g. those found mismatched by a reconciliation run whose
+// freeze was approved, so that no further transactions can be made on them until they have been reviewed.
+func (s DefaultReconciliationService) FreezeAccounts(accountIds []string) *errs.AppError {
+	return s.repo.FreezeAccounts(accountIds, s.clk.NowAsString())
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"database/sql"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	mocksDomain "github.com/aliciatay-zls/banking/backend/mocks/domain"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockReconciliationRepo *mocksDomain.MockReconciliationRepository
+var reconSvc DefaultReconciliationService
+
+func setupReconciliationServiceTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	mockReconciliationRepo = mocksDomain.NewMockReconciliationRepository(ctrl)
+	reconSvc = NewReconciliationService(mockReconciliationRepo, clock.StaticClock{})
+
+	return func() {
+		mockReconciliationRepo = nil
+		defer ctrl.Finish()
+	}
+}
+
+// getDummyBalanceSummaries returns one balanced account, one account whose stored balance is 100 too high, one
+// already-frozen account whose stored balance is 50 too low and one legacy account without an opening amount.
+func getDummyBalanceSummaries() []domain.AccountBalanceSummary {
+	openingAmount := sql.NullFloat64{Float64: 6000, Valid: true}
+	return []domain.AccountBalanceSummary{
+		{AccountId: "1977", Status: domain.AccountStatusActive, OpeningAmount: openingAmount, StoredBalance: 6500, TotalDeposits: 500},
+		{AccountId: "1978", Status: domain.AccountStatusActive, OpeningAmount: openingAmount, StoredBalance: 6100},
+		{AccountId: "1979", Status: domain.AccountStatusFrozen, OpeningAmount: openingAmount, StoredBalance: 5950},
+		{AccountId: "1980", Status: domain.AccountStatusActive, StoredBalance: 3000},
+	}
+}
+
+func TestDefaultReconciliationService_Reconcile_returns_error_when_repo_fails(t *testing.T) {
+	//Arrange
+	teardown := setupReconciliationServiceTest(t)
+	defer teardown()
+
+	dummyAppErr := errs.NewUnexpectedError("some error message")
+	mockReconciliationRepo.EXPECT().FindAllBalanceSummaries().Return(nil, dummyAppErr)
+
+	//Act
+	_, err := reconSvc.Reconcile(false)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing failure retrieving balance summaries")
+	}
+	if err.Message != dummyAppErr.Message {
+		t.Errorf("Expected error message to be \"%s\" but got \"%s\"", dummyAppErr.Message, err.Message)
+	}
+}
+
+func TestDefaultReconciliationService_Reconcile_returns_mismatches_without_freezing_when_freeze_false(t *testing.T) {
+	//Arrange
+	teardown := setupReconciliationServiceTest(t)
+	defer teardown()
+
+	mockReconciliationRepo.EXPECT().FindAllBalanceSummaries().Return(getDummyBalanceSummaries(), nil)
+	mockReconciliationRepo.EXPECT().FreezeAccounts(gomock.Any(), gomock.Any()).Times(0)
+
+	//Act
+	report, err := reconSvc.Reconcile(false)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing reconciliation: " + err.Message)
+	}
+	if report.AccountsChecked != 3 {
+		t.Errorf("Expected 3 accounts to be checked but got %d", report.AccountsChecked)
+	}
+	if len(report.Mismatches) != 2 {
+		t.Fatalf("Expected 2 mismatches but got %d", len(report.Mismatches))
+	}
+	if report.Mismatches[0].AccountId != "1978" || report.Mismatches[0].Delta != 100 || report.Mismatches[0].IsFrozen {
+		t.Errorf("Expected unfrozen mismatch of 100 on account 1978 but got %+v", report.Mismatches[0])
+	}
+	if report.Mismatches[1].Delta != -50 || !report.Mismatches[1].IsFrozen {
+		t.Errorf("Expected frozen mismatch of -50 on account 1979 but got %+v", report.Mismatches[1])
+	}
+	if len(report.Unreconciled) != 1 || report.Unreconciled[0].AccountId != "1980" {
+		t.Errorf("Expected account 1980 to be reported as unreconciled but got %+v", report.Unreconciled)
+	}
+}
+
+func TestDefaultReconciliationService_Reconcile_freezes_unfrozenMismatches_when_freeze_true(t *testing.T) {
+	//Arrange
+	teardown := setupReconciliationServiceTest(t)
+	defer teardown()
+
+	mockReconciliationRepo.EXPECT().FindAllBalanceSummaries().Return(getDummyBalanceSummaries(), nil)
+	mockReconciliationRepo.EXPECT().FreezeAccounts([]string{"1978"}, clock.StaticClock{}.NowAsString()).Return(nil)
+
+	//Act
+	report, err := reconSvc.Reconcile(true)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing reconciliation with freezing: " + err.Message)
+	}
+	for _, m := range report.Mismatches {
+		if !m.IsFrozen {
+			t.Errorf("Expected account %s to be frozen but was not", m.AccountId)
+		}
+	}
+	if len(report.Unreconciled) != 1 || report.Unreconciled[0].IsFrozen {
+		t.Errorf("Expected unreconciled account not to be frozen but got %+v", report.Unreconciled)
+	}
+}
+
+func TestDefaultReconciliationService_FreezeAccounts_freezes_givenAccounts_only(t *testing.T) {
+	//Arrange
+	teardown := setupReconciliationServiceTest(t)
+	defer teardown()
+
+	mockReconciliationRepo.EXPECT().FindAllBalanceSummaries().Times(0)
+	mockReconciliationRepo.EXPECT().FreezeAccounts([]string{"1978", "1980"}, clock.StaticClock{}.NowAsString()).Return(nil)
+
+	//Act
+	err := reconSvc.FreezeAccounts([]string{"1978", "1980"})
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing freezing of given accounts: " + err.Message)
+	}
+}
@@ -0,0 +1,243 @@
+package service
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/problem"
+	"strconv"
+	"strings"
+)
+
+//go:generate mockgen -destination=../mocks/service/mock_transactionImportService.go -package=service github.com/aliciatay-zls/banking/backend/service TransactionImportService
+type TransactionImportService interface { //service (primary port)
+	ImportTransactions(dto.TransactionImportRequest) (*dto.TransactionImportResponse, *errs.AppError)
+}
+
+type DefaultTransactionImportService struct { //business/domain object
+	accountRepo domain.AccountRepository
+	importRepo  domain.TransactionImportRepository
+	reviews     domain.TransactionReviewRepository
+	holds       domain.HoldRepository
+	clk         clock.Clock
+}
+
+func NewTransactionImportService(accountRepo domain.AccountRepository, importRepo domain.TransactionImportRepository, reviews domain.TransactionReviewRepository, holds domain.HoldRepository, clk clock.Clock) DefaultTransactionImportService {
+	return DefaultTransactionImportService{accountRepo, importRepo, reviews, holds, clk}
+}
+
+// ImportTransactions parses the uploaded CSV file and validates every row with the same rules as a single
+// transaction request, keeping a running balance per account so that withdrawals later in the file take earlier rows
+// into account. In dry-run mode, it only returns the per-row report. In commit mode, it posts all rows in one database
+// transaction if every row is valid and the file has not been imported before.
+func (s DefaultTransactionImportService) ImportTransactions(request dto.TransactionImportRequest) (*dto.TransactionImportResponse, *errs.AppError) {
+	records, appErr := parseTransactionImportFile(request.FileContent)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	hash := sha256.Sum256(request.FileContent)
+	fileHash := hex.EncodeToString(hash[:])
+	isDuplicate, appErr := s.importRepo.ExistsByFileHash(fileHash)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	response := dto.TransactionImportResponse{
+		FileHash:    fileHash,
+		Mode:        request.Mode,
+		IsDuplicate: isDuplicate,
+		TotalRows:   len(records),
+		Rows:        make([]dto.TransactionImportRowResponse, 0, len(records)),
+	}
+	entries := make([]domain.TransactionImportEntry, 0, len(records))
+	runningAccounts := map[string]*domain.Account{}
+
+	for i, record := range records {
+		rowNumber := i + 2 //row 1 is the header
+		row, entry := s.checkRow(rowNumber, record, runningAccounts)
+		if row.Status == dto.TransactionImportRowStatusInvalid {
+			response.InvalidRows++
+		} else {
+			entries = append(entries, *entry)
+		}
+		response.Rows = append(response.Rows, row)
+	}
+
+	if request.Mode == dto.TransactionImportModeDryRun || response.InvalidRows > 0 {
+		return &response, nil
+	}
+
+	if isDuplicate {
+		logger.Error("Transaction import file has already been imported: " + fileHash)
+		return nil, problem.NewConflictError(problem.ImportDuplicate, "This file has already been imported")
+	}
+
+	transactionImport := domain.TransactionImport{
+		FileHash:   fileHash,
+		RowCount:   len(entries),
+		ImportedOn: s.clk.NowAsString(),
+	}
+	savedImport, postedEntries, appErr := s.importRepo.Save(transactionImport, entries)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	response.ImportId = savedImport.ImportId
+	response.IsCommitted = true
+	for i, entry := range postedEntries {
+		response.Rows[i].Status = dto.TransactionImportRowStatusPosted
+		response.Rows[i].TransactionId = entry.Transaction.TransactionId
+	}
+
+	return &response, nil
+}
+
+// checkRow validates a single row of the file, first on its own with the same rules as a single transaction request
+// and then against the available balance of the account it refers to, which is looked up at most once and then kept
+// in runningAccounts with its balance updated by every valid row. A row whose account cannot be looked up, for
+// whatever reason, is invalid.
+func (s DefaultTransactionImportService) checkRow(rowNumber int, record []string, runningAccounts map[string]*domain.Account) (dto.TransactionImportRowResponse, *domain.TransactionImportEntry) {
+	row := dto.TransactionImportRowResponse{
+		Row:             rowNumber,
+		AccountId:       strings.TrimSpace(record[0]),
+		TransactionType: strings.TrimSpace(record[2]),
+		Reference:       strings.TrimSpace(record[3]),
+		Status:          dto.TransactionImportRowStatusInvalid,
+	}
+
+	amount, err := strconv.ParseFloat(strings.TrimSpace(record[1]), 64)
+	if err != nil {
+		row.Message = "Amount must be a number."
+		return row, nil
+	}
+	row.Amount = amount
+
+	if len(row.Reference) > dto.TransactionImportMaxReferenceLength {
+		row.Message = fmt.Sprintf("Reference must be at most %d characters.", dto.TransactionImportMaxReferenceLength)
+		return row, nil
+	}
+
+	transactionRequest := dto.TransactionRequest{
+		AccountId:       row.AccountId,
+		Amount:          row.Amount,
+		TransactionType: row.TransactionType,
+	}
+	if message := rowValidationMessage(transactionRequest.Validate()); message != "" {
+		row.Message = message
+		return row, nil
+	}
+
+	account, ok := runningAccounts[row.AccountId]
+	if !ok {
+		var appErr *errs.AppError
+		account, appErr = s.findAvailable(row.AccountId)
+		if appErr != nil {
+			row.Message = appErr.Message
+			return row, nil
+		}
+		runningAccounts[row.AccountId] = account
+	}
+
+	if account.IsFrozen() {
+		row.Message = "Account is frozen pending review"
+		return row, nil
+	}
+
+	transaction := domain.NewTransaction(row.AccountId, row.Amount, row.TransactionType, s.clk)
+	if transaction.IsWithdrawal() {
+		if !account.CanWithdraw(row.Amount) {
+			row.Message = "Account balance insufficient to withdraw given amount"
+			return row, nil
+		}
+		account.Amount -= row.Amount
+	} else {
+		account.Amount += row.Amount
+	}
+
+	row.Status = dto.TransactionImportRowStatusValid
+	return row, &domain.TransactionImportEntry{RowNumber: rowNumber, Reference: row.Reference, Transaction: transaction}
+}
+
+// findAvailable returns the account with the given id with the funds on hold and those reserved for withdrawals pending
+// review set aside, as neither can be withdrawn by an imported row.
+func (s DefaultTransactionImportService) findAvailable(accountId string) (*domain.Account, *errs.AppError) {
+	account, appErr := s.accountRepo.FindById(accountId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if appErr = applyHolds(s.holds, account, s.clk); appErr != nil {
+		return nil, appErr
+	}
+	reserved, appErr := s.reviews.FindReservedAmount(accountId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	account.HeldAmount += reserved
+	return account, nil
+}
+
+// rowValidationMessage returns the messages of the fields of a row in error, leaving out the customer, which a row
+// does not have and is only known once its account is found, or an empty string if there are none.
+func rowValidationMessage(valErr *dto.ValidationError) string {
+	if valErr == nil {
+		return ""
+	}
+	messages := make([]string, 0, len(valErr.Errors))
+	for _, fieldError := range valErr.Errors {
+		if fieldError.Field != "customer_id" {
+			messages = append(messages, fieldError.Message)
+		}
+	}
+	return strings.Join(messages, " ")
+}
+
+// parseTransactionImportFile reads the given CSV file content, checks the header row and the number of data rows,
+// and returns the data rows.
+func parseTransactionImportFile(content []byte) ([][]string, *errs.AppError) {
+	content = bytes.TrimPrefix(content, []byte("\xef\xbb\xbf")) //byte order mark added by some spreadsheet tools
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.FieldsPerRecord = len(dto.TransactionImportHeader)
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		logger.Error("Error while parsing transaction import file: " + err.Error())
+		return nil, problem.NewValidationError(problem.ImportFileInvalid,
+			fmt.Sprintf("Please check that the file is a valid CSV file with the columns %s.",
+				strings.Join(dto.TransactionImportHeader, ", ")))
+	}
+
+	if len(records) == 0 || !isTransactionImportHeader(records[0]) {
+		logger.Error("Transaction import file has a missing or unexpected header row")
+		return nil, problem.NewValidationError(problem.ImportFileInvalid,
+			fmt.Sprintf("The first row of the file should be the header %s.",
+				strings.Join(dto.TransactionImportHeader, ",")))
+	}
+
+	records = records[1:]
+	if len(records) == 0 || len(records) > dto.TransactionImportMaxRows {
+		logger.Error(fmt.Sprintf("Transaction import file has %d rows", len(records)))
+		return nil, problem.NewValidationError(problem.ImportFileInvalid,
+			fmt.Sprintf("The file should contain between 1 and %d transactions.",
+				dto.TransactionImportMaxRows))
+	}
+
+	return records, nil
+}
+
+func isTransactionImportHeader(record []string) bool {
+	for i, column := range dto.TransactionImportHeader {
+		if strings.ToLower(strings.TrimSpace(record[i])) != column {
+			return false
+		}
+	}
+	return true
+}
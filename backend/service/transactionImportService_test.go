@@ -0,0 +1,252 @@
+package service
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	mocksDomain "github.com/aliciatay-zls/banking/backend/mocks/domain"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockImportRepo *mocksDomain.MockTransactionImportRepository
+var importSvc DefaultTransactionImportService
+
+const dummyImportFile = "account_id,amount,type,reference\n" +
+	"1977,500,deposit,PAYROLL-1\n" +
+	"1977,6500,withdrawal,CORRECTION-1\n"
+
+func setupTransactionImportServiceTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	mockAccountRepo = mocksDomain.NewMockAccountRepository(ctrl)
+	mockImportRepo = mocksDomain.NewMockTransactionImportRepository(ctrl)
+	mockTransactionReviewRepo = mocksDomain.NewMockTransactionReviewRepository(ctrl)
+	mockHoldRepo = mocksDomain.NewMockHoldRepository(ctrl)
+	mockClock = clock.StaticClock{}
+	importSvc = NewTransactionImportService(mockAccountRepo, mockImportRepo, mockTransactionReviewRepo, mockHoldRepo, mockClock)
+
+	return func() {
+		mockAccountRepo = nil
+		mockImportRepo = nil
+		mockTransactionReviewRepo = nil
+		mockHoldRepo = nil
+		defer ctrl.Finish()
+	}
+}
+
+// getDefaultDummyImportAccount returns a domain.Account with id 1977 of amount 6000 belonging to the customer with
+// id 2.
+func getDefaultDummyImportAccount() *domain.Account {
+	account := domain.NewAccount(dummyCustomerId, dummyAccountType, dummyAmount, mockClock)
+	account.AccountId = dummyAccountId
+	return &account
+}
+
+// expectImportAccount expects the given account to be looked up once, with the given amounts on hold and reserved for
+// withdrawals pending review.
+func expectImportAccount(account *domain.Account, held float64, reserved float64) {
+	mockAccountRepo.EXPECT().FindById(account.AccountId).Return(account, nil)
+	mockHoldRepo.EXPECT().FindHeldAmount(account.AccountId, mockClock.NowAsString()).Return(held, nil)
+	mockTransactionReviewRepo.EXPECT().FindReservedAmount(account.AccountId).Return(reserved, nil)
+}
+
+func TestDefaultTransactionImportService_ImportTransactions_returns_error_when_header_missing(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionImportServiceTest(t)
+	defer teardown()
+
+	request := dto.TransactionImportRequest{Mode: dto.TransactionImportModeDryRun, FileContent: []byte("1977,500,deposit,REF\n")}
+
+	//Act
+	_, err := importSvc.ImportTransactions(request)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing file without header")
+	}
+	if err.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status code %d but got %d", http.StatusUnprocessableEntity, err.Code)
+	}
+}
+
+func TestDefaultTransactionImportService_ImportTransactions_returns_report_when_dryRun(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionImportServiceTest(t)
+	defer teardown()
+
+	request := dto.TransactionImportRequest{Mode: dto.TransactionImportModeDryRun, FileContent: []byte(dummyImportFile)}
+	mockImportRepo.EXPECT().ExistsByFileHash(gomock.Any()).Return(false, nil)
+	expectImportAccount(getDefaultDummyImportAccount(), 0, 0)
+
+	//Act
+	response, err := importSvc.ImportTransactions(request)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing dry run: " + err.Message)
+	}
+	if response.IsCommitted {
+		t.Error("Expected dry run not to be committed but it was")
+	}
+	if response.TotalRows != 2 || response.InvalidRows != 0 {
+		t.Errorf("Expected 2 rows with 0 invalid but got %d rows with %d invalid", response.TotalRows, response.InvalidRows)
+	}
+	for _, row := range response.Rows {
+		if row.Status != dto.TransactionImportRowStatusValid {
+			t.Errorf("Expected row %d to be valid but got status %s (%s)", row.Row, row.Status, row.Message)
+		}
+	}
+}
+
+func TestDefaultTransactionImportService_ImportTransactions_returns_uncommittedReport_when_row_invalid(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionImportServiceTest(t)
+	defer teardown()
+
+	invalidFile := "account_id,amount,type,reference\n" +
+		"1977,7000,withdrawal,TOO-MUCH\n" +
+		"1977,abc,deposit,NOT-A-NUMBER\n" +
+		"1980,100,deposit,NO-SUCH-ACCOUNT\n"
+	request := dto.TransactionImportRequest{Mode: dto.TransactionImportModeCommit, FileContent: []byte(invalidFile)}
+	mockImportRepo.EXPECT().ExistsByFileHash(gomock.Any()).Return(false, nil)
+	expectImportAccount(getDefaultDummyImportAccount(), 0, 0)
+	mockAccountRepo.EXPECT().FindById("1980").Return(nil, errs.NewNotFoundError("Account not found"))
+	mockImportRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(0)
+
+	//Act
+	response, err := importSvc.ImportTransactions(request)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing file with invalid rows: " + err.Message)
+	}
+	if response.IsCommitted {
+		t.Error("Expected import not to be committed but it was")
+	}
+	if response.InvalidRows != 3 {
+		t.Errorf("Expected 3 invalid rows but got %d", response.InvalidRows)
+	}
+}
+
+func TestDefaultTransactionImportService_ImportTransactions_checks_withdrawals_against_availableBalance(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionImportServiceTest(t)
+	defer teardown()
+
+	file := "account_id,amount,type,reference\n" +
+		"1977,5500,withdrawal,SPOKEN-FOR\n" +
+		"1978,6500,withdrawal,OVERDRAFT\n"
+	request := dto.TransactionImportRequest{Mode: dto.TransactionImportModeDryRun, FileContent: []byte(file)}
+	checking := getDefaultDummyImportAccount()
+	checking.AccountId = "1978"
+	checking.AccountType = dto.AccountTypeChecking
+	checking.OverdraftLimit = 1000
+	mockImportRepo.EXPECT().ExistsByFileHash(gomock.Any()).Return(false, nil)
+	expectImportAccount(getDefaultDummyImportAccount(), 400, 200) //only 5400 of 6000 available
+	expectImportAccount(checking, 0, 0)
+	expectedStatuses := []string{dto.TransactionImportRowStatusInvalid, dto.TransactionImportRowStatusValid}
+
+	//Act
+	response, err := importSvc.ImportTransactions(request)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing withdrawals against available balance: " + err.Message)
+	}
+	for i, expected := range expectedStatuses {
+		if response.Rows[i].Status != expected {
+			t.Errorf("Expected row %d to be %s but got %s (%s)", response.Rows[i].Row, expected, response.Rows[i].Status,
+				response.Rows[i].Message)
+		}
+	}
+}
+
+func TestDefaultTransactionImportService_ImportTransactions_marks_row_invalid_when_account_cannot_be_looked_up(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionImportServiceTest(t)
+	defer teardown()
+
+	invalidFile := "account_id,amount,type,reference\n" +
+		"ACC-1,100,deposit,NOT-A-NUMBER\n" +
+		"1980,100,deposit,DB-DOWN\n"
+	request := dto.TransactionImportRequest{Mode: dto.TransactionImportModeDryRun, FileContent: []byte(invalidFile)}
+	mockImportRepo.EXPECT().ExistsByFileHash(gomock.Any()).Return(false, nil)
+	mockAccountRepo.EXPECT().FindById("ACC-1").Times(0) //not looked up as the id is not a number
+	mockAccountRepo.EXPECT().FindById("1980").Return(nil, errs.NewUnexpectedError("Unexpected database error"))
+	expectedMessages := []string{"Account ID must be a number.", "Unexpected database error"}
+
+	//Act
+	response, err := importSvc.ImportTransactions(request)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing rows whose account cannot be looked up: " + err.Message)
+	}
+	if response.InvalidRows != 2 {
+		t.Fatalf("Expected 2 invalid rows but got %d", response.InvalidRows)
+	}
+	for i, expected := range expectedMessages {
+		if response.Rows[i].Message != expected {
+			t.Errorf("Expected row %d to have message \"%s\" but got \"%s\"", response.Rows[i].Row, expected, response.Rows[i].Message)
+		}
+	}
+}
+
+func TestDefaultTransactionImportService_ImportTransactions_returns_error_when_file_previouslyCommitted(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionImportServiceTest(t)
+	defer teardown()
+
+	request := dto.TransactionImportRequest{Mode: dto.TransactionImportModeCommit, FileContent: []byte(dummyImportFile)}
+	mockImportRepo.EXPECT().ExistsByFileHash(gomock.Any()).Return(true, nil)
+	expectImportAccount(getDefaultDummyImportAccount(), 0, 0)
+	mockImportRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(0)
+
+	//Act
+	_, err := importSvc.ImportTransactions(request)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing re-upload of committed file")
+	}
+	if err.Code != http.StatusConflict {
+		t.Errorf("Expected status code %d but got %d", http.StatusConflict, err.Code)
+	}
+}
+
+func TestDefaultTransactionImportService_ImportTransactions_returns_committedReport_when_repo_succeeds(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionImportServiceTest(t)
+	defer teardown()
+
+	request := dto.TransactionImportRequest{Mode: dto.TransactionImportModeCommit, FileContent: []byte(dummyImportFile)}
+	mockImportRepo.EXPECT().ExistsByFileHash(gomock.Any()).Return(false, nil)
+	expectImportAccount(getDefaultDummyImportAccount(), 0, 0)
+	mockImportRepo.EXPECT().Save(gomock.Any(), gomock.Len(2)).
+		DoAndReturn(func(i domain.TransactionImport, entries []domain.TransactionImportEntry) (*domain.TransactionImport, []domain.TransactionImportEntry, *errs.AppError) {
+			i.ImportId = "11"
+			for k := range entries {
+				entries[k].Transaction.TransactionId = dummyTransactionId
+			}
+			return &i, entries, nil
+		})
+
+	//Act
+	response, err := importSvc.ImportTransactions(request)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing successful import: " + err.Message)
+	}
+	if !response.IsCommitted || response.ImportId != "11" {
+		t.Errorf("Expected import 11 to be committed but got %+v", response)
+	}
+	for _, row := range response.Rows {
+		if row.Status != dto.TransactionImportRowStatusPosted || row.TransactionId != dummyTransactionId {
+			t.Errorf("Expected row %d to be posted as transaction %s but got %+v", row.Row, dummyTransactionId, row)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/problem"
+)
+
+//go:generate mockgen -destination=../mocks/service/mock_transactionReversalService.go -package=service github.com/aliciatay-zls/banking/backend/service TransactionReversalService
+type TransactionReversalService interface { //service (primary port)
+	GetReversibleTransaction(string) (*dto.AccountTransactionResponse, *errs.AppError)
+	Reverse(dto.TransactionReversalRequest) (*dto.TransactionReversalResponse, *errs.AppError)
+}
+
+type DefaultTransactionReversalService struct { //business/domain object
+	repo        domain.TransactionReversalRepository
+	accountRepo domain.AccountRepository
+	reviews     domain.TransactionReviewRepository
+	holds       domain.HoldRepository
+	alerts      AlertService
+	overdrafts  OverdraftService
+	clk         clock.Clock
+}
+
+func NewTransactionReversalService(repo domain.TransactionReversalRepository, accountRepo domain.AccountRepository, reviews domain.TransactionReviewRepository, holds domain.HoldRepository, alerts AlertService, overdrafts OverdraftService, clk clock.Clock) DefaultTransactionReversalService {
+	return DefaultTransactionReversalService{repo, accountRepo, reviews, holds, alerts, overdrafts, clk}
+}
+
+// GetReversibleTransaction returns the posted transaction with the given id, as listed in the history of its account,
+// or a conflict error if it has already been reversed.
+func (s DefaultTransactionReversalService) GetReversibleTransaction(transactionId string) (*dto.AccountTransactionResponse, *errs.AppError) {
+	transaction, appErr := s.findReversible(transactionId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	response := transaction.ToAccountTransactionDTO()
+	return &response, nil
+}
+
+// Reverse undoes the given posted transaction by posting a compensating transaction of the same amount in the
+// opposite direction, linked to the original. A transaction can only be reversed once. A compensating withdrawal that
+// the available balance, less the funds reserved for withdrawals pending review, and the overdraft limit do not cover
+// is refused unless the reversal is forced. Like any other transaction, the compensating
+// transaction alerts the customer as set in their alert rules and is charged as set in the overdraft terms if it
+// overdraws the account, but it is never charged a withdrawal fee. Reversals are admin corrections, so they are also
+// made on frozen accounts.
+func (s DefaultTransactionReversalService) Reverse(request dto.TransactionReversalRequest) (*dto.TransactionReversalResponse, *errs.AppError) {
+	original, appErr := s.findReversible(request.TransactionId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	account, appErr := s.accountRepo.FindById(original.AccountId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	compensating := original.ToReversal(s.clk)
+	if compensating.IsDebit() && !request.Force {
+		if appErr = applyHolds(s.holds, account, s.clk); appErr != nil {
+			return nil, appErr
+		}
+		reserved, appErr := s.reviews.FindReservedAmount(account.AccountId)
+		if appErr != nil {
+			return nil, appErr
+		}
+		if !account.CanWithdraw(compensating.Amount + reserved) {
+			logger.Error("Reversal of transaction " + original.TransactionId + " exceeds account balance")
+			return nil, problem.NewValidationError(problem.InsufficientFunds,
+				"Account balance insufficient to reverse the transaction")
+		}
+	}
+
+	reversal, posted, appErr := s.repo.Save(domain.NewTransactionReversal(request, s.clk), compensating)
+	if appErr != nil {
+		return nil, appErr
+	}
+	s.alerts.EvaluateTransaction(account.CustomerId, *posted)
+	chargeOverdraft(s.overdrafts, *posted)
+
+	response := reversal.ToDTO(*posted)
+	return &response, nil
+}
+
+// findReversible returns the posted transaction with the given id, or a conflict error if it has already been reversed.
+func (s DefaultTransactionReversalService) findReversible(transactionId string) (*domain.Transaction, *errs.AppError) {
+	transaction, appErr := s.repo.FindTransactionById(transactionId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if transaction.IsReversed() {
+		logger.Error("Reversal attempted of transaction " + transaction.TransactionId + " already reversed")
+		return nil, problem.NewConflictError(problem.TransactionAlreadyReversed,
+			"Transaction has already been reversed")
+	}
+	return transaction, nil
+}
@@ -0,0 +1,243 @@
+package service
+
+import (
+	"database/sql"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	mocksDomain "github.com/aliciatay-zls/banking/backend/mocks/domain"
+	mocksService "github.com/aliciatay-zls/banking/backend/mocks/service"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockReversalRepo *mocksDomain.MockTransactionReversalRepository
+var mockReversalAccountRepo *mocksDomain.MockAccountRepository
+var mockReversalReviewRepo *mocksDomain.MockTransactionReviewRepository
+var mockReversalHoldRepo *mocksDomain.MockHoldRepository
+var mockReversalAlertService *mocksService.MockAlertService
+var mockReversalOverdraftService *mocksService.MockOverdraftService
+var reversalSvc DefaultTransactionReversalService
+
+const dummyReversedTransactionId = "7791"
+
+func setupTransactionReversalServiceTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	mockReversalRepo = mocksDomain.NewMockTransactionReversalRepository(ctrl)
+	mockReversalAccountRepo = mocksDomain.NewMockAccountRepository(ctrl)
+	mockReversalReviewRepo = mocksDomain.NewMockTransactionReviewRepository(ctrl)
+	mockReversalHoldRepo = mocksDomain.NewMockHoldRepository(ctrl)
+	mockReversalAlertService = mocksService.NewMockAlertService(ctrl)
+	mockReversalOverdraftService = mocksService.NewMockOverdraftService(ctrl)
+	reversalSvc = NewTransactionReversalService(mockReversalRepo, mockReversalAccountRepo, mockReversalReviewRepo,
+		mockReversalHoldRepo, mockReversalAlertService, mockReversalOverdraftService, clock.StaticClock{})
+	logger.MuteLogger()
+
+	return func() {
+		mockReversalRepo = nil
+		mockReversalAccountRepo = nil
+		mockReversalReviewRepo = nil
+		mockReversalHoldRepo = nil
+		mockReversalAlertService = nil
+		mockReversalOverdraftService = nil
+		defer ctrl.Finish()
+	}
+}
+
+// getDummyReversalRequest returns a request from an admin to reverse the transaction with id 7791 without force
+func getDummyReversalRequest() dto.TransactionReversalRequest {
+	return dto.TransactionReversalRequest{TransactionId: dummyReversedTransactionId, Reason: "Duplicate deposit", ReversedBy: "admin"}
+}
+
+// getDummyReversedDeposit returns a posted deposit of 500 with id 7791 on the account with id 1977
+func getDummyReversedDeposit() domain.Transaction {
+	return domain.Transaction{TransactionId: dummyReversedTransactionId, AccountId: dummyAccountId, Amount: 500,
+		TransactionType: dto.TransactionTypeDeposit, TransactionDate: dummyTwoDaysAgo}
+}
+
+func getDummyReversalAccount(amount float64) *domain.Account {
+	return &domain.Account{AccountId: dummyAccountId, CustomerId: dummyCustomerId, AccountType: dto.AccountTypeSaving,
+		Amount: amount, Status: domain.AccountStatusActive}
+}
+
+func TestDefaultTransactionReversalService_GetReversibleTransaction_returns_transaction_when_not_reversed(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionReversalServiceTest(t)
+	defer teardown()
+
+	deposit := getDummyReversedDeposit()
+	mockReversalRepo.EXPECT().FindTransactionById(dummyReversedTransactionId).Return(&deposit, nil)
+
+	//Act
+	response, err := reversalSvc.GetReversibleTransaction(dummyReversedTransactionId)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing retrieval of reversible transaction: " + err.Message)
+	}
+	if response.TransactionId != dummyReversedTransactionId || response.Amount != 500 ||
+		response.TransactionType != dto.TransactionTypeDeposit {
+		t.Errorf("Expected deposit %s of 500 but got %v", dummyReversedTransactionId, *response)
+	}
+}
+
+func TestDefaultTransactionReversalService_GetReversibleTransaction_returns_conflictError_when_already_reversed(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionReversalServiceTest(t)
+	defer teardown()
+
+	reversed := getDummyReversedDeposit()
+	reversed.ReversedByTransactionId = sql.NullString{String: "7792", Valid: true}
+	mockReversalRepo.EXPECT().FindTransactionById(dummyReversedTransactionId).Return(&reversed, nil)
+
+	//Act
+	_, err := reversalSvc.GetReversibleTransaction(dummyReversedTransactionId)
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing retrieval of transaction already reversed")
+	}
+	if err.Code != http.StatusConflict {
+		t.Errorf("Expected status code %d but got %d", http.StatusConflict, err.Code)
+	}
+}
+
+func TestDefaultTransactionReversalService_Reverse_returns_conflictError_when_transaction_already_reversed(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionReversalServiceTest(t)
+	defer teardown()
+
+	reversed := getDummyReversedDeposit()
+	reversed.ReversedByTransactionId = sql.NullString{String: "7792", Valid: true}
+	mockReversalRepo.EXPECT().FindTransactionById(dummyReversedTransactionId).Return(&reversed, nil)
+	mockReversalRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(0)
+
+	//Act
+	_, err := reversalSvc.Reverse(getDummyReversalRequest())
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing reversal of transaction already reversed")
+	}
+	if err.Code != http.StatusConflict {
+		t.Errorf("Expected status code %d but got %d", http.StatusConflict, err.Code)
+	}
+}
+
+func TestDefaultTransactionReversalService_Reverse_returns_validationError_when_reversal_exceeds_balance_and_not_forced(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionReversalServiceTest(t)
+	defer teardown()
+
+	deposit := getDummyReversedDeposit()
+	mockReversalRepo.EXPECT().FindTransactionById(dummyReversedTransactionId).Return(&deposit, nil)
+	mockReversalAccountRepo.EXPECT().FindById(dummyAccountId).Return(getDummyReversalAccount(499.99), nil)
+	mockReversalHoldRepo.EXPECT().FindHeldAmount(dummyAccountId, gomock.Any()).Return(float64(0), nil)
+	mockReversalReviewRepo.EXPECT().FindReservedAmount(dummyAccountId).Return(float64(0), nil)
+	mockReversalRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(0)
+
+	//Act
+	_, err := reversalSvc.Reverse(getDummyReversalRequest())
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing reversal exceeding account balance")
+	}
+	if err.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status code %d but got %d", http.StatusUnprocessableEntity, err.Code)
+	}
+}
+
+func TestDefaultTransactionReversalService_Reverse_returns_validationError_when_holds_and_reservations_leave_too_little(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionReversalServiceTest(t)
+	defer teardown()
+
+	deposit := getDummyReversedDeposit()
+	mockReversalRepo.EXPECT().FindTransactionById(dummyReversedTransactionId).Return(&deposit, nil)
+	mockReversalAccountRepo.EXPECT().FindById(dummyAccountId).Return(getDummyReversalAccount(600), nil)
+	mockReversalHoldRepo.EXPECT().FindHeldAmount(dummyAccountId, gomock.Any()).Return(float64(60), nil)
+	mockReversalReviewRepo.EXPECT().FindReservedAmount(dummyAccountId).Return(float64(50), nil)
+	mockReversalRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Times(0)
+
+	//Act
+	_, err := reversalSvc.Reverse(getDummyReversalRequest())
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing reversal exceeding available balance")
+	}
+	if err.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status code %d but got %d", http.StatusUnprocessableEntity, err.Code)
+	}
+}
+
+func TestDefaultTransactionReversalService_Reverse_posts_compensating_transaction_within_overdraftLimit(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionReversalServiceTest(t)
+	defer teardown()
+
+	deposit := getDummyReversedDeposit()
+	account := getDummyReversalAccount(400)
+	account.AccountType, account.OverdraftLimit = dto.AccountTypeChecking, 200
+	compensating := deposit.ToReversal(clock.StaticClock{})
+	posted := compensating
+	posted.TransactionId, posted.Balance = "7792", -100
+
+	mockReversalRepo.EXPECT().FindTransactionById(dummyReversedTransactionId).Return(&deposit, nil)
+	mockReversalAccountRepo.EXPECT().FindById(dummyAccountId).Return(account, nil)
+	mockReversalHoldRepo.EXPECT().FindHeldAmount(dummyAccountId, gomock.Any()).Return(float64(0), nil)
+	mockReversalReviewRepo.EXPECT().FindReservedAmount(dummyAccountId).Return(float64(0), nil)
+	mockReversalRepo.EXPECT().Save(gomock.Any(), compensating).
+		Return(&domain.TransactionReversal{ReversalId: "3", ReversalTransactionId: "7792"}, &posted, nil)
+	mockReversalAlertService.EXPECT().EvaluateTransaction(dummyCustomerId, posted)
+	mockReversalOverdraftService.EXPECT().ChargeForTransaction(posted)
+
+	//Act
+	response, err := reversalSvc.Reverse(getDummyReversalRequest())
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing reversal within overdraft limit: " + err.Message)
+	}
+	if response.Balance != -100 {
+		t.Errorf("Expected balance -100 but got %v", *response)
+	}
+}
+
+func TestDefaultTransactionReversalService_Reverse_posts_compensating_transaction_when_forced(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionReversalServiceTest(t)
+	defer teardown()
+
+	request := getDummyReversalRequest()
+	request.Force = true
+	deposit := getDummyReversedDeposit()
+	compensating := deposit.ToReversal(clock.StaticClock{})
+	expectedReversal := domain.NewTransactionReversal(request, clock.StaticClock{})
+	savedReversal := expectedReversal
+	savedReversal.ReversalId, savedReversal.ReversalTransactionId = "3", "7792"
+	posted := compensating
+	posted.TransactionId, posted.Balance = "7792", -100
+
+	mockReversalRepo.EXPECT().FindTransactionById(dummyReversedTransactionId).Return(&deposit, nil)
+	mockReversalAccountRepo.EXPECT().FindById(dummyAccountId).Return(getDummyReversalAccount(400), nil)
+	mockReversalRepo.EXPECT().Save(expectedReversal, compensating).Return(&savedReversal, &posted, nil)
+	mockReversalAlertService.EXPECT().EvaluateTransaction(dummyCustomerId, posted)
+	mockReversalOverdraftService.EXPECT().ChargeForTransaction(posted)
+
+	//Act
+	response, err := reversalSvc.Reverse(request)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing forced reversal: " + err.Message)
+	}
+	if response.ReversalTransactionId != "7792" || response.TransactionType != dto.TransactionTypeWithdrawal ||
+		response.Balance != -100 || !response.Forced {
+		t.Errorf("Expected forced withdrawal 7792 leaving balance -100 but got %v", *response)
+	}
+}
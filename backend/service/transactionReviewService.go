@@ -0,0 +1,132 @@
+package service
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/problem"
+)
+
+//go:generate mockgen -destination=../mocks/service/mock_transactionReviewService.go -package=service github.com/aliciatay-zls/banking/backend/service TransactionReviewService
+type TransactionReviewService interface { //service (primary port)
+	GetPendingReviews() ([]dto.TransactionReviewResponse, *errs.AppError)
+	Approve(dto.TransactionReviewRequest) (*dto.TransactionReviewResponse, *errs.AppError)
+	Reject(dto.TransactionReviewRequest) (*dto.TransactionReviewResponse, *errs.AppError)
+}
+
+type DefaultTransactionReviewService struct { //business/domain object
+	repo        domain.TransactionReviewRepository
+	accountRepo domain.AccountRepository
+	holds       domain.HoldRepository
+	alerts      AlertService
+	overdrafts  OverdraftService
+	fees        FeeService
+	clk         clock.Clock
+}
+
+func NewTransactionReviewService(repo domain.TransactionReviewRepository, accountRepo domain.AccountRepository, holds domain.HoldRepository, alerts AlertService, overdrafts OverdraftService, fees FeeService, clk clock.Clock) DefaultTransactionReviewService {
+	return DefaultTransactionReviewService{repo, accountRepo, holds, alerts, overdrafts, fees, clk}
+}
+
+// GetPendingReviews returns the review queue: the transactions held for review that are waiting for an admin, oldest
+// first.
+func (s DefaultTransactionReviewService) GetPendingReviews() ([]dto.TransactionReviewResponse, *errs.AppError) {
+	reviews, appErr := s.repo.FindPending()
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	response := make([]dto.TransactionReviewResponse, 0)
+	for _, r := range reviews {
+		response = append(response, r.ToDTO())
+	}
+	return response, nil
+}
+
+// Approve posts the held transaction of the given review, unless its account has been frozen or no longer has the
+// available balance for it, alerts the account owner as set in their alert rules and charges the account as set in the
+// overdraft terms if the transaction took it below zero or back from below zero and charges the withdrawal fee of the
+// fee schedule in effect. The review is resolved before the transaction is posted, so that two admins approving it at
+// once cannot post it twice, and is put back in the queue if the transaction could not be posted.
+func (s DefaultTransactionReviewService) Approve(request dto.TransactionReviewRequest) (*dto.TransactionReviewResponse, *errs.AppError) {
+	review, appErr := s.findPending(request.ReviewId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	account, appErr := s.accountRepo.FindById(review.AccountId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if account.IsFrozen() {
+		logger.Error("Approval of transaction review attempted on frozen account " + account.AccountId)
+		return nil, problem.NewAuthorizationError(problem.AccountFrozen, "Account is frozen pending review")
+	}
+	transaction := review.ToTransaction(s.clk)
+	if transaction.IsWithdrawal() {
+		if appErr = applyHolds(s.holds, account, s.clk); appErr != nil {
+			return nil, appErr
+		}
+		reserved, appErr := s.repo.FindReservedAmount(account.AccountId)
+		if appErr != nil {
+			return nil, appErr
+		}
+		reserved -= review.Amount //the funds reserved by the review are available to its own approval
+		if !account.CanWithdraw(transaction.Amount + reserved) {
+			logger.Error("Amount of held withdrawal exceeds account balance")
+			return nil, problem.NewValidationError(problem.InsufficientFunds,
+				"Account balance insufficient to post the held withdrawal")
+		}
+	}
+
+	approved := review.Resolve(dto.TransactionStatusPosted, request.Comment, s.clk)
+	if appErr = s.repo.UpdateStatus(approved, dto.TransactionStatusPendingReview); appErr != nil {
+		return nil, appErr
+	}
+
+	completedTransaction, appErr := s.accountRepo.Transact(transaction)
+	if appErr != nil {
+		if reopenErr := s.repo.UpdateStatus(*review, dto.TransactionStatusPosted); reopenErr != nil {
+			logger.Error("Error while putting transaction review " + review.ReviewId + " back in the queue: " + reopenErr.Message)
+		}
+		return nil, appErr
+	}
+	s.alerts.EvaluateTransaction(account.CustomerId, *completedTransaction)
+	chargeOverdraft(s.overdrafts, *completedTransaction)
+	s.fees.ChargeForTransaction(*completedTransaction)
+
+	response := approved.ToDTO()
+	return &response, nil
+}
+
+// Reject releases the held transaction of the given review without posting it, which frees any funds it reserved.
+func (s DefaultTransactionReviewService) Reject(request dto.TransactionReviewRequest) (*dto.TransactionReviewResponse, *errs.AppError) {
+	review, appErr := s.findPending(request.ReviewId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	rejected := review.Resolve(dto.TransactionStatusRejected, request.Comment, s.clk)
+	if appErr = s.repo.UpdateStatus(rejected, dto.TransactionStatusPendingReview); appErr != nil {
+		return nil, appErr
+	}
+
+	response := rejected.ToDTO()
+	return &response, nil
+}
+
+// findPending returns the review with the given id, or a conflict error if it has already been resolved.
+func (s DefaultTransactionReviewService) findPending(reviewId string) (*domain.TransactionReview, *errs.AppError) {
+	review, appErr := s.repo.FindById(reviewId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if !review.IsPending() {
+		logger.Error("Transaction review " + reviewId + " has already been resolved as " + review.Status)
+		return nil, problem.NewConflictError(problem.TransactionReviewResolved,
+			"Transaction review has already been resolved")
+	}
+	return review, nil
+}
@@ -0,0 +1,234 @@
+package service
+
+import (
+	"database/sql"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	mocksDomain "github.com/aliciatay-zls/banking/backend/mocks/domain"
+	mocksService "github.com/aliciatay-zls/banking/backend/mocks/service"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockReviewRepo *mocksDomain.MockTransactionReviewRepository
+var mockReviewAccountRepo *mocksDomain.MockAccountRepository
+var mockReviewHoldRepo *mocksDomain.MockHoldRepository
+var mockReviewAlertService *mocksService.MockAlertService
+var mockReviewOverdraftService *mocksService.MockOverdraftService
+var mockReviewFeeService *mocksService.MockFeeService
+var reviewSvc DefaultTransactionReviewService
+
+const dummyReviewId = "5"
+const dummyReviewComment = "Customer confirmed the withdrawal by phone"
+
+func setupTransactionReviewServiceTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	mockReviewRepo = mocksDomain.NewMockTransactionReviewRepository(ctrl)
+	mockReviewAccountRepo = mocksDomain.NewMockAccountRepository(ctrl)
+	mockReviewHoldRepo = mocksDomain.NewMockHoldRepository(ctrl)
+	mockReviewAlertService = mocksService.NewMockAlertService(ctrl)
+	mockReviewOverdraftService = mocksService.NewMockOverdraftService(ctrl)
+	mockReviewFeeService = mocksService.NewMockFeeService(ctrl)
+	reviewSvc = NewTransactionReviewService(mockReviewRepo, mockReviewAccountRepo, mockReviewHoldRepo, mockReviewAlertService, mockReviewOverdraftService, mockReviewFeeService, clock.StaticClock{})
+
+	return func() {
+		mockReviewRepo = nil
+		mockReviewAccountRepo = nil
+		mockReviewHoldRepo = nil
+		mockReviewAlertService = nil
+		mockReviewOverdraftService = nil
+		mockReviewFeeService = nil
+		defer ctrl.Finish()
+	}
+}
+
+// getDummyPendingReview returns a withdrawal of 100 from the account with id 1977 held for review
+func getDummyPendingReview() domain.TransactionReview {
+	return domain.TransactionReview{
+		ReviewId:        dummyReviewId,
+		AccountId:       dummyAccountId,
+		Amount:          100,
+		TransactionType: dto.TransactionTypeWithdrawal,
+		Reasons:         "withdrawal within 1h0m0s of account opening",
+		Status:          dto.TransactionStatusPendingReview,
+		RequestedOn:     "2006-01-02 15:00:00",
+	}
+}
+
+func getDummyReviewRequest() dto.TransactionReviewRequest {
+	return dto.TransactionReviewRequest{ReviewId: dummyReviewId, Comment: dummyReviewComment}
+}
+
+func TestDefaultTransactionReviewService_Approve_posts_heldTransaction_and_alerts_customer(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionReviewServiceTest(t)
+	defer teardown()
+
+	review := getDummyPendingReview()
+	account := domain.Account{AccountId: dummyAccountId, CustomerId: dummyCustomerId, Amount: 500, Status: domain.AccountStatusActive}
+	approved := review.Resolve(dto.TransactionStatusPosted, dummyReviewComment, clock.StaticClock{})
+	transaction := review.ToTransaction(clock.StaticClock{})
+	postedTransaction := transaction
+	postedTransaction.TransactionId = dummyTransactionId
+	postedTransaction.Balance = 400
+
+	mockReviewRepo.EXPECT().FindById(dummyReviewId).Return(&review, nil)
+	mockReviewAccountRepo.EXPECT().FindById(dummyAccountId).Return(&account, nil)
+	mockReviewHoldRepo.EXPECT().FindHeldAmount(dummyAccountId, clock.StaticClock{}.NowAsString()).Return(float64(0), nil)
+	mockReviewRepo.EXPECT().FindReservedAmount(dummyAccountId).Return(review.Amount, nil)
+	gomock.InOrder(
+		mockReviewRepo.EXPECT().UpdateStatus(approved, dto.TransactionStatusPendingReview).Return(nil),
+		mockReviewAccountRepo.EXPECT().Transact(transaction).Return(&postedTransaction, nil),
+	)
+	mockReviewAlertService.EXPECT().EvaluateTransaction(dummyCustomerId, postedTransaction)
+	mockReviewFeeService.EXPECT().ChargeForTransaction(postedTransaction)
+
+	//Act
+	response, err := reviewSvc.Approve(getDummyReviewRequest())
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing approval of held transaction: " + err.Message)
+	}
+	if response.Status != dto.TransactionStatusPosted || response.Comment != dummyReviewComment || response.ReviewedOn == "" {
+		t.Errorf("Expected review to be resolved as posted but got %v", *response)
+	}
+}
+
+func TestDefaultTransactionReviewService_Approve_puts_review_backInQueue_when_transact_fails(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionReviewServiceTest(t)
+	defer teardown()
+
+	review := getDummyPendingReview()
+	account := domain.Account{AccountId: dummyAccountId, CustomerId: dummyCustomerId, Amount: 500, Status: domain.AccountStatusActive}
+	dummyAppErr := errs.NewUnexpectedError("Unexpected database error")
+
+	mockReviewRepo.EXPECT().FindById(dummyReviewId).Return(&review, nil)
+	mockReviewAccountRepo.EXPECT().FindById(dummyAccountId).Return(&account, nil)
+	mockReviewHoldRepo.EXPECT().FindHeldAmount(dummyAccountId, clock.StaticClock{}.NowAsString()).Return(float64(0), nil)
+	mockReviewRepo.EXPECT().FindReservedAmount(dummyAccountId).Return(review.Amount, nil)
+	gomock.InOrder(
+		mockReviewRepo.EXPECT().UpdateStatus(gomock.Any(), dto.TransactionStatusPendingReview).Return(nil),
+		mockReviewAccountRepo.EXPECT().Transact(gomock.Any()).Return(nil, dummyAppErr),
+		mockReviewRepo.EXPECT().UpdateStatus(review, dto.TransactionStatusPosted).Return(nil),
+	)
+	mockReviewAlertService.EXPECT().EvaluateTransaction(gomock.Any(), gomock.Any()).Times(0)
+
+	//Act
+	_, err := reviewSvc.Approve(getDummyReviewRequest())
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing failed posting of approved transaction")
+	}
+	if err.Message != dummyAppErr.Message {
+		t.Errorf("Expected error message to be \"%s\" but got \"%s\"", dummyAppErr.Message, err.Message)
+	}
+}
+
+func TestDefaultTransactionReviewService_Approve_returns_error_when_balance_insufficient(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionReviewServiceTest(t)
+	defer teardown()
+
+	review := getDummyPendingReview()
+	account := domain.Account{AccountId: dummyAccountId, CustomerId: dummyCustomerId, Amount: 50, Status: domain.AccountStatusActive}
+	mockReviewRepo.EXPECT().FindById(dummyReviewId).Return(&review, nil)
+	mockReviewAccountRepo.EXPECT().FindById(dummyAccountId).Return(&account, nil)
+	mockReviewHoldRepo.EXPECT().FindHeldAmount(dummyAccountId, clock.StaticClock{}.NowAsString()).Return(float64(0), nil)
+	mockReviewRepo.EXPECT().FindReservedAmount(dummyAccountId).Return(review.Amount, nil)
+	mockReviewRepo.EXPECT().UpdateStatus(gomock.Any(), gomock.Any()).Times(0)
+	mockReviewAccountRepo.EXPECT().Transact(gomock.Any()).Times(0)
+	logger.MuteLogger()
+
+	//Act
+	_, err := reviewSvc.Approve(getDummyReviewRequest())
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing approval of held withdrawal exceeding balance")
+	}
+	if err.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status code %d but got %d", http.StatusUnprocessableEntity, err.Code)
+	}
+}
+
+func TestDefaultTransactionReviewService_Approve_returns_error_when_balance_reserved_by_otherReviews(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionReviewServiceTest(t)
+	defer teardown()
+
+	review := getDummyPendingReview()
+	account := domain.Account{AccountId: dummyAccountId, CustomerId: dummyCustomerId, Amount: 150, Status: domain.AccountStatusActive}
+	mockReviewRepo.EXPECT().FindById(dummyReviewId).Return(&review, nil)
+	mockReviewAccountRepo.EXPECT().FindById(dummyAccountId).Return(&account, nil)
+	mockReviewHoldRepo.EXPECT().FindHeldAmount(dummyAccountId, clock.StaticClock{}.NowAsString()).Return(float64(0), nil)
+	mockReviewRepo.EXPECT().FindReservedAmount(dummyAccountId).Return(review.Amount+100, nil)
+	mockReviewRepo.EXPECT().UpdateStatus(gomock.Any(), gomock.Any()).Times(0)
+	mockReviewAccountRepo.EXPECT().Transact(gomock.Any()).Times(0)
+	logger.MuteLogger()
+
+	//Act
+	_, err := reviewSvc.Approve(getDummyReviewRequest())
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing approval of held withdrawal exceeding unreserved balance")
+	}
+	if err.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status code %d but got %d", http.StatusUnprocessableEntity, err.Code)
+	}
+}
+
+func TestDefaultTransactionReviewService_Reject_returns_conflictError_when_already_resolved(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionReviewServiceTest(t)
+	defer teardown()
+
+	review := getDummyPendingReview()
+	review.Status = dto.TransactionStatusPosted
+	review.ReviewedOn = sql.NullString{String: "2006-01-02 15:04:00", Valid: true}
+	mockReviewRepo.EXPECT().FindById(dummyReviewId).Return(&review, nil)
+	mockReviewRepo.EXPECT().UpdateStatus(gomock.Any(), gomock.Any()).Times(0)
+	logger.MuteLogger()
+
+	//Act
+	_, err := reviewSvc.Reject(getDummyReviewRequest())
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing rejection of resolved review")
+	}
+	if err.Code != http.StatusConflict {
+		t.Errorf("Expected status code %d but got %d", http.StatusConflict, err.Code)
+	}
+}
+
+func TestDefaultTransactionReviewService_Reject_resolves_review_without_posting(t *testing.T) {
+	//Arrange
+	teardown := setupTransactionReviewServiceTest(t)
+	defer teardown()
+
+	review := getDummyPendingReview()
+	rejected := review.Resolve(dto.TransactionStatusRejected, dummyReviewComment, clock.StaticClock{})
+	mockReviewRepo.EXPECT().FindById(dummyReviewId).Return(&review, nil)
+	mockReviewRepo.EXPECT().UpdateStatus(rejected, dto.TransactionStatusPendingReview).Return(nil)
+	mockReviewAccountRepo.EXPECT().Transact(gomock.Any()).Times(0)
+
+	//Act
+	response, err := reviewSvc.Reject(getDummyReviewRequest())
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing rejection of held transaction: " + err.Message)
+	}
+	if response.Status != dto.TransactionStatusRejected {
+		t.Errorf("Expected review to be rejected but got %v", *response)
+	}
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"github.com/aliciatay-zls/banking/backend/problem"
+)
+
+//go:generate mockgen -destination=../mocks/service/mock_transferService.go -package=service github.com/aliciatay-zls/banking/backend/service TransferService
+type TransferService interface { //service (primary port)
+	Transfer(dto.TransferRequest) (*dto.TransferResponse, *errs.AppError)
+}
+
+type DefaultTransferService struct { //business/domain object
+	repo       domain.AccountRepository
+	payees     domain.PayeeRepository
+	reviews    domain.TransactionReviewRepository
+	holds      domain.HoldRepository
+	fraud      FraudService
+	alerts     AlertService
+	overdrafts OverdraftService
+	fees       FeeService
+	terms      domain.PayeeTerms
+	clk        clock.Clock
+}
+
+func NewTransferService(repo domain.AccountRepository, payees domain.PayeeRepository, reviews domain.TransactionReviewRepository, holds domain.HoldRepository, fraud FraudService, alerts AlertService, overdrafts OverdraftService, fees FeeService, terms domain.PayeeTerms, clk clock.Clock) DefaultTransferService {
+	return DefaultTransferService{repo, payees, reviews, holds, fraud, alerts, overdrafts, fees, terms, clk}
+}
+
+// Transfer sends the amount in the given request from the given account of the customer to the account of the given
+// payee of the customer, as a withdrawal and a deposit posted together. It checks that neither account is frozen, that
+// the payee is past its cooling-off period or the amount within the cooling-off limit, that the first transfer to the
+// payee confirms it, that the available account balance, less the funds reserved for withdrawals pending review,
+// allows for the transfer and that the fraud rules let it through. Transfers cannot be held for review, so those
+// flagged by the fraud rules are declined. The payee is only confirmed once the first transfer to it has been posted,
+// so that a failed transfer leaves no confirmation in the audit trail. Both account owners are then alerted as set in
+// their alert rules, both accounts are charged as set in the overdraft terms and the withdrawal fee of the fee schedule
+// in effect is charged.
+func (s DefaultTransferService) Transfer(request dto.TransferRequest) (*dto.TransferResponse, *errs.AppError) {
+	account, appErr := s.repo.FindById(request.AccountId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if account.CustomerId != request.CustomerId {
+		logger.Error("Account " + request.AccountId + " does not belong to customer " + request.CustomerId)
+		return nil, problem.NewNotFoundError(problem.AccountNotFound, "Account not found")
+	}
+	if account.IsFrozen() {
+		logger.Error("Transfer attempted from frozen account " + account.AccountId)
+		return nil, problem.NewAuthorizationError(problem.AccountFrozen, "Account is frozen pending review")
+	}
+
+	payee, appErr := findPayeeOf(s.payees, request.CustomerId, request.PayeeId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if payee.AccountId == account.AccountId {
+		logger.Error("Transfer attempted from account " + account.AccountId + " to itself")
+		return nil, problem.NewValidationError(problem.TransferToSameAccount,
+			"Cannot transfer to the account the transfer is sent from.")
+	}
+	toAccount, appErr := s.repo.FindById(payee.AccountId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if toAccount.IsFrozen() {
+		logger.Error("Transfer attempted to frozen account " + toAccount.AccountId)
+		return nil, problem.NewAuthorizationError(problem.PayeeAccountUnavailable,
+			"The account of the payee cannot receive transfers at the moment.")
+	}
+
+	if !s.terms.Allows(*payee, request.Amount, s.clk) {
+		logger.Error("Transfer to payee " + payee.PayeeId + " exceeds the cooling-off limit")
+		return nil, problem.NewValidationError(problem.PayeeCoolingOff, s.terms.CoolingOffMessage(*payee))
+	}
+	if !payee.IsConfirmed() && !request.ConfirmPayee {
+		logger.Error("First transfer to payee " + payee.PayeeId + " was not confirmed")
+		return nil, problem.NewConflictError(problem.PayeeNotConfirmed,
+			"Please confirm the payee before the first transfer to it.")
+	}
+
+	if appErr = applyHolds(s.holds, account, s.clk); appErr != nil {
+		return nil, appErr
+	}
+	reserved, appErr := s.reviews.FindReservedAmount(account.AccountId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if !account.CanWithdraw(request.Amount + reserved) {
+		logger.Error("Amount to transfer exceeds account balance")
+		return nil, problem.NewValidationError(problem.InsufficientFunds,
+			"Account balance insufficient to transfer given amount")
+	}
+
+	withdrawal, deposit := payee.ToTransfer(request, s.clk)
+
+	decision, appErr := s.fraud.Screen(*account, withdrawal)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if decision.IsBlocked() || decision.IsFlaggedForReview() {
+		logger.Error("Transfer from account " + account.AccountId + " stopped by fraud rules: " + decision.Reasons)
+		return nil, problem.NewAuthorizationError(problem.TransactionDeclined,
+			"Transaction declined for security reasons. Please contact the bank.")
+	}
+
+	postedWithdrawal, postedDeposit, appErr := s.repo.Transfer(withdrawal, deposit)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if !payee.IsConfirmed() {
+		if confirmErr := s.payees.Confirm(payee.Confirm(s.clk), request.RequestedBy); confirmErr != nil {
+			logger.Error("Error while confirming payee " + payee.PayeeId + " after the first transfer to it: " +
+				confirmErr.Message)
+		}
+	}
+	s.alerts.EvaluateTransaction(account.CustomerId, *postedWithdrawal)
+	s.alerts.EvaluateTransaction(toAccount.CustomerId, *postedDeposit)
+	chargeOverdraft(s.overdrafts, *postedWithdrawal)
+	chargeOverdraft(s.overdrafts, *postedDeposit)
+	s.fees.ChargeForTransaction(*postedWithdrawal)
+
+	return payee.ToTransferResponseDTO(*postedWithdrawal), nil
+}
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"database/sql"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	mocksDomain "github.com/aliciatay-zls/banking/backend/mocks/domain"
+	mocksService "github.com/aliciatay-zls/banking/backend/mocks/service"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockTransferAccountRepo *mocksDomain.MockAccountRepository
+var mockTransferPayeeRepo *mocksDomain.MockPayeeRepository
+var mockTransferReviewRepo *mocksDomain.MockTransactionReviewRepository
+var mockTransferHoldRepo *mocksDomain.MockHoldRepository
+var mockTransferFraudService *mocksService.MockFraudService
+var mockTransferAlertService *mocksService.MockAlertService
+var mockTransferOverdraftService *mocksService.MockOverdraftService
+var mockTransferFeeService *mocksService.MockFeeService
+var transferSvc DefaultTransferService
+
+func setupTransferServiceTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	mockTransferAccountRepo = mocksDomain.NewMockAccountRepository(ctrl)
+	mockTransferPayeeRepo = mocksDomain.NewMockPayeeRepository(ctrl)
+	mockTransferReviewRepo = mocksDomain.NewMockTransactionReviewRepository(ctrl)
+	mockTransferHoldRepo = mocksDomain.NewMockHoldRepository(ctrl)
+	mockTransferFraudService = mocksService.NewMockFraudService(ctrl)
+	mockTransferAlertService = mocksService.NewMockAlertService(ctrl)
+	mockTransferOverdraftService = mocksService.NewMockOverdraftService(ctrl)
+	mockTransferFeeService = mocksService.NewMockFeeService(ctrl)
+	transferSvc = NewTransferService(mockTransferAccountRepo, mockTransferPayeeRepo, mockTransferReviewRepo, mockTransferHoldRepo,
+		mockTransferFraudService, mockTransferAlertService, mockTransferOverdraftService, mockTransferFeeService, dummyPayeeTerms, clock.StaticClock{})
+	logger.MuteLogger()
+
+	return func() {
+		mockTransferAccountRepo = nil
+		mockTransferPayeeRepo = nil
+		mockTransferReviewRepo = nil
+		mockTransferHoldRepo = nil
+		mockTransferFraudService = nil
+		mockTransferAlertService = nil
+		mockTransferOverdraftService = nil
+		mockTransferFeeService = nil
+		defer ctrl.Finish()
+	}
+}
+
+func getDummyTransferRequest(amount float64) dto.TransferRequest {
+	return dto.TransferRequest{CustomerId: dummyCustomerId, AccountId: dummyAccountId, PayeeId: dummyPayeeId, Amount: amount, RequestedBy: "2"}
+}
+
+// expectTransferAccounts expects the account with id 1977 of the customer with id 2, holding 5000, and the account of
+// the payee with id 3, belonging to the customer with id 1, to be looked up.
+func expectTransferAccounts(payee domain.Payee) domain.Account {
+	account := domain.Account{AccountId: dummyAccountId, CustomerId: dummyCustomerId, Amount: 5000, Status: domain.AccountStatusActive}
+	mockTransferAccountRepo.EXPECT().FindById(dummyAccountId).Return(&account, nil)
+	mockTransferPayeeRepo.EXPECT().FindById(dummyPayeeId).Return(&payee, nil)
+	mockTransferAccountRepo.EXPECT().FindById(dummyPayeeAccountId).
+		Return(&domain.Account{AccountId: dummyPayeeAccountId, CustomerId: "1", Status: domain.AccountStatusActive}, nil)
+	return account
+}
+
+func getConfirmedDummyPayee() domain.Payee {
+	payee := getDummyPayee()
+	payee.CreatedOn = "2005-01-02 15:04:05"
+	payee.ConfirmedOn = sql.NullString{String: payee.CreatedOn, Valid: true}
+	return payee
+}
+
+func TestDefaultTransferService_Transfer_returns_notFoundError_when_account_of_otherCustomer(t *testing.T) {
+	//Arrange
+	teardown := setupTransferServiceTest(t)
+	defer teardown()
+
+	mockTransferAccountRepo.EXPECT().FindById(dummyAccountId).Return(&domain.Account{AccountId: dummyAccountId, CustomerId: "1"}, nil)
+	mockTransferAccountRepo.EXPECT().Transfer(gomock.Any(), gomock.Any()).Times(0)
+
+	//Act
+	_, err := transferSvc.Transfer(getDummyTransferRequest(50))
+
+	//Assert
+	if err == nil || err.Code != http.StatusNotFound || err.Message != "Account not found" {
+		t.Errorf("Expected account not found error but got %v", err)
+	}
+}
+
+func TestDefaultTransferService_Transfer_returns_validationError_when_amount_over_coolingOffLimit(t *testing.T) {
+	//Arrange
+	teardown := setupTransferServiceTest(t)
+	defer teardown()
+
+	expectTransferAccounts(getDummyPayee())
+	mockTransferAccountRepo.EXPECT().Transfer(gomock.Any(), gomock.Any()).Times(0)
+
+	//Act
+	_, err := transferSvc.Transfer(getDummyTransferRequest(1000.01))
+
+	//Assert
+	if err == nil || err.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("Expected validation error but got %v", err)
+	}
+	expectedMessage := "Transfers to a new payee are limited to 1000.00 until 2006-01-03 15:04:05."
+	if err.Message != expectedMessage {
+		t.Errorf("Expected error message \"%s\" but got \"%s\"", expectedMessage, err.Message)
+	}
+}
+
+func TestDefaultTransferService_Transfer_returns_conflictError_when_firstTransfer_not_confirmed(t *testing.T) {
+	//Arrange
+	teardown := setupTransferServiceTest(t)
+	defer teardown()
+
+	expectTransferAccounts(getDummyPayee())
+	mockTransferAccountRepo.EXPECT().Transfer(gomock.Any(), gomock.Any()).Times(0)
+
+	//Act
+	_, err := transferSvc.Transfer(getDummyTransferRequest(50))
+
+	//Assert
+	if err == nil || err.Code != http.StatusConflict {
+		t.Errorf("Expected conflict error but got %v", err)
+	}
+}
+
+func TestDefaultTransferService_Transfer_declines_transfer_flagged_for_review(t *testing.T) {
+	//Arrange
+	teardown := setupTransferServiceTest(t)
+	defer teardown()
+
+	expectTransferAccounts(getConfirmedDummyPayee())
+	mockTransferHoldRepo.EXPECT().FindHeldAmount(dummyAccountId, gomock.Any()).Return(float64(0), nil)
+	mockTransferReviewRepo.EXPECT().FindReservedAmount(dummyAccountId).Return(float64(0), nil)
+	dummyReviewDecision := domain.FraudDecision{Decision: domain.FraudDecisionReview, Reasons: "new payee"}
+	mockTransferFraudService.EXPECT().Screen(gomock.Any(), gomock.Any()).Return(&dummyReviewDecision, nil)
+	mockTransferAccountRepo.EXPECT().Transfer(gomock.Any(), gomock.Any()).Times(0)
+
+	//Act
+	_, err := transferSvc.Transfer(getDummyTransferRequest(50))
+
+	//Assert
+	if err == nil || err.Code != http.StatusForbidden {
+		t.Errorf("Expected authorization error but got %v", err)
+	}
+}
+
+func TestDefaultTransferService_Transfer_confirms_payee_and_posts_transfer_when_firstTransfer_confirmed(t *testing.T) {
+	//Arrange
+	teardown := setupTransferServiceTest(t)
+	defer teardown()
+
+	payee := getDummyPayee()
+	account := expectTransferAccounts(payee)
+	request := getDummyTransferRequest(50)
+	request.ConfirmPayee = true
+	mockTransferHoldRepo.EXPECT().FindHeldAmount(dummyAccountId, gomock.Any()).Return(float64(0), nil)
+	mockTransferReviewRepo.EXPECT().FindReservedAmount(dummyAccountId).Return(float64(0), nil)
+	withdrawal, deposit := payee.ToTransfer(request, clock.StaticClock{})
+	mockTransferFraudService.EXPECT().Screen(account, withdrawal).Return(&dummyAllowDecision, nil)
+
+	postedWithdrawal := withdrawal
+	postedWithdrawal.TransactionId, postedWithdrawal.Balance = "10", 4950
+	postedDeposit := deposit
+	postedDeposit.TransactionId, postedDeposit.Balance = "11", 50
+	postedDeposit.RelatedTransactionId = sql.NullString{String: "10", Valid: true}
+	gomock.InOrder(
+		mockTransferAccountRepo.EXPECT().Transfer(withdrawal, deposit).Return(&postedWithdrawal, &postedDeposit, nil),
+		mockTransferPayeeRepo.EXPECT().Confirm(payee.Confirm(clock.StaticClock{}), "2").Return(nil),
+	)
+	mockTransferAlertService.EXPECT().EvaluateTransaction(dummyCustomerId, postedWithdrawal)
+	mockTransferAlertService.EXPECT().EvaluateTransaction("1", postedDeposit)
+	mockTransferFeeService.EXPECT().ChargeForTransaction(postedWithdrawal)
+
+	//Act
+	response, err := transferSvc.Transfer(request)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing confirmed first transfer: " + err.Message)
+	}
+	expectedResponse := dto.TransferResponse{TransactionId: "10", PayeeId: dummyPayeeId, ToAccountId: dummyPayeeAccountId, Amount: 50,
+		Balance: 4950, TransactionDate: clock.StaticClock{}.NowAsString()}
+	if *response != expectedResponse {
+		t.Errorf("Expected response %v but got %v", expectedResponse, *response)
+	}
+}
+
+func TestDefaultTransferService_Transfer_does_not_confirm_payee_when_transfer_fails(t *testing.T) {
+	//Arrange
+	teardown := setupTransferServiceTest(t)
+	defer teardown()
+
+	payee := getDummyPayee()
+	account := expectTransferAccounts(payee)
+	request := getDummyTransferRequest(50)
+	request.ConfirmPayee = true
+	mockTransferHoldRepo.EXPECT().FindHeldAmount(dummyAccountId, gomock.Any()).Return(float64(0), nil)
+	mockTransferReviewRepo.EXPECT().FindReservedAmount(dummyAccountId).Return(float64(0), nil)
+	withdrawal, deposit := payee.ToTransfer(request, clock.StaticClock{})
+	mockTransferFraudService.EXPECT().Screen(account, withdrawal).Return(&dummyAllowDecision, nil)
+	dummyAppErr := errs.NewUnexpectedError("Unexpected database error")
+	mockTransferAccountRepo.EXPECT().Transfer(withdrawal, deposit).Return(nil, nil, dummyAppErr)
+	mockTransferPayeeRepo.EXPECT().Confirm(gomock.Any(), gomock.Any()).Times(0)
+	mockTransferAlertService.EXPECT().EvaluateTransaction(gomock.Any(), gomock.Any()).Times(0)
+
+	//Act
+	_, err := transferSvc.Transfer(request)
+
+	//Assert
+	if err == nil || err.Message != dummyAppErr.Message {
+		t.Errorf("Expected error \"%s\" but got %v", dummyAppErr.Message, err)
+	}
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// webhookBatchSize is the maximum number of due deliveries attempted per poll.
+const webhookBatchSize = 50
+
+// webhookErrorMessageMaxLength is the length of the error message column of a delivery attempt.
+const webhookErrorMessageMaxLength = 255
+
+// Headers sent with every webhook delivery. The signature is "sha256=" followed by domain.SignWebhookPayload of the
+// timestamp and the request body.
+const WebhookHeaderEventId = "X-Webhook-Event-Id"
+const WebhookHeaderEventType = "X-Webhook-Event-Type"
+const WebhookHeaderTimestamp = "X-Webhook-Timestamp"
+const WebhookHeaderSignature = "X-Webhook-Signature"
+
+// WebhookDispatcher POSTs enqueued webhook deliveries to the URLs of their subscriptions and records every attempt.
+// Only one dispatcher should run against a database, so that a due delivery is not attempted twice at the same time.
+type WebhookDispatcher struct {
+	repo   domain.WebhookRepository
+	client *http.Client
+	clk    clock.Clock
+}
+
+func NewWebhookDispatcher(repo domain.WebhookRepository, client *http.Client, clk clock.Clock) WebhookDispatcher {
+	return WebhookDispatcher{repo, client, clk}
+}
+
+// DispatchDue attempts every delivery that is due, oldest first. A delivery whose attempt fails is scheduled for a
+// retry and does not stop the others.
+// DispatchDue returns the number of deliveries attempted.
+func (d WebhookDispatcher) DispatchDue() (int, *errs.AppError) {
+	deliveries, appErr := d.repo.FindDueDeliveries(d.clk.NowAsString(), webhookBatchSize)
+	if appErr != nil {
+		return 0, appErr
+	}
+
+	for i, delivery := range deliveries {
+		if _, appErr = d.Deliver(delivery); appErr != nil {
+			return i, appErr
+		}
+	}
+
+	return len(deliveries), nil
+}
+
+// Deliver makes one attempt of the given delivery, then records the attempt and the resulting status of the delivery.
+// Deliver returns the delivery with its status updated.
+func (d WebhookDispatcher) Deliver(delivery domain.WebhookDelivery) (*domain.WebhookDelivery, *errs.AppError) {
+	now := d.clk.Now()
+	attempt := domain.WebhookDeliveryAttempt{
+		DeliveryId:  delivery.DeliveryId,
+		AttemptedOn: now.UTC().Format(clock.FormatDateTime),
+	}
+
+	statusCode, err := d.post(delivery, strconv.FormatInt(now.Unix(), 10))
+	if err != nil {
+		logger.Error(fmt.Sprintf("Error while delivering webhook %s: %s", delivery.DeliveryId, err.Error()))
+		attempt.ErrorMessage = err.Error()
+		if len(attempt.ErrorMessage) > webhookErrorMessageMaxLength {
+			attempt.ErrorMessage = attempt.ErrorMessage[:webhookErrorMessageMaxLength]
+		}
+	}
+	attempt.StatusCode = statusCode
+	if err == nil && !attempt.IsSuccessful() {
+		logger.Error(fmt.Sprintf("Webhook %s was rejected with status code %d", delivery.DeliveryId, statusCode))
+	}
+
+	delivery.RecordAttempt(attempt, now)
+	if appErr := d.repo.SaveDeliveryAttempt(delivery, attempt); appErr != nil {
+		return nil, appErr
+	}
+
+	return &delivery, nil
+}
+
+// post sends the signed payload of the delivery and returns the status code of the response.
+func (d WebhookDispatcher) post(delivery domain.WebhookDelivery, timestamp string) (int, error) {
+	request, err := http.NewRequest(http.MethodPost, delivery.Url, strings.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(WebhookHeaderEventId, delivery.EventId)
+	request.Header.Set(WebhookHeaderEventType, delivery.EventType)
+	request.Header.Set(WebhookHeaderTimestamp, timestamp)
+	request.Header.Set(WebhookHeaderSignature,
+		"sha256="+domain.SignWebhookPayload(delivery.Secret, timestamp, delivery.Payload))
+
+	response, err := d.client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, response.Body) //lets the connection be reused
+
+	return response.StatusCode, nil
+}
+
+// Run dispatches the due deliveries every interval until the given context is cancelled.
+func (d WebhookDispatcher) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		for {
+			count, appErr := d.DispatchDue()
+			if appErr != nil {
+				logger.Error(fmt.Sprintf("Error while dispatching webhooks (%d attempted): %s", count, appErr.Message))
+				break
+			}
+			if count < webhookBatchSize {
+				break
+			}
+		}
+	}
+}
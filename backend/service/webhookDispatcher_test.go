@@ -0,0 +1,175 @@
+package service
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking-lib/logger"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	mocksDomain "github.com/aliciatay-zls/banking/backend/mocks/domain"
+	"go.uber.org/mock/gomock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+// Test common variables and inputs
+var mockWebhookRepo *mocksDomain.MockWebhookRepository
+var dispatcher WebhookDispatcher
+
+const dummyWebhookSecret = "0123456789abcdef"
+const dummyWebhookPayload = `{"event_id":"31","event_type":"AccountOpened"}`
+
+func setupWebhookDispatcherTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	mockWebhookRepo = mocksDomain.NewMockWebhookRepository(ctrl)
+	dispatcher = NewWebhookDispatcher(mockWebhookRepo, http.DefaultClient, clock.StaticClock{})
+
+	return func() {
+		mockWebhookRepo = nil
+		defer ctrl.Finish()
+	}
+}
+
+// getDummyWebhookDelivery returns a pending delivery of the AccountOpened event with id 31 to the given URL.
+func getDummyWebhookDelivery(url string) domain.WebhookDelivery {
+	return domain.WebhookDelivery{
+		DeliveryId:     "8",
+		SubscriptionId: "5",
+		EventId:        "31",
+		EventType:      domain.EventTypeAccountOpened,
+		Payload:        dummyWebhookPayload,
+		Status:         dto.WebhookDeliveryStatusPending,
+		Url:            url,
+		Secret:         dummyWebhookSecret,
+	}
+}
+
+func TestWebhookDispatcher_Deliver_posts_signedPayload_and_records_success(t *testing.T) {
+	//Arrange
+	teardown := setupWebhookDispatcherTest(t)
+	defer teardown()
+
+	var receivedRequest *http.Request
+	var receivedBody []byte
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedRequest = r
+		receivedBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	now := clock.StaticClock{}.Now()
+	delivery := getDummyWebhookDelivery(receiver.URL)
+	expectedDelivery := delivery
+	expectedDelivery.Status = dto.WebhookDeliveryStatusSucceeded
+	expectedDelivery.AttemptCount = 1
+	expectedAttempt := domain.WebhookDeliveryAttempt{DeliveryId: "8", AttemptedOn: clock.StaticClock{}.NowAsString(), StatusCode: 204}
+	mockWebhookRepo.EXPECT().SaveDeliveryAttempt(expectedDelivery, expectedAttempt).Return(nil)
+
+	//Act
+	actualDelivery, err := dispatcher.Deliver(delivery)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing successful delivery: " + err.Message)
+	}
+	if *actualDelivery != expectedDelivery {
+		t.Errorf("Expected delivery %v but got %v", expectedDelivery, *actualDelivery)
+	}
+	if receivedRequest == nil || string(receivedBody) != dummyWebhookPayload {
+		t.Fatalf("Expected receiver to get payload %s but got %s", dummyWebhookPayload, string(receivedBody))
+	}
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	expectedSignature := "sha256=" + domain.SignWebhookPayload(dummyWebhookSecret, timestamp, dummyWebhookPayload)
+	if receivedRequest.Header.Get(WebhookHeaderTimestamp) != timestamp ||
+		receivedRequest.Header.Get(WebhookHeaderSignature) != expectedSignature {
+		t.Errorf("Expected timestamp %s and signature %s but got headers %v", timestamp, expectedSignature, receivedRequest.Header)
+	}
+	if receivedRequest.Header.Get(WebhookHeaderEventId) != "31" ||
+		receivedRequest.Header.Get(WebhookHeaderEventType) != domain.EventTypeAccountOpened {
+		t.Errorf("Expected event id and type headers but got headers %v", receivedRequest.Header)
+	}
+}
+
+func TestWebhookDispatcher_Deliver_schedules_retry_when_receiver_rejects(t *testing.T) {
+	//Arrange
+	teardown := setupWebhookDispatcherTest(t)
+	defer teardown()
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer receiver.Close()
+	logger.MuteLogger()
+
+	delivery := getDummyWebhookDelivery(receiver.URL)
+	expectedNextAttemptOn := clock.StaticClock{}.Now().Add(domain.WebhookRetryBaseDelay).Format(clock.FormatDateTime)
+	mockWebhookRepo.EXPECT().SaveDeliveryAttempt(gomock.Any(), gomock.Any()).Return(nil)
+
+	//Act
+	actualDelivery, err := dispatcher.Deliver(delivery)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing rejected delivery: " + err.Message)
+	}
+	if actualDelivery.Status != dto.WebhookDeliveryStatusPending || actualDelivery.NextAttemptOn != expectedNextAttemptOn {
+		t.Errorf("Expected pending delivery retried on %s but got %v", expectedNextAttemptOn, *actualDelivery)
+	}
+}
+
+func TestWebhookDispatcher_Deliver_records_error_when_receiver_unreachable(t *testing.T) {
+	//Arrange
+	teardown := setupWebhookDispatcherTest(t)
+	defer teardown()
+
+	receiver := httptest.NewServer(http.NotFoundHandler())
+	receiver.Close() //nothing listens on its URL any more
+	logger.MuteLogger()
+
+	var actualAttempt domain.WebhookDeliveryAttempt
+	mockWebhookRepo.EXPECT().SaveDeliveryAttempt(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ domain.WebhookDelivery, attempt domain.WebhookDeliveryAttempt) *errs.AppError {
+			actualAttempt = attempt
+			return nil
+		})
+
+	//Act
+	_, err := dispatcher.Deliver(getDummyWebhookDelivery(receiver.URL))
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing unreachable receiver: " + err.Message)
+	}
+	if actualAttempt.StatusCode != 0 || actualAttempt.ErrorMessage == "" {
+		t.Errorf("Expected attempt without status code and with error message but got %v", actualAttempt)
+	}
+}
+
+func TestWebhookDispatcher_DispatchDue_returns_error_when_recordingAttempt_fails(t *testing.T) {
+	//Arrange
+	teardown := setupWebhookDispatcherTest(t)
+	defer teardown()
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer receiver.Close()
+
+	deliveries := []domain.WebhookDelivery{getDummyWebhookDelivery(receiver.URL), getDummyWebhookDelivery(receiver.URL)}
+	dummyAppErr := errs.NewUnexpectedError("some error message")
+	mockWebhookRepo.EXPECT().FindDueDeliveries(clock.StaticClock{}.NowAsString(), webhookBatchSize).Return(deliveries, nil)
+	mockWebhookRepo.EXPECT().SaveDeliveryAttempt(gomock.Any(), gomock.Any()).Return(dummyAppErr)
+
+	//Act
+	count, err := dispatcher.DispatchDue()
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing failure recording attempt")
+	}
+	if count != 0 {
+		t.Errorf("Expected 0 deliveries attempted but got %d", count)
+	}
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+)
+
+//go:generate mockgen -destination=../mocks/service/mock_webhookService.go -package=service github.com/aliciatay-zls/banking/backend/service WebhookService
+type WebhookService interface { //service (primary port)
+	CreateSubscription(dto.NewWebhookSubscriptionRequest) (*dto.WebhookSubscriptionResponse, *errs.AppError)
+	GetAllSubscriptions() ([]dto.WebhookSubscriptionResponse, *errs.AppError)
+	DeleteSubscription(string) *errs.AppError
+	GetDeliveries(string) ([]dto.WebhookDeliveryResponse, *errs.AppError)
+	GetDelivery(string) (*dto.WebhookDeliveryResponse, *errs.AppError)
+	Redeliver(string) (*dto.WebhookDeliveryResponse, *errs.AppError)
+}
+
+type DefaultWebhookService struct { //business/domain object
+	repo       domain.WebhookRepository
+	dispatcher WebhookDispatcher
+	clk        clock.Clock
+}
+
+func NewWebhookService(repo domain.WebhookRepository, dispatcher WebhookDispatcher, clk clock.Clock) DefaultWebhookService {
+	return DefaultWebhookService{repo, dispatcher, clk}
+}
+
+func (s DefaultWebhookService) CreateSubscription(request dto.NewWebhookSubscriptionRequest) (*dto.WebhookSubscriptionResponse, *errs.AppError) {
+	subscription, appErr := s.repo.SaveSubscription(domain.NewWebhookSubscription(request, s.clk))
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	response := subscription.ToDTO()
+	return &response, nil
+}
+
+func (s DefaultWebhookService) GetAllSubscriptions() ([]dto.WebhookSubscriptionResponse, *errs.AppError) {
+	subscriptions, appErr := s.repo.FindAllSubscriptions()
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	response := make([]dto.WebhookSubscriptionResponse, 0)
+	for _, subscription := range subscriptions {
+		response = append(response, subscription.ToDTO())
+	}
+	return response, nil
+}
+
+func (s DefaultWebhookService) DeleteSubscription(subscriptionId string) *errs.AppError {
+	return s.repo.DeleteSubscription(subscriptionId)
+}
+
+func (s DefaultWebhookService) GetDeliveries(subscriptionId string) ([]dto.WebhookDeliveryResponse, *errs.AppError) {
+	deliveries, appErr := s.repo.FindDeliveries(subscriptionId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	response := make([]dto.WebhookDeliveryResponse, 0)
+	for _, delivery := range deliveries {
+		response = append(response, delivery.ToDTO())
+	}
+	return response, nil
+}
+
+// GetDelivery returns the delivery with the given id together with all of its attempts.
+func (s DefaultWebhookService) GetDelivery(deliveryId string) (*dto.WebhookDeliveryResponse, *errs.AppError) {
+	delivery, appErr := s.repo.FindDeliveryById(deliveryId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return s.withAttempts(*delivery)
+}
+
+// Redeliver attempts the delivery with the given id again right away, whatever its status, e.g. after the receiver
+// has fixed a problem or lost a delivery that had succeeded. A failed delivery is not retried automatically again.
+// Redeliver returns the delivery together with all of its attempts, including the new one.
+func (s DefaultWebhookService) Redeliver(deliveryId string) (*dto.WebhookDeliveryResponse, *errs.AppError) {
+	delivery, appErr := s.repo.FindDeliveryById(deliveryId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	redelivered, appErr := s.dispatcher.Deliver(*delivery)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return s.withAttempts(*redelivered)
+}
+
+func (s DefaultWebhookService) withAttempts(delivery domain.WebhookDelivery) (*dto.WebhookDeliveryResponse, *errs.AppError) {
+	attempts, appErr := s.repo.FindDeliveryAttempts(delivery.DeliveryId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	response := delivery.ToDTO()
+	response.Attempts = make([]dto.WebhookDeliveryAttemptResponse, 0)
+	for _, attempt := range attempts {
+		response.Attempts = append(response.Attempts, attempt.ToDTO())
+	}
+	return &response, nil
+}
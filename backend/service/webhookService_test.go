@@ -0,0 +1,120 @@
+package service
+
+import (
+	"github.com/aliciatay-zls/banking-lib/clock"
+	"github.com/aliciatay-zls/banking-lib/errs"
+	"github.com/aliciatay-zls/banking/backend/domain"
+	"github.com/aliciatay-zls/banking/backend/dto"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Test common variables and inputs
+var webhookSvc DefaultWebhookService
+
+func setupWebhookServiceTest(t *testing.T) func() {
+	teardown := setupWebhookDispatcherTest(t)
+	webhookSvc = NewWebhookService(mockWebhookRepo, dispatcher, clock.StaticClock{})
+	return teardown
+}
+
+func TestDefaultWebhookService_CreateSubscription_returns_subscription_without_secret(t *testing.T) {
+	//Arrange
+	teardown := setupWebhookServiceTest(t)
+	defer teardown()
+
+	request := dto.NewWebhookSubscriptionRequest{
+		Url:        "https://example.com/hooks",
+		EventTypes: []string{domain.EventTypeAccountOpened, domain.EventTypeTransactionPosted},
+		Secret:     dummyWebhookSecret,
+	}
+	subscription := domain.NewWebhookSubscription(request, clock.StaticClock{})
+	savedSubscription := subscription
+	savedSubscription.SubscriptionId = "5"
+	mockWebhookRepo.EXPECT().SaveSubscription(subscription).Return(&savedSubscription, nil)
+
+	//Act
+	response, err := webhookSvc.CreateSubscription(request)
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing successful subscription: " + err.Message)
+	}
+	if response.SubscriptionId != "5" || len(response.EventTypes) != 2 {
+		t.Errorf("Expected subscription 5 to 2 event types but got %v", *response)
+	}
+}
+
+func TestDefaultWebhookService_GetDelivery_returns_delivery_with_attempts(t *testing.T) {
+	//Arrange
+	teardown := setupWebhookServiceTest(t)
+	defer teardown()
+
+	delivery := getDummyWebhookDelivery("https://example.com/hooks")
+	attempts := []domain.WebhookDeliveryAttempt{
+		{AttemptId: "1", DeliveryId: "8", AttemptedOn: clock.StaticClock{}.NowAsString(), StatusCode: 500},
+		{AttemptId: "2", DeliveryId: "8", AttemptedOn: clock.StaticClock{}.NowAsString(), ErrorMessage: "connection refused"},
+	}
+	mockWebhookRepo.EXPECT().FindDeliveryById("8").Return(&delivery, nil)
+	mockWebhookRepo.EXPECT().FindDeliveryAttempts("8").Return(attempts, nil)
+
+	//Act
+	response, err := webhookSvc.GetDelivery("8")
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing successful retrieval of delivery: " + err.Message)
+	}
+	if len(response.Attempts) != 2 || response.Attempts[0].StatusCode != 500 || response.Attempts[1].IsSuccessful {
+		t.Errorf("Expected 2 unsuccessful attempts but got %v", response.Attempts)
+	}
+}
+
+func TestDefaultWebhookService_Redeliver_returns_error_when_delivery_notFound(t *testing.T) {
+	//Arrange
+	teardown := setupWebhookServiceTest(t)
+	defer teardown()
+
+	dummyAppErr := errs.NewNotFoundError("Webhook delivery not found")
+	mockWebhookRepo.EXPECT().FindDeliveryById("8").Return(nil, dummyAppErr)
+
+	//Act
+	_, err := webhookSvc.Redeliver("8")
+
+	//Assert
+	if err == nil {
+		t.Fatal("Expected error but got none while testing redelivery of nonexistent delivery")
+	}
+	if err.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d but got %d", http.StatusNotFound, err.Code)
+	}
+}
+
+func TestDefaultWebhookService_Redeliver_attempts_failed_delivery_again(t *testing.T) {
+	//Arrange
+	teardown := setupWebhookServiceTest(t)
+	defer teardown()
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer receiver.Close()
+
+	delivery := getDummyWebhookDelivery(receiver.URL)
+	delivery.Status = dto.WebhookDeliveryStatusFailed
+	delivery.AttemptCount = domain.WebhookMaxAttempts
+	mockWebhookRepo.EXPECT().FindDeliveryById("8").Return(&delivery, nil)
+	mockWebhookRepo.EXPECT().SaveDeliveryAttempt(gomock.Any(), gomock.Any()).Return(nil)
+	mockWebhookRepo.EXPECT().FindDeliveryAttempts("8").Return([]domain.WebhookDeliveryAttempt{}, nil)
+
+	//Act
+	response, err := webhookSvc.Redeliver("8")
+
+	//Assert
+	if err != nil {
+		t.Fatal("Expected no error but got error while testing successful redelivery: " + err.Message)
+	}
+	if response.Status != dto.WebhookDeliveryStatusSucceeded || response.AttemptCount != domain.WebhookMaxAttempts+1 {
+		t.Errorf("Expected succeeded delivery after %d attempts but got %v", domain.WebhookMaxAttempts+1, *response)
+	}
+}